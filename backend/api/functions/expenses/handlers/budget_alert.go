@@ -0,0 +1,38 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"github.com/JoelD7/money/backend/models"
+	"github.com/JoelD7/money/backend/shared/notifier"
+	"github.com/JoelD7/money/backend/storage/budgetalert"
+	"github.com/JoelD7/money/backend/storage/exchangerate"
+	"github.com/JoelD7/money/backend/usecases"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+)
+
+// newBudgetAlertChecker returns nil when no budget alert destination is configured, which disables the alerts.
+func newBudgetAlertChecker(ctx context.Context, dynamoClient *dynamodb.Client, envConfig *models.EnvironmentConfiguration, em usecases.ExpenseManager,
+	um usecases.UserManager, pm usecases.PeriodManager) (usecases.BudgetAlertChecker, error) {
+	budgetAlertNotifier := notifier.New(envConfig)
+	if budgetAlertNotifier == nil {
+		return nil, nil
+	}
+
+	thresholds, err := usecases.ParseBudgetAlertThresholds(envConfig.BudgetAlertThresholds)
+	if err != nil {
+		return nil, fmt.Errorf("initialize budget alerts failed: %w", err)
+	}
+
+	budgetAlertRepo, err := budgetalert.NewRepository(ctx, dynamoClient, envConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	exchangeRateRepo, err := exchangerate.NewRepository(ctx, dynamoClient, envConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	return usecases.NewBudgetAlertChecker(em, um, exchangeRateRepo, pm, budgetAlertRepo, budgetAlertNotifier, thresholds), nil
+}
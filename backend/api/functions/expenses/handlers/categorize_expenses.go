@@ -0,0 +1,108 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"github.com/JoelD7/money/backend/models"
+	"github.com/JoelD7/money/backend/shared/apigateway"
+	"github.com/JoelD7/money/backend/shared/logger"
+	"github.com/JoelD7/money/backend/storage/dynamo"
+	"github.com/JoelD7/money/backend/storage/expenses"
+	"github.com/JoelD7/money/backend/storage/users"
+	"github.com/JoelD7/money/backend/usecases"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+var (
+	catExpensesRequest *CategorizeExpensesRequest
+	catExpensesOnce    sync.Once
+)
+
+type CategorizeExpensesRequest struct {
+	startingTime time.Time
+	err          error
+	ExpensesRepo expenses.Repository
+	UserRepo     users.Repository
+}
+
+type categorizedExpensesResponse struct {
+	Expenses []*models.Expense `json:"expenses"`
+}
+
+func (request *CategorizeExpensesRequest) init(ctx context.Context, envConfig *models.EnvironmentConfiguration) error {
+	var err error
+	catExpensesOnce.Do(func() {
+		dynamoClient := dynamo.InitClient(ctx)
+
+		request.ExpensesRepo, err = expenses.NewRepository(ctx, dynamoClient, envConfig)
+		if err != nil {
+			return
+		}
+
+		request.UserRepo, err = users.NewRepository(ctx, dynamoClient, envConfig)
+		if err != nil {
+			return
+		}
+	})
+	request.startingTime = time.Now()
+
+	return err
+}
+
+func (request *CategorizeExpensesRequest) finish() {
+	logger.LogLambdaTime(request.startingTime, request.err, recover())
+}
+
+// CategorizeExpenses assigns categories to the expenses of a period based on the keywords of the user's categories.
+// Only uncategorized expenses are evaluated unless the "overwrite" query parameter is set to true.
+func CategorizeExpenses(ctx context.Context, envConfig *models.EnvironmentConfiguration, req *apigateway.Request) (*apigateway.Response, error) {
+	if catExpensesRequest == nil {
+		catExpensesRequest = new(CategorizeExpensesRequest)
+	}
+
+	err := catExpensesRequest.init(ctx, envConfig)
+	if err != nil {
+		logger.Error("categorize_expenses_init_failed", err, req)
+
+		return req.NewErrorResponse(err), nil
+	}
+
+	defer catExpensesRequest.finish()
+
+	return catExpensesRequest.Process(ctx, req)
+}
+
+func (request *CategorizeExpensesRequest) Process(ctx context.Context, req *apigateway.Request) (*apigateway.Response, error) {
+	periodID, ok := req.PathParameters["periodID"]
+	if !ok || periodID == "" {
+		request.err = models.ErrMissingPeriodID
+		logger.Error("missing_period_id", fmt.Errorf("period ID not in path parameters"), req)
+
+		return req.NewErrorResponse(models.ErrMissingPeriodID), nil
+	}
+
+	username, err := apigateway.GetUsernameFromContext(req)
+	if err != nil {
+		request.err = err
+		logger.Error("get_username_from_context_failed", err, req)
+
+		return req.NewErrorResponse(err), nil
+	}
+
+	overwrite := strings.EqualFold(req.QueryStringParameters["overwrite"], "true")
+
+	categorizeExpenses := usecases.NewExpensesCategorizer(request.ExpensesRepo, request.UserRepo)
+
+	categorizedExpenses, err := categorizeExpenses(ctx, username, periodID, overwrite)
+	if err != nil {
+		request.err = err
+		logger.Error("categorize_expenses_failed", err, req)
+
+		return req.NewErrorResponse(err), nil
+	}
+
+	return req.NewJSONResponse(http.StatusOK, &categorizedExpensesResponse{categorizedExpenses}), nil
+}
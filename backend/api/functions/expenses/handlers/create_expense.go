@@ -31,6 +31,7 @@ type createExpenseRequest struct {
 	userRepo         users.Repository
 	periodRepo       period.Repository
 	idempotenceCache cache.IdempotenceCacheManager
+	checkBudget      usecases.BudgetAlertChecker
 }
 
 func (request *createExpenseRequest) init(ctx context.Context, envConfig *models.EnvironmentConfiguration) error {
@@ -38,17 +39,27 @@ func (request *createExpenseRequest) init(ctx context.Context, envConfig *models
 	ceOnce.Do(func() {
 		dynamoClient := dynamo.InitClient(ctx)
 
-		request.expensesRepo, err = expenses.NewDynamoRepository(dynamoClient, envConfig)
+		request.expensesRepo, err = expenses.NewRepository(ctx, dynamoClient, envConfig)
 		if err != nil {
 			return
 		}
 
-		request.periodRepo, err = period.NewDynamoRepository(dynamoClient, envConfig)
+		request.periodRepo, err = period.NewRepository(ctx, dynamoClient, envConfig)
+		if err != nil {
+			return
+		}
+
+		request.userRepo, err = users.NewRepository(ctx, dynamoClient, envConfig)
 		if err != nil {
 			return
 		}
 
 		request.idempotenceCache = cache.NewRedisCache()
+
+		request.checkBudget, err = newBudgetAlertChecker(ctx, dynamoClient, envConfig, request.expensesRepo, request.userRepo, request.periodRepo)
+		if err != nil {
+			return
+		}
 	})
 	request.startingTime = time.Now()
 
@@ -98,7 +109,8 @@ func (request *createExpenseRequest) process(ctx context.Context, req *apigatewa
 		return req.NewErrorResponse(err), nil
 	}
 
-	createExpense := usecases.NewExpenseCreator(request.expensesRepo, request.periodRepo, request.idempotenceCache)
+	createExpense := usecases.NewExpenseCreator(request.expensesRepo, request.periodRepo, request.userRepo, request.idempotenceCache,
+		request.checkBudget)
 
 	newExpense, err := createExpense(ctx, username, idempotencyKey, expense)
 	if err != nil {
@@ -125,30 +137,10 @@ func validateInput(req *apigateway.Request, username string) (*models.Expense, e
 
 	expense.Username = username
 
-	if expense.Name == nil {
-		return nil, models.ErrMissingName
-	}
-
-	if expense.Amount == nil {
-		return nil, models.ErrMissingAmount
-	}
-
-	err = validate.Amount(expense.Amount)
+	err = validate.Expense(expense)
 	if err != nil {
 		return nil, err
 	}
 
-	if expense.PeriodID == "" {
-		return nil, models.ErrMissingPeriod
-	}
-
-	if expense.IsRecurring && expense.RecurringDay == nil {
-		return nil, models.ErrMissingRecurringDay
-	}
-
-	if (expense.IsRecurring && expense.RecurringDay != nil) && (*expense.RecurringDay < 1 || *expense.RecurringDay > 31) {
-		return nil, models.ErrInvalidRecurringDay
-	}
-
 	return expense, nil
 }
@@ -0,0 +1,155 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/JoelD7/money/backend/models"
+	"github.com/JoelD7/money/backend/shared/apigateway"
+	"github.com/JoelD7/money/backend/shared/logger"
+	"github.com/JoelD7/money/backend/shared/validate"
+	"github.com/JoelD7/money/backend/storage/cache"
+	"github.com/JoelD7/money/backend/storage/dynamo"
+	expensesRecurring "github.com/JoelD7/money/backend/storage/expenses-recurring"
+	"github.com/JoelD7/money/backend/storage/users"
+	"github.com/JoelD7/money/backend/usecases"
+	"net/http"
+	"sync"
+	"time"
+)
+
+var (
+	cerRequest *CreateExpenseRecurringRequest
+	cerOnce    sync.Once
+)
+
+type CreateExpenseRecurringRequest struct {
+	startingTime     time.Time
+	err              error
+	Repo             expensesRecurring.Repository
+	UserRepo         users.Repository
+	IdempotenceCache cache.IdempotenceCacheManager
+}
+
+func (request *CreateExpenseRecurringRequest) init(ctx context.Context, envConfig *models.EnvironmentConfiguration) error {
+	var err error
+
+	cerOnce.Do(func() {
+		dynamoClient := dynamo.InitClient(ctx)
+		request.Repo, err = expensesRecurring.NewRepository(ctx, dynamoClient, envConfig)
+		if err != nil {
+			return
+		}
+
+		request.UserRepo, err = users.NewRepository(ctx, dynamoClient, envConfig)
+		if err != nil {
+			return
+		}
+
+		request.IdempotenceCache = cache.NewRedisCache()
+	})
+
+	request.startingTime = time.Now()
+
+	return err
+}
+
+func (request *CreateExpenseRecurringRequest) finish() {
+	logger.LogLambdaTime(request.startingTime, request.err, recover())
+}
+
+func CreateExpenseRecurring(ctx context.Context, envConfig *models.EnvironmentConfiguration, req *apigateway.Request) (*apigateway.Response, error) {
+	if cerRequest == nil {
+		cerRequest = new(CreateExpenseRecurringRequest)
+	}
+
+	err := cerRequest.init(ctx, envConfig)
+	if err != nil {
+		logger.Error("create_expense_recurring_init_failed", err, req)
+		return req.NewErrorResponse(err), nil
+	}
+	defer cerRequest.finish()
+
+	return cerRequest.Process(ctx, req)
+}
+
+func (request *CreateExpenseRecurringRequest) Process(ctx context.Context, req *apigateway.Request) (*apigateway.Response, error) {
+	idempotencyKey, err := req.GetIdempotenceyKeyFromHeader()
+	if err != nil {
+		request.err = err
+		logger.Error("http_request_validation_failed", err, req)
+
+		return req.NewErrorResponse(err), nil
+	}
+
+	username, err := apigateway.GetUsernameFromContext(req)
+	if err != nil {
+		request.err = err
+		logger.Error("get_username_from_context_failed", err, req)
+
+		return req.NewErrorResponse(err), nil
+	}
+
+	expenseRecurring, err := validateCreateExpenseRecurringInput(req)
+	if err != nil {
+		request.err = err
+		logger.Error("validate_input_failed", err, req)
+
+		return req.NewErrorResponse(err), nil
+	}
+
+	createExpenseRecurring := usecases.NewExpenseRecurringCreator(request.Repo, request.UserRepo, request.IdempotenceCache)
+
+	newExpenseRecurring, err := createExpenseRecurring(ctx, username, idempotencyKey, expenseRecurring)
+	if err != nil {
+		request.err = err
+		logger.Error("create_expense_recurring_failed", err, req)
+
+		return req.NewErrorResponse(err), nil
+	}
+
+	return req.NewJSONResponse(http.StatusCreated, newExpenseRecurring), nil
+}
+
+func validateCreateExpenseRecurringInput(req *apigateway.Request) (*models.ExpenseRecurring, error) {
+	expenseRecurring := new(models.ExpenseRecurring)
+
+	err := json.Unmarshal([]byte(req.Body), expenseRecurring)
+	if err != nil {
+		return nil, fmt.Errorf("%v: %w", err, models.ErrInvalidRequestBody)
+	}
+
+	if expenseRecurring.Name == "" {
+		return nil, models.ErrMissingName
+	}
+
+	return expenseRecurring, validateExpenseRecurringValues(expenseRecurring)
+}
+
+// validateExpenseRecurringValues validates the attributes of a recurring expense that can be set on creation and
+// updated later.
+func validateExpenseRecurringValues(expenseRecurring *models.ExpenseRecurring) error {
+	if expenseRecurring.Amount == 0 {
+		return models.ErrMissingAmount
+	}
+
+	err := validate.Amount(&expenseRecurring.Amount)
+	if err != nil {
+		return err
+	}
+
+	err = validate.Currency(expenseRecurring.Currency)
+	if err != nil {
+		return err
+	}
+
+	if expenseRecurring.RecurringDay == 0 {
+		return models.ErrMissingRecurringDay
+	}
+
+	if expenseRecurring.RecurringDay < 1 || expenseRecurring.RecurringDay > 31 {
+		return models.ErrInvalidRecurringDay
+	}
+
+	return nil
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/JoelD7/money/backend/shared/validate"
 	"github.com/JoelD7/money/backend/storage/dynamo"
 	"github.com/JoelD7/money/backend/storage/expenses"
+	"github.com/JoelD7/money/backend/storage/trash"
 	"github.com/JoelD7/money/backend/usecases"
 	"net/http"
 	"sync"
@@ -21,6 +22,7 @@ type deleteExpenseRequest struct {
 	startingTime time.Time
 	err          error
 	expensesRepo expenses.Repository
+	trashRepo    trash.Repository
 }
 
 func (request *deleteExpenseRequest) init(ctx context.Context, envConfig *models.EnvironmentConfiguration) error {
@@ -29,7 +31,12 @@ func (request *deleteExpenseRequest) init(ctx context.Context, envConfig *models
 	deOnce.Do(func() {
 		dynamoClient := dynamo.InitClient(ctx)
 
-		request.expensesRepo, err = expenses.NewDynamoRepository(dynamoClient, envConfig)
+		request.expensesRepo, err = expenses.NewRepository(ctx, dynamoClient, envConfig)
+		if err != nil {
+			return
+		}
+
+		request.trashRepo, err = trash.NewRepository(ctx, dynamoClient, envConfig)
 	})
 
 	request.startingTime = time.Now()
@@ -78,9 +85,16 @@ func (request *deleteExpenseRequest) process(ctx context.Context, req *apigatewa
 		return req.NewErrorResponse(err), nil
 	}
 
-	deleteExpense := usecases.NewExpensesDeleter(request.expensesRepo)
+	version, err := req.GetIfMatchVersion()
+	if err != nil {
+		logger.Error("invalid_if_match", err, req)
+
+		return req.NewErrorResponse(err), nil
+	}
+
+	deleteExpense := usecases.NewExpensesDeleter(request.expensesRepo, request.trashRepo)
 
-	err = deleteExpense(ctx, expenseID, username)
+	err = deleteExpense(ctx, expenseID, username, version)
 	if err != nil {
 		logger.Error("delete_expense_failed", err, req)
 
@@ -29,7 +29,7 @@ func (request *DeleteExpenseRecurringRequest) init(ctx context.Context, envConfi
 
 	derOnce.Do(func() {
 		dynamoClient := dynamo.InitClient(ctx)
-		request.Repo, err = expensesRecurring.NewExpenseRecurringDynamoRepository(dynamoClient, envConfig.ExpensesRecurringTable)
+		request.Repo, err = expensesRecurring.NewRepository(ctx, dynamoClient, envConfig)
 	})
 
 	request.startingTime = time.Now()
@@ -4,6 +4,7 @@ import (
 	"context"
 	"github.com/JoelD7/money/backend/shared/apigateway"
 	"github.com/JoelD7/money/backend/storage/expenses"
+	"github.com/JoelD7/money/backend/storage/trash"
 	"github.com/aws/aws-lambda-go/events"
 	"github.com/stretchr/testify/require"
 	"net/http"
@@ -19,6 +20,7 @@ func TestDeleteHandlerSuccess(t *testing.T) {
 
 	request := &deleteExpenseRequest{
 		expensesRepo: expensesMock,
+		trashRepo:    trash.NewMock(),
 	}
 
 	apiRequest := getDeleteExpenseRequest()
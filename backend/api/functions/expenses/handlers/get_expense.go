@@ -36,17 +36,17 @@ func (request *getExpenseRequest) init(ctx context.Context, envConfig *models.En
 	getExpenseOnce.Do(func() {
 		dynamoClient := dynamo.InitClient(ctx)
 
-		request.expensesRepo, err = expenses.NewDynamoRepository(dynamoClient, envConfig)
+		request.expensesRepo, err = expenses.NewRepository(ctx, dynamoClient, envConfig)
 		if err != nil {
 			return
 		}
 
-		request.userRepo, err = users.NewDynamoRepository(dynamoClient, envConfig.UsersTable)
+		request.userRepo, err = users.NewRepository(ctx, dynamoClient, envConfig)
 		if err != nil {
 			return
 		}
 
-		request.periodRepo, err = period.NewDynamoRepository(dynamoClient, envConfig)
+		request.periodRepo, err = period.NewRepository(ctx, dynamoClient, envConfig)
 		if err != nil {
 			return
 		}
@@ -110,5 +110,5 @@ func (request *getExpenseRequest) process(ctx context.Context, req *apigateway.R
 		return req.NewErrorResponse(err), nil
 	}
 
-	return req.NewJSONResponse(http.StatusOK, expense), nil
+	return req.NewJSONResponse(http.StatusOK, expense, apigateway.NewETagHeader(expense.Version)), nil
 }
@@ -0,0 +1,101 @@
+package handlers
+
+import (
+	"context"
+	"github.com/JoelD7/money/backend/models"
+	"github.com/JoelD7/money/backend/shared/apigateway"
+	"github.com/JoelD7/money/backend/shared/logger"
+	"github.com/JoelD7/money/backend/shared/validate"
+	"github.com/JoelD7/money/backend/storage/audit"
+	"github.com/JoelD7/money/backend/storage/dynamo"
+	"github.com/JoelD7/money/backend/usecases"
+	"net/http"
+	"sync"
+	"time"
+)
+
+var (
+	gehRequest *getExpenseHistoryRequest
+	gehOnce    sync.Once
+)
+
+type getExpenseHistoryRequest struct {
+	startingTime time.Time
+	err          error
+	auditRepo    audit.Repository
+}
+
+type historyResponse struct {
+	Entries []*models.AuditEntry `json:"entries"`
+	NextKey string               `json:"next_key,omitempty"`
+}
+
+func (request *getExpenseHistoryRequest) init(ctx context.Context, envConfig *models.EnvironmentConfiguration) error {
+	var err error
+	gehOnce.Do(func() {
+		dynamoClient := dynamo.InitClient(ctx)
+
+		request.auditRepo, err = audit.NewRepository(ctx, dynamoClient, envConfig)
+		if err != nil {
+			return
+		}
+	})
+	request.startingTime = time.Now()
+
+	return err
+}
+
+func (request *getExpenseHistoryRequest) finish() {
+	logger.LogLambdaTime(request.startingTime, request.err, recover())
+}
+
+func GetExpenseHistory(ctx context.Context, envConfig *models.EnvironmentConfiguration, req *apigateway.Request) (*apigateway.Response, error) {
+	if gehRequest == nil {
+		gehRequest = new(getExpenseHistoryRequest)
+	}
+
+	err := gehRequest.init(ctx, envConfig)
+	if err != nil {
+		logger.Error("get_expense_history_init_failed", err, req)
+
+		return req.NewErrorResponse(err), nil
+	}
+	defer gehRequest.finish()
+
+	return gehRequest.process(ctx, req)
+}
+
+func (request *getExpenseHistoryRequest) process(ctx context.Context, req *apigateway.Request) (*apigateway.Response, error) {
+	username, err := apigateway.GetUsernameFromContext(req)
+	if err != nil {
+		logger.Error("get_username_from_context_failed", err, req)
+
+		return req.NewErrorResponse(err), nil
+	}
+
+	params, err := req.GetQueryParameters()
+	if err != nil {
+		logger.Error("get_query_parameters_failed", err, req)
+
+		return req.NewErrorResponse(err), nil
+	}
+
+	err = validate.PageSize(params.PageSize)
+	if err != nil {
+		logger.Error("invalid_page_size", err, req)
+
+		return req.NewErrorResponse(err), nil
+	}
+
+	getEntityHistory := usecases.NewEntityHistoryGetter(request.auditRepo)
+
+	entries, nextKey, err := getEntityHistory(ctx, username, models.AuditEntityExpense, req.PathParameters["expenseID"], params)
+	if err != nil {
+		request.err = err
+		logger.Error("get_expense_history_failed", err, req)
+
+		return req.NewErrorResponse(err), nil
+	}
+
+	return req.NewJSONResponse(http.StatusOK, &historyResponse{entries, nextKey}), nil
+}
@@ -0,0 +1,87 @@
+package handlers
+
+import (
+	"context"
+	"github.com/JoelD7/money/backend/models"
+	"github.com/JoelD7/money/backend/shared/apigateway"
+	"github.com/JoelD7/money/backend/shared/logger"
+	"github.com/JoelD7/money/backend/storage/dynamo"
+	expensesRecurring "github.com/JoelD7/money/backend/storage/expenses-recurring"
+	"github.com/JoelD7/money/backend/usecases"
+	"net/http"
+	"sync"
+	"time"
+)
+
+var (
+	gerRequest *GetExpenseRecurringRequest
+	gerOnce    sync.Once
+)
+
+type GetExpenseRecurringRequest struct {
+	startingTime time.Time
+	err          error
+	Repo         expensesRecurring.Repository
+}
+
+func (request *GetExpenseRecurringRequest) init(ctx context.Context, envConfig *models.EnvironmentConfiguration) error {
+	var err error
+
+	gerOnce.Do(func() {
+		dynamoClient := dynamo.InitClient(ctx)
+		request.Repo, err = expensesRecurring.NewRepository(ctx, dynamoClient, envConfig)
+	})
+
+	request.startingTime = time.Now()
+
+	return err
+}
+
+func (request *GetExpenseRecurringRequest) finish() {
+	logger.LogLambdaTime(request.startingTime, request.err, recover())
+}
+
+func GetExpenseRecurring(ctx context.Context, envConfig *models.EnvironmentConfiguration, req *apigateway.Request) (*apigateway.Response, error) {
+	if gerRequest == nil {
+		gerRequest = new(GetExpenseRecurringRequest)
+	}
+
+	err := gerRequest.init(ctx, envConfig)
+	if err != nil {
+		logger.Error("get_expense_recurring_init_failed", err, req)
+		return req.NewErrorResponse(err), nil
+	}
+	defer gerRequest.finish()
+
+	return gerRequest.Process(ctx, req)
+}
+
+func (request *GetExpenseRecurringRequest) Process(ctx context.Context, req *apigateway.Request) (*apigateway.Response, error) {
+	expenseRecurringID, ok := req.PathParameters["expenseRecurringID"]
+	if !ok || expenseRecurringID == "" {
+		request.err = models.ErrMissingExpenseRecurringID
+		logger.Error("missing_expense_recurring_id", nil, req)
+
+		return req.NewErrorResponse(models.ErrMissingExpenseRecurringID), nil
+	}
+
+	username, err := apigateway.GetUsernameFromContext(req)
+	if err != nil {
+		request.err = err
+		logger.Error("get_username_from_context_failed", err, req)
+
+		return req.NewErrorResponse(err), nil
+	}
+
+	getExpenseRecurring := usecases.NewExpenseRecurringGetter(request.Repo)
+
+	expenseRecurring, err := getExpenseRecurring(ctx, expenseRecurringID, username)
+	if err != nil {
+		request.err = err
+		logger.Error("get_expense_recurring_failed", err, req)
+
+		return req.NewErrorResponse(err), nil
+	}
+
+	return req.NewJSONResponse(http.StatusOK, expenseRecurring), nil
+}
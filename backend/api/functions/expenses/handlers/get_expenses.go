@@ -41,17 +41,17 @@ func (request *GetExpensesRequest) init(ctx context.Context, envConfig *models.E
 	gesOnce.Do(func() {
 		dynamoClient := dynamo.InitClient(ctx)
 
-		request.ExpensesRepo, err = expenses.NewDynamoRepository(dynamoClient, envConfig)
+		request.ExpensesRepo, err = expenses.NewRepository(ctx, dynamoClient, envConfig)
 		if err != nil {
 			return
 		}
 
-		request.UserRepo, err = users.NewDynamoRepository(dynamoClient, envConfig.UsersTable)
+		request.UserRepo, err = users.NewRepository(ctx, dynamoClient, envConfig)
 		if err != nil {
 			return
 		}
 
-		request.PeriodRepo, err = period.NewDynamoRepository(dynamoClient, envConfig)
+		request.PeriodRepo, err = period.NewRepository(ctx, dynamoClient, envConfig)
 		if err != nil {
 			return
 		}
@@ -0,0 +1,101 @@
+package handlers
+
+import (
+	"context"
+	"github.com/JoelD7/money/backend/models"
+	"github.com/JoelD7/money/backend/shared/apigateway"
+	"github.com/JoelD7/money/backend/shared/logger"
+	"github.com/JoelD7/money/backend/shared/validate"
+	"github.com/JoelD7/money/backend/storage/dynamo"
+	expensesRecurring "github.com/JoelD7/money/backend/storage/expenses-recurring"
+	"github.com/JoelD7/money/backend/usecases"
+	"net/http"
+	"sync"
+	"time"
+)
+
+var (
+	gersRequest *GetExpensesRecurringRequest
+	gersOnce    sync.Once
+)
+
+type GetExpensesRecurringRequest struct {
+	startingTime time.Time
+	err          error
+	Repo         expensesRecurring.Repository
+}
+
+type expensesRecurringResponse struct {
+	ExpensesRecurring []*models.ExpenseRecurring `json:"expenses_recurring"`
+	NextKey           string                     `json:"next_key,omitempty"`
+}
+
+func (request *GetExpensesRecurringRequest) init(ctx context.Context, envConfig *models.EnvironmentConfiguration) error {
+	var err error
+
+	gersOnce.Do(func() {
+		dynamoClient := dynamo.InitClient(ctx)
+		request.Repo, err = expensesRecurring.NewRepository(ctx, dynamoClient, envConfig)
+	})
+
+	request.startingTime = time.Now()
+
+	return err
+}
+
+func (request *GetExpensesRecurringRequest) finish() {
+	logger.LogLambdaTime(request.startingTime, request.err, recover())
+}
+
+func GetExpensesRecurring(ctx context.Context, envConfig *models.EnvironmentConfiguration, req *apigateway.Request) (*apigateway.Response, error) {
+	if gersRequest == nil {
+		gersRequest = new(GetExpensesRecurringRequest)
+	}
+
+	err := gersRequest.init(ctx, envConfig)
+	if err != nil {
+		logger.Error("get_expenses_recurring_init_failed", err, req)
+		return req.NewErrorResponse(err), nil
+	}
+	defer gersRequest.finish()
+
+	return gersRequest.Process(ctx, req)
+}
+
+func (request *GetExpensesRecurringRequest) Process(ctx context.Context, req *apigateway.Request) (*apigateway.Response, error) {
+	username, err := apigateway.GetUsernameFromContext(req)
+	if err != nil {
+		request.err = err
+		logger.Error("get_username_from_context_failed", err, req)
+
+		return req.NewErrorResponse(err), nil
+	}
+
+	params, err := req.GetQueryParameters()
+	if err != nil {
+		request.err = err
+		logger.Error("get_request_params_failed", err, req)
+
+		return req.NewErrorResponse(err), nil
+	}
+
+	err = validate.PageSize(params.PageSize)
+	if err != nil {
+		request.err = err
+		logger.Error("invalid_page_size", err, req)
+
+		return req.NewErrorResponse(err), nil
+	}
+
+	getExpensesRecurring := usecases.NewExpensesRecurringGetter(request.Repo)
+
+	userExpensesRecurring, nextKey, err := getExpensesRecurring(ctx, username, params)
+	if err != nil {
+		request.err = err
+		logger.Error("get_expenses_recurring_failed", err, req)
+
+		return req.NewErrorResponse(err), nil
+	}
+
+	return req.NewJSONResponse(http.StatusOK, &expensesRecurringResponse{userExpensesRecurring, nextKey}), nil
+}
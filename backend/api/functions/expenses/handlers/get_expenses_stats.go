@@ -7,7 +7,9 @@ import (
 	"github.com/JoelD7/money/backend/shared/apigateway"
 	"github.com/JoelD7/money/backend/shared/logger"
 	"github.com/JoelD7/money/backend/storage/dynamo"
+	"github.com/JoelD7/money/backend/storage/exchangerate"
 	"github.com/JoelD7/money/backend/storage/expenses"
+	"github.com/JoelD7/money/backend/storage/period"
 	"github.com/JoelD7/money/backend/storage/users"
 	"github.com/JoelD7/money/backend/usecases"
 	"net/http"
@@ -21,10 +23,14 @@ var (
 )
 
 type GetExpensesStatsRequest struct {
-	startingTime time.Time
-	err          error
-	ExpensesRepo expenses.Repository
-	UserRepo     users.Repository
+	startingTime     time.Time
+	err              error
+	ExpensesRepo     expenses.Repository
+	UserRepo         users.Repository
+	ExchangeRateRepo exchangerate.Repository
+	PeriodRepo       period.Repository
+	// NearLimitPercentage is the percentage of a category budget from which its spending is considered near the limit.
+	NearLimitPercentage int
 }
 
 func (request *GetExpensesStatsRequest) init(ctx context.Context, envConfig *models.EnvironmentConfiguration) error {
@@ -32,10 +38,27 @@ func (request *GetExpensesStatsRequest) init(ctx context.Context, envConfig *mod
 	gestExpenseOnce.Do(func() {
 		dynamoClient := dynamo.InitClient(ctx)
 
-		request.ExpensesRepo, err = expenses.NewDynamoRepository(dynamoClient, envConfig)
+		request.ExpensesRepo, err = expenses.NewRepository(ctx, dynamoClient, envConfig)
 		if err != nil {
 			return
 		}
+
+		request.UserRepo, err = users.NewRepository(ctx, dynamoClient, envConfig)
+		if err != nil {
+			return
+		}
+
+		request.ExchangeRateRepo, err = exchangerate.NewRepository(ctx, dynamoClient, envConfig)
+		if err != nil {
+			return
+		}
+
+		request.PeriodRepo, err = period.NewRepository(ctx, dynamoClient, envConfig)
+		if err != nil {
+			return
+		}
+
+		request.NearLimitPercentage = envConfig.BudgetNearLimitPercentage
 	})
 	request.startingTime = time.Now()
 
@@ -78,7 +101,7 @@ func (request *GetExpensesStatsRequest) Process(ctx context.Context, req *apigat
 		return req.NewErrorResponse(err), nil
 	}
 
-	getCategoryExpensesSummary := usecases.NewCategoryExpenseSummaryGetter(request.ExpensesRepo)
+	getCategoryExpensesSummary := usecases.NewCategoryExpenseSummaryGetter(request.ExpensesRepo, request.UserRepo, request.ExchangeRateRepo, request.PeriodRepo, request.NearLimitPercentage)
 	categoryExpenseSummary, err := getCategoryExpensesSummary(ctx, username, periodID)
 	if err != nil {
 		logger.Error("get_expenses_stats_failed", err, req)
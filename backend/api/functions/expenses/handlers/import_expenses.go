@@ -0,0 +1,126 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/JoelD7/money/backend/models"
+	"github.com/JoelD7/money/backend/shared/apigateway"
+	"github.com/JoelD7/money/backend/shared/logger"
+	"github.com/JoelD7/money/backend/shared/statement"
+	"github.com/JoelD7/money/backend/storage/audit"
+	"github.com/JoelD7/money/backend/storage/dynamo"
+	"github.com/JoelD7/money/backend/storage/expenses"
+	"github.com/JoelD7/money/backend/storage/period"
+	"github.com/JoelD7/money/backend/storage/users"
+	"github.com/JoelD7/money/backend/usecases"
+	"net/http"
+	"sync"
+	"time"
+)
+
+var (
+	ieRequest *importExpensesRequest
+	ieOnce    sync.Once
+)
+
+type importExpensesRequest struct {
+	startingTime time.Time
+	err          error
+	expensesRepo expenses.Repository
+	periodRepo   period.Repository
+	userRepo     users.Repository
+}
+
+func (request *importExpensesRequest) init(ctx context.Context, envConfig *models.EnvironmentConfiguration) error {
+	var err error
+	ieOnce.Do(func() {
+		dynamoClient := dynamo.InitClient(ctx)
+
+		request.expensesRepo, err = expenses.NewRepository(ctx, dynamoClient, envConfig)
+		if err != nil {
+			return
+		}
+
+		request.periodRepo, err = period.NewRepository(ctx, dynamoClient, envConfig)
+		if err != nil {
+			return
+		}
+
+		request.userRepo, err = users.NewRepository(ctx, dynamoClient, envConfig)
+		if err != nil {
+			return
+		}
+	})
+	request.startingTime = time.Now()
+
+	return err
+}
+
+func (request *importExpensesRequest) finish() {
+	logger.LogLambdaTime(request.startingTime, request.err, recover())
+}
+
+// ImportExpenses creates expenses from the debits of a CSV or OFX bank statement.
+func ImportExpenses(ctx context.Context, envConfig *models.EnvironmentConfiguration, req *apigateway.Request) (*apigateway.Response, error) {
+	if ieRequest == nil {
+		ieRequest = new(importExpensesRequest)
+	}
+
+	err := ieRequest.init(ctx, envConfig)
+	if err != nil {
+		logger.Error("import_expenses_init_failed", err, req)
+
+		return req.NewErrorResponse(err), nil
+	}
+
+	defer ieRequest.finish()
+
+	return ieRequest.process(ctx, req)
+}
+
+func (request *importExpensesRequest) process(ctx context.Context, req *apigateway.Request) (*apigateway.Response, error) {
+	username, err := apigateway.GetUsernameFromContext(req)
+	if err != nil {
+		request.err = err
+		logger.Error("get_username_from_context_failed", err, req)
+
+		return req.NewErrorResponse(err), nil
+	}
+
+	importRequest := new(statement.ImportRequest)
+
+	err = json.Unmarshal([]byte(req.Body), importRequest)
+	if err != nil {
+		request.err = err
+		logger.Error("validate_input_failed", err, req)
+
+		return req.NewErrorResponse(fmt.Errorf("%v: %w", err, models.ErrInvalidRequestBody)), nil
+	}
+
+	transactions, err := importRequest.Transactions()
+	if err != nil {
+		request.err = err
+		logger.Error("parse_statement_failed", err, req)
+
+		return req.NewErrorResponse(err), nil
+	}
+
+	importExpenses := usecases.NewExpensesImporter(request.expensesRepo, request.periodRepo, request.userRepo)
+
+	ctx = audit.WithSource(ctx, models.AuditSourceImport, "")
+
+	result, err := importExpenses(ctx, username, transactions, importRequest.DryRun)
+	if err != nil {
+		request.err = err
+		logger.Error("import_expenses_failed", err, req)
+
+		return req.NewErrorResponse(err), nil
+	}
+
+	if importRequest.DryRun {
+		return req.NewJSONResponse(http.StatusOK, result), nil
+	}
+
+	return req.NewJSONResponse(http.StatusCreated, result), nil
+}
@@ -0,0 +1,114 @@
+package handlers
+
+import (
+	"context"
+	"github.com/JoelD7/money/backend/models"
+	"github.com/JoelD7/money/backend/shared/apigateway"
+	"github.com/JoelD7/money/backend/shared/logger"
+	"github.com/JoelD7/money/backend/storage/dynamo"
+	"github.com/JoelD7/money/backend/storage/expenses"
+	"github.com/JoelD7/money/backend/storage/period"
+	"github.com/JoelD7/money/backend/storage/users"
+	"github.com/JoelD7/money/backend/usecases"
+	"net/http"
+	"sync"
+	"time"
+)
+
+var peRequest *patchExpenseRequest
+var peOnce sync.Once
+
+type patchExpenseRequest struct {
+	startingTime time.Time
+	err          error
+	expensesRepo expenses.Repository
+	userRepo     users.Repository
+	periodRepo   period.Repository
+	checkBudget  usecases.BudgetAlertChecker
+}
+
+func (request *patchExpenseRequest) init(ctx context.Context, envConfig *models.EnvironmentConfiguration) error {
+	var err error
+	peOnce.Do(func() {
+		dynamoClient := dynamo.InitClient(ctx)
+
+		request.expensesRepo, err = expenses.NewRepository(ctx, dynamoClient, envConfig)
+		if err != nil {
+			return
+		}
+
+		request.periodRepo, err = period.NewRepository(ctx, dynamoClient, envConfig)
+		if err != nil {
+			return
+		}
+
+		request.userRepo, err = users.NewRepository(ctx, dynamoClient, envConfig)
+		if err != nil {
+			return
+		}
+
+		request.checkBudget, err = newBudgetAlertChecker(ctx, dynamoClient, envConfig, request.expensesRepo, request.userRepo, request.periodRepo)
+		if err != nil {
+			return
+		}
+	})
+	request.startingTime = time.Now()
+
+	return err
+}
+
+func (request *patchExpenseRequest) finish() {
+	logger.LogLambdaTime(request.startingTime, request.err, recover())
+}
+
+// PatchExpense applies the JSON merge patch of the request body to an expense.
+func PatchExpense(ctx context.Context, envConfig *models.EnvironmentConfiguration, req *apigateway.Request) (*apigateway.Response, error) {
+	if peRequest == nil {
+		peRequest = new(patchExpenseRequest)
+	}
+
+	err := peRequest.init(ctx, envConfig)
+	if err != nil {
+		logger.Error("patch_expense_init_failed", err, req)
+
+		return req.NewErrorResponse(err), nil
+	}
+	defer peRequest.finish()
+
+	return peRequest.process(ctx, req)
+}
+
+func (request *patchExpenseRequest) process(ctx context.Context, req *apigateway.Request) (*apigateway.Response, error) {
+	expenseID, ok := req.PathParameters["expenseID"]
+	if !ok || expenseID == "" {
+		logger.Error("missing_expense_id", nil, req)
+
+		return req.NewErrorResponse(models.ErrMissingExpenseID), nil
+	}
+
+	username, err := apigateway.GetUsernameFromContext(req)
+	if err != nil {
+		logger.Error("get_username_from_context_failed", err, req)
+
+		return req.NewErrorResponse(err), nil
+	}
+
+	version, err := req.GetIfMatchVersion()
+	if err != nil {
+		logger.Error("invalid_if_match", err, req)
+
+		return req.NewErrorResponse(err), nil
+	}
+
+	patchExpense := usecases.NewExpensePatcher(request.expensesRepo, request.periodRepo, request.userRepo, request.checkBudget)
+
+	patchedExpense, err := patchExpense(ctx, expenseID, username, version, []byte(req.Body))
+	if err != nil {
+		request.err = err
+		logger.Error("patch_expense_failed", err, req)
+
+		return req.NewErrorResponse(err), nil
+	}
+
+	return req.NewJSONResponse(http.StatusOK, patchedExpense, apigateway.NewETagHeader(patchedExpense.Version)), nil
+}
@@ -0,0 +1,49 @@
+package handlers
+
+import (
+	"github.com/JoelD7/money/backend/models"
+	"github.com/JoelD7/money/backend/shared/router"
+)
+
+// NewRouter returns the router with all the endpoints of the expenses API.
+func NewRouter(envConfig *models.EnvironmentConfiguration) *router.Router {
+	rootRouter := router.NewRouter(envConfig)
+
+	rootRouter.Route("/", func(r *router.Router) {
+		r.Route("/expenses", func(r *router.Router) {
+			r.Get("/{expenseID}", GetExpense)
+			r.Put("/{expenseID}", UpdateExpense)
+			r.Patch("/{expenseID}", PatchExpense)
+			r.Delete("/{expenseID}", DeleteExpense)
+			r.Get("/", GetExpenses)
+			r.Post("/", CreateExpense)
+			r.Post("/import", ImportExpenses)
+
+			r.Route("/{expenseID}", func(r *router.Router) {
+				r.Get("/history", GetExpenseHistory)
+			})
+
+			r.Route("/recurring", func(r *router.Router) {
+				r.Get("/", GetExpensesRecurring)
+				r.Post("/", CreateExpenseRecurring)
+				r.Get("/{expenseRecurringID}", GetExpenseRecurring)
+				r.Put("/{expenseRecurringID}", UpdateExpenseRecurring)
+				r.Delete("/{expenseRecurringID}", DeleteExpenseRecurring)
+			})
+
+			r.Route("/categorize", func(r *router.Router) {
+				r.Route("/period", func(r *router.Router) {
+					r.Post("/{periodID}", CategorizeExpenses)
+				})
+			})
+
+			r.Route("/stats", func(r *router.Router) {
+				r.Route("/period", func(r *router.Router) {
+					r.Get("/{periodID}", GetExpensesStats)
+				})
+			})
+		})
+	})
+
+	return rootRouter
+}
@@ -27,6 +27,7 @@ type updateExpenseRequest struct {
 	expensesRepo expenses.Repository
 	userRepo     users.Repository
 	periodRepo   period.Repository
+	checkBudget  usecases.BudgetAlertChecker
 }
 
 func (request *updateExpenseRequest) init(ctx context.Context, envConfig *models.EnvironmentConfiguration) error {
@@ -34,17 +35,22 @@ func (request *updateExpenseRequest) init(ctx context.Context, envConfig *models
 	ueOnce.Do(func() {
 		dynamoClient := dynamo.InitClient(ctx)
 
-		request.expensesRepo, err = expenses.NewDynamoRepository(dynamoClient, envConfig)
+		request.expensesRepo, err = expenses.NewRepository(ctx, dynamoClient, envConfig)
 		if err != nil {
 			return
 		}
 
-		request.periodRepo, err = period.NewDynamoRepository(dynamoClient, envConfig)
+		request.periodRepo, err = period.NewRepository(ctx, dynamoClient, envConfig)
 		if err != nil {
 			return
 		}
 
-		request.userRepo, err = users.NewDynamoRepository(dynamoClient, envConfig.UsersTable)
+		request.userRepo, err = users.NewRepository(ctx, dynamoClient, envConfig)
+		if err != nil {
+			return
+		}
+
+		request.checkBudget, err = newBudgetAlertChecker(ctx, dynamoClient, envConfig, request.expensesRepo, request.userRepo, request.periodRepo)
 		if err != nil {
 			return
 		}
@@ -96,7 +102,16 @@ func (request *updateExpenseRequest) process(ctx context.Context, req *apigatewa
 		return req.NewErrorResponse(err), nil
 	}
 
-	updateExpense := usecases.NewExpenseUpdater(request.expensesRepo, request.periodRepo, request.userRepo)
+	version, err := req.GetIfMatchVersion()
+	if err != nil {
+		logger.Error("invalid_if_match", err, req)
+
+		return req.NewErrorResponse(err), nil
+	}
+
+	expense.Version = version
+
+	updateExpense := usecases.NewExpenseUpdater(request.expensesRepo, request.periodRepo, request.userRepo, request.checkBudget)
 
 	updatedExpense, err := updateExpense(ctx, expenseID, username, expense)
 	if err != nil {
@@ -105,7 +120,7 @@ func (request *updateExpenseRequest) process(ctx context.Context, req *apigatewa
 		return req.NewErrorResponse(err), nil
 	}
 
-	return req.NewJSONResponse(http.StatusOK, updatedExpense), nil
+	return req.NewJSONResponse(http.StatusOK, updatedExpense, apigateway.NewETagHeader(updatedExpense.Version)), nil
 }
 
 func validateUpdateInput(req *apigateway.Request, username string) (*models.Expense, error) {
@@ -128,5 +143,10 @@ func validateUpdateInput(req *apigateway.Request, username string) (*models.Expe
 		return nil, err
 	}
 
+	err = validate.Currency(expense.Currency)
+	if err != nil {
+		return nil, err
+	}
+
 	return expense, nil
 }
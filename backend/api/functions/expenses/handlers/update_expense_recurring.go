@@ -0,0 +1,114 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/JoelD7/money/backend/models"
+	"github.com/JoelD7/money/backend/shared/apigateway"
+	"github.com/JoelD7/money/backend/shared/logger"
+	"github.com/JoelD7/money/backend/storage/dynamo"
+	expensesRecurring "github.com/JoelD7/money/backend/storage/expenses-recurring"
+	"github.com/JoelD7/money/backend/storage/users"
+	"github.com/JoelD7/money/backend/usecases"
+	"net/http"
+	"sync"
+	"time"
+)
+
+var (
+	uerRequest *UpdateExpenseRecurringRequest
+	uerOnce    sync.Once
+)
+
+type UpdateExpenseRecurringRequest struct {
+	startingTime time.Time
+	err          error
+	Repo         expensesRecurring.Repository
+	UserRepo     users.Repository
+}
+
+func (request *UpdateExpenseRecurringRequest) init(ctx context.Context, envConfig *models.EnvironmentConfiguration) error {
+	var err error
+
+	uerOnce.Do(func() {
+		dynamoClient := dynamo.InitClient(ctx)
+		request.Repo, err = expensesRecurring.NewRepository(ctx, dynamoClient, envConfig)
+		if err != nil {
+			return
+		}
+
+		request.UserRepo, err = users.NewRepository(ctx, dynamoClient, envConfig)
+	})
+
+	request.startingTime = time.Now()
+
+	return err
+}
+
+func (request *UpdateExpenseRecurringRequest) finish() {
+	logger.LogLambdaTime(request.startingTime, request.err, recover())
+}
+
+func UpdateExpenseRecurring(ctx context.Context, envConfig *models.EnvironmentConfiguration, req *apigateway.Request) (*apigateway.Response, error) {
+	if uerRequest == nil {
+		uerRequest = new(UpdateExpenseRecurringRequest)
+	}
+
+	err := uerRequest.init(ctx, envConfig)
+	if err != nil {
+		logger.Error("update_expense_recurring_init_failed", err, req)
+		return req.NewErrorResponse(err), nil
+	}
+	defer uerRequest.finish()
+
+	return uerRequest.Process(ctx, req)
+}
+
+func (request *UpdateExpenseRecurringRequest) Process(ctx context.Context, req *apigateway.Request) (*apigateway.Response, error) {
+	expenseRecurringID, ok := req.PathParameters["expenseRecurringID"]
+	if !ok || expenseRecurringID == "" {
+		request.err = models.ErrMissingExpenseRecurringID
+		logger.Error("missing_expense_recurring_id", nil, req)
+
+		return req.NewErrorResponse(models.ErrMissingExpenseRecurringID), nil
+	}
+
+	username, err := apigateway.GetUsernameFromContext(req)
+	if err != nil {
+		request.err = err
+		logger.Error("get_username_from_context_failed", err, req)
+
+		return req.NewErrorResponse(err), nil
+	}
+
+	expenseRecurring := new(models.ExpenseRecurring)
+
+	err = json.Unmarshal([]byte(req.Body), expenseRecurring)
+	if err != nil {
+		request.err = err
+		logger.Error("validate_input_failed", err, req)
+
+		return req.NewErrorResponse(fmt.Errorf("%v: %w", err, models.ErrInvalidRequestBody)), nil
+	}
+
+	err = validateExpenseRecurringValues(expenseRecurring)
+	if err != nil {
+		request.err = err
+		logger.Error("validate_input_failed", err, req)
+
+		return req.NewErrorResponse(err), nil
+	}
+
+	updateExpenseRecurring := usecases.NewExpenseRecurringUpdater(request.Repo, request.UserRepo)
+
+	updatedExpenseRecurring, err := updateExpenseRecurring(ctx, expenseRecurringID, username, expenseRecurring)
+	if err != nil {
+		request.err = err
+		logger.Error("update_expense_recurring_failed", err, req)
+
+		return req.NewErrorResponse(err), nil
+	}
+
+	return req.NewJSONResponse(http.StatusOK, updatedExpenseRecurring), nil
+}
@@ -7,8 +7,8 @@ import (
 	"github.com/JoelD7/money/backend/shared/apigateway"
 	"github.com/JoelD7/money/backend/shared/env"
 	"github.com/JoelD7/money/backend/shared/logger"
-	"github.com/JoelD7/money/backend/shared/router"
 	"github.com/JoelD7/money/backend/shared/uuid"
+	"github.com/JoelD7/money/backend/storage/cursor"
 	"github.com/aws/aws-lambda-go/lambda"
 )
 
@@ -18,27 +18,12 @@ func main() {
 		panic(fmt.Errorf("failed to load environment variables: %w", err))
 	}
 
-	rootRouter := router.NewRouter(envConfig)
-
-	rootRouter.Route("/", func(r *router.Router) {
-		r.Route("/expenses", func(r *router.Router) {
-			r.Get("/{expenseID}", handlers.GetExpense)
-			r.Put("/{expenseID}", handlers.UpdateExpense)
-			r.Delete("/{expenseID}", handlers.DeleteExpense)
-			r.Get("/", handlers.GetExpenses)
-			r.Post("/", handlers.CreateExpense)
-
-			r.Route("/recurring", func(r *router.Router) {
-				r.Delete("/{expenseRecurringID}", handlers.DeleteExpenseRecurring)
-			})
+	err = cursor.CheckSecret()
+	if err != nil {
+		panic(fmt.Errorf("failed to load environment variables: %w", err))
+	}
 
-			r.Route("/stats", func(r *router.Router) {
-				r.Route("/period", func(r *router.Router) {
-					r.Get("/{periodID}", handlers.GetExpensesStats)
-				})
-			})
-		})
-	})
+	rootRouter := handlers.NewRouter(envConfig)
 
 	lambda.Start(func(ctx context.Context, request *apigateway.Request) (res *apigateway.Response, err error) {
 		logger.InitLogger(logger.LogstashImplementation)
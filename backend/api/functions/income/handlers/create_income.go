@@ -12,6 +12,7 @@ import (
 	"github.com/JoelD7/money/backend/storage/dynamo"
 	"github.com/JoelD7/money/backend/storage/income"
 	"github.com/JoelD7/money/backend/storage/period"
+	"github.com/JoelD7/money/backend/storage/users"
 	"github.com/JoelD7/money/backend/usecases"
 	"net/http"
 	"sync"
@@ -28,6 +29,7 @@ type createIncomeRequest struct {
 	err              error
 	incomeRepo       income.Repository
 	periodRepo       period.Repository
+	userRepo         users.Repository
 	idempotenceCache cache.IdempotenceCacheManager
 }
 
@@ -36,11 +38,16 @@ func (request *createIncomeRequest) init(ctx context.Context, envConfig *models.
 	ciOnce.Do(func() {
 		dynamoClient := dynamo.InitClient(ctx)
 
-		request.incomeRepo, err = income.NewDynamoRepository(dynamoClient, envConfig)
+		request.incomeRepo, err = income.NewRepository(ctx, dynamoClient, envConfig)
 		if err != nil {
 			return
 		}
-		request.periodRepo, err = period.NewDynamoRepository(dynamoClient, envConfig)
+		request.periodRepo, err = period.NewRepository(ctx, dynamoClient, envConfig)
+		if err != nil {
+			return
+		}
+
+		request.userRepo, err = users.NewRepository(ctx, dynamoClient, envConfig)
 		if err != nil {
 			return
 		}
@@ -96,7 +103,7 @@ func (request *createIncomeRequest) process(ctx context.Context, req *apigateway
 		return req.NewErrorResponse(err), nil
 	}
 
-	createIncome := usecases.NewIncomeCreator(request.incomeRepo, request.periodRepo, request.idempotenceCache)
+	createIncome := usecases.NewIncomeCreator(request.incomeRepo, request.periodRepo, request.userRepo, request.idempotenceCache)
 
 	newIncome, err := createIncome(ctx, username, idempotencyKey, reqIncome)
 	if err != nil {
@@ -117,19 +124,7 @@ func validateCreateIncomeBody(req *apigateway.Request) (*models.Income, error) {
 		return nil, fmt.Errorf("%v: %w", err, models.ErrInvalidRequestBody)
 	}
 
-	if reqIncome.Amount == nil {
-		return nil, models.ErrMissingAmount
-	}
-
-	if reqIncome.Name == nil {
-		return nil, models.ErrMissingName
-	}
-
-	if reqIncome.PeriodID == nil {
-		return nil, models.ErrMissingPeriod
-	}
-
-	err = validate.Amount(reqIncome.Amount)
+	err = validate.Income(reqIncome)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,104 @@
+package handlers
+
+import (
+	"context"
+	"github.com/JoelD7/money/backend/models"
+	"github.com/JoelD7/money/backend/shared/apigateway"
+	"github.com/JoelD7/money/backend/shared/logger"
+	"github.com/JoelD7/money/backend/storage/cache"
+	"github.com/JoelD7/money/backend/storage/dynamo"
+	"github.com/JoelD7/money/backend/storage/income"
+	"github.com/JoelD7/money/backend/storage/trash"
+	"github.com/JoelD7/money/backend/usecases"
+	"net/http"
+	"sync"
+	"time"
+)
+
+var (
+	diRequest *deleteIncomeRequest
+	diOnce    sync.Once
+)
+
+type deleteIncomeRequest struct {
+	startingTime time.Time
+	err          error
+	incomeRepo   income.Repository
+	trashRepo    trash.Repository
+	cacheManager cache.IncomePeriodCacheManager
+}
+
+func (request *deleteIncomeRequest) init(ctx context.Context, envConfig *models.EnvironmentConfiguration) error {
+	var err error
+	diOnce.Do(func() {
+		dynamoClient := dynamo.InitClient(ctx)
+
+		request.incomeRepo, err = income.NewRepository(ctx, dynamoClient, envConfig)
+		if err != nil {
+			return
+		}
+
+		request.trashRepo, err = trash.NewRepository(ctx, dynamoClient, envConfig)
+		if err != nil {
+			return
+		}
+
+		request.cacheManager = cache.NewRedisCache()
+	})
+	request.startingTime = time.Now()
+
+	return err
+}
+
+func (request *deleteIncomeRequest) finish() {
+	logger.LogLambdaTime(request.startingTime, request.err, recover())
+}
+
+func DeleteIncomeHandler(ctx context.Context, envConfig *models.EnvironmentConfiguration, req *apigateway.Request) (*apigateway.Response, error) {
+	if diRequest == nil {
+		diRequest = new(deleteIncomeRequest)
+	}
+
+	err := diRequest.init(ctx, envConfig)
+	if err != nil {
+		logger.Error("init_failed", err, req)
+
+		return req.NewErrorResponse(err), nil
+	}
+
+	defer diRequest.finish()
+
+	return diRequest.process(ctx, req)
+}
+
+func (request *deleteIncomeRequest) process(ctx context.Context, req *apigateway.Request) (*apigateway.Response, error) {
+	incomeID, ok := req.PathParameters["incomeID"]
+	if !ok || incomeID == "" {
+		request.err = models.ErrMissingIncomeID
+
+		logger.Error("missing_income_id", nil, req)
+		return req.NewErrorResponse(models.ErrMissingIncomeID), nil
+	}
+
+	username, err := apigateway.GetUsernameFromContext(req)
+	if err != nil {
+		request.err = err
+
+		logger.Error("get_username_from_context_failed", err, req)
+		return req.NewErrorResponse(err), nil
+	}
+
+	deleteIncome := usecases.NewIncomeDeleter(request.incomeRepo, request.trashRepo, request.cacheManager)
+
+	err = deleteIncome(ctx, incomeID, username)
+	if err != nil {
+		request.err = err
+
+		logger.Error("delete_income_failed", err, req)
+		return req.NewErrorResponse(err), nil
+	}
+
+	return &apigateway.Response{
+		StatusCode: http.StatusNoContent,
+	}, nil
+}
@@ -29,12 +29,12 @@ func (request *incomeGetRequest) init(ctx context.Context, envConfig *models.Env
 	once.Do(func() {
 		dynamoClient := dynamo.InitClient(ctx)
 
-		request.incomeRepo, err = income.NewDynamoRepository(dynamoClient, envConfig)
+		request.incomeRepo, err = income.NewRepository(ctx, dynamoClient, envConfig)
 		if err != nil {
 			return
 		}
 
-		request.periodRepo, err = period.NewDynamoRepository(dynamoClient, envConfig)
+		request.periodRepo, err = period.NewRepository(ctx, dynamoClient, envConfig)
 		if err != nil {
 			return
 		}
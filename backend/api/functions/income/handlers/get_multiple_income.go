@@ -42,12 +42,12 @@ func (request *GetMultipleIncomeRequest) init(ctx context.Context, envConfig *mo
 	gmiOnce.Do(func() {
 		dynamoClient := dynamo.InitClient(ctx)
 
-		request.IncomeRepo, err = income.NewDynamoRepository(dynamoClient, envConfig)
+		request.IncomeRepo, err = income.NewRepository(ctx, dynamoClient, envConfig)
 		if err != nil {
 			return
 		}
 
-		request.PeriodRepo, err = period.NewDynamoRepository(dynamoClient, envConfig)
+		request.PeriodRepo, err = period.NewRepository(ctx, dynamoClient, envConfig)
 		if err != nil {
 			return
 		}
@@ -0,0 +1,130 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/JoelD7/money/backend/models"
+	"github.com/JoelD7/money/backend/shared/apigateway"
+	"github.com/JoelD7/money/backend/shared/logger"
+	"github.com/JoelD7/money/backend/shared/statement"
+	"github.com/JoelD7/money/backend/storage/audit"
+	"github.com/JoelD7/money/backend/storage/cache"
+	"github.com/JoelD7/money/backend/storage/dynamo"
+	"github.com/JoelD7/money/backend/storage/income"
+	"github.com/JoelD7/money/backend/storage/period"
+	"github.com/JoelD7/money/backend/storage/users"
+	"github.com/JoelD7/money/backend/usecases"
+	"net/http"
+	"sync"
+	"time"
+)
+
+var (
+	iiRequest *importIncomeRequest
+	iiOnce    sync.Once
+)
+
+type importIncomeRequest struct {
+	startingTime time.Time
+	err          error
+	incomeRepo   income.Repository
+	periodRepo   period.Repository
+	userRepo     users.Repository
+	cacheManager cache.IncomePeriodCacheManager
+}
+
+func (request *importIncomeRequest) init(ctx context.Context, envConfig *models.EnvironmentConfiguration) error {
+	var err error
+	iiOnce.Do(func() {
+		dynamoClient := dynamo.InitClient(ctx)
+
+		request.incomeRepo, err = income.NewRepository(ctx, dynamoClient, envConfig)
+		if err != nil {
+			return
+		}
+
+		request.periodRepo, err = period.NewRepository(ctx, dynamoClient, envConfig)
+		if err != nil {
+			return
+		}
+
+		request.userRepo, err = users.NewRepository(ctx, dynamoClient, envConfig)
+		if err != nil {
+			return
+		}
+
+		request.cacheManager = cache.NewRedisCache()
+	})
+	request.startingTime = time.Now()
+
+	return err
+}
+
+func (request *importIncomeRequest) finish() {
+	logger.LogLambdaTime(request.startingTime, request.err, recover())
+}
+
+// ImportIncomeHandler creates income from the credits of a CSV or OFX bank statement.
+func ImportIncomeHandler(ctx context.Context, envConfig *models.EnvironmentConfiguration, req *apigateway.Request) (*apigateway.Response, error) {
+	if iiRequest == nil {
+		iiRequest = new(importIncomeRequest)
+	}
+
+	err := iiRequest.init(ctx, envConfig)
+	if err != nil {
+		logger.Error("import_income_init_failed", err, req)
+
+		return req.NewErrorResponse(err), nil
+	}
+
+	defer iiRequest.finish()
+
+	return iiRequest.process(ctx, req)
+}
+
+func (request *importIncomeRequest) process(ctx context.Context, req *apigateway.Request) (*apigateway.Response, error) {
+	username, err := apigateway.GetUsernameFromContext(req)
+	if err != nil {
+		request.err = err
+		logger.Error("get_username_from_context_failed", err, req)
+
+		return req.NewErrorResponse(err), nil
+	}
+
+	importRequest := new(statement.ImportRequest)
+
+	err = json.Unmarshal([]byte(req.Body), importRequest)
+	if err != nil {
+		request.err = err
+		logger.Error("validate_input_failed", err, req)
+
+		return req.NewErrorResponse(fmt.Errorf("%v: %w", err, models.ErrInvalidRequestBody)), nil
+	}
+
+	transactions, err := importRequest.Transactions()
+	if err != nil {
+		request.err = err
+		logger.Error("parse_statement_failed", err, req)
+
+		return req.NewErrorResponse(err), nil
+	}
+
+	importIncome := usecases.NewIncomeImporter(request.incomeRepo, request.periodRepo, request.userRepo, request.cacheManager)
+
+	ctx = audit.WithSource(ctx, models.AuditSourceImport, "")
+
+	result, err := importIncome(ctx, username, transactions, importRequest.DryRun)
+	if err != nil {
+		request.err = err
+		logger.Error("import_income_failed", err, req)
+
+		return req.NewErrorResponse(err), nil
+	}
+
+	if importRequest.DryRun {
+		return req.NewJSONResponse(http.StatusOK, result), nil
+	}
+
+	return req.NewJSONResponse(http.StatusCreated, result), nil
+}
@@ -0,0 +1,110 @@
+package handlers
+
+import (
+	"context"
+	"github.com/JoelD7/money/backend/models"
+	"github.com/JoelD7/money/backend/shared/apigateway"
+	"github.com/JoelD7/money/backend/shared/logger"
+	"github.com/JoelD7/money/backend/storage/cache"
+	"github.com/JoelD7/money/backend/storage/dynamo"
+	"github.com/JoelD7/money/backend/storage/income"
+	"github.com/JoelD7/money/backend/storage/period"
+	"github.com/JoelD7/money/backend/storage/users"
+	"github.com/JoelD7/money/backend/usecases"
+	"net/http"
+	"sync"
+	"time"
+)
+
+var (
+	piRequest *patchIncomeRequest
+	piOnce    sync.Once
+)
+
+type patchIncomeRequest struct {
+	startingTime time.Time
+	err          error
+	incomeRepo   income.Repository
+	periodRepo   period.Repository
+	userRepo     users.Repository
+	cacheManager cache.IncomePeriodCacheManager
+}
+
+func (request *patchIncomeRequest) init(ctx context.Context, envConfig *models.EnvironmentConfiguration) error {
+	var err error
+	piOnce.Do(func() {
+		dynamoClient := dynamo.InitClient(ctx)
+
+		request.incomeRepo, err = income.NewRepository(ctx, dynamoClient, envConfig)
+		if err != nil {
+			return
+		}
+
+		request.periodRepo, err = period.NewRepository(ctx, dynamoClient, envConfig)
+		if err != nil {
+			return
+		}
+
+		request.userRepo, err = users.NewRepository(ctx, dynamoClient, envConfig)
+		if err != nil {
+			return
+		}
+
+		request.cacheManager = cache.NewRedisCache()
+	})
+	request.startingTime = time.Now()
+
+	return err
+}
+
+func (request *patchIncomeRequest) finish() {
+	logger.LogLambdaTime(request.startingTime, request.err, recover())
+}
+
+// PatchIncomeHandler applies the JSON merge patch of the request body to an income.
+func PatchIncomeHandler(ctx context.Context, envConfig *models.EnvironmentConfiguration, req *apigateway.Request) (*apigateway.Response, error) {
+	if piRequest == nil {
+		piRequest = new(patchIncomeRequest)
+	}
+
+	err := piRequest.init(ctx, envConfig)
+	if err != nil {
+		logger.Error("patch_income_init_failed", err, req)
+
+		return req.NewErrorResponse(err), nil
+	}
+
+	defer piRequest.finish()
+
+	return piRequest.process(ctx, req)
+}
+
+func (request *patchIncomeRequest) process(ctx context.Context, req *apigateway.Request) (*apigateway.Response, error) {
+	incomeID, ok := req.PathParameters["incomeID"]
+	if !ok || incomeID == "" {
+		request.err = models.ErrMissingIncomeID
+
+		logger.Error("missing_income_id", nil, req)
+		return req.NewErrorResponse(models.ErrMissingIncomeID), nil
+	}
+
+	username, err := apigateway.GetUsernameFromContext(req)
+	if err != nil {
+		request.err = err
+
+		logger.Error("get_username_from_context_failed", err, req)
+		return req.NewErrorResponse(err), nil
+	}
+
+	patchIncome := usecases.NewIncomePatcher(request.incomeRepo, request.periodRepo, request.userRepo, request.cacheManager)
+
+	patchedIncome, err := patchIncome(ctx, incomeID, username, []byte(req.Body))
+	if err != nil {
+		request.err = err
+
+		logger.Error("patch_income_failed", err, req)
+		return req.NewErrorResponse(err), nil
+	}
+
+	return req.NewJSONResponse(http.StatusOK, patchedIncome), nil
+}
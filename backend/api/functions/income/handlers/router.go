@@ -0,0 +1,29 @@
+package handlers
+
+import (
+	"github.com/JoelD7/money/backend/models"
+	"github.com/JoelD7/money/backend/shared/router"
+)
+
+// NewRouter returns the router with all the endpoints of the income API.
+func NewRouter(envConfig *models.EnvironmentConfiguration) *router.Router {
+	rootRouter := router.NewRouter(envConfig)
+
+	rootRouter.Route("/", func(r *router.Router) {
+		r.Route("/income", func(r *router.Router) {
+			r.Post("/", CreateIncomeHandler)
+			r.Post("/import", ImportIncomeHandler)
+			r.Get("/{incomeID}", GetIncomeHandler)
+			r.Put("/{incomeID}", UpdateIncomeHandler)
+			r.Patch("/{incomeID}", PatchIncomeHandler)
+			r.Delete("/{incomeID}", DeleteIncomeHandler)
+			r.Get("/", GetMultipleIncomeHandler)
+
+			r.Route("/{incomeID}", func(r *router.Router) {
+				r.Get("/history", GetIncomeHistoryHandler)
+			})
+		})
+	})
+
+	return rootRouter
+}
@@ -0,0 +1,142 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/JoelD7/money/backend/models"
+	"github.com/JoelD7/money/backend/shared/apigateway"
+	"github.com/JoelD7/money/backend/shared/logger"
+	"github.com/JoelD7/money/backend/shared/validate"
+	"github.com/JoelD7/money/backend/storage/cache"
+	"github.com/JoelD7/money/backend/storage/dynamo"
+	"github.com/JoelD7/money/backend/storage/income"
+	"github.com/JoelD7/money/backend/storage/period"
+	"github.com/JoelD7/money/backend/usecases"
+	"net/http"
+	"sync"
+	"time"
+)
+
+var (
+	uiRequest *updateIncomeRequest
+	uiOnce    sync.Once
+)
+
+type updateIncomeRequest struct {
+	startingTime time.Time
+	err          error
+	incomeRepo   income.Repository
+	periodRepo   period.Repository
+	cacheManager cache.IncomePeriodCacheManager
+}
+
+func (request *updateIncomeRequest) init(ctx context.Context, envConfig *models.EnvironmentConfiguration) error {
+	var err error
+	uiOnce.Do(func() {
+		dynamoClient := dynamo.InitClient(ctx)
+
+		request.incomeRepo, err = income.NewRepository(ctx, dynamoClient, envConfig)
+		if err != nil {
+			return
+		}
+
+		request.periodRepo, err = period.NewRepository(ctx, dynamoClient, envConfig)
+		if err != nil {
+			return
+		}
+
+		request.cacheManager = cache.NewRedisCache()
+	})
+	request.startingTime = time.Now()
+
+	return err
+}
+
+func (request *updateIncomeRequest) finish() {
+	logger.LogLambdaTime(request.startingTime, request.err, recover())
+}
+
+func UpdateIncomeHandler(ctx context.Context, envConfig *models.EnvironmentConfiguration, req *apigateway.Request) (*apigateway.Response, error) {
+	if uiRequest == nil {
+		uiRequest = new(updateIncomeRequest)
+	}
+
+	err := uiRequest.init(ctx, envConfig)
+	if err != nil {
+		logger.Error("init_failed", err, req)
+
+		return req.NewErrorResponse(err), nil
+	}
+
+	defer uiRequest.finish()
+
+	return uiRequest.process(ctx, req)
+}
+
+func (request *updateIncomeRequest) process(ctx context.Context, req *apigateway.Request) (*apigateway.Response, error) {
+	incomeID, ok := req.PathParameters["incomeID"]
+	if !ok || incomeID == "" {
+		request.err = models.ErrMissingIncomeID
+
+		logger.Error("missing_income_id", nil, req)
+		return req.NewErrorResponse(models.ErrMissingIncomeID), nil
+	}
+
+	username, err := apigateway.GetUsernameFromContext(req)
+	if err != nil {
+		request.err = err
+
+		logger.Error("get_username_from_context_failed", err, req)
+		return req.NewErrorResponse(err), nil
+	}
+
+	reqIncome, err := validateUpdateIncomeBody(req)
+	if err != nil {
+		request.err = err
+
+		logger.Error("validate_update_income_body_failed", err, req)
+		return req.NewErrorResponse(err), nil
+	}
+
+	updateIncome := usecases.NewIncomeUpdater(request.incomeRepo, request.periodRepo, request.cacheManager)
+
+	updatedIncome, err := updateIncome(ctx, incomeID, username, reqIncome)
+	if err != nil {
+		request.err = err
+
+		logger.Error("update_income_failed", err, req)
+		return req.NewErrorResponse(err), nil
+	}
+
+	return req.NewJSONResponse(http.StatusOK, updatedIncome), nil
+}
+
+func validateUpdateIncomeBody(req *apigateway.Request) (*models.Income, error) {
+	reqIncome := new(models.Income)
+
+	err := json.Unmarshal([]byte(req.Body), reqIncome)
+	if err != nil {
+		return nil, fmt.Errorf("%v: %w", err, models.ErrInvalidRequestBody)
+	}
+
+	if reqIncome.Name != nil && *reqIncome.Name == "" {
+		return nil, models.ErrMissingName
+	}
+
+	if reqIncome.PeriodID != nil && *reqIncome.PeriodID == "" {
+		return nil, models.ErrMissingPeriod
+	}
+
+	err = validate.Amount(reqIncome.Amount)
+	if err != nil {
+		return nil, err
+	}
+
+	err = validate.Currency(reqIncome.Currency)
+	if err != nil {
+		return nil, err
+	}
+
+	return reqIncome, nil
+}
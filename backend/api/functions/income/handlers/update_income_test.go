@@ -0,0 +1,120 @@
+package handlers
+
+import (
+	"context"
+	"github.com/JoelD7/money/backend/models"
+	"github.com/JoelD7/money/backend/shared/apigateway"
+	"github.com/JoelD7/money/backend/shared/logger"
+	"github.com/JoelD7/money/backend/storage/cache"
+	"github.com/JoelD7/money/backend/storage/income"
+	"github.com/JoelD7/money/backend/storage/period"
+	"github.com/JoelD7/money/backend/storage/trash"
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"os"
+	"testing"
+)
+
+func TestMain(m *testing.M) {
+	logger.InitLogger(logger.ConsoleImplementation)
+
+	os.Exit(m.Run())
+}
+
+func TestUpdateIncomeHandlerSuccess(t *testing.T) {
+	c := require.New(t)
+
+	ctx := context.Background()
+
+	request := &updateIncomeRequest{
+		incomeRepo:   income.NewDynamoMock(),
+		periodRepo:   period.NewDynamoMock(),
+		cacheManager: cache.NewRedisCacheMock(),
+	}
+
+	response, err := request.process(ctx, getUpdateIncomeRequest())
+	c.NoError(err)
+	c.Equal(http.StatusOK, response.StatusCode)
+	c.Contains(response.Body, `"amount":9100`)
+}
+
+func TestUpdateIncomeHandlerFailed(t *testing.T) {
+	c := require.New(t)
+
+	incomeMock := income.NewDynamoMock()
+	ctx := context.Background()
+
+	request := &updateIncomeRequest{
+		incomeRepo:   incomeMock,
+		periodRepo:   period.NewDynamoMock(),
+		cacheManager: cache.NewRedisCacheMock(),
+	}
+
+	t.Run("Invalid amount", func(t *testing.T) {
+		apigwRequest := getUpdateIncomeRequest()
+		apigwRequest.Body = `{"amount":-5}`
+
+		response, err := request.process(ctx, apigwRequest)
+		c.NoError(err)
+		c.Equal(http.StatusBadRequest, response.StatusCode)
+	})
+
+	t.Run("Missing income ID", func(t *testing.T) {
+		apigwRequest := getUpdateIncomeRequest()
+		apigwRequest.PathParameters = nil
+
+		response, err := request.process(ctx, apigwRequest)
+		c.NoError(err)
+		c.Equal(http.StatusBadRequest, response.StatusCode)
+	})
+
+	t.Run("Income doesn't exist", func(t *testing.T) {
+		incomeMock.ActivateForceFailure(models.ErrIncomeNotFound)
+		defer incomeMock.DeactivateForceFailure()
+
+		response, err := request.process(ctx, getUpdateIncomeRequest())
+		c.NoError(err)
+		c.Equal(http.StatusNotFound, response.StatusCode)
+	})
+}
+
+func TestDeleteIncomeHandler(t *testing.T) {
+	c := require.New(t)
+
+	incomeMock := income.NewDynamoMock()
+	ctx := context.Background()
+
+	request := &deleteIncomeRequest{
+		incomeRepo:   incomeMock,
+		trashRepo:    trash.NewMock(),
+		cacheManager: cache.NewRedisCacheMock(),
+	}
+
+	response, err := request.process(ctx, getUpdateIncomeRequest())
+	c.NoError(err)
+	c.Equal(http.StatusNoContent, response.StatusCode)
+
+	t.Run("Income doesn't exist", func(t *testing.T) {
+		incomeMock.ActivateForceFailure(models.ErrIncomeNotFound)
+		defer incomeMock.DeactivateForceFailure()
+
+		response, err = request.process(ctx, getUpdateIncomeRequest())
+		c.NoError(err)
+		c.Equal(http.StatusNotFound, response.StatusCode)
+	})
+}
+
+func getUpdateIncomeRequest() *apigateway.Request {
+	return &apigateway.Request{
+		Body: `{"amount":9100,"period_id":"2020-01"}`,
+		PathParameters: map[string]string{
+			"incomeID": "INC123",
+		},
+		RequestContext: events.APIGatewayProxyRequestContext{
+			Authorizer: map[string]interface{}{
+				"username": "test@gmail.com",
+			},
+		},
+	}
+}
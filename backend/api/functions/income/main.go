@@ -7,8 +7,8 @@ import (
 	"github.com/JoelD7/money/backend/shared/apigateway"
 	"github.com/JoelD7/money/backend/shared/env"
 	"github.com/JoelD7/money/backend/shared/logger"
-	"github.com/JoelD7/money/backend/shared/router"
 	"github.com/JoelD7/money/backend/shared/uuid"
+	"github.com/JoelD7/money/backend/storage/cursor"
 	"github.com/aws/aws-lambda-go/lambda"
 )
 
@@ -18,15 +18,12 @@ func main() {
 		panic(fmt.Errorf("failed to load environment variables: %w", err))
 	}
 
-	rootRouter := router.NewRouter(envConfig)
+	err = cursor.CheckSecret()
+	if err != nil {
+		panic(fmt.Errorf("failed to load environment variables: %w", err))
+	}
 
-	rootRouter.Route("/", func(r *router.Router) {
-		r.Route("/income", func(r *router.Router) {
-			r.Post("/", handlers.CreateIncomeHandler)
-			r.Get("/{incomeID}", handlers.GetIncomeHandler)
-			r.Get("/", handlers.GetMultipleIncomeHandler)
-		})
-	})
+	rootRouter := handlers.NewRouter(envConfig)
 
 	lambda.Start(func(ctx context.Context, request *apigateway.Request) (res *apigateway.Response, err error) {
 		logger.InitLogger(logger.LogstashImplementation)
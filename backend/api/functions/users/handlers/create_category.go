@@ -12,7 +12,6 @@ import (
 	"github.com/JoelD7/money/backend/storage/dynamo"
 	"github.com/JoelD7/money/backend/storage/users"
 	"github.com/JoelD7/money/backend/usecases"
-	"math"
 	"net/http"
 	"sync"
 	"time"
@@ -34,7 +33,7 @@ func (request *createCategoryRequest) init(ctx context.Context, envConfig *model
 		logger.SetHandler("create-category")
 		dynamoClient := dynamo.InitClient(ctx)
 
-		request.userRepo, err = users.NewDynamoRepository(dynamoClient, envConfig.UsersTable)
+		request.userRepo, err = users.NewRepository(ctx, dynamoClient, envConfig)
 		if err != nil {
 			return
 		}
@@ -120,20 +119,9 @@ func validateCreateCategoryRequestBody(req *apigateway.Request) (*models.Categor
 		return nil, fmt.Errorf("%v: %w", err, models.ErrInvalidRequestBody)
 	}
 
-	if category.Name == nil || *category.Name == "" {
-		return nil, models.ErrMissingCategoryName
-	}
-
-	if category.Budget == nil {
-		return nil, models.ErrMissingCategoryBudget
-	}
-
-	if *category.Budget < 0 || *category.Budget >= math.MaxFloat64 {
-		return nil, models.ErrInvalidBudget
-	}
-
-	if category.Color == nil || *category.Color == "" {
-		return nil, models.ErrMissingCategoryColor
+	err = validate.Category(category)
+	if err != nil {
+		return nil, err
 	}
 
 	return category, nil
@@ -7,6 +7,7 @@ import (
 	"github.com/JoelD7/money/backend/models"
 	"github.com/JoelD7/money/backend/shared/apigateway"
 	"github.com/JoelD7/money/backend/shared/logger"
+	"github.com/JoelD7/money/backend/shared/validate"
 	"github.com/JoelD7/money/backend/storage/cache"
 	"github.com/JoelD7/money/backend/storage/dynamo"
 	"github.com/JoelD7/money/backend/storage/period"
@@ -36,17 +37,17 @@ func (request *CreatePeriodRequest) init(ctx context.Context, envConfig *models.
 	cpOnce.Do(func() {
 		dynamoClient := dynamo.InitClient(ctx)
 
-		request.PeriodRepo, err = period.NewDynamoRepository(dynamoClient, envConfig)
+		request.PeriodRepo, err = period.NewRepository(ctx, dynamoClient, envConfig)
 		if err != nil {
 			return
 		}
 
-		request.SavingGoalRepo, err = savingoal.NewDynamoRepository(dynamoClient, envConfig)
+		request.SavingGoalRepo, err = savingoal.NewRepository(ctx, dynamoClient, envConfig)
 		if err != nil {
 			return
 		}
 
-		request.SavingsRepo, err = savings.NewDynamoRepository(dynamoClient, envConfig)
+		request.SavingsRepo, err = savings.NewRepository(ctx, dynamoClient, envConfig)
 		if err != nil {
 			return
 		}
@@ -127,12 +128,9 @@ func (request *CreatePeriodRequest) validateCreateRequestBody(req *apigateway.Re
 		return nil, fmt.Errorf("%v:%w", err, models.ErrInvalidRequestBody)
 	}
 
-	if p.Name == nil || p.Name != nil && *p.Name == "" {
-		return nil, models.ErrMissingPeriodName
-	}
-
-	if p.StartDate.IsZero() || p.EndDate.IsZero() {
-		return nil, models.ErrMissingPeriodDates
+	err = validate.Period(p)
+	if err != nil {
+		return nil, err
 	}
 
 	return p, nil
@@ -38,11 +38,16 @@ func (request *createSavingRequest) init(ctx context.Context, envConfig *models.
 		logger.SetHandler("create-saving")
 		dynamoClient := dynamo.InitClient(ctx)
 
-		request.savingsRepo, err = savings.NewDynamoRepository(dynamoClient, envConfig)
+		request.savingsRepo, err = savings.NewRepository(ctx, dynamoClient, envConfig)
 		if err != nil {
 			return
 		}
-		request.periodRepo, err = period.NewDynamoRepository(dynamoClient, envConfig)
+		request.periodRepo, err = period.NewRepository(ctx, dynamoClient, envConfig)
+		if err != nil {
+			return
+		}
+
+		request.userRepo, err = users.NewRepository(ctx, dynamoClient, envConfig)
 		if err != nil {
 			return
 		}
@@ -97,7 +102,7 @@ func (request *createSavingRequest) process(ctx context.Context, req *apigateway
 		return req.NewErrorResponse(err), nil
 	}
 
-	createSaving := usecases.NewSavingCreator(request.savingsRepo, request.periodRepo, request.idempotenceCache)
+	createSaving := usecases.NewSavingCreator(request.savingsRepo, request.periodRepo, request.userRepo, request.idempotenceCache)
 
 	saving, err := createSaving(ctx, username, idempotencyKey, userSaving)
 	if err != nil {
@@ -117,17 +122,9 @@ func validateBody(req *apigateway.Request) (*models.Saving, error) {
 		return nil, models.ErrInvalidRequestBody
 	}
 
-	if userSaving.Amount == nil || (userSaving.Amount != nil && *userSaving.Amount == 0) {
-		return nil, models.ErrMissingAmount
-	}
-
-	err = validate.Amount(userSaving.Amount)
+	err = validate.Saving(userSaving)
 	if err != nil {
-		return nil, models.ErrInvalidSavingAmount
-	}
-
-	if userSaving.PeriodID == nil || (userSaving.PeriodID != nil && *userSaving.PeriodID == "") {
-		return nil, models.ErrMissingPeriod
+		return nil, err
 	}
 
 	return userSaving, nil
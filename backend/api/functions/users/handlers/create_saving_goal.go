@@ -10,6 +10,7 @@ import (
 	"github.com/JoelD7/money/backend/storage/cache"
 	"github.com/JoelD7/money/backend/storage/dynamo"
 	"github.com/JoelD7/money/backend/storage/savingoal"
+	"github.com/JoelD7/money/backend/storage/users"
 	"github.com/JoelD7/money/backend/usecases"
 	"net/http"
 	"sync"
@@ -25,6 +26,7 @@ type createSavingGoalRequest struct {
 	startingTime   time.Time
 	err            error
 	savingGoalRepo savingoal.Repository
+	userRepo       users.Repository
 	cacheManager   cache.IdempotenceCacheManager
 }
 
@@ -33,7 +35,12 @@ func (request *createSavingGoalRequest) init(ctx context.Context, envConfig *mod
 	csgOnce.Do(func() {
 		request.startingTime = time.Now()
 		dynamoClient := dynamo.InitClient(ctx)
-		request.savingGoalRepo, err = savingoal.NewDynamoRepository(dynamoClient, envConfig)
+		request.savingGoalRepo, err = savingoal.NewRepository(ctx, dynamoClient, envConfig)
+		if err != nil {
+			return
+		}
+
+		request.userRepo, err = users.NewRepository(ctx, dynamoClient, envConfig)
 		if err != nil {
 			return
 		}
@@ -85,7 +92,7 @@ func (request *createSavingGoalRequest) process(ctx context.Context, req *apigat
 		return req.NewErrorResponse(err), nil
 	}
 
-	createSavingGoal := usecases.NewSavingGoalCreator(request.savingGoalRepo, request.cacheManager)
+	createSavingGoal := usecases.NewSavingGoalCreator(request.savingGoalRepo, request.userRepo, request.cacheManager)
 
 	savingGoal, err = createSavingGoal(ctx, username, idempotencyKey, savingGoal)
 	if err != nil {
@@ -105,27 +112,13 @@ func validateSavingGoalBody(req *apigateway.Request) (*models.SavingGoal, error)
 		return nil, err
 	}
 
-	if savingGoal.Name == nil || (savingGoal.Name != nil && *savingGoal.Name == "") {
-		return nil, models.ErrMissingSavingGoalName
-	}
-
-	if savingGoal.Target == nil {
-		return nil, models.ErrMissingSavingGoalTarget
-	}
-
-	if savingGoal.Target != nil && *savingGoal.Target <= 0 {
-		return nil, models.ErrInvalidSavingGoalTarget
-	}
-
-	if savingGoal.Deadline != nil && savingGoal.Deadline.Before(time.Now()) {
-		return nil, models.ErrInvalidSavingGoalDeadline
-	}
-
-	if savingGoal.IsRecurring && savingGoal.RecurringAmount == nil {
-		return nil, models.ErrMissingSavingGoalRecurringAmount
+	err = validate.SavingGoal(&savingGoal)
+	if err != nil {
+		return nil, err
 	}
 
-	if err = validate.Amount(savingGoal.RecurringAmount); savingGoal.IsRecurring && err != nil {
+	err = validate.SavingGoalDeadline(savingGoal.Deadline)
+	if err != nil {
 		return nil, err
 	}
 
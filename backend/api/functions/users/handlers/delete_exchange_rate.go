@@ -0,0 +1,97 @@
+package handlers
+
+import (
+	"context"
+	"github.com/JoelD7/money/backend/models"
+	"github.com/JoelD7/money/backend/shared/apigateway"
+	"github.com/JoelD7/money/backend/shared/logger"
+	"github.com/JoelD7/money/backend/storage/dynamo"
+	"github.com/JoelD7/money/backend/storage/exchangerate"
+	"github.com/JoelD7/money/backend/usecases"
+	"net/http"
+	"sync"
+	"time"
+)
+
+var (
+	derRequest *deleteExchangeRateRequest
+	derOnce    sync.Once
+)
+
+type deleteExchangeRateRequest struct {
+	startingTime     time.Time
+	err              error
+	exchangeRateRepo exchangerate.Repository
+}
+
+func (request *deleteExchangeRateRequest) init(ctx context.Context, envConfig *models.EnvironmentConfiguration) error {
+	var err error
+	derOnce.Do(func() {
+		logger.SetHandler("delete-exchange-rate")
+		dynamoClient := dynamo.InitClient(ctx)
+
+		request.exchangeRateRepo, err = exchangerate.NewRepository(ctx, dynamoClient, envConfig)
+	})
+	request.startingTime = time.Now()
+
+	return err
+}
+
+func (request *deleteExchangeRateRequest) finish() {
+	logger.LogLambdaTime(request.startingTime, request.err, recover())
+}
+
+func DeleteExchangeRateHandler(ctx context.Context, envConfig *models.EnvironmentConfiguration, req *apigateway.Request) (*apigateway.Response, error) {
+	if derRequest == nil {
+		derRequest = new(deleteExchangeRateRequest)
+	}
+
+	err := derRequest.init(ctx, envConfig)
+	if err != nil {
+		logger.Error("delete_exchange_rate_init_failed", err, req)
+
+		return req.NewErrorResponse(err), nil
+	}
+
+	defer derRequest.finish()
+
+	return derRequest.process(ctx, req)
+}
+
+func (request *deleteExchangeRateRequest) process(ctx context.Context, req *apigateway.Request) (*apigateway.Response, error) {
+	periodID, err := getPeriodIDFromPath(req)
+	if err != nil {
+		request.err = err
+		logger.Error("missing_period_id", err, req)
+
+		return req.NewErrorResponse(err), nil
+	}
+
+	currency, err := getCurrencyFromPath(req)
+	if err != nil {
+		request.err = err
+		logger.Error("validate_currency_failed", err, req)
+
+		return req.NewErrorResponse(err), nil
+	}
+
+	username, err := apigateway.GetUsernameFromContext(req)
+	if err != nil {
+		request.err = err
+		logger.Error("get_username_from_context_failed", err, req)
+
+		return req.NewErrorResponse(err), nil
+	}
+
+	deleteExchangeRate := usecases.NewExchangeRateDeleter(request.exchangeRateRepo)
+
+	err = deleteExchangeRate(ctx, username, periodID, currency)
+	if err != nil {
+		request.err = err
+		logger.Error("delete_exchange_rate_failed", err, req)
+
+		return req.NewErrorResponse(err), nil
+	}
+
+	return req.NewJSONResponse(http.StatusNoContent, nil), nil
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/JoelD7/money/backend/storage/cache"
 	"github.com/JoelD7/money/backend/storage/dynamo"
 	"github.com/JoelD7/money/backend/storage/period"
+	"github.com/JoelD7/money/backend/storage/trash"
 	"github.com/JoelD7/money/backend/usecases"
 	"net/http"
 	"sync"
@@ -21,6 +22,7 @@ type deletePeriodRequest struct {
 	startingTime time.Time
 	err          error
 	periodRepo   period.Repository
+	trashRepo    trash.Repository
 	cacheManager cache.IncomePeriodCacheManager
 }
 
@@ -30,7 +32,12 @@ func (request *deletePeriodRequest) init(ctx context.Context, envConfig *models.
 		dynamoClient := dynamo.InitClient(ctx)
 		logger.SetHandler("delete-period")
 
-		request.periodRepo, err = period.NewDynamoRepository(dynamoClient, envConfig)
+		request.periodRepo, err = period.NewRepository(ctx, dynamoClient, envConfig)
+		if err != nil {
+			return
+		}
+
+		request.trashRepo, err = trash.NewRepository(ctx, dynamoClient, envConfig)
 		if err != nil {
 			return
 		}
@@ -81,9 +88,16 @@ func (request *deletePeriodRequest) process(ctx context.Context, req *apigateway
 		return req.NewErrorResponse(err), nil
 	}
 
-	deletePeriod := usecases.NewPeriodDeleter(request.periodRepo, request.cacheManager)
+	version, err := req.GetIfMatchVersion()
+	if err != nil {
+		logger.Error("invalid_if_match", err, req)
+
+		return req.NewErrorResponse(err), nil
+	}
+
+	deletePeriod := usecases.NewPeriodDeleter(request.periodRepo, request.trashRepo, request.cacheManager)
 
-	err = deletePeriod(ctx, periodID, username)
+	err = deletePeriod(ctx, periodID, username, version)
 	if err != nil {
 		logger.Error("delete_period_failed", err, req)
 
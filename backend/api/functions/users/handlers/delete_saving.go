@@ -7,6 +7,7 @@ import (
 	"github.com/JoelD7/money/backend/shared/logger"
 	"github.com/JoelD7/money/backend/storage/dynamo"
 	"github.com/JoelD7/money/backend/storage/savings"
+	"github.com/JoelD7/money/backend/storage/trash"
 	"github.com/JoelD7/money/backend/usecases"
 	"net/http"
 	"sync"
@@ -20,6 +21,7 @@ type deleteSavingRequest struct {
 	startingTime time.Time
 	err          error
 	savingsRepo  savings.Repository
+	trashRepo    trash.Repository
 }
 
 func (request *deleteSavingRequest) init(ctx context.Context, envConfig *models.EnvironmentConfiguration) error {
@@ -27,7 +29,12 @@ func (request *deleteSavingRequest) init(ctx context.Context, envConfig *models.
 	dsOnce.Do(func() {
 		dynamoClient := dynamo.InitClient(ctx)
 
-		request.savingsRepo, err = savings.NewDynamoRepository(dynamoClient, envConfig)
+		request.savingsRepo, err = savings.NewRepository(ctx, dynamoClient, envConfig)
+		if err != nil {
+			return
+		}
+
+		request.trashRepo, err = trash.NewRepository(ctx, dynamoClient, envConfig)
 		if err != nil {
 			return
 		}
@@ -67,9 +74,16 @@ func (request *deleteSavingRequest) process(ctx context.Context, req *apigateway
 		return req.NewErrorResponse(err), nil
 	}
 
-	deleteSaving := usecases.NewSavingDeleter(request.savingsRepo)
+	version, err := req.GetIfMatchVersion()
+	if err != nil {
+		logger.Error("invalid_if_match", err, req)
+
+		return req.NewErrorResponse(err), nil
+	}
+
+	deleteSaving := usecases.NewSavingDeleter(request.savingsRepo, request.trashRepo)
 
-	err = deleteSaving(ctx, savingID, username)
+	err = deleteSaving(ctx, savingID, username, version)
 	if err != nil {
 		logger.Error("delete_saving_failed", err, req)
 
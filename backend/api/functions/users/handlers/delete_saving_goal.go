@@ -7,6 +7,7 @@ import (
 	"github.com/JoelD7/money/backend/shared/logger"
 	"github.com/JoelD7/money/backend/storage/dynamo"
 	"github.com/JoelD7/money/backend/storage/savingoal"
+	"github.com/JoelD7/money/backend/storage/trash"
 	"github.com/JoelD7/money/backend/usecases"
 	"net/http"
 	"sync"
@@ -22,6 +23,7 @@ type deleteSavingGoalRequest struct {
 	startingTime   time.Time
 	err            error
 	savingGoalRepo savingoal.Repository
+	trashRepo      trash.Repository
 }
 
 func (request *deleteSavingGoalRequest) init(ctx context.Context, envConfig *models.EnvironmentConfiguration) error {
@@ -29,7 +31,12 @@ func (request *deleteSavingGoalRequest) init(ctx context.Context, envConfig *mod
 	dsg.Do(func() {
 		request.startingTime = time.Now()
 		dynamoClient := dynamo.InitClient(ctx)
-		request.savingGoalRepo, err = savingoal.NewDynamoRepository(dynamoClient, envConfig)
+		request.savingGoalRepo, err = savingoal.NewRepository(ctx, dynamoClient, envConfig)
+		if err != nil {
+			return
+		}
+
+		request.trashRepo, err = trash.NewRepository(ctx, dynamoClient, envConfig)
 		if err != nil {
 			return
 		}
@@ -68,9 +75,16 @@ func (request *deleteSavingGoalRequest) process(ctx context.Context, req *apigat
 		return req.NewErrorResponse(err), nil
 	}
 
-	deleteSavingGoal := usecases.NewSavingGoalEliminator(request.savingGoalRepo)
+	version, err := req.GetIfMatchVersion()
+	if err != nil {
+		logger.Error("invalid_if_match", err, req)
+
+		return req.NewErrorResponse(err), nil
+	}
+
+	deleteSavingGoal := usecases.NewSavingGoalEliminator(request.savingGoalRepo, request.trashRepo)
 
-	err = deleteSavingGoal(ctx, username, savingGoalID)
+	err = deleteSavingGoal(ctx, username, savingGoalID, version)
 	if err != nil {
 		logger.Error("delete_saving_goal_failed", err, req, models.Any("username", username))
 
@@ -5,6 +5,7 @@ import (
 	"github.com/JoelD7/money/backend/models"
 	"github.com/JoelD7/money/backend/shared/apigateway"
 	"github.com/JoelD7/money/backend/storage/savings"
+	"github.com/JoelD7/money/backend/storage/trash"
 	"github.com/stretchr/testify/require"
 	"net/http"
 	"testing"
@@ -17,8 +18,8 @@ func TestDeleteHandlerSuccess(t *testing.T) {
 	ctx := context.Background()
 
 	req := &deleteSavingRequest{
-
 		savingsRepo: savingsMock,
+		trashRepo:   trash.NewMock(),
 	}
 
 	apigwRequest := &apigateway.Request{
@@ -37,8 +38,8 @@ func TestDeleteHandlerFailed(t *testing.T) {
 	ctx := context.Background()
 
 	req := &deleteSavingRequest{
-
 		savingsRepo: savingsMock,
+		trashRepo:   trash.NewMock(),
 	}
 
 	apigwRequest := &apigateway.Request{
@@ -9,8 +9,8 @@ import (
 	"github.com/JoelD7/money/backend/models"
 	"github.com/JoelD7/money/backend/shared/apigateway"
 	"github.com/JoelD7/money/backend/shared/logger"
+	"github.com/JoelD7/money/backend/storage/accountdeletion"
 	"github.com/JoelD7/money/backend/storage/dynamo"
-	"github.com/JoelD7/money/backend/storage/users"
 	"github.com/JoelD7/money/backend/usecases"
 )
 
@@ -20,7 +20,8 @@ var (
 )
 
 type deleteUserRequest struct {
-	userRepo users.Repository
+	accountDeletionRepo  accountdeletion.Repository
+	accountDeletionQueue accountdeletion.Queue
 
 	startingTime time.Time
 	err          error
@@ -31,7 +32,12 @@ func (dur *deleteUserRequest) init(ctx context.Context, envConfig *models.Enviro
 	duOnce.Do(func() {
 		dynamoClient := dynamo.InitClient(ctx)
 
-		dur.userRepo, err = users.NewDynamoRepository(dynamoClient, envConfig.UsersTable)
+		dur.accountDeletionRepo, err = accountdeletion.NewRepository(ctx, dynamoClient, envConfig)
+		if err != nil {
+			return
+		}
+
+		dur.accountDeletionQueue, err = accountdeletion.NewSQSQueue(envConfig)
 		if err != nil {
 			return
 		}
@@ -76,14 +82,15 @@ func (dur *deleteUserRequest) process(ctx context.Context, req *apigateway.Reque
 		return req.NewErrorResponse(models.ErrUsernameDeleteMismatch), nil
 	}
 
-	deleteUser := usecases.NewUserDeleter(dur.userRepo)
+	requestAccountDeletion := usecases.NewAccountDeletionRequester(dur.accountDeletionRepo, dur.accountDeletionQueue)
 
-	err = deleteUser(ctx, pathUsername)
+	accountDeletion, err := requestAccountDeletion(ctx, pathUsername)
 	if err != nil {
+		dur.err = err
 		logger.Error("delete_user_failed", err, req)
 
 		return req.NewErrorResponse(err), nil
 	}
 
-	return req.NewJSONResponse(http.StatusNoContent, nil), nil
+	return req.NewJSONResponse(http.StatusAccepted, accountDeletion), nil
 }
@@ -0,0 +1,188 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"github.com/JoelD7/money/backend/models"
+	"github.com/JoelD7/money/backend/shared/apigateway"
+	"github.com/JoelD7/money/backend/shared/export"
+	"github.com/JoelD7/money/backend/shared/logger"
+	"github.com/JoelD7/money/backend/storage/dynamo"
+	"github.com/JoelD7/money/backend/storage/expenses"
+	expensesRecurring "github.com/JoelD7/money/backend/storage/expenses-recurring"
+	"github.com/JoelD7/money/backend/storage/income"
+	"github.com/JoelD7/money/backend/storage/period"
+	"github.com/JoelD7/money/backend/storage/savingoal"
+	"github.com/JoelD7/money/backend/storage/savings"
+	"github.com/JoelD7/money/backend/storage/users"
+	"github.com/JoelD7/money/backend/usecases"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+var (
+	eudRequest *exportUserDataRequest
+	eudOnce    sync.Once
+)
+
+// userDataWriteCloser is an encoder of the export that has to be closed once all the data is written.
+type userDataWriteCloser interface {
+	usecases.UserDataWriter
+	Close() error
+}
+
+type exportUserDataRequest struct {
+	startingTime          time.Time
+	err                   error
+	userRepo              users.Repository
+	periodRepo            period.Repository
+	expensesRepo          expenses.Repository
+	incomeRepo            income.Repository
+	savingsRepo           savings.Repository
+	savingGoalRepo        savingoal.Repository
+	expensesRecurringRepo expensesRecurring.Repository
+}
+
+func (request *exportUserDataRequest) init(ctx context.Context, envConfig *models.EnvironmentConfiguration) error {
+	var err error
+	eudOnce.Do(func() {
+		dynamoClient := dynamo.InitClient(ctx)
+
+		request.userRepo, err = users.NewRepository(ctx, dynamoClient, envConfig)
+		if err != nil {
+			return
+		}
+
+		request.periodRepo, err = period.NewRepository(ctx, dynamoClient, envConfig)
+		if err != nil {
+			return
+		}
+
+		request.expensesRepo, err = expenses.NewRepository(ctx, dynamoClient, envConfig)
+		if err != nil {
+			return
+		}
+
+		request.incomeRepo, err = income.NewRepository(ctx, dynamoClient, envConfig)
+		if err != nil {
+			return
+		}
+
+		request.savingsRepo, err = savings.NewRepository(ctx, dynamoClient, envConfig)
+		if err != nil {
+			return
+		}
+
+		request.savingGoalRepo, err = savingoal.NewRepository(ctx, dynamoClient, envConfig)
+		if err != nil {
+			return
+		}
+
+		request.expensesRecurringRepo, err = expensesRecurring.NewRepository(ctx, dynamoClient, envConfig)
+	})
+	request.startingTime = time.Now()
+
+	return err
+}
+
+func (request *exportUserDataRequest) finish() {
+	logger.LogLambdaTime(request.startingTime, request.err, recover())
+}
+
+// ExportUserDataHandler returns all the data of the user, either as a JSON document or as a zip archive of CSV files
+// depending on the "format" query parameter.
+func ExportUserDataHandler(ctx context.Context, envConfig *models.EnvironmentConfiguration, req *apigateway.Request) (*apigateway.Response, error) {
+	if eudRequest == nil {
+		eudRequest = new(exportUserDataRequest)
+	}
+
+	err := eudRequest.init(ctx, envConfig)
+	if err != nil {
+		logger.Error("export_user_data_init_failed", err, req)
+
+		return req.NewErrorResponse(err), nil
+	}
+
+	defer eudRequest.finish()
+
+	return eudRequest.process(ctx, req)
+}
+
+func (request *exportUserDataRequest) process(ctx context.Context, req *apigateway.Request) (*apigateway.Response, error) {
+	username, err := apigateway.GetUsernameFromContext(req)
+	if err != nil {
+		request.err = err
+		logger.Error("get_username_from_context_failed", err, req)
+
+		return req.NewErrorResponse(err), nil
+	}
+
+	format := strings.ToLower(req.QueryStringParameters["format"])
+	if format == "" {
+		format = export.FormatJSON
+	}
+
+	if format != export.FormatJSON && format != export.FormatCSV {
+		request.err = models.ErrInvalidExportFormat
+		logger.Error("invalid_export_format", models.ErrInvalidExportFormat, req)
+
+		return req.NewErrorResponse(models.ErrInvalidExportFormat), nil
+	}
+
+	exportUserData := usecases.NewUserDataExporter(request.userRepo, request.periodRepo, request.expensesRepo, request.incomeRepo,
+		request.savingsRepo, request.savingGoalRepo, request.expensesRecurringRepo)
+
+	exportedDate := time.Now()
+
+	// The pages of each entity are encoded into the body as they are read. The zip archive is base64 encoded on the way
+	// too, so that it isn't held twice.
+	var buf bytes.Buffer
+	var writer userDataWriteCloser
+	var base64Encoder io.WriteCloser
+
+	if format == export.FormatJSON {
+		writer = export.NewJSONWriter(&buf, exportedDate)
+	} else {
+		base64Encoder = base64.NewEncoder(base64.StdEncoding, &buf)
+		writer = export.NewCSVZipWriter(base64Encoder, exportedDate)
+	}
+
+	err = exportUserData(ctx, username, writer)
+	if err != nil {
+		request.err = err
+		logger.Error("export_user_data_failed", err, req)
+
+		return req.NewErrorResponse(err), nil
+	}
+
+	err = writer.Close()
+	if err == nil && base64Encoder != nil {
+		err = base64Encoder.Close()
+	}
+
+	if err != nil {
+		request.err = err
+		logger.Error("export_user_data_encoding_failed", err, req)
+
+		return req.NewErrorResponse(err), nil
+	}
+
+	if format == export.FormatJSON {
+		return req.NewJSONResponse(http.StatusOK, buf.String()), nil
+	}
+
+	fileName := fmt.Sprintf("money-export-%s.zip", exportedDate.Format(time.DateOnly))
+
+	response := req.NewJSONResponse(http.StatusOK, buf.String(),
+		apigateway.Header{Key: "Content-Type", Value: "application/zip"},
+		apigateway.Header{Key: "Content-Disposition", Value: fmt.Sprintf("attachment; filename=%q", fileName)},
+	)
+	response.IsBase64Encoded = true
+
+	return response, nil
+}
@@ -0,0 +1,88 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/JoelD7/money/backend/models"
+	"github.com/JoelD7/money/backend/shared/apigateway"
+	"github.com/JoelD7/money/backend/shared/logger"
+	"github.com/JoelD7/money/backend/storage/accountdeletion"
+	"github.com/JoelD7/money/backend/storage/dynamo"
+	"github.com/JoelD7/money/backend/usecases"
+)
+
+var (
+	gadRequest *getAccountDeletionRequest
+	gadOnce    sync.Once
+)
+
+type getAccountDeletionRequest struct {
+	accountDeletionRepo accountdeletion.Repository
+
+	startingTime time.Time
+	err          error
+}
+
+func (request *getAccountDeletionRequest) init(ctx context.Context, envConfig *models.EnvironmentConfiguration) error {
+	var err error
+	gadOnce.Do(func() {
+		dynamoClient := dynamo.InitClient(ctx)
+
+		request.accountDeletionRepo, err = accountdeletion.NewRepository(ctx, dynamoClient, envConfig)
+	})
+
+	request.startingTime = time.Now()
+
+	return err
+}
+
+func (request *getAccountDeletionRequest) finish() {
+	logger.LogLambdaTime(request.startingTime, request.err, recover())
+}
+
+// GetAccountDeletionHandler returns the progress of the deletion of a user's data.
+func GetAccountDeletionHandler(ctx context.Context, envConfig *models.EnvironmentConfiguration, req *apigateway.Request) (*apigateway.Response, error) {
+	if gadRequest == nil {
+		gadRequest = new(getAccountDeletionRequest)
+	}
+
+	err := gadRequest.init(ctx, envConfig)
+	if err != nil {
+		logger.Error("get_account_deletion_init_failed", err, req)
+		return req.NewErrorResponse(err), nil
+	}
+
+	defer gadRequest.finish()
+
+	return gadRequest.process(ctx, req)
+}
+
+func (request *getAccountDeletionRequest) process(ctx context.Context, req *apigateway.Request) (*apigateway.Response, error) {
+	pathUsername := req.PathParameters["username"]
+
+	authorizerUsername, err := apigateway.GetUsernameFromContext(req)
+	if err != nil {
+		logger.Error("get_username_from_context_failed", err, req)
+
+		return req.NewErrorResponse(err), nil
+	}
+
+	if pathUsername != authorizerUsername {
+		return req.NewErrorResponse(models.ErrUsernameDeleteMismatch), nil
+	}
+
+	getAccountDeletion := usecases.NewAccountDeletionGetter(request.accountDeletionRepo)
+
+	accountDeletion, err := getAccountDeletion(ctx, pathUsername)
+	if err != nil {
+		request.err = err
+		logger.Error("get_account_deletion_failed", err, req)
+
+		return req.NewErrorResponse(err), nil
+	}
+
+	return req.NewJSONResponse(http.StatusOK, accountDeletion), nil
+}
@@ -0,0 +1,98 @@
+package handlers
+
+import (
+	"context"
+	"github.com/JoelD7/money/backend/models"
+	"github.com/JoelD7/money/backend/shared/apigateway"
+	"github.com/JoelD7/money/backend/shared/logger"
+	"github.com/JoelD7/money/backend/shared/validate"
+	"github.com/JoelD7/money/backend/storage/audit"
+	"github.com/JoelD7/money/backend/storage/dynamo"
+	"github.com/JoelD7/money/backend/usecases"
+	"net/http"
+	"sync"
+	"time"
+)
+
+var (
+	gaRequest *getActivityRequest
+	gaOnce    sync.Once
+)
+
+type getActivityRequest struct {
+	startingTime time.Time
+	err          error
+	auditRepo    audit.Repository
+}
+
+func (request *getActivityRequest) init(ctx context.Context, envConfig *models.EnvironmentConfiguration) error {
+	var err error
+	gaOnce.Do(func() {
+		dynamoClient := dynamo.InitClient(ctx)
+
+		request.auditRepo, err = audit.NewRepository(ctx, dynamoClient, envConfig)
+		if err != nil {
+			return
+		}
+
+		logger.SetHandler("get-activity")
+	})
+	request.startingTime = time.Now()
+
+	return err
+}
+
+func (request *getActivityRequest) finish() {
+	logger.LogLambdaTime(request.startingTime, request.err, recover())
+}
+
+func GetActivityHandler(ctx context.Context, envConfig *models.EnvironmentConfiguration, req *apigateway.Request) (*apigateway.Response, error) {
+	if gaRequest == nil {
+		gaRequest = new(getActivityRequest)
+	}
+
+	err := gaRequest.init(ctx, envConfig)
+	if err != nil {
+		logger.Error("get_activity_init_failed", err, req)
+
+		return req.NewErrorResponse(err), nil
+	}
+	defer gaRequest.finish()
+
+	return gaRequest.process(ctx, req)
+}
+
+func (request *getActivityRequest) process(ctx context.Context, req *apigateway.Request) (*apigateway.Response, error) {
+	username, err := apigateway.GetUsernameFromContext(req)
+	if err != nil {
+		logger.Error("get_username_from_context_failed", err, req)
+
+		return req.NewErrorResponse(err), nil
+	}
+
+	params, err := req.GetQueryParameters()
+	if err != nil {
+		logger.Error("get_query_parameters_failed", err, req)
+
+		return req.NewErrorResponse(err), nil
+	}
+
+	err = validate.PageSize(params.PageSize)
+	if err != nil {
+		logger.Error("invalid_page_size", err, req)
+
+		return req.NewErrorResponse(err), nil
+	}
+
+	getActivity := usecases.NewActivityGetter(request.auditRepo)
+
+	entries, nextKey, err := getActivity(ctx, username, params)
+	if err != nil {
+		request.err = err
+		logger.Error("get_activity_failed", err, req)
+
+		return req.NewErrorResponse(err), nil
+	}
+
+	return req.NewJSONResponse(http.StatusOK, &auditEntriesResponse{entries, nextKey}), nil
+}
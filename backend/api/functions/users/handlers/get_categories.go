@@ -29,7 +29,7 @@ func (request *getCategoriesRequest) init(ctx context.Context, envConfig *models
 		logger.SetHandler("get-categories")
 		dynamoClient := dynamo.InitClient(ctx)
 
-		request.userRepo, err = users.NewDynamoRepository(dynamoClient, envConfig.UsersTable)
+		request.userRepo, err = users.NewRepository(ctx, dynamoClient, envConfig)
 		if err != nil {
 			return
 		}
@@ -80,7 +80,7 @@ func (request *getCategoriesRequest) process(ctx context.Context, req *apigatewa
 
 	getCategories := usecases.NewCategoriesGetter(request.userRepo)
 
-	categories, err := getCategories(ctx, username)
+	categories, version, err := getCategories(ctx, username)
 	if err != nil {
 		request.err = err
 		logger.Error("get_categories_failed", err, req)
@@ -88,5 +88,5 @@ func (request *getCategoriesRequest) process(ctx context.Context, req *apigatewa
 		return req.NewErrorResponse(err), nil
 	}
 
-	return req.NewJSONResponse(http.StatusOK, categories), nil
+	return req.NewJSONResponse(http.StatusOK, categories, apigateway.NewETagHeader(version)), nil
 }
@@ -0,0 +1,89 @@
+package handlers
+
+import (
+	"context"
+	"github.com/JoelD7/money/backend/models"
+	"github.com/JoelD7/money/backend/shared/apigateway"
+	"github.com/JoelD7/money/backend/shared/logger"
+	"github.com/JoelD7/money/backend/storage/dynamo"
+	"github.com/JoelD7/money/backend/storage/exchangerate"
+	"github.com/JoelD7/money/backend/usecases"
+	"net/http"
+	"sync"
+	"time"
+)
+
+var (
+	gerRequest *getExchangeRatesRequest
+	gerOnce    sync.Once
+)
+
+type getExchangeRatesRequest struct {
+	startingTime     time.Time
+	err              error
+	exchangeRateRepo exchangerate.Repository
+}
+
+func (request *getExchangeRatesRequest) init(ctx context.Context, envConfig *models.EnvironmentConfiguration) error {
+	var err error
+	gerOnce.Do(func() {
+		logger.SetHandler("get-exchange-rates")
+		dynamoClient := dynamo.InitClient(ctx)
+
+		request.exchangeRateRepo, err = exchangerate.NewRepository(ctx, dynamoClient, envConfig)
+	})
+	request.startingTime = time.Now()
+
+	return err
+}
+
+func (request *getExchangeRatesRequest) finish() {
+	logger.LogLambdaTime(request.startingTime, request.err, recover())
+}
+
+func GetExchangeRatesHandler(ctx context.Context, envConfig *models.EnvironmentConfiguration, req *apigateway.Request) (*apigateway.Response, error) {
+	if gerRequest == nil {
+		gerRequest = new(getExchangeRatesRequest)
+	}
+
+	err := gerRequest.init(ctx, envConfig)
+	if err != nil {
+		logger.Error("get_exchange_rates_init_failed", err, req)
+
+		return req.NewErrorResponse(err), nil
+	}
+
+	defer gerRequest.finish()
+
+	return gerRequest.process(ctx, req)
+}
+
+func (request *getExchangeRatesRequest) process(ctx context.Context, req *apigateway.Request) (*apigateway.Response, error) {
+	periodID, err := getPeriodIDFromPath(req)
+	if err != nil {
+		request.err = err
+		logger.Error("missing_period_id", err, req)
+
+		return req.NewErrorResponse(err), nil
+	}
+
+	username, err := apigateway.GetUsernameFromContext(req)
+	if err != nil {
+		request.err = err
+		logger.Error("get_username_from_context_failed", err, req)
+
+		return req.NewErrorResponse(err), nil
+	}
+
+	getExchangeRates := usecases.NewExchangeRatesGetter(request.exchangeRateRepo)
+
+	exchangeRates, err := getExchangeRates(ctx, username, periodID)
+	if err != nil {
+		request.err = err
+		logger.Error("get_exchange_rates_failed", err, req)
+
+		return req.NewErrorResponse(err), nil
+	}
+
+	return req.NewJSONResponse(http.StatusOK, exchangeRates), nil
+}
@@ -0,0 +1,121 @@
+package handlers
+
+import (
+	"context"
+	"github.com/JoelD7/money/backend/models"
+	"github.com/JoelD7/money/backend/shared/apigateway"
+	"github.com/JoelD7/money/backend/shared/logger"
+	"github.com/JoelD7/money/backend/shared/validate"
+	"github.com/JoelD7/money/backend/storage/audit"
+	"github.com/JoelD7/money/backend/storage/dynamo"
+	"github.com/JoelD7/money/backend/usecases"
+	"net/http"
+	"sync"
+	"time"
+)
+
+var (
+	ghRequest *getHistoryRequest
+	ghOnce    sync.Once
+)
+
+// getHistoryRequest handles the history of every entity of this API, as they only differ in the type of the entity and
+// the path parameter of its ID.
+type getHistoryRequest struct {
+	startingTime time.Time
+	err          error
+	auditRepo    audit.Repository
+}
+
+type auditEntriesResponse struct {
+	Entries []*models.AuditEntry `json:"entries"`
+	NextKey string               `json:"next_key,omitempty"`
+}
+
+func (request *getHistoryRequest) init(ctx context.Context, envConfig *models.EnvironmentConfiguration) error {
+	var err error
+	ghOnce.Do(func() {
+		dynamoClient := dynamo.InitClient(ctx)
+
+		request.auditRepo, err = audit.NewRepository(ctx, dynamoClient, envConfig)
+		if err != nil {
+			return
+		}
+
+		logger.SetHandler("get-history")
+	})
+	request.startingTime = time.Now()
+
+	return err
+}
+
+func (request *getHistoryRequest) finish() {
+	logger.LogLambdaTime(request.startingTime, request.err, recover())
+}
+
+func GetPeriodHistoryHandler(ctx context.Context, envConfig *models.EnvironmentConfiguration, req *apigateway.Request) (*apigateway.Response, error) {
+	return handleHistory(ctx, envConfig, req, models.AuditEntityPeriod, "periodID")
+}
+
+func GetSavingHistoryHandler(ctx context.Context, envConfig *models.EnvironmentConfiguration, req *apigateway.Request) (*apigateway.Response, error) {
+	return handleHistory(ctx, envConfig, req, models.AuditEntitySaving, "savingID")
+}
+
+func GetSavingGoalHistoryHandler(ctx context.Context, envConfig *models.EnvironmentConfiguration, req *apigateway.Request) (*apigateway.Response, error) {
+	return handleHistory(ctx, envConfig, req, models.AuditEntitySavingGoal, "savingGoalID")
+}
+
+func GetCategoryHistoryHandler(ctx context.Context, envConfig *models.EnvironmentConfiguration, req *apigateway.Request) (*apigateway.Response, error) {
+	return handleHistory(ctx, envConfig, req, models.AuditEntityCategory, "categoryID")
+}
+
+func handleHistory(ctx context.Context, envConfig *models.EnvironmentConfiguration, req *apigateway.Request, entityType models.AuditEntityType, idParameter string) (*apigateway.Response, error) {
+	if ghRequest == nil {
+		ghRequest = new(getHistoryRequest)
+	}
+
+	err := ghRequest.init(ctx, envConfig)
+	if err != nil {
+		logger.Error("get_history_init_failed", err, req)
+
+		return req.NewErrorResponse(err), nil
+	}
+	defer ghRequest.finish()
+
+	return ghRequest.process(ctx, req, entityType, req.PathParameters[idParameter])
+}
+
+func (request *getHistoryRequest) process(ctx context.Context, req *apigateway.Request, entityType models.AuditEntityType, entityID string) (*apigateway.Response, error) {
+	username, err := apigateway.GetUsernameFromContext(req)
+	if err != nil {
+		logger.Error("get_username_from_context_failed", err, req)
+
+		return req.NewErrorResponse(err), nil
+	}
+
+	params, err := req.GetQueryParameters()
+	if err != nil {
+		logger.Error("get_query_parameters_failed", err, req)
+
+		return req.NewErrorResponse(err), nil
+	}
+
+	err = validate.PageSize(params.PageSize)
+	if err != nil {
+		logger.Error("invalid_page_size", err, req)
+
+		return req.NewErrorResponse(err), nil
+	}
+
+	getEntityHistory := usecases.NewEntityHistoryGetter(request.auditRepo)
+
+	entries, nextKey, err := getEntityHistory(ctx, username, entityType, entityID, params)
+	if err != nil {
+		request.err = err
+		logger.Error("get_entity_history_failed", err, req)
+
+		return req.NewErrorResponse(err), nil
+	}
+
+	return req.NewJSONResponse(http.StatusOK, &auditEntriesResponse{entries, nextKey}), nil
+}
@@ -28,7 +28,7 @@ func (request *getPeriodRequest) init(ctx context.Context, envConfig *models.Env
 		logger.SetHandler("get-period")
 		dynamoClient := dynamo.InitClient(ctx)
 
-		request.periodRepo, err = period.NewDynamoRepository(dynamoClient, envConfig)
+		request.periodRepo, err = period.NewRepository(ctx, dynamoClient, envConfig)
 		if err != nil {
 			return
 		}
@@ -87,5 +87,5 @@ func (request *getPeriodRequest) process(ctx context.Context, req *apigateway.Re
 		return req.NewErrorResponse(err), nil
 	}
 
-	return req.NewJSONResponse(http.StatusOK, userPeriod), nil
+	return req.NewJSONResponse(http.StatusOK, userPeriod, apigateway.NewETagHeader(userPeriod.Version)), nil
 }
@@ -6,8 +6,11 @@ import (
 	"github.com/JoelD7/money/backend/shared/apigateway"
 	"github.com/JoelD7/money/backend/shared/logger"
 	"github.com/JoelD7/money/backend/storage/dynamo"
+	"github.com/JoelD7/money/backend/storage/exchangerate"
 	"github.com/JoelD7/money/backend/storage/expenses"
 	"github.com/JoelD7/money/backend/storage/income"
+	"github.com/JoelD7/money/backend/storage/period"
+	"github.com/JoelD7/money/backend/storage/users"
 	"github.com/JoelD7/money/backend/usecases"
 	"net/http"
 	"sync"
@@ -18,10 +21,15 @@ var gpstRequest *GetPeriodStatRequest
 var gpstOnce sync.Once
 
 type GetPeriodStatRequest struct {
-	startingTime time.Time
-	err          error
-	ExpensesRepo expenses.Repository
-	IncomeRepo   income.Repository
+	startingTime     time.Time
+	err              error
+	ExpensesRepo     expenses.Repository
+	IncomeRepo       income.Repository
+	UserRepo         users.Repository
+	ExchangeRateRepo exchangerate.Repository
+	PeriodRepo       period.Repository
+	// NearLimitPercentage is the percentage of a category budget from which its spending is considered near the limit.
+	NearLimitPercentage int
 }
 
 func (request *GetPeriodStatRequest) init(ctx context.Context, envConfig *models.EnvironmentConfiguration) error {
@@ -31,15 +39,32 @@ func (request *GetPeriodStatRequest) init(ctx context.Context, envConfig *models
 		logger.SetHandler("get-period-stat")
 		dynamoClient := dynamo.InitClient(ctx)
 
-		request.IncomeRepo, err = income.NewDynamoRepository(dynamoClient, envConfig)
+		request.IncomeRepo, err = income.NewRepository(ctx, dynamoClient, envConfig)
 		if err != nil {
 			return
 		}
 
-		request.ExpensesRepo, err = expenses.NewDynamoRepository(dynamoClient, envConfig)
+		request.ExpensesRepo, err = expenses.NewRepository(ctx, dynamoClient, envConfig)
 		if err != nil {
 			return
 		}
+
+		request.UserRepo, err = users.NewRepository(ctx, dynamoClient, envConfig)
+		if err != nil {
+			return
+		}
+
+		request.ExchangeRateRepo, err = exchangerate.NewRepository(ctx, dynamoClient, envConfig)
+		if err != nil {
+			return
+		}
+
+		request.PeriodRepo, err = period.NewRepository(ctx, dynamoClient, envConfig)
+		if err != nil {
+			return
+		}
+
+		request.NearLimitPercentage = envConfig.BudgetNearLimitPercentage
 	})
 
 	request.startingTime = time.Now()
@@ -84,7 +109,7 @@ func (request *GetPeriodStatRequest) Process(ctx context.Context, req *apigatewa
 		return req.NewErrorResponse(err), nil
 	}
 
-	getPeriodStats := usecases.NewPeriodStatsGetter(request.ExpensesRepo, request.IncomeRepo)
+	getPeriodStats := usecases.NewPeriodStatsGetter(request.ExpensesRepo, request.IncomeRepo, request.UserRepo, request.ExchangeRateRepo, request.PeriodRepo, request.NearLimitPercentage)
 
 	periodStats, err := getPeriodStats(ctx, username, periodID)
 	if err != nil {
@@ -39,7 +39,7 @@ func (request *getPeriodsRequest) init(ctx context.Context, envConfig *models.En
 
 		logger.SetHandler("get-periods")
 
-		request.periodRepo, err = period.NewDynamoRepository(dynamoClient, envConfig)
+		request.periodRepo, err = period.NewRepository(ctx, dynamoClient, envConfig)
 		if err != nil {
 			return
 		}
@@ -37,15 +37,15 @@ func (request *getSavingRequest) init(ctx context.Context, envConfig *models.Env
 	gsOnce.Do(func() {
 		dynamoClient := dynamo.InitClient(ctx)
 
-		request.savingsRepo, err = savings.NewDynamoRepository(dynamoClient, envConfig)
+		request.savingsRepo, err = savings.NewRepository(ctx, dynamoClient, envConfig)
 		if err != nil {
 			return
 		}
-		request.savingGoalRepo, err = savingoal.NewDynamoRepository(dynamoClient, envConfig)
+		request.savingGoalRepo, err = savingoal.NewRepository(ctx, dynamoClient, envConfig)
 		if err != nil {
 			return
 		}
-		request.periodRepo, err = period.NewDynamoRepository(dynamoClient, envConfig)
+		request.periodRepo, err = period.NewRepository(ctx, dynamoClient, envConfig)
 		if err != nil {
 			return
 		}
@@ -107,7 +107,7 @@ func (request *getSavingRequest) process(ctx context.Context, req *apigateway.Re
 	if errors.Is(err, models.ErrSavingGoalNameSettingFailed) {
 		logger.Error("get_saving_goal_name_failed", err, req)
 
-		return req.NewJSONResponse(http.StatusOK, saving), nil
+		return req.NewJSONResponse(http.StatusOK, saving, apigateway.NewETagHeader(saving.Version)), nil
 	}
 
 	if err != nil {
@@ -116,5 +116,5 @@ func (request *getSavingRequest) process(ctx context.Context, req *apigateway.Re
 		return req.NewErrorResponse(err), nil
 	}
 
-	return req.NewJSONResponse(http.StatusOK, saving), nil
+	return req.NewJSONResponse(http.StatusOK, saving, apigateway.NewETagHeader(saving.Version)), nil
 }
@@ -2,7 +2,9 @@ package handlers
 
 import (
 	"context"
+	"github.com/JoelD7/money/backend/storage/exchangerate"
 	"github.com/JoelD7/money/backend/storage/savings"
+	"github.com/JoelD7/money/backend/storage/users"
 	"net/http"
 	"sync"
 	"time"
@@ -21,10 +23,12 @@ var (
 )
 
 type getSavingGoalRequest struct {
-	startingTime   time.Time
-	err            error
-	savingGoalRepo savingoal.Repository
-	savingsRepo    savings.Repository
+	startingTime     time.Time
+	err              error
+	savingGoalRepo   savingoal.Repository
+	savingsRepo      savings.Repository
+	userRepo         users.Repository
+	exchangeRateRepo exchangerate.Repository
 }
 
 func (request *getSavingGoalRequest) init(ctx context.Context, envConfig *models.EnvironmentConfiguration) error {
@@ -32,12 +36,22 @@ func (request *getSavingGoalRequest) init(ctx context.Context, envConfig *models
 	getSavingGoalOnce.Do(func() {
 		request.startingTime = time.Now()
 		dynamoClient := dynamo.InitClient(ctx)
-		request.savingGoalRepo, err = savingoal.NewDynamoRepository(dynamoClient, envConfig)
+		request.savingGoalRepo, err = savingoal.NewRepository(ctx, dynamoClient, envConfig)
 		if err != nil {
 			return
 		}
 
-		request.savingsRepo, err = savings.NewDynamoRepository(dynamoClient, envConfig)
+		request.savingsRepo, err = savings.NewRepository(ctx, dynamoClient, envConfig)
+		if err != nil {
+			return
+		}
+
+		request.userRepo, err = users.NewRepository(ctx, dynamoClient, envConfig)
+		if err != nil {
+			return
+		}
+
+		request.exchangeRateRepo, err = exchangerate.NewRepository(ctx, dynamoClient, envConfig)
 		if err != nil {
 			return
 		}
@@ -76,7 +90,7 @@ func (request *getSavingGoalRequest) process(ctx context.Context, req *apigatewa
 		return req.NewErrorResponse(err), nil
 	}
 
-	getSavingGoal := usecases.NewSavingGoalGetter(request.savingGoalRepo, request.savingsRepo)
+	getSavingGoal := usecases.NewSavingGoalGetter(request.savingGoalRepo, request.savingsRepo, request.userRepo, request.exchangeRateRepo)
 
 	savingGoal, err := getSavingGoal(ctx, username, savingGoalID)
 	if err != nil {
@@ -85,5 +99,5 @@ func (request *getSavingGoalRequest) process(ctx context.Context, req *apigatewa
 		return req.NewErrorResponse(err), nil
 	}
 
-	return req.NewJSONResponse(http.StatusOK, savingGoal), nil
+	return req.NewJSONResponse(http.StatusOK, savingGoal, apigateway.NewETagHeader(savingGoal.Version)), nil
 }
@@ -7,8 +7,10 @@ import (
 	"github.com/JoelD7/money/backend/shared/logger"
 	"github.com/JoelD7/money/backend/shared/validate"
 	"github.com/JoelD7/money/backend/storage/dynamo"
+	"github.com/JoelD7/money/backend/storage/exchangerate"
 	"github.com/JoelD7/money/backend/storage/savingoal"
 	"github.com/JoelD7/money/backend/storage/savings"
+	"github.com/JoelD7/money/backend/storage/users"
 	"github.com/JoelD7/money/backend/usecases"
 	"net/http"
 	"sync"
@@ -21,11 +23,13 @@ var (
 )
 
 type getSavingGoalsRequest struct {
-	startingTime   time.Time
-	err            error
-	savingGoalRepo savingoal.Repository
-	savingsRepo    savings.Repository
-	queryParams    *models.QueryParameters
+	startingTime     time.Time
+	err              error
+	savingGoalRepo   savingoal.Repository
+	savingsRepo      savings.Repository
+	userRepo         users.Repository
+	exchangeRateRepo exchangerate.Repository
+	queryParams      *models.QueryParameters
 }
 
 type SavingGoalsResponse struct {
@@ -38,12 +42,22 @@ func (request *getSavingGoalsRequest) init(ctx context.Context, envConfig *model
 	getSavingGoalsOnce.Do(func() {
 		request.startingTime = time.Now()
 		dynamoClient := dynamo.InitClient(ctx)
-		request.savingGoalRepo, err = savingoal.NewDynamoRepository(dynamoClient, envConfig)
+		request.savingGoalRepo, err = savingoal.NewRepository(ctx, dynamoClient, envConfig)
 		if err != nil {
 			return
 		}
 
-		request.savingsRepo, err = savings.NewDynamoRepository(dynamoClient, envConfig)
+		request.savingsRepo, err = savings.NewRepository(ctx, dynamoClient, envConfig)
+		if err != nil {
+			return
+		}
+
+		request.userRepo, err = users.NewRepository(ctx, dynamoClient, envConfig)
+		if err != nil {
+			return
+		}
+
+		request.exchangeRateRepo, err = exchangerate.NewRepository(ctx, dynamoClient, envConfig)
 		if err != nil {
 			return
 		}
@@ -113,7 +127,7 @@ func (request *getSavingGoalsRequest) process(ctx context.Context, req *apigatew
 		return req.NewErrorResponse(err), nil
 	}
 
-	getSavingGoals := usecases.NewSavingGoalsGetter(request.savingGoalRepo, request.savingsRepo)
+	getSavingGoals := usecases.NewSavingGoalsGetter(request.savingGoalRepo, request.savingsRepo, request.userRepo, request.exchangeRateRepo)
 
 	savingGoals, nextKey, err := getSavingGoals(ctx, username, request.queryParams)
 	if err != nil {
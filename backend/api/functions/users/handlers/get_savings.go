@@ -40,16 +40,16 @@ func (request *getSavingsRequest) init(ctx context.Context, envConfig *models.En
 	gssOnce.Do(func() {
 		dynamoClient := dynamo.InitClient(ctx)
 
-		request.savingsRepo, err = savings.NewDynamoRepository(dynamoClient, envConfig)
+		request.savingsRepo, err = savings.NewRepository(ctx, dynamoClient, envConfig)
 		if err != nil {
 			return
 		}
-		request.savingGoalRepo, err = savingoal.NewDynamoRepository(dynamoClient, envConfig)
+		request.savingGoalRepo, err = savingoal.NewRepository(ctx, dynamoClient, envConfig)
 		if err != nil {
 			return
 		}
 
-		request.periodRepo, err = period.NewDynamoRepository(dynamoClient, envConfig)
+		request.periodRepo, err = period.NewRepository(ctx, dynamoClient, envConfig)
 		if err != nil {
 			return
 		}
@@ -177,7 +177,7 @@ func (request *getSavingsRequest) getUserSavingsByPeriod(ctx context.Context, re
 func (request *getSavingsRequest) getUserSavingsBySavingGoal(ctx context.Context, req *apigateway.Request) (*apigateway.Response, error) {
 	getSavingsBySavingGoal := usecases.NewSavingBySavingGoalGetter(request.savingsRepo, request.savingGoalRepo, request.periodRepo)
 
-	userSavings, nextKey, err := getSavingsBySavingGoal(ctx, request.QueryParameters)
+	userSavings, nextKey, err := getSavingsBySavingGoal(ctx, request.username, request.QueryParameters)
 	if err != nil {
 		logger.Error("savings_fetch_failed", err, req)
 
@@ -197,7 +197,7 @@ func (request *getSavingsRequest) getUserSavingsBySavingGoal(ctx context.Context
 func (request *getSavingsRequest) getUserSavingsByPeriodAndSavingGoal(ctx context.Context, req *apigateway.Request) (*apigateway.Response, error) {
 	getSavingsBySavingGoalAndPeriod := usecases.NewSavingBySavingGoalAndPeriodGetter(request.savingsRepo, request.savingGoalRepo, request.periodRepo)
 
-	userSavings, nextKey, err := getSavingsBySavingGoalAndPeriod(ctx, request.QueryParameters)
+	userSavings, nextKey, err := getSavingsBySavingGoalAndPeriod(ctx, request.username, request.QueryParameters)
 	if err != nil {
 		logger.Error("savings_fetch_failed", err, req)
 
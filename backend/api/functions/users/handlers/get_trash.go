@@ -0,0 +1,103 @@
+package handlers
+
+import (
+	"context"
+	"github.com/JoelD7/money/backend/models"
+	"github.com/JoelD7/money/backend/shared/apigateway"
+	"github.com/JoelD7/money/backend/shared/logger"
+	"github.com/JoelD7/money/backend/shared/validate"
+	"github.com/JoelD7/money/backend/storage/dynamo"
+	"github.com/JoelD7/money/backend/storage/trash"
+	"github.com/JoelD7/money/backend/usecases"
+	"net/http"
+	"sync"
+	"time"
+)
+
+var (
+	gtRequest *getTrashRequest
+	gtOnce    sync.Once
+)
+
+type getTrashRequest struct {
+	startingTime time.Time
+	err          error
+	trashRepo    trash.Repository
+}
+
+type trashResponse struct {
+	Items   []*models.TrashItem `json:"items"`
+	NextKey string              `json:"next_key,omitempty"`
+}
+
+func (request *getTrashRequest) init(ctx context.Context, envConfig *models.EnvironmentConfiguration) error {
+	var err error
+	gtOnce.Do(func() {
+		dynamoClient := dynamo.InitClient(ctx)
+
+		request.trashRepo, err = trash.NewRepository(ctx, dynamoClient, envConfig)
+		if err != nil {
+			return
+		}
+
+		logger.SetHandler("get-trash")
+	})
+	request.startingTime = time.Now()
+
+	return err
+}
+
+func (request *getTrashRequest) finish() {
+	logger.LogLambdaTime(request.startingTime, request.err, recover())
+}
+
+func GetTrashHandler(ctx context.Context, envConfig *models.EnvironmentConfiguration, req *apigateway.Request) (*apigateway.Response, error) {
+	if gtRequest == nil {
+		gtRequest = new(getTrashRequest)
+	}
+
+	err := gtRequest.init(ctx, envConfig)
+	if err != nil {
+		logger.Error("get_trash_init_failed", err, req)
+
+		return req.NewErrorResponse(err), nil
+	}
+	defer gtRequest.finish()
+
+	return gtRequest.process(ctx, req)
+}
+
+func (request *getTrashRequest) process(ctx context.Context, req *apigateway.Request) (*apigateway.Response, error) {
+	username, err := apigateway.GetUsernameFromContext(req)
+	if err != nil {
+		logger.Error("get_username_from_context_failed", err, req)
+
+		return req.NewErrorResponse(err), nil
+	}
+
+	params, err := req.GetQueryParameters()
+	if err != nil {
+		logger.Error("get_query_parameters_failed", err, req)
+
+		return req.NewErrorResponse(err), nil
+	}
+
+	err = validate.PageSize(params.PageSize)
+	if err != nil {
+		logger.Error("invalid_page_size", err, req)
+
+		return req.NewErrorResponse(err), nil
+	}
+
+	getTrashItems := usecases.NewTrashItemsGetter(request.trashRepo)
+
+	items, nextKey, err := getTrashItems(ctx, username, params)
+	if err != nil {
+		request.err = err
+		logger.Error("get_trash_items_failed", err, req)
+
+		return req.NewErrorResponse(err), nil
+	}
+
+	return req.NewJSONResponse(http.StatusOK, &trashResponse{items, nextKey}), nil
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/JoelD7/money/backend/shared/apigateway"
 	"github.com/JoelD7/money/backend/shared/logger"
 	"github.com/JoelD7/money/backend/storage/dynamo"
+	"github.com/JoelD7/money/backend/storage/exchangerate"
 	"github.com/JoelD7/money/backend/storage/expenses"
 	"github.com/JoelD7/money/backend/storage/income"
 	"github.com/JoelD7/money/backend/storage/users"
@@ -22,11 +23,12 @@ var (
 )
 
 type getUserRequest struct {
-	startingTime time.Time
-	err          error
-	userRepo     users.Repository
-	incomeRepo   income.Repository
-	expensesRepo expenses.Repository
+	startingTime     time.Time
+	err              error
+	userRepo         users.Repository
+	incomeRepo       income.Repository
+	expensesRepo     expenses.Repository
+	exchangeRateRepo exchangerate.Repository
 }
 
 func (request *getUserRequest) init(ctx context.Context, envConfig *models.EnvironmentConfiguration) error {
@@ -36,17 +38,22 @@ func (request *getUserRequest) init(ctx context.Context, envConfig *models.Envir
 
 		dynamoClient := dynamo.InitClient(ctx)
 
-		request.userRepo, err = users.NewDynamoRepository(dynamoClient, envConfig.UsersTable)
+		request.userRepo, err = users.NewRepository(ctx, dynamoClient, envConfig)
 		if err != nil {
 			return
 		}
 
-		request.incomeRepo, err = income.NewDynamoRepository(dynamoClient, envConfig)
+		request.incomeRepo, err = income.NewRepository(ctx, dynamoClient, envConfig)
 		if err != nil {
 			return
 		}
 
-		request.expensesRepo, err = expenses.NewDynamoRepository(dynamoClient, envConfig)
+		request.expensesRepo, err = expenses.NewRepository(ctx, dynamoClient, envConfig)
+		if err != nil {
+			return
+		}
+
+		request.exchangeRateRepo, err = exchangerate.NewRepository(ctx, dynamoClient, envConfig)
 		if err != nil {
 			return
 		}
@@ -84,7 +91,7 @@ func (request *getUserRequest) process(ctx context.Context, req *apigateway.Requ
 		return req.NewErrorResponse(err), nil
 	}
 
-	getUser := usecases.NewUserGetter(request.userRepo, request.incomeRepo, request.expensesRepo)
+	getUser := usecases.NewUserGetter(request.userRepo, request.incomeRepo, request.expensesRepo, request.exchangeRateRepo)
 
 	user, err := getUser(ctx, username)
 	if user != nil && user.CurrentPeriod == "" {
@@ -116,5 +123,5 @@ func (request *getUserRequest) process(ctx context.Context, req *apigateway.Requ
 		return req.NewErrorResponse(err), nil
 	}
 
-	return req.NewJSONResponse(http.StatusOK, user), nil
+	return req.NewJSONResponse(http.StatusOK, user, apigateway.NewETagHeader(user.Version)), nil
 }
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"github.com/JoelD7/money/backend/models"
 	"github.com/JoelD7/money/backend/shared/apigateway"
+	"github.com/JoelD7/money/backend/storage/exchangerate"
 	"github.com/JoelD7/money/backend/storage/expenses"
 	"github.com/JoelD7/money/backend/storage/income"
 	"github.com/JoelD7/money/backend/storage/users"
@@ -22,11 +23,13 @@ func TestHandlerSuccess(t *testing.T) {
 	usersMock := users.NewDynamoMock()
 	expensesMock := expenses.NewDynamoMock()
 	incomeMock := income.NewDynamoMock()
+	exchangeRateMock := exchangerate.NewMock()
 
 	request := &getUserRequest{
-		userRepo:     usersMock,
-		expensesRepo: expensesMock,
-		incomeRepo:   incomeMock,
+		userRepo:         usersMock,
+		expensesRepo:     expensesMock,
+		incomeRepo:       incomeMock,
+		exchangeRateRepo: exchangeRateMock,
 	}
 
 	apigwRequest := &apigateway.Request{
@@ -66,13 +69,15 @@ func TestHandlerFailed(t *testing.T) {
 	usersMock := users.NewDynamoMock()
 	expensesMock := expenses.NewDynamoMock()
 	incomeMock := income.NewDynamoMock()
+	exchangeRateMock := exchangerate.NewMock()
 
 	ctx := context.Background()
 
 	request := &getUserRequest{
-		userRepo:     usersMock,
-		expensesRepo: expensesMock,
-		incomeRepo:   incomeMock,
+		userRepo:         usersMock,
+		expensesRepo:     expensesMock,
+		incomeRepo:       incomeMock,
+		exchangeRateRepo: exchangeRateMock,
 	}
 
 	apigwRequest := &apigateway.Request{
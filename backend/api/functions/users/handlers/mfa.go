@@ -0,0 +1,218 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/JoelD7/money/backend/models"
+	"github.com/JoelD7/money/backend/shared/apigateway"
+	"github.com/JoelD7/money/backend/shared/logger"
+	"github.com/JoelD7/money/backend/storage/cache"
+	"github.com/JoelD7/money/backend/storage/dynamo"
+	"github.com/JoelD7/money/backend/storage/users"
+	"github.com/JoelD7/money/backend/usecases"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+var (
+	mfaReq  *mfaRequest
+	mfaOnce sync.Once
+)
+
+type mfaRequest struct {
+	startingTime  time.Time
+	err           error
+	userRepo      users.Repository
+	loginAttempts cache.LoginAttemptManager
+}
+
+type mfaCodeBody struct {
+	Code string `json:"code"`
+}
+
+type mfaRecoveryCodesResponse struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+func (request *mfaRequest) init(ctx context.Context, envConfig *models.EnvironmentConfiguration) error {
+	var err error
+	mfaOnce.Do(func() {
+		logger.SetHandler("mfa")
+		dynamoClient := dynamo.InitClient(ctx)
+
+		request.userRepo, err = users.NewRepository(ctx, dynamoClient, envConfig)
+		if err != nil {
+			return
+		}
+
+		request.loginAttempts = cache.NewRedisCache()
+	})
+	request.startingTime = time.Now()
+	request.err = nil
+
+	return err
+}
+
+func (request *mfaRequest) finish() {
+	logger.LogLambdaTime(request.startingTime, request.err, recover())
+}
+
+func initMFARequest(ctx context.Context, envConfig *models.EnvironmentConfiguration) error {
+	if mfaReq == nil {
+		mfaReq = new(mfaRequest)
+	}
+
+	return mfaReq.init(ctx, envConfig)
+}
+
+// EnrollMFAHandler starts the enrollment of a TOTP authenticator, returning the secret and the provisioning URI for the
+// authenticator app.
+func EnrollMFAHandler(ctx context.Context, envConfig *models.EnvironmentConfiguration, req *apigateway.Request) (*apigateway.Response, error) {
+	err := initMFARequest(ctx, envConfig)
+	if err != nil {
+		logger.Error("enroll_mfa_init_failed", err, req)
+
+		return req.NewErrorResponse(err), nil
+	}
+
+	defer mfaReq.finish()
+
+	return mfaReq.processEnroll(ctx, req)
+}
+
+// ConfirmMFAHandler enables MFA with the first code of the authenticator, returning the recovery codes of the user.
+func ConfirmMFAHandler(ctx context.Context, envConfig *models.EnvironmentConfiguration, req *apigateway.Request) (*apigateway.Response, error) {
+	err := initMFARequest(ctx, envConfig)
+	if err != nil {
+		logger.Error("confirm_mfa_init_failed", err, req)
+
+		return req.NewErrorResponse(err), nil
+	}
+
+	defer mfaReq.finish()
+
+	return mfaReq.processConfirm(ctx, req)
+}
+
+// DisableMFAHandler disables MFA with a TOTP or recovery code.
+func DisableMFAHandler(ctx context.Context, envConfig *models.EnvironmentConfiguration, req *apigateway.Request) (*apigateway.Response, error) {
+	err := initMFARequest(ctx, envConfig)
+	if err != nil {
+		logger.Error("disable_mfa_init_failed", err, req)
+
+		return req.NewErrorResponse(err), nil
+	}
+
+	defer mfaReq.finish()
+
+	return mfaReq.processDisable(ctx, req)
+}
+
+func (request *mfaRequest) processEnroll(ctx context.Context, req *apigateway.Request) (*apigateway.Response, error) {
+	username, err := apigateway.GetUsernameFromContext(req)
+	if err != nil {
+		request.err = err
+		logger.Error("get_username_from_context_failed", err, req)
+
+		return req.NewErrorResponse(err), nil
+	}
+
+	enrollMFA := usecases.NewMFAEnroller(request.userRepo)
+
+	enrollment, err := enrollMFA(ctx, username)
+	if err != nil {
+		request.err = err
+		logger.Error("enroll_mfa_failed", err, req)
+
+		return req.NewErrorResponse(err), nil
+	}
+
+	return req.NewJSONResponse(http.StatusOK, enrollment), nil
+}
+
+func (request *mfaRequest) processConfirm(ctx context.Context, req *apigateway.Request) (*apigateway.Response, error) {
+	code, err := validateMFACodeBody(req)
+	if err != nil {
+		request.err = err
+		logger.Error("validate_request_body_failed", err, req)
+
+		return req.NewErrorResponse(err), nil
+	}
+
+	username, err := apigateway.GetUsernameFromContext(req)
+	if err != nil {
+		request.err = err
+		logger.Error("get_username_from_context_failed", err, req)
+
+		return req.NewErrorResponse(err), nil
+	}
+
+	confirmMFA := usecases.NewMFAEnrollmentConfirmer(request.userRepo)
+
+	recoveryCodes, err := confirmMFA(ctx, username, code)
+	if err != nil {
+		request.err = err
+		logger.Error("confirm_mfa_failed", err, req)
+
+		return req.NewErrorResponse(err), nil
+	}
+
+	return req.NewJSONResponse(http.StatusOK, &mfaRecoveryCodesResponse{recoveryCodes}), nil
+}
+
+func (request *mfaRequest) processDisable(ctx context.Context, req *apigateway.Request) (*apigateway.Response, error) {
+	code, err := validateMFACodeBody(req)
+	if err != nil {
+		request.err = err
+		logger.Error("validate_request_body_failed", err, req)
+
+		return req.NewErrorResponse(err), nil
+	}
+
+	username, err := apigateway.GetUsernameFromContext(req)
+	if err != nil {
+		request.err = err
+		logger.Error("get_username_from_context_failed", err, req)
+
+		return req.NewErrorResponse(err), nil
+	}
+
+	disableMFA := usecases.NewMFADisabler(request.userRepo, request.loginAttempts)
+
+	err = disableMFA(ctx, username, code)
+	if err != nil {
+		request.err = err
+		logger.Error("disable_mfa_failed", err, req)
+
+		response := req.NewErrorResponse(err)
+
+		// Tells the client when it can try again, as the blocked logins do.
+		var blockedErr *models.LoginBlockedError
+		if errors.As(err, &blockedErr) {
+			response.Headers["Retry-After"] = strconv.FormatInt(blockedErr.RetryAfter(), 10)
+		}
+
+		return response, nil
+	}
+
+	return req.NewJSONResponse(http.StatusNoContent, nil), nil
+}
+
+func validateMFACodeBody(req *apigateway.Request) (string, error) {
+	body := new(mfaCodeBody)
+
+	err := json.Unmarshal([]byte(req.Body), body)
+	if err != nil {
+		return "", fmt.Errorf("%v: %w", err, models.ErrInvalidRequestBody)
+	}
+
+	if body.Code == "" {
+		return "", models.ErrMissingMFACode
+	}
+
+	return body.Code, nil
+}
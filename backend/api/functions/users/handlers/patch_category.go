@@ -0,0 +1,110 @@
+package handlers
+
+import (
+	"context"
+	"github.com/JoelD7/money/backend/models"
+	"github.com/JoelD7/money/backend/shared/apigateway"
+	"github.com/JoelD7/money/backend/shared/logger"
+	"github.com/JoelD7/money/backend/storage/dynamo"
+	"github.com/JoelD7/money/backend/storage/users"
+	"github.com/JoelD7/money/backend/usecases"
+	"net/http"
+	"sync"
+	"time"
+)
+
+var (
+	pcRequest *patchCategoryRequest
+	pcOnce    sync.Once
+)
+
+type patchCategoryRequest struct {
+	startingTime time.Time
+	err          error
+	userRepo     users.Repository
+}
+
+func (request *patchCategoryRequest) init(ctx context.Context, envConfig *models.EnvironmentConfiguration) error {
+	var err error
+	pcOnce.Do(func() {
+		logger.SetHandler("patch-category")
+		dynamoClient := dynamo.InitClient(ctx)
+
+		request.userRepo, err = users.NewRepository(ctx, dynamoClient, envConfig)
+		if err != nil {
+			return
+		}
+	})
+	request.startingTime = time.Now()
+
+	return err
+}
+
+func (request *patchCategoryRequest) finish() {
+	logger.LogLambdaTime(request.startingTime, request.err, recover())
+}
+
+// PatchCategoryHandler applies the JSON merge patch of the request body to a category of the user.
+func PatchCategoryHandler(ctx context.Context, envConfig *models.EnvironmentConfiguration, req *apigateway.Request) (*apigateway.Response, error) {
+	if pcRequest == nil {
+		pcRequest = new(patchCategoryRequest)
+	}
+
+	err := pcRequest.init(ctx, envConfig)
+	if err != nil {
+		pcRequest.err = err
+
+		logger.Error("patch_category_init_failed", err, req)
+
+		return req.NewErrorResponse(err), nil
+	}
+	defer pcRequest.finish()
+
+	return pcRequest.process(ctx, req)
+}
+
+func (request *patchCategoryRequest) process(ctx context.Context, req *apigateway.Request) (*apigateway.Response, error) {
+	categoryID, ok := req.PathParameters["categoryID"]
+	if !ok {
+		request.err = errNoCategoryIDInPath
+		logger.Error("get_category_id_from_path_failed", errNoCategoryIDInPath, req)
+
+		return req.NewErrorResponse(errNoCategoryIDInPath), nil
+	}
+
+	username, err := apigateway.GetUsernameFromContext(req)
+	if err != nil {
+		request.err = err
+		logger.Error("get_user_email_from_context_failed", err, req)
+
+		return req.NewErrorResponse(err), nil
+	}
+
+	version, err := req.GetIfMatchVersion()
+	if err != nil {
+		logger.Error("invalid_if_match", err, req)
+
+		return req.NewErrorResponse(err), nil
+	}
+
+	patchCategory := usecases.NewCategoryPatcher(request.userRepo)
+
+	user, err := patchCategory(ctx, username, categoryID, version, []byte(req.Body))
+	if err != nil {
+		request.err = err
+		logger.Error("patch_category_failed", err, req)
+
+		return req.NewErrorResponse(err), nil
+	}
+
+	var patchedCategory *models.Category
+
+	for _, category := range user.Categories {
+		if category.ID == categoryID {
+			patchedCategory = category
+			break
+		}
+	}
+
+	return req.NewJSONResponse(http.StatusOK, patchedCategory, apigateway.NewETagHeader(user.Version)), nil
+}
@@ -0,0 +1,97 @@
+package handlers
+
+import (
+	"context"
+	"github.com/JoelD7/money/backend/models"
+	"github.com/JoelD7/money/backend/shared/apigateway"
+	"github.com/JoelD7/money/backend/shared/logger"
+	"github.com/JoelD7/money/backend/storage/dynamo"
+	"github.com/JoelD7/money/backend/storage/period"
+	"github.com/JoelD7/money/backend/usecases"
+	"net/http"
+	"sync"
+	"time"
+)
+
+var ppRequest *patchPeriodRequest
+var ppOnce sync.Once
+
+type patchPeriodRequest struct {
+	startingTime time.Time
+	err          error
+	periodRepo   period.Repository
+}
+
+func (request *patchPeriodRequest) init(ctx context.Context, envConfig *models.EnvironmentConfiguration) error {
+	var err error
+	ppOnce.Do(func() {
+		dynamoClient := dynamo.InitClient(ctx)
+
+		request.periodRepo, err = period.NewRepository(ctx, dynamoClient, envConfig)
+		if err != nil {
+			return
+		}
+		logger.SetHandler("patch-period")
+	})
+	request.startingTime = time.Now()
+
+	return err
+}
+
+func (request *patchPeriodRequest) finish() {
+	logger.LogLambdaTime(request.startingTime, request.err, recover())
+}
+
+// PatchPeriodHandler applies the JSON merge patch of the request body to a period.
+func PatchPeriodHandler(ctx context.Context, envConfig *models.EnvironmentConfiguration, req *apigateway.Request) (*apigateway.Response, error) {
+	if ppRequest == nil {
+		ppRequest = new(patchPeriodRequest)
+	}
+
+	err := ppRequest.init(ctx, envConfig)
+	if err != nil {
+		logger.Error("patch_period_init_failed", err, req)
+
+		return req.NewErrorResponse(err), nil
+	}
+	defer ppRequest.finish()
+
+	return ppRequest.process(ctx, req)
+}
+
+func (request *patchPeriodRequest) process(ctx context.Context, req *apigateway.Request) (*apigateway.Response, error) {
+	periodID, ok := req.PathParameters["periodID"]
+	if !ok || periodID == "" {
+		request.err = models.ErrMissingPeriodID
+		logger.Error("missing_period_id", nil, req)
+
+		return req.NewErrorResponse(models.ErrMissingPeriodID), nil
+	}
+
+	username, err := apigateway.GetUsernameFromContext(req)
+	if err != nil {
+		request.err = err
+		logger.Error("get_username_from_context_failed", err, req)
+
+		return req.NewErrorResponse(err), nil
+	}
+
+	version, err := req.GetIfMatchVersion()
+	if err != nil {
+		logger.Error("invalid_if_match", err, req)
+
+		return req.NewErrorResponse(err), nil
+	}
+
+	patchPeriod := usecases.NewPeriodPatcher(request.periodRepo)
+
+	patchedPeriod, err := patchPeriod(ctx, username, periodID, version, []byte(req.Body))
+	if err != nil {
+		request.err = err
+		logger.Error("patch_period_failed", err, req)
+
+		return req.NewErrorResponse(err), nil
+	}
+
+	return req.NewJSONResponse(http.StatusOK, patchedPeriod, apigateway.NewETagHeader(patchedPeriod.Version)), nil
+}
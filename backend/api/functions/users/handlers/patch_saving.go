@@ -0,0 +1,119 @@
+package handlers
+
+import (
+	"context"
+	"github.com/JoelD7/money/backend/models"
+	"github.com/JoelD7/money/backend/shared/apigateway"
+	"github.com/JoelD7/money/backend/shared/logger"
+	"github.com/JoelD7/money/backend/storage/dynamo"
+	"github.com/JoelD7/money/backend/storage/period"
+	"github.com/JoelD7/money/backend/storage/savingoal"
+	"github.com/JoelD7/money/backend/storage/savings"
+	"github.com/JoelD7/money/backend/storage/users"
+	"github.com/JoelD7/money/backend/usecases"
+	"net/http"
+	"sync"
+	"time"
+)
+
+var psRequest *patchSavingRequest
+var psOnce sync.Once
+
+type patchSavingRequest struct {
+	startingTime   time.Time
+	err            error
+	savingsRepo    savings.Repository
+	savingGoalRepo savingoal.Repository
+	periodRepo     period.Repository
+	userRepo       users.Repository
+}
+
+func (request *patchSavingRequest) init(ctx context.Context, envConfig *models.EnvironmentConfiguration) error {
+	var err error
+	psOnce.Do(func() {
+		dynamoClient := dynamo.InitClient(ctx)
+
+		request.savingsRepo, err = savings.NewRepository(ctx, dynamoClient, envConfig)
+		if err != nil {
+			return
+		}
+
+		request.periodRepo, err = period.NewRepository(ctx, dynamoClient, envConfig)
+		if err != nil {
+			return
+		}
+
+		request.savingGoalRepo, err = savingoal.NewRepository(ctx, dynamoClient, envConfig)
+		if err != nil {
+			return
+		}
+
+		request.userRepo, err = users.NewRepository(ctx, dynamoClient, envConfig)
+		if err != nil {
+			return
+		}
+
+		logger.SetHandler("patch-saving")
+	})
+	request.startingTime = time.Now()
+
+	return err
+}
+
+func (request *patchSavingRequest) finish() {
+	logger.LogLambdaTime(request.startingTime, request.err, recover())
+}
+
+// PatchSavingHandler applies the JSON merge patch of the request body to a saving.
+func PatchSavingHandler(ctx context.Context, envConfig *models.EnvironmentConfiguration, req *apigateway.Request) (*apigateway.Response, error) {
+	if psRequest == nil {
+		psRequest = new(patchSavingRequest)
+	}
+
+	err := psRequest.init(ctx, envConfig)
+	if err != nil {
+		logger.Error("patch_saving_init_failed", err, req)
+
+		return req.NewErrorResponse(err), nil
+	}
+	defer psRequest.finish()
+
+	return psRequest.process(ctx, req)
+}
+
+func (request *patchSavingRequest) process(ctx context.Context, req *apigateway.Request) (*apigateway.Response, error) {
+	savingID, ok := req.PathParameters["savingID"]
+	if !ok || savingID == "" {
+		request.err = models.ErrMissingSavingID
+		logger.Error("missing_saving_id", nil, req)
+
+		return req.NewErrorResponse(models.ErrMissingSavingID), nil
+	}
+
+	username, err := apigateway.GetUsernameFromContext(req)
+	if err != nil {
+		request.err = err
+		logger.Error("get_username_from_context_failed", err, req)
+
+		return req.NewErrorResponse(err), nil
+	}
+
+	version, err := req.GetIfMatchVersion()
+	if err != nil {
+		logger.Error("invalid_if_match", err, req)
+
+		return req.NewErrorResponse(err), nil
+	}
+
+	patchSaving := usecases.NewSavingPatcher(request.savingsRepo, request.periodRepo, request.savingGoalRepo, request.userRepo)
+
+	saving, err := patchSaving(ctx, username, savingID, version, []byte(req.Body))
+	if err != nil {
+		request.err = err
+		logger.Error("patch_saving_failed", err, req)
+
+		return req.NewErrorResponse(err), nil
+	}
+
+	return req.NewJSONResponse(http.StatusOK, saving, apigateway.NewETagHeader(saving.Version)), nil
+}
@@ -0,0 +1,106 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"github.com/JoelD7/money/backend/models"
+	"github.com/JoelD7/money/backend/shared/apigateway"
+	"github.com/JoelD7/money/backend/shared/logger"
+	"github.com/JoelD7/money/backend/storage/dynamo"
+	"github.com/JoelD7/money/backend/storage/savingoal"
+	"github.com/JoelD7/money/backend/storage/users"
+	"github.com/JoelD7/money/backend/usecases"
+	"net/http"
+	"sync"
+	"time"
+)
+
+var (
+	patchSavingGoalReq  *patchSavingGoalRequest
+	patchSavingGoalOnce sync.Once
+)
+
+type patchSavingGoalRequest struct {
+	startingTime   time.Time
+	err            error
+	savingGoalRepo savingoal.Repository
+	userRepo       users.Repository
+}
+
+func (request *patchSavingGoalRequest) init(ctx context.Context, envConfig *models.EnvironmentConfiguration) error {
+	var err error
+	patchSavingGoalOnce.Do(func() {
+		logger.SetHandler("patch-saving-goal")
+		dynamoClient := dynamo.InitClient(ctx)
+
+		request.savingGoalRepo, err = savingoal.NewRepository(ctx, dynamoClient, envConfig)
+		if err != nil {
+			return
+		}
+
+		request.userRepo, err = users.NewRepository(ctx, dynamoClient, envConfig)
+		if err != nil {
+			return
+		}
+	})
+	request.startingTime = time.Now()
+
+	return err
+}
+
+func (request *patchSavingGoalRequest) finish() {
+	logger.LogLambdaTime(request.startingTime, request.err, recover())
+}
+
+// PatchSavingGoalHandler applies the JSON merge patch of the request body to a saving goal.
+func PatchSavingGoalHandler(ctx context.Context, envConfig *models.EnvironmentConfiguration, req *apigateway.Request) (*apigateway.Response, error) {
+	if patchSavingGoalReq == nil {
+		patchSavingGoalReq = new(patchSavingGoalRequest)
+	}
+
+	err := patchSavingGoalReq.init(ctx, envConfig)
+	if err != nil {
+		logger.Error("patch_saving_goal_init_failed", err, req)
+
+		return req.NewErrorResponse(err), nil
+	}
+	defer patchSavingGoalReq.finish()
+
+	return patchSavingGoalReq.process(ctx, req)
+}
+
+func (request *patchSavingGoalRequest) process(ctx context.Context, req *apigateway.Request) (*apigateway.Response, error) {
+	username, err := apigateway.GetUsernameFromContext(req)
+	if err != nil {
+		logger.Error("get_username_from_context_failed", err, req)
+
+		return req.NewErrorResponse(err), nil
+	}
+
+	savingGoalID, ok := req.PathParameters["savingGoalID"]
+	if !ok || savingGoalID == "" {
+		err = fmt.Errorf("missing saving goal ID")
+		logger.Error("missing_saving_goal_id", err, req)
+
+		return req.NewErrorResponse(err), nil
+	}
+
+	version, err := req.GetIfMatchVersion()
+	if err != nil {
+		logger.Error("invalid_if_match", err, req)
+
+		return req.NewErrorResponse(err), nil
+	}
+
+	patchSavingGoal := usecases.NewSavingGoalPatcher(request.savingGoalRepo, request.userRepo)
+
+	patchedGoal, err := patchSavingGoal(ctx, username, savingGoalID, version, []byte(req.Body))
+	if err != nil {
+		request.err = err
+		logger.Error("patch_saving_goal_failed", err, req)
+
+		return req.NewErrorResponse(err), nil
+	}
+
+	return req.NewJSONResponse(http.StatusOK, patchedGoal, apigateway.NewETagHeader(patchedGoal.Version)), nil
+}
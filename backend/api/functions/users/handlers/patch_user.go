@@ -0,0 +1,89 @@
+package handlers
+
+import (
+	"context"
+	"github.com/JoelD7/money/backend/models"
+	"github.com/JoelD7/money/backend/shared/apigateway"
+	"github.com/JoelD7/money/backend/shared/logger"
+	"github.com/JoelD7/money/backend/storage/dynamo"
+	"github.com/JoelD7/money/backend/storage/users"
+	"github.com/JoelD7/money/backend/usecases"
+	"net/http"
+	"sync"
+	"time"
+)
+
+var (
+	puRequest *patchUserRequest
+	puOnce    sync.Once
+)
+
+type patchUserRequest struct {
+	startingTime time.Time
+	err          error
+	userRepo     users.Repository
+}
+
+func (request *patchUserRequest) init(ctx context.Context, envConfig *models.EnvironmentConfiguration) error {
+	var err error
+	puOnce.Do(func() {
+		logger.SetHandler("patch-user")
+		dynamoClient := dynamo.InitClient(ctx)
+
+		request.userRepo, err = users.NewRepository(ctx, dynamoClient, envConfig)
+	})
+	request.startingTime = time.Now()
+
+	return err
+}
+
+func (request *patchUserRequest) finish() {
+	logger.LogLambdaTime(request.startingTime, request.err, recover())
+}
+
+// PatchUserHandler applies the JSON merge patch of the request body to the profile of the user.
+func PatchUserHandler(ctx context.Context, envConfig *models.EnvironmentConfiguration, req *apigateway.Request) (*apigateway.Response, error) {
+	if puRequest == nil {
+		puRequest = new(patchUserRequest)
+	}
+
+	err := puRequest.init(ctx, envConfig)
+	if err != nil {
+		logger.Error("patch_user_init_failed", err, req)
+
+		return req.NewErrorResponse(err), nil
+	}
+
+	defer puRequest.finish()
+
+	return puRequest.process(ctx, req)
+}
+
+func (request *patchUserRequest) process(ctx context.Context, req *apigateway.Request) (*apigateway.Response, error) {
+	username, err := apigateway.GetUsernameFromContext(req)
+	if err != nil {
+		request.err = err
+		logger.Error("get_username_from_context_failed", err, req)
+
+		return req.NewErrorResponse(err), nil
+	}
+
+	version, err := req.GetIfMatchVersion()
+	if err != nil {
+		logger.Error("invalid_if_match", err, req)
+
+		return req.NewErrorResponse(err), nil
+	}
+
+	patchUser := usecases.NewUserPatcher(request.userRepo)
+
+	user, err := patchUser(ctx, username, version, []byte(req.Body))
+	if err != nil {
+		request.err = err
+		logger.Error("patch_user_failed", err, req)
+
+		return req.NewErrorResponse(err), nil
+	}
+
+	return req.NewJSONResponse(http.StatusOK, user, apigateway.NewETagHeader(user.Version)), nil
+}
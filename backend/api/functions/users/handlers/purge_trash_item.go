@@ -0,0 +1,85 @@
+package handlers
+
+import (
+	"context"
+	"github.com/JoelD7/money/backend/models"
+	"github.com/JoelD7/money/backend/shared/apigateway"
+	"github.com/JoelD7/money/backend/shared/logger"
+	"github.com/JoelD7/money/backend/storage/dynamo"
+	"github.com/JoelD7/money/backend/storage/trash"
+	"github.com/JoelD7/money/backend/usecases"
+	"net/http"
+	"sync"
+	"time"
+)
+
+var (
+	ptiRequest *purgeTrashItemRequest
+	ptiOnce    sync.Once
+)
+
+type purgeTrashItemRequest struct {
+	startingTime time.Time
+	err          error
+	trashRepo    trash.Repository
+}
+
+func (request *purgeTrashItemRequest) init(ctx context.Context, envConfig *models.EnvironmentConfiguration) error {
+	var err error
+	ptiOnce.Do(func() {
+		dynamoClient := dynamo.InitClient(ctx)
+
+		request.trashRepo, err = trash.NewRepository(ctx, dynamoClient, envConfig)
+		if err != nil {
+			return
+		}
+
+		logger.SetHandler("purge-trash-item")
+	})
+	request.startingTime = time.Now()
+
+	return err
+}
+
+func (request *purgeTrashItemRequest) finish() {
+	logger.LogLambdaTime(request.startingTime, request.err, recover())
+}
+
+func PurgeTrashItemHandler(ctx context.Context, envConfig *models.EnvironmentConfiguration, req *apigateway.Request) (*apigateway.Response, error) {
+	if ptiRequest == nil {
+		ptiRequest = new(purgeTrashItemRequest)
+	}
+
+	err := ptiRequest.init(ctx, envConfig)
+	if err != nil {
+		logger.Error("purge_trash_item_init_failed", err, req)
+
+		return req.NewErrorResponse(err), nil
+	}
+	defer ptiRequest.finish()
+
+	return ptiRequest.process(ctx, req)
+}
+
+func (request *purgeTrashItemRequest) process(ctx context.Context, req *apigateway.Request) (*apigateway.Response, error) {
+	itemID := req.PathParameters["itemID"]
+
+	username, err := apigateway.GetUsernameFromContext(req)
+	if err != nil {
+		logger.Error("get_username_from_context_failed", err, req)
+
+		return req.NewErrorResponse(err), nil
+	}
+
+	purgeTrashItem := usecases.NewTrashItemPurger(request.trashRepo)
+
+	err = purgeTrashItem(ctx, username, itemID)
+	if err != nil {
+		request.err = err
+		logger.Error("purge_trash_item_failed", err, req)
+
+		return req.NewErrorResponse(err), nil
+	}
+
+	return req.NewJSONResponse(http.StatusNoContent, nil), nil
+}
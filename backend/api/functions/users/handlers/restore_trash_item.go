@@ -0,0 +1,132 @@
+package handlers
+
+import (
+	"context"
+	"github.com/JoelD7/money/backend/models"
+	"github.com/JoelD7/money/backend/shared/apigateway"
+	"github.com/JoelD7/money/backend/shared/logger"
+	"github.com/JoelD7/money/backend/storage/cache"
+	"github.com/JoelD7/money/backend/storage/dynamo"
+	"github.com/JoelD7/money/backend/storage/expenses"
+	"github.com/JoelD7/money/backend/storage/income"
+	"github.com/JoelD7/money/backend/storage/period"
+	"github.com/JoelD7/money/backend/storage/savingoal"
+	"github.com/JoelD7/money/backend/storage/savings"
+	"github.com/JoelD7/money/backend/storage/trash"
+	"github.com/JoelD7/money/backend/storage/users"
+	"github.com/JoelD7/money/backend/usecases"
+	"net/http"
+	"sync"
+	"time"
+)
+
+var (
+	rtiRequest *restoreTrashItemRequest
+	rtiOnce    sync.Once
+)
+
+type restoreTrashItemRequest struct {
+	startingTime   time.Time
+	err            error
+	trashRepo      trash.Repository
+	userRepo       users.Repository
+	periodRepo     period.Repository
+	expensesRepo   expenses.Repository
+	incomeRepo     income.Repository
+	savingsRepo    savings.Repository
+	savingGoalRepo savingoal.Repository
+	cacheManager   cache.IncomePeriodCacheManager
+}
+
+func (request *restoreTrashItemRequest) init(ctx context.Context, envConfig *models.EnvironmentConfiguration) error {
+	var err error
+	rtiOnce.Do(func() {
+		dynamoClient := dynamo.InitClient(ctx)
+
+		request.trashRepo, err = trash.NewRepository(ctx, dynamoClient, envConfig)
+		if err != nil {
+			return
+		}
+
+		request.userRepo, err = users.NewRepository(ctx, dynamoClient, envConfig)
+		if err != nil {
+			return
+		}
+
+		request.periodRepo, err = period.NewRepository(ctx, dynamoClient, envConfig)
+		if err != nil {
+			return
+		}
+
+		request.expensesRepo, err = expenses.NewRepository(ctx, dynamoClient, envConfig)
+		if err != nil {
+			return
+		}
+
+		request.incomeRepo, err = income.NewRepository(ctx, dynamoClient, envConfig)
+		if err != nil {
+			return
+		}
+
+		request.savingsRepo, err = savings.NewRepository(ctx, dynamoClient, envConfig)
+		if err != nil {
+			return
+		}
+
+		request.savingGoalRepo, err = savingoal.NewRepository(ctx, dynamoClient, envConfig)
+		if err != nil {
+			return
+		}
+
+		request.cacheManager = cache.NewRedisCache()
+
+		logger.SetHandler("restore-trash-item")
+	})
+	request.startingTime = time.Now()
+
+	return err
+}
+
+func (request *restoreTrashItemRequest) finish() {
+	logger.LogLambdaTime(request.startingTime, request.err, recover())
+}
+
+func RestoreTrashItemHandler(ctx context.Context, envConfig *models.EnvironmentConfiguration, req *apigateway.Request) (*apigateway.Response, error) {
+	if rtiRequest == nil {
+		rtiRequest = new(restoreTrashItemRequest)
+	}
+
+	err := rtiRequest.init(ctx, envConfig)
+	if err != nil {
+		logger.Error("restore_trash_item_init_failed", err, req)
+
+		return req.NewErrorResponse(err), nil
+	}
+	defer rtiRequest.finish()
+
+	return rtiRequest.process(ctx, req)
+}
+
+func (request *restoreTrashItemRequest) process(ctx context.Context, req *apigateway.Request) (*apigateway.Response, error) {
+	itemID := req.PathParameters["itemID"]
+
+	username, err := apigateway.GetUsernameFromContext(req)
+	if err != nil {
+		logger.Error("get_username_from_context_failed", err, req)
+
+		return req.NewErrorResponse(err), nil
+	}
+
+	restoreTrashItem := usecases.NewTrashItemRestorer(request.trashRepo, request.userRepo, request.periodRepo,
+		request.expensesRepo, request.incomeRepo, request.savingsRepo, request.savingGoalRepo, request.cacheManager)
+
+	item, err := restoreTrashItem(ctx, username, itemID)
+	if err != nil {
+		request.err = err
+		logger.Error("restore_trash_item_failed", err, req)
+
+		return req.NewErrorResponse(err), nil
+	}
+
+	return req.NewJSONResponse(http.StatusOK, item), nil
+}
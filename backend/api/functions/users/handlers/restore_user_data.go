@@ -0,0 +1,159 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/JoelD7/money/backend/models"
+	"github.com/JoelD7/money/backend/shared/apigateway"
+	"github.com/JoelD7/money/backend/shared/logger"
+	"github.com/JoelD7/money/backend/storage/audit"
+	"github.com/JoelD7/money/backend/storage/cache"
+	"github.com/JoelD7/money/backend/storage/dynamo"
+	"github.com/JoelD7/money/backend/storage/expenses"
+	expensesRecurring "github.com/JoelD7/money/backend/storage/expenses-recurring"
+	"github.com/JoelD7/money/backend/storage/income"
+	"github.com/JoelD7/money/backend/storage/period"
+	"github.com/JoelD7/money/backend/storage/savingoal"
+	"github.com/JoelD7/money/backend/storage/savings"
+	"github.com/JoelD7/money/backend/storage/users"
+	"github.com/JoelD7/money/backend/usecases"
+	"net/http"
+	"sync"
+	"time"
+)
+
+var (
+	rudRequest *restoreUserDataRequest
+	rudOnce    sync.Once
+)
+
+type restoreUserDataRequest struct {
+	startingTime             time.Time
+	err                      error
+	userRepo                 users.Repository
+	periodRepo               period.Repository
+	expensesRepo             expenses.Repository
+	incomeRepo               income.Repository
+	savingsRepo              savings.Repository
+	savingGoalRepo           savingoal.Repository
+	expensesRecurringRepo    expensesRecurring.Repository
+	incomePeriodCacheManager cache.IncomePeriodCacheManager
+	idempotenceCache         cache.IdempotenceCacheManager
+}
+
+func (request *restoreUserDataRequest) init(ctx context.Context, envConfig *models.EnvironmentConfiguration) error {
+	var err error
+	rudOnce.Do(func() {
+		dynamoClient := dynamo.InitClient(ctx)
+
+		request.userRepo, err = users.NewRepository(ctx, dynamoClient, envConfig)
+		if err != nil {
+			return
+		}
+
+		request.periodRepo, err = period.NewRepository(ctx, dynamoClient, envConfig)
+		if err != nil {
+			return
+		}
+
+		request.expensesRepo, err = expenses.NewRepository(ctx, dynamoClient, envConfig)
+		if err != nil {
+			return
+		}
+
+		request.incomeRepo, err = income.NewRepository(ctx, dynamoClient, envConfig)
+		if err != nil {
+			return
+		}
+
+		request.savingsRepo, err = savings.NewRepository(ctx, dynamoClient, envConfig)
+		if err != nil {
+			return
+		}
+
+		request.savingGoalRepo, err = savingoal.NewRepository(ctx, dynamoClient, envConfig)
+		if err != nil {
+			return
+		}
+
+		request.expensesRecurringRepo, err = expensesRecurring.NewRepository(ctx, dynamoClient, envConfig)
+		if err != nil {
+			return
+		}
+
+		redisCache := cache.NewRedisCache()
+		request.incomePeriodCacheManager = redisCache
+		request.idempotenceCache = redisCache
+	})
+	request.startingTime = time.Now()
+
+	return err
+}
+
+func (request *restoreUserDataRequest) finish() {
+	logger.LogLambdaTime(request.startingTime, request.err, recover())
+}
+
+// RestoreUserDataHandler recreates the data of a JSON export, as returned by ExportUserDataHandler, in the account of
+// the user.
+func RestoreUserDataHandler(ctx context.Context, envConfig *models.EnvironmentConfiguration, req *apigateway.Request) (*apigateway.Response, error) {
+	if rudRequest == nil {
+		rudRequest = new(restoreUserDataRequest)
+	}
+
+	err := rudRequest.init(ctx, envConfig)
+	if err != nil {
+		logger.Error("restore_user_data_init_failed", err, req)
+
+		return req.NewErrorResponse(err), nil
+	}
+
+	defer rudRequest.finish()
+
+	return rudRequest.process(ctx, req)
+}
+
+func (request *restoreUserDataRequest) process(ctx context.Context, req *apigateway.Request) (*apigateway.Response, error) {
+	idempotencyKey, err := req.GetIdempotenceyKeyFromHeader()
+	if err != nil {
+		request.err = err
+		logger.Error("http_request_validation_failed", err, req)
+
+		return req.NewErrorResponse(err), nil
+	}
+
+	username, err := apigateway.GetUsernameFromContext(req)
+	if err != nil {
+		request.err = err
+		logger.Error("get_username_from_context_failed", err, req)
+
+		return req.NewErrorResponse(err), nil
+	}
+
+	userData := new(models.Export)
+
+	err = json.Unmarshal([]byte(req.Body), userData)
+	if err != nil {
+		request.err = fmt.Errorf("%v:%w", err, models.ErrInvalidRequestBody)
+		logger.Error("validate_request_body_failed", request.err, req)
+
+		return req.NewErrorResponse(request.err), nil
+	}
+
+	restoreUserData := usecases.NewUserDataRestorer(request.userRepo, request.periodRepo, request.expensesRepo, request.incomeRepo,
+		request.savingsRepo, request.savingGoalRepo, request.expensesRecurringRepo, request.incomePeriodCacheManager,
+		request.idempotenceCache)
+
+	ctx = audit.WithSource(ctx, models.AuditSourceImport, "")
+
+	result, err := restoreUserData(ctx, username, idempotencyKey, userData)
+	if err != nil {
+		request.err = err
+		logger.Error("restore_user_data_failed", err, req)
+
+		return req.NewErrorResponse(err), nil
+	}
+
+	return req.NewJSONResponse(http.StatusCreated, result), nil
+}
@@ -0,0 +1,108 @@
+package handlers
+
+import (
+	"github.com/JoelD7/money/backend/models"
+	"github.com/JoelD7/money/backend/shared/router"
+)
+
+// NewRouter returns the router with all the endpoints of the users API.
+func NewRouter(envConfig *models.EnvironmentConfiguration) *router.Router {
+	rootRouter := router.NewRouter(envConfig)
+
+	rootRouter.Route("/", func(r *router.Router) {
+		r.Route("/users", func(r *router.Router) {
+			r.Get("/", GetUserHandler)
+			r.Patch("/", PatchUserHandler)
+			r.Get("/export", ExportUserDataHandler)
+			r.Post("/restore", RestoreUserDataHandler)
+			r.Put("/base-currency", UpdateBaseCurrencyHandler)
+			r.Get("/activity", GetActivityHandler)
+
+			r.Route("/mfa", func(r *router.Router) {
+				r.Post("/", EnrollMFAHandler)
+				r.Post("/confirm", ConfirmMFAHandler)
+				r.Post("/disable", DisableMFAHandler)
+			})
+
+			r.Route("/sessions", func(r *router.Router) {
+				r.Get("/", GetSessionsHandler)
+				r.Delete("/", DeleteSessionsHandler)
+				r.Delete("/{sessionID}", DeleteSessionHandler)
+			})
+
+			r.Route("/{username}", func(r *router.Router) {
+				r.Delete("/", DeleteUserHandler)
+				r.Get("/deletion", GetAccountDeletionHandler)
+			})
+
+			r.Route("/categories", func(r *router.Router) {
+				r.Get("/", GetCategoriesHandler)
+				r.Post("/", CreateCategoryHandler)
+				r.Put("/{categoryID}", UpdateCategoryHandler)
+				r.Patch("/{categoryID}", PatchCategoryHandler)
+
+				r.Route("/{categoryID}", func(r *router.Router) {
+					r.Get("/history", GetCategoryHistoryHandler)
+				})
+			})
+		})
+
+		r.Route("/savings", func(r *router.Router) {
+			r.Get("/{savingID}", GetSavingHandler)
+			r.Get("/", GetSavingsHandler)
+			r.Post("/", CreateSavingHandler)
+			r.Put("/{savingID}", UpdateSavingHandler)
+			r.Patch("/{savingID}", PatchSavingHandler)
+			r.Delete("/{savingID}", DeleteSavingHandler)
+
+			r.Route("/{savingID}", func(r *router.Router) {
+				r.Get("/history", GetSavingHistoryHandler)
+			})
+
+			r.Route("/goals", func(r *router.Router) {
+				r.Post("/", CreateSavingGoalHandler)
+				r.Get("/{savingGoalID}", GetSavingGoalHandler)
+				r.Get("/", GetSavingGoalsHandler)
+				r.Put("/{savingGoalID}", UpdateSavingGoalsHandler)
+				r.Patch("/{savingGoalID}", PatchSavingGoalHandler)
+				r.Delete("/{savingGoalID}", DeleteSavingGoalHandler)
+
+				r.Route("/{savingGoalID}", func(r *router.Router) {
+					r.Get("/history", GetSavingGoalHistoryHandler)
+				})
+			})
+		})
+
+		r.Route("/periods", func(r *router.Router) {
+			r.Post("/", CreatePeriodHandler)
+			r.Get("/", GetPeriodsHandler)
+
+			r.Route("/{periodID}", func(r *router.Router) {
+				r.Put("/", UpdatePeriodHandler)
+				r.Patch("/", PatchPeriodHandler)
+				r.Get("/", GetPeriodHandler)
+				r.Delete("/", DeletePeriodHandler)
+
+				r.Get("/stats", GetPeriodStatHandler)
+				r.Get("/history", GetPeriodHistoryHandler)
+
+				r.Route("/rates", func(r *router.Router) {
+					r.Get("/", GetExchangeRatesHandler)
+					r.Put("/{currency}", SetExchangeRateHandler)
+					r.Delete("/{currency}", DeleteExchangeRateHandler)
+				})
+			})
+		})
+
+		r.Route("/trash", func(r *router.Router) {
+			r.Get("/", GetTrashHandler)
+
+			r.Route("/{itemID}", func(r *router.Router) {
+				r.Delete("/", PurgeTrashItemHandler)
+				r.Post("/restore", RestoreTrashItemHandler)
+			})
+		})
+	})
+
+	return rootRouter
+}
@@ -0,0 +1,177 @@
+package handlers
+
+import (
+	"context"
+	"github.com/JoelD7/money/backend/models"
+	"github.com/JoelD7/money/backend/shared/apigateway"
+	"github.com/JoelD7/money/backend/shared/logger"
+	"github.com/JoelD7/money/backend/storage/cache"
+	"github.com/JoelD7/money/backend/storage/dynamo"
+	"github.com/JoelD7/money/backend/storage/sessions"
+	"github.com/JoelD7/money/backend/usecases"
+	"net/http"
+	"sync"
+	"time"
+)
+
+var (
+	sessionsReq  *sessionsRequest
+	sessionsOnce sync.Once
+)
+
+type sessionsRequest struct {
+	startingTime        time.Time
+	err                 error
+	sessionRepo         sessions.Repository
+	invalidTokenManager cache.InvalidTokenManager
+}
+
+type sessionsResponse struct {
+	Sessions []*models.Session `json:"sessions"`
+}
+
+func (request *sessionsRequest) init(ctx context.Context, envConfig *models.EnvironmentConfiguration) error {
+	var err error
+	sessionsOnce.Do(func() {
+		logger.SetHandler("sessions")
+		dynamoClient := dynamo.InitClient(ctx)
+
+		request.sessionRepo, err = sessions.NewRepository(ctx, dynamoClient, envConfig)
+		if err != nil {
+			return
+		}
+
+		request.invalidTokenManager = cache.NewRedisCache()
+	})
+	request.startingTime = time.Now()
+	request.err = nil
+
+	return err
+}
+
+func (request *sessionsRequest) finish() {
+	logger.LogLambdaTime(request.startingTime, request.err, recover())
+}
+
+func initSessionsRequest(ctx context.Context, envConfig *models.EnvironmentConfiguration) error {
+	if sessionsReq == nil {
+		sessionsReq = new(sessionsRequest)
+	}
+
+	return sessionsReq.init(ctx, envConfig)
+}
+
+// GetSessionsHandler returns the active sessions of the user, the most recently used first.
+func GetSessionsHandler(ctx context.Context, envConfig *models.EnvironmentConfiguration, req *apigateway.Request) (*apigateway.Response, error) {
+	err := initSessionsRequest(ctx, envConfig)
+	if err != nil {
+		logger.Error("get_sessions_init_failed", err, req)
+
+		return req.NewErrorResponse(err), nil
+	}
+
+	defer sessionsReq.finish()
+
+	return sessionsReq.processGetSessions(ctx, req)
+}
+
+// DeleteSessionHandler logs the user out of a session.
+func DeleteSessionHandler(ctx context.Context, envConfig *models.EnvironmentConfiguration, req *apigateway.Request) (*apigateway.Response, error) {
+	err := initSessionsRequest(ctx, envConfig)
+	if err != nil {
+		logger.Error("delete_session_init_failed", err, req)
+
+		return req.NewErrorResponse(err), nil
+	}
+
+	defer sessionsReq.finish()
+
+	return sessionsReq.processDeleteSession(ctx, req)
+}
+
+// DeleteSessionsHandler logs the user out of all the sessions, including the one making the request.
+func DeleteSessionsHandler(ctx context.Context, envConfig *models.EnvironmentConfiguration, req *apigateway.Request) (*apigateway.Response, error) {
+	err := initSessionsRequest(ctx, envConfig)
+	if err != nil {
+		logger.Error("delete_sessions_init_failed", err, req)
+
+		return req.NewErrorResponse(err), nil
+	}
+
+	defer sessionsReq.finish()
+
+	return sessionsReq.processDeleteSessions(ctx, req)
+}
+
+func (request *sessionsRequest) processGetSessions(ctx context.Context, req *apigateway.Request) (*apigateway.Response, error) {
+	username, err := apigateway.GetUsernameFromContext(req)
+	if err != nil {
+		request.err = err
+		logger.Error("get_username_from_context_failed", err, req)
+
+		return req.NewErrorResponse(err), nil
+	}
+
+	getSessions := usecases.NewSessionsGetter(request.sessionRepo)
+
+	userSessions, err := getSessions(ctx, username)
+	if err != nil {
+		request.err = err
+		logger.Error("get_sessions_failed", err, req)
+
+		return req.NewErrorResponse(err), nil
+	}
+
+	return req.NewJSONResponse(http.StatusOK, &sessionsResponse{userSessions}), nil
+}
+
+func (request *sessionsRequest) processDeleteSession(ctx context.Context, req *apigateway.Request) (*apigateway.Response, error) {
+	sessionID, ok := req.PathParameters["sessionID"]
+	if !ok || sessionID == "" {
+		logger.Error("missing_session_id", nil, req)
+
+		return req.NewErrorResponse(models.ErrMissingSessionID), nil
+	}
+
+	username, err := apigateway.GetUsernameFromContext(req)
+	if err != nil {
+		request.err = err
+		logger.Error("get_username_from_context_failed", err, req)
+
+		return req.NewErrorResponse(err), nil
+	}
+
+	revokeSession := usecases.NewSessionRevoker(request.sessionRepo, request.invalidTokenManager)
+
+	err = revokeSession(ctx, username, sessionID)
+	if err != nil {
+		request.err = err
+		logger.Error("delete_session_failed", err, req)
+
+		return req.NewErrorResponse(err), nil
+	}
+
+	return req.NewJSONResponse(http.StatusNoContent, nil), nil
+}
+
+func (request *sessionsRequest) processDeleteSessions(ctx context.Context, req *apigateway.Request) (*apigateway.Response, error) {
+	username, err := apigateway.GetUsernameFromContext(req)
+	if err != nil {
+		request.err = err
+		logger.Error("get_username_from_context_failed", err, req)
+
+		return req.NewErrorResponse(err), nil
+	}
+
+	revokeSessions := usecases.NewAllSessionsRevoker(request.sessionRepo, request.invalidTokenManager)
+
+	err = revokeSessions(ctx, username)
+	if err != nil {
+		request.err = err
+		logger.Error("delete_sessions_failed", err, req)
+
+		return req.NewErrorResponse(err), nil
+	}
+
+	return req.NewJSONResponse(http.StatusNoContent, nil), nil
+}
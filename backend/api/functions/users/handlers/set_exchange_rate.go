@@ -0,0 +1,156 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/JoelD7/money/backend/models"
+	"github.com/JoelD7/money/backend/shared/apigateway"
+	"github.com/JoelD7/money/backend/shared/logger"
+	"github.com/JoelD7/money/backend/shared/validate"
+	"github.com/JoelD7/money/backend/storage/dynamo"
+	"github.com/JoelD7/money/backend/storage/exchangerate"
+	"github.com/JoelD7/money/backend/storage/period"
+	"github.com/JoelD7/money/backend/storage/users"
+	"github.com/JoelD7/money/backend/usecases"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+var (
+	serRequest *setExchangeRateRequest
+	serOnce    sync.Once
+)
+
+type setExchangeRateRequest struct {
+	startingTime     time.Time
+	err              error
+	exchangeRateRepo exchangerate.Repository
+	periodRepo       period.Repository
+	userRepo         users.Repository
+}
+
+func (request *setExchangeRateRequest) init(ctx context.Context, envConfig *models.EnvironmentConfiguration) error {
+	var err error
+	serOnce.Do(func() {
+		logger.SetHandler("set-exchange-rate")
+		dynamoClient := dynamo.InitClient(ctx)
+
+		request.exchangeRateRepo, err = exchangerate.NewRepository(ctx, dynamoClient, envConfig)
+		if err != nil {
+			return
+		}
+
+		request.periodRepo, err = period.NewRepository(ctx, dynamoClient, envConfig)
+		if err != nil {
+			return
+		}
+
+		request.userRepo, err = users.NewRepository(ctx, dynamoClient, envConfig)
+	})
+	request.startingTime = time.Now()
+
+	return err
+}
+
+func (request *setExchangeRateRequest) finish() {
+	logger.LogLambdaTime(request.startingTime, request.err, recover())
+}
+
+// SetExchangeRateHandler sets how much of the user's base currency one unit of a currency is worth during a period.
+func SetExchangeRateHandler(ctx context.Context, envConfig *models.EnvironmentConfiguration, req *apigateway.Request) (*apigateway.Response, error) {
+	if serRequest == nil {
+		serRequest = new(setExchangeRateRequest)
+	}
+
+	err := serRequest.init(ctx, envConfig)
+	if err != nil {
+		logger.Error("set_exchange_rate_init_failed", err, req)
+
+		return req.NewErrorResponse(err), nil
+	}
+
+	defer serRequest.finish()
+
+	return serRequest.process(ctx, req)
+}
+
+func (request *setExchangeRateRequest) process(ctx context.Context, req *apigateway.Request) (*apigateway.Response, error) {
+	exchangeRate, err := validateSetExchangeRateRequest(req)
+	if err != nil {
+		request.err = err
+		logger.Error("validate_request_failed", err, req)
+
+		return req.NewErrorResponse(err), nil
+	}
+
+	username, err := apigateway.GetUsernameFromContext(req)
+	if err != nil {
+		request.err = err
+		logger.Error("get_username_from_context_failed", err, req)
+
+		return req.NewErrorResponse(err), nil
+	}
+
+	setExchangeRate := usecases.NewExchangeRateSetter(request.exchangeRateRepo, request.periodRepo, request.userRepo)
+
+	exchangeRate, err = setExchangeRate(ctx, username, exchangeRate)
+	if err != nil {
+		request.err = err
+		logger.Error("set_exchange_rate_failed", err, req)
+
+		return req.NewErrorResponse(err), nil
+	}
+
+	return req.NewJSONResponse(http.StatusOK, exchangeRate), nil
+}
+
+func validateSetExchangeRateRequest(req *apigateway.Request) (*models.ExchangeRate, error) {
+	periodID, err := getPeriodIDFromPath(req)
+	if err != nil {
+		return nil, err
+	}
+
+	currency, err := getCurrencyFromPath(req)
+	if err != nil {
+		return nil, err
+	}
+
+	exchangeRate := new(models.ExchangeRate)
+
+	err = json.Unmarshal([]byte(req.Body), exchangeRate)
+	if err != nil {
+		return nil, fmt.Errorf("%v: %w", err, models.ErrInvalidRequestBody)
+	}
+
+	exchangeRate.PeriodID = periodID
+	exchangeRate.Currency = currency
+
+	return exchangeRate, nil
+}
+
+func getPeriodIDFromPath(req *apigateway.Request) (string, error) {
+	periodID, ok := req.PathParameters["periodID"]
+	if !ok || periodID == "" {
+		return "", models.ErrMissingPeriodID
+	}
+
+	return periodID, nil
+}
+
+// getCurrencyFromPath returns the currency path parameter in upper case, as ISO-4217 codes are.
+func getCurrencyFromPath(req *apigateway.Request) (string, error) {
+	currency := strings.ToUpper(req.PathParameters["currency"])
+	if currency == "" {
+		return "", models.ErrMissingCurrency
+	}
+
+	err := validate.Currency(currency)
+	if err != nil {
+		return "", err
+	}
+
+	return currency, nil
+}
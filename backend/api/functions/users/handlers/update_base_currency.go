@@ -0,0 +1,119 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/JoelD7/money/backend/models"
+	"github.com/JoelD7/money/backend/shared/apigateway"
+	"github.com/JoelD7/money/backend/shared/logger"
+	"github.com/JoelD7/money/backend/shared/validate"
+	"github.com/JoelD7/money/backend/storage/dynamo"
+	"github.com/JoelD7/money/backend/storage/users"
+	"github.com/JoelD7/money/backend/usecases"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+var (
+	ubcRequest *updateBaseCurrencyRequest
+	ubcOnce    sync.Once
+)
+
+type updateBaseCurrencyRequest struct {
+	startingTime time.Time
+	err          error
+	userRepo     users.Repository
+}
+
+type updateBaseCurrencyBody struct {
+	BaseCurrency string `json:"base_currency"`
+}
+
+func (request *updateBaseCurrencyRequest) init(ctx context.Context, envConfig *models.EnvironmentConfiguration) error {
+	var err error
+	ubcOnce.Do(func() {
+		logger.SetHandler("update-base-currency")
+		dynamoClient := dynamo.InitClient(ctx)
+
+		request.userRepo, err = users.NewRepository(ctx, dynamoClient, envConfig)
+	})
+	request.startingTime = time.Now()
+
+	return err
+}
+
+func (request *updateBaseCurrencyRequest) finish() {
+	logger.LogLambdaTime(request.startingTime, request.err, recover())
+}
+
+// UpdateBaseCurrencyHandler changes the currency in which the totals of the user are expressed.
+func UpdateBaseCurrencyHandler(ctx context.Context, envConfig *models.EnvironmentConfiguration, req *apigateway.Request) (*apigateway.Response, error) {
+	if ubcRequest == nil {
+		ubcRequest = new(updateBaseCurrencyRequest)
+	}
+
+	err := ubcRequest.init(ctx, envConfig)
+	if err != nil {
+		logger.Error("update_base_currency_init_failed", err, req)
+
+		return req.NewErrorResponse(err), nil
+	}
+
+	defer ubcRequest.finish()
+
+	return ubcRequest.process(ctx, req)
+}
+
+func (request *updateBaseCurrencyRequest) process(ctx context.Context, req *apigateway.Request) (*apigateway.Response, error) {
+	baseCurrency, err := validateUpdateBaseCurrencyBody(req)
+	if err != nil {
+		request.err = err
+		logger.Error("validate_request_body_failed", err, req)
+
+		return req.NewErrorResponse(err), nil
+	}
+
+	username, err := apigateway.GetUsernameFromContext(req)
+	if err != nil {
+		request.err = err
+		logger.Error("get_username_from_context_failed", err, req)
+
+		return req.NewErrorResponse(err), nil
+	}
+
+	updateBaseCurrency := usecases.NewBaseCurrencyUpdater(request.userRepo)
+
+	user, err := updateBaseCurrency(ctx, username, baseCurrency)
+	if err != nil {
+		request.err = err
+		logger.Error("update_base_currency_failed", err, req)
+
+		return req.NewErrorResponse(err), nil
+	}
+
+	return req.NewJSONResponse(http.StatusOK, user), nil
+}
+
+func validateUpdateBaseCurrencyBody(req *apigateway.Request) (string, error) {
+	body := new(updateBaseCurrencyBody)
+
+	err := json.Unmarshal([]byte(req.Body), body)
+	if err != nil {
+		return "", fmt.Errorf("%v: %w", err, models.ErrInvalidRequestBody)
+	}
+
+	baseCurrency := strings.ToUpper(body.BaseCurrency)
+	if baseCurrency == "" {
+		return "", models.ErrMissingCurrency
+	}
+
+	err = validate.Currency(baseCurrency)
+	if err != nil {
+		return "", err
+	}
+
+	return baseCurrency, nil
+}
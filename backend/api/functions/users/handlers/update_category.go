@@ -12,7 +12,6 @@ import (
 	"github.com/JoelD7/money/backend/storage/dynamo"
 	"github.com/JoelD7/money/backend/storage/users"
 	"github.com/JoelD7/money/backend/usecases"
-	"math"
 	"net/http"
 	"sync"
 	"time"
@@ -36,7 +35,7 @@ func (request *updateCategoryRequest) init(ctx context.Context, envConfig *model
 		logger.SetHandler("update-category")
 		dynamoClient := dynamo.InitClient(ctx)
 
-		request.userRepo, err = users.NewDynamoRepository(dynamoClient, envConfig.UsersTable)
+		request.userRepo, err = users.NewRepository(ctx, dynamoClient, envConfig)
 		if err != nil {
 			return
 		}
@@ -99,9 +98,16 @@ func (request *updateCategoryRequest) process(ctx context.Context, req *apigatew
 		return req.NewErrorResponse(err), nil
 	}
 
+	version, err := req.GetIfMatchVersion()
+	if err != nil {
+		logger.Error("invalid_if_match", err, req)
+
+		return req.NewErrorResponse(err), nil
+	}
+
 	updateCategory := usecases.NewCategoryUpdater(request.userRepo)
 
-	err = updateCategory(ctx, username, categoryID, requestCategory)
+	user, err := updateCategory(ctx, username, categoryID, version, requestCategory)
 	if err != nil {
 		request.err = err
 		logger.Error("update_category_failed", err, req)
@@ -109,7 +115,7 @@ func (request *updateCategoryRequest) process(ctx context.Context, req *apigatew
 		return req.NewErrorResponse(err), nil
 	}
 
-	return req.NewJSONResponse(http.StatusOK, nil), nil
+	return req.NewJSONResponse(http.StatusOK, nil, apigateway.NewETagHeader(user.Version)), nil
 }
 
 func validateRequestBody(req *apigateway.Request) (*models.Category, error) {
@@ -124,7 +130,7 @@ func validateRequestBody(req *apigateway.Request) (*models.Category, error) {
 		return nil, models.ErrMissingCategoryName
 	}
 
-	if requestCategory.Budget != nil && (*requestCategory.Budget < 0 || *requestCategory.Budget >= math.MaxFloat64) {
+	if requestCategory.Budget != nil && *requestCategory.Budget < 0 {
 		return nil, models.ErrInvalidBudget
 	}
 
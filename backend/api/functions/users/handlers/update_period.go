@@ -7,6 +7,7 @@ import (
 	"github.com/JoelD7/money/backend/models"
 	"github.com/JoelD7/money/backend/shared/apigateway"
 	"github.com/JoelD7/money/backend/shared/logger"
+	"github.com/JoelD7/money/backend/shared/validate"
 	"github.com/JoelD7/money/backend/storage/dynamo"
 	"github.com/JoelD7/money/backend/storage/period"
 	"github.com/JoelD7/money/backend/usecases"
@@ -29,7 +30,7 @@ func (request *updatePeriodRequest) init(ctx context.Context, envConfig *models.
 	upOnce.Do(func() {
 		dynamoClient := dynamo.InitClient(ctx)
 
-		request.periodRepo, err = period.NewDynamoRepository(dynamoClient, envConfig)
+		request.periodRepo, err = period.NewRepository(ctx, dynamoClient, envConfig)
 		if err != nil {
 			return
 		}
@@ -69,6 +70,15 @@ func (request *updatePeriodRequest) process(ctx context.Context, req *apigateway
 		return req.NewErrorResponse(err), nil
 	}
 
+	version, err := req.GetIfMatchVersion()
+	if err != nil {
+		logger.Error("invalid_if_match", err, req)
+
+		return req.NewErrorResponse(err), nil
+	}
+
+	periodBody.Version = version
+
 	updatePeriod := usecases.NewPeriodUpdater(request.periodRepo)
 
 	updatedPeriod, err := updatePeriod(ctx, periodBody.Username, periodBody.ID, periodBody)
@@ -79,7 +89,7 @@ func (request *updatePeriodRequest) process(ctx context.Context, req *apigateway
 		return req.NewErrorResponse(err), nil
 	}
 
-	return req.NewJSONResponse(http.StatusOK, updatedPeriod), nil
+	return req.NewJSONResponse(http.StatusOK, updatedPeriod, apigateway.NewETagHeader(updatedPeriod.Version)), nil
 }
 
 func validateUpdateRequestBody(req *apigateway.Request) (*models.Period, error) {
@@ -119,5 +129,10 @@ func validateUpdateRequestBody(req *apigateway.Request) (*models.Period, error)
 		return nil, models.ErrMissingPeriodCreatedDate
 	}
 
+	err = validate.CategoryBudgets(periodModel.CategoryBudgets)
+	if err != nil {
+		return nil, err
+	}
+
 	return periodModel, nil
 }
@@ -33,17 +33,17 @@ func (request *updateSavingRequest) init(ctx context.Context, envConfig *models.
 	usOnce.Do(func() {
 		dynamoClient := dynamo.InitClient(ctx)
 
-		request.savingsRepo, err = savings.NewDynamoRepository(dynamoClient, envConfig)
+		request.savingsRepo, err = savings.NewRepository(ctx, dynamoClient, envConfig)
 		if err != nil {
 			return
 		}
 
-		request.periodRepo, err = period.NewDynamoRepository(dynamoClient, envConfig)
+		request.periodRepo, err = period.NewRepository(ctx, dynamoClient, envConfig)
 		if err != nil {
 			return
 		}
 
-		request.savingGoalRepo, err = savingoal.NewDynamoRepository(dynamoClient, envConfig)
+		request.savingGoalRepo, err = savingoal.NewRepository(ctx, dynamoClient, envConfig)
 		if err != nil {
 			return
 		}
@@ -83,6 +83,15 @@ func (request *updateSavingRequest) process(ctx context.Context, req *apigateway
 		return req.NewErrorResponse(err), nil
 	}
 
+	version, err := req.GetIfMatchVersion()
+	if err != nil {
+		logger.Error("invalid_if_match", err, req)
+
+		return req.NewErrorResponse(err), nil
+	}
+
+	userSaving.Version = version
+
 	updateSaving := usecases.NewSavingUpdater(request.savingsRepo, request.periodRepo, request.savingGoalRepo)
 
 	saving, err := updateSaving(ctx, userSaving.Username, userSaving)
@@ -92,7 +101,7 @@ func (request *updateSavingRequest) process(ctx context.Context, req *apigateway
 		return req.NewErrorResponse(err), nil
 	}
 
-	return req.NewJSONResponse(http.StatusOK, saving), nil
+	return req.NewJSONResponse(http.StatusOK, saving, apigateway.NewETagHeader(saving.Version)), nil
 }
 
 func (request *updateSavingRequest) validateUpdateInputs(req *apigateway.Request) (*models.Saving, error) {
@@ -126,5 +135,10 @@ func (request *updateSavingRequest) validateUpdateInputs(req *apigateway.Request
 		return nil, err
 	}
 
+	err = validate.Currency(saving.Currency)
+	if err != nil {
+		return nil, err
+	}
+
 	return saving, nil
 }
@@ -31,7 +31,7 @@ func (request *updateSavingGoalsRequest) init(ctx context.Context, envConfig *mo
 	updateSavingGoalsOnce.Do(func() {
 		request.startingTime = time.Now()
 		dynamoClient := dynamo.InitClient(ctx)
-		request.savingGoalRepo, err = savingoal.NewDynamoRepository(dynamoClient, envConfig)
+		request.savingGoalRepo, err = savingoal.NewRepository(ctx, dynamoClient, envConfig)
 		if err != nil {
 			return
 		}
@@ -83,6 +83,15 @@ func (request *updateSavingGoalsRequest) process(ctx context.Context, req *apiga
 		return req.NewErrorResponse(err), nil
 	}
 
+	version, err := req.GetIfMatchVersion()
+	if err != nil {
+		logger.Error("invalid_if_match", err, req)
+
+		return req.NewErrorResponse(err), nil
+	}
+
+	savingGoal.Version = version
+
 	updateSavingGoal := usecases.NewSavingGoalUpdator(request.savingGoalRepo)
 
 	updatedGoal, err := updateSavingGoal(ctx, username, savingGoalID, savingGoal)
@@ -92,5 +101,5 @@ func (request *updateSavingGoalsRequest) process(ctx context.Context, req *apiga
 		return req.NewErrorResponse(err), nil
 	}
 
-	return req.NewJSONResponse(http.StatusOK, updatedGoal), nil
+	return req.NewJSONResponse(http.StatusOK, updatedGoal, apigateway.NewETagHeader(updatedGoal.Version)), nil
 }
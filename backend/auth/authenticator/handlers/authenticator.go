@@ -0,0 +1,119 @@
+// Package handlers contains the handlers of the authentication server, which authenticates users and generates JWTs.
+package handlers
+
+import (
+	"github.com/JoelD7/money/backend/models"
+	"github.com/JoelD7/money/backend/shared/apigateway"
+	"github.com/JoelD7/money/backend/shared/router"
+	"github.com/JoelD7/money/backend/shared/validate"
+	"net/http"
+	"strings"
+)
+
+var (
+	errCookiesNotFound              = apigateway.NewError("cookies not found in request object", http.StatusBadRequest)
+	errMissingRefreshTokenInCookies = apigateway.NewError("missing refresh token in cookies", http.StatusBadRequest)
+	errUserNotFound                 = apigateway.NewError("", http.StatusBadRequest)
+)
+
+const (
+	refreshTokenCookieName = "RefreshToken"
+)
+
+type signUpBody struct {
+	FullName string `json:"fullname"`
+	*Credentials
+}
+
+type Credentials struct {
+	Username string `json:"username"`
+	Password string `json:"password,omitempty"`
+}
+
+func (c *Credentials) Key() string {
+	return "user_data"
+}
+
+func (c *Credentials) Value() map[string]interface{} {
+	return map[string]interface{}{
+		"username": c.Username,
+	}
+}
+
+func getRefreshTokenCookie(request *apigateway.Request) (string, error) {
+	cookies, ok := request.Headers["Cookie"]
+	if !ok {
+		return "", errCookiesNotFound
+	}
+
+	cookieParts := make([]string, 0)
+	var name, value string
+
+	for _, cookie := range strings.Split(cookies, ";") {
+		cookieParts = strings.Split(cookie, "=")
+		if len(cookieParts) < 2 {
+			continue
+		}
+
+		name = strings.TrimSpace(cookieParts[0])
+		value = strings.TrimSpace(cookieParts[1])
+
+		if name == "" || value == "" {
+			continue
+		}
+
+		if name == refreshTokenCookieName && len(cookieParts) > 1 {
+			return value, nil
+		}
+	}
+
+	return "", errMissingRefreshTokenInCookies
+}
+
+// newSession returns the session of a new login, with the details of the device it was made from so that the user can
+// tell the sessions apart.
+func newSession(request *apigateway.Request) *models.Session {
+	return &models.Session{
+		UserAgent: request.Headers["User-Agent"],
+		IPAddress: request.RequestContext.Identity.SourceIP,
+	}
+}
+
+func validateCredentials(email, password string) error {
+	err := validate.Email(email)
+	if err != nil {
+		return err
+	}
+
+	if password == "" {
+		return models.ErrMissingPassword
+	}
+
+	return nil
+}
+
+// NewRouter returns the router with all the endpoints of the authentication server.
+func NewRouter(envConfig *models.EnvironmentConfiguration) *router.Router {
+	rootRouter := router.NewRouter(envConfig)
+
+	rootRouter.Route("/auth", func(r *router.Router) {
+		r.Post("/login", logInHandler)
+		r.Post("/login/mfa", logInMFAHandler)
+		r.Post("/login/unlock", logInUnlockHandler)
+
+		r.Post("/signup", signUpHandler)
+		r.Get("/verify", verifyEmailHandler)
+		r.Post("/verify", verifyEmailHandler)
+		r.Post("/verify/resend", resendEmailVerificationHandler)
+
+		r.Post("/token", tokenHandler)
+		r.Get("/jwks", jwksHandler)
+		r.Post("/logout", logoutHandler)
+
+		r.Post("/password", passwordChangeHandler)
+		r.Post("/password/reset", passwordResetHandler)
+		r.Post("/password/reset/confirm", passwordResetConfirmHandler)
+	})
+
+	return rootRouter
+}
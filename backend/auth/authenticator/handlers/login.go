@@ -1,4 +1,4 @@
-package main
+package handlers
 
 import (
 	"context"
@@ -9,10 +9,13 @@ import (
 	"github.com/JoelD7/money/backend/shared/apigateway"
 	"github.com/JoelD7/money/backend/shared/logger"
 	"github.com/JoelD7/money/backend/shared/secrets"
+	"github.com/JoelD7/money/backend/storage/cache"
 	"github.com/JoelD7/money/backend/storage/dynamo"
+	"github.com/JoelD7/money/backend/storage/sessions"
 	"github.com/JoelD7/money/backend/storage/users"
 	"github.com/JoelD7/money/backend/usecases"
 	"net/http"
+	"strconv"
 	"sync"
 	"time"
 )
@@ -24,13 +27,22 @@ type requestLoginHandler struct {
 	startingTime   time.Time
 	err            error
 	userRepo       users.Repository
+	sessionRepo    sessions.Repository
 	secretsManager secrets.SecretManager
+	loginAttempts  cache.LoginAttemptManager
 }
 
 type accessTokenResponse struct {
 	AccessToken string `json:"accessToken"`
 }
 
+// mfaChallengeResponse is sent instead of the tokens to the users with MFA enabled, who complete the login sending the
+// token with a TOTP code to logInMFAHandler.
+type mfaChallengeResponse struct {
+	MFARequired bool   `json:"mfaRequired"`
+	MFAToken    string `json:"mfaToken"`
+}
+
 func logInHandler(ctx context.Context, envConfig *models.EnvironmentConfiguration, request *apigateway.Request) (*apigateway.Response, error) {
 	if loginRequest == nil {
 		loginRequest = new(requestLoginHandler)
@@ -54,12 +66,18 @@ func (req *requestLoginHandler) initLoginHandler(ctx context.Context, envConfig
 		logger.SetHandler("login")
 		dynamoClient := dynamo.InitClient(ctx)
 
-		req.userRepo, err = users.NewDynamoRepository(dynamoClient, envConfig.UsersTable)
+		req.userRepo, err = users.NewRepository(ctx, dynamoClient, envConfig)
+		if err != nil {
+			return
+		}
+
+		req.sessionRepo, err = sessions.NewRepository(ctx, dynamoClient, envConfig)
 		if err != nil {
 			return
 		}
 
 		req.secretsManager = secrets.NewAWSSecretManager()
+		req.loginAttempts = cache.NewRedisCache()
 	})
 	req.startingTime = time.Now()
 	req.err = nil
@@ -80,19 +98,29 @@ func (req *requestLoginHandler) processLogin(ctx context.Context, request *apiga
 		return request.NewErrorResponse(err), nil
 	}
 
-	authenticate := usecases.NewUserAuthenticator(req.userRepo)
-	generateTokens := usecases.NewUserTokenGenerator(req.userRepo, req.secretsManager)
+	authenticate := usecases.NewUserAuthenticator(req.userRepo, req.loginAttempts)
+	generateTokens := usecases.NewUserTokenGenerator(req.sessionRepo, req.secretsManager)
 
-	user, err := authenticate(ctx, reqBody.Username, reqBody.Password)
+	user, err := authenticate(ctx, reqBody.Username, reqBody.Password, request.RequestContext.Identity.SourceIP)
 	if errors.Is(err, models.ErrUserNotFound) {
 		return request.NewErrorResponse(errUserNotFound), nil
 	}
 
+	if response, ok := newLoginBlockedResponse(request, err); ok {
+		req.err = err
+
+		return response, nil
+	}
+
+	if errors.Is(err, models.ErrMFARequired) {
+		return req.processMFAChallenge(ctx, request, user)
+	}
+
 	if err != nil {
 		return request.NewErrorResponse(err), nil
 	}
 
-	accessToken, refreshToken, err := generateTokens(ctx, user)
+	accessToken, refreshToken, err := generateTokens(ctx, user, newSession(request))
 	if err != nil {
 		return request.NewErrorResponse(err), nil
 	}
@@ -111,6 +139,21 @@ func (req *requestLoginHandler) processLogin(ctx context.Context, request *apiga
 	return request.NewJSONResponse(http.StatusOK, string(data), apigateway.Header{Key: "Set-Cookie", Value: cookieStr}), nil
 }
 
+func (req *requestLoginHandler) processMFAChallenge(ctx context.Context, request *apigateway.Request, user *models.User) (*apigateway.Response, error) {
+	issueChallenge := usecases.NewMFAChallengeIssuer(req.secretsManager)
+
+	mfaToken, err := issueChallenge(ctx, user)
+	if err != nil {
+		req.err = err
+
+		return request.NewErrorResponse(err), nil
+	}
+
+	logger.Info("login_mfa_required", request)
+
+	return request.NewJSONResponse(http.StatusOK, &mfaChallengeResponse{true, mfaToken.Value}), nil
+}
+
 func getRefreshTokenCookieStr(value string, expiration time.Time) string {
 	return fmt.Sprintf("%s=%s; Expires=%s; Path=/; Secure; SameSite=None; HttpOnly;", refreshTokenCookieName, value,
 		expiration.Format(time.RFC1123))
@@ -131,3 +174,17 @@ func validateLoginInput(request *apigateway.Request) (*Credentials, error) {
 
 	return reqBody, nil
 }
+
+// newLoginBlockedResponse returns the response to a blocked login, which tells the client when it can try again in the
+// Retry-After header. It returns false if err isn't a *models.LoginBlockedError.
+func newLoginBlockedResponse(request *apigateway.Request, err error) (*apigateway.Response, bool) {
+	var blockedErr *models.LoginBlockedError
+	if !errors.As(err, &blockedErr) {
+		return nil, false
+	}
+
+	response := request.NewErrorResponse(err)
+	response.Headers["Retry-After"] = strconv.FormatInt(blockedErr.RetryAfter(), 10)
+
+	return response, true
+}
@@ -0,0 +1,99 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/JoelD7/money/backend/models"
+	"github.com/JoelD7/money/backend/shared/apigateway"
+	"github.com/JoelD7/money/backend/shared/logger"
+	"github.com/JoelD7/money/backend/usecases"
+	"net/http"
+)
+
+type mfaLoginBody struct {
+	MFAToken string `json:"mfa_token"`
+	Code     string `json:"code"`
+}
+
+// logInMFAHandler completes the login of a user with MFA enabled, with the token sent by logInHandler and a TOTP or
+// recovery code.
+func logInMFAHandler(ctx context.Context, envConfig *models.EnvironmentConfiguration, request *apigateway.Request) (*apigateway.Response, error) {
+	if loginRequest == nil {
+		loginRequest = new(requestLoginHandler)
+	}
+
+	err := loginRequest.initLoginHandler(ctx, envConfig)
+	if err != nil {
+		loginRequest.err = err
+		logger.Error("login_mfa_init_failed", err, request)
+
+		return request.NewErrorResponse(err), nil
+	}
+	defer loginRequest.finish()
+
+	return loginRequest.processMFALogin(ctx, request)
+}
+
+func (req *requestLoginHandler) processMFALogin(ctx context.Context, request *apigateway.Request) (*apigateway.Response, error) {
+	reqBody, err := validateMFALoginInput(request)
+	if err != nil {
+		req.err = err
+		logger.Error("validate_input_failed", err, request)
+
+		return request.NewErrorResponse(err), nil
+	}
+
+	verifyMFA := usecases.NewMFALoginVerifier(req.userRepo, req.secretsManager, req.loginAttempts)
+	generateTokens := usecases.NewUserTokenGenerator(req.sessionRepo, req.secretsManager)
+
+	user, err := verifyMFA(ctx, reqBody.MFAToken, reqBody.Code, request.RequestContext.Identity.SourceIP)
+	if response, ok := newLoginBlockedResponse(request, err); ok {
+		req.err = err
+
+		return response, nil
+	}
+
+	if err != nil {
+		req.err = err
+
+		return request.NewErrorResponse(err), nil
+	}
+
+	accessToken, refreshToken, err := generateTokens(ctx, user, newSession(request))
+	if err != nil {
+		return request.NewErrorResponse(err), nil
+	}
+
+	response := &accessTokenResponse{accessToken.Value}
+
+	data, err := json.Marshal(response)
+	if err != nil {
+		return request.NewErrorResponse(err), nil
+	}
+
+	cookieStr := getRefreshTokenCookieStr(refreshToken.Value, refreshToken.Expiration)
+
+	logger.Info("login_succeeded", request)
+
+	return request.NewJSONResponse(http.StatusOK, string(data), apigateway.Header{Key: "Set-Cookie", Value: cookieStr}), nil
+}
+
+func validateMFALoginInput(request *apigateway.Request) (*mfaLoginBody, error) {
+	reqBody := new(mfaLoginBody)
+
+	err := json.Unmarshal([]byte(request.Body), reqBody)
+	if err != nil {
+		return nil, fmt.Errorf("%v: %w", err, models.ErrInvalidRequestBody)
+	}
+
+	if reqBody.MFAToken == "" {
+		return nil, models.ErrMissingMFAToken
+	}
+
+	if reqBody.Code == "" {
+		return nil, models.ErrMissingMFACode
+	}
+
+	return reqBody, nil
+}
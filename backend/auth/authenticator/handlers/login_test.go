@@ -1,8 +1,10 @@
-package main
+package handlers
 
 import (
 	"context"
 	"github.com/JoelD7/money/backend/models"
+	"github.com/JoelD7/money/backend/storage/cache"
+	"github.com/JoelD7/money/backend/storage/sessions"
 	"github.com/JoelD7/money/backend/storage/users"
 	"net/http"
 	"testing"
@@ -42,7 +44,9 @@ func TestLoginHandler(t *testing.T) {
 
 	request := &requestLoginHandler{
 		userRepo:       usersMock,
+		sessionRepo:    sessions.NewMemoryRepository(),
 		secretsManager: secretMock,
+		loginAttempts:  cache.NewRedisCacheMock(),
 	}
 
 	apigwRequest := &apigateway.Request{Body: jsonBody}
@@ -84,7 +88,9 @@ func TestLoginHandlerFailed(t *testing.T) {
 
 	request := &requestLoginHandler{
 		userRepo:       usersMock,
+		sessionRepo:    sessions.NewMemoryRepository(),
 		secretsManager: secretMock,
+		loginAttempts:  cache.NewRedisCacheMock(),
 	}
 
 	apigwRequest := &apigateway.Request{Body: jsonBody}
@@ -0,0 +1,78 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/JoelD7/money/backend/models"
+	"github.com/JoelD7/money/backend/shared/apigateway"
+	"github.com/JoelD7/money/backend/shared/logger"
+	"github.com/JoelD7/money/backend/usecases"
+	"net/http"
+	"strings"
+)
+
+const (
+	adminKeyHeaderName = "X-Admin-Key"
+)
+
+type loginUnlockBody struct {
+	Username  string `json:"username,omitempty"`
+	IPAddress string `json:"ip_address,omitempty"`
+}
+
+// logInUnlockHandler lifts the login block of a username or source IP. Only admins can use it, sending the admin key
+// in the X-Admin-Key header.
+func logInUnlockHandler(ctx context.Context, envConfig *models.EnvironmentConfiguration, request *apigateway.Request) (*apigateway.Response, error) {
+	if loginRequest == nil {
+		loginRequest = new(requestLoginHandler)
+	}
+
+	err := loginRequest.initLoginHandler(ctx, envConfig)
+	if err != nil {
+		loginRequest.err = err
+		logger.Error("login_unlock_init_failed", err, request)
+
+		return request.NewErrorResponse(err), nil
+	}
+	defer loginRequest.finish()
+
+	return loginRequest.processLoginUnlock(ctx, request)
+}
+
+func (req *requestLoginHandler) processLoginUnlock(ctx context.Context, request *apigateway.Request) (*apigateway.Response, error) {
+	reqBody := new(loginUnlockBody)
+
+	err := json.Unmarshal([]byte(request.Body), reqBody)
+	if err != nil {
+		err = fmt.Errorf("%v: %w", err, models.ErrInvalidRequestBody)
+		req.err = err
+		logger.Error("validate_input_failed", err, request)
+
+		return request.NewErrorResponse(err), nil
+	}
+
+	unlock := usecases.NewLoginUnlocker(req.secretsManager, req.loginAttempts)
+
+	err = unlock(ctx, getAdminKey(request), reqBody.Username, reqBody.IPAddress)
+	if err != nil {
+		req.err = err
+		logger.Error("login_unlock_failed", err, request)
+
+		return request.NewErrorResponse(err), nil
+	}
+
+	return request.NewJSONResponse(http.StatusNoContent, nil), nil
+}
+
+// getAdminKey returns the admin key of the request. Header names are case-insensitive, so clients may send it in any
+// case.
+func getAdminKey(request *apigateway.Request) string {
+	for headerName, value := range request.Headers {
+		if strings.EqualFold(headerName, adminKeyHeaderName) {
+			return value
+		}
+	}
+
+	return ""
+}
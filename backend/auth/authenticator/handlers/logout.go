@@ -1,16 +1,16 @@
-package main
+package handlers
 
 import (
 	"context"
 	"encoding/json"
-	"errors"
 	"fmt"
 	"github.com/JoelD7/money/backend/models"
 	"github.com/JoelD7/money/backend/shared/apigateway"
 	"github.com/JoelD7/money/backend/shared/logger"
+	"github.com/JoelD7/money/backend/shared/secrets"
 	"github.com/JoelD7/money/backend/storage/cache"
 	"github.com/JoelD7/money/backend/storage/dynamo"
-	"github.com/JoelD7/money/backend/storage/users"
+	"github.com/JoelD7/money/backend/storage/sessions"
 	"github.com/JoelD7/money/backend/usecases"
 	"net/http"
 	"sync"
@@ -23,8 +23,9 @@ var logoutOnce sync.Once
 type requestLogoutHandler struct {
 	startingTime        time.Time
 	err                 error
-	userRepo            users.Repository
+	sessionRepo         sessions.Repository
 	invalidTokenManager cache.InvalidTokenManager
+	secretsManager      secrets.SecretManager
 }
 
 func logoutHandler(ctx context.Context, envConfig *models.EnvironmentConfiguration, request *apigateway.Request) (*apigateway.Response, error) {
@@ -51,11 +52,12 @@ func (req *requestLogoutHandler) initLogoutHandler(ctx context.Context, envConfi
 		logger.SetHandler("logout")
 		dynamoClient := dynamo.InitClient(ctx)
 
-		req.userRepo, err = users.NewDynamoRepository(dynamoClient, envConfig.UsersTable)
+		req.sessionRepo, err = sessions.NewRepository(ctx, dynamoClient, envConfig)
 		if err != nil {
 			return
 		}
 		req.invalidTokenManager = cache.NewRedisCache()
+		req.secretsManager = secrets.NewAWSSecretManager()
 	})
 	req.startingTime = time.Now()
 	req.err = nil
@@ -78,16 +80,12 @@ func (req *requestLogoutHandler) processLogout(ctx context.Context, request *api
 		return request.NewErrorResponse(err), nil
 	}
 
-	logout := usecases.NewUserLogout(req.userRepo, req.invalidTokenManager)
+	// Without the cookie, the user is logged out of all the sessions.
+	refreshToken, _ := getRefreshTokenCookie(request)
 
-	err = logout(ctx, credentials.Username)
-	if errors.Is(err, models.ErrUserNotFound) {
-		req.err = err
-		logger.Error("logout_failed", err, nil)
-
-		return request.NewErrorResponse(errUserNotFound), nil
-	}
+	logout := usecases.NewUserLogout(req.sessionRepo, req.secretsManager, req.invalidTokenManager)
 
+	err = logout(ctx, credentials.Username, refreshToken)
 	if err != nil {
 		req.err = err
 		logger.Error("logout_failed", err, nil)
@@ -1,11 +1,11 @@
-package main
+package handlers
 
 import (
 	"context"
 	"fmt"
 	"github.com/JoelD7/money/backend/shared/apigateway"
 	"github.com/JoelD7/money/backend/storage/cache"
-	"github.com/JoelD7/money/backend/storage/users"
+	"github.com/JoelD7/money/backend/storage/sessions"
 	"github.com/stretchr/testify/require"
 	"net/http"
 	"testing"
@@ -14,12 +14,11 @@ import (
 func TestLogoutHandlerSuccess(t *testing.T) {
 	c := require.New(t)
 
-	usersMock := users.NewDynamoMock()
 	redisMock := cache.NewRedisCacheMock()
 	ctx := context.Background()
 
 	request := &requestLogoutHandler{
-		userRepo:            usersMock,
+		sessionRepo:         sessions.NewMemoryRepository(),
 		invalidTokenManager: redisMock,
 	}
 
@@ -38,11 +37,10 @@ func TestLogoutHandlerFailed(t *testing.T) {
 	c := require.New(t)
 
 	ctx := context.Background()
-	usersMock := users.NewDynamoMock()
 	redisMock := cache.NewRedisCacheMock()
 
 	request := &requestLogoutHandler{
-		userRepo:            usersMock,
+		sessionRepo:         sessions.NewMemoryRepository(),
 		invalidTokenManager: redisMock,
 	}
 
@@ -0,0 +1,111 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/JoelD7/money/backend/models"
+	"github.com/JoelD7/money/backend/shared/apigateway"
+	"github.com/JoelD7/money/backend/shared/logger"
+	"github.com/JoelD7/money/backend/storage/dynamo"
+	"github.com/JoelD7/money/backend/storage/users"
+	"github.com/JoelD7/money/backend/usecases"
+	"net/http"
+	"sync"
+	"time"
+)
+
+var passwordChangeRequest *requestPasswordChangeHandler
+var passwordChangeOnce sync.Once
+
+type requestPasswordChangeHandler struct {
+	startingTime time.Time
+	err          error
+	userRepo     users.Repository
+}
+
+type passwordChangeBody struct {
+	Username        string `json:"username"`
+	CurrentPassword string `json:"current_password"`
+	NewPassword     string `json:"new_password"`
+}
+
+func passwordChangeHandler(ctx context.Context, envConfig *models.EnvironmentConfiguration, request *apigateway.Request) (*apigateway.Response, error) {
+	if passwordChangeRequest == nil {
+		passwordChangeRequest = new(requestPasswordChangeHandler)
+	}
+
+	err := passwordChangeRequest.initPasswordChangeHandler(ctx, envConfig)
+	if err != nil {
+		passwordChangeRequest.err = err
+
+		logger.Error("password_change_init_failed", err, request)
+
+		return request.NewErrorResponse(err), nil
+	}
+	defer passwordChangeRequest.finish()
+
+	return passwordChangeRequest.processPasswordChange(ctx, request)
+}
+
+func (req *requestPasswordChangeHandler) initPasswordChangeHandler(ctx context.Context, envConfig *models.EnvironmentConfiguration) error {
+	var err error
+	passwordChangeOnce.Do(func() {
+		logger.SetHandler("password-change")
+		dynamoClient := dynamo.InitClient(ctx)
+
+		req.userRepo, err = users.NewRepository(ctx, dynamoClient, envConfig)
+	})
+	req.startingTime = time.Now()
+	req.err = nil
+
+	return err
+}
+
+func (req *requestPasswordChangeHandler) finish() {
+	logger.LogLambdaTime(req.startingTime, req.err, recover())
+}
+
+func (req *requestPasswordChangeHandler) processPasswordChange(ctx context.Context, request *apigateway.Request) (*apigateway.Response, error) {
+	reqBody, err := validatePasswordChangeBody(request)
+	if err != nil {
+		req.err = err
+		logger.Error("validate_input_failed", err, request)
+
+		return request.NewErrorResponse(err), nil
+	}
+
+	changePassword := usecases.NewPasswordChanger(req.userRepo)
+
+	err = changePassword(ctx, reqBody.Username, reqBody.CurrentPassword, reqBody.NewPassword)
+	if err != nil {
+		req.err = err
+		logger.Error("password_change_failed", err, request)
+
+		return request.NewErrorResponse(err), nil
+	}
+
+	logger.Info("password_changed", request)
+
+	return request.NewJSONResponse(http.StatusNoContent, nil), nil
+}
+
+func validatePasswordChangeBody(request *apigateway.Request) (*passwordChangeBody, error) {
+	reqBody := new(passwordChangeBody)
+
+	err := json.Unmarshal([]byte(request.Body), reqBody)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", models.ErrInvalidRequestBody, err)
+	}
+
+	err = validateCredentials(reqBody.Username, reqBody.CurrentPassword)
+	if err != nil {
+		return nil, err
+	}
+
+	if reqBody.NewPassword == "" {
+		return nil, models.ErrMissingNewPassword
+	}
+
+	return reqBody, nil
+}
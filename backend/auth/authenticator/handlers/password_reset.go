@@ -0,0 +1,206 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/JoelD7/money/backend/models"
+	"github.com/JoelD7/money/backend/shared/apigateway"
+	"github.com/JoelD7/money/backend/shared/logger"
+	"github.com/JoelD7/money/backend/shared/mail"
+	"github.com/JoelD7/money/backend/shared/validate"
+	"github.com/JoelD7/money/backend/storage/cache"
+	"github.com/JoelD7/money/backend/storage/dynamo"
+	"github.com/JoelD7/money/backend/storage/sessions"
+	"github.com/JoelD7/money/backend/storage/users"
+	"github.com/JoelD7/money/backend/usecases"
+	"net/http"
+	"sync"
+	"time"
+)
+
+var passwordResetRequest *requestPasswordResetHandler
+var passwordResetOnce sync.Once
+
+type requestPasswordResetHandler struct {
+	startingTime        time.Time
+	err                 error
+	userRepo            users.Repository
+	sessionRepo         sessions.Repository
+	invalidTokenManager cache.InvalidTokenManager
+	mailSender          mail.Sender
+}
+
+type passwordResetBody struct {
+	Username string `json:"username"`
+	// Token and Password are only sent to confirm the reset.
+	Token    string `json:"token,omitempty"`
+	Password string `json:"password,omitempty"`
+}
+
+// passwordResetHandler sends the user a token to reset the password.
+func passwordResetHandler(ctx context.Context, envConfig *models.EnvironmentConfiguration, request *apigateway.Request) (*apigateway.Response, error) {
+	err := initPasswordResetRequest(ctx, envConfig)
+	if err != nil {
+		logger.Error("password_reset_init_failed", err, request)
+
+		return request.NewErrorResponse(err), nil
+	}
+	defer passwordResetRequest.finish()
+
+	return passwordResetRequest.processPasswordReset(ctx, request)
+}
+
+// passwordResetConfirmHandler sets the password of the user with the token sent by passwordResetHandler.
+func passwordResetConfirmHandler(ctx context.Context, envConfig *models.EnvironmentConfiguration, request *apigateway.Request) (*apigateway.Response, error) {
+	err := initPasswordResetRequest(ctx, envConfig)
+	if err != nil {
+		logger.Error("password_reset_confirm_init_failed", err, request)
+
+		return request.NewErrorResponse(err), nil
+	}
+	defer passwordResetRequest.finish()
+
+	return passwordResetRequest.processPasswordResetConfirm(ctx, request)
+}
+
+func initPasswordResetRequest(ctx context.Context, envConfig *models.EnvironmentConfiguration) error {
+	if passwordResetRequest == nil {
+		passwordResetRequest = new(requestPasswordResetHandler)
+	}
+
+	err := passwordResetRequest.initPasswordResetHandler(ctx, envConfig)
+	if err != nil {
+		passwordResetRequest.err = err
+	}
+
+	return err
+}
+
+func (req *requestPasswordResetHandler) initPasswordResetHandler(ctx context.Context, envConfig *models.EnvironmentConfiguration) error {
+	var err error
+	passwordResetOnce.Do(func() {
+		logger.SetHandler("password-reset")
+		dynamoClient := dynamo.InitClient(ctx)
+
+		req.userRepo, err = users.NewRepository(ctx, dynamoClient, envConfig)
+		if err != nil {
+			return
+		}
+
+		req.sessionRepo, err = sessions.NewRepository(ctx, dynamoClient, envConfig)
+		if err != nil {
+			return
+		}
+
+		req.mailSender, err = mail.New(envConfig)
+		if err != nil {
+			return
+		}
+
+		req.invalidTokenManager = cache.NewRedisCache()
+	})
+	req.startingTime = time.Now()
+	req.err = nil
+
+	return err
+}
+
+func (req *requestPasswordResetHandler) finish() {
+	logger.LogLambdaTime(req.startingTime, req.err, recover())
+}
+
+func (req *requestPasswordResetHandler) processPasswordReset(ctx context.Context, request *apigateway.Request) (*apigateway.Response, error) {
+	reqBody, err := getPasswordResetBody(request)
+	if err != nil {
+		req.err = err
+		logger.Error("validate_input_failed", err, request)
+
+		return request.NewErrorResponse(err), nil
+	}
+
+	err = validate.Email(reqBody.Username)
+	if err != nil {
+		req.err = err
+		logger.Error("validate_input_failed", err, request)
+
+		return request.NewErrorResponse(err), nil
+	}
+
+	requestReset := usecases.NewPasswordResetRequester(req.userRepo, req.mailSender)
+
+	err = requestReset(ctx, reqBody.Username)
+	if err != nil {
+		req.err = err
+		logger.Error("password_reset_request_failed", err, request)
+
+		return request.NewErrorResponse(err), nil
+	}
+
+	// The response doesn't tell if the user exists, so it's the same when no email was sent.
+	return request.NewJSONResponse(http.StatusAccepted, nil), nil
+}
+
+func (req *requestPasswordResetHandler) processPasswordResetConfirm(ctx context.Context, request *apigateway.Request) (*apigateway.Response, error) {
+	reqBody, err := getPasswordResetBody(request)
+	if err != nil {
+		req.err = err
+		logger.Error("validate_input_failed", err, request)
+
+		return request.NewErrorResponse(err), nil
+	}
+
+	err = validatePasswordResetConfirmBody(reqBody)
+	if err != nil {
+		req.err = err
+		logger.Error("validate_input_failed", err, request)
+
+		return request.NewErrorResponse(err), nil
+	}
+
+	resetPassword := usecases.NewPasswordResetter(req.userRepo, req.sessionRepo, req.invalidTokenManager)
+
+	err = resetPassword(ctx, reqBody.Username, reqBody.Token, reqBody.Password)
+	if err != nil {
+		req.err = err
+		logger.Error("password_reset_failed", err, request)
+
+		return request.NewErrorResponse(err), nil
+	}
+
+	logger.Info("password_reset_succeeded", request)
+
+	// The sessions of the user were revoked, so the one of this client is removed too.
+	return request.NewJSONResponse(http.StatusNoContent, nil, apigateway.Header{
+		Key:   "Set-Cookie",
+		Value: getExpiredRefreshTokenCookie(),
+	}), nil
+}
+
+func getPasswordResetBody(request *apigateway.Request) (*passwordResetBody, error) {
+	reqBody := new(passwordResetBody)
+
+	err := json.Unmarshal([]byte(request.Body), reqBody)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", models.ErrInvalidRequestBody, err)
+	}
+
+	return reqBody, nil
+}
+
+func validatePasswordResetConfirmBody(reqBody *passwordResetBody) error {
+	err := validate.Email(reqBody.Username)
+	if err != nil {
+		return err
+	}
+
+	if reqBody.Token == "" {
+		return models.ErrMissingPasswordResetToken
+	}
+
+	if reqBody.Password == "" {
+		return models.ErrMissingNewPassword
+	}
+
+	return nil
+}
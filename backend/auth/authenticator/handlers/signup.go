@@ -1,14 +1,17 @@
-package main
+package handlers
 
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"github.com/JoelD7/money/backend/models"
 	"github.com/JoelD7/money/backend/shared/apigateway"
 	"github.com/JoelD7/money/backend/shared/logger"
+	"github.com/JoelD7/money/backend/shared/mail"
 	"github.com/JoelD7/money/backend/shared/secrets"
 	"github.com/JoelD7/money/backend/storage/cache"
 	"github.com/JoelD7/money/backend/storage/dynamo"
+	"github.com/JoelD7/money/backend/storage/sessions"
 	"github.com/JoelD7/money/backend/storage/users"
 	"github.com/JoelD7/money/backend/usecases"
 	"net/http"
@@ -23,8 +26,16 @@ type requestSignUpHandler struct {
 	startingTime     time.Time
 	err              error
 	userRepo         users.Repository
+	sessionRepo      sessions.Repository
 	secretsManager   secrets.SecretManager
 	idempotenceCache cache.IdempotenceCacheManager
+	mailSender       mail.Sender
+}
+
+// verificationRequiredResponse is sent instead of the tokens when the new user can't get them until the email is
+// verified.
+type verificationRequiredResponse struct {
+	VerificationRequired bool `json:"verificationRequired"`
 }
 
 func signUpHandler(ctx context.Context, envConfig *models.EnvironmentConfiguration, request *apigateway.Request) (*apigateway.Response, error) {
@@ -51,12 +62,22 @@ func (req *requestSignUpHandler) initSignUpHandler(ctx context.Context, envConfi
 		logger.SetHandler("sign-up")
 		dynamoClient := dynamo.InitClient(ctx)
 
-		req.userRepo, err = users.NewDynamoRepository(dynamoClient, envConfig.UsersTable)
+		req.userRepo, err = users.NewRepository(ctx, dynamoClient, envConfig)
+		if err != nil {
+			return
+		}
+
+		req.sessionRepo, err = sessions.NewRepository(ctx, dynamoClient, envConfig)
 		if err != nil {
 			return
 		}
 		req.secretsManager = secrets.NewAWSSecretManager()
 
+		req.mailSender, err = mail.New(envConfig)
+		if err != nil {
+			return
+		}
+
 		req.idempotenceCache = cache.NewRedisCache()
 		req.idempotenceCache.SetTTL(envConfig.IdempotencyKeyCacheTTLSeconds)
 	})
@@ -96,9 +117,23 @@ func (req *requestSignUpHandler) processSignUp(ctx context.Context, request *api
 		return request.NewErrorResponse(err), nil
 	}
 
-	generateTokens := usecases.NewUserTokenGenerator(req.userRepo, req.secretsManager)
+	sendVerification := usecases.NewEmailVerificationSender(req.secretsManager, req.mailSender)
+
+	// The account is already created, so the user can ask for the verification email again if this one fails.
+	err = sendVerification(ctx, newUser)
+	if err != nil {
+		logger.Error("email_verification_sending_failed", err, request)
+	}
+
+	generateTokens := usecases.NewUserTokenGenerator(req.sessionRepo, req.secretsManager)
+
+	accessToken, refreshToken, err := generateTokens(ctx, newUser, newSession(request))
+	if errors.Is(err, models.ErrUnverifiedUser) {
+		logger.Info("signup_succeeded", request)
+
+		return request.NewJSONResponse(http.StatusCreated, &verificationRequiredResponse{true}), nil
+	}
 
-	accessToken, refreshToken, err := generateTokens(ctx, newUser)
 	if err != nil {
 		return request.NewErrorResponse(err), nil
 	}
@@ -1,8 +1,9 @@
-package main
+package handlers
 
 import (
 	"context"
 	"github.com/JoelD7/money/backend/models"
+	"github.com/JoelD7/money/backend/storage/sessions"
 	"github.com/JoelD7/money/backend/storage/users"
 	"net/http"
 	"testing"
@@ -26,7 +27,8 @@ func TestSignUpHandler(t *testing.T) {
 	c.Nil(err)
 
 	request := &requestSignUpHandler{
-		userRepo: usersMock,
+		userRepo:    usersMock,
+		sessionRepo: sessions.NewMemoryRepository(),
 	}
 
 	apigwRequest := &apigateway.Request{Body: jsonBody}
@@ -51,7 +53,8 @@ func TestSignUpHandlerFailed(t *testing.T) {
 	usersMock := users.NewDynamoMock()
 
 	request := &requestSignUpHandler{
-		userRepo: usersMock,
+		userRepo:    usersMock,
+		sessionRepo: sessions.NewMemoryRepository(),
 	}
 
 	t.Run("Existing user error", func(t *testing.T) {
@@ -1,15 +1,15 @@
-package main
+package handlers
 
 import (
 	"context"
 	"encoding/json"
-	"errors"
 	"github.com/JoelD7/money/backend/models"
 	"github.com/JoelD7/money/backend/shared/apigateway"
 	"github.com/JoelD7/money/backend/shared/logger"
 	"github.com/JoelD7/money/backend/shared/secrets"
 	"github.com/JoelD7/money/backend/storage/cache"
 	"github.com/JoelD7/money/backend/storage/dynamo"
+	"github.com/JoelD7/money/backend/storage/sessions"
 	"github.com/JoelD7/money/backend/storage/users"
 	"github.com/JoelD7/money/backend/usecases"
 	"net/http"
@@ -28,6 +28,7 @@ type requestTokenHandler struct {
 	startingTime        time.Time
 	err                 error
 	userRepo            users.Repository
+	sessionRepo         sessions.Repository
 	invalidTokenManager cache.InvalidTokenManager
 	secretsManager      secrets.SecretManager
 }
@@ -54,7 +55,12 @@ func (req *requestTokenHandler) initTokenHandler(ctx context.Context, envConfig
 	once.Do(func() {
 		dynamoClient := dynamo.InitClient(ctx)
 
-		req.userRepo, err = users.NewDynamoRepository(dynamoClient, envConfig.UsersTable)
+		req.userRepo, err = users.NewRepository(ctx, dynamoClient, envConfig)
+		if err != nil {
+			return
+		}
+
+		req.sessionRepo, err = sessions.NewRepository(ctx, dynamoClient, envConfig)
 		if err != nil {
 			return
 		}
@@ -83,33 +89,15 @@ func (req *requestTokenHandler) processToken(ctx context.Context, request *apiga
 		return request.NewErrorResponse(err), nil
 	}
 
-	validateRefreshToken := usecases.NewRefreshTokenValidator(req.userRepo)
-
-	user, err := validateRefreshToken(ctx, req.RefreshToken)
-
-	if err != nil && errors.Is(err, models.ErrInvalidToken) {
-		req.err = err
-
-		return req.handleValidationError(ctx, user, request)
-	}
-
-	if errors.Is(err, models.ErrUserNotFound) {
-		return request.NewErrorResponse(errUserNotFound), nil
-	}
+	refreshTokens := usecases.NewTokenRefresher(req.userRepo, req.sessionRepo, req.secretsManager, req.invalidTokenManager)
 
+	accessToken, refreshToken, err := refreshTokens(ctx, req.RefreshToken)
 	if err != nil {
 		req.err = err
 
 		return request.NewErrorResponse(err), nil
 	}
 
-	generateTokens := usecases.NewUserTokenGenerator(req.userRepo, req.secretsManager)
-
-	accessToken, refreshToken, err := generateTokens(ctx, user)
-	if err != nil {
-		return request.NewErrorResponse(err), nil
-	}
-
 	response := &accessTokenResponse{accessToken.Value}
 
 	data, err := json.Marshal(response)
@@ -119,18 +107,7 @@ func (req *requestTokenHandler) processToken(ctx context.Context, request *apiga
 
 	cookieStr := getRefreshTokenCookieStr(refreshToken.Value, refreshToken.Expiration)
 
-	logger.Info("new_tokens_issued_successfully", user)
+	logger.Info("new_tokens_issued_successfully", request)
 
 	return request.NewJSONResponse(http.StatusOK, string(data), apigateway.Header{Key: "Set-Cookie", Value: cookieStr}), nil
 }
-
-func (req *requestTokenHandler) handleValidationError(ctx context.Context, user *models.User, request *apigateway.Request) (*apigateway.Response, error) {
-	invalidateTokens := usecases.NewTokenInvalidator(req.invalidTokenManager)
-
-	err := invalidateTokens(ctx, user)
-	if err != nil {
-		return request.NewErrorResponse(err), nil
-	}
-
-	return request.NewErrorResponse(models.ErrInvalidToken), nil
-}
@@ -1,4 +1,4 @@
-package main
+package handlers
 
 import (
 	"context"
@@ -11,6 +11,7 @@ import (
 	"github.com/JoelD7/money/backend/shared/apigateway"
 	"github.com/JoelD7/money/backend/shared/secrets"
 	"github.com/JoelD7/money/backend/storage/cache"
+	"github.com/JoelD7/money/backend/storage/sessions"
 	"github.com/JoelD7/money/backend/storage/users"
 	"github.com/stretchr/testify/require"
 )
@@ -29,6 +30,7 @@ func TestTokenHandler(t *testing.T) {
 	request := &requestTokenHandler{
 		secretsManager:      secretMock,
 		userRepo:            usersMock,
+		sessionRepo:         sessions.NewMemoryRepository(),
 		invalidTokenManager: cache.NewRedisCacheMock(),
 	}
 
@@ -66,6 +68,7 @@ func TestTokenHandlerFailed(t *testing.T) {
 	request := &requestTokenHandler{
 		secretsManager:      secretMock,
 		userRepo:            usersMock,
+		sessionRepo:         sessions.NewMemoryRepository(),
 		invalidTokenManager: redisMock,
 	}
 
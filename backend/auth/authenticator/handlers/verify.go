@@ -0,0 +1,189 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/JoelD7/money/backend/models"
+	"github.com/JoelD7/money/backend/shared/apigateway"
+	"github.com/JoelD7/money/backend/shared/logger"
+	"github.com/JoelD7/money/backend/shared/mail"
+	"github.com/JoelD7/money/backend/shared/secrets"
+	"github.com/JoelD7/money/backend/shared/validate"
+	"github.com/JoelD7/money/backend/storage/dynamo"
+	"github.com/JoelD7/money/backend/storage/users"
+	"github.com/JoelD7/money/backend/usecases"
+	"net/http"
+	"sync"
+	"time"
+)
+
+var emailVerificationRequest *requestEmailVerificationHandler
+var emailVerificationOnce sync.Once
+
+type requestEmailVerificationHandler struct {
+	startingTime   time.Time
+	err            error
+	userRepo       users.Repository
+	secretsManager secrets.SecretManager
+	mailSender     mail.Sender
+}
+
+type emailVerificationBody struct {
+	Token    string `json:"token,omitempty"`
+	Username string `json:"username,omitempty"`
+}
+
+// verifyEmailHandler verifies the email of a user with the token sent on the sign-up. The token comes in the query
+// string when the user opens the link of the email, or in the body when it's sent by the frontend.
+func verifyEmailHandler(ctx context.Context, envConfig *models.EnvironmentConfiguration, request *apigateway.Request) (*apigateway.Response, error) {
+	err := initEmailVerificationRequest(ctx, envConfig)
+	if err != nil {
+		logger.Error("email_verification_init_failed", err, request)
+
+		return request.NewErrorResponse(err), nil
+	}
+	defer emailVerificationRequest.finish()
+
+	return emailVerificationRequest.processVerifyEmail(ctx, request)
+}
+
+// resendEmailVerificationHandler sends a new verification token to the user.
+func resendEmailVerificationHandler(ctx context.Context, envConfig *models.EnvironmentConfiguration, request *apigateway.Request) (*apigateway.Response, error) {
+	err := initEmailVerificationRequest(ctx, envConfig)
+	if err != nil {
+		logger.Error("email_verification_resend_init_failed", err, request)
+
+		return request.NewErrorResponse(err), nil
+	}
+	defer emailVerificationRequest.finish()
+
+	return emailVerificationRequest.processResendEmailVerification(ctx, request)
+}
+
+func initEmailVerificationRequest(ctx context.Context, envConfig *models.EnvironmentConfiguration) error {
+	if emailVerificationRequest == nil {
+		emailVerificationRequest = new(requestEmailVerificationHandler)
+	}
+
+	err := emailVerificationRequest.initEmailVerificationHandler(ctx, envConfig)
+	if err != nil {
+		emailVerificationRequest.err = err
+	}
+
+	return err
+}
+
+func (req *requestEmailVerificationHandler) initEmailVerificationHandler(ctx context.Context, envConfig *models.EnvironmentConfiguration) error {
+	var err error
+	emailVerificationOnce.Do(func() {
+		logger.SetHandler("email-verification")
+		dynamoClient := dynamo.InitClient(ctx)
+
+		req.userRepo, err = users.NewRepository(ctx, dynamoClient, envConfig)
+		if err != nil {
+			return
+		}
+
+		req.mailSender, err = mail.New(envConfig)
+		if err != nil {
+			return
+		}
+
+		req.secretsManager = secrets.NewAWSSecretManager()
+	})
+	req.startingTime = time.Now()
+	req.err = nil
+
+	return err
+}
+
+func (req *requestEmailVerificationHandler) finish() {
+	logger.LogLambdaTime(req.startingTime, req.err, recover())
+}
+
+func (req *requestEmailVerificationHandler) processVerifyEmail(ctx context.Context, request *apigateway.Request) (*apigateway.Response, error) {
+	token, err := getEmailVerificationToken(request)
+	if err != nil {
+		req.err = err
+		logger.Error("validate_input_failed", err, request)
+
+		return request.NewErrorResponse(err), nil
+	}
+
+	verifyEmail := usecases.NewEmailVerifier(req.userRepo, req.secretsManager)
+
+	_, err = verifyEmail(ctx, token)
+	if err != nil {
+		req.err = err
+		logger.Error("email_verification_failed", err, request)
+
+		return request.NewErrorResponse(err), nil
+	}
+
+	return request.NewJSONResponse(http.StatusNoContent, nil), nil
+}
+
+func (req *requestEmailVerificationHandler) processResendEmailVerification(ctx context.Context, request *apigateway.Request) (*apigateway.Response, error) {
+	reqBody, err := getEmailVerificationBody(request)
+	if err != nil {
+		req.err = err
+		logger.Error("validate_input_failed", err, request)
+
+		return request.NewErrorResponse(err), nil
+	}
+
+	err = validate.Email(reqBody.Username)
+	if err != nil {
+		req.err = err
+		logger.Error("validate_input_failed", err, request)
+
+		return request.NewErrorResponse(err), nil
+	}
+
+	resendVerification := usecases.NewEmailVerificationResender(req.userRepo, req.secretsManager, req.mailSender)
+
+	err = resendVerification(ctx, reqBody.Username)
+	if err != nil {
+		req.err = err
+		logger.Error("email_verification_resend_failed", err, request)
+
+		return request.NewErrorResponse(err), nil
+	}
+
+	// Same response whether the user exists or not, or is already verified.
+	return request.NewJSONResponse(http.StatusAccepted, nil), nil
+}
+
+func getEmailVerificationToken(request *apigateway.Request) (string, error) {
+	if request.HTTPMethod == http.MethodGet {
+		token := request.QueryStringParameters["token"]
+		if token == "" {
+			return "", models.ErrMissingEmailVerificationToken
+		}
+
+		return token, nil
+	}
+
+	reqBody, err := getEmailVerificationBody(request)
+	if err != nil {
+		return "", err
+	}
+
+	if reqBody.Token == "" {
+		return "", models.ErrMissingEmailVerificationToken
+	}
+
+	return reqBody.Token, nil
+}
+
+func getEmailVerificationBody(request *apigateway.Request) (*emailVerificationBody, error) {
+	reqBody := new(emailVerificationBody)
+
+	err := json.Unmarshal([]byte(request.Body), reqBody)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", models.ErrInvalidRequestBody, err)
+	}
+
+	return reqBody, nil
+}
@@ -0,0 +1,59 @@
+// This command runs the APIs of every lambda function in a single HTTP server, so that the backend can be used without
+// deploying it. The environment is read from a .env file instead of AWS Secrets Manager. Access tokens are validated
+// against the JWKS of their issuer, so TOKEN_ISSUER should be the address of this server, e.g. http://localhost:8080.
+// Set STORAGE_BACKEND=sql, SQL_DRIVER=sqlite and SQL_DATA_SOURCE=money.db to store the data in a SQLite file instead
+// of DynamoDB. Pagination cursors are sealed with a random key when PAGINATION_KEY_SECRET isn't set, so they aren't
+// valid after a restart.
+package main
+
+import (
+	"flag"
+	"fmt"
+	expenses "github.com/JoelD7/money/backend/api/functions/expenses/handlers"
+	income "github.com/JoelD7/money/backend/api/functions/income/handlers"
+	users "github.com/JoelD7/money/backend/api/functions/users/handlers"
+	authenticator "github.com/JoelD7/money/backend/auth/authenticator/handlers"
+	"github.com/JoelD7/money/backend/shared/env"
+	"github.com/JoelD7/money/backend/shared/logger"
+	"github.com/JoelD7/money/backend/shared/restclient"
+	"github.com/JoelD7/money/backend/shared/secrets"
+	"github.com/JoelD7/money/backend/storage/cache"
+	"github.com/JoelD7/money/backend/storage/cursor"
+	"github.com/JoelD7/money/backend/usecases"
+	"github.com/joho/godotenv"
+	"net/http"
+)
+
+func main() {
+	addr := flag.String("addr", ":8080", "address the server listens on")
+	envFile := flag.String("env", ".env", "file with the environment variables of the lambda functions")
+	flag.Parse()
+
+	err := godotenv.Load(*envFile)
+	if err != nil {
+		panic(fmt.Errorf("failed to load environment variables: %w", err))
+	}
+
+	logger.InitLogger(logger.ConsoleImplementation)
+
+	cursor.UseEphemeralSecret()
+
+	envConfig := env.GetEnvConfig()
+
+	s := &server{
+		apis: []api{
+			{router: authenticator.NewRouter(envConfig)},
+			{router: users.NewRouter(envConfig), authorize: true},
+			{router: expenses.NewRouter(envConfig), authorize: true},
+			{router: income.NewRouter(envConfig), authorize: true},
+		},
+		verifyToken: usecases.NewTokenVerifier(restclient.New(), secrets.NewAWSSecretManager(), cache.NewRedisCache()),
+	}
+
+	fmt.Printf("Listening on %s\n", *addr)
+
+	err = http.ListenAndServe(*addr, s)
+	if err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,204 @@
+package main
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"github.com/JoelD7/money/backend/shared/apigateway"
+	"github.com/JoelD7/money/backend/shared/logger"
+	"github.com/JoelD7/money/backend/shared/router"
+	"github.com/JoelD7/money/backend/shared/uuid"
+	"github.com/aws/aws-lambda-go/events"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+var (
+	errPathNotFound = errors.New("no API handles this path")
+	errUnauthorized = apigateway.NewError("Unauthorized", http.StatusUnauthorized)
+)
+
+// tokenVerifier validates an access token and returns its subject, like the lambda authorizer does.
+type tokenVerifier func(ctx context.Context, token string) (string, error)
+
+// api is one of the routers that run as a lambda function behind API Gateway.
+type api struct {
+	router *router.Router
+	// authorize tells whether the requests to the API go through the lambda authorizer.
+	authorize bool
+}
+
+// server turns HTTP requests into API Gateway requests and hands them to the router of the API that handles them.
+type server struct {
+	apis        []api
+	verifyToken tokenVerifier
+	// mu makes the server handle one request at a time, as a lambda function does. The handlers keep their state in
+	// package variables, so they aren't safe to run concurrently.
+	mu sync.Mutex
+}
+
+func (s *server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	logger.AddToContext("request_id", uuid.Generate(r.URL.Path))
+
+	apiRouter, request, err := s.newRequest(r)
+	if errors.Is(err, errPathNotFound) && r.Method == http.MethodOptions {
+		writePreflightResponse(w, r)
+		return
+	}
+
+	if errors.Is(err, errPathNotFound) {
+		http.NotFound(w, r)
+		return
+	}
+
+	if err != nil {
+		writeResponse(w, (&apigateway.Request{}).NewErrorResponse(err))
+		return
+	}
+
+	response, err := apiRouter.router.Handle(r.Context(), request)
+	if err != nil {
+		logger.Error("local_server_handle_failed", err, request)
+
+		writeResponse(w, request.NewErrorResponse(err))
+		return
+	}
+
+	writeResponse(w, response)
+}
+
+// newRequest builds the request API Gateway would send to the lambda function of the API that handles r.
+func (s *server) newRequest(r *http.Request) (*api, *apigateway.Request, error) {
+	for i := range s.apis {
+		resource, pathParameters, ok := s.apis[i].router.Match(r.Method, r.URL.Path)
+		if !ok {
+			continue
+		}
+
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		request := &apigateway.Request{
+			Resource:                        resource,
+			Path:                            r.URL.Path,
+			HTTPMethod:                      r.Method,
+			Headers:                         make(map[string]string),
+			MultiValueHeaders:               make(map[string][]string),
+			QueryStringParameters:           make(map[string]string),
+			MultiValueQueryStringParameters: r.URL.Query(),
+			PathParameters:                  pathParameters,
+			Body:                            string(body),
+			RequestContext: events.APIGatewayProxyRequestContext{
+				ExtendedRequestID: uuid.Generate(r.URL.Path),
+				ResourcePath:      resource,
+				HTTPMethod:        r.Method,
+				Path:              r.URL.Path,
+				Identity: events.APIGatewayRequestIdentity{
+					SourceIP: getSourceIP(r),
+				},
+			},
+		}
+
+		for name, values := range r.URL.Query() {
+			request.QueryStringParameters[name] = values[len(values)-1]
+		}
+
+		for name, values := range r.Header {
+			// Clients may send header names in any case, and the handlers read some of them in lower case.
+			request.Headers[name] = strings.Join(values, ",")
+			request.Headers[strings.ToLower(name)] = strings.Join(values, ",")
+			request.MultiValueHeaders[name] = values
+		}
+
+		if s.apis[i].authorize {
+			username, err := s.authorize(r)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			request.RequestContext.Authorizer = map[string]interface{}{
+				"username": username,
+			}
+		}
+
+		return &s.apis[i], request, nil
+	}
+
+	return nil, nil, errPathNotFound
+}
+
+func (s *server) authorize(r *http.Request) (string, error) {
+	token := strings.ReplaceAll(r.Header.Get("Authorization"), "Bearer ", "")
+	if token == "" {
+		return "", errUnauthorized
+	}
+
+	username, err := s.verifyToken(r.Context(), token)
+	if err != nil {
+		logger.Error("request_unauthorized", err, nil)
+
+		return "", errUnauthorized
+	}
+
+	return username, nil
+}
+
+// getSourceIP returns the address of the client, without the port, as API Gateway sets it.
+func getSourceIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+
+	return host
+}
+
+func writeResponse(w http.ResponseWriter, response *apigateway.Response) {
+	for name, value := range response.Headers {
+		w.Header().Set(name, value)
+	}
+
+	for name, values := range response.MultiValueHeaders {
+		for _, value := range values {
+			w.Header().Add(name, value)
+		}
+	}
+
+	body := []byte(response.Body)
+
+	if response.IsBase64Encoded {
+		decoded, err := base64.StdEncoding.DecodeString(response.Body)
+		if err != nil {
+			logger.Error("decoding_response_body_failed", err, nil)
+
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		body = decoded
+	}
+
+	w.WriteHeader(response.StatusCode)
+
+	_, err := w.Write(body)
+	if err != nil {
+		logger.Error("writing_response_failed", err, nil)
+	}
+}
+
+// writePreflightResponse answers the CORS preflight requests, which are handled by API Gateway when deployed.
+func writePreflightResponse(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Access-Control-Allow-Origin", r.Header.Get("Origin"))
+	w.Header().Set("Access-Control-Allow-Credentials", "true")
+	w.Header().Set("Access-Control-Allow-Methods", "GET,HEAD,POST,PUT,PATCH,DELETE,OPTIONS")
+	w.Header().Set("Access-Control-Allow-Headers", r.Header.Get("Access-Control-Request-Headers"))
+	w.WriteHeader(http.StatusNoContent)
+}
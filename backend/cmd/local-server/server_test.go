@@ -0,0 +1,111 @@
+package main
+
+import (
+	"context"
+	"github.com/JoelD7/money/backend/models"
+	"github.com/JoelD7/money/backend/shared/apigateway"
+	"github.com/JoelD7/money/backend/shared/logger"
+	"github.com/JoelD7/money/backend/shared/router"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func echoHandler(ctx context.Context, envConfig *models.EnvironmentConfiguration, request *apigateway.Request) (*apigateway.Response, error) {
+	return request.NewJSONResponse(http.StatusOK, map[string]interface{}{
+		"resource":        request.Resource,
+		"path_parameters": request.PathParameters,
+		"query":           request.QueryStringParameters["period"],
+		"username":        request.RequestContext.Authorizer["username"],
+		"body":            request.Body,
+	}), nil
+}
+
+func newTestServer() *server {
+	authRouter := router.NewRouter(&models.EnvironmentConfiguration{})
+	authRouter.Route("/auth", func(r *router.Router) {
+		r.Post("/login", echoHandler)
+	})
+
+	expensesRouter := router.NewRouter(&models.EnvironmentConfiguration{})
+	expensesRouter.Route("/", func(r *router.Router) {
+		r.Route("/expenses", func(r *router.Router) {
+			r.Get("/{expenseID}", echoHandler)
+		})
+	})
+
+	return &server{
+		apis: []api{
+			{router: authRouter},
+			{router: expensesRouter, authorize: true},
+		},
+		verifyToken: func(ctx context.Context, token string) (string, error) {
+			if token != "valid" {
+				return "", models.ErrInvalidToken
+			}
+
+			return "test@gmail.com", nil
+		},
+	}
+}
+
+func TestServeHTTP(t *testing.T) {
+	c := require.New(t)
+
+	logger.InitLogger(logger.ConsoleImplementation)
+
+	s := newTestServer()
+
+	t.Run("Authorized request", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "/expenses/EX123?period=2024-01", nil)
+		r.Header.Set("Authorization", "Bearer valid")
+
+		w := httptest.NewRecorder()
+		s.ServeHTTP(w, r)
+
+		c.Equal(http.StatusOK, w.Code)
+		c.JSONEq(`{"resource":"/expenses/{expenseID}","path_parameters":{"expenseID":"EX123"},"query":"2024-01",
+			"username":"test@gmail.com","body":""}`, w.Body.String())
+	})
+
+	t.Run("Public request", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodPost, "/auth/login", strings.NewReader(`{"username":"test@gmail.com"}`))
+
+		w := httptest.NewRecorder()
+		s.ServeHTTP(w, r)
+
+		c.Equal(http.StatusOK, w.Code)
+		c.Contains(w.Body.String(), `"username":null`)
+		c.Contains(w.Body.String(), `test@gmail.com`)
+	})
+
+	t.Run("Invalid token", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "/expenses/EX123", nil)
+		r.Header.Set("Authorization", "Bearer invalid")
+
+		w := httptest.NewRecorder()
+		s.ServeHTTP(w, r)
+
+		c.Equal(http.StatusUnauthorized, w.Code)
+	})
+
+	t.Run("Path not found", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		s.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/income", nil))
+
+		c.Equal(http.StatusNotFound, w.Code)
+	})
+
+	t.Run("Preflight request", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodOptions, "/expenses/EX123", nil)
+		r.Header.Set("Origin", "http://localhost:5173")
+
+		w := httptest.NewRecorder()
+		s.ServeHTTP(w, r)
+
+		c.Equal(http.StatusNoContent, w.Code)
+		c.Equal("http://localhost:5173", w.Header().Get("Access-Control-Allow-Origin"))
+	})
+}
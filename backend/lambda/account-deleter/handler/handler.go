@@ -0,0 +1,239 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/JoelD7/money/backend/models"
+	"github.com/JoelD7/money/backend/shared/env"
+	"github.com/JoelD7/money/backend/shared/logger"
+	"github.com/JoelD7/money/backend/storage/accountdeletion"
+	"github.com/JoelD7/money/backend/storage/audit"
+	"github.com/JoelD7/money/backend/storage/budgetalert"
+	"github.com/JoelD7/money/backend/storage/cache"
+	"github.com/JoelD7/money/backend/storage/dynamo"
+	"github.com/JoelD7/money/backend/storage/exchangerate"
+	"github.com/JoelD7/money/backend/storage/expenses"
+	expensesRecurring "github.com/JoelD7/money/backend/storage/expenses-recurring"
+	"github.com/JoelD7/money/backend/storage/income"
+	"github.com/JoelD7/money/backend/storage/period"
+	"github.com/JoelD7/money/backend/storage/savingoal"
+	"github.com/JoelD7/money/backend/storage/savings"
+	"github.com/JoelD7/money/backend/storage/sessions"
+	"github.com/JoelD7/money/backend/storage/trash"
+	"github.com/JoelD7/money/backend/storage/users"
+	"github.com/JoelD7/money/backend/usecases"
+	"github.com/aws/aws-lambda-go/events"
+	"sync"
+	"time"
+)
+
+var (
+	adRequest *Request
+	adOnce    sync.Once
+)
+
+type Request struct {
+	startingTime          time.Time
+	err                   error
+	AccountDeletionRepo   accountdeletion.Repository
+	AccountDeletionQueue  accountdeletion.Queue
+	UserRepo              users.Repository
+	ExpensesRepo          expenses.Repository
+	ExpensesRecurringRepo expensesRecurring.Repository
+	IncomeRepo            income.Repository
+	SavingsRepo           savings.Repository
+	SavingGoalRepo        savingoal.Repository
+	// BudgetAlertRepo is only set when budget alerts are enabled.
+	BudgetAlertRepo  usecases.BudgetAlertManager
+	PeriodRepo       period.Repository
+	ExchangeRateRepo exchangerate.Repository
+	TrashRepo        trash.Repository
+	// AuditRepo is only set when the audit log is enabled.
+	AuditRepo   usecases.AuditManager
+	SessionRepo sessions.Repository
+	UserCache   cache.UserCacheManager
+}
+
+func (request *Request) init(ctx context.Context) error {
+	var err error
+
+	adOnce.Do(func() {
+		var envConfig *models.EnvironmentConfiguration
+
+		envConfig, err = env.LoadEnv(ctx)
+		if err != nil {
+			return
+		}
+
+		dynamoClient := dynamo.InitClient(ctx)
+
+		request.AccountDeletionRepo, err = accountdeletion.NewRepository(ctx, dynamoClient, envConfig)
+		if err != nil {
+			return
+		}
+
+		request.AccountDeletionQueue, err = accountdeletion.NewSQSQueue(envConfig)
+		if err != nil {
+			return
+		}
+
+		request.UserRepo, err = users.NewRepository(ctx, dynamoClient, envConfig)
+		if err != nil {
+			return
+		}
+
+		request.ExpensesRepo, err = expenses.NewRepository(ctx, dynamoClient, envConfig)
+		if err != nil {
+			return
+		}
+
+		request.ExpensesRecurringRepo, err = expensesRecurring.NewRepository(ctx, dynamoClient, envConfig)
+		if err != nil {
+			return
+		}
+
+		request.IncomeRepo, err = income.NewRepository(ctx, dynamoClient, envConfig)
+		if err != nil {
+			return
+		}
+
+		request.SavingsRepo, err = savings.NewRepository(ctx, dynamoClient, envConfig)
+		if err != nil {
+			return
+		}
+
+		request.SavingGoalRepo, err = savingoal.NewRepository(ctx, dynamoClient, envConfig)
+		if err != nil {
+			return
+		}
+
+		if envConfig.BudgetAlertsTable != "" {
+			request.BudgetAlertRepo, err = budgetalert.NewRepository(ctx, dynamoClient, envConfig)
+			if err != nil {
+				return
+			}
+		}
+
+		request.PeriodRepo, err = period.NewRepository(ctx, dynamoClient, envConfig)
+		if err != nil {
+			return
+		}
+
+		request.ExchangeRateRepo, err = exchangerate.NewRepository(ctx, dynamoClient, envConfig)
+		if err != nil {
+			return
+		}
+
+		request.TrashRepo, err = trash.NewRepository(ctx, dynamoClient, envConfig)
+		if err != nil {
+			return
+		}
+
+		if audit.IsEnabled(envConfig) {
+			request.AuditRepo, err = audit.NewRepository(ctx, dynamoClient, envConfig)
+			if err != nil {
+				return
+			}
+		}
+
+		request.SessionRepo, err = sessions.NewRepository(ctx, dynamoClient, envConfig)
+		if err != nil {
+			return
+		}
+
+		request.UserCache = cache.NewRedisCache()
+	})
+	request.startingTime = time.Now()
+
+	return err
+}
+
+func (request *Request) finish() {
+	logger.LogLambdaTime(request.startingTime, request.err, recover())
+}
+
+func Handle(ctx context.Context, sqsEvent events.SQSEvent) error {
+	if adRequest == nil {
+		adRequest = &Request{}
+	}
+
+	err := adRequest.init(ctx)
+	if err != nil {
+		logger.Error("init_failed", err, nil)
+
+		return err
+	}
+	defer adRequest.finish()
+
+	for _, record := range sqsEvent.Records {
+		err = adRequest.ProcessMessage(ctx, models.SQSMessage{SQSMessage: record})
+		if err != nil {
+			adRequest.err = err
+			return err
+		}
+	}
+
+	logger.Info("message_processing_successful", models.Any("message_data", map[string]interface{}{
+		"i_message_count": len(sqsEvent.Records),
+	}))
+
+	return nil
+}
+
+// ProcessMessage deletes the data of the user in the message. If the deletion doesn't finish before the lambda times
+// out, the message is sent again so that another invocation resumes it.
+func (request *Request) ProcessMessage(ctx context.Context, record models.SQSMessage) error {
+	msgBody, err := validateMessageBody(record)
+	if err != nil {
+		logger.Error("validate_request_body_failed", err, models.Any("record", record))
+
+		return err
+	}
+
+	logger.Info("received_message", models.Any("message_data", msgBody))
+
+	deleteAccount := usecases.NewAccountDeleter(request.AccountDeletionRepo, request.UserRepo, request.ExpensesRepo,
+		request.ExpensesRecurringRepo, request.IncomeRepo, request.SavingsRepo, request.SavingGoalRepo, request.BudgetAlertRepo,
+		request.PeriodRepo, request.ExchangeRateRepo, request.TrashRepo, request.AuditRepo, request.SessionRepo,
+		request.UserCache)
+
+	accountDeletion, err := deleteAccount(ctx, msgBody.Username)
+	if err != nil {
+		logger.Error("account_deletion_failed", err, models.Any("record", record))
+
+		return err
+	}
+
+	if accountDeletion.Status == models.AccountDeletionStatusCompleted {
+		logger.Info("account_deletion_completed", models.Any("account_deletion", accountDeletion))
+
+		return nil
+	}
+
+	err = request.AccountDeletionQueue.SendAccountDeletion(ctx, msgBody.Username)
+	if err != nil {
+		logger.Error("resend_account_deletion_failed", err, models.Any("account_deletion", accountDeletion))
+
+		return err
+	}
+
+	logger.Info("account_deletion_paused", models.Any("account_deletion", accountDeletion))
+
+	return nil
+}
+
+func validateMessageBody(record models.SQSMessage) (*models.AccountDeletionMessage, error) {
+	msgBody := new(models.AccountDeletionMessage)
+
+	err := json.Unmarshal([]byte(record.Body), msgBody)
+	if err != nil {
+		return nil, fmt.Errorf("invalid message body: %v", err)
+	}
+
+	if msgBody.Username == "" {
+		return nil, models.ErrMissingUsername
+	}
+
+	return msgBody, nil
+}
@@ -0,0 +1,388 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/JoelD7/money/backend/models"
+	"github.com/JoelD7/money/backend/shared/env"
+	"github.com/JoelD7/money/backend/shared/logger"
+	"github.com/JoelD7/money/backend/storage/dynamo"
+	"github.com/JoelD7/money/backend/storage/shared"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/expression"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"math/big"
+	"strconv"
+	"time"
+)
+
+const (
+	usernameAttribute  = "username"
+	amountKeyAttribute = "amount_key"
+	budgetAttribute    = "budget"
+)
+
+// Event is the input of the migration. With DryRun set, the items that need to be migrated are counted but not
+// updated.
+type Event struct {
+	DryRun bool `json:"dry_run"`
+}
+
+// amountTable describes a table that stores amounts of money. The partition key of every table is the username.
+type amountTable struct {
+	name string
+	// idAttribute is the sort key of the table. It's empty if the table only has a partition key.
+	idAttribute      string
+	amountAttributes []string
+	// hasAmountKey indicates whether the table has an amount_key attribute built from the amount and the ID.
+	hasAmountKey bool
+	// budgetsAttribute is a list of maps with a budget amount each, like the categories of a user.
+	budgetsAttribute string
+	// versioned indicates whether the items have a version attribute, which is checked so that an item that changed
+	// after the scan isn't overwritten.
+	versioned bool
+}
+
+// MigrationRequest rewrites the amounts stored as float64 before models.Money existed, so that every amount is a whole
+// number of cents and every amount_key is built from the exact amount. Amounts are readable without migrating them, as
+// they are rounded to the nearest cent when read; the migration makes the stored data match what's read, which matters
+// for the tools that read the tables directly and for sorting by amount. It's safe to run it more than once, as the
+// amounts that are already a whole number of cents are left as they are.
+type MigrationRequest struct {
+	client *dynamodb.Client
+	tables []*amountTable
+
+	err          error
+	startingTime time.Time
+}
+
+var errItemChanged = errors.New("the item changed after it was scanned")
+
+type migrationResult struct {
+	scanned  int
+	migrated int
+}
+
+func Handle(ctx context.Context, event Event) error {
+	req := new(MigrationRequest)
+	var err error
+
+	stackTrace, ctxError := shared.ExecuteLambda(ctx, func(ctx context.Context) {
+		req.init(ctx)
+
+		defer req.finish()
+
+		err = req.Process(ctx, event)
+	})
+
+	if ctxError != nil {
+		logger.Error("request_timeout", ctxError, models.Any("stack", map[string]interface{}{
+			"s_trace": stackTrace,
+		}))
+	}
+
+	if err != nil {
+		logger.Error("request_error", err, nil)
+
+		return err
+	}
+
+	return nil
+}
+
+func (req *MigrationRequest) init(ctx context.Context) {
+	envConfig := env.GetEnvConfig()
+
+	req.client = dynamo.InitClient(ctx)
+	req.tables = []*amountTable{
+		{name: envConfig.ExpensesTable, idAttribute: "expense_id", amountAttributes: []string{"amount"}, hasAmountKey: true},
+		{name: envConfig.IncomeTable, idAttribute: "income_id", amountAttributes: []string{"amount"}, hasAmountKey: true},
+		{name: envConfig.SavingsTable, idAttribute: "saving_id", amountAttributes: []string{"amount"}},
+		{name: envConfig.SavingGoalsTable, idAttribute: "saving_goal_id", amountAttributes: []string{"target", "recurring_amount"}},
+		{name: envConfig.ExpensesRecurringTable, idAttribute: "id", amountAttributes: []string{"amount"}},
+		{name: envConfig.UsersTable, amountAttributes: []string{"remainder"}, budgetsAttribute: "categories", versioned: true},
+	}
+	req.startingTime = time.Now()
+}
+
+func (req *MigrationRequest) finish() {
+	logger.LogLambdaTime(req.startingTime, req.err, recover())
+}
+
+func (req *MigrationRequest) Process(ctx context.Context, event Event) error {
+	for _, table := range req.tables {
+		if table.name == "" {
+			continue
+		}
+
+		result, err := req.migrateTable(ctx, table, event.DryRun)
+		if err != nil {
+			req.err = err
+			logger.Error("money_migration_failed", err, models.Any("table", map[string]interface{}{
+				"s_name": table.name,
+			}))
+
+			return err
+		}
+
+		logger.Info("money_migration_finished", models.Any("table", map[string]interface{}{
+			"s_name":     table.name,
+			"i_scanned":  result.scanned,
+			"i_migrated": result.migrated,
+			"b_dry_run":  event.DryRun,
+		}))
+	}
+
+	return nil
+}
+
+func (req *MigrationRequest) migrateTable(ctx context.Context, table *amountTable, dryRun bool) (*migrationResult, error) {
+	input, err := buildScanInput(table)
+	if err != nil {
+		return nil, err
+	}
+
+	result := new(migrationResult)
+	paginator := dynamodb.NewScanPaginator(req.client, input)
+
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("scan failed: %w", err)
+		}
+
+		for _, item := range page.Items {
+			result.scanned++
+
+			update, err := buildItemUpdate(table, item)
+			if err != nil {
+				return nil, err
+			}
+
+			if update == nil {
+				continue
+			}
+
+			if dryRun {
+				result.migrated++
+
+				continue
+			}
+
+			err = req.updateItem(ctx, table, item, update)
+			if errors.Is(err, errItemChanged) {
+				// The item was written after the scan, so its amounts were written as models.Money already.
+				continue
+			}
+
+			if err != nil {
+				return nil, err
+			}
+
+			result.migrated++
+		}
+	}
+
+	return result, nil
+}
+
+func buildScanInput(table *amountTable) (*dynamodb.ScanInput, error) {
+	projection := expression.NamesList(expression.Name(usernameAttribute))
+
+	if table.idAttribute != "" {
+		projection = projection.AddNames(expression.Name(table.idAttribute))
+	}
+
+	for _, attribute := range table.amountAttributes {
+		projection = projection.AddNames(expression.Name(attribute))
+	}
+
+	if table.hasAmountKey {
+		projection = projection.AddNames(expression.Name(amountKeyAttribute))
+	}
+
+	if table.budgetsAttribute != "" {
+		projection = projection.AddNames(expression.Name(table.budgetsAttribute))
+	}
+
+	if table.versioned {
+		projection = projection.AddNames(expression.Name(dynamo.VersionAttributeName))
+	}
+
+	expr, err := expression.NewBuilder().WithProjection(projection).Build()
+	if err != nil {
+		return nil, fmt.Errorf("build projection expression failed: %w", err)
+	}
+
+	return &dynamodb.ScanInput{
+		TableName:                aws.String(table.name),
+		ProjectionExpression:     expr.Projection(),
+		ExpressionAttributeNames: expr.Names(),
+	}, nil
+}
+
+// buildItemUpdate returns the update that migrates the item, or nil if the item is already migrated.
+func buildItemUpdate(table *amountTable, item map[string]types.AttributeValue) (*expression.UpdateBuilder, error) {
+	var update *expression.UpdateBuilder
+
+	set := func(name string, value interface{}) {
+		if update == nil {
+			update = new(expression.UpdateBuilder)
+		}
+
+		*update = update.Set(expression.Name(name), expression.Value(value))
+	}
+
+	for _, attribute := range table.amountAttributes {
+		number, ok := item[attribute].(*types.AttributeValueMemberN)
+		if !ok {
+			continue
+		}
+
+		amount, migrated, err := parseStoredAmount(number.Value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", attribute, err)
+		}
+
+		if !migrated {
+			set(attribute, amount)
+		}
+
+		if !table.hasAmountKey {
+			continue
+		}
+
+		id, _ := item[table.idAttribute].(*types.AttributeValueMemberS)
+		if id == nil {
+			continue
+		}
+
+		amountKey := dynamo.BuildAmountKey(amount, id.Value)
+
+		currentAmountKey, _ := item[amountKeyAttribute].(*types.AttributeValueMemberS)
+		if currentAmountKey == nil || currentAmountKey.Value != amountKey {
+			set(amountKeyAttribute, amountKey)
+		}
+	}
+
+	if table.budgetsAttribute == "" {
+		return update, nil
+	}
+
+	list, _ := item[table.budgetsAttribute].(*types.AttributeValueMemberL)
+	if list == nil {
+		return update, nil
+	}
+
+	for i, element := range list.Value {
+		budgets, ok := element.(*types.AttributeValueMemberM)
+		if !ok {
+			continue
+		}
+
+		number, ok := budgets.Value[budgetAttribute].(*types.AttributeValueMemberN)
+		if !ok {
+			continue
+		}
+
+		amount, migrated, err := parseStoredAmount(number.Value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s[%d].%s: %w", table.budgetsAttribute, i, budgetAttribute, err)
+		}
+
+		if !migrated {
+			set(fmt.Sprintf("%s[%d].%s", table.budgetsAttribute, i, budgetAttribute), amount)
+		}
+	}
+
+	return update, nil
+}
+
+// parseStoredAmount parses a number attribute into models.Money and tells whether it's migrated already, that is,
+// whether it's a whole number of cents. The numbers are compared instead of their text, as DynamoDB normalizes the
+// numbers it stores: 12.50 is stored as 12.5 and 100.00 as 100.
+func parseStoredAmount(value string) (models.Money, bool, error) {
+	amount, err := models.ParseMoney(value)
+	if err != nil {
+		return 0, false, err
+	}
+
+	stored, ok := new(big.Rat).SetString(value)
+	if !ok {
+		return 0, false, fmt.Errorf("%w: %q", models.ErrInvalidAmount, value)
+	}
+
+	return amount, stored.Cmp(big.NewRat(amount.Cents(), 100)) == 0, nil
+}
+
+func (req *MigrationRequest) updateItem(ctx context.Context, table *amountTable, item map[string]types.AttributeValue, update *expression.UpdateBuilder) error {
+	// The condition prevents recreating items that were deleted after the scan
+	condition := expression.AttributeExists(expression.Name(usernameAttribute))
+
+	if table.versioned {
+		// The budgets are updated by their position in the list, which may have changed after the scan
+		condition = condition.And(dynamo.VersionCondition(getItemVersion(item)))
+	}
+
+	key := map[string]types.AttributeValue{
+		usernameAttribute: item[usernameAttribute],
+	}
+
+	if table.idAttribute != "" {
+		key[table.idAttribute] = item[table.idAttribute]
+	}
+
+	expr, err := expression.NewBuilder().WithUpdate(*update).WithCondition(condition).Build()
+	if err != nil {
+		return fmt.Errorf("build update expression failed: %w", err)
+	}
+
+	_, err = req.client.UpdateItem(ctx, &dynamodb.UpdateItemInput{
+		TableName:                 aws.String(table.name),
+		Key:                       key,
+		UpdateExpression:          expr.Update(),
+		ConditionExpression:       expr.Condition(),
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+	})
+	var conditionErr *types.ConditionalCheckFailedException
+	if table.versioned && errors.As(err, &conditionErr) {
+		logger.Warning("money_migration_item_changed", errItemChanged, models.Any("item", map[string]interface{}{
+			"s_table":    table.name,
+			"s_username": getStringAttribute(item, usernameAttribute),
+		}))
+
+		return errItemChanged
+	}
+
+	if err != nil {
+		return fmt.Errorf("update item failed: %w", err)
+	}
+
+	return nil
+}
+
+func getItemVersion(item map[string]types.AttributeValue) int64 {
+	number, ok := item[dynamo.VersionAttributeName].(*types.AttributeValueMemberN)
+	if !ok {
+		return 0
+	}
+
+	version, err := strconv.ParseInt(number.Value, 10, 64)
+	if err != nil {
+		return 0
+	}
+
+	return version
+}
+
+func getStringAttribute(item map[string]types.AttributeValue, name string) string {
+	value, _ := item[name].(*types.AttributeValueMemberS)
+	if value == nil {
+		return ""
+	}
+
+	return value.Value
+}
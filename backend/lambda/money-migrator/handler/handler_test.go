@@ -0,0 +1,70 @@
+package handler
+
+import (
+	"github.com/JoelD7/money/backend/models"
+	"github.com/JoelD7/money/backend/storage/dynamo"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/expression"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestBuildItemUpdate(t *testing.T) {
+	c := require.New(t)
+
+	expensesTable := &amountTable{idAttribute: "expense_id", amountAttributes: []string{"amount"}, hasAmountKey: true}
+	usersTable := &amountTable{amountAttributes: []string{"remainder"}, budgetsAttribute: "categories", versioned: true}
+
+	newExpense := func(amount string) map[string]types.AttributeValue {
+		money, err := models.ParseMoney(amount)
+		c.NoError(err)
+
+		return map[string]types.AttributeValue{
+			"username":   &types.AttributeValueMemberS{Value: "test@gmail.com"},
+			"expense_id": &types.AttributeValueMemberS{Value: "EX123"},
+			"amount":     &types.AttributeValueMemberN{Value: amount},
+			"amount_key": &types.AttributeValueMemberS{Value: dynamo.BuildAmountKey(money, "EX123")},
+		}
+	}
+
+	newUser := func(budgets ...string) map[string]types.AttributeValue {
+		categories := make([]types.AttributeValue, 0, len(budgets))
+
+		for _, budget := range budgets {
+			categories = append(categories, &types.AttributeValueMemberM{Value: map[string]types.AttributeValue{
+				"budget": &types.AttributeValueMemberN{Value: budget},
+			}})
+		}
+
+		return map[string]types.AttributeValue{
+			"username":   &types.AttributeValueMemberS{Value: "test@gmail.com"},
+			"categories": &types.AttributeValueMemberL{Value: categories},
+		}
+	}
+
+	t.Run("Migrated amounts are normalized by DynamoDB", func(t *testing.T) {
+		for _, amount := range []string{"12.5", "100", "0.01", "-3.1"} {
+			update, err := buildItemUpdate(expensesTable, newExpense(amount))
+			c.NoError(err)
+			c.Nil(update, amount)
+		}
+
+		update, err := buildItemUpdate(usersTable, newUser("100", "12.5"))
+		c.NoError(err)
+		c.Nil(update)
+	})
+
+	t.Run("Float amounts are rounded to the cent", func(t *testing.T) {
+		update, err := buildItemUpdate(expensesTable, newExpense("12.499999999999998"))
+		c.NoError(err)
+		c.NotNil(update)
+
+		update, err = buildItemUpdate(usersTable, newUser("100", "33.333333333333336"))
+		c.NoError(err)
+		c.NotNil(update)
+
+		expr, err := expression.NewBuilder().WithUpdate(*update).Build()
+		c.NoError(err)
+		c.Contains(*expr.Update(), "[1].")
+	})
+}
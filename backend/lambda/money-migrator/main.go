@@ -0,0 +1,25 @@
+package main
+
+import (
+	"context"
+	"github.com/JoelD7/money/backend/lambda/money-migrator/handler"
+	"github.com/JoelD7/money/backend/shared/logger"
+	"github.com/JoelD7/money/backend/shared/uuid"
+	"github.com/aws/aws-lambda-go/lambda"
+)
+
+func main() {
+	lambda.Start(func(ctx context.Context, event handler.Event) error {
+		logger.InitLogger(logger.LogstashImplementation)
+		logger.AddToContext("request_id", uuid.Generate("money-migrator"))
+
+		defer func() {
+			err := logger.Finish()
+			if err != nil {
+				logger.ErrPrintln("failed to finish logger", err)
+			}
+		}()
+
+		return handler.Handle(ctx, event)
+	})
+}
@@ -6,11 +6,13 @@ import (
 	"github.com/JoelD7/money/backend/models"
 	"github.com/JoelD7/money/backend/shared/env"
 	"github.com/JoelD7/money/backend/shared/logger"
+	"github.com/JoelD7/money/backend/storage/audit"
 	"github.com/JoelD7/money/backend/storage/dynamo"
 	"github.com/JoelD7/money/backend/storage/expenses"
 	expenses_recurring "github.com/JoelD7/money/backend/storage/expenses-recurring"
 	"github.com/JoelD7/money/backend/storage/period"
 	"github.com/JoelD7/money/backend/storage/shared"
+	"github.com/JoelD7/money/backend/storage/users"
 	"github.com/JoelD7/money/backend/usecases"
 	"sync"
 	"time"
@@ -24,6 +26,7 @@ type CronRequest struct {
 	Repo         expenses_recurring.Repository
 	PeriodRepo   period.Repository
 	ExpensesRepo expenses.Repository
+	UsersRepo    users.Repository
 
 	err          error
 	startingTime time.Time
@@ -62,21 +65,25 @@ func Handle(ctx context.Context) error {
 func (req *CronRequest) init(ctx context.Context) error {
 	var err error
 	once.Do(func() {
-		expensesRecurringTableName := env.GetString("EXPENSES_RECURRING_TABLE_NAME", "")
 		envConfig := env.GetEnvConfig()
 
 		dynamoClient := dynamo.InitClient(ctx)
-		req.Repo, err = expenses_recurring.NewExpenseRecurringDynamoRepository(dynamoClient, expensesRecurringTableName)
+		req.Repo, err = expenses_recurring.NewRepository(ctx, dynamoClient, envConfig)
 		if err != nil {
 			return
 		}
 
-		req.PeriodRepo, err = period.NewDynamoRepository(dynamoClient, envConfig)
+		req.PeriodRepo, err = period.NewRepository(ctx, dynamoClient, envConfig)
 		if err != nil {
 			return
 		}
 
-		req.ExpensesRepo, err = expenses.NewDynamoRepository(dynamoClient, envConfig)
+		req.ExpensesRepo, err = expenses.NewRepository(ctx, dynamoClient, envConfig)
+		if err != nil {
+			return
+		}
+
+		req.UsersRepo, err = users.NewRepository(ctx, dynamoClient, envConfig)
 		if err != nil {
 			return
 		}
@@ -99,6 +106,8 @@ func (req *CronRequest) finish() {
 }
 
 func (req *CronRequest) Process(ctx context.Context) error {
+	ctx = audit.WithSource(ctx, models.AuditSourceCronGenerator, "recurrent-expense-generator")
+
 	day := time.Now().Day()
 
 	recExpenses, err := req.Repo.ScanExpensesForDay(ctx, day)
@@ -137,11 +146,16 @@ func (req *CronRequest) createExpenses(ctx context.Context, username string, rec
 	expensesToCreate := make([]*models.Expense, 0, len(recExpenses))
 
 	for _, expense := range recExpenses {
+		if expense.Paused {
+			continue
+		}
+
 		if isRecurringExpenseWithinPeriod(expense, lastPeriod) {
 			expensesToCreate = append(expensesToCreate, &models.Expense{
 				Username:   expense.Username,
 				CategoryID: expense.CategoryID,
 				Amount:     &expense.Amount,
+				Currency:   expense.Currency,
 				Name:       &expense.Name,
 				Notes:      expense.Notes,
 				PeriodID:   lastPeriod.ID,
@@ -149,7 +163,7 @@ func (req *CronRequest) createExpenses(ctx context.Context, username string, rec
 		}
 	}
 
-	batchCreateExpenses := usecases.NewBatchExpensesCreator(req.ExpensesRepo)
+	batchCreateExpenses := usecases.NewBatchExpensesCreator(req.ExpensesRepo, req.UsersRepo)
 	err = batchCreateExpenses(ctx, expensesToCreate)
 	if err != nil {
 		return fmt.Errorf("batch create expenses failed: %v", err)
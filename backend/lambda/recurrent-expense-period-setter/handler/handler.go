@@ -7,6 +7,7 @@ import (
 	"github.com/JoelD7/money/backend/models"
 	"github.com/JoelD7/money/backend/shared/env"
 	"github.com/JoelD7/money/backend/shared/logger"
+	"github.com/JoelD7/money/backend/storage/audit"
 	"github.com/JoelD7/money/backend/storage/dynamo"
 	"github.com/JoelD7/money/backend/storage/expenses"
 	"github.com/JoelD7/money/backend/storage/period"
@@ -39,12 +40,12 @@ func (request *Request) init(ctx context.Context) error {
 
 		dynamoClient := dynamo.InitClient(ctx)
 
-		request.ExpensesRepo, err = expenses.NewDynamoRepository(dynamoClient, envConfig)
+		request.ExpensesRepo, err = expenses.NewRepository(ctx, dynamoClient, envConfig)
 		if err != nil {
 			return
 		}
 
-		request.PeriodRepo, err = period.NewDynamoRepository(dynamoClient, envConfig)
+		request.PeriodRepo, err = period.NewRepository(ctx, dynamoClient, envConfig)
 		if err != nil {
 			return
 		}
@@ -96,6 +97,7 @@ func (request *Request) ProcessMessage(ctx context.Context, record models.SQSMes
 	logger.Info("received_message", models.Any("message_data", msgBody))
 
 	updateExpensesWoPeriod := usecases.NewExpensesPeriodSetter(request.ExpensesRepo, request.PeriodRepo)
+	ctx = audit.WithSource(ctx, models.AuditSourcePeriodSetter, "recurrent-expense-period-setter")
 
 	err = updateExpensesWoPeriod(ctx, msgBody.Username, msgBody.Period)
 	if err != nil {
@@ -0,0 +1,56 @@
+package models
+
+import "time"
+
+// AccountDeletionStatus is the state of the background job that deletes the data of a user.
+type AccountDeletionStatus string
+
+const (
+	AccountDeletionStatusPending    AccountDeletionStatus = "pending"
+	AccountDeletionStatusInProgress AccountDeletionStatus = "in_progress"
+	AccountDeletionStatusCompleted  AccountDeletionStatus = "completed"
+	AccountDeletionStatusFailed     AccountDeletionStatus = "failed"
+)
+
+// AccountDeletionStep is a kind of resource owned by a user. The steps of a deletion are run in a fixed order, so the
+// current step is enough to resume a deletion that was interrupted.
+type AccountDeletionStep string
+
+const (
+	AccountDeletionStepExpenses          AccountDeletionStep = "expenses"
+	AccountDeletionStepExpensesRecurring AccountDeletionStep = "expenses_recurring"
+	AccountDeletionStepIncome            AccountDeletionStep = "income"
+	AccountDeletionStepSavings           AccountDeletionStep = "savings"
+	AccountDeletionStepSavingGoals       AccountDeletionStep = "saving_goals"
+	AccountDeletionStepBudgetAlerts      AccountDeletionStep = "budget_alerts"
+	AccountDeletionStepPeriods           AccountDeletionStep = "periods"
+	AccountDeletionStepTrash             AccountDeletionStep = "trash"
+	AccountDeletionStepAudit             AccountDeletionStep = "audit"
+	AccountDeletionStepSessions          AccountDeletionStep = "sessions"
+	AccountDeletionStepUser              AccountDeletionStep = "user"
+	AccountDeletionStepCache             AccountDeletionStep = "cache"
+)
+
+// AccountDeletion tracks the progress of the deletion of all the data of a user.
+type AccountDeletion struct {
+	Username string                `json:"username"`
+	Status   AccountDeletionStatus `json:"status"`
+	// Step is the kind of resource being deleted.
+	Step AccountDeletionStep `json:"step,omitempty"`
+	// DeletedItems is the number of items deleted by step.
+	DeletedItems  map[AccountDeletionStep]int `json:"deleted_items,omitempty"`
+	Error         string                      `json:"error,omitempty"`
+	CreatedDate   time.Time                   `json:"created_date"`
+	UpdatedDate   time.Time                   `json:"updated_date,omitempty"`
+	CompletedDate time.Time                   `json:"completed_date,omitempty"`
+}
+
+// IsRunning returns true if the deletion was requested and hasn't finished yet.
+func (a *AccountDeletion) IsRunning() bool {
+	return a.Status == AccountDeletionStatusPending || a.Status == AccountDeletionStatusInProgress
+}
+
+// AccountDeletionMessage is the SQS message that starts or resumes the deletion of a user's data.
+type AccountDeletionMessage struct {
+	Username string `json:"username"`
+}
@@ -0,0 +1,59 @@
+package models
+
+import "time"
+
+// AuditEntityType is the kind of entity an audit entry is about.
+type AuditEntityType string
+
+const (
+	AuditEntityExpense    AuditEntityType = "expense"
+	AuditEntityIncome     AuditEntityType = "income"
+	AuditEntityPeriod     AuditEntityType = "period"
+	AuditEntityCategory   AuditEntityType = "category"
+	AuditEntitySaving     AuditEntityType = "saving"
+	AuditEntitySavingGoal AuditEntityType = "saving_goal"
+)
+
+type AuditAction string
+
+const (
+	AuditActionCreate AuditAction = "create"
+	AuditActionUpdate AuditAction = "update"
+	AuditActionDelete AuditAction = "delete"
+)
+
+// AuditSource is where a change was made from.
+type AuditSource string
+
+const (
+	AuditSourceAPI AuditSource = "api"
+	// AuditSourceCronGenerator is the recurrent-expense-generator lambda, which creates the recurring expenses.
+	AuditSourceCronGenerator AuditSource = "cron_generator"
+	// AuditSourcePeriodSetter is the recurrent-expense-period-setter lambda, which sets the period of the expenses
+	// created before it.
+	AuditSourcePeriodSetter AuditSource = "sqs_period_setter"
+	// AuditSourceImport is the import of bank statements and the restore of exported data.
+	AuditSourceImport AuditSource = "import"
+)
+
+// AuditChange is the change of a field of an entity. OldValue is empty for created entities and NewValue is empty for
+// deleted ones.
+type AuditChange struct {
+	Field    string      `json:"field"`
+	OldValue interface{} `json:"old_value,omitempty"`
+	NewValue interface{} `json:"new_value,omitempty"`
+}
+
+// AuditEntry is a record of a create, update or delete of an entity of a user.
+type AuditEntry struct {
+	Username   string          `json:"username,omitempty"`
+	EntryID    string          `json:"entry_id"`
+	EntityType AuditEntityType `json:"entity_type"`
+	EntityID   string          `json:"entity_id"`
+	Action     AuditAction     `json:"action"`
+	// Actor is who made the change: the user for changes made from the API, or the process that made it otherwise.
+	Actor       string         `json:"actor"`
+	Source      AuditSource    `json:"source"`
+	Changes     []*AuditChange `json:"changes,omitempty"`
+	CreatedDate time.Time      `json:"created_date"`
+}
@@ -0,0 +1,18 @@
+package models
+
+import "time"
+
+// BudgetAlert is published when the spending of a category during a period crosses a percentage of its budget.
+type BudgetAlert struct {
+	Username     string `json:"username"`
+	PeriodID     string `json:"period_id"`
+	CategoryID   string `json:"category_id"`
+	CategoryName string `json:"category_name,omitempty"`
+	// Threshold is the percentage of the budget that was crossed.
+	Threshold      int       `json:"threshold"`
+	Budget         Money     `json:"budget"`
+	Total          Money     `json:"total"`
+	PercentageUsed float64   `json:"percentage_used"`
+	Currency       string    `json:"currency"`
+	CreatedDate    time.Time `json:"created_date"`
+}
@@ -0,0 +1,18 @@
+package models
+
+import "time"
+
+// DefaultCurrency is the base currency of the users that haven't chosen one.
+const DefaultCurrency = "USD"
+
+// ExchangeRate is the value of a currency in terms of the base currency of a user during a period.
+type ExchangeRate struct {
+	Username string `json:"username,omitempty"`
+	PeriodID string `json:"period_id,omitempty"`
+	// Currency is the ISO-4217 code of the currency being converted.
+	Currency string `json:"currency"`
+	// Rate is the amount of the user's base currency that one unit of Currency is worth.
+	Rate        float64   `json:"rate"`
+	CreatedDate time.Time `json:"created_date,omitempty"`
+	UpdatedDate time.Time `json:"updated_date,omitempty"`
+}
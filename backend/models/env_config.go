@@ -1,5 +1,10 @@
 package models
 
+const (
+	StorageBackendDynamoDB = "dynamodb"
+	StorageBackendSQL      = "sql"
+)
+
 type EnvironmentConfiguration struct {
 	MissingExpensePeriodQueueURL string `json:"MISSING_EXPENSE_PERIOD_QUEUE_URL"`
 	AwsRegion                    string `json:"AWS_REGION"`
@@ -23,6 +28,12 @@ type EnvironmentConfiguration struct {
 	TokenScope           string `json:"TOKEN_SCOPE"`
 	LambdaTimeout        string `json:"LAMBDA_TIMEOUT"`
 
+	// StorageBackend is where the data is stored, StorageBackendDynamoDB or StorageBackendSQL. DynamoDB is the default.
+	StorageBackend string `json:"STORAGE_BACKEND"`
+	// SQLDriver is the database of the SQL storage backend, "sqlite" or "postgres".
+	SQLDriver     string `json:"SQL_DRIVER"`
+	SQLDataSource string `json:"SQL_DATA_SOURCE"`
+
 	UsersTable             string `json:"USERS_TABLE_NAME"`
 	ExpensesTable          string `json:"EXPENSES_TABLE_NAME"`
 	ExpensesRecurringTable string `json:"EXPENSES_RECURRING_TABLE_NAME"`
@@ -53,6 +64,64 @@ type EnvironmentConfiguration struct {
 	UsernameTargetIndex          string `json:"USERNAME_TARGET_INDEX"`
 	UsernameDeadlineIndex        string `json:"USERNAME_DEADLINE_INDEX"`
 
+	ExchangeRatesTable string `json:"EXCHANGE_RATES_TABLE_NAME"`
+
+	// BudgetNearLimitPercentage is the percentage of a category budget from which its spending is considered near the
+	// limit.
+	BudgetNearLimitPercentage int `json:"BUDGET_NEAR_LIMIT_PERCENTAGE"`
+
+	BudgetAlertsTable string `json:"BUDGET_ALERTS_TABLE_NAME"`
+	// BudgetAlertThresholds is a comma separated list of the percentages of a category budget that trigger an alert.
+	BudgetAlertThresholds    string `json:"BUDGET_ALERT_THRESHOLDS"`
+	BudgetAlertQueueURL      string `json:"BUDGET_ALERT_QUEUE_URL"`
+	BudgetAlertWebhookURL    string `json:"BUDGET_ALERT_WEBHOOK_URL"`
+	BudgetAlertWebhookSecret string `json:"BUDGET_ALERT_WEBHOOK_SECRET"`
+
+	// MailSender is how emails are sent to the users: "ses", "file" or "console", which is the default.
+	MailSender   string `json:"MAIL_SENDER"`
+	MailFrom     string `json:"MAIL_FROM"`
+	MailFilePath string `json:"MAIL_FILE_PATH"`
+	// PasswordResetTokenDuration is how many seconds a password reset token is valid for.
+	PasswordResetTokenDuration string `json:"PASSWORD_RESET_TOKEN_DURATION"`
+
+	// EmailVerificationRequired denies tokens to the users that haven't verified their email once the grace period,
+	// in seconds, is over.
+	EmailVerificationRequired      bool   `json:"EMAIL_VERIFICATION_REQUIRED"`
+	EmailVerificationGracePeriod   string `json:"EMAIL_VERIFICATION_GRACE_PERIOD"`
+	EmailVerificationTokenDuration string `json:"EMAIL_VERIFICATION_TOKEN_DURATION"`
+
+	// MFAIssuer is the name of the account in the authenticator apps of the users.
+	MFAIssuer string `json:"MFA_ISSUER"`
+	// MFATokenDuration is how many seconds the user has to enter the TOTP code after the password.
+	MFATokenDuration string `json:"MFA_TOKEN_DURATION"`
+
+	// LoginBackoffAttempts is how many failed logins of a username are allowed before every new attempt has to wait,
+	// twice as long each time, until LoginMaxAttempts locks the username out for LoginLockoutDuration seconds. The IP
+	// limits are the same for a source IP, which is usually shared by more people, so they are higher.
+	LoginBackoffAttempts   int `json:"LOGIN_BACKOFF_ATTEMPTS"`
+	LoginMaxAttempts       int `json:"LOGIN_MAX_ATTEMPTS"`
+	LoginIPBackoffAttempts int `json:"LOGIN_IP_BACKOFF_ATTEMPTS"`
+	LoginIPMaxAttempts     int `json:"LOGIN_IP_MAX_ATTEMPTS"`
+	LoginLockoutDuration   int `json:"LOGIN_LOCKOUT_DURATION"`
+	// LoginAttemptsWindow is how many seconds the failed logins are counted for after the last one.
+	LoginAttemptsWindow int `json:"LOGIN_ATTEMPTS_WINDOW"`
+	// AdminKeySecret is the name of the secret with the key of the admin endpoints, which are disabled if it isn't set.
+	AdminKeySecret string `json:"ADMIN_KEY_SECRET"`
+
+	AccountDeletionsTable   string `json:"ACCOUNT_DELETIONS_TABLE_NAME"`
+	AccountDeletionQueueURL string `json:"ACCOUNT_DELETION_QUEUE_URL"`
+
+	SessionsTable string `json:"SESSIONS_TABLE_NAME"`
+
+	TrashTable               string `json:"TRASH_TABLE_NAME"`
+	UsernameDeletedDateIndex string `json:"USERNAME_DELETED_DATE_INDEX"`
+	// TrashRetentionDays is how long deleted items are kept in the trash before they are deleted permanently.
+	TrashRetentionDays int `json:"TRASH_RETENTION_DAYS"`
+
+	// AuditTable enables the audit log of the changes made to the entities of the users when it's set.
+	AuditTable       string `json:"AUDIT_TABLE_NAME"`
+	EntityAuditIndex string `json:"ENTITY_AUDIT_INDEX"`
+
 	BatchWriteRetries       int `json:"BATCH_WRITE_RETRIES"`
 	BatchWriteBaseDelayInMs int `json:"BATCH_WRITE_BASE_DELAY_IN_MS"`
 	BatchWriteBackoffFactor int `json:"BATCH_WRITE_BACKOFF_FACTOR"`
@@ -13,7 +13,6 @@ var (
 	ErrExistingUser          = errors.New("this account already exists")
 	ErrWrongCredentials      = errors.New("the email or password are incorrect")
 	ErrInvalidToken          = errors.New("invalid token")
-	ErrRefreshTokenMismatch  = errors.New("received refresh token doesn't match with the user's")
 	ErrUnauthorized          = errors.New("Unauthorized")
 	ErrSigningKeyNotFound    = errors.New("signing key not found")
 	ErrInvalidTokensNotFound = errors.New("no invalid tokens found")
@@ -27,12 +26,50 @@ var (
 	// the path parameter of an endpoint like /users/{username}. This error is currently returned when a user tries to
 	// delete another user.
 	ErrUsernameDeleteMismatch = errors.New("authorization username doesn't match with path parameter username")
+	ErrMissingNewPassword     = errors.New("missing new password")
+	// ErrInvalidPasswordResetToken error when a password reset token doesn't match with the one of the user, expired or
+	// was already used. The cases aren't told apart, so that the error doesn't reveal which tokens exist.
+	ErrInvalidPasswordResetToken = errors.New("invalid or expired password reset token")
+	ErrMissingPasswordResetToken = errors.New("missing password reset token")
+	// ErrUnverifiedUser error when a user that hasn't verified the email of the account tries to get tokens, while the
+	// verification is required.
+	ErrUnverifiedUser                = errors.New("the email of the account hasn't been verified")
+	ErrInvalidEmailVerificationToken = errors.New("invalid or expired email verification token")
+	ErrMissingEmailVerificationToken = errors.New("missing email verification token")
+	// ErrMFARequired error when the password of a user with MFA enabled is right, but the login can't be completed
+	// without a TOTP code.
+	ErrMFARequired = errors.New("a TOTP code is required to log in")
+	// ErrInvalidMFACode error when a code is neither the current TOTP code of the user nor one of the recovery codes.
+	ErrInvalidMFACode    = errors.New("invalid TOTP or recovery code")
+	ErrMissingMFACode    = errors.New("missing TOTP code")
+	ErrInvalidMFAToken   = errors.New("invalid or expired MFA token")
+	ErrMissingMFAToken   = errors.New("missing MFA token")
+	ErrMFAAlreadyEnabled = errors.New("MFA is already enabled")
+	ErrMFANotEnabled     = errors.New("MFA isn't enabled")
+	// ErrMFANotEnrolled error when the enrollment of a TOTP authenticator is confirmed before it's started.
+	ErrMFANotEnrolled = errors.New("there isn't a TOTP authenticator to confirm")
+
+	// Sessions
+	ErrSessionNotFound  = errors.New("session not found")
+	ErrSessionsNotFound = errors.New("sessions not found")
+	ErrMissingSessionID = errors.New("missing session id")
+	// ErrRefreshTokenReused error when a refresh token is used after it was replaced by a new one. Only a copy of the
+	// token can be used that way, so the session it belongs to is revoked.
+	ErrRefreshTokenReused = errors.New("refresh token reused")
+
+	// Login attempts
+	ErrTooManyLoginAttempts = errors.New("too many failed login attempts")
+	ErrInvalidAdminKey      = errors.New("invalid admin key")
+	// ErrMissingLoginUnlockTarget error when a login unlock doesn't have the username nor the source IP to unlock.
+	ErrMissingLoginUnlockTarget = errors.New("missing username or ip address to unlock")
 
 	// Income
 	ErrIncomeNotFound        = errors.New("user income not found")
 	ErrExistingIncome        = errors.New("this income already exists")
 	ErrMissingIncomeID       = errors.New("missing income id")
 	ErrIncomePeriodsNotFound = errors.New("income periods not found")
+	ErrUpdateIncomeNotFound  = errors.New("the income you are trying to update does not exist")
+	ErrDeleteIncomeNotFound  = errors.New("the income you are trying to delete does not exist")
 
 	// General
 	ErrInvalidAmount            = errors.New("invalid amount. The amount has to be a number greater than zero")
@@ -45,10 +82,18 @@ var (
 	ErrInvalidSortOrder         = errors.New("invalid sort order")
 	ErrInvalidSortBy            = errors.New("invalid sort by")
 	ErrNoMoreItemsToBeRetrieved = errors.New("no more items to be retrieved")
+	ErrInvalidAmountFilter      = errors.New("invalid amount filter")
+	ErrInvalidDateFilter        = errors.New("invalid date filter. Dates must be in the YYYY-MM-DD or RFC 3339 format")
+	// ErrInvalidFilterRange error when the lower bound of an amount or date filter is greater than its upper bound.
+	ErrInvalidFilterRange = errors.New("invalid filter range")
 	// ErrIndexKeysNotFound error when a DynamoDB index is not included in the map used to build the LastEvaluatedKey.
 	// This is important as it breaks pagination.
 	ErrIndexKeysNotFound     = errors.New("index keys not found")
 	ErrMissingIdempotencyKey = errors.New("missing idempotency key header")
+	// ErrVersionConflict error when the If-Match header of a request doesn't match the current version of the resource,
+	// or the resource was changed by someone else while it was being updated.
+	ErrVersionConflict = errors.New("the resource was modified by another request")
+	ErrInvalidIfMatch  = errors.New("invalid If-Match header")
 	// ErrUnexpectedTypeAssertion error when the type of a resource obtained from the idempotency manager is unexpected.
 	ErrUnexpectedTypeAssertion = errors.New("unexpected type assertion in idempotency manager")
 
@@ -107,4 +152,48 @@ var (
 	ErrMissingPeriod                  = errors.New("missing period")
 	ErrMissingPeriodCreatedDate       = errors.New("missing period created date")
 	ErrMissingPeriodUpdatedDate       = errors.New("missing period updated date")
+
+	// Import
+	ErrInvalidImportFormat  = errors.New("invalid import format. The supported formats are csv and ofx")
+	ErrMissingImportFile    = errors.New("missing import file")
+	ErrInvalidImportFile    = errors.New("invalid import file")
+	ErrMissingCSVColumn     = errors.New("csv column not found")
+	ErrNoTransactionsInFile = errors.New("the import file doesn't have any transactions")
+
+	// Export
+	ErrInvalidExportFormat = errors.New("invalid export format. The supported formats are json and csv")
+
+	// Restore
+	ErrUnsupportedExportVersion = errors.New("unsupported export version")
+
+	// Budget alerts
+	ErrBudgetAlertAlreadySent = errors.New("budget alert already sent")
+
+	// Account deletion
+	ErrAccountDeletionNotFound = errors.New("account deletion not found")
+
+	// Trash
+	ErrTrashItemNotFound  = errors.New("trash item not found")
+	ErrTrashItemsNotFound = errors.New("trash items not found")
+	ErrMissingTrashItemID = errors.New("missing trash item id")
+	// ErrTrashItemAlreadyExists error when the record of a trash item can't be restored because there's a record with
+	// its ID already.
+	ErrTrashItemAlreadyExists      = errors.New("the item to restore already exists")
+	ErrTrashItemPeriodNotFound     = errors.New("the period of the item to restore no longer exists")
+	ErrTrashItemCategoryNotFound   = errors.New("the category of the item to restore no longer exists")
+	ErrTrashItemSavingGoalNotFound = errors.New("the saving goal of the item to restore no longer exists")
+	ErrInvalidTrashItemType        = errors.New("invalid trash item type")
+
+	// Audit log
+	ErrAuditEntriesNotFound = errors.New("audit entries not found")
+	ErrMissingEntityID      = errors.New("missing entity id")
+
+	// Currencies
+	ErrInvalidCurrency             = errors.New("invalid currency. The currency should be an ISO-4217 code")
+	ErrMissingCurrency             = errors.New("missing currency")
+	ErrInvalidExchangeRate         = errors.New("invalid exchange rate. The rate should be greater than 0")
+	ErrExchangeRateNotFound        = errors.New("exchange rate not found")
+	ErrExchangeRatesNotFound       = errors.New("exchange rates not found")
+	ErrMissingExchangeRate         = errors.New("missing exchange rate for currency")
+	ErrExchangeRateForBaseCurrency = errors.New("the base currency can't have an exchange rate")
 )
@@ -7,7 +7,8 @@ type Expense struct {
 	Username     string    `json:"username,omitempty"`
 	CategoryID   *string   `json:"category_id,omitempty"`
 	CategoryName string    `json:"category_name,omitempty"`
-	Amount       *float64  `json:"amount"`
+	Amount       *Money    `json:"amount"`
+	Currency     string    `json:"currency,omitempty"`
 	RecurringDay *int      `json:"recurring_day,omitempty"`
 	IsRecurring  bool      `json:"is_recurring"`
 	Name         *string   `json:"name,omitempty"`
@@ -17,12 +18,29 @@ type Expense struct {
 	PeriodName   string    `json:"period_name,omitempty"`
 	PeriodUser   *string   `json:"period_user,omitempty"`
 	UpdateDate   time.Time `json:"update_date,omitempty"`
+	// Version is incremented on every update and is sent as the ETag of the expense.
+	Version int64 `json:"version,omitempty"`
 }
 
+// BudgetStatus indicates how the spending of a category compares to its budget.
+type BudgetStatus string
+
+const (
+	BudgetStatusUnder     BudgetStatus = "under"
+	BudgetStatusNearLimit BudgetStatus = "near_limit"
+	BudgetStatusOver      BudgetStatus = "over"
+)
+
+// CategoryExpenseSummary is the amount spent on a category during a period. The budget fields are only set for
+// categories that have a budget.
 type CategoryExpenseSummary struct {
-	CategoryID string  `json:"category_id"`
-	Total      float64 `json:"total"`
-	Period     string  `json:"period,omitempty"`
+	CategoryID     string       `json:"category_id"`
+	Total          Money        `json:"total"`
+	Period         string       `json:"period,omitempty"`
+	Budget         *Money       `json:"budget,omitempty"`
+	Remaining      *Money       `json:"remaining,omitempty"`
+	PercentageUsed *float64     `json:"percentage_used,omitempty"`
+	BudgetStatus   BudgetStatus `json:"budget_status,omitempty"`
 }
 
 func (e *Expense) GetPeriodID() string {
@@ -45,10 +63,18 @@ func (e *Expense) GetName() string {
 	return ""
 }
 
-func (e *Expense) GetAmount() float64 {
+func (e *Expense) GetAmount() Money {
 	if e.Amount != nil {
 		return *e.Amount
 	}
 
 	return 0
 }
+
+func (e *Expense) GetCurrency() string {
+	return e.Currency
+}
+
+func (e *Expense) SetCurrency(currency string) {
+	e.Currency = currency
+}
@@ -5,13 +5,24 @@ import (
 )
 
 type ExpenseRecurring struct {
-	ID           string    `json:"id"`
-	Username     string    `json:"username,omitempty"`
-	CategoryID   *string   `json:"category_id,omitempty"`
-	Amount       float64   `json:"amount"`
-	RecurringDay int       `json:"recurring_day,omitempty"`
-	Name         string    `json:"name,omitempty"`
-	Notes        string    `json:"notes,omitempty"`
-	CreatedDate  time.Time `json:"created_date,omitempty"`
-	UpdateDate   time.Time `json:"update_date,omitempty"`
+	ID           string  `json:"id"`
+	Username     string  `json:"username,omitempty"`
+	CategoryID   *string `json:"category_id,omitempty"`
+	Amount       Money   `json:"amount"`
+	Currency     string  `json:"currency,omitempty"`
+	RecurringDay int     `json:"recurring_day,omitempty"`
+	Name         string  `json:"name,omitempty"`
+	Notes        string  `json:"notes,omitempty"`
+	// Paused indicates that the recurrent expense generator should skip this template until it's resumed.
+	Paused      bool      `json:"paused"`
+	CreatedDate time.Time `json:"created_date,omitempty"`
+	UpdateDate  time.Time `json:"update_date,omitempty"`
+}
+
+func (e *ExpenseRecurring) GetCurrency() string {
+	return e.Currency
+}
+
+func (e *ExpenseRecurring) SetCurrency(currency string) {
+	e.Currency = currency
 }
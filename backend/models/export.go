@@ -0,0 +1,20 @@
+package models
+
+import "time"
+
+// ExportVersion is the version of the Export document format. It must be increased whenever the format changes in a
+// way that older readers can't handle.
+const ExportVersion = 1
+
+// Export is a document with all the financial data that belongs to a user.
+type Export struct {
+	Version           int                 `json:"version"`
+	ExportedDate      time.Time           `json:"exported_date"`
+	User              *User               `json:"user"`
+	Periods           []*Period           `json:"periods"`
+	Expenses          []*Expense          `json:"expenses"`
+	Income            []*Income           `json:"income"`
+	Savings           []*Saving           `json:"savings"`
+	SavingGoals       []*SavingGoal       `json:"saving_goals"`
+	ExpensesRecurring []*ExpenseRecurring `json:"expenses_recurring"`
+}
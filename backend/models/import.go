@@ -0,0 +1,50 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// TransactionType indicates whether a bank statement transaction took money out of the account or put money into it.
+type TransactionType string
+
+const (
+	TransactionTypeDebit  TransactionType = "debit"
+	TransactionTypeCredit TransactionType = "credit"
+)
+
+// Transaction is a single entry of an imported bank statement.
+type Transaction struct {
+	Date time.Time
+	// Amount is always positive. Use Type to know the direction of the transaction.
+	Amount Money
+	Type   TransactionType
+	Name   string
+	Notes  string
+}
+
+// Fingerprint returns the value used to detect if the transaction has already been imported.
+func (t *Transaction) Fingerprint() string {
+	return BuildTransactionFingerprint(t.Date, t.Amount, t.Name)
+}
+
+// BuildTransactionFingerprint builds a value that identifies a transaction by its date, amount and name. Two records
+// with the same fingerprint are considered duplicates.
+func BuildTransactionFingerprint(date time.Time, amount Money, name string) string {
+	return fmt.Sprintf("%s#%s#%s", date.Format(time.DateOnly), amount, strings.ToLower(strings.TrimSpace(name)))
+}
+
+// ImportResult is the summary of a bank statement import.
+type ImportResult struct {
+	DryRun bool `json:"dry_run"`
+	// Imported is the number of records that were created, or that would be created if DryRun is true.
+	Imported int `json:"imported"`
+	// Duplicates is the number of transactions that were ignored because they already exist.
+	Duplicates int `json:"duplicates"`
+	// Skipped is the number of transactions that were ignored because they don't apply to the kind of record being
+	// imported, or because they don't belong to any period.
+	Skipped  int        `json:"skipped"`
+	Expenses []*Expense `json:"expenses,omitempty"`
+	Income   []*Income  `json:"income,omitempty"`
+}
@@ -5,7 +5,8 @@ import "time"
 type Income struct {
 	Username    string    `json:"username,omitempty"`
 	IncomeID    string    `json:"income_id,omitempty"`
-	Amount      *float64  `json:"amount"`
+	Amount      *Money    `json:"amount"`
+	Currency    string    `json:"currency,omitempty"`
 	Name        *string   `json:"name,omitempty"`
 	Notes       *string   `json:"notes,omitempty"`
 	CreatedDate time.Time `json:"created_date,omitempty"`
@@ -39,10 +40,18 @@ func (i *Income) GetName() string {
 	return ""
 }
 
-func (i *Income) GetAmount() float64 {
+func (i *Income) GetAmount() Money {
 	if i.Amount != nil {
 		return *i.Amount
 	}
 
 	return 0
 }
+
+func (i *Income) GetCurrency() string {
+	return i.Currency
+}
+
+func (i *Income) SetCurrency(currency string) {
+	i.Currency = currency
+}
@@ -30,5 +30,5 @@ func (a *AnyField) GetValue() (interface{}, error) {
 // Resource is an interface that represents any of the types that can be stored in the database. It's purpose is to
 // serve as a generics type.
 type Resource interface {
-	*User | *Category | *Expense | *SavingGoal | *Income | *Period | *Saving
+	*User | *Category | *Expense | *ExpenseRecurring | *SavingGoal | *Income | *Period | *Saving | *RestoreResult
 }
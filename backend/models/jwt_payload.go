@@ -4,5 +4,7 @@ import "github.com/gbrlsnchs/jwt/v3"
 
 type JWTPayload struct {
 	Scope string `json:"scope,omitempty"`
+	// SessionID is the session a refresh token belongs to.
+	SessionID string `json:"sid,omitempty"`
 	*jwt.Payload
 }
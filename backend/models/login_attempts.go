@@ -0,0 +1,31 @@
+package models
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// LoginBlockedError is returned when the logins of a username or source IP are blocked after too many failed
+// attempts. It wraps ErrTooManyLoginAttempts.
+type LoginBlockedError struct {
+	Until time.Time
+}
+
+func (e *LoginBlockedError) Error() string {
+	return fmt.Sprintf("%v: blocked until %s", ErrTooManyLoginAttempts, e.Until.Format(time.RFC3339))
+}
+
+func (e *LoginBlockedError) Unwrap() error {
+	return ErrTooManyLoginAttempts
+}
+
+// RetryAfter returns the seconds left until the block is over, rounded up, as the Retry-After header expects them.
+func (e *LoginBlockedError) RetryAfter() int64 {
+	seconds := int64(math.Ceil(time.Until(e.Until).Seconds()))
+	if seconds < 1 {
+		return 1
+	}
+
+	return seconds
+}
@@ -0,0 +1,8 @@
+package models
+
+// Mail is an email sent to a user, like the instructions to reset the password.
+type Mail struct {
+	To      string `json:"to"`
+	Subject string `json:"subject"`
+	Body    string `json:"body"`
+}
@@ -0,0 +1,8 @@
+package models
+
+// MFAEnrollment is a TOTP authenticator the user is enrolling. Authenticator apps add it from the provisioning URI,
+// usually shown as a QR code, or from the secret.
+type MFAEnrollment struct {
+	Secret          string `json:"secret"`
+	ProvisioningURI string `json:"provisioning_uri"`
+}
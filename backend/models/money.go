@@ -0,0 +1,146 @@
+package models
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"math"
+	"math/big"
+)
+
+// centsPerUnit is the number of minor units in a unit of currency. Every currency is handled with two decimal places.
+const centsPerUnit = 100
+
+var centsPerUnitRat = big.NewRat(centsPerUnit, 1)
+
+// Money is an amount of money expressed in cents. Storing the amount as an integer keeps additions and subtractions
+// exact, which isn't the case with floating point numbers.
+//
+// Money is encoded as a decimal number with two decimal places, both in JSON and in DynamoDB, so it's compatible with
+// the amounts stored as float64 before this type existed. Those amounts are rounded to the nearest cent when read.
+type Money int64
+
+// NewMoney builds a Money from a float, rounding it to the nearest cent.
+func NewMoney(amount float64) Money {
+	return Money(math.Round(amount * centsPerUnit))
+}
+
+// ParseMoney parses a decimal number, like "1234.56", into Money. The number is parsed exactly and then rounded to the
+// nearest cent, half away from zero.
+func ParseMoney(value string) (Money, error) {
+	amount, ok := new(big.Rat).SetString(value)
+	if !ok {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidAmount, value)
+	}
+
+	return fromRat(amount.Mul(amount, centsPerUnitRat))
+}
+
+// fromRat rounds an amount of cents to the nearest integer, half away from zero.
+func fromRat(cents *big.Rat) (Money, error) {
+	quotient, remainder := new(big.Int).QuoRem(cents.Num(), cents.Denom(), new(big.Int))
+
+	// Round half away from zero: |2 * remainder| >= denominator
+	remainder.Abs(remainder).Lsh(remainder, 1)
+	if remainder.Cmp(cents.Denom()) >= 0 {
+		quotient.Add(quotient, big.NewInt(int64(cents.Sign())))
+	}
+
+	if !quotient.IsInt64() {
+		return 0, fmt.Errorf("%w: amount out of range", ErrInvalidAmount)
+	}
+
+	return Money(quotient.Int64()), nil
+}
+
+// Cents returns the amount in cents.
+func (m Money) Cents() int64 {
+	return int64(m)
+}
+
+// Float64 returns the amount as a float. It should only be used where an approximate value is acceptable.
+func (m Money) Float64() float64 {
+	return float64(m) / centsPerUnit
+}
+
+// MulRate multiplies the amount by a rate, like an exchange rate, rounding the result to the nearest cent.
+func (m Money) MulRate(rate float64) (Money, error) {
+	r := new(big.Rat).SetFloat64(rate)
+	if r == nil {
+		return 0, fmt.Errorf("%w: invalid rate %v", ErrInvalidAmount, rate)
+	}
+
+	return fromRat(r.Mul(r, new(big.Rat).SetInt64(int64(m))))
+}
+
+// DivRate divides the amount by a rate, like an exchange rate, rounding the result to the nearest cent.
+func (m Money) DivRate(rate float64) (Money, error) {
+	r := new(big.Rat).SetFloat64(rate)
+	if r == nil || r.Sign() == 0 {
+		return 0, fmt.Errorf("%w: invalid rate %v", ErrInvalidAmount, rate)
+	}
+
+	return fromRat(r.Quo(new(big.Rat).SetInt64(int64(m)), r))
+}
+
+// String returns the amount as a decimal number with two decimal places, like "1234.56".
+func (m Money) String() string {
+	cents := int64(m)
+	sign := ""
+
+	if cents < 0 {
+		sign = "-"
+	}
+
+	// Use an unsigned value so that math.MinInt64 doesn't overflow
+	abs := uint64(cents)
+	if cents < 0 {
+		abs = -abs
+	}
+
+	return fmt.Sprintf("%s%d.%02d", sign, abs/centsPerUnit, abs%centsPerUnit)
+}
+
+func (m Money) MarshalJSON() ([]byte, error) {
+	return []byte(m.String()), nil
+}
+
+// UnmarshalJSON accepts the amount as a JSON number or as a string containing a number.
+func (m *Money) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+
+	value := string(bytes.Trim(data, `"`))
+
+	money, err := ParseMoney(value)
+	if err != nil {
+		return err
+	}
+
+	*m = money
+
+	return nil
+}
+
+func (m Money) MarshalDynamoDBAttributeValue() (types.AttributeValue, error) {
+	return &types.AttributeValueMemberN{Value: m.String()}, nil
+}
+
+// UnmarshalDynamoDBAttributeValue reads the amount from a number attribute. Amounts written as float64 are rounded to
+// the nearest cent.
+func (m *Money) UnmarshalDynamoDBAttributeValue(av types.AttributeValue) error {
+	number, ok := av.(*types.AttributeValueMemberN)
+	if !ok {
+		return fmt.Errorf("%w: expected a number attribute, got %T", ErrInvalidAmount, av)
+	}
+
+	money, err := ParseMoney(number.Value)
+	if err != nil {
+		return err
+	}
+
+	*m = money
+
+	return nil
+}
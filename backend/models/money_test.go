@@ -0,0 +1,112 @@
+package models
+
+import (
+	"encoding/json"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestParseMoney(t *testing.T) {
+	c := require.New(t)
+
+	cases := []struct {
+		value    string
+		expected Money
+	}{
+		{"1234.56", 123456},
+		{"12.5", 1250},
+		{"-0.01", -1},
+		{"0.30000000000000004", 30},
+		{"0.005", 1},
+		{"-0.005", -1},
+		{"0.0049", 0},
+		{"1e2", 10000},
+	}
+
+	for _, tc := range cases {
+		money, err := ParseMoney(tc.value)
+		c.NoError(err, tc.value)
+		c.Equal(tc.expected, money, tc.value)
+	}
+
+	_, err := ParseMoney("abc")
+	c.ErrorIs(err, ErrInvalidAmount)
+
+	_, err = ParseMoney("100000000000000000000")
+	c.ErrorIs(err, ErrInvalidAmount)
+}
+
+func TestMoneySum(t *testing.T) {
+	c := require.New(t)
+
+	var total Money
+	for i := 0; i < 1000; i++ {
+		total += NewMoney(0.1)
+	}
+
+	c.Equal("100.00", total.String())
+}
+
+func TestMoneyRates(t *testing.T) {
+	c := require.New(t)
+
+	money, err := NewMoney(100).MulRate(1.1)
+	c.NoError(err)
+	c.Equal(NewMoney(110), money)
+
+	money, err = NewMoney(10).DivRate(3)
+	c.NoError(err)
+	c.Equal(NewMoney(3.33), money)
+
+	_, err = NewMoney(10).DivRate(0)
+	c.ErrorIs(err, ErrInvalidAmount)
+}
+
+func TestMoneyJSON(t *testing.T) {
+	c := require.New(t)
+
+	expense := new(Expense)
+
+	err := json.Unmarshal([]byte(`{"amount":12.5}`), expense)
+	c.NoError(err)
+	c.Equal(NewMoney(12.5), expense.GetAmount())
+
+	data, err := json.Marshal(struct {
+		Amount Money `json:"amount"`
+	}{-1205})
+	c.NoError(err)
+	c.Equal(`{"amount":-12.05}`, string(data))
+
+	err = json.Unmarshal([]byte(`{"amount":"abc"}`), expense)
+	c.ErrorIs(err, ErrInvalidAmount)
+}
+
+func TestMoneyDynamoDB(t *testing.T) {
+	c := require.New(t)
+
+	type entity struct {
+		Amount  Money  `dynamodbav:"amount"`
+		Pointer *Money `dynamodbav:"pointer"`
+	}
+
+	pointer := NewMoney(0.3)
+
+	item, err := attributevalue.MarshalMap(entity{NewMoney(1234.5), &pointer})
+	c.NoError(err)
+	c.Equal(&types.AttributeValueMemberN{Value: "1234.50"}, item["amount"])
+	c.Equal(&types.AttributeValueMemberN{Value: "0.30"}, item["pointer"])
+
+	t.Run("Read amount stored as float", func(t *testing.T) {
+		var e entity
+
+		err = attributevalue.UnmarshalMap(map[string]types.AttributeValue{
+			"amount":  &types.AttributeValueMemberN{Value: "0.30000000000000004"},
+			"pointer": &types.AttributeValueMemberN{Value: "172.98"},
+		}, &e)
+		c.NoError(err)
+		c.Equal(Money(30), e.Amount)
+		c.Equal(Money(17298), *e.Pointer)
+	})
+}
@@ -14,6 +14,17 @@ type Period struct {
 	EndDate     time.Time `json:"end_date,omitempty"`
 	CreatedDate time.Time `json:"created_date,omitempty"`
 	UpdatedDate time.Time `json:"updated_date,omitempty"`
+	// CategoryBudgets overrides the budget of some categories for this period only. The key is the category ID.
+	CategoryBudgets map[string]Money `json:"category_budgets,omitempty"`
+	Version         int64            `json:"version,omitempty"`
+}
+
+func (period *Period) GetName() string {
+	if period.Name != nil {
+		return *period.Name
+	}
+
+	return ""
 }
 
 func (period *Period) Key() string {
@@ -34,6 +45,7 @@ func (period *Period) Value() map[string]interface{} {
 
 type PeriodStat struct {
 	PeriodID               string                    `json:"period_id"`
-	TotalIncome            float64                   `json:"total_income"`
+	Currency               string                    `json:"currency"`
+	TotalIncome            Money                     `json:"total_income"`
 	CategoryExpenseSummary []*CategoryExpenseSummary `json:"category_expense_summary"`
 }
@@ -5,6 +5,7 @@ import (
 	"net/url"
 	"strconv"
 	"strings"
+	"time"
 )
 
 const (
@@ -30,6 +31,74 @@ type QueryParameters struct {
 	SortType     string
 	SavingGoalID string
 	Active       bool
+	// MinAmount and MaxAmount are the bounds of the amount of the items, both inclusive.
+	MinAmount *Money
+	MaxAmount *Money
+	// FromDate and ToDate are the bounds of the creation date of the items, both inclusive.
+	FromDate *time.Time
+	ToDate   *time.Time
+	// Search is a text the name or the notes of the items must contain. The search isn't case-sensitive.
+	Search string
+}
+
+// HasFilters tells if the items are filtered by amount, date or text.
+func (qp *QueryParameters) HasFilters() bool {
+	return qp.MinAmount != nil || qp.MaxAmount != nil || qp.FromDate != nil || qp.ToDate != nil || qp.Search != ""
+}
+
+// MatchesFilters tells if an item with the given amount, creation date, name and notes passes the amount, date and text
+// filters.
+func (qp *QueryParameters) MatchesFilters(amount Money, createdDate time.Time, name, notes string) bool {
+	if qp.MinAmount != nil && amount < *qp.MinAmount {
+		return false
+	}
+
+	if qp.MaxAmount != nil && amount > *qp.MaxAmount {
+		return false
+	}
+
+	if qp.FromDate != nil && createdDate.Before(*qp.FromDate) {
+		return false
+	}
+
+	if qp.ToDate != nil && createdDate.After(*qp.ToDate) {
+		return false
+	}
+
+	return qp.MatchesSearch(name, notes)
+}
+
+// MatchesSearch tells if any of the texts contains the search text, regardless of case.
+func (qp *QueryParameters) MatchesSearch(texts ...string) bool {
+	if qp.Search == "" {
+		return true
+	}
+
+	search := strings.ToLower(qp.Search)
+
+	for _, text := range texts {
+		if strings.Contains(strings.ToLower(text), search) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Shape returns the filters and the sort of the query in a canonical form. The start key and the page size aren't part
+// of it, as they change from one page to the next of the same query.
+func (qp *QueryParameters) Shape() string {
+	query := url.Values{}
+	qp.ParseAsURLValues(&query)
+
+	query.Del("start_key")
+	query.Del("page_size")
+
+	if qp.Active {
+		query.Set("active", "true")
+	}
+
+	return query.Encode()
 }
 
 func (qp *QueryParameters) ParseAsURLValues(query *url.Values) {
@@ -60,6 +129,26 @@ func (qp *QueryParameters) ParseAsURLValues(query *url.Values) {
 	for _, category := range qp.Categories {
 		query.Add("category", category)
 	}
+
+	if qp.MinAmount != nil {
+		query.Add("min_amount", qp.MinAmount.String())
+	}
+
+	if qp.MaxAmount != nil {
+		query.Add("max_amount", qp.MaxAmount.String())
+	}
+
+	if qp.FromDate != nil {
+		query.Add("from_date", qp.FromDate.Format(time.RFC3339))
+	}
+
+	if qp.ToDate != nil {
+		query.Add("to_date", qp.ToDate.Format(time.RFC3339))
+	}
+
+	if qp.Search != "" {
+		query.Add("q", qp.Search)
+	}
 }
 
 func (qp *QueryParameters) ToURLParams() string {
@@ -93,5 +182,25 @@ func (qp *QueryParameters) ToURLParams() string {
 		urlParams = append(urlParams, "start_key="+qp.StartKey)
 	}
 
+	if qp.MinAmount != nil {
+		urlParams = append(urlParams, "min_amount="+qp.MinAmount.String())
+	}
+
+	if qp.MaxAmount != nil {
+		urlParams = append(urlParams, "max_amount="+qp.MaxAmount.String())
+	}
+
+	if qp.FromDate != nil {
+		urlParams = append(urlParams, "from_date="+url.QueryEscape(qp.FromDate.Format(time.RFC3339)))
+	}
+
+	if qp.ToDate != nil {
+		urlParams = append(urlParams, "to_date="+url.QueryEscape(qp.ToDate.Format(time.RFC3339)))
+	}
+
+	if qp.Search != "" {
+		urlParams = append(urlParams, "q="+url.QueryEscape(qp.Search))
+	}
+
 	return strings.Join(urlParams, "&")
 }
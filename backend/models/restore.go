@@ -0,0 +1,22 @@
+package models
+
+// RestoreSummary is the outcome of restoring one kind of entity.
+type RestoreSummary struct {
+	// Restored is the number of items written to the database, including the remapped ones.
+	Restored int `json:"restored"`
+	// Remapped is the number of restored items that got a new ID because theirs was already in use.
+	Remapped int `json:"remapped"`
+	// Skipped is the number of items that weren't restored because they already exist.
+	Skipped int `json:"skipped"`
+}
+
+// RestoreResult is the per-entity summary of restoring an Export document.
+type RestoreResult struct {
+	Categories        RestoreSummary `json:"categories"`
+	Periods           RestoreSummary `json:"periods"`
+	SavingGoals       RestoreSummary `json:"saving_goals"`
+	Expenses          RestoreSummary `json:"expenses"`
+	Income            RestoreSummary `json:"income"`
+	Savings           RestoreSummary `json:"savings"`
+	ExpensesRecurring RestoreSummary `json:"expenses_recurring"`
+}
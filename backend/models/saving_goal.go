@@ -6,22 +6,28 @@ type SavingGoal struct {
 	SavingGoalID    string     `json:"saving_goal_id,omitempty"`
 	Username        string     `json:"username,omitempty"`
 	Name            *string    `json:"name,omitempty"`
-	Target          *float64   `json:"target,omitempty"`
-	Progress        *float64   `json:"progress,omitempty"`
+	Target          *Money     `json:"target,omitempty"`
+	Currency        string     `json:"currency,omitempty"`
+	Progress        *Money     `json:"progress,omitempty"`
 	Deadline        *time.Time `json:"deadline,omitempty"`
 	IsRecurring     bool       `json:"is_recurring,omitempty"`
-	RecurringAmount *float64   `json:"recurring_amount,omitempty"`
+	RecurringAmount *Money     `json:"recurring_amount,omitempty"`
+	Version         int64      `json:"version,omitempty"`
 }
 
 func (sg *SavingGoal) SetName(name string) {
 	sg.Name = &name
 }
 
-func (sg *SavingGoal) SetTarget(target float64) {
+func (sg *SavingGoal) SetTarget(target Money) {
 	sg.Target = &target
 }
 
-func (sg *SavingGoal) SetProgress(progress float64) {
+func (sg *SavingGoal) SetCurrency(currency string) {
+	sg.Currency = currency
+}
+
+func (sg *SavingGoal) SetProgress(progress Money) {
 	sg.Progress = &progress
 }
 
@@ -33,7 +39,7 @@ func (sg *SavingGoal) SetIsRecurring(isRecurring bool) {
 	sg.IsRecurring = isRecurring
 }
 
-func (sg *SavingGoal) SetRecurringAmount(recurringAmount float64) {
+func (sg *SavingGoal) SetRecurringAmount(recurringAmount Money) {
 	sg.RecurringAmount = &recurringAmount
 }
 
@@ -58,14 +64,21 @@ func (sg *SavingGoal) GetName() string {
 	return *sg.Name
 }
 
-func (sg *SavingGoal) GetTarget() float64 {
+func (sg *SavingGoal) GetTarget() Money {
 	if sg == nil || sg.Target == nil {
 		return 0
 	}
 	return *sg.Target
 }
 
-func (sg *SavingGoal) GetProgress() float64 {
+func (sg *SavingGoal) GetCurrency() string {
+	if sg == nil {
+		return ""
+	}
+	return sg.Currency
+}
+
+func (sg *SavingGoal) GetProgress() Money {
 	if sg == nil || sg.Progress == nil {
 		return 0
 	}
@@ -86,7 +99,7 @@ func (sg *SavingGoal) GetIsRecurring() bool {
 	return sg.IsRecurring
 }
 
-func (sg *SavingGoal) GetRecurringAmount() float64 {
+func (sg *SavingGoal) GetRecurringAmount() Money {
 	if sg == nil || sg.RecurringAmount == nil {
 		return 0
 	}
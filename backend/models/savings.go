@@ -12,7 +12,9 @@ type Saving struct {
 	PeriodName     string    `json:"period_name,omitempty"`
 	CreatedDate    time.Time `json:"created_date,omitempty"`
 	UpdatedDate    time.Time `json:"updated_date,omitempty"`
-	Amount         *float64  `json:"amount"`
+	Amount         *Money    `json:"amount"`
+	Currency       string    `json:"currency,omitempty"`
+	Version        int64     `json:"version,omitempty"`
 }
 
 func (s *Saving) GetPeriodID() string {
@@ -27,7 +29,7 @@ func (s *Saving) SetPeriodName(name string) {
 	s.PeriodName = name
 }
 
-func (s *Saving) GetAmount() float64 {
+func (s *Saving) GetAmount() Money {
 	if s == nil || s.Amount == nil {
 		return 0
 	}
@@ -37,3 +39,14 @@ func (s *Saving) GetAmount() float64 {
 func (s *Saving) GetUsername() string {
 	return s.Username
 }
+
+func (s *Saving) GetCurrency() string {
+	if s == nil {
+		return ""
+	}
+	return s.Currency
+}
+
+func (s *Saving) SetCurrency(currency string) {
+	s.Currency = currency
+}
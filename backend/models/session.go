@@ -0,0 +1,33 @@
+package models
+
+import "time"
+
+// Session is a login of a user on a device. Each session has its own refresh token, which is replaced every time it's
+// used, so that a user can stay logged in on several devices and revoke each of them.
+type Session struct {
+	ID       string `json:"id"`
+	Username string `json:"username"`
+	// RefreshToken is the hash of the only refresh token of the session that can still be used.
+	RefreshToken string `json:"-"`
+	// AccessToken is the hash of the last access token issued for the session, which is invalidated when the session
+	// is revoked.
+	AccessToken    string    `json:"-"`
+	UserAgent      string    `json:"user_agent,omitempty"`
+	IPAddress      string    `json:"ip_address,omitempty"`
+	CreatedDate    time.Time `json:"created_date"`
+	LastUsedDate   time.Time `json:"last_used_date"`
+	ExpirationDate time.Time `json:"expiration_date"`
+}
+
+func (s *Session) GetKey() string {
+	return "session"
+}
+
+func (s *Session) GetValue() (interface{}, error) {
+	return map[string]interface{}{
+		"s_id":             s.ID,
+		"s_username":       s.Username,
+		"s_user_agent":     s.UserAgent,
+		"t_last_used_date": s.LastUsedDate,
+	}, nil
+}
@@ -0,0 +1,86 @@
+package models
+
+import "time"
+
+// TrashItemType is the kind of record held by a trash item.
+type TrashItemType string
+
+const (
+	TrashItemTypeExpense    TrashItemType = "expense"
+	TrashItemTypeIncome     TrashItemType = "income"
+	TrashItemTypeSaving     TrashItemType = "saving"
+	TrashItemTypeSavingGoal TrashItemType = "saving_goal"
+	TrashItemTypePeriod     TrashItemType = "period"
+)
+
+// TrashItem is a deleted record. It stays in the trash of its owner, from where it can be restored, until it's purged
+// or its retention period ends. Only the field of its Type is set.
+type TrashItem struct {
+	Username       string        `json:"username,omitempty"`
+	ItemID         string        `json:"item_id"`
+	Type           TrashItemType `json:"type"`
+	Name           string        `json:"name,omitempty"`
+	DeletedDate    time.Time     `json:"deleted_date"`
+	ExpirationDate time.Time     `json:"expiration_date"`
+	Expense        *Expense      `json:"expense,omitempty"`
+	Income         *Income       `json:"income,omitempty"`
+	Saving         *Saving       `json:"saving,omitempty"`
+	SavingGoal     *SavingGoal   `json:"saving_goal,omitempty"`
+	Period         *Period       `json:"period,omitempty"`
+}
+
+// NewExpenseTrashItem returns the trash item of a deleted expense.
+func NewExpenseTrashItem(expense *Expense) *TrashItem {
+	return &TrashItem{
+		Username: expense.Username,
+		ItemID:   expense.ExpenseID,
+		Type:     TrashItemTypeExpense,
+		Name:     expense.GetName(),
+		Expense:  expense,
+	}
+}
+
+// NewIncomeTrashItem returns the trash item of a deleted income.
+func NewIncomeTrashItem(income *Income) *TrashItem {
+	return &TrashItem{
+		Username: income.Username,
+		ItemID:   income.IncomeID,
+		Type:     TrashItemTypeIncome,
+		Name:     income.GetName(),
+		Income:   income,
+	}
+}
+
+// NewSavingTrashItem returns the trash item of a deleted saving. Savings have no name, so the name of their saving goal
+// is used.
+func NewSavingTrashItem(saving *Saving) *TrashItem {
+	return &TrashItem{
+		Username: saving.Username,
+		ItemID:   saving.SavingID,
+		Type:     TrashItemTypeSaving,
+		Name:     saving.SavingGoalName,
+		Saving:   saving,
+	}
+}
+
+// NewSavingGoalTrashItem returns the trash item of a deleted saving goal.
+func NewSavingGoalTrashItem(savingGoal *SavingGoal) *TrashItem {
+	return &TrashItem{
+		Username:   savingGoal.Username,
+		ItemID:     savingGoal.SavingGoalID,
+		Type:       TrashItemTypeSavingGoal,
+		Name:       savingGoal.GetName(),
+		SavingGoal: savingGoal,
+	}
+}
+
+// NewPeriodTrashItem returns the trash item of a deleted period.
+func NewPeriodTrashItem(period *Period) *TrashItem {
+	return &TrashItem{
+		Username: period.Username,
+		ItemID:   period.ID,
+		Type:     TrashItemTypePeriod,
+		Name:     period.GetName(),
+		Period:   period,
+	}
+}
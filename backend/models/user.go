@@ -12,17 +12,71 @@ type User struct {
 	AccessToken   string      `json:"-"`
 	RefreshToken  string      `json:"-"`
 	CurrentPeriod string      `json:"current_period,omitempty"`
-	Remainder     float64     `json:"remainder"`
+	BaseCurrency  string      `json:"base_currency,omitempty"`
+	// LegacyCurrency is the currency of the records saved without one. It's the base currency the user had before
+	// changing it for the first time, so that those records keep their currency.
+	LegacyCurrency string `json:"legacy_currency,omitempty"`
+	Remainder      Money  `json:"remainder"`
+	// Version is incremented on every update of the user. As the categories are stored in the user, it's the ETag of
+	// the categories too.
+	Version int64 `json:"version,omitempty"`
+	// Verified is whether the user confirmed the email of the account. The users created before emails were verified
+	// are considered verified.
+	Verified bool `json:"verified"`
+	// PasswordResetToken is the hash of the token sent to the user to reset the password, which is valid until
+	// PasswordResetExpiration. It's cleared once it's used, so that it can't be used twice.
+	PasswordResetToken      string     `json:"-"`
+	PasswordResetExpiration *time.Time `json:"-"`
+	// MFAEnabled is set once the user confirms the enrollment of a TOTP authenticator with a first code. Until then,
+	// MFASecret is the secret of the pending enrollment.
+	MFAEnabled bool   `json:"mfa_enabled"`
+	MFASecret  string `json:"-"`
+	// MFARecoveryCodes are the hashes of the codes that can be used instead of a TOTP code, once each.
+	MFARecoveryCodes []string `json:"-"`
+	// MFALastUsedStep is the time step of the last TOTP code used, so that a code can't be used twice.
+	MFALastUsedStep int64 `json:"-"`
 }
 
 type Category struct {
 	ID       string   `json:"id,omitempty"`
 	Name     *string  `json:"name,omitempty"`
-	Budget   *float64 `json:"budget,omitempty"`
+	Budget   *Money   `json:"budget,omitempty"`
 	Color    *string  `json:"color,omitempty"`
 	Keywords []string `json:"keywords,omitempty"`
 }
 
+// GetBaseCurrency returns the base currency of the user, falling back to DefaultCurrency for users created before
+// currencies were supported.
+func (u *User) GetBaseCurrency() string {
+	if u.BaseCurrency == "" {
+		return DefaultCurrency
+	}
+
+	return u.BaseCurrency
+}
+
+// GetLegacyCurrency returns the currency of the records saved without one, which is the base currency if it never
+// changed.
+func (u *User) GetLegacyCurrency() string {
+	if u.LegacyCurrency == "" {
+		return u.GetBaseCurrency()
+	}
+
+	return u.LegacyCurrency
+}
+
+// SetBaseCurrency changes the base currency of the user. The first time it changes, the previous one is kept as the
+// legacy currency so that the records saved without a currency aren't re-denominated.
+func (u *User) SetBaseCurrency(baseCurrency string) {
+	previousBaseCurrency := u.GetBaseCurrency()
+
+	u.BaseCurrency = baseCurrency
+
+	if u.LegacyCurrency == "" && u.GetBaseCurrency() != previousBaseCurrency {
+		u.LegacyCurrency = previousBaseCurrency
+	}
+}
+
 func (u *User) GetKey() string {
 	return "user"
 }
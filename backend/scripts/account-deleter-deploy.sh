@@ -0,0 +1,6 @@
+#!/bin/bash
+set -o pipefail
+echo "Deploying account-deleter"
+GOOS=linux GOARCH=arm64 go build -tags lambda.norpc -o lambda/bin/account-deleter/bootstrap github.com/JoelD7/money/backend/lambda/account-deleter
+zip -j lambda/bin/account-deleter/bootstrap.zip lambda/bin/account-deleter/bootstrap
+aws lambda update-function-code --function-name money-account-deleter --zip-file fileb://lambda/bin/account-deleter/bootstrap.zip | tee
\ No newline at end of file
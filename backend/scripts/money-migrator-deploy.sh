@@ -0,0 +1,6 @@
+#!/bin/bash
+set -o pipefail
+echo "Deploying money-migrator"
+GOOS=linux GOARCH=arm64 go build -tags lambda.norpc -o lambda/bin/money-migrator/bootstrap github.com/JoelD7/money/backend/lambda/money-migrator
+zip -j lambda/bin/money-migrator/bootstrap.zip lambda/bin/money-migrator/bootstrap
+aws lambda update-function-code --function-name money-money-migrator --zip-file fileb://lambda/bin/money-migrator/bootstrap.zip | tee
\ No newline at end of file
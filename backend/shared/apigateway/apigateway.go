@@ -9,6 +9,7 @@ import (
 	"net/http"
 	"strconv"
 	"strings"
+	"time"
 )
 
 var (
@@ -31,6 +32,9 @@ var (
 		models.ErrDeleteSavingNotFound:             {HTTPCode: http.StatusNotFound, Message: "The saving you are trying to delete does not exist"},
 		models.ErrInvalidPageSize:                  {HTTPCode: http.StatusBadRequest, Message: "Invalid page size"},
 		models.ErrInvalidStartKey:                  {HTTPCode: http.StatusBadRequest, Message: "Invalid start key"},
+		models.ErrInvalidAmountFilter:              {HTTPCode: http.StatusBadRequest, Message: "Invalid amount filter"},
+		models.ErrInvalidDateFilter:                {HTTPCode: http.StatusBadRequest, Message: "Invalid date filter. Dates must be in the YYYY-MM-DD or RFC 3339 format"},
+		models.ErrInvalidFilterRange:               {HTTPCode: http.StatusBadRequest, Message: "The lower bound of a filter can't be greater than its upper bound"},
 		models.ErrMissingUsername:                  {HTTPCode: http.StatusBadRequest, Message: "Missing username"},
 		models.ErrMissingPassword:                  {HTTPCode: http.StatusBadRequest, Message: "Missing password"},
 		models.ErrInvalidToken:                     {HTTPCode: http.StatusUnauthorized, Message: "Invalid token"},
@@ -65,11 +69,15 @@ var (
 		models.ErrMissingPeriodUpdatedDate:         {HTTPCode: http.StatusBadRequest, Message: "Missing period updated date"},
 		models.ErrExistingIncome:                   {HTTPCode: http.StatusBadRequest, Message: "This income already exists"},
 		models.ErrMissingIncomeID:                  {HTTPCode: http.StatusBadRequest, Message: "Missing income id"},
+		models.ErrUpdateIncomeNotFound:             {HTTPCode: http.StatusNotFound, Message: "The income you are trying to update does not exist"},
+		models.ErrDeleteIncomeNotFound:             {HTTPCode: http.StatusNotFound, Message: "The income you are trying to delete does not exist"},
 		models.ErrNoMoreItemsToBeRetrieved:         {HTTPCode: http.StatusNoContent, Message: "No more items to be retrieved"},
 		models.ErrMissingRecurringDay:              {HTTPCode: http.StatusBadRequest, Message: "Missing recurring_day. Recurring expenses must have a recurring_day"},
 		models.ErrInvalidRecurringDay:              {HTTPCode: http.StatusBadRequest, Message: "Recurring day must be between 1 and 31"},
 		models.ErrRecurringExpenseNameTaken:        {HTTPCode: http.StatusBadRequest, Message: "Recurring expense name is taken"},
 		models.ErrRecurringExpensesNotFound:        {HTTPCode: http.StatusNotFound, Message: "Recurring expenses not found"},
+		models.ErrRecurringExpenseNotFound:         {HTTPCode: http.StatusNotFound, Message: "Recurring expense not found"},
+		models.ErrMissingExpenseRecurringID:        {HTTPCode: http.StatusBadRequest, Message: "Missing expense recurring id"},
 		models.ErrInvalidSortOrder:                 {HTTPCode: http.StatusBadRequest, Message: "Invalid sort order. The sort order must be either 'asc' or 'desc'"},
 		models.ErrInvalidSortBy:                    {HTTPCode: http.StatusBadRequest, Message: "Invalid sort by"},
 		models.ErrMissingSavingGoalName:            {HTTPCode: http.StatusBadRequest, Message: "Missing saving goal name"},
@@ -80,12 +88,62 @@ var (
 		models.ErrMissingSavingGoalRecurringAmount: {HTTPCode: http.StatusBadRequest, Message: "Missing saving goal recurring amount"},
 		models.ErrUsernameDeleteMismatch:           {HTTPCode: http.StatusForbidden, Message: "You do not have permissions to delete this user"},
 		models.ErrMissingIdempotencyKey:            {HTTPCode: http.StatusBadRequest, Message: "Missing Idempotency-Key header"},
+		models.ErrInvalidImportFormat:              {HTTPCode: http.StatusBadRequest, Message: "Invalid import format. The supported formats are csv and ofx"},
+		models.ErrMissingImportFile:                {HTTPCode: http.StatusBadRequest, Message: "Missing import file"},
+		models.ErrInvalidImportFile:                {HTTPCode: http.StatusBadRequest, Message: "Invalid import file"},
+		models.ErrMissingCSVColumn:                 {HTTPCode: http.StatusBadRequest, Message: "A column of the mapping was not found in the CSV header"},
+		models.ErrNoTransactionsInFile:             {HTTPCode: http.StatusBadRequest, Message: "The import file doesn't have any transactions"},
+		models.ErrInvalidExportFormat:              {HTTPCode: http.StatusBadRequest, Message: "Invalid export format. The supported formats are json and csv"},
+		models.ErrUnsupportedExportVersion:         {HTTPCode: http.StatusBadRequest, Message: "Unsupported export version"},
+		models.ErrInvalidCurrency:                  {HTTPCode: http.StatusBadRequest, Message: "Invalid currency. The currency should be an ISO-4217 code"},
+		models.ErrMissingCurrency:                  {HTTPCode: http.StatusBadRequest, Message: "Missing currency"},
+		models.ErrInvalidExchangeRate:              {HTTPCode: http.StatusBadRequest, Message: "Invalid exchange rate. The rate should be greater than 0"},
+		models.ErrExchangeRateNotFound:             {HTTPCode: http.StatusNotFound, Message: "Not found"},
+		models.ErrExchangeRatesNotFound:            {HTTPCode: http.StatusNotFound, Message: "Not found"},
+		models.ErrMissingExchangeRate:              {HTTPCode: http.StatusBadRequest, Message: "Missing exchange rate. Set the exchange rate of every currency used in the period"},
+		models.ErrExchangeRateForBaseCurrency:      {HTTPCode: http.StatusBadRequest, Message: "The base currency can't have an exchange rate"},
+		models.ErrAccountDeletionNotFound:          {HTTPCode: http.StatusNotFound, Message: "Not found"},
+		models.ErrTrashItemNotFound:                {HTTPCode: http.StatusNotFound, Message: "Not found"},
+		models.ErrTrashItemsNotFound:               {HTTPCode: http.StatusNotFound, Message: "Not found"},
+		models.ErrMissingTrashItemID:               {HTTPCode: http.StatusBadRequest, Message: "Missing trash item ID"},
+		models.ErrTrashItemAlreadyExists:           {HTTPCode: http.StatusConflict, Message: "The item to restore already exists"},
+		models.ErrTrashItemPeriodNotFound:          {HTTPCode: http.StatusConflict, Message: "The period of the item to restore no longer exists"},
+		models.ErrTrashItemCategoryNotFound:        {HTTPCode: http.StatusConflict, Message: "The category of the item to restore no longer exists"},
+		models.ErrTrashItemSavingGoalNotFound:      {HTTPCode: http.StatusConflict, Message: "The saving goal of the item to restore no longer exists"},
+		models.ErrAuditEntriesNotFound:             {HTTPCode: http.StatusNotFound, Message: "Not found"},
+		models.ErrMissingEntityID:                  {HTTPCode: http.StatusBadRequest, Message: "Missing entity ID"},
+		models.ErrVersionConflict:                  {HTTPCode: http.StatusPreconditionFailed, Message: "The resource was modified by another request"},
+		models.ErrInvalidIfMatch:                   {HTTPCode: http.StatusBadRequest, Message: "Invalid If-Match header"},
+		models.ErrMissingNewPassword:               {HTTPCode: http.StatusBadRequest, Message: "Missing new password"},
+		models.ErrInvalidPasswordResetToken:        {HTTPCode: http.StatusBadRequest, Message: "Invalid or expired password reset token"},
+		models.ErrMissingPasswordResetToken:        {HTTPCode: http.StatusBadRequest, Message: "Missing password reset token"},
+		models.ErrUnverifiedUser:                   {HTTPCode: http.StatusForbidden, Message: "The email of the account hasn't been verified"},
+		models.ErrInvalidEmailVerificationToken:    {HTTPCode: http.StatusBadRequest, Message: "Invalid or expired email verification token"},
+		models.ErrMissingEmailVerificationToken:    {HTTPCode: http.StatusBadRequest, Message: "Missing email verification token"},
+		models.ErrMFARequired:                      {HTTPCode: http.StatusUnauthorized, Message: "A TOTP code is required to log in"},
+		models.ErrInvalidMFACode:                   {HTTPCode: http.StatusBadRequest, Message: "Invalid TOTP or recovery code"},
+		models.ErrMissingMFACode:                   {HTTPCode: http.StatusBadRequest, Message: "Missing TOTP code"},
+		models.ErrInvalidMFAToken:                  {HTTPCode: http.StatusUnauthorized, Message: "Invalid or expired MFA token"},
+		models.ErrMissingMFAToken:                  {HTTPCode: http.StatusBadRequest, Message: "Missing MFA token"},
+		models.ErrMFAAlreadyEnabled:                {HTTPCode: http.StatusConflict, Message: "MFA is already enabled"},
+		models.ErrMFANotEnabled:                    {HTTPCode: http.StatusConflict, Message: "MFA isn't enabled"},
+		models.ErrMFANotEnrolled:                   {HTTPCode: http.StatusConflict, Message: "There isn't a TOTP authenticator to confirm"},
+		models.ErrSessionNotFound:                  {HTTPCode: http.StatusNotFound, Message: "Not found"},
+		models.ErrSessionsNotFound:                 {HTTPCode: http.StatusNotFound, Message: "Not found"},
+		models.ErrMissingSessionID:                 {HTTPCode: http.StatusBadRequest, Message: "Missing session ID"},
+		models.ErrRefreshTokenReused:               {HTTPCode: http.StatusUnauthorized, Message: "Invalid token"},
+		models.ErrTooManyLoginAttempts:             {HTTPCode: http.StatusTooManyRequests, Message: "Too many failed login attempts, try again later"},
+		models.ErrInvalidAdminKey:                  {HTTPCode: http.StatusUnauthorized, Message: "Unauthorized"},
+		models.ErrMissingLoginUnlockTarget:         {HTTPCode: http.StatusBadRequest, Message: "Missing username or IP address to unlock"},
 	}
 )
 
 const (
 	// Header name of the idempotency key.
 	idempotencyKeyHeaderName = "Idempotency-Key"
+	ifMatchHeaderName        = "If-Match"
+	etagHeaderName           = "ETag"
+	retryAfterHeaderName     = "Retry-After"
 )
 
 type Response events.APIGatewayProxyResponse
@@ -122,6 +180,8 @@ func (req *Request) NewJSONResponse(statusCode int, body interface{}, headers ..
 		"Cache-Control":             "no-store",
 		"Pragma":                    "no-cache",
 		"Strict-Transport-Security": "max-age=63072000; includeSubdomains; preload",
+		// Lets the frontend read the version of the resources it updates, and when it can retry a blocked login.
+		"Access-Control-Expose-Headers": etagHeaderName + ", " + retryAfterHeaderName,
 	}
 
 	origin := req.Headers["origin"]
@@ -255,7 +315,7 @@ func (req *Request) GetQueryParameters() (*models.QueryParameters, error) {
 		active = true
 	}
 
-	return &models.QueryParameters{
+	params := &models.QueryParameters{
 		Categories:   req.MultiValueQueryStringParameters["category"],
 		Period:       req.QueryStringParameters["period"],
 		StartKey:     req.QueryStringParameters["start_key"],
@@ -264,7 +324,87 @@ func (req *Request) GetQueryParameters() (*models.QueryParameters, error) {
 		SortType:     req.QueryStringParameters["sort_order"],
 		SavingGoalID: req.QueryStringParameters["saving_goal_id"],
 		Active:       active,
-	}, nil
+		Search:       strings.TrimSpace(req.QueryStringParameters["q"]),
+	}
+
+	err = req.setFilterParameters(params)
+	if err != nil {
+		return &models.QueryParameters{}, err
+	}
+
+	return params, nil
+}
+
+// setFilterParameters sets the amount and date filters of the query parameters.
+func (req *Request) setFilterParameters(params *models.QueryParameters) error {
+	var err error
+
+	params.MinAmount, err = parseAmountFilter(req.QueryStringParameters["min_amount"])
+	if err != nil {
+		return err
+	}
+
+	params.MaxAmount, err = parseAmountFilter(req.QueryStringParameters["max_amount"])
+	if err != nil {
+		return err
+	}
+
+	params.FromDate, err = parseDateFilter(req.QueryStringParameters["from_date"], false)
+	if err != nil {
+		return err
+	}
+
+	params.ToDate, err = parseDateFilter(req.QueryStringParameters["to_date"], true)
+	if err != nil {
+		return err
+	}
+
+	if params.MinAmount != nil && params.MaxAmount != nil && *params.MinAmount > *params.MaxAmount {
+		return models.ErrInvalidFilterRange
+	}
+
+	if params.FromDate != nil && params.ToDate != nil && params.FromDate.After(*params.ToDate) {
+		return models.ErrInvalidFilterRange
+	}
+
+	return nil
+}
+
+func parseAmountFilter(value string) (*models.Money, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	amount, err := models.ParseMoney(value)
+	if err != nil {
+		return nil, models.ErrInvalidAmountFilter
+	}
+
+	return &amount, nil
+}
+
+// parseDateFilter parses a date in the YYYY-MM-DD or RFC 3339 formats. Dates without time are taken as the start of the
+// day, or as its end if endOfDay is true, so that the filter includes the whole day.
+func parseDateFilter(value string, endOfDay bool) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	date, err := time.Parse(time.RFC3339, value)
+	if err == nil {
+		return &date, nil
+	}
+
+	date, err = time.Parse("2006-01-02", value)
+	if err != nil {
+		return nil, models.ErrInvalidDateFilter
+	}
+
+	if endOfDay {
+		date = date.Add(24*time.Hour - time.Nanosecond)
+	}
+
+	return &date, nil
 }
 
 // GetIdempotenceyKeyFromHeader returns the idempotency key value from the header. Call this function on controllers,
@@ -277,3 +417,33 @@ func (req *Request) GetIdempotenceyKeyFromHeader() (string, error) {
 	}
 	return "", models.ErrMissingIdempotencyKey
 }
+
+// GetIfMatchVersion returns the version of the resource in the If-Match header, or 0 if the header isn't set or is "*".
+// The version is sent as the ETag of the resources, so weak and quoted ETags are accepted. Resources stored before they
+// had versions have an ETag of "0", which doesn't check the version either.
+func (req *Request) GetIfMatchVersion() (int64, error) {
+	for headerName, value := range req.Headers {
+		if !strings.EqualFold(headerName, ifMatchHeaderName) {
+			continue
+		}
+
+		value = strings.Trim(strings.TrimPrefix(strings.TrimSpace(value), "W/"), `"`)
+		if value == "*" {
+			return 0, nil
+		}
+
+		version, err := strconv.ParseInt(value, 10, 64)
+		if err != nil || version < 0 {
+			return 0, models.ErrInvalidIfMatch
+		}
+
+		return version, nil
+	}
+
+	return 0, nil
+}
+
+// NewETagHeader returns the ETag header of a resource with the given version.
+func NewETagHeader(version int64) Header {
+	return Header{Key: etagHeaderName, Value: `"` + strconv.FormatInt(version, 10) + `"`}
+}
@@ -68,6 +68,10 @@ func GetEnvConfig() *models.EnvironmentConfiguration {
 		TokenScope:           GetString("TOKEN_SCOPE", ""),
 		LambdaTimeout:        GetString("LAMBDA_TIMEOUT", ""),
 
+		StorageBackend: GetString("STORAGE_BACKEND", models.StorageBackendDynamoDB),
+		SQLDriver:      GetString("SQL_DRIVER", ""),
+		SQLDataSource:  GetString("SQL_DATA_SOURCE", ""),
+
 		UsersTable:             GetString("USERS_TABLE_NAME", ""),
 		ExpensesTable:          GetString("EXPENSES_TABLE_NAME", ""),
 		ExpensesRecurringTable: GetString("EXPENSES_RECURRING_TABLE_NAME", ""),
@@ -98,6 +102,48 @@ func GetEnvConfig() *models.EnvironmentConfiguration {
 		UsernameTargetIndex:          GetString("USERNAME_TARGET_INDEX", ""),
 		UsernameDeadlineIndex:        GetString("USERNAME_DEADLINE_INDEX", ""),
 
+		ExchangeRatesTable: GetString("EXCHANGE_RATES_TABLE_NAME", ""),
+
+		BudgetNearLimitPercentage: GetInt("BUDGET_NEAR_LIMIT_PERCENTAGE", 80),
+
+		BudgetAlertsTable:        GetString("BUDGET_ALERTS_TABLE_NAME", ""),
+		BudgetAlertThresholds:    GetString("BUDGET_ALERT_THRESHOLDS", "80,100"),
+		BudgetAlertQueueURL:      GetString("BUDGET_ALERT_QUEUE_URL", ""),
+		BudgetAlertWebhookURL:    GetString("BUDGET_ALERT_WEBHOOK_URL", ""),
+		BudgetAlertWebhookSecret: GetString("BUDGET_ALERT_WEBHOOK_SECRET", ""),
+
+		MailSender:                 GetString("MAIL_SENDER", ""),
+		MailFrom:                   GetString("MAIL_FROM", ""),
+		MailFilePath:               GetString("MAIL_FILE_PATH", ""),
+		PasswordResetTokenDuration: GetString("PASSWORD_RESET_TOKEN_DURATION", ""),
+
+		EmailVerificationRequired:      GetBool("EMAIL_VERIFICATION_REQUIRED"),
+		EmailVerificationGracePeriod:   GetString("EMAIL_VERIFICATION_GRACE_PERIOD", ""),
+		EmailVerificationTokenDuration: GetString("EMAIL_VERIFICATION_TOKEN_DURATION", ""),
+
+		MFAIssuer:        GetString("MFA_ISSUER", "Money"),
+		MFATokenDuration: GetString("MFA_TOKEN_DURATION", ""),
+
+		LoginBackoffAttempts:   GetInt("LOGIN_BACKOFF_ATTEMPTS", 3),
+		LoginMaxAttempts:       GetInt("LOGIN_MAX_ATTEMPTS", 10),
+		LoginIPBackoffAttempts: GetInt("LOGIN_IP_BACKOFF_ATTEMPTS", 20),
+		LoginIPMaxAttempts:     GetInt("LOGIN_IP_MAX_ATTEMPTS", 100),
+		LoginLockoutDuration:   GetInt("LOGIN_LOCKOUT_DURATION", 900),
+		LoginAttemptsWindow:    GetInt("LOGIN_ATTEMPTS_WINDOW", 900),
+		AdminKeySecret:         GetString("ADMIN_KEY_SECRET", ""),
+
+		AccountDeletionsTable:   GetString("ACCOUNT_DELETIONS_TABLE_NAME", ""),
+		AccountDeletionQueueURL: GetString("ACCOUNT_DELETION_QUEUE_URL", ""),
+
+		SessionsTable: GetString("SESSIONS_TABLE_NAME", ""),
+
+		TrashTable:               GetString("TRASH_TABLE_NAME", ""),
+		UsernameDeletedDateIndex: GetString("USERNAME_DELETED_DATE_INDEX", ""),
+		TrashRetentionDays:       GetInt("TRASH_RETENTION_DAYS", 30),
+
+		AuditTable:       GetString("AUDIT_TABLE_NAME", ""),
+		EntityAuditIndex: GetString("ENTITY_AUDIT_INDEX", ""),
+
 		BatchWriteRetries:       GetInt("BATCH_WRITE_RETRIES", 0),
 		BatchWriteBaseDelayInMs: GetInt("BATCH_WRITE_BASE_DELAY_IN_MS", 0),
 		BatchWriteBackoffFactor: GetInt("BATCH_WRITE_BACKOFF_FACTOR", 0),
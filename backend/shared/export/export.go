@@ -0,0 +1,523 @@
+// Package export encodes the data of a user in the formats supported by the export endpoint.
+package export
+
+import (
+	"archive/zip"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"github.com/JoelD7/money/backend/models"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	FormatJSON = "json"
+	FormatCSV  = "csv"
+)
+
+// section is an entity of the export that is written in pages, after the user. Sections are written in this order.
+type section int
+
+const (
+	periodsSection section = iota
+	expensesSection
+	incomeSection
+	savingsSection
+	savingGoalsSection
+	expensesRecurringSection
+	sectionsCount
+)
+
+// jsonSectionNames are the members of the JSON document of each section, as in models.Export.
+var jsonSectionNames = [sectionsCount]string{"periods", "expenses", "income", "savings", "saving_goals", "expenses_recurring"}
+
+// csvFile is a CSV file of the zip archive.
+type csvFile struct {
+	name   string
+	header []string
+	rows   [][]string
+}
+
+// JSONWriter writes the export as a single JSON document, with the same members as models.Export, as the data of the
+// user is read. The user must be written first, then the pages of each entity in the order of models.Export.
+type JSONWriter struct {
+	w            io.Writer
+	exportedDate time.Time
+	// section is the section that is open, or -1 if none is.
+	section section
+	// items is the number of items written to the open section.
+	items int
+}
+
+func NewJSONWriter(w io.Writer, exportedDate time.Time) *JSONWriter {
+	return &JSONWriter{
+		w:            w,
+		exportedDate: exportedDate,
+		section:      -1,
+	}
+}
+
+func (j *JSONWriter) WriteUser(user *models.User) error {
+	exportedDate, err := json.Marshal(j.exportedDate)
+	if err != nil {
+		return err
+	}
+
+	userData, err := json.MarshalIndent(user, "  ", "  ")
+	if err != nil {
+		return fmt.Errorf("encode user failed: %w", err)
+	}
+
+	return j.write(fmt.Sprintf("{\n  \"version\": %d,\n  \"exported_date\": %s,\n  \"user\": %s", models.ExportVersion,
+		exportedDate, userData))
+}
+
+func (j *JSONWriter) WritePeriods(periods []*models.Period) error {
+	return writeJSONItems(j, periodsSection, periods)
+}
+
+func (j *JSONWriter) WriteExpenses(expenses []*models.Expense) error {
+	return writeJSONItems(j, expensesSection, expenses)
+}
+
+func (j *JSONWriter) WriteIncome(income []*models.Income) error {
+	return writeJSONItems(j, incomeSection, income)
+}
+
+func (j *JSONWriter) WriteSavings(savings []*models.Saving) error {
+	return writeJSONItems(j, savingsSection, savings)
+}
+
+func (j *JSONWriter) WriteSavingGoals(savingGoals []*models.SavingGoal) error {
+	return writeJSONItems(j, savingGoalsSection, savingGoals)
+}
+
+func (j *JSONWriter) WriteExpensesRecurring(expensesRecurring []*models.ExpenseRecurring) error {
+	return writeJSONItems(j, expensesRecurringSection, expensesRecurring)
+}
+
+// Close writes the sections that had no items as empty lists and ends the document.
+func (j *JSONWriter) Close() error {
+	err := j.openSection(sectionsCount - 1)
+	if err != nil {
+		return err
+	}
+
+	err = j.closeSection()
+	if err != nil {
+		return err
+	}
+
+	return j.write("\n}\n")
+}
+
+func writeJSONItems[T any](j *JSONWriter, s section, items []T) error {
+	err := j.openSection(s)
+	if err != nil {
+		return err
+	}
+
+	for _, item := range items {
+		data, err := json.MarshalIndent(item, "    ", "  ")
+		if err != nil {
+			return fmt.Errorf("encode %s failed: %w", jsonSectionNames[s], err)
+		}
+
+		separator := ",\n    "
+		if j.items == 0 {
+			separator = "\n    "
+		}
+
+		err = j.write(separator + string(data))
+		if err != nil {
+			return err
+		}
+
+		j.items++
+	}
+
+	return nil
+}
+
+// openSection closes the open section and opens the next ones up to s, so that the sections without items are written
+// as empty lists.
+func (j *JSONWriter) openSection(s section) error {
+	for j.section < s {
+		err := j.closeSection()
+		if err != nil {
+			return err
+		}
+
+		j.section++
+		j.items = 0
+
+		err = j.write(fmt.Sprintf(",\n  %q: [", jsonSectionNames[j.section]))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (j *JSONWriter) closeSection() error {
+	if j.section < 0 {
+		return nil
+	}
+
+	if j.items == 0 {
+		return j.write("]")
+	}
+
+	return j.write("\n  ]")
+}
+
+func (j *JSONWriter) write(data string) error {
+	_, err := io.WriteString(j.w, data)
+	if err != nil {
+		return fmt.Errorf("write export failed: %w", err)
+	}
+
+	return nil
+}
+
+// CSVZipWriter writes a zip archive with one CSV file per entity of the export, as the data of the user is read. The
+// user must be written first, then the pages of each entity in the order of models.Export.
+type CSVZipWriter struct {
+	archive      *zip.Writer
+	exportedDate time.Time
+	// section is the section whose file is open, or -1 if none is.
+	section   section
+	csvWriter *csv.Writer
+}
+
+func NewCSVZipWriter(w io.Writer, exportedDate time.Time) *CSVZipWriter {
+	return &CSVZipWriter{
+		archive:      zip.NewWriter(w),
+		exportedDate: exportedDate,
+		section:      -1,
+	}
+}
+
+func (z *CSVZipWriter) WriteUser(user *models.User) error {
+	for _, file := range []*csvFile{userCSV(user), categoriesCSV(user)} {
+		err := z.createFile(file)
+		if err != nil {
+			return err
+		}
+
+		err = z.writeRows(file)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (z *CSVZipWriter) WritePeriods(periods []*models.Period) error {
+	return z.writeSection(periodsSection, periodsCSV(periods))
+}
+
+func (z *CSVZipWriter) WriteExpenses(expenses []*models.Expense) error {
+	return z.writeSection(expensesSection, expensesCSV(expenses))
+}
+
+func (z *CSVZipWriter) WriteIncome(income []*models.Income) error {
+	return z.writeSection(incomeSection, incomeCSV(income))
+}
+
+func (z *CSVZipWriter) WriteSavings(savings []*models.Saving) error {
+	return z.writeSection(savingsSection, savingsCSV(savings))
+}
+
+func (z *CSVZipWriter) WriteSavingGoals(savingGoals []*models.SavingGoal) error {
+	return z.writeSection(savingGoalsSection, savingGoalsCSV(savingGoals))
+}
+
+func (z *CSVZipWriter) WriteExpensesRecurring(expensesRecurring []*models.ExpenseRecurring) error {
+	return z.writeSection(expensesRecurringSection, expensesRecurringCSV(expensesRecurring))
+}
+
+// Close writes the files of the sections that had no items, with only the header, and ends the archive.
+func (z *CSVZipWriter) Close() error {
+	err := z.openSection(sectionsCount - 1)
+	if err != nil {
+		return err
+	}
+
+	return z.archive.Close()
+}
+
+func (z *CSVZipWriter) writeSection(s section, file *csvFile) error {
+	err := z.openSection(s)
+	if err != nil {
+		return err
+	}
+
+	return z.writeRows(file)
+}
+
+// openSection creates the files of the sections up to s, so that the sections without items have a file too. As the
+// entries of a zip archive are written one after the other, creating a file ends the previous one.
+func (z *CSVZipWriter) openSection(s section) error {
+	emptyFiles := [sectionsCount]*csvFile{periodsCSV(nil), expensesCSV(nil), incomeCSV(nil), savingsCSV(nil),
+		savingGoalsCSV(nil), expensesRecurringCSV(nil)}
+
+	for z.section < s {
+		z.section++
+
+		err := z.createFile(emptyFiles[z.section])
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (z *CSVZipWriter) createFile(file *csvFile) error {
+	fileWriter, err := z.archive.CreateHeader(&zip.FileHeader{
+		Name:     file.name,
+		Method:   zip.Deflate,
+		Modified: z.exportedDate,
+	})
+	if err != nil {
+		return fmt.Errorf("create zip entry %s failed: %w", file.name, err)
+	}
+
+	z.csvWriter = csv.NewWriter(fileWriter)
+
+	err = z.csvWriter.Write(file.header)
+	if err != nil {
+		return fmt.Errorf("write %s header failed: %w", file.name, err)
+	}
+
+	// The header must reach the entry before the next one is created, even if the file has no rows.
+	z.csvWriter.Flush()
+
+	err = z.csvWriter.Error()
+	if err != nil {
+		return fmt.Errorf("write %s header failed: %w", file.name, err)
+	}
+
+	return nil
+}
+
+func (z *CSVZipWriter) writeRows(file *csvFile) error {
+	err := z.csvWriter.WriteAll(file.rows)
+	if err != nil {
+		return fmt.Errorf("write %s rows failed: %w", file.name, err)
+	}
+
+	return nil
+}
+
+func userCSV(user *models.User) *csvFile {
+	file := &csvFile{
+		name:   "user.csv",
+		header: []string{"username", "full_name", "current_period", "remainder", "created_date", "updated_date"},
+	}
+
+	if user != nil {
+		file.rows = append(file.rows, []string{
+			user.Username,
+			user.FullName,
+			user.CurrentPeriod,
+			user.Remainder.String(),
+			formatTime(user.CreatedDate),
+			formatTime(user.UpdatedDate),
+		})
+	}
+
+	return file
+}
+
+func categoriesCSV(user *models.User) *csvFile {
+	file := &csvFile{
+		name:   "categories.csv",
+		header: []string{"category_id", "name", "budget", "color", "keywords"},
+	}
+
+	if user == nil {
+		return file
+	}
+
+	for _, category := range user.Categories {
+		file.rows = append(file.rows, []string{
+			category.ID,
+			stringValue(category.Name),
+			moneyValue(category.Budget),
+			stringValue(category.Color),
+			strings.Join(category.Keywords, ";"),
+		})
+	}
+
+	return file
+}
+
+func periodsCSV(periods []*models.Period) *csvFile {
+	file := &csvFile{
+		name:   "periods.csv",
+		header: []string{"period_id", "name", "start_date", "end_date", "created_date", "updated_date"},
+	}
+
+	for _, period := range periods {
+		file.rows = append(file.rows, []string{
+			period.ID,
+			period.GetName(),
+			formatTime(period.StartDate),
+			formatTime(period.EndDate),
+			formatTime(period.CreatedDate),
+			formatTime(period.UpdatedDate),
+		})
+	}
+
+	return file
+}
+
+func expensesCSV(expenses []*models.Expense) *csvFile {
+	file := &csvFile{
+		name:   "expenses.csv",
+		header: []string{"expense_id", "name", "amount", "category_id", "period_id", "notes", "is_recurring", "recurring_day", "created_date", "update_date"},
+	}
+
+	for _, expense := range expenses {
+		recurringDay := ""
+		if expense.RecurringDay != nil {
+			recurringDay = strconv.Itoa(*expense.RecurringDay)
+		}
+
+		file.rows = append(file.rows, []string{
+			expense.ExpenseID,
+			expense.GetName(),
+			moneyValue(expense.Amount),
+			stringValue(expense.CategoryID),
+			expense.PeriodID,
+			expense.Notes,
+			strconv.FormatBool(expense.IsRecurring),
+			recurringDay,
+			formatTime(expense.CreatedDate),
+			formatTime(expense.UpdateDate),
+		})
+	}
+
+	return file
+}
+
+func incomeCSV(income []*models.Income) *csvFile {
+	file := &csvFile{
+		name:   "income.csv",
+		header: []string{"income_id", "name", "amount", "period_id", "notes", "created_date", "updated_date"},
+	}
+
+	for _, i := range income {
+		file.rows = append(file.rows, []string{
+			i.IncomeID,
+			i.GetName(),
+			moneyValue(i.Amount),
+			stringValue(i.PeriodID),
+			stringValue(i.Notes),
+			formatTime(i.CreatedDate),
+			formatTime(i.UpdatedDate),
+		})
+	}
+
+	return file
+}
+
+func savingsCSV(savings []*models.Saving) *csvFile {
+	file := &csvFile{
+		name:   "savings.csv",
+		header: []string{"saving_id", "amount", "saving_goal_id", "period_id", "created_date", "updated_date"},
+	}
+
+	for _, saving := range savings {
+		file.rows = append(file.rows, []string{
+			saving.SavingID,
+			moneyValue(saving.Amount),
+			stringValue(saving.SavingGoalID),
+			stringValue(saving.PeriodID),
+			formatTime(saving.CreatedDate),
+			formatTime(saving.UpdatedDate),
+		})
+	}
+
+	return file
+}
+
+func savingGoalsCSV(savingGoals []*models.SavingGoal) *csvFile {
+	file := &csvFile{
+		name:   "saving_goals.csv",
+		header: []string{"saving_goal_id", "name", "target", "progress", "deadline", "is_recurring", "recurring_amount"},
+	}
+
+	for _, savingGoal := range savingGoals {
+		deadline := ""
+		if savingGoal.Deadline != nil {
+			deadline = formatTime(*savingGoal.Deadline)
+		}
+
+		file.rows = append(file.rows, []string{
+			savingGoal.SavingGoalID,
+			savingGoal.GetName(),
+			moneyValue(savingGoal.Target),
+			moneyValue(savingGoal.Progress),
+			deadline,
+			strconv.FormatBool(savingGoal.IsRecurring),
+			moneyValue(savingGoal.RecurringAmount),
+		})
+	}
+
+	return file
+}
+
+func expensesRecurringCSV(expensesRecurring []*models.ExpenseRecurring) *csvFile {
+	file := &csvFile{
+		name:   "expenses_recurring.csv",
+		header: []string{"id", "name", "amount", "category_id", "recurring_day", "notes", "paused", "created_date", "update_date"},
+	}
+
+	for _, expenseRecurring := range expensesRecurring {
+		file.rows = append(file.rows, []string{
+			expenseRecurring.ID,
+			expenseRecurring.Name,
+			expenseRecurring.Amount.String(),
+			stringValue(expenseRecurring.CategoryID),
+			strconv.Itoa(expenseRecurring.RecurringDay),
+			expenseRecurring.Notes,
+			strconv.FormatBool(expenseRecurring.Paused),
+			formatTime(expenseRecurring.CreatedDate),
+			formatTime(expenseRecurring.UpdateDate),
+		})
+	}
+
+	return file
+}
+
+func stringValue(value *string) string {
+	if value == nil {
+		return ""
+	}
+
+	return *value
+}
+
+func moneyValue(value *models.Money) string {
+	if value == nil {
+		return ""
+	}
+
+	return value.String()
+}
+
+func formatTime(value time.Time) string {
+	if value.IsZero() {
+		return ""
+	}
+
+	return value.Format(time.RFC3339)
+}
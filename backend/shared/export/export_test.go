@@ -0,0 +1,99 @@
+package export
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"github.com/JoelD7/money/backend/models"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+var exportedDate = time.Date(2024, 5, 2, 0, 0, 0, 0, time.UTC)
+
+type userDataWriter interface {
+	WriteUser(user *models.User) error
+	WriteExpenses(expenses []*models.Expense) error
+	WriteSavingGoals(savingGoals []*models.SavingGoal) error
+	Close() error
+}
+
+// writeDummyExport writes the expenses in two pages and leaves the rest of the entities but the saving goals empty.
+func writeDummyExport(c *require.Assertions, writer userDataWriter) {
+	name, otherName := "Groceries", "Pizza"
+	amount := models.NewMoney(45.5)
+	categoryName := "Food"
+
+	c.NoError(writer.WriteUser(&models.User{
+		Username:   "test@gmail.com",
+		Categories: []*models.Category{{ID: "CTGfood", Name: &categoryName, Keywords: []string{"market", "pizza"}}},
+	}))
+
+	c.NoError(writer.WriteExpenses([]*models.Expense{{ExpenseID: "EX1", Name: &name, Amount: &amount, PeriodID: "2024-05"}}))
+	c.NoError(writer.WriteExpenses([]*models.Expense{{ExpenseID: "EX2", Name: &otherName, Amount: &amount, PeriodID: "2024-05"}}))
+	c.NoError(writer.WriteSavingGoals([]*models.SavingGoal{{SavingGoalID: "SV1"}}))
+
+	c.NoError(writer.Close())
+}
+
+func TestCSVZipWriter(t *testing.T) {
+	c := require.New(t)
+
+	var buf bytes.Buffer
+
+	writeDummyExport(c, NewCSVZipWriter(&buf, exportedDate))
+
+	archive, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	c.NoError(err)
+
+	files := make(map[string][][]string)
+
+	for _, file := range archive.File {
+		reader, err := file.Open()
+		c.NoError(err)
+
+		records, err := csv.NewReader(reader).ReadAll()
+		c.NoError(err)
+		c.NoError(reader.Close())
+
+		files[file.Name] = records
+	}
+
+	c.Len(files, 8)
+	c.Len(files["periods.csv"], 1, "only the header is expected for empty entities")
+	c.Len(files["expenses_recurring.csv"], 1, "only the header is expected for empty entities")
+
+	c.Len(files["expenses.csv"], 3)
+	c.Equal([]string{"EX1", "Groceries", "45.50", "", "2024-05", "", "false", "", "", ""}, files["expenses.csv"][1])
+	c.Equal("EX2", files["expenses.csv"][2][0])
+
+	c.Len(files["saving_goals.csv"], 2)
+
+	c.Len(files["categories.csv"], 2)
+	c.Equal([]string{"CTGfood", "Food", "", "", "market;pizza"}, files["categories.csv"][1])
+}
+
+func TestJSONWriter(t *testing.T) {
+	c := require.New(t)
+
+	var buf bytes.Buffer
+
+	writeDummyExport(c, NewJSONWriter(&buf, exportedDate))
+
+	decoded := new(models.Export)
+	c.NoError(json.Unmarshal(buf.Bytes(), decoded))
+	c.Equal(models.ExportVersion, decoded.Version)
+	c.True(exportedDate.Equal(decoded.ExportedDate))
+	c.Equal("test@gmail.com", decoded.User.Username)
+	c.Len(decoded.Expenses, 2)
+	c.Equal("EX1", decoded.Expenses[0].ExpenseID)
+	c.Equal("EX2", decoded.Expenses[1].ExpenseID)
+	c.Len(decoded.SavingGoals, 1)
+
+	members := make(map[string]json.RawMessage)
+	c.NoError(json.Unmarshal(buf.Bytes(), &members))
+	c.JSONEq("[]", string(members["periods"]), "empty entities are expected as empty lists")
+	c.JSONEq("[]", string(members["expenses_recurring"]), "empty entities are expected as empty lists")
+}
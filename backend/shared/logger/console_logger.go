@@ -41,10 +41,8 @@ func (c *ConsoleLogger) Finish() error {
 	return nil
 }
 
-func (c *ConsoleLogger) AddToContext(key string, value interface{}) {
-	//TODO implement me
-	panic("implement me")
-}
+// AddToContext not implemented, the console logger doesn't keep a context
+func (c *ConsoleLogger) AddToContext(key string, value interface{}) {}
 
 func (c *ConsoleLogger) SetHandler(handler string) {}
 
@@ -34,13 +34,13 @@ func TestGetLogDataAsBytes(t *testing.T) {
 			AccessToken:   "access-token-placeholder",
 			RefreshToken:  "refresh-token-placeholder",
 			CurrentPeriod: "2025-01",
-			Remainder:     1500.0,
+			Remainder:     models.NewMoney(1500),
 		}
 
 		fields[0] = models.Any("user", user)
 		data = l.getLogDataAsBytes(infoLevel, "test_event", nil, fields)
 		c.NotNil(data)
-		c.Contains(string(data), `"properties":{"user":{"full_name":"John Doe","username":"johndoe123","created_date":"2025-01-05T20:40:56Z","updated_date":"2025-01-05T20:40:56Z","current_period":"2025-01","remainder":1500}`)
+		c.Contains(string(data), `"properties":{"user":{"full_name":"John Doe","username":"johndoe123","created_date":"2025-01-05T20:40:56Z","updated_date":"2025-01-05T20:40:56Z","current_period":"2025-01","remainder":1500.00,"verified":false,"mfa_enabled":false}`)
 	})
 
 	t.Run("Map field", func(t *testing.T) {
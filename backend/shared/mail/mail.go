@@ -0,0 +1,145 @@
+// Package mail sends emails to the users, like the instructions to reset their password. The sender is chosen with
+// MAIL_SENDER: "ses" sends the emails with Amazon SES, while "file" and "console" are stand-ins for local runs, which
+// write them to a file or to the standard output instead.
+package mail
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/JoelD7/money/backend/models"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/ses"
+	"io"
+	"os"
+	"sync"
+)
+
+const (
+	SenderSES     = "ses"
+	SenderFile    = "file"
+	SenderConsole = "console"
+
+	charset = "UTF-8"
+)
+
+var ErrUnknownSender = errors.New("unknown mail sender")
+
+type Sender interface {
+	Send(ctx context.Context, mail *models.Mail) error
+}
+
+// SESSender sends emails with Amazon SES, from an address verified in SES.
+type SESSender struct {
+	from   string
+	client *ses.SES
+}
+
+func NewSESSender(from string) (*SESSender, error) {
+	sess, err := session.NewSession()
+	if err != nil {
+		return nil, fmt.Errorf("couldn't initialize SES session: %w", err)
+	}
+
+	return &SESSender{from: from, client: ses.New(sess)}, nil
+}
+
+func (s *SESSender) Send(ctx context.Context, mail *models.Mail) error {
+	_, err := s.client.SendEmailWithContext(ctx, &ses.SendEmailInput{
+		Source: aws.String(s.from),
+		Destination: &ses.Destination{
+			ToAddresses: []*string{aws.String(mail.To)},
+		},
+		Message: &ses.Message{
+			Subject: &ses.Content{Charset: aws.String(charset), Data: aws.String(mail.Subject)},
+			Body: &ses.Body{
+				Text: &ses.Content{Charset: aws.String(charset), Data: aws.String(mail.Body)},
+			},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("couldn't send email with SES: %w", err)
+	}
+
+	return nil
+}
+
+// FileSender appends the emails to a file as JSON lines, so that they can be read by the tests and tools that need the
+// tokens they contain.
+type FileSender struct {
+	mu   sync.Mutex
+	path string
+}
+
+func NewFileSender(path string) *FileSender {
+	return &FileSender{path: path}
+}
+
+func (f *FileSender) Send(ctx context.Context, mail *models.Mail) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	file, err := os.OpenFile(f.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return fmt.Errorf("couldn't open mail file: %w", err)
+	}
+	defer file.Close()
+
+	err = json.NewEncoder(file).Encode(mail)
+	if err != nil {
+		return fmt.Errorf("couldn't write email to file: %w", err)
+	}
+
+	return nil
+}
+
+// WriterSender writes the emails to a writer in a readable format.
+type WriterSender struct {
+	mu     sync.Mutex
+	writer io.Writer
+}
+
+func NewWriterSender(writer io.Writer) *WriterSender {
+	return &WriterSender{writer: writer}
+}
+
+// NewConsoleSender returns a sender that writes the emails to the standard output.
+func NewConsoleSender() *WriterSender {
+	return NewWriterSender(os.Stdout)
+}
+
+func (w *WriterSender) Send(ctx context.Context, mail *models.Mail) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	_, err := fmt.Fprintf(w.writer, "To: %s\nSubject: %s\n\n%s\n\n", mail.To, mail.Subject, mail.Body)
+	if err != nil {
+		return fmt.Errorf("couldn't write email: %w", err)
+	}
+
+	return nil
+}
+
+// New returns the sender configured for the environment. The console sender is used when none is configured.
+func New(envConfig *models.EnvironmentConfiguration) (Sender, error) {
+	switch envConfig.MailSender {
+	case "", SenderConsole:
+		return NewConsoleSender(), nil
+	case SenderFile:
+		if envConfig.MailFilePath == "" {
+			return nil, fmt.Errorf("the file mail sender requires MAIL_FILE_PATH")
+		}
+
+		return NewFileSender(envConfig.MailFilePath), nil
+	case SenderSES:
+		if envConfig.MailFrom == "" {
+			return nil, fmt.Errorf("the SES mail sender requires MAIL_FROM")
+		}
+
+		return NewSESSender(envConfig.MailFrom)
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnknownSender, envConfig.MailSender)
+	}
+}
@@ -0,0 +1,72 @@
+package mail
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"github.com/JoelD7/money/backend/models"
+	"github.com/stretchr/testify/require"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFileSender(t *testing.T) {
+	c := require.New(t)
+
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "mails.jsonl")
+	sender := NewFileSender(path)
+
+	c.NoError(sender.Send(ctx, &models.Mail{To: "test@gmail.com", Subject: "First", Body: "token1"}))
+	c.NoError(sender.Send(ctx, &models.Mail{To: "test@gmail.com", Subject: "Second", Body: "token2"}))
+
+	file, err := os.Open(path)
+	c.NoError(err)
+	defer file.Close()
+
+	subjects := make([]string, 0)
+	scanner := bufio.NewScanner(file)
+
+	for scanner.Scan() {
+		mail := new(models.Mail)
+		c.NoError(json.Unmarshal(scanner.Bytes(), mail))
+
+		subjects = append(subjects, mail.Subject)
+	}
+
+	c.Equal([]string{"First", "Second"}, subjects)
+}
+
+func TestWriterSender(t *testing.T) {
+	c := require.New(t)
+
+	var buf bytes.Buffer
+
+	err := NewWriterSender(&buf).Send(context.Background(), &models.Mail{To: "test@gmail.com", Subject: "Reset", Body: "token"})
+	c.NoError(err)
+	c.Contains(buf.String(), "To: test@gmail.com")
+	c.Contains(buf.String(), "token")
+}
+
+func TestNew(t *testing.T) {
+	c := require.New(t)
+
+	sender, err := New(&models.EnvironmentConfiguration{})
+	c.NoError(err)
+	c.IsType(&WriterSender{}, sender)
+
+	sender, err = New(&models.EnvironmentConfiguration{MailSender: SenderFile, MailFilePath: "mails.jsonl"})
+	c.NoError(err)
+	c.IsType(&FileSender{}, sender)
+
+	_, err = New(&models.EnvironmentConfiguration{MailSender: SenderFile})
+	c.Error(err)
+
+	_, err = New(&models.EnvironmentConfiguration{MailSender: SenderSES})
+	c.Error(err)
+
+	_, err = New(&models.EnvironmentConfiguration{MailSender: "pigeon"})
+	c.ErrorIs(err, ErrUnknownSender)
+}
@@ -0,0 +1,53 @@
+// Package mergepatch applies JSON merge patches, as defined by RFC 7396. In a merge patch, the members of an object
+// replace the ones of the patched document, null members remove them and omitted members are kept.
+package mergepatch
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+var ErrInvalidPatch = errors.New("invalid merge patch")
+
+// Apply returns the result of applying the merge patch to the JSON document.
+func Apply(document, patch []byte) ([]byte, error) {
+	var patchValue interface{}
+
+	err := json.Unmarshal(patch, &patchValue)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidPatch, err)
+	}
+
+	var documentValue interface{}
+
+	err = json.Unmarshal(document, &documentValue)
+	if err != nil {
+		return nil, fmt.Errorf("invalid document: %v", err)
+	}
+
+	return json.Marshal(merge(documentValue, patchValue))
+}
+
+func merge(target, patch interface{}) interface{} {
+	patchObject, ok := patch.(map[string]interface{})
+	if !ok {
+		return patch
+	}
+
+	targetObject, ok := target.(map[string]interface{})
+	if !ok {
+		targetObject = make(map[string]interface{})
+	}
+
+	for name, value := range patchObject {
+		if value == nil {
+			delete(targetObject, name)
+			continue
+		}
+
+		targetObject[name] = merge(targetObject[name], value)
+	}
+
+	return targetObject
+}
@@ -0,0 +1,167 @@
+// Package notifier publishes budget alerts to the destinations configured for the environment.
+package notifier
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"github.com/JoelD7/money/backend/models"
+	"github.com/JoelD7/money/backend/shared/logger"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/sqs"
+	"net/http"
+	"time"
+)
+
+const (
+	// SignatureHeader contains the hex encoded HMAC-SHA256 of the request body, signed with the webhook secret.
+	SignatureHeader = "X-Money-Signature"
+
+	webhookTimeout = 5 * time.Second
+)
+
+type Notifier interface {
+	Notify(ctx context.Context, alert *models.BudgetAlert) error
+}
+
+// SQSNotifier sends budget alerts as messages to an SQS queue.
+type SQSNotifier struct {
+	queueURL string
+}
+
+func NewSQSNotifier(queueURL string) *SQSNotifier {
+	return &SQSNotifier{queueURL: queueURL}
+}
+
+func (n *SQSNotifier) Notify(ctx context.Context, alert *models.BudgetAlert) error {
+	sdkConfig, err := config.LoadDefaultConfig(ctx)
+	if err != nil {
+		return fmt.Errorf("couldn't initialize SQS client: %w", err)
+	}
+
+	sqsClient := sqs.NewFromConfig(sdkConfig)
+
+	msgBody, err := json.Marshal(alert)
+	if err != nil {
+		return fmt.Errorf("couldn't marshal message body: %w", err)
+	}
+
+	_, err = sqsClient.SendMessage(ctx, &sqs.SendMessageInput{
+		MessageBody: aws.String(string(msgBody)),
+		QueueUrl:    aws.String(n.queueURL),
+	})
+	if err != nil {
+		return fmt.Errorf("couldn't send message to SQS: %w", err)
+	}
+
+	return nil
+}
+
+// WebhookNotifier sends budget alerts as a JSON POST request to a URL. If a secret is set, the body of the request is
+// signed so that the receiver can verify where it came from.
+type WebhookNotifier struct {
+	url    string
+	secret string
+	client *http.Client
+}
+
+func NewWebhookNotifier(url, secret string) *WebhookNotifier {
+	return &WebhookNotifier{
+		url:    url,
+		secret: secret,
+		client: &http.Client{Timeout: webhookTimeout},
+	}
+}
+
+func (n *WebhookNotifier) Notify(ctx context.Context, alert *models.BudgetAlert) error {
+	body, err := json.Marshal(alert)
+	if err != nil {
+		return fmt.Errorf("couldn't marshal webhook body: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("couldn't build webhook request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	if n.secret != "" {
+		req.Header.Set(SignatureHeader, Sign(body, n.secret))
+	}
+
+	res, err := n.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("webhook request failed: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode < http.StatusOK || res.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("webhook responded with status %d", res.StatusCode)
+	}
+
+	return nil
+}
+
+// Sign returns the hex encoded HMAC-SHA256 of the body.
+func Sign(body []byte, secret string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// New returns a notifier that sends budget alerts to every destination configured for the environment. If none is
+// configured, nil is returned, which means that budget alerts are disabled.
+func New(envConfig *models.EnvironmentConfiguration) Notifier {
+	if envConfig == nil {
+		return nil
+	}
+
+	notifiers := make(multiNotifier, 0)
+
+	if envConfig.BudgetAlertQueueURL != "" {
+		notifiers = append(notifiers, NewSQSNotifier(envConfig.BudgetAlertQueueURL))
+	}
+
+	if envConfig.BudgetAlertWebhookURL != "" {
+		notifiers = append(notifiers, NewWebhookNotifier(envConfig.BudgetAlertWebhookURL, envConfig.BudgetAlertWebhookSecret))
+	}
+
+	if len(notifiers) == 0 {
+		return nil
+	}
+
+	return notifiers
+}
+
+type multiNotifier []Notifier
+
+// Notify sends the alert to every destination, even if one of them fails. An alert that failed is sent again with the
+// next expense of the category, so an error is only returned if no destination got the alert; otherwise a retry would
+// send it twice to the destinations that got it. The failures of the other destinations are logged.
+func (m multiNotifier) Notify(ctx context.Context, alert *models.BudgetAlert) error {
+	errs := make([]error, 0, len(m))
+
+	for _, n := range m {
+		err := n.Notify(ctx, alert)
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if len(errs) > 0 && len(errs) == len(m) {
+		return errs[0]
+	}
+
+	for _, err := range errs {
+		logger.Error("budget_alert_destination_failed", err, models.Any("budget_alert", alert))
+	}
+
+	return nil
+}
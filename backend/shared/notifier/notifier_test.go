@@ -0,0 +1,93 @@
+package notifier
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/JoelD7/money/backend/models"
+	"github.com/JoelD7/money/backend/shared/logger"
+	"github.com/stretchr/testify/require"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestWebhookNotifier(t *testing.T) {
+	c := require.New(t)
+
+	var (
+		body      []byte
+		signature string
+	)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ = io.ReadAll(r.Body)
+		signature = r.Header.Get(SignatureHeader)
+	}))
+	defer server.Close()
+
+	alert := &models.BudgetAlert{Username: "test@gmail.com", CategoryID: "CTGfood", Threshold: 80}
+
+	err := NewWebhookNotifier(server.URL, "secret").Notify(context.Background(), alert)
+	c.NoError(err)
+	c.Equal(Sign(body, "secret"), signature)
+
+	received := new(models.BudgetAlert)
+	c.NoError(json.Unmarshal(body, received))
+	c.Equal(alert.CategoryID, received.CategoryID)
+
+	t.Run("Unsigned", func(t *testing.T) {
+		err = NewWebhookNotifier(server.URL, "").Notify(context.Background(), alert)
+		c.NoError(err)
+		c.Empty(signature)
+	})
+
+	t.Run("Failed response", func(t *testing.T) {
+		failingServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		defer failingServer.Close()
+
+		err = NewWebhookNotifier(failingServer.URL, "").Notify(context.Background(), alert)
+		c.Error(err)
+	})
+}
+
+func TestNew(t *testing.T) {
+	c := require.New(t)
+
+	c.Nil(New(&models.EnvironmentConfiguration{}))
+	c.NotNil(New(&models.EnvironmentConfiguration{BudgetAlertWebhookURL: "https://example.com"}))
+}
+
+type notifierMock struct {
+	err   error
+	calls int
+}
+
+func (n *notifierMock) Notify(ctx context.Context, alert *models.BudgetAlert) error {
+	n.calls++
+
+	return n.err
+}
+
+func TestMultiNotifier(t *testing.T) {
+	c := require.New(t)
+
+	logger.InitLogger(logger.ConsoleImplementation)
+
+	ctx := context.Background()
+	alert := &models.BudgetAlert{Username: "test@gmail.com", CategoryID: "CTGfood", Threshold: 80}
+	errSend := errors.New("send failed")
+
+	failing := &notifierMock{err: errSend}
+	working := &notifierMock{}
+
+	// The alert isn't sent again as one of the destinations got it.
+	c.NoError(multiNotifier{failing, working}.Notify(ctx, alert))
+	c.Equal(1, failing.calls)
+	c.Equal(1, working.calls)
+
+	c.ErrorIs(multiNotifier{failing, &notifierMock{err: errSend}}.Notify(ctx, alert), errSend)
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/JoelD7/money/backend/shared/logger"
 	"github.com/JoelD7/money/backend/storage/shared"
 	"net/http"
+	"strings"
 )
 
 var (
@@ -191,6 +192,78 @@ func (router *Router) getEndpoint(pattern string) string {
 	return endpoint
 }
 
+// Match finds the endpoint of the root router that handles a request to path with the given method, as API Gateway
+// does when it sets the resource of a request. It returns the endpoint and the values of its path parameters. When
+// several endpoints match, the one with a fixed segment where the others have a parameter is chosen, so that
+// "/savings/goals" isn't handled as "/savings/{savingID}".
+func (router *Router) Match(method, path string) (string, map[string]string, bool) {
+	pathSegments := splitEndpoint(path)
+
+	var matchedEndpoint string
+	var matchedParameters map[string]string
+
+	for endpoint := range router.methodHandlers[method] {
+		endpointSegments := splitEndpoint(endpoint)
+
+		pathParameters, ok := matchSegments(endpointSegments, pathSegments)
+		if !ok {
+			continue
+		}
+
+		if matchedParameters == nil || isMoreSpecific(endpointSegments, splitEndpoint(matchedEndpoint)) {
+			matchedEndpoint = endpoint
+			matchedParameters = pathParameters
+		}
+	}
+
+	return matchedEndpoint, matchedParameters, matchedParameters != nil
+}
+
+func matchSegments(endpointSegments, pathSegments []string) (map[string]string, bool) {
+	if len(endpointSegments) != len(pathSegments) {
+		return nil, false
+	}
+
+	pathParameters := make(map[string]string)
+
+	for i, segment := range endpointSegments {
+		if isPathParameter(segment) {
+			pathParameters[strings.Trim(segment, "{}")] = pathSegments[i]
+			continue
+		}
+
+		if segment != pathSegments[i] {
+			return nil, false
+		}
+	}
+
+	return pathParameters, true
+}
+
+// isMoreSpecific reports whether the endpoint a has a fixed segment before b does.
+func isMoreSpecific(a, b []string) bool {
+	for i := range a {
+		if isPathParameter(a[i]) != isPathParameter(b[i]) {
+			return !isPathParameter(a[i])
+		}
+	}
+
+	return false
+}
+
+func isPathParameter(segment string) bool {
+	return strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}")
+}
+
+func splitEndpoint(endpoint string) []string {
+	endpoint = strings.Trim(endpoint, "/")
+	if endpoint == "" {
+		return []string{}
+	}
+
+	return strings.Split(endpoint, "/")
+}
+
 func (router *Router) isRoot() bool {
 	return router.root == nil
 }
@@ -71,6 +71,45 @@ func TestHandle(t *testing.T) {
 	c.Equal(http.StatusOK, response.StatusCode)
 }
 
+func TestMatch(t *testing.T) {
+	c := require.New(t)
+
+	rootRouter := NewRouter(&models.EnvironmentConfiguration{})
+
+	rootRouter.Route("/", func(r *Router) {
+		r.Route("/savings", func(r *Router) {
+			r.Get("/", dummyHandler())
+			r.Get("/{savingID}", dummyHandler())
+
+			r.Route("/goals", func(r *Router) {
+				r.Get("/", dummyHandler())
+				r.Get("/{savingGoalID}", dummyHandler())
+			})
+		})
+	})
+
+	endpoint, pathParameters, ok := rootRouter.Match(http.MethodGet, "/savings/SVa8Tq2")
+	c.True(ok)
+	c.Equal("/savings/{savingID}", endpoint)
+	c.Equal(map[string]string{"savingID": "SVa8Tq2"}, pathParameters)
+
+	endpoint, pathParameters, ok = rootRouter.Match(http.MethodGet, "/savings/goals/")
+	c.True(ok)
+	c.Equal("/savings/goals", endpoint)
+	c.Empty(pathParameters)
+
+	endpoint, pathParameters, ok = rootRouter.Match(http.MethodGet, "/savings/goals/SGa8Tq2")
+	c.True(ok)
+	c.Equal("/savings/goals/{savingGoalID}", endpoint)
+	c.Equal(map[string]string{"savingGoalID": "SGa8Tq2"}, pathParameters)
+
+	_, _, ok = rootRouter.Match(http.MethodPost, "/savings")
+	c.False(ok)
+
+	_, _, ok = rootRouter.Match(http.MethodGet, "/savings/goals/SGa8Tq2/other")
+	c.False(ok)
+}
+
 func TestHandleError(t *testing.T) {
 	c := require.New(t)
 
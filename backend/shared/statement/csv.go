@@ -0,0 +1,283 @@
+package statement
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"github.com/JoelD7/money/backend/models"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// CSVMapping indicates which columns of a CSV file hold the values of a transaction. Columns are referenced by the
+// name they have in the header row, compared case-insensitively.
+type CSVMapping struct {
+	Date string `json:"date"`
+	// Amount is the column of a signed amount, where negative values are debits. Use DebitsArePositive to invert this
+	// convention.
+	Amount string `json:"amount"`
+	// Debit and Credit are used by statements that have separate columns for each direction instead of a signed
+	// amount. They take precedence over Amount.
+	Debit  string `json:"debit"`
+	Credit string `json:"credit"`
+	Name   string `json:"name"`
+	Notes  string `json:"notes"`
+	// DateFormat is the layout of the date column, using Go's reference time. Defaults to 2006-01-02.
+	DateFormat string `json:"date_format"`
+	// Delimiter is the field separator. Defaults to a comma.
+	Delimiter string `json:"delimiter"`
+	// DecimalComma indicates that amounts use a comma as the decimal separator, like 1.250,75.
+	DecimalComma bool `json:"decimal_comma"`
+	// DebitsArePositive is used for statements, usually from credit cards, where charges are positive and payments are
+	// negative.
+	DebitsArePositive bool `json:"debits_are_positive"`
+}
+
+var defaultCSVMapping = CSVMapping{
+	Date:       "date",
+	Amount:     "amount",
+	Name:       "description",
+	Notes:      "notes",
+	DateFormat: time.DateOnly,
+	Delimiter:  ",",
+}
+
+// csvColumns holds the index of each mapped column. An index of -1 means that the column isn't used.
+type csvColumns struct {
+	date, amount, debit, credit, name, notes int
+}
+
+// ParseCSV parses a CSV statement with a header row. If mapping is nil, the default column names are used: date,
+// amount, description and notes.
+func ParseCSV(r io.Reader, mapping *CSVMapping) ([]*models.Transaction, error) {
+	mapping = withCSVDefaults(mapping)
+
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	delimiter, _ := utf8.DecodeRuneInString(mapping.Delimiter)
+	reader.Comma = delimiter
+
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, models.ErrNoTransactionsInFile
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("%v: %w", err, models.ErrInvalidImportFile)
+	}
+
+	columns, err := getCSVColumns(header, mapping)
+	if err != nil {
+		return nil, err
+	}
+
+	transactions := make([]*models.Transaction, 0)
+
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("%v: %w", err, models.ErrInvalidImportFile)
+		}
+
+		if isEmptyRecord(record) {
+			continue
+		}
+
+		transaction, err := parseCSVRecord(record, columns, mapping)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+
+		if transaction.Amount == 0 {
+			continue
+		}
+
+		transactions = append(transactions, transaction)
+	}
+
+	return transactions, nil
+}
+
+func withCSVDefaults(mapping *CSVMapping) *CSVMapping {
+	if mapping == nil {
+		m := defaultCSVMapping
+		return &m
+	}
+
+	m := *mapping
+
+	if m.Date == "" {
+		m.Date = defaultCSVMapping.Date
+	}
+
+	if m.Amount == "" && m.Debit == "" && m.Credit == "" {
+		m.Amount = defaultCSVMapping.Amount
+	}
+
+	if m.Name == "" {
+		m.Name = defaultCSVMapping.Name
+	}
+
+	if m.DateFormat == "" {
+		m.DateFormat = defaultCSVMapping.DateFormat
+	}
+
+	if m.Delimiter == "" {
+		m.Delimiter = defaultCSVMapping.Delimiter
+	}
+
+	return &m
+}
+
+func getCSVColumns(header []string, mapping *CSVMapping) (*csvColumns, error) {
+	indexes := make(map[string]int, len(header))
+
+	for i, column := range header {
+		// Some spreadsheet tools prepend a byte order mark to the first column.
+		column = strings.TrimPrefix(column, "\ufeff")
+		indexes[strings.ToLower(strings.TrimSpace(column))] = i
+	}
+
+	find := func(name string, required bool) (int, error) {
+		if name == "" {
+			return -1, nil
+		}
+
+		i, ok := indexes[strings.ToLower(strings.TrimSpace(name))]
+		if ok {
+			return i, nil
+		}
+
+		if required {
+			return -1, fmt.Errorf("'%s': %w", name, models.ErrMissingCSVColumn)
+		}
+
+		return -1, nil
+	}
+
+	var (
+		columns = &csvColumns{}
+		err     error
+	)
+
+	if columns.date, err = find(mapping.Date, true); err != nil {
+		return nil, err
+	}
+
+	if columns.name, err = find(mapping.Name, true); err != nil {
+		return nil, err
+	}
+
+	// Notes are optional, so a missing notes column isn't an error even if it was mapped by default.
+	if columns.notes, err = find(mapping.Notes, false); err != nil {
+		return nil, err
+	}
+
+	if columns.debit, err = find(mapping.Debit, true); err != nil {
+		return nil, err
+	}
+
+	if columns.credit, err = find(mapping.Credit, true); err != nil {
+		return nil, err
+	}
+
+	columns.amount = -1
+	if columns.debit == -1 && columns.credit == -1 {
+		if columns.amount, err = find(mapping.Amount, true); err != nil {
+			return nil, err
+		}
+	}
+
+	return columns, nil
+}
+
+func parseCSVRecord(record []string, columns *csvColumns, mapping *CSVMapping) (*models.Transaction, error) {
+	field := func(i int) string {
+		if i < 0 || i >= len(record) {
+			return ""
+		}
+
+		return strings.TrimSpace(record[i])
+	}
+
+	date, err := time.Parse(mapping.DateFormat, field(columns.date))
+	if err != nil {
+		return nil, fmt.Errorf("invalid date '%s': %w", field(columns.date), models.ErrInvalidImportFile)
+	}
+
+	amount, err := getCSVSignedAmount(field, columns, mapping)
+	if err != nil {
+		return nil, err
+	}
+
+	transaction := newTransaction(amount)
+	transaction.Date = date
+	transaction.Name = field(columns.name)
+	transaction.Notes = field(columns.notes)
+
+	return transaction, nil
+}
+
+// getCSVSignedAmount returns the amount of the record, negative if it's a debit.
+func getCSVSignedAmount(field func(i int) string, columns *csvColumns, mapping *CSVMapping) (models.Money, error) {
+	if columns.amount != -1 {
+		amount, err := parseAmount(field(columns.amount), mapping.DecimalComma)
+		if err != nil {
+			return 0, err
+		}
+
+		if mapping.DebitsArePositive {
+			return -amount, nil
+		}
+
+		return amount, nil
+	}
+
+	if value := field(columns.debit); value != "" {
+		debit, err := parseAmount(value, mapping.DecimalComma)
+		if err != nil {
+			return 0, err
+		}
+
+		if debit != 0 {
+			return -abs(debit), nil
+		}
+	}
+
+	if value := field(columns.credit); value != "" {
+		credit, err := parseAmount(value, mapping.DecimalComma)
+		if err != nil {
+			return 0, err
+		}
+
+		return abs(credit), nil
+	}
+
+	return 0, nil
+}
+
+func isEmptyRecord(record []string) bool {
+	for _, field := range record {
+		if strings.TrimSpace(field) != "" {
+			return false
+		}
+	}
+
+	return true
+}
+
+func abs(value models.Money) models.Money {
+	if value < 0 {
+		return -value
+	}
+
+	return value
+}
@@ -0,0 +1,104 @@
+package statement
+
+import (
+	"bufio"
+	"fmt"
+	"github.com/JoelD7/money/backend/models"
+	"io"
+	"regexp"
+	"strings"
+	"time"
+)
+
+const (
+	ofxTransactionTag = "STMTTRN"
+	// ofxDateLength is the length of the date part of OFX datetime values, which have the format
+	// YYYYMMDDHHMMSS.XXX[gmt offset:tz name], where everything after the date is optional.
+	ofxDateLength = 8
+	ofxDateLayout = "20060102"
+)
+
+// ofxTagRegex matches both the SGML form of OFX 1.x, where leaf elements have no closing tag, and the XML form of
+// OFX 2.x.
+var ofxTagRegex = regexp.MustCompile(`<(/?)([A-Za-z0-9.]+)>([^<]*)`)
+
+// ParseOFX parses the bank and credit card transactions of an OFX or QFX statement.
+func ParseOFX(r io.Reader) ([]*models.Transaction, error) {
+	content, err := io.ReadAll(bufio.NewReader(r))
+	if err != nil {
+		return nil, fmt.Errorf("%v: %w", err, models.ErrInvalidImportFile)
+	}
+
+	if !strings.Contains(strings.ToUpper(string(content)), "<OFX>") {
+		return nil, fmt.Errorf("missing OFX root element: %w", models.ErrInvalidImportFile)
+	}
+
+	transactions := make([]*models.Transaction, 0)
+
+	var values map[string]string
+
+	for _, match := range ofxTagRegex.FindAllStringSubmatch(string(content), -1) {
+		isClosing := match[1] == "/"
+		tag := strings.ToUpper(match[2])
+		value := strings.TrimSpace(match[3])
+
+		if tag == ofxTransactionTag {
+			// A new transaction can start without the previous one being closed in malformed SGML files.
+			if values != nil {
+				transaction, err := toOFXTransaction(values)
+				if err != nil {
+					return nil, err
+				}
+
+				transactions = append(transactions, transaction)
+			}
+
+			values = nil
+			if !isClosing {
+				values = make(map[string]string)
+			}
+
+			continue
+		}
+
+		if values != nil && !isClosing && value != "" {
+			values[tag] = value
+		}
+	}
+
+	if values != nil {
+		return nil, fmt.Errorf("unclosed %s element: %w", ofxTransactionTag, models.ErrInvalidImportFile)
+	}
+
+	return transactions, nil
+}
+
+func toOFXTransaction(values map[string]string) (*models.Transaction, error) {
+	amount, err := parseAmount(values["TRNAMT"], false)
+	if err != nil {
+		return nil, err
+	}
+
+	datePosted := values["DTPOSTED"]
+	if len(datePosted) < ofxDateLength {
+		return nil, fmt.Errorf("invalid date '%s': %w", datePosted, models.ErrInvalidImportFile)
+	}
+
+	date, err := time.Parse(ofxDateLayout, datePosted[:ofxDateLength])
+	if err != nil {
+		return nil, fmt.Errorf("invalid date '%s': %w", datePosted, models.ErrInvalidImportFile)
+	}
+
+	transaction := newTransaction(amount)
+	transaction.Date = date
+	transaction.Name = values["NAME"]
+	transaction.Notes = values["MEMO"]
+
+	// Some banks leave the name empty and put the description in the memo.
+	if transaction.Name == "" {
+		transaction.Name = transaction.Notes
+		transaction.Notes = ""
+	}
+
+	return transaction, nil
+}
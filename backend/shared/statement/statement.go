@@ -0,0 +1,111 @@
+// Package statement parses bank statement files into transactions that can be imported as expenses or income.
+package statement
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"github.com/JoelD7/money/backend/models"
+	"strings"
+)
+
+const (
+	FormatCSV = "csv"
+	FormatOFX = "ofx"
+	// FormatQFX is Quicken's flavor of OFX. It's parsed exactly like an OFX file.
+	FormatQFX = "qfx"
+)
+
+// ImportRequest is the body of the import endpoints.
+type ImportRequest struct {
+	Format string `json:"format"`
+	// File is the content of the statement file, encoded in base64.
+	File    string      `json:"file"`
+	Mapping *CSVMapping `json:"mapping,omitempty"`
+	// DryRun indicates that the records should be returned without being saved.
+	DryRun bool `json:"dry_run"`
+}
+
+// Transactions decodes the file of the request and parses it according to its format.
+func (r *ImportRequest) Transactions() ([]*models.Transaction, error) {
+	if r.File == "" {
+		return nil, models.ErrMissingImportFile
+	}
+
+	content, err := base64.StdEncoding.DecodeString(r.File)
+	if err != nil {
+		return nil, fmt.Errorf("%v: %w", err, models.ErrInvalidImportFile)
+	}
+
+	var transactions []*models.Transaction
+
+	switch strings.ToLower(r.Format) {
+	case FormatCSV:
+		transactions, err = ParseCSV(bytes.NewReader(content), r.Mapping)
+	case FormatOFX, FormatQFX:
+		transactions, err = ParseOFX(bytes.NewReader(content))
+	default:
+		return nil, models.ErrInvalidImportFormat
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(transactions) == 0 {
+		return nil, models.ErrNoTransactionsInFile
+	}
+
+	return transactions, nil
+}
+
+// parseAmount parses a monetary amount as it usually appears on bank statements. Currency symbols, thousands
+// separators and spaces are ignored, and amounts wrapped in parentheses are considered negative. If decimalComma is
+// true, the comma is taken as the decimal separator and the dot as the thousands separator.
+func parseAmount(value string, decimalComma bool) (models.Money, error) {
+	value = strings.TrimSpace(value)
+
+	if decimalComma {
+		value = strings.ReplaceAll(value, ".", "")
+		value = strings.ReplaceAll(value, ",", ".")
+	}
+
+	negative := strings.HasPrefix(value, "(") && strings.HasSuffix(value, ")")
+	if negative {
+		value = strings.Trim(value, "()")
+	}
+
+	value = strings.Map(func(r rune) rune {
+		if (r >= '0' && r <= '9') || r == '.' || r == '-' || r == '+' {
+			return r
+		}
+
+		return -1
+	}, value)
+
+	amount, err := models.ParseMoney(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid amount '%s': %w", value, models.ErrInvalidImportFile)
+	}
+
+	if negative {
+		amount = -amount
+	}
+
+	return amount, nil
+}
+
+// newTransaction builds a transaction from a signed amount, where negative amounts are debits.
+func newTransaction(amount models.Money) *models.Transaction {
+	transaction := &models.Transaction{
+		Amount: amount,
+		Type:   models.TransactionTypeCredit,
+	}
+
+	if amount < 0 {
+		transaction.Amount = -amount
+		transaction.Type = models.TransactionTypeDebit
+	}
+
+	return transaction
+}
@@ -0,0 +1,144 @@
+package statement
+
+import (
+	"errors"
+	"github.com/JoelD7/money/backend/models"
+	"github.com/stretchr/testify/require"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseCSV(t *testing.T) {
+	c := require.New(t)
+
+	t.Run("Default mapping", func(t *testing.T) {
+		file := "Date,Amount,Description,Notes\n" +
+			"2024-05-02,-45.30,Supermarket,weekly groceries\n" +
+			"2024-05-03,\"1,250.00\",Salary,\n" +
+			",,,\n" +
+			"2024-05-04,(12.5),Coffee,\n"
+
+		transactions, err := ParseCSV(strings.NewReader(file), nil)
+		c.NoError(err)
+		c.Len(transactions, 3)
+
+		c.Equal(models.TransactionTypeDebit, transactions[0].Type)
+		c.Equal(models.NewMoney(45.30), transactions[0].Amount)
+		c.Equal("Supermarket", transactions[0].Name)
+		c.Equal("weekly groceries", transactions[0].Notes)
+		c.Equal(time.Date(2024, 5, 2, 0, 0, 0, 0, time.UTC), transactions[0].Date)
+
+		c.Equal(models.TransactionTypeCredit, transactions[1].Type)
+		c.Equal(models.NewMoney(1250.0), transactions[1].Amount)
+
+		c.Equal(models.TransactionTypeDebit, transactions[2].Type)
+		c.Equal(models.NewMoney(12.5), transactions[2].Amount)
+	})
+
+	t.Run("Custom mapping with debit and credit columns", func(t *testing.T) {
+		file := "Posted;Payee;Withdrawal;Deposit\n" +
+			"02/05/2024;Gas station;1.030,50;\n" +
+			"03/05/2024;Refund;;15\n"
+
+		mapping := &CSVMapping{
+			Date:         "posted",
+			Name:         "payee",
+			Debit:        "withdrawal",
+			Credit:       "deposit",
+			DateFormat:   "02/01/2006",
+			Delimiter:    ";",
+			DecimalComma: true,
+		}
+
+		transactions, err := ParseCSV(strings.NewReader(file), mapping)
+		c.NoError(err)
+		c.Len(transactions, 2)
+		c.Equal(models.TransactionTypeDebit, transactions[0].Type)
+		c.Equal("Gas station", transactions[0].Name)
+		c.Equal(models.NewMoney(1030.50), transactions[0].Amount)
+		c.Equal(models.TransactionTypeCredit, transactions[1].Type)
+		c.Equal(models.NewMoney(15.0), transactions[1].Amount)
+	})
+
+	t.Run("Debits are positive", func(t *testing.T) {
+		file := "date,amount,description\n2024-05-02,20,Restaurant\n"
+
+		transactions, err := ParseCSV(strings.NewReader(file), &CSVMapping{DebitsArePositive: true})
+		c.NoError(err)
+		c.Len(transactions, 1)
+		c.Equal(models.TransactionTypeDebit, transactions[0].Type)
+	})
+
+	t.Run("Missing column", func(t *testing.T) {
+		file := "date,value,description\n2024-05-02,20,Restaurant\n"
+
+		_, err := ParseCSV(strings.NewReader(file), nil)
+		c.ErrorIs(err, models.ErrMissingCSVColumn)
+	})
+
+	t.Run("Invalid date", func(t *testing.T) {
+		file := "date,amount,description\n05/02/2024,20,Restaurant\n"
+
+		_, err := ParseCSV(strings.NewReader(file), nil)
+		c.ErrorIs(err, models.ErrInvalidImportFile)
+	})
+}
+
+func TestParseOFX(t *testing.T) {
+	c := require.New(t)
+
+	t.Run("SGML", func(t *testing.T) {
+		file := `OFXHEADER:100
+DATA:OFXSGML
+
+<OFX>
+<BANKMSGSRSV1><STMTTRNRS><STMTRS><BANKTRANLIST>
+<STMTTRN>
+<TRNTYPE>DEBIT
+<DTPOSTED>20240502120000.000[-4:EDT]
+<TRNAMT>-45.30
+<FITID>1
+<NAME>SUPERMARKET
+<MEMO>Card purchase
+</STMTTRN>
+<STMTTRN>
+<TRNTYPE>CREDIT
+<DTPOSTED>20240503
+<TRNAMT>1250.00
+<FITID>2
+<MEMO>Payroll deposit
+</STMTTRN>
+</BANKTRANLIST></STMTRS></STMTTRNRS></BANKMSGSRSV1>
+</OFX>`
+
+		transactions, err := ParseOFX(strings.NewReader(file))
+		c.NoError(err)
+		c.Len(transactions, 2)
+
+		c.Equal(models.TransactionTypeDebit, transactions[0].Type)
+		c.Equal(models.NewMoney(45.30), transactions[0].Amount)
+		c.Equal("SUPERMARKET", transactions[0].Name)
+		c.Equal("Card purchase", transactions[0].Notes)
+		c.Equal(time.Date(2024, 5, 2, 0, 0, 0, 0, time.UTC), transactions[0].Date)
+
+		c.Equal(models.TransactionTypeCredit, transactions[1].Type)
+		c.Equal("Payroll deposit", transactions[1].Name)
+	})
+
+	t.Run("XML", func(t *testing.T) {
+		file := `<?xml version="1.0"?><OFX><BANKTRANLIST><STMTTRN><TRNTYPE>DEBIT</TRNTYPE>` +
+			`<DTPOSTED>20240502</DTPOSTED><TRNAMT>-10</TRNAMT><NAME>Bus</NAME></STMTTRN></BANKTRANLIST></OFX>`
+
+		transactions, err := ParseOFX(strings.NewReader(file))
+		c.NoError(err)
+		c.Len(transactions, 1)
+		c.Equal("Bus", transactions[0].Name)
+		c.Equal(models.NewMoney(10.0), transactions[0].Amount)
+	})
+
+	t.Run("Not an OFX file", func(t *testing.T) {
+		_, err := ParseOFX(strings.NewReader("date,amount\n"))
+		c.True(errors.Is(err, models.ErrInvalidImportFile))
+	})
+}
@@ -0,0 +1,128 @@
+// Package totp implements the time-based one-time passwords of RFC 6238, as generated by authenticator apps.
+package totp
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"encoding/base32"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	// Digits is the length of the codes.
+	Digits = 6
+	// Period is how many seconds a code is valid for.
+	Period = 30
+	// skew is how many periods before and after the current one are accepted, to make up for clock drift and for the
+	// time the user takes to type the code.
+	skew = 1
+
+	secretSize = 20
+)
+
+var (
+	ErrInvalidCode   = errors.New("invalid code")
+	ErrInvalidSecret = errors.New("invalid secret")
+
+	encoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+)
+
+// GenerateSecret returns a random secret encoded in base32, which is how authenticator apps expect it.
+func GenerateSecret() (string, error) {
+	secret := make([]byte, secretSize)
+
+	_, err := io.ReadFull(rand.Reader, secret)
+	if err != nil {
+		return "", fmt.Errorf("generating secret failed: %v", err)
+	}
+
+	return encoding.EncodeToString(secret), nil
+}
+
+// ProvisioningURI returns the otpauth URI that authenticator apps read, usually from a QR code, to add the account.
+func ProvisioningURI(secret, issuer, account string) string {
+	params := url.Values{}
+	params.Set("secret", secret)
+	params.Set("issuer", issuer)
+	params.Set("algorithm", "SHA1")
+	params.Set("digits", fmt.Sprint(Digits))
+	params.Set("period", fmt.Sprint(Period))
+
+	label := url.PathEscape(issuer + ":" + account)
+
+	return "otpauth://totp/" + label + "?" + params.Encode()
+}
+
+// Code returns the code of the secret at the given time.
+func Code(secret string, t time.Time) (string, error) {
+	key, err := decodeSecret(secret)
+	if err != nil {
+		return "", err
+	}
+
+	return generateCode(key, step(t)), nil
+}
+
+// Validate checks that the code is the one of the secret at the given time, or at an adjacent period. To keep a code
+// from being used twice, only the codes of periods after lastUsedStep are accepted. The period of the code is
+// returned, so that it can be saved as the last one used.
+func Validate(secret, code string, t time.Time, lastUsedStep int64) (int64, error) {
+	key, err := decodeSecret(secret)
+	if err != nil {
+		return 0, err
+	}
+
+	current := step(t)
+
+	for i := current - skew; i <= current+skew; i++ {
+		if i <= lastUsedStep {
+			continue
+		}
+
+		if hmac.Equal([]byte(generateCode(key, i)), []byte(code)) {
+			return i, nil
+		}
+	}
+
+	return 0, ErrInvalidCode
+}
+
+func step(t time.Time) int64 {
+	return t.Unix() / Period
+}
+
+func decodeSecret(secret string) ([]byte, error) {
+	key, err := encoding.DecodeString(strings.ToUpper(strings.TrimRight(secret, "=")))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidSecret, err)
+	}
+
+	return key, nil
+}
+
+// generateCode implements the dynamic truncation of RFC 4226.
+func generateCode(key []byte, counter int64) string {
+	msg := make([]byte, 8)
+	binary.BigEndian.PutUint64(msg, uint64(counter))
+
+	mac := hmac.New(sha1.New, key)
+	mac.Write(msg)
+	sum := mac.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+
+	modulo := uint32(1)
+	for i := 0; i < Digits; i++ {
+		modulo *= 10
+	}
+
+	return fmt.Sprintf("%0*d", Digits, value%modulo)
+}
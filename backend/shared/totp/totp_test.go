@@ -0,0 +1,82 @@
+package totp
+
+import (
+	"encoding/base32"
+	"github.com/stretchr/testify/require"
+	"net/url"
+	"testing"
+	"time"
+)
+
+// The SHA1 test vectors of RFC 6238, truncated to 6 digits.
+func TestCode(t *testing.T) {
+	c := require.New(t)
+
+	secret := base32.StdEncoding.EncodeToString([]byte("12345678901234567890"))
+
+	tests := map[int64]string{
+		59:          "287082",
+		1111111109:  "081804",
+		1111111111:  "050471",
+		1234567890:  "005924",
+		2000000000:  "279037",
+		20000000000: "353130",
+	}
+
+	for unix, expected := range tests {
+		code, err := Code(secret, time.Unix(unix, 0))
+		c.NoError(err)
+		c.Equal(expected, code, unix)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	c := require.New(t)
+
+	secret, err := GenerateSecret()
+	c.NoError(err)
+
+	now := time.Now()
+
+	code, err := Code(secret, now)
+	c.NoError(err)
+
+	usedStep, err := Validate(secret, code, now, 0)
+	c.NoError(err)
+	c.Equal(now.Unix()/Period, usedStep)
+
+	t.Run("Code of an adjacent period", func(t *testing.T) {
+		_, err = Validate(secret, code, now.Add(Period*time.Second), 0)
+		c.NoError(err)
+
+		_, err = Validate(secret, code, now.Add(3*Period*time.Second), 0)
+		c.ErrorIs(err, ErrInvalidCode)
+	})
+
+	t.Run("Code used twice", func(t *testing.T) {
+		_, err = Validate(secret, code, now, usedStep)
+		c.ErrorIs(err, ErrInvalidCode)
+	})
+
+	t.Run("Wrong code", func(t *testing.T) {
+		_, err = Validate(secret, "000000x", now, 0)
+		c.ErrorIs(err, ErrInvalidCode)
+	})
+
+	t.Run("Invalid secret", func(t *testing.T) {
+		_, err = Validate("not base32!", code, now, 0)
+		c.ErrorIs(err, ErrInvalidSecret)
+	})
+}
+
+func TestProvisioningURI(t *testing.T) {
+	c := require.New(t)
+
+	uri, err := url.Parse(ProvisioningURI("JBSWY3DPEHPK3PXP", "Money", "test@gmail.com"))
+	c.NoError(err)
+	c.Equal("otpauth", uri.Scheme)
+	c.Equal("totp", uri.Host)
+	c.Equal("/Money:test@gmail.com", uri.Path)
+	c.Equal("JBSWY3DPEHPK3PXP", uri.Query().Get("secret"))
+	c.Equal("Money", uri.Query().Get("issuer"))
+}
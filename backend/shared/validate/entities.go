@@ -0,0 +1,155 @@
+package validate
+
+import (
+	"github.com/JoelD7/money/backend/models"
+	"time"
+)
+
+// Expense validates the fields of an expense that's created or patched.
+func Expense(expense *models.Expense) error {
+	if expense.Name == nil {
+		return models.ErrMissingName
+	}
+
+	if expense.Amount == nil {
+		return models.ErrMissingAmount
+	}
+
+	err := Amount(expense.Amount)
+	if err != nil {
+		return err
+	}
+
+	err = Currency(expense.Currency)
+	if err != nil {
+		return err
+	}
+
+	if expense.PeriodID == "" {
+		return models.ErrMissingPeriod
+	}
+
+	if expense.IsRecurring && expense.RecurringDay == nil {
+		return models.ErrMissingRecurringDay
+	}
+
+	if (expense.IsRecurring && expense.RecurringDay != nil) && (*expense.RecurringDay < 1 || *expense.RecurringDay > 31) {
+		return models.ErrInvalidRecurringDay
+	}
+
+	return nil
+}
+
+// Income validates the fields of an income that's created or patched.
+func Income(income *models.Income) error {
+	if income.Amount == nil {
+		return models.ErrMissingAmount
+	}
+
+	if income.Name == nil {
+		return models.ErrMissingName
+	}
+
+	if income.PeriodID == nil {
+		return models.ErrMissingPeriod
+	}
+
+	err := Amount(income.Amount)
+	if err != nil {
+		return err
+	}
+
+	return Currency(income.Currency)
+}
+
+// Period validates the fields of a period that's created or patched.
+func Period(period *models.Period) error {
+	if period.Name == nil || period.Name != nil && *period.Name == "" {
+		return models.ErrMissingPeriodName
+	}
+
+	if period.StartDate.IsZero() || period.EndDate.IsZero() {
+		return models.ErrMissingPeriodDates
+	}
+
+	return CategoryBudgets(period.CategoryBudgets)
+}
+
+// Saving validates the fields of a saving that's created or patched.
+func Saving(saving *models.Saving) error {
+	if saving.Amount == nil || (saving.Amount != nil && *saving.Amount == 0) {
+		return models.ErrMissingAmount
+	}
+
+	err := Amount(saving.Amount)
+	if err != nil {
+		return models.ErrInvalidSavingAmount
+	}
+
+	err = Currency(saving.Currency)
+	if err != nil {
+		return err
+	}
+
+	if saving.PeriodID == nil || (saving.PeriodID != nil && *saving.PeriodID == "") {
+		return models.ErrMissingPeriod
+	}
+
+	return nil
+}
+
+// SavingGoal validates the fields of a saving goal that's created or patched. The deadline isn't checked, as a goal
+// whose deadline already passed can still be patched; see SavingGoalDeadline.
+func SavingGoal(savingGoal *models.SavingGoal) error {
+	if savingGoal.Name == nil || (savingGoal.Name != nil && *savingGoal.Name == "") {
+		return models.ErrMissingSavingGoalName
+	}
+
+	if savingGoal.Target == nil {
+		return models.ErrMissingSavingGoalTarget
+	}
+
+	if savingGoal.Target != nil && *savingGoal.Target <= 0 {
+		return models.ErrInvalidSavingGoalTarget
+	}
+
+	if savingGoal.IsRecurring && savingGoal.RecurringAmount == nil {
+		return models.ErrMissingSavingGoalRecurringAmount
+	}
+
+	if err := Amount(savingGoal.RecurringAmount); savingGoal.IsRecurring && err != nil {
+		return err
+	}
+
+	return Currency(savingGoal.Currency)
+}
+
+// Category validates the fields of a category that's created or patched.
+func Category(category *models.Category) error {
+	if category.Name == nil || *category.Name == "" {
+		return models.ErrMissingCategoryName
+	}
+
+	if category.Budget == nil {
+		return models.ErrMissingCategoryBudget
+	}
+
+	if *category.Budget < 0 {
+		return models.ErrInvalidBudget
+	}
+
+	if category.Color == nil || *category.Color == "" {
+		return models.ErrMissingCategoryColor
+	}
+
+	return nil
+}
+
+// SavingGoalDeadline checks that the deadline of a saving goal, if any, isn't in the past.
+func SavingGoalDeadline(deadline *time.Time) error {
+	if deadline != nil && deadline.Before(time.Now()) {
+		return models.ErrInvalidSavingGoalDeadline
+	}
+
+	return nil
+}
@@ -44,14 +44,39 @@ func Email(email string) error {
 	return nil
 }
 
-func Amount(amount *float64) error {
-	if amount != nil && (*amount <= 0 || *amount > math.MaxFloat64) {
+func Amount(amount *models.Money) error {
+	if amount != nil && *amount <= 0 {
 		return models.ErrInvalidAmount
 	}
 
 	return nil
 }
 
+// CategoryBudgets validates the budgets of a period, which are indexed by category ID.
+func CategoryBudgets(budgets map[string]models.Money) error {
+	for categoryID, budget := range budgets {
+		if categoryID == "" || budget < 0 {
+			return models.ErrInvalidBudget
+		}
+	}
+
+	return nil
+}
+
+// Currency validates that the currency is an upper case ISO-4217 code. An empty currency is valid, as it means that the
+// currency wasn't specified.
+func Currency(currency string) error {
+	if currency == "" {
+		return nil
+	}
+
+	if _, ok := currencyCodes[currency]; !ok {
+		return models.ErrInvalidCurrency
+	}
+
+	return nil
+}
+
 func SortBy(sortBy string, model SortByModel) error {
 	if _, ok := validSortBy[model][sortBy]; !ok && sortBy != "" {
 		return models.ErrInvalidSortBy
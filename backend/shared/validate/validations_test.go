@@ -111,3 +111,39 @@ func TestSortBy_SavingGoals(t *testing.T) {
 		})
 	}
 }
+
+func TestCurrency(t *testing.T) {
+	cases := []struct {
+		name     string
+		input    string
+		expected error
+	}{
+		{
+			name:     "Valid currency",
+			input:    "EUR",
+			expected: nil,
+		},
+		{
+			name:     "Empty currency",
+			input:    "",
+			expected: nil,
+		},
+		{
+			name:     "Lower case currency",
+			input:    "eur",
+			expected: models.ErrInvalidCurrency,
+		},
+		{
+			name:     "Unknown currency",
+			input:    "ABC",
+			expected: models.ErrInvalidCurrency,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := Currency(tc.input)
+			assert.Equal(t, tc.expected, err)
+		})
+	}
+}
@@ -0,0 +1,56 @@
+package accountdeletion
+
+import (
+	"github.com/JoelD7/money/backend/models"
+	"time"
+)
+
+const (
+	// completedDeletionTTL is how long a finished deletion is kept, so that its result can still be checked.
+	completedDeletionTTL = 30 * 24 * time.Hour
+)
+
+type accountDeletionEntity struct {
+	Username      string                             `json:"username" dynamodbav:"username"`
+	Status        models.AccountDeletionStatus       `json:"status" dynamodbav:"status"`
+	Step          models.AccountDeletionStep         `json:"step,omitempty" dynamodbav:"step,omitempty"`
+	DeletedItems  map[models.AccountDeletionStep]int `json:"deleted_items,omitempty" dynamodbav:"deleted_items,omitempty"`
+	Error         string                             `json:"error,omitempty" dynamodbav:"error,omitempty"`
+	CreatedDate   time.Time                          `json:"created_date" dynamodbav:"created_date"`
+	UpdatedDate   time.Time                          `json:"updated_date,omitempty" dynamodbav:"updated_date"`
+	CompletedDate time.Time                          `json:"completed_date,omitempty" dynamodbav:"completed_date"`
+	// ExpirationTime is the TTL attribute of the table, in Unix seconds. Only completed deletions expire.
+	ExpirationTime int64 `json:"expiration_time,omitempty" dynamodbav:"expiration_time,omitempty"`
+}
+
+func toAccountDeletionEntity(a *models.AccountDeletion) *accountDeletionEntity {
+	entity := &accountDeletionEntity{
+		Username:      a.Username,
+		Status:        a.Status,
+		Step:          a.Step,
+		DeletedItems:  a.DeletedItems,
+		Error:         a.Error,
+		CreatedDate:   a.CreatedDate,
+		UpdatedDate:   a.UpdatedDate,
+		CompletedDate: a.CompletedDate,
+	}
+
+	if a.Status == models.AccountDeletionStatusCompleted && !a.CompletedDate.IsZero() {
+		entity.ExpirationTime = a.CompletedDate.Add(completedDeletionTTL).Unix()
+	}
+
+	return entity
+}
+
+func toAccountDeletionModel(e *accountDeletionEntity) *models.AccountDeletion {
+	return &models.AccountDeletion{
+		Username:      e.Username,
+		Status:        e.Status,
+		Step:          e.Step,
+		DeletedItems:  e.DeletedItems,
+		Error:         e.Error,
+		CreatedDate:   e.CreatedDate,
+		UpdatedDate:   e.UpdatedDate,
+		CompletedDate: e.CompletedDate,
+	}
+}
@@ -0,0 +1,76 @@
+package accountdeletion
+
+import (
+	"context"
+	"github.com/JoelD7/money/backend/models"
+)
+
+type Mock struct {
+	mockedErr        error
+	accountDeletions map[string]*models.AccountDeletion
+	sentUsernames    []string
+}
+
+func NewMock() *Mock {
+	return &Mock{
+		accountDeletions: make(map[string]*models.AccountDeletion),
+	}
+}
+
+func (m *Mock) ActivateForceFailure(err error) {
+	m.mockedErr = err
+}
+
+func (m *Mock) DeactivateForceFailure() {
+	m.mockedErr = nil
+}
+
+// GetSentUsernames returns the usernames sent to the queue, in order.
+func (m *Mock) GetSentUsernames() []string {
+	return m.sentUsernames
+}
+
+func (m *Mock) SaveAccountDeletion(ctx context.Context, accountDeletion *models.AccountDeletion) error {
+	if m.mockedErr != nil {
+		return m.mockedErr
+	}
+
+	m.accountDeletions[accountDeletion.Username] = copyAccountDeletion(accountDeletion)
+
+	return nil
+}
+
+func (m *Mock) GetAccountDeletion(ctx context.Context, username string) (*models.AccountDeletion, error) {
+	if m.mockedErr != nil {
+		return nil, m.mockedErr
+	}
+
+	accountDeletion, ok := m.accountDeletions[username]
+	if !ok {
+		return nil, models.ErrAccountDeletionNotFound
+	}
+
+	return copyAccountDeletion(accountDeletion), nil
+}
+
+func (m *Mock) SendAccountDeletion(ctx context.Context, username string) error {
+	if m.mockedErr != nil {
+		return m.mockedErr
+	}
+
+	m.sentUsernames = append(m.sentUsernames, username)
+
+	return nil
+}
+
+// copyAccountDeletion copies the deletion so that changes to it aren't visible until it's saved.
+func copyAccountDeletion(accountDeletion *models.AccountDeletion) *models.AccountDeletion {
+	copied := *accountDeletion
+	copied.DeletedItems = make(map[models.AccountDeletionStep]int, len(accountDeletion.DeletedItems))
+
+	for step, count := range accountDeletion.DeletedItems {
+		copied.DeletedItems[step] = count
+	}
+
+	return &copied
+}
@@ -0,0 +1,37 @@
+package accountdeletion
+
+import (
+	"context"
+	"github.com/JoelD7/money/backend/models"
+	"github.com/JoelD7/money/backend/storage/sqldb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+)
+
+type Repository interface {
+	SaveAccountDeletion(ctx context.Context, accountDeletion *models.AccountDeletion) error
+	GetAccountDeletion(ctx context.Context, username string) (*models.AccountDeletion, error)
+}
+
+// Queue starts the background deletion of a user's data.
+type Queue interface {
+	SendAccountDeletion(ctx context.Context, username string) error
+}
+
+// NewRepository returns the repository of the storage backend set in the configuration.
+func NewRepository(ctx context.Context, dynamoClient *dynamodb.Client, envConfig *models.EnvironmentConfiguration) (Repository, error) {
+	if envConfig.StorageBackend != models.StorageBackendSQL {
+		repo, err := NewDynamoRepository(dynamoClient, envConfig)
+		if err != nil {
+			return nil, err
+		}
+
+		return repo, nil
+	}
+
+	db, err := sqldb.Connect(ctx, envConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewSQLRepository(db), nil
+}
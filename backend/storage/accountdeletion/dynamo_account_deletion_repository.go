@@ -0,0 +1,76 @@
+package accountdeletion
+
+import (
+	"context"
+	"fmt"
+	"github.com/JoelD7/money/backend/models"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+type DynamoRepository struct {
+	dynamoClient *dynamodb.Client
+	tableName    string
+}
+
+func NewDynamoRepository(dynamoClient *dynamodb.Client, envConfig *models.EnvironmentConfiguration) (*DynamoRepository, error) {
+	d := &DynamoRepository{dynamoClient: dynamoClient}
+
+	if envConfig == nil || envConfig.AccountDeletionsTable == "" {
+		return nil, fmt.Errorf("initialize account deletions dynamo repository failed: table name is required")
+	}
+
+	d.tableName = envConfig.AccountDeletionsTable
+
+	return d, nil
+}
+
+// SaveAccountDeletion creates the account deletion of a user, or replaces it if it already exists.
+func (d *DynamoRepository) SaveAccountDeletion(ctx context.Context, accountDeletion *models.AccountDeletion) error {
+	item, err := attributevalue.MarshalMap(toAccountDeletionEntity(accountDeletion))
+	if err != nil {
+		return fmt.Errorf("marshal account deletion item failed: %v", err)
+	}
+
+	input := &dynamodb.PutItemInput{
+		TableName: aws.String(d.tableName),
+		Item:      item,
+	}
+
+	_, err = d.dynamoClient.PutItem(ctx, input)
+	if err != nil {
+		return fmt.Errorf("put account deletion item failed: %v", err)
+	}
+
+	return nil
+}
+
+func (d *DynamoRepository) GetAccountDeletion(ctx context.Context, username string) (*models.AccountDeletion, error) {
+	input := &dynamodb.GetItemInput{
+		TableName: aws.String(d.tableName),
+		Key: map[string]types.AttributeValue{
+			"username": &types.AttributeValueMemberS{Value: username},
+		},
+		ConsistentRead: aws.Bool(true),
+	}
+
+	result, err := d.dynamoClient.GetItem(ctx, input)
+	if err != nil {
+		return nil, fmt.Errorf("get account deletion item failed: %v", err)
+	}
+
+	if result.Item == nil {
+		return nil, models.ErrAccountDeletionNotFound
+	}
+
+	entity := new(accountDeletionEntity)
+
+	err = attributevalue.UnmarshalMap(result.Item, entity)
+	if err != nil {
+		return nil, fmt.Errorf("unmarshal account deletion item failed: %v", err)
+	}
+
+	return toAccountDeletionModel(entity), nil
+}
@@ -0,0 +1,85 @@
+package accountdeletion
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/JoelD7/money/backend/models"
+	"github.com/JoelD7/money/backend/storage/sqldb"
+)
+
+type SQLRepository struct {
+	db *sqldb.DB
+}
+
+func NewSQLRepository(db *sqldb.DB) *SQLRepository {
+	return &SQLRepository{db: db}
+}
+
+// SaveAccountDeletion saves the progress of the deletion. Unlike in DynamoDB, completed deletions don't expire.
+func (s *SQLRepository) SaveAccountDeletion(ctx context.Context, accountDeletion *models.AccountDeletion) error {
+	entity := toAccountDeletionEntity(accountDeletion)
+
+	deletedItems, err := sqldb.MarshalJSON(entity.DeletedItems)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.db.ExecContext(ctx, `INSERT INTO account_deletions (username, status, step, deleted_items, error,
+		created_date, updated_date, completed_date) VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT (username) DO UPDATE SET status = excluded.status, step = excluded.step,
+		deleted_items = excluded.deleted_items, error = excluded.error, created_date = excluded.created_date,
+		updated_date = excluded.updated_date, completed_date = excluded.completed_date`,
+		entity.Username, string(entity.Status), string(entity.Step), deletedItems, entity.Error,
+		sqldb.FormatTime(entity.CreatedDate), sqldb.FormatTime(entity.UpdatedDate), sqldb.FormatTime(entity.CompletedDate))
+	if err != nil {
+		return fmt.Errorf("save account deletion failed: %v", err)
+	}
+
+	return nil
+}
+
+func (s *SQLRepository) GetAccountDeletion(ctx context.Context, username string) (*models.AccountDeletion, error) {
+	entity := new(accountDeletionEntity)
+
+	var status, step string
+	var deletedItems sql.NullString
+	var createdDate, updatedDate, completedDate string
+
+	err := s.db.QueryRowContext(ctx, `SELECT username, status, step, deleted_items, error, created_date, updated_date,
+		completed_date FROM account_deletions WHERE username = ?`, username).Scan(&entity.Username, &status, &step,
+		&deletedItems, &entity.Error, &createdDate, &updatedDate, &completedDate)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, models.ErrAccountDeletionNotFound
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("get account deletion failed: %v", err)
+	}
+
+	entity.Status = models.AccountDeletionStatus(status)
+	entity.Step = models.AccountDeletionStep(step)
+
+	err = sqldb.UnmarshalJSON(deletedItems, &entity.DeletedItems)
+	if err != nil {
+		return nil, err
+	}
+
+	entity.CreatedDate, err = sqldb.ParseTime(createdDate)
+	if err != nil {
+		return nil, err
+	}
+
+	entity.UpdatedDate, err = sqldb.ParseTime(updatedDate)
+	if err != nil {
+		return nil, err
+	}
+
+	entity.CompletedDate, err = sqldb.ParseTime(completedDate)
+	if err != nil {
+		return nil, err
+	}
+
+	return toAccountDeletionModel(entity), nil
+}
@@ -0,0 +1,48 @@
+package accountdeletion
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/JoelD7/money/backend/models"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/sqs"
+)
+
+// SQSQueue sends account deletions to the queue consumed by the account-deleter lambda.
+type SQSQueue struct {
+	queueURL string
+}
+
+func NewSQSQueue(envConfig *models.EnvironmentConfiguration) (*SQSQueue, error) {
+	if envConfig == nil || envConfig.AccountDeletionQueueURL == "" {
+		return nil, fmt.Errorf("initialize account deletion queue failed: queue url is required")
+	}
+
+	return &SQSQueue{queueURL: envConfig.AccountDeletionQueueURL}, nil
+}
+
+func (q *SQSQueue) SendAccountDeletion(ctx context.Context, username string) error {
+	sdkConfig, err := config.LoadDefaultConfig(ctx)
+	if err != nil {
+		return fmt.Errorf("couldn't initialize SQS client: %w", err)
+	}
+
+	sqsClient := sqs.NewFromConfig(sdkConfig)
+
+	msgBody, err := json.Marshal(&models.AccountDeletionMessage{Username: username})
+	if err != nil {
+		return fmt.Errorf("couldn't marshal message body: %w", err)
+	}
+
+	_, err = sqsClient.SendMessage(ctx, &sqs.SendMessageInput{
+		MessageBody: aws.String(string(msgBody)),
+		QueueUrl:    aws.String(q.queueURL),
+	})
+	if err != nil {
+		return fmt.Errorf("couldn't send message to SQS: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,87 @@
+package audit
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/JoelD7/money/backend/models"
+	"github.com/JoelD7/money/backend/storage/dynamo"
+	"time"
+)
+
+type auditEntryEntity struct {
+	Username string `json:"username" dynamodbav:"username"`
+	// AuditKey sorts the entries of a user by date. It's made of the creation date and the entry ID.
+	AuditKey string `json:"audit_key" dynamodbav:"audit_key"`
+	// EntityKey groups the entries of an entity. It's made of the username, the entity type and the entity ID.
+	EntityKey   string                 `json:"entity_key" dynamodbav:"entity_key"`
+	EntryID     string                 `json:"entry_id" dynamodbav:"entry_id"`
+	EntityType  models.AuditEntityType `json:"entity_type" dynamodbav:"entity_type"`
+	EntityID    string                 `json:"entity_id" dynamodbav:"entity_id"`
+	Action      models.AuditAction     `json:"action" dynamodbav:"action"`
+	Actor       string                 `json:"actor" dynamodbav:"actor"`
+	Source      models.AuditSource     `json:"source" dynamodbav:"source"`
+	Changes     string                 `json:"changes" dynamodbav:"changes"`
+	CreatedDate time.Time              `json:"created_date" dynamodbav:"created_date"`
+}
+
+func buildEntityKey(username string, entityType models.AuditEntityType, entityID string) string {
+	return fmt.Sprintf("%s:%s:%s", username, entityType, entityID)
+}
+
+func toAuditEntryEntity(entry *models.AuditEntry) (*auditEntryEntity, error) {
+	changes, err := json.Marshal(entry.Changes)
+	if err != nil {
+		return nil, fmt.Errorf("marshal audit entry changes failed: %v", err)
+	}
+
+	return &auditEntryEntity{
+		Username:    entry.Username,
+		AuditKey:    dynamo.BuildCreatedDateEntityIDKey(entry.CreatedDate, entry.EntryID),
+		EntityKey:   buildEntityKey(entry.Username, entry.EntityType, entry.EntityID),
+		EntryID:     entry.EntryID,
+		EntityType:  entry.EntityType,
+		EntityID:    entry.EntityID,
+		Action:      entry.Action,
+		Actor:       entry.Actor,
+		Source:      entry.Source,
+		Changes:     string(changes),
+		CreatedDate: entry.CreatedDate,
+	}, nil
+}
+
+func toAuditEntryModel(e *auditEntryEntity) (*models.AuditEntry, error) {
+	entry := &models.AuditEntry{
+		Username:    e.Username,
+		EntryID:     e.EntryID,
+		EntityType:  e.EntityType,
+		EntityID:    e.EntityID,
+		Action:      e.Action,
+		Actor:       e.Actor,
+		Source:      e.Source,
+		CreatedDate: e.CreatedDate,
+	}
+
+	if e.Changes != "" {
+		err := json.Unmarshal([]byte(e.Changes), &entry.Changes)
+		if err != nil {
+			return nil, fmt.Errorf("unmarshal audit entry changes failed: %v", err)
+		}
+	}
+
+	return entry, nil
+}
+
+func toAuditEntryModels(entities []*auditEntryEntity) ([]*models.AuditEntry, error) {
+	entries := make([]*models.AuditEntry, 0, len(entities))
+
+	for _, entity := range entities {
+		entry, err := toAuditEntryModel(entity)
+		if err != nil {
+			return nil, err
+		}
+
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
@@ -0,0 +1,83 @@
+package audit
+
+import (
+	"context"
+	"github.com/JoelD7/money/backend/models"
+)
+
+type Mock struct {
+	mockedErr error
+	// entries holds the entries of each user in the order they were created.
+	entries map[string][]*models.AuditEntry
+}
+
+func NewMock() *Mock {
+	return &Mock{
+		entries: make(map[string][]*models.AuditEntry),
+	}
+}
+
+func (m *Mock) ActivateForceFailure(err error) {
+	m.mockedErr = err
+}
+
+func (m *Mock) DeactivateForceFailure() {
+	m.mockedErr = nil
+}
+
+func (m *Mock) CreateAuditEntries(ctx context.Context, entries []*models.AuditEntry) error {
+	if m.mockedErr != nil {
+		return m.mockedErr
+	}
+
+	for _, entry := range entries {
+		m.entries[entry.Username] = append(m.entries[entry.Username], entry)
+	}
+
+	return nil
+}
+
+// GetEntityHistory returns all the entries of the entity, most recent first. The pagination parameters are ignored.
+func (m *Mock) GetEntityHistory(ctx context.Context, username string, entityType models.AuditEntityType, entityID string, params *models.QueryParameters) ([]*models.AuditEntry, string, error) {
+	return m.filter(username, func(entry *models.AuditEntry) bool {
+		return entry.EntityType == entityType && entry.EntityID == entityID
+	})
+}
+
+// GetAuditEntries returns all the entries of the user, most recent first. The pagination parameters are ignored.
+func (m *Mock) GetAuditEntries(ctx context.Context, username string, params *models.QueryParameters) ([]*models.AuditEntry, string, error) {
+	return m.filter(username, func(entry *models.AuditEntry) bool {
+		return true
+	})
+}
+
+func (m *Mock) filter(username string, match func(entry *models.AuditEntry) bool) ([]*models.AuditEntry, string, error) {
+	if m.mockedErr != nil {
+		return nil, "", m.mockedErr
+	}
+
+	entries := make([]*models.AuditEntry, 0)
+
+	for i := len(m.entries[username]) - 1; i >= 0; i-- {
+		if match(m.entries[username][i]) {
+			entries = append(entries, m.entries[username][i])
+		}
+	}
+
+	if len(entries) == 0 {
+		return nil, "", models.ErrAuditEntriesNotFound
+	}
+
+	return entries, "", nil
+}
+
+func (m *Mock) DeleteAllAuditEntries(ctx context.Context, username string) (int, error) {
+	if m.mockedErr != nil {
+		return 0, m.mockedErr
+	}
+
+	deleted := len(m.entries[username])
+	delete(m.entries, username)
+
+	return deleted, nil
+}
@@ -0,0 +1,40 @@
+package audit
+
+import (
+	"context"
+	"github.com/JoelD7/money/backend/models"
+	"github.com/JoelD7/money/backend/storage/sqldb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+)
+
+type Repository interface {
+	CreateAuditEntries(ctx context.Context, entries []*models.AuditEntry) error
+	GetEntityHistory(ctx context.Context, username string, entityType models.AuditEntityType, entityID string, params *models.QueryParameters) ([]*models.AuditEntry, string, error)
+	GetAuditEntries(ctx context.Context, username string, params *models.QueryParameters) ([]*models.AuditEntry, string, error)
+	DeleteAllAuditEntries(ctx context.Context, username string) (int, error)
+}
+
+// NewRepository returns the repository of the storage backend set in the configuration.
+func NewRepository(ctx context.Context, dynamoClient *dynamodb.Client, envConfig *models.EnvironmentConfiguration) (Repository, error) {
+	if envConfig.StorageBackend != models.StorageBackendSQL {
+		repo, err := NewDynamoRepository(dynamoClient, envConfig)
+		if err != nil {
+			return nil, err
+		}
+
+		return repo, nil
+	}
+
+	db, err := sqldb.Connect(ctx, envConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewSQLRepository(db), nil
+}
+
+// IsEnabled tells if the changes to the entities are recorded. The SQL backend always has the audit log table, while
+// DynamoDB needs the table to be configured.
+func IsEnabled(envConfig *models.EnvironmentConfiguration) bool {
+	return envConfig.StorageBackend == models.StorageBackendSQL || envConfig.AuditTable != ""
+}
@@ -0,0 +1,195 @@
+package audit
+
+import (
+	"context"
+	"fmt"
+	"github.com/JoelD7/money/backend/models"
+	"github.com/JoelD7/money/backend/storage/cursor"
+	"github.com/JoelD7/money/backend/storage/dynamo"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/expression"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+type DynamoRepository struct {
+	dynamoClient     *dynamodb.Client
+	tableName        string
+	entityAuditIndex string
+}
+
+func NewDynamoRepository(dynamoClient *dynamodb.Client, envConfig *models.EnvironmentConfiguration) (*DynamoRepository, error) {
+	d := &DynamoRepository{dynamoClient: dynamoClient}
+
+	if envConfig == nil || envConfig.AuditTable == "" {
+		return nil, fmt.Errorf("initialize audit dynamo repository failed: table name is required")
+	}
+
+	if envConfig.EntityAuditIndex == "" {
+		return nil, fmt.Errorf("initialize audit dynamo repository failed: entity audit index is required")
+	}
+
+	d.tableName = envConfig.AuditTable
+	d.entityAuditIndex = envConfig.EntityAuditIndex
+
+	return d, nil
+}
+
+func (d *DynamoRepository) CreateAuditEntries(ctx context.Context, entries []*models.AuditEntry) error {
+	writeRequests := make([]types.WriteRequest, 0, len(entries))
+
+	for _, entry := range entries {
+		entity, err := toAuditEntryEntity(entry)
+		if err != nil {
+			return err
+		}
+
+		item, err := attributevalue.MarshalMap(entity)
+		if err != nil {
+			return fmt.Errorf("marshal audit entry failed: %v", err)
+		}
+
+		writeRequests = append(writeRequests, types.WriteRequest{
+			PutRequest: &types.PutRequest{Item: item},
+		})
+	}
+
+	err := dynamo.BatchWrite(ctx, d.dynamoClient, &dynamodb.BatchWriteItemInput{
+		RequestItems: map[string][]types.WriteRequest{
+			d.tableName: writeRequests,
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("create audit entries failed: %w", err)
+	}
+
+	return nil
+}
+
+// GetEntityHistory returns a page of the entries of an entity, most recent first.
+func (d *DynamoRepository) GetEntityHistory(ctx context.Context, username string, entityType models.AuditEntityType, entityID string, params *models.QueryParameters) ([]*models.AuditEntry, string, error) {
+	entityKey := buildEntityKey(username, entityType, entityID)
+	keyCondition := expression.Key("entity_key").Equal(expression.Value(entityKey))
+
+	return d.query(ctx, d.entityAuditIndex, keyCondition, cursor.Scope{Username: username, Query: entityKey}, params)
+}
+
+// GetAuditEntries returns a page of the entries of all the entities of the user, most recent first.
+func (d *DynamoRepository) GetAuditEntries(ctx context.Context, username string, params *models.QueryParameters) ([]*models.AuditEntry, string, error) {
+	keyCondition := expression.Key("username").Equal(expression.Value(username))
+
+	return d.query(ctx, "", keyCondition, cursor.NewScope(username, params), params)
+}
+
+func (d *DynamoRepository) query(ctx context.Context, indexName string, keyCondition expression.KeyConditionBuilder, scope cursor.Scope, params *models.QueryParameters) ([]*models.AuditEntry, string, error) {
+	expr, err := expression.NewBuilder().WithKeyCondition(keyCondition).Build()
+	if err != nil {
+		return nil, "", fmt.Errorf("build expression failed: %v", err)
+	}
+
+	input := &dynamodb.QueryInput{
+		TableName:                 aws.String(d.tableName),
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		KeyConditionExpression:    expr.KeyCondition(),
+		ScanIndexForward:          aws.Bool(false),
+		Limit:                     dynamo.GetPageSize(params.PageSize),
+	}
+
+	if indexName != "" {
+		input.IndexName = aws.String(indexName)
+	}
+
+	err = dynamo.SetExclusiveStartKey(params.StartKey, scope, input)
+	if err != nil {
+		return nil, "", err
+	}
+
+	result, err := d.dynamoClient.Query(ctx, input)
+	if err != nil {
+		return nil, "", fmt.Errorf("query audit entries failed: %v", err)
+	}
+
+	if len(result.Items) == 0 && params.StartKey == "" {
+		return nil, "", models.ErrAuditEntriesNotFound
+	}
+
+	if len(result.Items) == 0 {
+		return nil, "", models.ErrNoMoreItemsToBeRetrieved
+	}
+
+	entities := make([]*auditEntryEntity, 0, len(result.Items))
+
+	err = attributevalue.UnmarshalListOfMaps(result.Items, &entities)
+	if err != nil {
+		return nil, "", fmt.Errorf("unmarshal audit entries failed: %v", err)
+	}
+
+	nextKey, err := dynamo.EncodePaginationKey(result.LastEvaluatedKey, scope)
+	if err != nil {
+		return nil, "", err
+	}
+
+	entries, err := toAuditEntryModels(entities)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return entries, nextKey, nil
+}
+
+// DeleteAllAuditEntries deletes every entry of the user and returns how many were deleted.
+func (d *DynamoRepository) DeleteAllAuditEntries(ctx context.Context, username string) (int, error) {
+	keyCondition := expression.Key("username").Equal(expression.Value(username))
+	projection := expression.NamesList(expression.Name("username"), expression.Name("audit_key"))
+
+	expr, err := expression.NewBuilder().WithKeyCondition(keyCondition).WithProjection(projection).Build()
+	if err != nil {
+		return 0, fmt.Errorf("build expression failed: %v", err)
+	}
+
+	input := &dynamodb.QueryInput{
+		TableName:                 aws.String(d.tableName),
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		KeyConditionExpression:    expr.KeyCondition(),
+		ProjectionExpression:      expr.Projection(),
+	}
+
+	deleted := 0
+
+	for {
+		result, err := d.dynamoClient.Query(ctx, input)
+		if err != nil {
+			return deleted, fmt.Errorf("query audit entries failed: %v", err)
+		}
+
+		if len(result.Items) > 0 {
+			writeRequests := make([]types.WriteRequest, 0, len(result.Items))
+
+			for _, key := range result.Items {
+				writeRequests = append(writeRequests, types.WriteRequest{
+					DeleteRequest: &types.DeleteRequest{Key: key},
+				})
+			}
+
+			err = dynamo.BatchWrite(ctx, d.dynamoClient, &dynamodb.BatchWriteItemInput{
+				RequestItems: map[string][]types.WriteRequest{
+					d.tableName: writeRequests,
+				},
+			})
+			if err != nil {
+				return deleted, fmt.Errorf("delete audit entries failed: %w", err)
+			}
+
+			deleted += len(writeRequests)
+		}
+
+		if result.LastEvaluatedKey == nil {
+			return deleted, nil
+		}
+
+		input.ExclusiveStartKey = result.LastEvaluatedKey
+	}
+}
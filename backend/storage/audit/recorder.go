@@ -0,0 +1,228 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/JoelD7/money/backend/models"
+	"github.com/JoelD7/money/backend/shared/logger"
+	"github.com/JoelD7/money/backend/shared/uuid"
+	"github.com/JoelD7/money/backend/storage/dynamo"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"reflect"
+	"sort"
+	"time"
+)
+
+// ignoredFields are the fields that change on every update, so they aren't part of the changes of an entry.
+var ignoredFields = map[string]bool{
+	"username":     true,
+	"update_date":  true,
+	"updated_date": true,
+	"version":      true,
+}
+
+type sourceKey struct{}
+
+type source struct {
+	source models.AuditSource
+	actor  string
+}
+
+// WithSource returns a context whose changes are recorded as made from source by actor. An empty actor means the owner
+// of the changed entity. Changes made with a context without source are recorded as made from the API by the owner.
+func WithSource(ctx context.Context, auditSource models.AuditSource, actor string) context.Context {
+	return context.WithValue(ctx, sourceKey{}, source{auditSource, actor})
+}
+
+func getSource(ctx context.Context, username string) (models.AuditSource, string) {
+	s, ok := ctx.Value(sourceKey{}).(source)
+	if !ok {
+		return models.AuditSourceAPI, username
+	}
+
+	if s.actor == "" {
+		return s.source, username
+	}
+
+	return s.source, s.actor
+}
+
+// Change is a write to an entity. Old is nil for created entities and New is nil for deleted ones.
+type Change struct {
+	Username   string
+	EntityType models.AuditEntityType
+	EntityID   string
+	Old        interface{}
+	New        interface{}
+}
+
+// Recorder saves the changes made by the repositories of the entities in the audit log. A nil Recorder doesn't record
+// anything, which is the case when the audit log is disabled.
+type Recorder struct {
+	repo Repository
+}
+
+// NewRecorder returns the recorder of the audit log, or nil if it isn't enabled in the configuration.
+func NewRecorder(ctx context.Context, dynamoClient *dynamodb.Client, envConfig *models.EnvironmentConfiguration) (*Recorder, error) {
+	if !IsEnabled(envConfig) {
+		return nil, nil
+	}
+
+	repo, err := NewRepository(ctx, dynamoClient, envConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewRecorderWithRepository(repo), nil
+}
+
+func NewRecorderWithRepository(repo Repository) *Recorder {
+	return &Recorder{repo: repo}
+}
+
+// Record saves an entry for each of the changes. Updates that didn't change any field are skipped. The changes were
+// already made when they are recorded, so failing to record them is logged instead of returned.
+func (r *Recorder) Record(ctx context.Context, changes ...*Change) {
+	if r == nil || len(changes) == 0 {
+		return
+	}
+
+	entries := make([]*models.AuditEntry, 0, len(changes))
+
+	for _, change := range changes {
+		entry, err := newAuditEntry(ctx, change)
+		if err != nil {
+			logger.Error("build_audit_entry_failed", err, models.Any("audit_change", map[string]interface{}{
+				"s_username":    change.Username,
+				"s_entity_type": change.EntityType,
+				"s_entity_id":   change.EntityID,
+			}))
+
+			continue
+		}
+
+		if entry.Action == models.AuditActionUpdate && len(entry.Changes) == 0 {
+			continue
+		}
+
+		entries = append(entries, entry)
+	}
+
+	if len(entries) == 0 {
+		return
+	}
+
+	err := r.repo.CreateAuditEntries(ctx, entries)
+	if err != nil {
+		logger.Error("record_audit_entries_failed", err, models.Any("audit_data", map[string]interface{}{
+			"s_username":      entries[0].Username,
+			"i_entries_count": len(entries),
+		}))
+	}
+}
+
+func newAuditEntry(ctx context.Context, change *Change) (*models.AuditEntry, error) {
+	auditSource, actor := getSource(ctx, change.Username)
+
+	entry := &models.AuditEntry{
+		Username:    change.Username,
+		EntryID:     uuid.Generate(dynamo.GenerateID("AUD")),
+		EntityType:  change.EntityType,
+		EntityID:    change.EntityID,
+		Action:      models.AuditActionUpdate,
+		Actor:       actor,
+		Source:      auditSource,
+		CreatedDate: time.Now(),
+	}
+
+	if isNil(change.Old) {
+		entry.Action = models.AuditActionCreate
+	}
+
+	if isNil(change.New) {
+		entry.Action = models.AuditActionDelete
+	}
+
+	var err error
+
+	entry.Changes, err = diff(change.Old, change.New)
+	if err != nil {
+		return nil, err
+	}
+
+	return entry, nil
+}
+
+// diff returns the fields of the JSON representation of the entity that are different between old and new.
+func diff(old, new interface{}) ([]*models.AuditChange, error) {
+	oldFields, err := toFields(old)
+	if err != nil {
+		return nil, err
+	}
+
+	newFields, err := toFields(new)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(oldFields)+len(newFields))
+
+	for name := range oldFields {
+		names = append(names, name)
+	}
+
+	for name := range newFields {
+		if _, ok := oldFields[name]; !ok {
+			names = append(names, name)
+		}
+	}
+
+	sort.Strings(names)
+
+	changes := make([]*models.AuditChange, 0)
+
+	for _, name := range names {
+		if ignoredFields[name] || reflect.DeepEqual(oldFields[name], newFields[name]) {
+			continue
+		}
+
+		changes = append(changes, &models.AuditChange{
+			Field:    name,
+			OldValue: oldFields[name],
+			NewValue: newFields[name],
+		})
+	}
+
+	return changes, nil
+}
+
+func toFields(entity interface{}) (map[string]interface{}, error) {
+	fields := make(map[string]interface{})
+
+	if isNil(entity) {
+		return fields, nil
+	}
+
+	data, err := json.Marshal(entity)
+	if err != nil {
+		return nil, fmt.Errorf("marshal audited entity failed: %v", err)
+	}
+
+	err = json.Unmarshal(data, &fields)
+	if err != nil {
+		return nil, fmt.Errorf("unmarshal audited entity failed: %v", err)
+	}
+
+	return fields, nil
+}
+
+func isNil(value interface{}) bool {
+	if value == nil {
+		return true
+	}
+
+	v := reflect.ValueOf(value)
+
+	return v.Kind() == reflect.Ptr && v.IsNil()
+}
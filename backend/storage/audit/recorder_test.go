@@ -0,0 +1,67 @@
+package audit
+
+import (
+	"context"
+	"github.com/JoelD7/money/backend/models"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestRecorder(t *testing.T) {
+	c := require.New(t)
+
+	ctx := context.Background()
+	mock := NewMock()
+	recorder := NewRecorderWithRepository(mock)
+
+	name := "Groceries"
+	oldAmount := models.NewMoney(100)
+	newAmount := models.NewMoney(150)
+
+	oldExpense := &models.Expense{ExpenseID: "EX1", Username: "test", Name: &name, Amount: &oldAmount, PeriodID: "2024-01"}
+	newExpense := &models.Expense{ExpenseID: "EX1", Username: "test", Name: &name, Amount: &newAmount, PeriodID: "2024-02"}
+
+	recorder.Record(ctx, &Change{Username: "test", EntityType: models.AuditEntityExpense, EntityID: "EX1", New: oldExpense})
+	recorder.Record(WithSource(ctx, models.AuditSourcePeriodSetter, "recurrent-expense-period-setter"), &Change{
+		Username:   "test",
+		EntityType: models.AuditEntityExpense,
+		EntityID:   "EX1",
+		Old:        oldExpense,
+		New:        newExpense,
+	})
+	// Nothing changed, so it isn't recorded.
+	recorder.Record(ctx, &Change{Username: "test", EntityType: models.AuditEntityExpense, EntityID: "EX1", Old: newExpense, New: newExpense})
+	recorder.Record(ctx, &Change{Username: "test", EntityType: models.AuditEntityExpense, EntityID: "EX1", Old: newExpense})
+
+	entries, _, err := mock.GetEntityHistory(ctx, "test", models.AuditEntityExpense, "EX1", &models.QueryParameters{})
+	c.NoError(err)
+	c.Len(entries, 3)
+
+	deleted, updated, created := entries[0], entries[1], entries[2]
+
+	c.Equal(models.AuditActionCreate, created.Action)
+	c.Equal(models.AuditSourceAPI, created.Source)
+	c.Equal("test", created.Actor)
+
+	c.Equal(models.AuditActionUpdate, updated.Action)
+	c.Equal(models.AuditSourcePeriodSetter, updated.Source)
+	c.Equal("recurrent-expense-period-setter", updated.Actor)
+	c.Len(updated.Changes, 2)
+	c.Equal("amount", updated.Changes[0].Field)
+	c.Equal(float64(100), updated.Changes[0].OldValue)
+	c.Equal(float64(150), updated.Changes[0].NewValue)
+	c.Equal("period_id", updated.Changes[1].Field)
+	c.Equal("2024-01", updated.Changes[1].OldValue)
+	c.Equal("2024-02", updated.Changes[1].NewValue)
+
+	c.Equal(models.AuditActionDelete, deleted.Action)
+	c.NotEmpty(deleted.Changes)
+
+	t.Run("Nil recorder", func(t *testing.T) {
+		var nilRecorder *Recorder
+
+		c.NotPanics(func() {
+			nilRecorder.Record(ctx, &Change{Username: "test", EntityType: models.AuditEntityExpense, EntityID: "EX2", New: newExpense})
+		})
+	})
+}
@@ -0,0 +1,142 @@
+package audit
+
+import (
+	"context"
+	"fmt"
+	"github.com/JoelD7/money/backend/models"
+	"github.com/JoelD7/money/backend/storage/cursor"
+	"github.com/JoelD7/money/backend/storage/sqldb"
+)
+
+const auditEntryColumns = `username, entry_id, entity_type, entity_id, action, actor, source, changes, created_date`
+
+type SQLRepository struct {
+	db *sqldb.DB
+}
+
+func NewSQLRepository(db *sqldb.DB) *SQLRepository {
+	return &SQLRepository{db: db}
+}
+
+func (s *SQLRepository) CreateAuditEntries(ctx context.Context, entries []*models.AuditEntry) error {
+	return s.db.WithTx(ctx, func(tx *sqldb.Tx) error {
+		for _, entry := range entries {
+			entity, err := toAuditEntryEntity(entry)
+			if err != nil {
+				return err
+			}
+
+			_, err = tx.ExecContext(ctx, `INSERT INTO audit_entries (`+auditEntryColumns+`) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+				entity.Username, entity.EntryID, string(entity.EntityType), entity.EntityID, string(entity.Action),
+				entity.Actor, string(entity.Source), entity.Changes, sqldb.FormatTime(entity.CreatedDate))
+			if err != nil {
+				return fmt.Errorf("insert audit entry failed: %v", err)
+			}
+		}
+
+		return nil
+	})
+}
+
+// GetEntityHistory returns a page of the entries of an entity, most recent first.
+func (s *SQLRepository) GetEntityHistory(ctx context.Context, username string, entityType models.AuditEntityType, entityID string, params *models.QueryParameters) ([]*models.AuditEntry, string, error) {
+	return s.query(ctx, `username = ? AND entity_type = ? AND entity_id = ?`,
+		[]interface{}{username, string(entityType), entityID},
+		cursor.Scope{Username: username, Query: string(entityType) + ":" + entityID}, params)
+}
+
+// GetAuditEntries returns a page of the entries of all the entities of the user, most recent first.
+func (s *SQLRepository) GetAuditEntries(ctx context.Context, username string, params *models.QueryParameters) ([]*models.AuditEntry, string, error) {
+	return s.query(ctx, `username = ?`, []interface{}{username}, cursor.NewScope(username, params), params)
+}
+
+func (s *SQLRepository) query(ctx context.Context, where string, whereArgs []interface{}, scope cursor.Scope, params *models.QueryParameters) ([]*models.AuditEntry, string, error) {
+	page := &sqldb.Page{
+		SortColumn: "created_date",
+		IDColumn:   "entry_id",
+		Descending: true,
+		StartKey:   params.StartKey,
+		PageSize:   params.PageSize,
+		Scope:      scope,
+	}
+
+	condition, args, err := page.Condition()
+	if err != nil {
+		return nil, "", err
+	}
+
+	rows, err := s.db.QueryContext(ctx, `SELECT `+auditEntryColumns+` FROM audit_entries WHERE `+where+` AND `+
+		condition+` `+page.OrderBy(), append(whereArgs, args...)...)
+	if err != nil {
+		return nil, "", fmt.Errorf("query audit entries failed: %v", err)
+	}
+
+	defer rows.Close()
+
+	entities := make([]*auditEntryEntity, 0)
+
+	for rows.Next() {
+		entity, err := scanAuditEntry(rows)
+		if err != nil {
+			return nil, "", err
+		}
+
+		entities = append(entities, entity)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, "", fmt.Errorf("query audit entries failed: %v", err)
+	}
+
+	entities, nextKey, err := sqldb.Paginate(entities, page, func(e *auditEntryEntity) (interface{}, string) {
+		return sqldb.FormatTime(e.CreatedDate), e.EntryID
+	}, models.ErrAuditEntriesNotFound)
+	if err != nil {
+		return nil, "", err
+	}
+
+	entries, err := toAuditEntryModels(entities)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return entries, nextKey, nil
+}
+
+// DeleteAllAuditEntries deletes every entry of the user and returns how many were deleted.
+func (s *SQLRepository) DeleteAllAuditEntries(ctx context.Context, username string) (int, error) {
+	result, err := s.db.ExecContext(ctx, `DELETE FROM audit_entries WHERE username = ?`, username)
+	if err != nil {
+		return 0, fmt.Errorf("delete audit entries failed: %v", err)
+	}
+
+	deleted, err := sqldb.RowsAffected(result)
+	if err != nil {
+		return 0, err
+	}
+
+	return int(deleted), nil
+}
+
+func scanAuditEntry(row sqldb.Scanner) (*auditEntryEntity, error) {
+	entity := new(auditEntryEntity)
+
+	var entityType, action, auditSource, createdDate string
+
+	err := row.Scan(&entity.Username, &entity.EntryID, &entityType, &entity.EntityID, &action, &entity.Actor,
+		&auditSource, &entity.Changes, &createdDate)
+	if err != nil {
+		return nil, err
+	}
+
+	entity.EntityType = models.AuditEntityType(entityType)
+	entity.Action = models.AuditAction(action)
+	entity.Source = models.AuditSource(auditSource)
+
+	entity.CreatedDate, err = sqldb.ParseTime(createdDate)
+	if err != nil {
+		return nil, err
+	}
+
+	return entity, nil
+}
@@ -0,0 +1,91 @@
+package audit
+
+import (
+	"context"
+	"github.com/JoelD7/money/backend/models"
+	"github.com/JoelD7/money/backend/storage/sqldb"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestSQLRepository(t *testing.T) {
+	t.Setenv("PAGINATION_KEY_SECRET", "pagination-secret")
+
+	c := require.New(t)
+
+	ctx := context.Background()
+	repo := NewSQLRepository(newTestDB(c))
+
+	now := time.Now()
+
+	entries := []*models.AuditEntry{
+		newTestEntry("AUD1", models.AuditEntityExpense, "EX1", models.AuditActionCreate, now),
+		newTestEntry("AUD2", models.AuditEntityIncome, "IN1", models.AuditActionCreate, now.Add(time.Minute)),
+		newTestEntry("AUD3", models.AuditEntityExpense, "EX1", models.AuditActionUpdate, now.Add(2*time.Minute)),
+		newTestEntry("AUD4", models.AuditEntityExpense, "EX1", models.AuditActionDelete, now.Add(3*time.Minute)),
+	}
+	entries[2].Changes = []*models.AuditChange{{Field: "amount", OldValue: float64(10), NewValue: float64(20)}}
+
+	c.NoError(repo.CreateAuditEntries(ctx, entries))
+
+	history, nextKey, err := repo.GetEntityHistory(ctx, "test", models.AuditEntityExpense, "EX1", &models.QueryParameters{PageSize: 2})
+	c.NoError(err)
+	c.NotEmpty(nextKey)
+	c.Equal([]string{"AUD4", "AUD3"}, getEntryIDs(history))
+	c.Equal(models.AuditSourceAPI, history[1].Source)
+	c.Len(history[1].Changes, 1)
+	c.Equal("amount", history[1].Changes[0].Field)
+	c.Equal(float64(20), history[1].Changes[0].NewValue)
+
+	history, nextKey, err = repo.GetEntityHistory(ctx, "test", models.AuditEntityExpense, "EX1", &models.QueryParameters{PageSize: 2, StartKey: nextKey})
+	c.NoError(err)
+	c.Empty(nextKey)
+	c.Equal([]string{"AUD1"}, getEntryIDs(history))
+
+	activity, _, err := repo.GetAuditEntries(ctx, "test", &models.QueryParameters{})
+	c.NoError(err)
+	c.Equal([]string{"AUD4", "AUD3", "AUD2", "AUD1"}, getEntryIDs(activity))
+
+	_, _, err = repo.GetEntityHistory(ctx, "test", models.AuditEntityIncome, "EX1", &models.QueryParameters{})
+	c.ErrorIs(err, models.ErrAuditEntriesNotFound)
+
+	deleted, err := repo.DeleteAllAuditEntries(ctx, "test")
+	c.NoError(err)
+	c.Equal(4, deleted)
+
+	_, _, err = repo.GetAuditEntries(ctx, "test", &models.QueryParameters{})
+	c.ErrorIs(err, models.ErrAuditEntriesNotFound)
+}
+
+func newTestEntry(entryID string, entityType models.AuditEntityType, entityID string, action models.AuditAction, createdDate time.Time) *models.AuditEntry {
+	return &models.AuditEntry{
+		Username:    "test",
+		EntryID:     entryID,
+		EntityType:  entityType,
+		EntityID:    entityID,
+		Action:      action,
+		Actor:       "test",
+		Source:      models.AuditSourceAPI,
+		CreatedDate: createdDate,
+	}
+}
+
+func getEntryIDs(entries []*models.AuditEntry) []string {
+	ids := make([]string, 0, len(entries))
+
+	for _, entry := range entries {
+		ids = append(ids, entry.EntryID)
+	}
+
+	return ids
+}
+
+func newTestDB(c *require.Assertions) *sqldb.DB {
+	db, err := sqldb.Open(sqldb.DriverSQLite, ":memory:")
+	c.NoError(err)
+
+	c.NoError(db.Migrate(context.Background()))
+
+	return db
+}
@@ -0,0 +1,39 @@
+package budgetalert
+
+import (
+	"github.com/JoelD7/money/backend/models"
+	"github.com/JoelD7/money/backend/storage/dynamo"
+	"time"
+)
+
+type budgetAlertEntity struct {
+	Username string `json:"username" dynamodbav:"username"`
+	// AlertKey is the sort key of the table. It's composed of the period id, the category id and the threshold, so that
+	// an alert can only be stored once for each threshold of a category in a period.
+	AlertKey       string       `json:"alert_key" dynamodbav:"alert_key"`
+	PeriodID       string       `json:"period_id" dynamodbav:"period_id"`
+	CategoryID     string       `json:"category_id" dynamodbav:"category_id"`
+	CategoryName   string       `json:"category_name,omitempty" dynamodbav:"category_name,omitempty"`
+	Threshold      int          `json:"threshold" dynamodbav:"threshold"`
+	Budget         models.Money `json:"budget" dynamodbav:"budget"`
+	Total          models.Money `json:"total" dynamodbav:"total"`
+	PercentageUsed float64      `json:"percentage_used" dynamodbav:"percentage_used"`
+	Currency       string       `json:"currency,omitempty" dynamodbav:"currency,omitempty"`
+	CreatedDate    time.Time    `json:"created_date,omitempty" dynamodbav:"created_date"`
+}
+
+func toBudgetAlertEntity(a *models.BudgetAlert) *budgetAlertEntity {
+	return &budgetAlertEntity{
+		Username:       a.Username,
+		AlertKey:       dynamo.BuildBudgetAlertKey(a.PeriodID, a.CategoryID, a.Threshold),
+		PeriodID:       a.PeriodID,
+		CategoryID:     a.CategoryID,
+		CategoryName:   a.CategoryName,
+		Threshold:      a.Threshold,
+		Budget:         a.Budget,
+		Total:          a.Total,
+		PercentageUsed: a.PercentageUsed,
+		Currency:       a.Currency,
+		CreatedDate:    a.CreatedDate,
+	}
+}
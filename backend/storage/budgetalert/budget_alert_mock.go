@@ -0,0 +1,80 @@
+package budgetalert
+
+import (
+	"context"
+	"github.com/JoelD7/money/backend/models"
+	"github.com/JoelD7/money/backend/storage/dynamo"
+)
+
+type Mock struct {
+	mockedErr error
+	alerts    map[string]*models.BudgetAlert
+}
+
+func NewMock() *Mock {
+	return &Mock{
+		alerts: make(map[string]*models.BudgetAlert),
+	}
+}
+
+func (m *Mock) ActivateForceFailure(err error) {
+	m.mockedErr = err
+}
+
+func (m *Mock) DeactivateForceFailure() {
+	m.mockedErr = nil
+}
+
+// GetAlerts returns the alerts stored in the mock.
+func (m *Mock) GetAlerts() []*models.BudgetAlert {
+	alerts := make([]*models.BudgetAlert, 0, len(m.alerts))
+
+	for _, alert := range m.alerts {
+		alerts = append(alerts, alert)
+	}
+
+	return alerts
+}
+
+func (m *Mock) CreateBudgetAlert(ctx context.Context, alert *models.BudgetAlert) error {
+	if m.mockedErr != nil {
+		return m.mockedErr
+	}
+
+	key := alert.Username + ":" + dynamo.BuildBudgetAlertKey(alert.PeriodID, alert.CategoryID, alert.Threshold)
+
+	if _, ok := m.alerts[key]; ok {
+		return models.ErrBudgetAlertAlreadySent
+	}
+
+	m.alerts[key] = alert
+
+	return nil
+}
+
+func (m *Mock) DeleteBudgetAlert(ctx context.Context, alert *models.BudgetAlert) error {
+	if m.mockedErr != nil {
+		return m.mockedErr
+	}
+
+	delete(m.alerts, alert.Username+":"+dynamo.BuildBudgetAlertKey(alert.PeriodID, alert.CategoryID, alert.Threshold))
+
+	return nil
+}
+
+func (m *Mock) DeleteAllBudgetAlerts(ctx context.Context, username string) (int, error) {
+	if m.mockedErr != nil {
+		return 0, m.mockedErr
+	}
+
+	deleted := 0
+
+	for key, alert := range m.alerts {
+		if alert.Username == username {
+			delete(m.alerts, key)
+			deleted++
+		}
+	}
+
+	return deleted, nil
+}
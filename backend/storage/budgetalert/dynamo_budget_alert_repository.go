@@ -0,0 +1,133 @@
+package budgetalert
+
+import (
+	"context"
+	"fmt"
+	"github.com/JoelD7/money/backend/models"
+	"github.com/JoelD7/money/backend/storage/dynamo"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/expression"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"strings"
+)
+
+const (
+	conditionalFailedKeyword = "ConditionalCheckFailed"
+)
+
+type DynamoRepository struct {
+	dynamoClient *dynamodb.Client
+	tableName    string
+}
+
+func NewDynamoRepository(dynamoClient *dynamodb.Client, envConfig *models.EnvironmentConfiguration) (*DynamoRepository, error) {
+	d := &DynamoRepository{dynamoClient: dynamoClient}
+
+	if envConfig == nil || envConfig.BudgetAlertsTable == "" {
+		return nil, fmt.Errorf("initialize budget alerts dynamo repository failed: table name is required")
+	}
+
+	d.tableName = envConfig.BudgetAlertsTable
+
+	return d, nil
+}
+
+// CreateBudgetAlert stores the alert only if it doesn't exist yet. An alert that was already stored means that it was
+// already sent, in which case models.ErrBudgetAlertAlreadySent is returned.
+func (d *DynamoRepository) CreateBudgetAlert(ctx context.Context, alert *models.BudgetAlert) error {
+	item, err := attributevalue.MarshalMap(toBudgetAlertEntity(alert))
+	if err != nil {
+		return fmt.Errorf("marshal budget alert item failed: %v", err)
+	}
+
+	input := &dynamodb.PutItemInput{
+		TableName:           aws.String(d.tableName),
+		Item:                item,
+		ConditionExpression: aws.String("attribute_not_exists(alert_key)"),
+	}
+
+	_, err = d.dynamoClient.PutItem(ctx, input)
+	if err != nil && strings.Contains(err.Error(), conditionalFailedKeyword) {
+		return models.ErrBudgetAlertAlreadySent
+	}
+
+	if err != nil {
+		return fmt.Errorf("put budget alert item failed: %v", err)
+	}
+
+	return nil
+}
+
+func (d *DynamoRepository) DeleteBudgetAlert(ctx context.Context, alert *models.BudgetAlert) error {
+	input := &dynamodb.DeleteItemInput{
+		TableName: aws.String(d.tableName),
+		Key: map[string]types.AttributeValue{
+			"username":  &types.AttributeValueMemberS{Value: alert.Username},
+			"alert_key": &types.AttributeValueMemberS{Value: dynamo.BuildBudgetAlertKey(alert.PeriodID, alert.CategoryID, alert.Threshold)},
+		},
+	}
+
+	_, err := d.dynamoClient.DeleteItem(ctx, input)
+	if err != nil {
+		return fmt.Errorf("delete budget alert item failed: %v", err)
+	}
+
+	return nil
+}
+
+// DeleteAllBudgetAlerts deletes every budget alert of the user and returns how many were deleted.
+func (d *DynamoRepository) DeleteAllBudgetAlerts(ctx context.Context, username string) (int, error) {
+	keyCondition := expression.Key("username").Equal(expression.Value(username))
+	projection := expression.NamesList(expression.Name("username"), expression.Name("alert_key"))
+
+	expr, err := expression.NewBuilder().WithKeyCondition(keyCondition).WithProjection(projection).Build()
+	if err != nil {
+		return 0, fmt.Errorf("build expression failed: %v", err)
+	}
+
+	input := &dynamodb.QueryInput{
+		TableName:                 aws.String(d.tableName),
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		KeyConditionExpression:    expr.KeyCondition(),
+		ProjectionExpression:      expr.Projection(),
+	}
+
+	deleted := 0
+
+	for {
+		result, err := d.dynamoClient.Query(ctx, input)
+		if err != nil {
+			return deleted, fmt.Errorf("query budget alerts failed: %v", err)
+		}
+
+		if len(result.Items) > 0 {
+			writeRequests := make([]types.WriteRequest, 0, len(result.Items))
+
+			for _, key := range result.Items {
+				writeRequests = append(writeRequests, types.WriteRequest{
+					DeleteRequest: &types.DeleteRequest{Key: key},
+				})
+			}
+
+			err = dynamo.BatchWrite(ctx, d.dynamoClient, &dynamodb.BatchWriteItemInput{
+				RequestItems: map[string][]types.WriteRequest{
+					d.tableName: writeRequests,
+				},
+			})
+			if err != nil {
+				return deleted, fmt.Errorf("delete budget alerts failed: %w", err)
+			}
+
+			deleted += len(writeRequests)
+		}
+
+		if result.LastEvaluatedKey == nil {
+			return deleted, nil
+		}
+
+		input.ExclusiveStartKey = result.LastEvaluatedKey
+	}
+}
@@ -0,0 +1,66 @@
+package budgetalert
+
+import (
+	"context"
+	"fmt"
+	"github.com/JoelD7/money/backend/models"
+	"github.com/JoelD7/money/backend/storage/sqldb"
+)
+
+type SQLRepository struct {
+	db *sqldb.DB
+}
+
+func NewSQLRepository(db *sqldb.DB) *SQLRepository {
+	return &SQLRepository{db: db}
+}
+
+func (s *SQLRepository) CreateBudgetAlert(ctx context.Context, alert *models.BudgetAlert) error {
+	entity := toBudgetAlertEntity(alert)
+
+	result, err := s.db.ExecContext(ctx, `INSERT INTO budget_alerts (username, period_id, category_id, threshold,
+		category_name, budget, total, percentage_used, currency, created_date) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT (username, period_id, category_id, threshold) DO NOTHING`,
+		entity.Username, entity.PeriodID, entity.CategoryID, entity.Threshold, entity.CategoryName, entity.Budget,
+		entity.Total, entity.PercentageUsed, entity.Currency, sqldb.FormatTime(entity.CreatedDate))
+	if err != nil {
+		return fmt.Errorf("insert budget alert failed: %v", err)
+	}
+
+	affected, err := sqldb.RowsAffected(result)
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return models.ErrBudgetAlertAlreadySent
+	}
+
+	return nil
+}
+
+func (s *SQLRepository) DeleteBudgetAlert(ctx context.Context, alert *models.BudgetAlert) error {
+	_, err := s.db.ExecContext(ctx, `DELETE FROM budget_alerts
+		WHERE username = ? AND period_id = ? AND category_id = ? AND threshold = ?`,
+		alert.Username, alert.PeriodID, alert.CategoryID, alert.Threshold)
+	if err != nil {
+		return fmt.Errorf("delete budget alert failed: %v", err)
+	}
+
+	return nil
+}
+
+// DeleteAllBudgetAlerts deletes every budget alert of the user and returns how many were deleted.
+func (s *SQLRepository) DeleteAllBudgetAlerts(ctx context.Context, username string) (int, error) {
+	result, err := s.db.ExecContext(ctx, `DELETE FROM budget_alerts WHERE username = ?`, username)
+	if err != nil {
+		return 0, fmt.Errorf("delete budget alerts failed: %v", err)
+	}
+
+	deleted, err := sqldb.RowsAffected(result)
+	if err != nil {
+		return 0, err
+	}
+
+	return int(deleted), nil
+}
@@ -3,11 +3,14 @@ package cache
 import (
 	"context"
 	"github.com/JoelD7/money/backend/models"
+	"time"
 )
 
 const (
 	invalidTokenKeyPrefix  = "invalid_tokens"
 	incomePeriodsKeyPrefix = "income_periods"
+	failedLoginsKeyPrefix  = "failed_logins"
+	loginBlockKeyPrefix    = "login_block"
 )
 
 type InvalidTokenManager interface {
@@ -15,12 +18,30 @@ type InvalidTokenManager interface {
 	AddInvalidToken(ctx context.Context, username, token string, ttl int64) error
 }
 
+// LoginAttemptManager counts the failed logins of a key, like a username or a source IP, and blocks its logins.
+type LoginAttemptManager interface {
+	// AddFailedLogin counts a failed login of the key and returns how many there are. The count is kept until window
+	// seconds pass without failures.
+	AddFailedLogin(ctx context.Context, key string, window int64) (int64, error)
+	// GetLoginBlock returns until when the logins of the key are blocked, or the zero time if they aren't.
+	GetLoginBlock(ctx context.Context, key string) (time.Time, error)
+	BlockLogin(ctx context.Context, key string, until time.Time) error
+	// DeleteLoginAttempts removes the failed logins and the block of the key.
+	DeleteLoginAttempts(ctx context.Context, key string) error
+}
+
 type IncomePeriodCacheManager interface {
 	AddIncomePeriods(ctx context.Context, username string, periods []string) error
 	GetIncomePeriods(ctx context.Context, username string) ([]string, error)
 	DeleteIncomePeriods(ctx context.Context, username string, periods ...string) error
 }
 
+// UserCacheManager removes the keys that belong to a user. Idempotency keys aren't included, as they aren't
+// associated with a user and expire on their own.
+type UserCacheManager interface {
+	DeleteUserData(ctx context.Context, username string) error
+}
+
 // IdempotenceCacheManager handles reads and writes to cached resources with idempotency keys
 type IdempotenceCacheManager interface {
 	// AddResource adds a resource to the cache for ttl seconds. If the passed-in ttl is 0, the default TTL set via the
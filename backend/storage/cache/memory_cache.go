@@ -0,0 +1,249 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+	"github.com/JoelD7/money/backend/models"
+	"github.com/redis/go-redis/v9"
+	"sync"
+	"time"
+)
+
+// cachedResource is a resource stored with an idempotency key, along with the time it expires.
+type cachedResource struct {
+	value  string
+	expire time.Time
+}
+
+// failedLogins is the count of failed logins of a key, along with the time it expires.
+type failedLogins struct {
+	count  int64
+	expire time.Time
+}
+
+// MemoryCache is a thread-safe implementation of the cache managers that keeps the keys in memory instead of Redis.
+type MemoryCache struct {
+	mu            sync.Mutex
+	invalidTokens map[string][]*models.InvalidToken
+	incomePeriods map[string]map[string]struct{}
+	resources     map[string]cachedResource
+	failedLogins  map[string]failedLogins
+	loginBlocks   map[string]time.Time
+	ttl           int64
+}
+
+func NewMemoryCache() *MemoryCache {
+	return &MemoryCache{
+		invalidTokens: make(map[string][]*models.InvalidToken),
+		incomePeriods: make(map[string]map[string]struct{}),
+		resources:     make(map[string]cachedResource),
+		failedLogins:  make(map[string]failedLogins),
+		loginBlocks:   make(map[string]time.Time),
+	}
+}
+
+func (m *MemoryCache) GetInvalidTokens(ctx context.Context, username string) ([]*models.InvalidToken, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if len(m.invalidTokens[username]) == 0 {
+		return nil, models.ErrInvalidTokensNotFound
+	}
+
+	invalidTokens := make([]*models.InvalidToken, 0, len(m.invalidTokens[username]))
+
+	for _, it := range m.invalidTokens[username] {
+		invalidToken := *it
+		invalidTokens = append(invalidTokens, &invalidToken)
+	}
+
+	return invalidTokens, nil
+}
+
+// AddInvalidToken adds the token to the invalid tokens of the user, removing the ones that have expired, as the Redis
+// cache does.
+func (m *MemoryCache) AddInvalidToken(ctx context.Context, username, token string, ttl int64) error {
+	if time.Now().Unix() > ttl && ttl > 0 {
+		return ErrInvalidTTL
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	newInvalidTokens := make([]*models.InvalidToken, 0)
+	newInvalidTokens = append(newInvalidTokens, &models.InvalidToken{Token: token, Expire: ttl, CreatedDate: time.Now()})
+
+	now := time.Now().Unix()
+
+	for _, it := range m.invalidTokens[username] {
+		if now <= it.Expire {
+			newInvalidTokens = append(newInvalidTokens, it)
+		}
+	}
+
+	m.invalidTokens[username] = newInvalidTokens
+
+	return nil
+}
+
+func (m *MemoryCache) AddIncomePeriods(ctx context.Context, username string, periods []string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.incomePeriods[username] == nil {
+		m.incomePeriods[username] = make(map[string]struct{})
+	}
+
+	for _, period := range periods {
+		m.incomePeriods[username][period] = struct{}{}
+	}
+
+	return nil
+}
+
+func (m *MemoryCache) GetIncomePeriods(ctx context.Context, username string) ([]string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if len(m.incomePeriods[username]) == 0 {
+		return nil, models.ErrIncomePeriodsNotFound
+	}
+
+	periods := make([]string, 0, len(m.incomePeriods[username]))
+
+	for period := range m.incomePeriods[username] {
+		periods = append(periods, period)
+	}
+
+	return periods, nil
+}
+
+func (m *MemoryCache) DeleteIncomePeriods(ctx context.Context, username string, periods ...string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, period := range periods {
+		delete(m.incomePeriods[username], period)
+	}
+
+	return nil
+}
+
+func (m *MemoryCache) DeleteUserData(ctx context.Context, username string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.invalidTokens, username)
+	delete(m.incomePeriods, username)
+
+	return nil
+}
+
+func (m *MemoryCache) AddFailedLogin(ctx context.Context, key string, window int64) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := time.Now()
+
+	failures := m.failedLogins[key]
+	if !now.Before(failures.expire) {
+		failures.count = 0
+	}
+
+	failures.count++
+	failures.expire = now.Add(time.Duration(window) * time.Second)
+
+	m.failedLogins[key] = failures
+
+	return failures.count, nil
+}
+
+func (m *MemoryCache) GetLoginBlock(ctx context.Context, key string) (time.Time, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	until, ok := m.loginBlocks[key]
+	if !ok || !time.Now().Before(until) {
+		delete(m.loginBlocks, key)
+		return time.Time{}, nil
+	}
+
+	return until, nil
+}
+
+func (m *MemoryCache) BlockLogin(ctx context.Context, key string, until time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.loginBlocks[key] = until
+
+	return nil
+}
+
+func (m *MemoryCache) DeleteLoginAttempts(ctx context.Context, key string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.failedLogins, key)
+	delete(m.loginBlocks, key)
+
+	return nil
+}
+
+// AddResource stores the resource only if there isn't one with the same key, like the SETNX command of Redis.
+func (m *MemoryCache) AddResource(ctx context.Context, key string, resource interface{}, ttl int64) error {
+	if ttl == 0 && m.ttl == 0 {
+		ttl = defaultIdempotencyCacheTTLSeconds
+	}
+
+	if ttl == 0 {
+		ttl = m.ttl
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if existing, ok := m.resources[key]; ok && time.Now().Before(existing.expire) {
+		return nil
+	}
+
+	m.resources[key] = cachedResource{
+		value:  resourceToString(resource),
+		expire: time.Now().Add(time.Duration(ttl) * time.Second),
+	}
+
+	return nil
+}
+
+// resourceToString converts the resource to the string Redis would store.
+func resourceToString(resource interface{}) string {
+	switch value := resource.(type) {
+	case string:
+		return value
+	case []byte:
+		return string(value)
+	default:
+		return fmt.Sprint(value)
+	}
+}
+
+// GetResource returns redis.Nil when the key doesn't exist, as the Redis cache does.
+func (m *MemoryCache) GetResource(ctx context.Context, key string) (string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	resource, ok := m.resources[key]
+	if !ok || !time.Now().Before(resource.expire) {
+		delete(m.resources, key)
+		return "", redis.Nil
+	}
+
+	return resource.value, nil
+}
+
+func (m *MemoryCache) SetTTL(ttl int64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.ttl = ttl
+}
@@ -147,6 +147,75 @@ func (r *RedisCache) DeleteIncomePeriods(ctx context.Context, username string, p
 	return nil
 }
 
+func (r *RedisCache) DeleteUserData(ctx context.Context, username string) error {
+	keys := []string{
+		buildKey(invalidTokenKeyPrefix, username),
+		buildKey(incomePeriodsKeyPrefix, username),
+	}
+
+	_, err := r.client.Del(ctx, keys...).Result()
+	if err != nil {
+		return fmt.Errorf("cache: delete user data: %v", err)
+	}
+
+	return nil
+}
+
+func (r *RedisCache) AddFailedLogin(ctx context.Context, key string, window int64) (int64, error) {
+	redisKey := buildKey(failedLoginsKeyPrefix, key)
+
+	var failures *redis.IntCmd
+
+	// The count and its expiration are set in a transaction, so that a count without expiration is never left behind.
+	_, err := r.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		failures = pipe.Incr(ctx, redisKey)
+		pipe.Expire(ctx, redisKey, time.Duration(window)*time.Second)
+
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("cache: add failed login: %v", err)
+	}
+
+	return failures.Val(), nil
+}
+
+func (r *RedisCache) GetLoginBlock(ctx context.Context, key string) (time.Time, error) {
+	until, err := r.client.Get(ctx, buildKey(loginBlockKeyPrefix, key)).Int64()
+	if errors.Is(err, redis.Nil) {
+		return time.Time{}, nil
+	}
+
+	if err != nil {
+		return time.Time{}, fmt.Errorf("cache: get login block: %v", err)
+	}
+
+	return time.UnixMilli(until), nil
+}
+
+func (r *RedisCache) BlockLogin(ctx context.Context, key string, until time.Time) error {
+	ttl := time.Until(until)
+	if ttl <= 0 {
+		return nil
+	}
+
+	err := r.client.Set(ctx, buildKey(loginBlockKeyPrefix, key), until.UnixMilli(), ttl).Err()
+	if err != nil {
+		return fmt.Errorf("cache: block login: %v", err)
+	}
+
+	return nil
+}
+
+func (r *RedisCache) DeleteLoginAttempts(ctx context.Context, key string) error {
+	_, err := r.client.Del(ctx, buildKey(failedLoginsKeyPrefix, key), buildKey(loginBlockKeyPrefix, key)).Result()
+	if err != nil {
+		return fmt.Errorf("cache: delete login attempts: %v", err)
+	}
+
+	return nil
+}
+
 func (r *RedisCache) AddResource(ctx context.Context, key string, resource interface{}, ttl int64) error {
 	if ttl == 0 && r.ttl == 0 {
 		ttl = defaultIdempotencyCacheTTLSeconds
@@ -3,6 +3,7 @@ package cache
 import (
 	"context"
 	"github.com/JoelD7/money/backend/models"
+	"time"
 )
 
 type redisMock struct {
@@ -81,3 +82,37 @@ func (r *redisMock) GetIncomePeriods(ctx context.Context, username string) ([]st
 func (r *redisMock) DeleteIncomePeriods(ctx context.Context, username string, periods ...string) error {
 	return nil
 }
+
+func (r *redisMock) DeleteUserData(ctx context.Context, username string) error {
+	if r.mockedErr != nil {
+		return r.mockedErr
+	}
+
+	delete(r.store, username)
+
+	return nil
+}
+
+func (r *redisMock) AddFailedLogin(ctx context.Context, key string, window int64) (int64, error) {
+	if r.mockedErr != nil {
+		return 0, r.mockedErr
+	}
+
+	return 1, nil
+}
+
+func (r *redisMock) GetLoginBlock(ctx context.Context, key string) (time.Time, error) {
+	if r.mockedErr != nil {
+		return time.Time{}, r.mockedErr
+	}
+
+	return time.Time{}, nil
+}
+
+func (r *redisMock) BlockLogin(ctx context.Context, key string, until time.Time) error {
+	return r.mockedErr
+}
+
+func (r *redisMock) DeleteLoginAttempts(ctx context.Context, key string) error {
+	return r.mockedErr
+}
@@ -0,0 +1,255 @@
+// Package cursor seals the start keys of paginated queries, so that clients can't read or forge them.
+//
+// A cursor carries the position of the last item of a page, which is an internal key of the storage backend, e.g. the
+// LastEvaluatedKey of DynamoDB. The cursor is authenticated with an HMAC of the position, its expiry and the scope of
+// the query, so it's only accepted for the same user, filters and sort of the query that returned it. The position is
+// also encrypted when PAGINATION_ENCRYPTION_KEY is set.
+//
+// The HMAC key is PAGINATION_KEY_SECRET, which is required: a key of its own in every instance of a lambda function
+// would make the cursors fail on the next instance. Only the local server may use a random key for the process, with
+// UseEphemeralSecret.
+package cursor
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"github.com/JoelD7/money/backend/models"
+	"github.com/JoelD7/money/backend/shared/env"
+	"io"
+	"sync"
+	"time"
+)
+
+const (
+	formatSigned    byte = 1
+	formatEncrypted byte = 2
+
+	// headerSize is the size of the format byte and the expiry.
+	headerSize = 1 + 8
+
+	defaultTTL = 24 * time.Hour
+)
+
+var (
+	// ErrMissingSecret is returned when PAGINATION_KEY_SECRET isn't set.
+	ErrMissingSecret = errors.New("PAGINATION_KEY_SECRET is required")
+
+	ephemeralSecret     []byte
+	ephemeralSecretOnce sync.Once
+
+	encoding = base64.RawURLEncoding
+	// now is replaced in tests to expire cursors.
+	now = time.Now
+)
+
+// Scope is what a cursor is bound to: the user that runs the query and the shape of the query.
+type Scope struct {
+	Username string
+	// Query identifies the filters and the sort of the query. Queries that aren't described by models.QueryParameters
+	// set it to whatever tells them apart, e.g. the ID of the entity whose items are listed.
+	Query string
+}
+
+// NewScope returns the scope of a query run by the user with the given parameters.
+func NewScope(username string, params *models.QueryParameters) Scope {
+	if params == nil {
+		return Scope{Username: username}
+	}
+
+	return Scope{Username: username, Query: params.Shape()}
+}
+
+// CheckSecret fails with ErrMissingSecret if cursors can't be sealed, so that the functions that paginate fail on
+// initialization instead of on every paginated request.
+func CheckSecret() error {
+	_, err := getSecret()
+
+	return err
+}
+
+// UseEphemeralSecret makes the cursors be sealed with a random key of the process when PAGINATION_KEY_SECRET isn't
+// set. The cursors aren't valid after a restart, which is fine for the local server, but not for lambda functions.
+func UseEphemeralSecret() {
+	ephemeralSecretOnce.Do(func() {
+		ephemeralSecret = make([]byte, sha256.Size)
+
+		_, err := io.ReadFull(rand.Reader, ephemeralSecret)
+		if err != nil {
+			panic(fmt.Errorf("cursor: generating secret failed: %w", err))
+		}
+	})
+}
+
+// Seal returns the cursor of the position for a query of the given scope.
+func Seal(position []byte, scope Scope) (string, error) {
+	format := formatSigned
+	payload := position
+
+	gcm, err := getCipher()
+	if err != nil {
+		return "", err
+	}
+
+	if gcm != nil {
+		format = formatEncrypted
+
+		payload, err = encrypt(gcm, position)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	token := make([]byte, headerSize, headerSize+len(payload)+sha256.Size)
+	token[0] = format
+	binary.BigEndian.PutUint64(token[1:headerSize], uint64(now().Add(getTTL()).Unix()))
+	token = append(token, payload...)
+
+	signature, err := sign(token, scope)
+	if err != nil {
+		return "", err
+	}
+
+	token = append(token, signature...)
+
+	return encoding.EncodeToString(token), nil
+}
+
+// Open returns the position of the cursor. It fails with models.ErrInvalidStartKey if the cursor was tampered with,
+// expired or belongs to a query of another scope.
+func Open(cursor string, scope Scope) ([]byte, error) {
+	token, err := encoding.DecodeString(cursor)
+	if err != nil {
+		return nil, fmt.Errorf("decoding cursor: %v: %w", err, models.ErrInvalidStartKey)
+	}
+
+	if len(token) < headerSize+sha256.Size {
+		return nil, fmt.Errorf("cursor is too short: %w", models.ErrInvalidStartKey)
+	}
+
+	signed, signature := token[:len(token)-sha256.Size], token[len(token)-sha256.Size:]
+
+	expectedSignature, err := sign(signed, scope)
+	if err != nil {
+		return nil, err
+	}
+
+	if !hmac.Equal(signature, expectedSignature) {
+		return nil, fmt.Errorf("cursor signature doesn't match: %w", models.ErrInvalidStartKey)
+	}
+
+	expiry := time.Unix(int64(binary.BigEndian.Uint64(signed[1:headerSize])), 0)
+	if now().After(expiry) {
+		return nil, fmt.Errorf("cursor expired at %s: %w", expiry.Format(time.RFC3339), models.ErrInvalidStartKey)
+	}
+
+	payload := signed[headerSize:]
+
+	switch signed[0] {
+	case formatSigned:
+		return payload, nil
+	case formatEncrypted:
+		return decryptPayload(payload)
+	default:
+		return nil, fmt.Errorf("unknown cursor format %d: %w", signed[0], models.ErrInvalidStartKey)
+	}
+}
+
+func decryptPayload(payload []byte) ([]byte, error) {
+	gcm, err := getCipher()
+	if err != nil {
+		return nil, err
+	}
+
+	if gcm == nil {
+		return nil, fmt.Errorf("cursor is encrypted, but there's no encryption key: %w", models.ErrInvalidStartKey)
+	}
+
+	if len(payload) < gcm.NonceSize() {
+		return nil, fmt.Errorf("encrypted cursor is too short: %w", models.ErrInvalidStartKey)
+	}
+
+	nonce, ciphertext := payload[:gcm.NonceSize()], payload[gcm.NonceSize():]
+
+	position, err := gcm.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return nil, fmt.Errorf("decrypting cursor: %v: %w", err, models.ErrInvalidStartKey)
+	}
+
+	return position, nil
+}
+
+// sign returns the HMAC of the token along with the scope. The parts of the scope are separated by a zero byte, which
+// can't be part of a username, so that no two scopes are signed the same way.
+func sign(token []byte, scope Scope) ([]byte, error) {
+	secret, err := getSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(token)
+	mac.Write([]byte{0})
+	mac.Write([]byte(scope.Username))
+	mac.Write([]byte{0})
+	mac.Write([]byte(scope.Query))
+
+	return mac.Sum(nil), nil
+}
+
+func encrypt(gcm cipher.AEAD, position []byte) ([]byte, error) {
+	nonce := make([]byte, gcm.NonceSize())
+
+	_, err := io.ReadFull(rand.Reader, nonce)
+	if err != nil {
+		return nil, fmt.Errorf("generating cursor nonce failed: %v", err)
+	}
+
+	return gcm.Seal(nonce, nonce, position, nil), nil
+}
+
+// getCipher returns the AES-GCM cipher of the encryption key, or nil when cursors aren't encrypted. The key is hashed
+// so that it can be a passphrase of any length.
+func getCipher() (cipher.AEAD, error) {
+	encryptionKey := env.GetString("PAGINATION_ENCRYPTION_KEY", "")
+	if encryptionKey == "" {
+		return nil, nil
+	}
+
+	key := sha256.Sum256([]byte(encryptionKey))
+
+	block, err := aes.NewCipher(key[:])
+	if err != nil {
+		return nil, fmt.Errorf("creating cursor cipher failed: %v", err)
+	}
+
+	return cipher.NewGCM(block)
+}
+
+func getSecret() ([]byte, error) {
+	secret := env.GetString("PAGINATION_KEY_SECRET", "")
+	if secret != "" {
+		return []byte(secret), nil
+	}
+
+	if ephemeralSecret != nil {
+		return ephemeralSecret, nil
+	}
+
+	return nil, ErrMissingSecret
+}
+
+func getTTL() time.Duration {
+	ttl, err := time.ParseDuration(env.GetString("PAGINATION_KEY_TTL", ""))
+	if err != nil || ttl <= 0 {
+		return defaultTTL
+	}
+
+	return ttl
+}
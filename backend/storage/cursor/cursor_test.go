@@ -0,0 +1,128 @@
+package cursor
+
+import (
+	"github.com/JoelD7/money/backend/models"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestSealAndOpen(t *testing.T) {
+	t.Setenv("PAGINATION_KEY_SECRET", "pagination-secret")
+
+	position := []byte(`{"expense_id":"EX1","period_user":"PRD1:test@gmail.com"}`)
+	scope := NewScope("test@gmail.com", &models.QueryParameters{Period: "PRD1", SortBy: "amount", PageSize: 10})
+
+	t.Run("Signed", func(t *testing.T) {
+		c := require.New(t)
+
+		sealed, err := Seal(position, scope)
+		c.NoError(err)
+
+		opened, err := Open(sealed, scope)
+		c.NoError(err)
+		c.Equal(position, opened)
+
+		// The page size can change from one page to the next.
+		opened, err = Open(sealed, NewScope("test@gmail.com", &models.QueryParameters{Period: "PRD1", SortBy: "amount", PageSize: 5}))
+		c.NoError(err)
+		c.Equal(position, opened)
+	})
+
+	t.Run("Encrypted", func(t *testing.T) {
+		c := require.New(t)
+
+		t.Setenv("PAGINATION_ENCRYPTION_KEY", "encryption-key")
+
+		sealed, err := Seal(position, scope)
+		c.NoError(err)
+
+		decoded, err := encoding.DecodeString(sealed)
+		c.NoError(err)
+		c.NotContains(string(decoded), "PRD1")
+
+		opened, err := Open(sealed, scope)
+		c.NoError(err)
+		c.Equal(position, opened)
+	})
+
+	t.Run("Tampered", func(t *testing.T) {
+		c := require.New(t)
+
+		sealed, err := Seal(position, scope)
+		c.NoError(err)
+
+		token, err := encoding.DecodeString(sealed)
+		c.NoError(err)
+
+		token[headerSize] ^= 1
+
+		_, err = Open(encoding.EncodeToString(token), scope)
+		c.ErrorIs(err, models.ErrInvalidStartKey)
+
+		_, err = Open("%invalid%", scope)
+		c.ErrorIs(err, models.ErrInvalidStartKey)
+
+		_, err = Open(encoding.EncodeToString([]byte("short")), scope)
+		c.ErrorIs(err, models.ErrInvalidStartKey)
+	})
+
+	t.Run("Another scope", func(t *testing.T) {
+		c := require.New(t)
+
+		sealed, err := Seal(position, scope)
+		c.NoError(err)
+
+		_, err = Open(sealed, NewScope("other@gmail.com", &models.QueryParameters{Period: "PRD1", SortBy: "amount"}))
+		c.ErrorIs(err, models.ErrInvalidStartKey)
+
+		_, err = Open(sealed, NewScope("test@gmail.com", &models.QueryParameters{Period: "PRD1", SortBy: "name"}))
+		c.ErrorIs(err, models.ErrInvalidStartKey)
+
+		_, err = Open(sealed, NewScope("test@gmail.com", &models.QueryParameters{Period: "PRD2", SortBy: "amount"}))
+		c.ErrorIs(err, models.ErrInvalidStartKey)
+	})
+
+	t.Run("Another secret", func(t *testing.T) {
+		c := require.New(t)
+
+		sealed, err := Seal(position, scope)
+		c.NoError(err)
+
+		t.Setenv("PAGINATION_KEY_SECRET", "another-secret")
+
+		_, err = Open(sealed, scope)
+		c.ErrorIs(err, models.ErrInvalidStartKey)
+	})
+
+	t.Run("Expired", func(t *testing.T) {
+		c := require.New(t)
+
+		t.Setenv("PAGINATION_KEY_TTL", "1h")
+
+		sealed, err := Seal(position, scope)
+		c.NoError(err)
+
+		defer func() {
+			now = time.Now
+		}()
+
+		now = func() time.Time {
+			return time.Now().Add(2 * time.Hour)
+		}
+
+		_, err = Open(sealed, scope)
+		c.ErrorIs(err, models.ErrInvalidStartKey)
+	})
+}
+
+func TestMissingSecret(t *testing.T) {
+	c := require.New(t)
+
+	t.Setenv("PAGINATION_KEY_SECRET", "")
+
+	c.ErrorIs(CheckSecret(), ErrMissingSecret)
+
+	_, err := Seal([]byte("position"), NewScope("test@gmail.com", nil))
+	c.ErrorIs(err, ErrMissingSecret)
+}
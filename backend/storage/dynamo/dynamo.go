@@ -2,7 +2,6 @@ package dynamo
 
 import (
 	"context"
-	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"math/rand"
@@ -11,6 +10,7 @@ import (
 	"github.com/JoelD7/money/backend/models"
 	"github.com/JoelD7/money/backend/shared/env"
 	"github.com/JoelD7/money/backend/shared/logger"
+	"github.com/JoelD7/money/backend/storage/cursor"
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
@@ -37,9 +37,9 @@ func GenerateID(prefix string) string {
 }
 
 // EncodePaginationKey encodes the last evaluated key returned by Dynamo in a string format to be used in the next query
-// as the start key.
-// The "keyType" parameter should be a pointer to a struct that maps ot the primary key of the table or index in question.
-func EncodePaginationKey(lastKey map[string]types.AttributeValue) (string, error) {
+// as the start key. The key is sealed in a cursor bound to the scope of the query, so that clients can't read the
+// attributes of the index or forge keys of other partitions.
+func EncodePaginationKey(lastKey map[string]types.AttributeValue, scope cursor.Scope) (string, error) {
 	if len(lastKey) == 0 {
 		return "", nil
 	}
@@ -56,30 +56,28 @@ func EncodePaginationKey(lastKey map[string]types.AttributeValue) (string, error
 		return "", fmt.Errorf("json marshalling primary key: %v", err)
 	}
 
-	encoded := base64.URLEncoding.EncodeToString(data)
-
-	return encoded, nil
+	return cursor.Seal(data, scope)
 }
 
 // DecodePaginationKey parses the start key string into a map of attribute values to be used as ExclusiveStartKey in a paginated
-// query.
-// The "keyType" parameter should be a pointer to a struct that maps ot the primary key of the table or index in question.
-func DecodePaginationKey(startKey string) (map[string]types.AttributeValue, error) {
-	decoded, err := base64.URLEncoding.DecodeString(startKey)
+// query. It fails with models.ErrInvalidStartKey if the start key was tampered with, expired or was returned by a query
+// of another scope.
+func DecodePaginationKey(startKey string, scope cursor.Scope) (map[string]types.AttributeValue, error) {
+	decoded, err := cursor.Open(startKey, scope)
 	if err != nil {
-		return nil, fmt.Errorf("decoding last key: %v", err)
+		return nil, err
 	}
 
 	var keyType interface{}
 
 	err = json.Unmarshal(decoded, &keyType)
 	if err != nil {
-		return nil, fmt.Errorf("json unmarshalling primary key: %v", err)
+		return nil, fmt.Errorf("json unmarshalling primary key: %v: %w", err, models.ErrInvalidStartKey)
 	}
 
 	exclusiveStartKey, err := attributevalue.MarshalMap(keyType)
 	if err != nil {
-		return nil, fmt.Errorf("marshalling to map of attribute value: %v", err)
+		return nil, fmt.Errorf("marshalling to map of attribute value: %v: %w", err, models.ErrInvalidStartKey)
 	}
 
 	return exclusiveStartKey, nil
@@ -99,7 +97,10 @@ func BatchWrite(ctx context.Context, dynamoClient *dynamodb.Client, input *dynam
 		}
 
 		if result != nil && len(result.UnprocessedItems) > 0 {
-			return handleBatchWriteRetries(ctx, dynamoClient, result.UnprocessedItems)
+			err = handleBatchWriteRetries(ctx, dynamoClient, result.UnprocessedItems)
+			if err != nil {
+				return err
+			}
 		}
 	}
 
@@ -188,7 +189,17 @@ func handleBatchWriteRetries(ctx context.Context, d *dynamodb.Client, unprocesse
 		delay *= time.Duration(batchWriteBackoffFactor)
 	}
 
-	return nil
+	return fmt.Errorf("batch write failed: %d items remained unprocessed after %d retries", countWriteRequests(unprocessedItems), batchWriteRetries)
+}
+
+func countWriteRequests(requestItems map[string][]types.WriteRequest) int {
+	count := 0
+
+	for _, items := range requestItems {
+		count += len(items)
+	}
+
+	return count
 }
 
 func InitClient(ctx context.Context) *dynamodb.Client {
@@ -214,14 +225,14 @@ func InitClient(ctx context.Context) *dynamodb.Client {
 }
 
 // SetExclusiveStartKey sets the ExclusiveStartKey in a DynamoDB query input based on the provided startKey string.
-func SetExclusiveStartKey(startKey string, input *dynamodb.QueryInput) error {
+func SetExclusiveStartKey(startKey string, scope cursor.Scope, input *dynamodb.QueryInput) error {
 	if startKey == "" {
 		return nil
 	}
 
-	decodedStartKey, err := DecodePaginationKey(startKey)
+	decodedStartKey, err := DecodePaginationKey(startKey, scope)
 	if err != nil {
-		return fmt.Errorf("%v: %w", err, models.ErrInvalidStartKey)
+		return err
 	}
 
 	input.ExclusiveStartKey = decodedStartKey
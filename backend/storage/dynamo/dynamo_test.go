@@ -2,7 +2,9 @@ package dynamo
 
 import (
 	"fmt"
+	"github.com/JoelD7/money/backend/models"
 	"github.com/JoelD7/money/backend/shared/env"
+	"github.com/JoelD7/money/backend/storage/cursor"
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -45,39 +47,45 @@ func TestSplitRequestItems(t *testing.T) {
 func TestBuildAmountKey(t *testing.T) {
 	cases := []struct {
 		name        string
-		amount      float64
+		amount      models.Money
 		id          string
 		expectedKey string
 	}{
 		{
 			name:        "Positive amount",
-			amount:      1234.56,
+			amount:      models.NewMoney(1234.56),
 			id:          "abc123",
-			expectedKey: "01234.560000:abc123",
+			expectedKey: "000001234.56:abc123",
 		},
 		{
 			name:        "Negative amount",
-			amount:      -987.65,
+			amount:      models.Money(-98765),
 			id:          "xyz789",
-			expectedKey: "-987.650000:xyz789",
+			expectedKey: "00000-987.65:xyz789",
 		},
 		{
 			name:        "Zero amount",
 			amount:      0,
 			id:          "zero123",
-			expectedKey: "00000.000000:zero123",
+			expectedKey: "000000000.00:zero123",
 		},
 		{
 			name:        "Large amount",
-			amount:      99999999.99,
+			amount:      models.NewMoney(99999999.99),
 			id:          "big123",
-			expectedKey: "99999999.990000:big123",
+			expectedKey: "099999999.99:big123",
 		},
 		{
 			name:        "Small fractional amount",
-			amount:      0.000001,
+			amount:      models.Money(1),
 			id:          "tiny123",
-			expectedKey: "00000.000001:tiny123",
+			expectedKey: "000000000.01:tiny123",
+		},
+		{
+			name:        "Amount that isn't exact as a float",
+			amount:      models.NewMoney(0.1) + models.NewMoney(0.2),
+			id:          "float123",
+			expectedKey: "000000000.30:float123",
 		},
 	}
 
@@ -104,3 +112,32 @@ func buildSampleRequestItems(length int) []types.WriteRequest {
 
 	return requestItems
 }
+
+func TestPaginationKey(t *testing.T) {
+	t.Setenv("PAGINATION_KEY_SECRET", "pagination-secret")
+
+	c := require.New(t)
+
+	lastKey := map[string]types.AttributeValue{
+		"expense_id":  &types.AttributeValueMemberS{Value: "EX1"},
+		"period_user": &types.AttributeValueMemberS{Value: "PRD1:test@gmail.com"},
+	}
+	scope := cursor.NewScope("test@gmail.com", &models.QueryParameters{Period: "PRD1"})
+
+	startKey, err := EncodePaginationKey(lastKey, scope)
+	c.NoError(err)
+
+	decoded, err := DecodePaginationKey(startKey, scope)
+	c.NoError(err)
+	c.Equal(lastKey, decoded)
+
+	_, err = DecodePaginationKey(startKey, cursor.NewScope("other@gmail.com", &models.QueryParameters{Period: "PRD1"}))
+	c.ErrorIs(err, models.ErrInvalidStartKey)
+
+	_, err = DecodePaginationKey(startKey, cursor.NewScope("test@gmail.com", &models.QueryParameters{Period: "PRD2"}))
+	c.ErrorIs(err, models.ErrInvalidStartKey)
+
+	emptyKey, err := EncodePaginationKey(nil, scope)
+	c.NoError(err)
+	c.Empty(emptyKey)
+}
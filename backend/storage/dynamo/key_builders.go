@@ -2,6 +2,7 @@ package dynamo
 
 import (
 	"fmt"
+	"github.com/JoelD7/money/backend/models"
 	"strings"
 	"time"
 )
@@ -12,11 +13,11 @@ func BuildPeriodUser(username, period string) *string {
 	return &p
 }
 
-// BuildAmountKey builds the amount sort key, which is a combined string of the amount and the item ID.
+// BuildAmountKey builds the amount sort key, which is a combined string of the amount and the item ID. The amount is
+// built from its cents, so the key is exact and keeps the same format of the keys built from float amounts.
 // Example -> Input: 1234.56, abc123 -> Output: 000001234.56:abc123
-func BuildAmountKey(amount float64, id string) string {
-	amountStr := fmt.Sprintf("%0.2f", amount)
-	return fmt.Sprintf("%012s:%s", amountStr, id)
+func BuildAmountKey(amount models.Money, id string) string {
+	return fmt.Sprintf("%012s:%s", amount.String(), id)
 }
 
 // BuildNameKey builds the name sort key, which is a combined string of the name and the item ID.
@@ -31,6 +32,18 @@ func BuildCreatedDateEntityIDKey(createdDate time.Time, id string) string {
 	return fmt.Sprintf("%s:%s", createdDate.Format(time.RFC3339Nano), id)
 }
 
+// BuildPeriodCurrencyKey builds the sort key of an exchange rate, which is a combined string of the period and the
+// currency code.
+func BuildPeriodCurrencyKey(period, currency string) string {
+	return fmt.Sprintf("%s:%s", period, currency)
+}
+
+// BuildBudgetAlertKey builds the sort key of a budget alert, which is a combined string of the period, the category ID
+// and the threshold. There's at most one alert for each combination.
+func BuildBudgetAlertKey(period, categoryID string, threshold int) string {
+	return fmt.Sprintf("%s:%s:%d", period, categoryID, threshold)
+}
+
 func BuildEndDatePeriodKey(period string, endDate time.Time) string {
 	return fmt.Sprintf("%s:%s", endDate.Format(time.RFC3339), period)
 }
@@ -0,0 +1,28 @@
+package dynamo
+
+import (
+	"errors"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/expression"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// VersionAttributeName is the attribute of the items that are updated with optimistic concurrency control.
+const VersionAttributeName = "version"
+
+// VersionCondition returns the condition that an item is at version. Items stored before they were versioned don't
+// have the attribute, so they are at version 0.
+func VersionCondition(version int64) expression.ConditionBuilder {
+	if version == 0 {
+		return expression.Name(VersionAttributeName).AttributeNotExists()
+	}
+
+	return expression.Name(VersionAttributeName).Equal(expression.Value(version))
+}
+
+// IsVersionConflict tells if a conditional write failed because the item exists, but at another version. The write
+// must return the old item when its condition fails, as that's how a conflict is told apart from a missing item.
+func IsVersionConflict(err error) bool {
+	var conditionErr *types.ConditionalCheckFailedException
+
+	return errors.As(err, &conditionErr) && len(conditionErr.Item) > 0
+}
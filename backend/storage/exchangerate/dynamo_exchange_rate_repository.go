@@ -0,0 +1,154 @@
+package exchangerate
+
+import (
+	"context"
+	"fmt"
+	"github.com/JoelD7/money/backend/models"
+	"github.com/JoelD7/money/backend/storage/dynamo"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/expression"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"strings"
+)
+
+const (
+	conditionalFailedKeyword = "ConditionalCheckFailed"
+)
+
+type DynamoRepository struct {
+	dynamoClient *dynamodb.Client
+	tableName    string
+}
+
+func NewDynamoRepository(dynamoClient *dynamodb.Client, envConfig *models.EnvironmentConfiguration) (*DynamoRepository, error) {
+	d := &DynamoRepository{dynamoClient: dynamoClient}
+
+	if envConfig == nil || envConfig.ExchangeRatesTable == "" {
+		return nil, fmt.Errorf("initialize exchange rates dynamo repository failed: table name is required")
+	}
+
+	d.tableName = envConfig.ExchangeRatesTable
+
+	return d, nil
+}
+
+// SaveExchangeRate creates the exchange rate of a currency for a period, or replaces it if it already exists.
+func (d *DynamoRepository) SaveExchangeRate(ctx context.Context, exchangeRate *models.ExchangeRate) error {
+	item, err := attributevalue.MarshalMap(toExchangeRateEntity(exchangeRate))
+	if err != nil {
+		return fmt.Errorf("marshal exchange rate item failed: %v", err)
+	}
+
+	input := &dynamodb.PutItemInput{
+		TableName: aws.String(d.tableName),
+		Item:      item,
+	}
+
+	_, err = d.dynamoClient.PutItem(ctx, input)
+	if err != nil {
+		return fmt.Errorf("put exchange rate item failed: %v", err)
+	}
+
+	return nil
+}
+
+func (d *DynamoRepository) GetExchangeRate(ctx context.Context, username, periodID, currency string) (*models.ExchangeRate, error) {
+	input := &dynamodb.GetItemInput{
+		TableName: aws.String(d.tableName),
+		Key: map[string]types.AttributeValue{
+			"username":        &types.AttributeValueMemberS{Value: username},
+			"period_currency": &types.AttributeValueMemberS{Value: dynamo.BuildPeriodCurrencyKey(periodID, currency)},
+		},
+	}
+
+	result, err := d.dynamoClient.GetItem(ctx, input)
+	if err != nil {
+		return nil, fmt.Errorf("get exchange rate item failed: %v", err)
+	}
+
+	if result.Item == nil {
+		return nil, models.ErrExchangeRateNotFound
+	}
+
+	entity := new(exchangeRateEntity)
+
+	err = attributevalue.UnmarshalMap(result.Item, entity)
+	if err != nil {
+		return nil, fmt.Errorf("unmarshal exchange rate item failed: %v", err)
+	}
+
+	return toExchangeRateModel(entity), nil
+}
+
+// GetExchangeRates returns all the exchange rates of a period. A period has at most one rate per currency, so all the
+// pages of the query are retrieved at once.
+func (d *DynamoRepository) GetExchangeRates(ctx context.Context, username, periodID string) ([]*models.ExchangeRate, error) {
+	keyCondition := expression.Key("username").Equal(expression.Value(username)).
+		And(expression.Key("period_currency").BeginsWith(dynamo.BuildPeriodCurrencyKey(periodID, "")))
+
+	expr, err := expression.NewBuilder().WithKeyCondition(keyCondition).Build()
+	if err != nil {
+		return nil, fmt.Errorf("build expression failed: %v", err)
+	}
+
+	input := &dynamodb.QueryInput{
+		TableName:                 aws.String(d.tableName),
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		KeyConditionExpression:    expr.KeyCondition(),
+	}
+
+	entities := make([]*exchangeRateEntity, 0)
+
+	for {
+		result, err := d.dynamoClient.Query(ctx, input)
+		if err != nil {
+			return nil, fmt.Errorf("query exchange rates failed: %v", err)
+		}
+
+		pageEntities := make([]*exchangeRateEntity, 0, len(result.Items))
+
+		err = attributevalue.UnmarshalListOfMaps(result.Items, &pageEntities)
+		if err != nil {
+			return nil, fmt.Errorf("unmarshal exchange rates items failed: %v", err)
+		}
+
+		entities = append(entities, pageEntities...)
+
+		if result.LastEvaluatedKey == nil {
+			break
+		}
+
+		input.ExclusiveStartKey = result.LastEvaluatedKey
+	}
+
+	if len(entities) == 0 {
+		return nil, models.ErrExchangeRatesNotFound
+	}
+
+	return toExchangeRateModels(entities), nil
+}
+
+func (d *DynamoRepository) DeleteExchangeRate(ctx context.Context, username, periodID, currency string) error {
+	input := &dynamodb.DeleteItemInput{
+		TableName: aws.String(d.tableName),
+		Key: map[string]types.AttributeValue{
+			"username":        &types.AttributeValueMemberS{Value: username},
+			"period_currency": &types.AttributeValueMemberS{Value: dynamo.BuildPeriodCurrencyKey(periodID, currency)},
+		},
+		ConditionExpression: aws.String("attribute_exists(period_currency)"),
+	}
+
+	_, err := d.dynamoClient.DeleteItem(ctx, input)
+	if err != nil && strings.Contains(err.Error(), conditionalFailedKeyword) {
+		return fmt.Errorf("%v: %w", err, models.ErrExchangeRateNotFound)
+	}
+
+	if err != nil {
+		return fmt.Errorf("delete exchange rate item failed: %v", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,52 @@
+package exchangerate
+
+import (
+	"github.com/JoelD7/money/backend/models"
+	"github.com/JoelD7/money/backend/storage/dynamo"
+	"time"
+)
+
+type exchangeRateEntity struct {
+	Username string `json:"username" dynamodbav:"username"`
+	// PeriodCurrency is the sort key of the table. It's composed of the period id plus the currency code, so that the
+	// rates of a period can be queried together.
+	PeriodCurrency string    `json:"period_currency" dynamodbav:"period_currency"`
+	PeriodID       string    `json:"period_id" dynamodbav:"period_id"`
+	Currency       string    `json:"currency" dynamodbav:"currency"`
+	Rate           float64   `json:"rate" dynamodbav:"rate"`
+	CreatedDate    time.Time `json:"created_date,omitempty" dynamodbav:"created_date"`
+	UpdatedDate    time.Time `json:"updated_date,omitempty" dynamodbav:"updated_date"`
+}
+
+func toExchangeRateEntity(e *models.ExchangeRate) *exchangeRateEntity {
+	return &exchangeRateEntity{
+		Username:       e.Username,
+		PeriodCurrency: dynamo.BuildPeriodCurrencyKey(e.PeriodID, e.Currency),
+		PeriodID:       e.PeriodID,
+		Currency:       e.Currency,
+		Rate:           e.Rate,
+		CreatedDate:    e.CreatedDate,
+		UpdatedDate:    e.UpdatedDate,
+	}
+}
+
+func toExchangeRateModel(e *exchangeRateEntity) *models.ExchangeRate {
+	return &models.ExchangeRate{
+		Username:    e.Username,
+		PeriodID:    e.PeriodID,
+		Currency:    e.Currency,
+		Rate:        e.Rate,
+		CreatedDate: e.CreatedDate,
+		UpdatedDate: e.UpdatedDate,
+	}
+}
+
+func toExchangeRateModels(entities []*exchangeRateEntity) []*models.ExchangeRate {
+	exchangeRates := make([]*models.ExchangeRate, 0, len(entities))
+
+	for _, entity := range entities {
+		exchangeRates = append(exchangeRates, toExchangeRateModel(entity))
+	}
+
+	return exchangeRates
+}
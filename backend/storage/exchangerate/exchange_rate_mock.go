@@ -0,0 +1,80 @@
+package exchangerate
+
+import (
+	"context"
+	"github.com/JoelD7/money/backend/models"
+)
+
+type Mock struct {
+	mockedErr           error
+	mockedExchangeRates []*models.ExchangeRate
+}
+
+func NewMock() *Mock {
+	return &Mock{
+		mockedExchangeRates: make([]*models.ExchangeRate, 0),
+	}
+}
+
+func (m *Mock) ActivateForceFailure(err error) {
+	m.mockedErr = err
+}
+
+func (m *Mock) DeactivateForceFailure() {
+	m.mockedErr = nil
+}
+
+// SetMockedExchangeRates sets the exchange rates returned by the mock.
+func (m *Mock) SetMockedExchangeRates(exchangeRates ...*models.ExchangeRate) {
+	m.mockedExchangeRates = exchangeRates
+}
+
+func (m *Mock) SaveExchangeRate(ctx context.Context, exchangeRate *models.ExchangeRate) error {
+	if m.mockedErr != nil {
+		return m.mockedErr
+	}
+
+	return nil
+}
+
+func (m *Mock) GetExchangeRate(ctx context.Context, username, periodID, currency string) (*models.ExchangeRate, error) {
+	if m.mockedErr != nil {
+		return nil, m.mockedErr
+	}
+
+	for _, exchangeRate := range m.mockedExchangeRates {
+		if exchangeRate.PeriodID == periodID && exchangeRate.Currency == currency {
+			return exchangeRate, nil
+		}
+	}
+
+	return nil, models.ErrExchangeRateNotFound
+}
+
+func (m *Mock) GetExchangeRates(ctx context.Context, username, periodID string) ([]*models.ExchangeRate, error) {
+	if m.mockedErr != nil {
+		return nil, m.mockedErr
+	}
+
+	exchangeRates := make([]*models.ExchangeRate, 0)
+
+	for _, exchangeRate := range m.mockedExchangeRates {
+		if exchangeRate.PeriodID == periodID {
+			exchangeRates = append(exchangeRates, exchangeRate)
+		}
+	}
+
+	if len(exchangeRates) == 0 {
+		return nil, models.ErrExchangeRatesNotFound
+	}
+
+	return exchangeRates, nil
+}
+
+func (m *Mock) DeleteExchangeRate(ctx context.Context, username, periodID, currency string) error {
+	if m.mockedErr != nil {
+		return m.mockedErr
+	}
+
+	return nil
+}
@@ -0,0 +1,34 @@
+package exchangerate
+
+import (
+	"context"
+	"github.com/JoelD7/money/backend/models"
+	"github.com/JoelD7/money/backend/storage/sqldb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+)
+
+type Repository interface {
+	SaveExchangeRate(ctx context.Context, exchangeRate *models.ExchangeRate) error
+	GetExchangeRate(ctx context.Context, username, periodID, currency string) (*models.ExchangeRate, error)
+	GetExchangeRates(ctx context.Context, username, periodID string) ([]*models.ExchangeRate, error)
+	DeleteExchangeRate(ctx context.Context, username, periodID, currency string) error
+}
+
+// NewRepository returns the repository of the storage backend set in the configuration.
+func NewRepository(ctx context.Context, dynamoClient *dynamodb.Client, envConfig *models.EnvironmentConfiguration) (Repository, error) {
+	if envConfig.StorageBackend != models.StorageBackendSQL {
+		repo, err := NewDynamoRepository(dynamoClient, envConfig)
+		if err != nil {
+			return nil, err
+		}
+
+		return repo, nil
+	}
+
+	db, err := sqldb.Connect(ctx, envConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewSQLRepository(db), nil
+}
@@ -0,0 +1,124 @@
+package exchangerate
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/JoelD7/money/backend/models"
+	"github.com/JoelD7/money/backend/storage/sqldb"
+)
+
+const exchangeRateColumns = `username, period_id, currency, rate, created_date, updated_date`
+
+type SQLRepository struct {
+	db *sqldb.DB
+}
+
+func NewSQLRepository(db *sqldb.DB) *SQLRepository {
+	return &SQLRepository{db: db}
+}
+
+func (s *SQLRepository) SaveExchangeRate(ctx context.Context, exchangeRate *models.ExchangeRate) error {
+	entity := toExchangeRateEntity(exchangeRate)
+
+	_, err := s.db.ExecContext(ctx, `INSERT INTO exchange_rates (`+exchangeRateColumns+`) VALUES (?, ?, ?, ?, ?, ?)
+		ON CONFLICT (username, period_id, currency) DO UPDATE SET rate = excluded.rate,
+		created_date = excluded.created_date, updated_date = excluded.updated_date`,
+		entity.Username, entity.PeriodID, entity.Currency, entity.Rate, sqldb.FormatTime(entity.CreatedDate),
+		sqldb.FormatTime(entity.UpdatedDate))
+	if err != nil {
+		return fmt.Errorf("save exchange rate failed: %v", err)
+	}
+
+	return nil
+}
+
+func (s *SQLRepository) GetExchangeRate(ctx context.Context, username, periodID, currency string) (*models.ExchangeRate, error) {
+	row := s.db.QueryRowContext(ctx, `SELECT `+exchangeRateColumns+` FROM exchange_rates
+		WHERE username = ? AND period_id = ? AND currency = ?`, username, periodID, currency)
+
+	entity, err := scanExchangeRate(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, models.ErrExchangeRateNotFound
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return toExchangeRateModel(entity), nil
+}
+
+func (s *SQLRepository) GetExchangeRates(ctx context.Context, username, periodID string) ([]*models.ExchangeRate, error) {
+	rows, err := s.db.QueryContext(ctx, `SELECT `+exchangeRateColumns+` FROM exchange_rates
+		WHERE username = ? AND period_id = ? ORDER BY currency`, username, periodID)
+	if err != nil {
+		return nil, fmt.Errorf("query exchange rates failed: %v", err)
+	}
+
+	defer rows.Close()
+
+	entities := make([]*exchangeRateEntity, 0)
+
+	for rows.Next() {
+		entity, err := scanExchangeRate(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		entities = append(entities, entity)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(entities) == 0 {
+		return nil, models.ErrExchangeRatesNotFound
+	}
+
+	return toExchangeRateModels(entities), nil
+}
+
+func (s *SQLRepository) DeleteExchangeRate(ctx context.Context, username, periodID, currency string) error {
+	result, err := s.db.ExecContext(ctx, `DELETE FROM exchange_rates WHERE username = ? AND period_id = ? AND currency = ?`,
+		username, periodID, currency)
+	if err != nil {
+		return fmt.Errorf("delete exchange rate failed: %v", err)
+	}
+
+	affected, err := sqldb.RowsAffected(result)
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return models.ErrExchangeRateNotFound
+	}
+
+	return nil
+}
+
+func scanExchangeRate(row sqldb.Scanner) (*exchangeRateEntity, error) {
+	entity := new(exchangeRateEntity)
+
+	var createdDate, updatedDate string
+
+	err := row.Scan(&entity.Username, &entity.PeriodID, &entity.Currency, &entity.Rate, &createdDate, &updatedDate)
+	if err != nil {
+		return nil, err
+	}
+
+	entity.CreatedDate, err = sqldb.ParseTime(createdDate)
+	if err != nil {
+		return nil, err
+	}
+
+	entity.UpdatedDate, err = sqldb.ParseTime(updatedDate)
+	if err != nil {
+		return nil, err
+	}
+
+	return entity, nil
+}
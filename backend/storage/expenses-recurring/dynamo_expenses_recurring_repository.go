@@ -6,12 +6,19 @@ import (
 	"github.com/JoelD7/money/backend/models"
 	"github.com/JoelD7/money/backend/shared/env"
 	"github.com/JoelD7/money/backend/shared/logger"
+	"github.com/JoelD7/money/backend/storage/cursor"
 	"github.com/JoelD7/money/backend/storage/dynamo"
 	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
 	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/expression"
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
 	"github.com/aws/aws-sdk-go/aws"
+	"strings"
+)
+
+const (
+	defaultPageSize          = 10
+	conditionalFailedKeyword = "ConditionalCheckFailed"
 )
 
 type DynamoRepository struct {
@@ -43,12 +50,25 @@ func (d *DynamoRepository) CreateExpenseRecurring(ctx context.Context, expenseRe
 		return nil, fmt.Errorf("marshal expense recurring entity failed: %v", err)
 	}
 
+	condExpr := expression.Name("id").AttributeNotExists().And(expression.Name("username").AttributeNotExists())
+
+	expr, err := expression.NewBuilder().WithCondition(condExpr).Build()
+	if err != nil {
+		return nil, fmt.Errorf("build expression failed: %v", err)
+	}
+
 	input := &dynamodb.PutItemInput{
-		Item:      item,
-		TableName: aws.String(d.tableName),
+		Item:                     item,
+		TableName:                aws.String(d.tableName),
+		ConditionExpression:      expr.Condition(),
+		ExpressionAttributeNames: expr.Names(),
 	}
 
 	_, err = d.dynamoClient.PutItem(ctx, input)
+	if err != nil && strings.Contains(err.Error(), conditionalFailedKeyword) {
+		return nil, fmt.Errorf("%v: %w", err, models.ErrRecurringExpenseNameTaken)
+	}
+
 	if err != nil {
 		return nil, fmt.Errorf("put expense recurring item failed: %v", err)
 	}
@@ -120,6 +140,83 @@ func (d *DynamoRepository) GetExpenseRecurring(ctx context.Context, expenseRecur
 	return toExpenseRecurringModel(*entity), nil
 }
 
+func (d *DynamoRepository) GetExpensesRecurring(ctx context.Context, username string, params *models.QueryParameters) ([]*models.ExpenseRecurring, string, error) {
+	keyCondition := expression.Key("username").Equal(expression.Value(username))
+
+	expr, err := expression.NewBuilder().WithKeyCondition(keyCondition).Build()
+	if err != nil {
+		return nil, "", fmt.Errorf("build expression failed: %v", err)
+	}
+
+	input := &dynamodb.QueryInput{
+		TableName:                 aws.String(d.tableName),
+		Limit:                     getPageSize(params.PageSize),
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		KeyConditionExpression:    expr.KeyCondition(),
+	}
+
+	scope := cursor.NewScope(username, params)
+
+	err = dynamo.SetExclusiveStartKey(params.StartKey, scope, input)
+	if err != nil {
+		return nil, "", err
+	}
+
+	result, err := d.dynamoClient.Query(ctx, input)
+	if err != nil {
+		return nil, "", fmt.Errorf("query expenses recurring failed: %v", err)
+	}
+
+	if len(result.Items) == 0 && params.StartKey == "" {
+		return nil, "", models.ErrRecurringExpensesNotFound
+	}
+
+	if len(result.Items) == 0 {
+		return nil, "", models.ErrNoMoreItemsToBeRetrieved
+	}
+
+	entities := make([]*ExpenseRecurringEntity, 0, len(result.Items))
+
+	err = attributevalue.UnmarshalListOfMaps(result.Items, &entities)
+	if err != nil {
+		return nil, "", fmt.Errorf("unmarshal recurring expenses items failed: %v", err)
+	}
+
+	nextKey, err := dynamo.EncodePaginationKey(result.LastEvaluatedKey, scope)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return toExpensesRecurringModel(entities), nextKey, nil
+}
+
+func (d *DynamoRepository) UpdateExpenseRecurring(ctx context.Context, expenseRecurring *models.ExpenseRecurring) error {
+	entity := toExpenseRecurringEntity(expenseRecurring)
+
+	item, err := attributevalue.MarshalMap(entity)
+	if err != nil {
+		return fmt.Errorf("marshal expense recurring entity failed: %v", err)
+	}
+
+	input := &dynamodb.PutItemInput{
+		Item:                item,
+		TableName:           aws.String(d.tableName),
+		ConditionExpression: aws.String("attribute_exists(id)"),
+	}
+
+	_, err = d.dynamoClient.PutItem(ctx, input)
+	if err != nil && strings.Contains(err.Error(), conditionalFailedKeyword) {
+		return fmt.Errorf("%v: %w", err, models.ErrRecurringExpenseNotFound)
+	}
+
+	if err != nil {
+		return fmt.Errorf("update expense recurring item failed: %v", err)
+	}
+
+	return nil
+}
+
 func (d *DynamoRepository) ScanExpensesForDay(ctx context.Context, day int) ([]*models.ExpenseRecurring, error) {
 	filter := expression.Name("recurring_day").Equal(expression.Value(day))
 	expr, err := expression.NewBuilder().WithFilter(filter).Build()
@@ -223,3 +320,11 @@ func (d *DynamoRepository) DeleteExpenseRecurring(ctx context.Context, expenseRe
 
 	return nil
 }
+
+func getPageSize(pageSize int) *int32 {
+	if pageSize == 0 {
+		return aws.Int32(defaultPageSize)
+	}
+
+	return aws.Int32(int32(pageSize))
+}
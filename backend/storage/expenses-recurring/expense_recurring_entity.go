@@ -6,15 +6,17 @@ import (
 )
 
 type ExpenseRecurringEntity struct {
-	ID           string    `json:"id" dynamodbav:"id"`
-	Username     string    `json:"username,omitempty" dynamodbav:"username"`
-	CategoryID   *string   `json:"category_id,omitempty" dynamodbav:"category_id"`
-	Amount       float64   `json:"amount" dynamodbav:"amount"`
-	Name         string    `json:"name,omitempty" dynamodbav:"name"`
-	RecurringDay int       `json:"recurring_day,omitempty" dynamodbav:"recurring_day"`
-	Notes        string    `json:"notes,omitempty" dynamodbav:"notes"`
-	CreatedDate  time.Time `json:"created_date,omitempty" dynamodbav:"created_date"`
-	UpdateDate   time.Time `json:"update_date,omitempty" dynamodbav:"update_date"`
+	ID           string       `json:"id" dynamodbav:"id"`
+	Username     string       `json:"username,omitempty" dynamodbav:"username"`
+	CategoryID   *string      `json:"category_id,omitempty" dynamodbav:"category_id"`
+	Amount       models.Money `json:"amount" dynamodbav:"amount"`
+	Currency     string       `json:"currency,omitempty" dynamodbav:"currency,omitempty"`
+	Name         string       `json:"name,omitempty" dynamodbav:"name"`
+	RecurringDay int          `json:"recurring_day,omitempty" dynamodbav:"recurring_day"`
+	Notes        string       `json:"notes,omitempty" dynamodbav:"notes"`
+	Paused       bool         `json:"paused" dynamodbav:"paused"`
+	CreatedDate  time.Time    `json:"created_date,omitempty" dynamodbav:"created_date"`
+	UpdateDate   time.Time    `json:"update_date,omitempty" dynamodbav:"update_date"`
 }
 
 func toExpenseRecurringEntity(e *models.ExpenseRecurring) *ExpenseRecurringEntity {
@@ -23,9 +25,11 @@ func toExpenseRecurringEntity(e *models.ExpenseRecurring) *ExpenseRecurringEntit
 		Username:     e.Username,
 		CategoryID:   e.CategoryID,
 		Amount:       e.Amount,
+		Currency:     e.Currency,
 		Name:         e.Name,
 		RecurringDay: e.RecurringDay,
 		Notes:        e.Notes,
+		Paused:       e.Paused,
 		CreatedDate:  e.CreatedDate,
 		UpdateDate:   e.UpdateDate,
 	}
@@ -37,9 +41,11 @@ func toExpenseRecurringModel(e ExpenseRecurringEntity) *models.ExpenseRecurring
 		Username:     e.Username,
 		CategoryID:   e.CategoryID,
 		Amount:       e.Amount,
+		Currency:     e.Currency,
 		Name:         e.Name,
 		RecurringDay: e.RecurringDay,
 		Notes:        e.Notes,
+		Paused:       e.Paused,
 		CreatedDate:  e.CreatedDate,
 		UpdateDate:   e.UpdateDate,
 	}
@@ -70,6 +76,7 @@ func (e *ExpenseRecurringEntity) Value() map[string]interface{} {
 		"recurring_day": e.RecurringDay,
 		"name":          e.Name,
 		"notes":         e.Notes,
+		"paused":        e.Paused,
 		"created_date":  e.CreatedDate,
 		"update_date":   e.UpdateDate,
 	}
@@ -3,6 +3,8 @@ package expenses_recurring
 import (
 	"context"
 	"github.com/JoelD7/money/backend/models"
+	"github.com/JoelD7/money/backend/storage/sqldb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
 )
 
 type Repository interface {
@@ -11,7 +13,29 @@ type Repository interface {
 
 	ScanExpensesForDay(ctx context.Context, day int) ([]*models.ExpenseRecurring, error)
 	GetExpenseRecurring(ctx context.Context, expenseRecurringID, username string) (*models.ExpenseRecurring, error)
+	GetExpensesRecurring(ctx context.Context, username string, params *models.QueryParameters) ([]*models.ExpenseRecurring, string, error)
+
+	UpdateExpenseRecurring(ctx context.Context, expenseRecurring *models.ExpenseRecurring) error
 
 	BatchDeleteExpenseRecurring(ctx context.Context, expenseRecurring []*models.ExpenseRecurring) error
 	DeleteExpenseRecurring(ctx context.Context, expenseRecurringID, username string) error
 }
+
+// NewRepository returns the repository of the storage backend set in the configuration.
+func NewRepository(ctx context.Context, dynamoClient *dynamodb.Client, envConfig *models.EnvironmentConfiguration) (Repository, error) {
+	if envConfig.StorageBackend != models.StorageBackendSQL {
+		repo, err := NewExpenseRecurringDynamoRepository(dynamoClient, envConfig.ExpensesRecurringTable)
+		if err != nil {
+			return nil, err
+		}
+
+		return repo, nil
+	}
+
+	db, err := sqldb.Connect(ctx, envConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewSQLRepository(db), nil
+}
@@ -0,0 +1,128 @@
+package expenses_recurring
+
+import (
+	"context"
+	"github.com/JoelD7/money/backend/models"
+	"github.com/JoelD7/money/backend/storage/sqldb"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+// repositoryConstructors are the implementations of Repository that run without external services.
+var repositoryConstructors = []struct {
+	name    string
+	newRepo func(c *require.Assertions) Repository
+}{
+	{"Memory", func(c *require.Assertions) Repository { return NewMemoryRepository() }},
+	{"SQL", func(c *require.Assertions) Repository { return NewSQLRepository(newTestDB(c)) }},
+}
+
+func TestRepositoryPagination(t *testing.T) {
+	t.Setenv("PAGINATION_KEY_SECRET", "pagination-secret")
+
+	for _, constructor := range repositoryConstructors {
+		t.Run(constructor.name, func(t *testing.T) {
+			c := require.New(t)
+
+			ctx := context.Background()
+			repo := constructor.newRepo(c)
+
+			c.NoError(repo.BatchCreateExpenseRecurring(ctx, []*models.ExpenseRecurring{
+				newTestExpenseRecurring("gym", "test"),
+				newTestExpenseRecurring("rent", "test"),
+				newTestExpenseRecurring("internet", "test"),
+				newTestExpenseRecurring("car", "other"),
+			}))
+
+			params := &models.QueryParameters{PageSize: 2}
+
+			expensesRecurring, nextKey, err := repo.GetExpensesRecurring(ctx, "test", params)
+			c.NoError(err)
+			c.NotEmpty(nextKey)
+			c.Equal([]string{"gym", "internet"}, getExpenseRecurringIDs(expensesRecurring))
+
+			// The cursor is bound to the user it was issued for.
+			_, _, err = repo.GetExpensesRecurring(ctx, "other", &models.QueryParameters{PageSize: 2, StartKey: nextKey})
+			c.ErrorIs(err, models.ErrInvalidStartKey)
+
+			params.StartKey = nextKey
+
+			expensesRecurring, nextKey, err = repo.GetExpensesRecurring(ctx, "test", params)
+			c.NoError(err)
+			c.Empty(nextKey)
+			c.Equal([]string{"rent"}, getExpenseRecurringIDs(expensesRecurring))
+
+			_, _, err = repo.GetExpensesRecurring(ctx, "unknown", &models.QueryParameters{})
+			c.ErrorIs(err, models.ErrRecurringExpensesNotFound)
+
+			_, _, err = repo.GetExpensesRecurring(ctx, "test", &models.QueryParameters{StartKey: "%invalid%"})
+			c.ErrorIs(err, models.ErrInvalidStartKey)
+		})
+	}
+}
+
+func TestRepositoryUpdate(t *testing.T) {
+	for _, constructor := range repositoryConstructors {
+		t.Run(constructor.name, func(t *testing.T) {
+			c := require.New(t)
+
+			ctx := context.Background()
+			repo := constructor.newRepo(c)
+
+			_, err := repo.CreateExpenseRecurring(ctx, newTestExpenseRecurring("rent", "test"))
+			c.NoError(err)
+
+			updated := newTestExpenseRecurring("rent", "test")
+			updated.Amount = 2500
+			updated.Paused = true
+
+			c.NoError(repo.UpdateExpenseRecurring(ctx, updated))
+
+			expenseRecurring, err := repo.GetExpenseRecurring(ctx, "rent", "test")
+			c.NoError(err)
+			c.Equal(models.Money(2500), expenseRecurring.Amount)
+			c.True(expenseRecurring.Paused)
+
+			c.ErrorIs(repo.UpdateExpenseRecurring(ctx, newTestExpenseRecurring("gym", "test")),
+				models.ErrRecurringExpenseNotFound)
+
+			// A user can't update the recurring expense of another user, even if it has the same ID.
+			c.ErrorIs(repo.UpdateExpenseRecurring(ctx, newTestExpenseRecurring("rent", "other")),
+				models.ErrRecurringExpenseNotFound)
+
+			_, err = repo.GetExpenseRecurring(ctx, "rent", "other")
+			c.ErrorIs(err, models.ErrRecurringExpenseNotFound)
+		})
+	}
+}
+
+func newTestExpenseRecurring(id, username string) *models.ExpenseRecurring {
+	return &models.ExpenseRecurring{
+		ID:           id,
+		Username:     username,
+		Name:         id,
+		Amount:       1000,
+		RecurringDay: 15,
+		CreatedDate:  time.Now(),
+	}
+}
+
+func getExpenseRecurringIDs(expensesRecurring []*models.ExpenseRecurring) []string {
+	ids := make([]string, 0, len(expensesRecurring))
+
+	for _, expenseRecurring := range expensesRecurring {
+		ids = append(ids, expenseRecurring.ID)
+	}
+
+	return ids
+}
+
+func newTestDB(c *require.Assertions) *sqldb.DB {
+	db, err := sqldb.Open(sqldb.DriverSQLite, ":memory:")
+	c.NoError(err)
+
+	c.NoError(db.Migrate(context.Background()))
+
+	return db
+}
@@ -0,0 +1,168 @@
+package expenses_recurring
+
+import (
+	"context"
+	"github.com/JoelD7/money/backend/models"
+	"github.com/JoelD7/money/backend/storage/cursor"
+	"github.com/JoelD7/money/backend/storage/memory"
+	"sync"
+)
+
+// MemoryRepository is a thread-safe implementation of Repository that keeps the recurring expenses in memory.
+type MemoryRepository struct {
+	mu sync.RWMutex
+	// expensesRecurring holds the recurring expenses by username and ID.
+	expensesRecurring map[string]map[string]*ExpenseRecurringEntity
+}
+
+func NewMemoryRepository() *MemoryRepository {
+	return &MemoryRepository{
+		expensesRecurring: make(map[string]map[string]*ExpenseRecurringEntity),
+	}
+}
+
+func (m *MemoryRepository) CreateExpenseRecurring(ctx context.Context, expenseRecurring *models.ExpenseRecurring) (*models.ExpenseRecurring, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.expensesRecurring[expenseRecurring.Username][expenseRecurring.ID]; ok {
+		return nil, models.ErrRecurringExpenseNameTaken
+	}
+
+	err := m.put(expenseRecurring)
+	if err != nil {
+		return nil, err
+	}
+
+	return expenseRecurring, nil
+}
+
+func (m *MemoryRepository) BatchCreateExpenseRecurring(ctx context.Context, expenseRecurring []*models.ExpenseRecurring) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, expense := range expenseRecurring {
+		err := m.put(expense)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (m *MemoryRepository) put(expenseRecurring *models.ExpenseRecurring) error {
+	entity, err := memory.Copy(toExpenseRecurringEntity(expenseRecurring))
+	if err != nil {
+		return err
+	}
+
+	if m.expensesRecurring[entity.Username] == nil {
+		m.expensesRecurring[entity.Username] = make(map[string]*ExpenseRecurringEntity)
+	}
+
+	m.expensesRecurring[entity.Username][entity.ID] = entity
+
+	return nil
+}
+
+func (m *MemoryRepository) ScanExpensesForDay(ctx context.Context, day int) ([]*models.ExpenseRecurring, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	entities := make([]*ExpenseRecurringEntity, 0)
+
+	for _, userExpenses := range m.expensesRecurring {
+		for _, entity := range userExpenses {
+			if entity.RecurringDay != day {
+				continue
+			}
+
+			copied, err := memory.Copy(entity)
+			if err != nil {
+				return nil, err
+			}
+
+			entities = append(entities, copied)
+		}
+	}
+
+	if len(entities) == 0 {
+		return nil, models.ErrRecurringExpensesNotFound
+	}
+
+	return toExpensesRecurringModel(entities), nil
+}
+
+func (m *MemoryRepository) GetExpenseRecurring(ctx context.Context, expenseRecurringID, username string) (*models.ExpenseRecurring, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	entity, ok := m.expensesRecurring[username][expenseRecurringID]
+	if !ok {
+		return nil, models.ErrRecurringExpenseNotFound
+	}
+
+	copied, err := memory.Copy(entity)
+	if err != nil {
+		return nil, err
+	}
+
+	return toExpenseRecurringModel(*copied), nil
+}
+
+func (m *MemoryRepository) GetExpensesRecurring(ctx context.Context, username string, params *models.QueryParameters) ([]*models.ExpenseRecurring, string, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	entities := make([]*ExpenseRecurringEntity, 0, len(m.expensesRecurring[username]))
+
+	for _, entity := range m.expensesRecurring[username] {
+		copied, err := memory.Copy(entity)
+		if err != nil {
+			return nil, "", err
+		}
+
+		entities = append(entities, copied)
+	}
+
+	page, nextKey, err := memory.Paginate(entities, func(e *ExpenseRecurringEntity) string {
+		return e.ID
+	}, params.StartKey, cursor.NewScope(username, params), params.PageSize, false, models.ErrRecurringExpensesNotFound)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return toExpensesRecurringModel(page), nextKey, nil
+}
+
+func (m *MemoryRepository) UpdateExpenseRecurring(ctx context.Context, expenseRecurring *models.ExpenseRecurring) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.expensesRecurring[expenseRecurring.Username][expenseRecurring.ID]; !ok {
+		return models.ErrRecurringExpenseNotFound
+	}
+
+	return m.put(expenseRecurring)
+}
+
+func (m *MemoryRepository) BatchDeleteExpenseRecurring(ctx context.Context, expenseRecurring []*models.ExpenseRecurring) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, expense := range expenseRecurring {
+		delete(m.expensesRecurring[expense.Username], expense.ID)
+	}
+
+	return nil
+}
+
+func (m *MemoryRepository) DeleteExpenseRecurring(ctx context.Context, expenseRecurringID, username string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.expensesRecurring[username], expenseRecurringID)
+
+	return nil
+}
@@ -0,0 +1,231 @@
+package expenses_recurring
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/JoelD7/money/backend/models"
+	"github.com/JoelD7/money/backend/storage/cursor"
+	"github.com/JoelD7/money/backend/storage/sqldb"
+)
+
+const expenseRecurringColumns = `username, id, category_id, amount, currency, name, recurring_day, notes, paused,
+	created_date, update_date`
+
+type SQLRepository struct {
+	db *sqldb.DB
+}
+
+func NewSQLRepository(db *sqldb.DB) *SQLRepository {
+	return &SQLRepository{db: db}
+}
+
+func (s *SQLRepository) CreateExpenseRecurring(ctx context.Context, expenseRecurring *models.ExpenseRecurring) (*models.ExpenseRecurring, error) {
+	err := InsertExpenseRecurring(ctx, s.db, toExpenseRecurringEntity(expenseRecurring))
+	if err != nil {
+		return nil, err
+	}
+
+	return expenseRecurring, nil
+}
+
+// InsertExpenseRecurring inserts the recurring expense with db, which can be a transaction. It fails with
+// models.ErrRecurringExpenseNameTaken if the user has a recurring expense with the same ID.
+func InsertExpenseRecurring(ctx context.Context, db sqldb.Execer, entity *ExpenseRecurringEntity) error {
+	result, err := db.ExecContext(ctx, `INSERT INTO expenses_recurring (`+expenseRecurringColumns+`)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?) ON CONFLICT (username, id) DO NOTHING`, getExpenseRecurringArgs(entity)...)
+	if err != nil {
+		return fmt.Errorf("insert expense recurring failed: %v", err)
+	}
+
+	affected, err := sqldb.RowsAffected(result)
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return models.ErrRecurringExpenseNameTaken
+	}
+
+	return nil
+}
+
+func (s *SQLRepository) BatchCreateExpenseRecurring(ctx context.Context, expenseRecurring []*models.ExpenseRecurring) error {
+	return s.db.WithTx(ctx, func(tx *sqldb.Tx) error {
+		for _, e := range expenseRecurring {
+			_, err := tx.ExecContext(ctx, `INSERT INTO expenses_recurring (`+expenseRecurringColumns+`)
+				VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?) ON CONFLICT (username, id) DO UPDATE SET
+				category_id = excluded.category_id, amount = excluded.amount, currency = excluded.currency,
+				name = excluded.name, recurring_day = excluded.recurring_day, notes = excluded.notes,
+				paused = excluded.paused, created_date = excluded.created_date, update_date = excluded.update_date`,
+				getExpenseRecurringArgs(toExpenseRecurringEntity(e))...)
+			if err != nil {
+				return fmt.Errorf("batch insert expenses recurring failed: %v", err)
+			}
+		}
+
+		return nil
+	})
+}
+
+func (s *SQLRepository) ScanExpensesForDay(ctx context.Context, day int) ([]*models.ExpenseRecurring, error) {
+	entities, err := s.query(ctx, `SELECT `+expenseRecurringColumns+` FROM expenses_recurring WHERE recurring_day = ?
+		ORDER BY username, id`, day)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(entities) == 0 {
+		return nil, models.ErrRecurringExpensesNotFound
+	}
+
+	return toExpensesRecurringModel(entities), nil
+}
+
+func (s *SQLRepository) GetExpenseRecurring(ctx context.Context, expenseRecurringID, username string) (*models.ExpenseRecurring, error) {
+	row := s.db.QueryRowContext(ctx, `SELECT `+expenseRecurringColumns+` FROM expenses_recurring
+		WHERE username = ? AND id = ?`, username, expenseRecurringID)
+
+	entity, err := scanExpenseRecurring(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, models.ErrRecurringExpenseNotFound
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return toExpenseRecurringModel(*entity), nil
+}
+
+func (s *SQLRepository) GetExpensesRecurring(ctx context.Context, username string, params *models.QueryParameters) ([]*models.ExpenseRecurring, string, error) {
+	page := &sqldb.Page{
+		SortColumn: "id",
+		IDColumn:   "id",
+		StartKey:   params.StartKey,
+		PageSize:   params.PageSize,
+		Scope:      cursor.NewScope(username, params),
+	}
+
+	condition, args, err := page.Condition()
+	if err != nil {
+		return nil, "", err
+	}
+
+	entities, err := s.query(ctx, `SELECT `+expenseRecurringColumns+` FROM expenses_recurring WHERE username = ? AND `+
+		condition+` `+page.OrderBy(), append([]interface{}{username}, args...)...)
+	if err != nil {
+		return nil, "", err
+	}
+
+	entities, nextKey, err := sqldb.Paginate(entities, page, func(e *ExpenseRecurringEntity) (interface{}, string) {
+		return e.ID, e.ID
+	}, models.ErrRecurringExpensesNotFound)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return toExpensesRecurringModel(entities), nextKey, nil
+}
+
+func (s *SQLRepository) UpdateExpenseRecurring(ctx context.Context, expenseRecurring *models.ExpenseRecurring) error {
+	entity := toExpenseRecurringEntity(expenseRecurring)
+
+	result, err := s.db.ExecContext(ctx, `UPDATE expenses_recurring SET category_id = ?, amount = ?, currency = ?,
+		name = ?, recurring_day = ?, notes = ?, paused = ?, created_date = ?, update_date = ?
+		WHERE username = ? AND id = ?`,
+		sqldb.NullString(entity.CategoryID), entity.Amount, entity.Currency, entity.Name, entity.RecurringDay,
+		entity.Notes, entity.Paused, sqldb.FormatTime(entity.CreatedDate), sqldb.FormatTime(entity.UpdateDate),
+		entity.Username, entity.ID)
+	if err != nil {
+		return fmt.Errorf("update expense recurring failed: %v", err)
+	}
+
+	affected, err := sqldb.RowsAffected(result)
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return models.ErrRecurringExpenseNotFound
+	}
+
+	return nil
+}
+
+func (s *SQLRepository) BatchDeleteExpenseRecurring(ctx context.Context, expenseRecurring []*models.ExpenseRecurring) error {
+	return s.db.WithTx(ctx, func(tx *sqldb.Tx) error {
+		for _, e := range expenseRecurring {
+			_, err := tx.ExecContext(ctx, `DELETE FROM expenses_recurring WHERE username = ? AND id = ?`, e.Username, e.ID)
+			if err != nil {
+				return fmt.Errorf("batch delete expenses recurring failed: %v", err)
+			}
+		}
+
+		return nil
+	})
+}
+
+func (s *SQLRepository) DeleteExpenseRecurring(ctx context.Context, expenseRecurringID, username string) error {
+	_, err := s.db.ExecContext(ctx, `DELETE FROM expenses_recurring WHERE username = ? AND id = ?`, username, expenseRecurringID)
+	if err != nil {
+		return fmt.Errorf("delete expense recurring failed: %v", err)
+	}
+
+	return nil
+}
+
+func (s *SQLRepository) query(ctx context.Context, query string, args ...interface{}) ([]*ExpenseRecurringEntity, error) {
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("query expenses recurring failed: %v", err)
+	}
+
+	defer rows.Close()
+
+	entities := make([]*ExpenseRecurringEntity, 0)
+
+	for rows.Next() {
+		entity, err := scanExpenseRecurring(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		entities = append(entities, entity)
+	}
+
+	return entities, rows.Err()
+}
+
+func getExpenseRecurringArgs(e *ExpenseRecurringEntity) []interface{} {
+	return []interface{}{e.Username, e.ID, sqldb.NullString(e.CategoryID), e.Amount, e.Currency, e.Name,
+		e.RecurringDay, e.Notes, e.Paused, sqldb.FormatTime(e.CreatedDate), sqldb.FormatTime(e.UpdateDate)}
+}
+
+func scanExpenseRecurring(row sqldb.Scanner) (*ExpenseRecurringEntity, error) {
+	entity := new(ExpenseRecurringEntity)
+
+	var categoryID sql.NullString
+	var createdDate, updateDate string
+
+	err := row.Scan(&entity.Username, &entity.ID, &categoryID, &entity.Amount, &entity.Currency, &entity.Name,
+		&entity.RecurringDay, &entity.Notes, &entity.Paused, &createdDate, &updateDate)
+	if err != nil {
+		return nil, err
+	}
+
+	entity.CategoryID = sqldb.StringPtr(categoryID)
+
+	entity.CreatedDate, err = sqldb.ParseTime(createdDate)
+	if err != nil {
+		return nil, err
+	}
+
+	entity.UpdateDate, err = sqldb.ParseTime(updateDate)
+	if err != nil {
+		return nil, err
+	}
+
+	return entity, nil
+}
@@ -0,0 +1,158 @@
+package expenses
+
+import (
+	"context"
+	"github.com/JoelD7/money/backend/models"
+	"github.com/JoelD7/money/backend/storage/audit"
+)
+
+// auditedRepository records the writes made to the expenses in the audit log.
+type auditedRepository struct {
+	Repository
+	recorder *audit.Recorder
+}
+
+func newAuditedRepository(repo Repository, recorder *audit.Recorder) Repository {
+	if recorder == nil {
+		return repo
+	}
+
+	return &auditedRepository{Repository: repo, recorder: recorder}
+}
+
+func newChange(username, expenseID string, old, new *models.Expense) *audit.Change {
+	change := &audit.Change{Username: username, EntityType: models.AuditEntityExpense, EntityID: expenseID}
+
+	if old != nil {
+		change.Old = old
+	}
+
+	if new != nil {
+		change.New = new
+	}
+
+	return change
+}
+
+func (a *auditedRepository) CreateExpense(ctx context.Context, expense *models.Expense) (*models.Expense, error) {
+	created, err := a.Repository.CreateExpense(ctx, expense)
+	if err != nil {
+		return nil, err
+	}
+
+	a.recorder.Record(ctx, newChange(created.Username, created.ExpenseID, nil, created))
+
+	return created, nil
+}
+
+func (a *auditedRepository) BatchCreateExpenses(ctx context.Context, expenses []*models.Expense) error {
+	err := a.Repository.BatchCreateExpenses(ctx, expenses)
+	if err != nil {
+		return err
+	}
+
+	changes := make([]*audit.Change, 0, len(expenses))
+
+	for _, expense := range expenses {
+		changes = append(changes, newChange(expense.Username, expense.ExpenseID, nil, expense))
+	}
+
+	a.recorder.Record(ctx, changes...)
+
+	return nil
+}
+
+// UpdateExpense records the expense as it's stored before and after the update, as only the fields that are set are
+// updated.
+func (a *auditedRepository) UpdateExpense(ctx context.Context, expense *models.Expense) error {
+	old, err := a.Repository.GetExpense(ctx, expense.Username, expense.ExpenseID)
+	if err != nil {
+		return a.Repository.UpdateExpense(ctx, expense)
+	}
+
+	err = a.Repository.UpdateExpense(ctx, expense)
+	if err != nil {
+		return err
+	}
+
+	updated, err := a.Repository.GetExpense(ctx, expense.Username, expense.ExpenseID)
+	if err != nil {
+		updated = expense
+	}
+
+	a.recorder.Record(ctx, newChange(expense.Username, expense.ExpenseID, old, updated))
+
+	return nil
+}
+
+func (a *auditedRepository) ReplaceExpense(ctx context.Context, expense *models.Expense) error {
+	old, err := a.Repository.GetExpense(ctx, expense.Username, expense.ExpenseID)
+	if err != nil {
+		return a.Repository.ReplaceExpense(ctx, expense)
+	}
+
+	err = a.Repository.ReplaceExpense(ctx, expense)
+	if err != nil {
+		return err
+	}
+
+	a.recorder.Record(ctx, newChange(expense.Username, expense.ExpenseID, old, expense))
+
+	return nil
+}
+
+func (a *auditedRepository) BatchUpdateExpenses(ctx context.Context, expenses []*models.Expense) error {
+	changes := make([]*audit.Change, 0, len(expenses))
+
+	for _, expense := range expenses {
+		// Expenses that can't be read aren't recorded, as the update would fail for them anyway.
+		old, err := a.Repository.GetExpense(ctx, expense.Username, expense.ExpenseID)
+		if err != nil {
+			continue
+		}
+
+		changes = append(changes, newChange(expense.Username, expense.ExpenseID, old, expense))
+	}
+
+	err := a.Repository.BatchUpdateExpenses(ctx, expenses)
+	if err != nil {
+		return err
+	}
+
+	a.recorder.Record(ctx, changes...)
+
+	return nil
+}
+
+func (a *auditedRepository) DeleteExpense(ctx context.Context, expenseID, username string, version int64) error {
+	old, err := a.Repository.GetExpense(ctx, username, expenseID)
+	if err != nil {
+		return a.Repository.DeleteExpense(ctx, expenseID, username, version)
+	}
+
+	err = a.Repository.DeleteExpense(ctx, expenseID, username, version)
+	if err != nil {
+		return err
+	}
+
+	a.recorder.Record(ctx, newChange(username, expenseID, old, nil))
+
+	return nil
+}
+
+func (a *auditedRepository) BatchDeleteExpenses(ctx context.Context, expenses []*models.Expense) error {
+	err := a.Repository.BatchDeleteExpenses(ctx, expenses)
+	if err != nil {
+		return err
+	}
+
+	changes := make([]*audit.Change, 0, len(expenses))
+
+	for _, expense := range expenses {
+		changes = append(changes, newChange(expense.Username, expense.ExpenseID, expense, nil))
+	}
+
+	a.recorder.Record(ctx, changes...)
+
+	return nil
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"github.com/JoelD7/money/backend/models"
 	"github.com/JoelD7/money/backend/shared/logger"
+	"github.com/JoelD7/money/backend/storage/cursor"
 	"github.com/JoelD7/money/backend/storage/dynamo"
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
@@ -22,17 +23,6 @@ const (
 	conditionalFailedKeyword = "ConditionalCheckFailed"
 )
 
-var (
-	//All indices must include "expense_id" and "username" even when they aren't keys of the index, because they are
-	//the main table's primary key and we get a validation error from Dynamo otherwise.
-	keysByIndex = map[string][]string{
-		"period_user-created_date-index": {"expense_id", "username", "period_user", "created_date"},
-		"period_user-expense_id-index":   {"expense_id", "username", "period_user", "expense_id"},
-		"username-created_date-index":    {"expense_id", "username", "created_date"},
-		"":                               {"expense_id", "username"}, //If no index is specified, use the main table's primary key
-	}
-)
-
 type DynamoRepository struct {
 	dynamoClient                 *dynamodb.Client
 	tableName                    string
@@ -42,6 +32,8 @@ type DynamoRepository struct {
 	usernameCreatedDateIndex     string
 	periodUserNameExpenseIDIndex string
 	periodUserAmountIndex        string
+	// keysByIndex holds the key attributes of each index, used to build the LastEvaluatedKey of a page.
+	keysByIndex map[string][]string
 }
 
 func NewDynamoRepository(dynamoClient *dynamodb.Client, envConfig *models.EnvironmentConfiguration) (*DynamoRepository, error) {
@@ -60,6 +52,17 @@ func NewDynamoRepository(dynamoClient *dynamodb.Client, envConfig *models.Enviro
 	d.periodUserNameExpenseIDIndex = envConfig.PeriodUserNameExpenseIDIndex
 	d.periodUserAmountIndex = envConfig.PeriodUserAmountIndex
 
+	//All indices must include "expense_id" and "username" even when they aren't keys of the index, because they are
+	//the main table's primary key and we get a validation error from Dynamo otherwise.
+	d.keysByIndex = map[string][]string{
+		d.periodUserCreatedDateIndex:   {"expense_id", "username", "period_user", "created_date"},
+		d.periodUserIndex:              {"expense_id", "username", "period_user"},
+		d.usernameCreatedDateIndex:     {"expense_id", "username", "created_date"},
+		d.periodUserAmountIndex:        {"expense_id", "username", "period_user", "amount_key"},
+		d.periodUserNameExpenseIDIndex: {"expense_id", "username", "period_user", "name_expense_id"},
+		"":                             {"expense_id", "username"}, //If no index is specified, use the main table's primary key
+	}
+
 	return d, nil
 }
 
@@ -97,6 +100,7 @@ func validateParams(envConfig *models.EnvironmentConfiguration) error {
 
 func (d *DynamoRepository) CreateExpense(ctx context.Context, expense *models.Expense) (*models.Expense, error) {
 	entity := toExpenseEntity(expense)
+	entity.setInitialVersion()
 
 	input, err := d.buildTransactWriteItemsInput(entity, expense)
 	if err != nil {
@@ -171,6 +175,7 @@ func (d *DynamoRepository) BatchCreateExpenses(ctx context.Context, expenses []*
 
 	for _, expense := range expenses {
 		entity := toExpenseEntity(expense)
+		entity.setInitialVersion()
 		entity.PeriodUser = dynamo.BuildPeriodUser(entity.Username, entity.PeriodID)
 		entities = append(entities, entity)
 	}
@@ -204,6 +209,8 @@ func getBatchWriteRequests(entities []*expenseEntity) []types.WriteRequest {
 	return writeRequests
 }
 
+// UpdateExpense updates the fields set in the expense. It fails with models.ErrVersionConflict if the stored expense
+// isn't at the version of expense, and increments the version otherwise.
 func (d *DynamoRepository) UpdateExpense(ctx context.Context, expense *models.Expense) error {
 	entity := toExpenseEntity(expense)
 
@@ -226,24 +233,46 @@ func (d *DynamoRepository) UpdateExpense(ctx context.Context, expense *models.Ex
 		return fmt.Errorf("get attribute values failed: %v", err)
 	}
 
+	attributeValues[":version"], err = attributevalue.Marshal(expense.Version + 1)
+	if err != nil {
+		return fmt.Errorf("marshaling version: %v", err)
+	}
+
 	updateExpression := getUpdateExpression(attributeValues)
 
+	cond := expression.Name("expense_id").AttributeExists().And(dynamo.VersionCondition(expense.Version))
+
+	condExpr, err := expression.NewBuilder().WithCondition(cond).Build()
+	if err != nil {
+		return fmt.Errorf("build condition expression failed: %v", err)
+	}
+
+	for key, value := range condExpr.Values() {
+		attributeValues[key] = value
+	}
+
 	input := &dynamodb.UpdateItemInput{
 		Key: map[string]types.AttributeValue{
 			"username":   username,
 			"expense_id": expenseID,
 		},
-		TableName:                 aws.String(d.tableName),
-		ConditionExpression:       aws.String("attribute_exists(expense_id)"),
-		ExpressionAttributeValues: attributeValues,
-		UpdateExpression:          updateExpression,
+		TableName:                           aws.String(d.tableName),
+		ConditionExpression:                 condExpr.Condition(),
+		ExpressionAttributeNames:            condExpr.Names(),
+		ExpressionAttributeValues:           attributeValues,
+		UpdateExpression:                    updateExpression,
+		ReturnValuesOnConditionCheckFailure: types.ReturnValuesOnConditionCheckFailureAllOld,
 	}
 
 	if expense.Name != nil {
-		input.ExpressionAttributeNames = map[string]string{nameAttributeName: "name"}
+		input.ExpressionAttributeNames[nameAttributeName] = "name"
 	}
 
 	_, err = d.dynamoClient.UpdateItem(ctx, input)
+	if dynamo.IsVersionConflict(err) {
+		return fmt.Errorf("%v: %w", err, models.ErrVersionConflict)
+	}
+
 	if err != nil && strings.Contains(err.Error(), "ConditionalCheckFailedException") {
 		return fmt.Errorf("%v: %w", err, models.ErrExpensesNotFound)
 	}
@@ -255,6 +284,51 @@ func (d *DynamoRepository) UpdateExpense(ctx context.Context, expense *models.Ex
 	return nil
 }
 
+// ReplaceExpense replaces the stored expense with the given one, removing the attributes that it doesn't set. Like
+// UpdateExpense, it fails with models.ErrVersionConflict if the stored expense isn't at the version of expense.
+func (d *DynamoRepository) ReplaceExpense(ctx context.Context, expense *models.Expense) error {
+	entity := toExpenseEntity(expense)
+	entity.UpdateDate = time.Now()
+	entity.Version = expense.Version + 1
+	entity.PeriodUser = dynamo.BuildPeriodUser(entity.Username, entity.PeriodID)
+
+	item, err := attributevalue.MarshalMap(entity)
+	if err != nil {
+		return fmt.Errorf("marshal expense item failed: %v", err)
+	}
+
+	cond := expression.Name("expense_id").AttributeExists().And(dynamo.VersionCondition(expense.Version))
+
+	expr, err := expression.NewBuilder().WithCondition(cond).Build()
+	if err != nil {
+		return fmt.Errorf("build condition expression failed: %v", err)
+	}
+
+	input := &dynamodb.PutItemInput{
+		TableName:                           aws.String(d.tableName),
+		Item:                                item,
+		ConditionExpression:                 expr.Condition(),
+		ExpressionAttributeNames:            expr.Names(),
+		ExpressionAttributeValues:           expr.Values(),
+		ReturnValuesOnConditionCheckFailure: types.ReturnValuesOnConditionCheckFailureAllOld,
+	}
+
+	_, err = d.dynamoClient.PutItem(ctx, input)
+	if dynamo.IsVersionConflict(err) {
+		return fmt.Errorf("%v: %w", err, models.ErrVersionConflict)
+	}
+
+	if err != nil && strings.Contains(err.Error(), "ConditionalCheckFailedException") {
+		return fmt.Errorf("%v: %w", err, models.ErrExpensesNotFound)
+	}
+
+	if err != nil {
+		return fmt.Errorf("replace expense failed: %v", err)
+	}
+
+	return nil
+}
+
 func getAttributeValues(expense *expenseEntity) (map[string]types.AttributeValue, error) {
 	attrValues := make(map[string]types.AttributeValue)
 
@@ -268,6 +342,11 @@ func getAttributeValues(expense *expenseEntity) (map[string]types.AttributeValue
 		return nil, err
 	}
 
+	currency, err := attributevalue.Marshal(expense.Currency)
+	if err != nil {
+		return nil, err
+	}
+
 	name, err := attributevalue.Marshal(expense.Name)
 	if err != nil {
 		return nil, err
@@ -301,6 +380,10 @@ func getAttributeValues(expense *expenseEntity) (map[string]types.AttributeValue
 		attrValues[":amount"] = amount
 	}
 
+	if expense.Currency != "" {
+		attrValues[":currency"] = currency
+	}
+
 	if expense.Name != "" {
 		attrValues[":name"] = name
 	}
@@ -337,11 +420,14 @@ func getUpdateExpression(attributeValues map[string]types.AttributeValue) *strin
 	return aws.String("SET " + strings.Join(attributes, ", "))
 }
 
+// BatchUpdateExpenses replaces the expenses, incrementing their version. Batch writes can't be conditional, so the
+// version isn't checked.
 func (d *DynamoRepository) BatchUpdateExpenses(ctx context.Context, expenses []*models.Expense) error {
 	entities := make([]*expenseEntity, 0, len(expenses))
 
 	for _, expense := range expenses {
 		entity := toExpenseEntity(expense)
+		entity.Version = expense.Version + 1
 		entity.PeriodUser = dynamo.BuildPeriodUser(entity.Username, entity.PeriodID)
 		entities = append(entities, entity)
 	}
@@ -389,7 +475,7 @@ func (d *DynamoRepository) GetExpenses(ctx context.Context, username string, par
 		return nil, "", err
 	}
 
-	return d.performQuery(ctx, input, params.StartKey)
+	return d.performQuery(ctx, input, cursor.NewScope(username, params), params)
 }
 
 func (d *DynamoRepository) GetExpensesByPeriodAndCategories(ctx context.Context, username string, params *models.QueryParameters) ([]*models.Expense, string, error) {
@@ -398,7 +484,7 @@ func (d *DynamoRepository) GetExpensesByPeriodAndCategories(ctx context.Context,
 		return nil, "", err
 	}
 
-	return d.performQuery(ctx, input, params.StartKey)
+	return d.performQuery(ctx, input, cursor.NewScope(username, params), params)
 }
 
 func (d *DynamoRepository) GetExpensesByPeriod(ctx context.Context, username string, params *models.QueryParameters) ([]*models.Expense, string, error) {
@@ -407,7 +493,7 @@ func (d *DynamoRepository) GetExpensesByPeriod(ctx context.Context, username str
 		return nil, "", err
 	}
 
-	return d.performQuery(ctx, input, params.StartKey)
+	return d.performQuery(ctx, input, cursor.NewScope(username, params), params)
 }
 
 func (d *DynamoRepository) GetExpensesByCategory(ctx context.Context, username string, params *models.QueryParameters) ([]*models.Expense, string, error) {
@@ -416,7 +502,7 @@ func (d *DynamoRepository) GetExpensesByCategory(ctx context.Context, username s
 		return nil, "", err
 	}
 
-	return d.performQuery(ctx, input, params.StartKey)
+	return d.performQuery(ctx, input, cursor.NewScope(username, params), params)
 }
 
 func (d *DynamoRepository) GetAllExpensesBetweenDates(ctx context.Context, username, startDate, endDate string) ([]*models.Expense, error) {
@@ -524,16 +610,36 @@ func (d *DynamoRepository) GetAllExpensesByPeriod(ctx context.Context, username
 	return toExpenseModels(entities), nil
 }
 
-func (d *DynamoRepository) DeleteExpense(ctx context.Context, expenseID, username string) error {
+// DeleteExpense deletes the expense if it's at version.
+func (d *DynamoRepository) DeleteExpense(ctx context.Context, expenseID, username string, version int64) error {
+	cond := expression.Name("expense_id").AttributeExists().And(dynamo.VersionCondition(version))
+
+	expr, err := expression.NewBuilder().WithCondition(cond).Build()
+	if err != nil {
+		return fmt.Errorf("build condition expression failed: %v", err)
+	}
+
 	input := &dynamodb.DeleteItemInput{
 		TableName: aws.String(d.tableName),
 		Key: map[string]types.AttributeValue{
 			"username":   &types.AttributeValueMemberS{Value: username},
 			"expense_id": &types.AttributeValueMemberS{Value: expenseID},
 		},
+		ConditionExpression:                 expr.Condition(),
+		ExpressionAttributeNames:            expr.Names(),
+		ExpressionAttributeValues:           expr.Values(),
+		ReturnValuesOnConditionCheckFailure: types.ReturnValuesOnConditionCheckFailureAllOld,
+	}
+
+	_, err = d.dynamoClient.DeleteItem(ctx, input)
+	if dynamo.IsVersionConflict(err) {
+		return fmt.Errorf("%v: %w", err, models.ErrVersionConflict)
+	}
+
+	if err != nil && strings.Contains(err.Error(), "ConditionalCheckFailedException") {
+		return fmt.Errorf("%v: %w", err, models.ErrExpensesNotFound)
 	}
 
-	_, err := d.dynamoClient.DeleteItem(ctx, input)
 	if err != nil {
 		return fmt.Errorf("delete expense failed: %v", err)
 	}
@@ -592,16 +698,21 @@ func (d *DynamoRepository) buildQueryInput(username string, params *models.Query
 
 	keyConditionEx := d.setQueryIndex(input, username, params)
 
-	err = dynamo.SetExclusiveStartKey(params.StartKey, input)
+	err = dynamo.SetExclusiveStartKey(params.StartKey, cursor.NewScope(username, params), input)
 	if err != nil {
 		return nil, err
 	}
 
 	conditionBuilder := expression.NewBuilder().WithCondition(keyConditionEx)
 
-	if params.Categories != nil || len(params.Categories) > 0 {
-		filterCondition := buildCategoriesConditionFilter(params.Categories)
-		conditionBuilder = conditionBuilder.WithFilter(filterCondition)
+	filterConditions := buildFilterConditions(params)
+
+	switch len(filterConditions) {
+	case 0:
+	case 1:
+		conditionBuilder = conditionBuilder.WithFilter(filterConditions[0])
+	default:
+		conditionBuilder = conditionBuilder.WithFilter(expression.And(filterConditions[0], filterConditions[1], filterConditions[2:]...))
 	}
 
 	expr, err := conditionBuilder.Build()
@@ -648,19 +759,33 @@ func (d *DynamoRepository) setQueryIndex(input *dynamodb.QueryInput, username st
 	return keyConditionEx
 }
 
-func (d *DynamoRepository) setExclusiveStartKey(startKey string, input *dynamodb.QueryInput) error {
-	if startKey == "" {
-		return nil
+// buildFilterConditions returns the conditions of the category, amount and date filters. The text search isn't included
+// because DynamoDB can't match it regardless of case, so it's applied to the query results instead.
+func buildFilterConditions(params *models.QueryParameters) []expression.ConditionBuilder {
+	conditions := make([]expression.ConditionBuilder, 0)
+
+	if len(params.Categories) > 0 {
+		conditions = append(conditions, buildCategoriesConditionFilter(params.Categories))
 	}
 
-	decodedStartKey, err := dynamo.DecodePaginationKey(startKey)
-	if err != nil {
-		return fmt.Errorf("%v: %w", err, models.ErrInvalidStartKey)
+	if params.MinAmount != nil {
+		conditions = append(conditions, expression.Name("amount").GreaterThanEqual(expression.Value(*params.MinAmount)))
+	}
+
+	if params.MaxAmount != nil {
+		conditions = append(conditions, expression.Name("amount").LessThanEqual(expression.Value(*params.MaxAmount)))
 	}
 
-	input.ExclusiveStartKey = decodedStartKey
+	// Dates are stored in UTC in the RFC 3339 format, so they can be compared as strings once the bounds are in UTC.
+	if params.FromDate != nil {
+		conditions = append(conditions, expression.Name("created_date").GreaterThanEqual(expression.Value(params.FromDate.UTC().Format(time.RFC3339Nano))))
+	}
 
-	return nil
+	if params.ToDate != nil {
+		conditions = append(conditions, expression.Name("created_date").LessThanEqual(expression.Value(params.ToDate.UTC().Format(time.RFC3339Nano))))
+	}
+
+	return conditions
 }
 
 func buildCategoriesConditionFilter(categories []string) expression.ConditionBuilder {
@@ -685,11 +810,11 @@ func buildCategoriesConditionFilter(categories []string) expression.ConditionBui
 	return expression.Or(conditions[0], conditions[1], conditions[2:]...)
 }
 
-func (d *DynamoRepository) performQuery(ctx context.Context, input *dynamodb.QueryInput, startKey string) ([]*models.Expense, string, error) {
+func (d *DynamoRepository) performQuery(ctx context.Context, input *dynamodb.QueryInput, scope cursor.Scope, params *models.QueryParameters) ([]*models.Expense, string, error) {
 	// If the query has a filter expression it may not include all the items one intends to fetch.
 	// See more details here: https://docs.aws.amazon.com/amazondynamodb/latest/developerguide/Query.FilterExpression.html
-	if input.FilterExpression != nil {
-		return d.performQueryWithFilter(ctx, input, startKey)
+	if input.FilterExpression != nil || params.Search != "" {
+		return d.performQueryWithFilter(ctx, input, scope, params)
 	}
 
 	result, err := d.dynamoClient.Query(ctx, input)
@@ -712,7 +837,7 @@ func (d *DynamoRepository) performQuery(ctx context.Context, input *dynamodb.Que
 		return nil, "", fmt.Errorf("unmarshal expenses items failed: %v", err)
 	}
 
-	nextKey, err := dynamo.EncodePaginationKey(result.LastEvaluatedKey)
+	nextKey, err := dynamo.EncodePaginationKey(result.LastEvaluatedKey, scope)
 	if err != nil {
 		return nil, "", err
 	}
@@ -720,7 +845,7 @@ func (d *DynamoRepository) performQuery(ctx context.Context, input *dynamodb.Que
 	return toExpenseModels(expensesEntities), nextKey, nil
 }
 
-func (d *DynamoRepository) performQueryWithFilter(ctx context.Context, input *dynamodb.QueryInput, startKey string) ([]*models.Expense, string, error) {
+func (d *DynamoRepository) performQueryWithFilter(ctx context.Context, input *dynamodb.QueryInput, scope cursor.Scope, params *models.QueryParameters) ([]*models.Expense, string, error) {
 	retrievedItems := 0
 	resultSet := make([]expenseEntity, 0)
 	var result *dynamodb.QueryOutput
@@ -742,14 +867,16 @@ func (d *DynamoRepository) performQueryWithFilter(ctx context.Context, input *dy
 			return nil, "", fmt.Errorf("unmarshal expenses items failed: %v", err)
 		}
 
-		retrievedItems += len(result.Items)
+		items := filterBySearch(result.Items, itemsInQuery, params)
+
+		retrievedItems += len(items)
 
 		// This asks: should we implement custom pagination?
 		if retrievedItems >= int(*input.Limit) {
-			return d.getPaginatedExpenses(acumItems, result.Items, input)
+			return d.getPaginatedExpenses(acumItems, items, input, scope)
 		}
 
-		acumItems = append(acumItems, result.Items...)
+		acumItems = append(acumItems, items...)
 
 		if result.LastEvaluatedKey == nil {
 			break
@@ -761,12 +888,12 @@ func (d *DynamoRepository) performQueryWithFilter(ctx context.Context, input *dy
 		return nil, "", fmt.Errorf("unmarshal expenses items failed: %v", err)
 	}
 
-	nextKey, err := dynamo.EncodePaginationKey(input.ExclusiveStartKey)
+	nextKey, err := dynamo.EncodePaginationKey(input.ExclusiveStartKey, scope)
 	if err != nil {
 		return nil, "", err
 	}
 
-	if len(resultSet) == 0 && startKey == "" {
+	if len(resultSet) == 0 && params.StartKey == "" {
 		return nil, "", models.ErrExpensesNotFound
 	}
 
@@ -777,7 +904,7 @@ func (d *DynamoRepository) performQueryWithFilter(ctx context.Context, input *dy
 	return toExpenseModels(resultSet), nextKey, nil
 }
 
-func (d *DynamoRepository) getPaginatedExpenses(acumItems, itemsInQuery []map[string]types.AttributeValue, input *dynamodb.QueryInput) ([]*models.Expense, string, error) {
+func (d *DynamoRepository) getPaginatedExpenses(acumItems, itemsInQuery []map[string]types.AttributeValue, input *dynamodb.QueryInput, scope cursor.Scope) ([]*models.Expense, string, error) {
 	var err error
 
 	copyUpto := getCopyUpto(itemsInQuery, acumItems, input)
@@ -788,7 +915,7 @@ func (d *DynamoRepository) getPaginatedExpenses(acumItems, itemsInQuery []map[st
 		return nil, "", fmt.Errorf("getting expenses failed: %v", err)
 	}
 
-	nextKey, err := dynamo.EncodePaginationKey(input.ExclusiveStartKey)
+	nextKey, err := dynamo.EncodePaginationKey(input.ExclusiveStartKey, scope)
 	if err != nil {
 		return nil, "", err
 	}
@@ -819,7 +946,7 @@ func (d *DynamoRepository) buildCustomExclusiveStartKey(lastEvaluatedKey map[str
 		indexName = *input.IndexName
 	}
 
-	keys, ok := keysByIndex[indexName]
+	keys, ok := d.keysByIndex[indexName]
 	if !ok {
 		return nil, models.ErrIndexKeysNotFound
 	}
@@ -831,6 +958,23 @@ func (d *DynamoRepository) buildCustomExclusiveStartKey(lastEvaluatedKey map[str
 	return exclusiveStartKey, nil
 }
 
+// filterBySearch returns the items whose name or notes contain the search text. entities must be the unmarshalled items.
+func filterBySearch(items []map[string]types.AttributeValue, entities []expenseEntity, params *models.QueryParameters) []map[string]types.AttributeValue {
+	if params.Search == "" {
+		return items
+	}
+
+	filtered := make([]map[string]types.AttributeValue, 0, len(items))
+
+	for i, entity := range entities {
+		if params.MatchesSearch(entity.Name, entity.Notes) {
+			filtered = append(filtered, items[i])
+		}
+	}
+
+	return filtered
+}
+
 // getCopyUpto returns the index up to which we can copy the items from the current query result to the list of items to
 // return. This ensures that the total quantity of requested items, as indicated by the pageSize parameter, is satisfied.
 func getCopyUpto(itemsInQuery, expensesEntities []map[string]types.AttributeValue, input *dynamodb.QueryInput) int {
@@ -55,6 +55,14 @@ func (d *DynamoMock) UpdateExpense(ctx context.Context, expense *models.Expense)
 	return nil
 }
 
+func (d *DynamoMock) ReplaceExpense(ctx context.Context, expense *models.Expense) error {
+	if d.mockedErr != nil {
+		return d.mockedErr
+	}
+
+	return nil
+}
+
 func (d *DynamoMock) GetExpenses(ctx context.Context, username string, params *models.QueryParameters) ([]*models.Expense, string, error) {
 	if d.mockedErr != nil {
 		return nil, "", d.mockedErr
@@ -116,11 +124,26 @@ func (d *DynamoMock) GetExpense(ctx context.Context, username, expenseID string)
 }
 
 func (d *DynamoMock) GetAllExpensesByPeriod(ctx context.Context, username string, params *models.QueryParameters) ([]*models.Expense, error) {
-	//TODO implement me
-	panic("implement me")
+	if d.mockedErr != nil {
+		return nil, d.mockedErr
+	}
+
+	expenses := make([]*models.Expense, 0)
+
+	for _, expense := range d.mockedExpenses {
+		if expense.PeriodID == params.Period {
+			expenses = append(expenses, expense)
+		}
+	}
+
+	if len(expenses) == 0 {
+		return nil, models.ErrExpensesNotFound
+	}
+
+	return expenses, nil
 }
 
-func (d *DynamoMock) DeleteExpense(ctx context.Context, expenseID, username string) error {
+func (d *DynamoMock) DeleteExpense(ctx context.Context, expenseID, username string, version int64) error {
 	if d.mockedErr != nil {
 		return d.mockedErr
 	}
@@ -149,7 +172,7 @@ func GetDummyExpenses() []*models.Expense {
 			ExpenseID:   "EXP123",
 			Username:    "test@mail.com",
 			CategoryID:  getStringPtr(""),
-			Amount:      getFloat64Ptr(893),
+			Amount:      getMoneyPtr(893),
 			Name:        getStringPtr("Jordan shopping"),
 			Notes:       "",
 			CreatedDate: time.Date(2023, 5, 12, 20, 15, 0, 0, time.UTC),
@@ -160,7 +183,7 @@ func GetDummyExpenses() []*models.Expense {
 			ExpenseID:   "EXP456",
 			Username:    "test@mail.com",
 			CategoryID:  getStringPtr(""),
-			Amount:      getFloat64Ptr(112),
+			Amount:      getMoneyPtr(112),
 			Name:        getStringPtr("Uber drive"),
 			Notes:       "",
 			CreatedDate: time.Date(2023, 5, 15, 12, 15, 0, 0, time.UTC),
@@ -171,7 +194,7 @@ func GetDummyExpenses() []*models.Expense {
 			ExpenseID:   "EXP789",
 			Username:    "test@mail.com",
 			CategoryID:  getStringPtr(""),
-			Amount:      getFloat64Ptr(525),
+			Amount:      getMoneyPtr(525),
 			Name:        getStringPtr("Lunch"),
 			Notes:       "",
 			CreatedDate: time.Date(2023, 5, 12, 11, 15, 0, 0, time.UTC),
@@ -181,8 +204,9 @@ func GetDummyExpenses() []*models.Expense {
 	}
 }
 
-func getFloat64Ptr(f float64) *float64 {
-	return &f
+func getMoneyPtr(f float64) *models.Money {
+	money := models.NewMoney(f)
+	return &money
 }
 
 func getStringPtr(s string) *string {
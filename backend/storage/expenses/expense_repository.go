@@ -3,6 +3,8 @@ package expenses
 import (
 	"context"
 	"github.com/JoelD7/money/backend/models"
+	"github.com/JoelD7/money/backend/storage/audit"
+	"github.com/JoelD7/money/backend/storage/sqldb"
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
 )
 
@@ -25,8 +27,34 @@ type Repository interface {
 	GetAllExpensesByPeriod(ctx context.Context, username string, params *models.QueryParameters) ([]*models.Expense, error)
 
 	UpdateExpense(ctx context.Context, expense *models.Expense) error
+	ReplaceExpense(ctx context.Context, expense *models.Expense) error
 	BatchUpdateExpenses(ctx context.Context, expenses []*models.Expense) error
 
-	DeleteExpense(ctx context.Context, expenseID, username string) error
+	DeleteExpense(ctx context.Context, expenseID, username string, version int64) error
 	BatchDeleteExpenses(ctx context.Context, expenses []*models.Expense) error
 }
+
+// NewRepository returns the repository of the storage backend set in the configuration. Its writes are recorded in the
+// audit log when it's enabled.
+func NewRepository(ctx context.Context, dynamoClient *dynamodb.Client, envConfig *models.EnvironmentConfiguration) (Repository, error) {
+	recorder, err := audit.NewRecorder(ctx, dynamoClient, envConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	if envConfig.StorageBackend != models.StorageBackendSQL {
+		repo, err := NewDynamoRepository(dynamoClient, envConfig)
+		if err != nil {
+			return nil, err
+		}
+
+		return newAuditedRepository(repo, recorder), nil
+	}
+
+	db, err := sqldb.Connect(ctx, envConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	return newAuditedRepository(NewSQLRepository(db), recorder), nil
+}
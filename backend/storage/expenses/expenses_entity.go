@@ -10,21 +10,23 @@ import (
 )
 
 type expenseEntity struct {
-	ExpenseID   string    `json:"expense_id" dynamodbav:"expense_id"`
-	Username    string    `json:"username,omitempty" dynamodbav:"username"`
-	CategoryID  *string   `json:"category_id,omitempty" dynamodbav:"category_id"`
-	Amount      float64   `json:"amount" dynamodbav:"amount"`
-	Name        string    `json:"name,omitempty" dynamodbav:"name"`
-	Notes       string    `json:"notes,omitempty" dynamodbav:"notes"`
-	CreatedDate time.Time `json:"created_date,omitempty" dynamodbav:"created_date"`
-	PeriodID    string    `json:"period_id,omitempty" dynamodbav:"period_id"`
-	PeriodUser  *string   `json:"period_user,omitempty" dynamodbav:"period_user"`
-	UpdateDate  time.Time `json:"update_date,omitempty" dynamodbav:"update_date"`
+	ExpenseID   string       `json:"expense_id" dynamodbav:"expense_id"`
+	Username    string       `json:"username,omitempty" dynamodbav:"username"`
+	CategoryID  *string      `json:"category_id,omitempty" dynamodbav:"category_id"`
+	Amount      models.Money `json:"amount" dynamodbav:"amount"`
+	Currency    string       `json:"currency,omitempty" dynamodbav:"currency,omitempty"`
+	Name        string       `json:"name,omitempty" dynamodbav:"name"`
+	Notes       string       `json:"notes,omitempty" dynamodbav:"notes"`
+	CreatedDate time.Time    `json:"created_date,omitempty" dynamodbav:"created_date"`
+	PeriodID    string       `json:"period_id,omitempty" dynamodbav:"period_id"`
+	PeriodUser  *string      `json:"period_user,omitempty" dynamodbav:"period_user"`
+	UpdateDate  time.Time    `json:"update_date,omitempty" dynamodbav:"update_date"`
 	// AmountKey is a special attribute used to sort expenses by amount. It's composed of a padded-string of the amount
 	// plus the expense id.
 	AmountKey string `json:"amount_key,omitempty" dynamodbav:"amount_key"`
 	// NameExpenseID is a special attribute used to sort expenses by name. It's composed of the name plus the expense id.
 	NameExpenseID string `json:"name_expense_id,omitempty" dynamodbav:"name_expense_id"`
+	Version       int64  `json:"version,omitempty" dynamodbav:"version,omitempty"`
 }
 
 func toExpenseEntity(e *models.Expense) *expenseEntity {
@@ -32,10 +34,12 @@ func toExpenseEntity(e *models.Expense) *expenseEntity {
 		ExpenseID:     e.ExpenseID,
 		Username:      e.Username,
 		CategoryID:    e.CategoryID,
+		Currency:      e.Currency,
 		Notes:         e.Notes,
 		CreatedDate:   e.CreatedDate,
 		PeriodID:      e.PeriodID,
 		UpdateDate:    e.UpdateDate,
+		Version:       e.Version,
 		AmountKey:     dynamo.BuildAmountKey(e.GetAmount(), e.ExpenseID),
 		NameExpenseID: dynamo.BuildNameKey(e.GetName(), e.ExpenseID),
 	}
@@ -57,11 +61,13 @@ func toExpenseModel(e expenseEntity) *models.Expense {
 		Username:    e.Username,
 		CategoryID:  e.CategoryID,
 		Amount:      &e.Amount,
+		Currency:    e.Currency,
 		Name:        &e.Name,
 		Notes:       e.Notes,
 		CreatedDate: e.CreatedDate,
 		PeriodID:    e.PeriodID,
 		UpdateDate:  e.UpdateDate,
+		Version:     e.Version,
 	}
 }
 
@@ -79,6 +85,7 @@ func toExpenseRecurringEntity(e *models.Expense) *er.ExpenseRecurringEntity {
 		ID:           strings.ToLower(*e.Name),
 		Username:     e.Username,
 		CategoryID:   e.CategoryID,
+		Currency:     e.Currency,
 		Notes:        e.Notes,
 		RecurringDay: *e.RecurringDay,
 		CreatedDate:  e.CreatedDate,
@@ -113,3 +120,11 @@ func (e *expenseEntity) Value() map[string]interface{} {
 		"update_date":  e.UpdateDate,
 	}
 }
+
+// setInitialVersion sets the version of an expense that's being created. Expenses that already have one, like the
+// restored ones, keep it.
+func (e *expenseEntity) setInitialVersion() {
+	if e.Version == 0 {
+		e.Version = 1
+	}
+}
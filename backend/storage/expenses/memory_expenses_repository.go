@@ -0,0 +1,382 @@
+package expenses
+
+import (
+	"context"
+	"github.com/JoelD7/money/backend/models"
+	"github.com/JoelD7/money/backend/storage/cursor"
+	"github.com/JoelD7/money/backend/storage/dynamo"
+	er "github.com/JoelD7/money/backend/storage/expenses-recurring"
+	"github.com/JoelD7/money/backend/storage/memory"
+	"strings"
+	"sync"
+	"time"
+)
+
+// MemoryRepository is a thread-safe implementation of Repository that keeps the expenses in memory. Queries are sorted
+// and paginated as they would be by the indexes of the DynamoDB table.
+type MemoryRepository struct {
+	mu sync.RWMutex
+	// expenses holds the expenses by username and expense ID.
+	expenses map[string]map[string]*expenseEntity
+	// expensesRecurringRepo is where the recurring expenses are created along with their expense. It's optional.
+	expensesRecurringRepo er.Repository
+}
+
+// NewMemoryRepository creates an in-memory expenses repository. Recurring expenses are saved in expensesRecurringRepo,
+// which can be nil if they aren't needed.
+func NewMemoryRepository(expensesRecurringRepo er.Repository) *MemoryRepository {
+	return &MemoryRepository{
+		expenses:              make(map[string]map[string]*expenseEntity),
+		expensesRecurringRepo: expensesRecurringRepo,
+	}
+}
+
+func (m *MemoryRepository) CreateExpense(ctx context.Context, expense *models.Expense) (*models.Expense, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	entity := toExpenseEntity(expense)
+	entity.setInitialVersion()
+
+	if expense.IsRecurring && m.expensesRecurringRepo != nil {
+		_, err := m.expensesRecurringRepo.CreateExpenseRecurring(ctx, toExpenseRecurringModel(expense))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err := m.put(entity)
+	if err != nil {
+		return nil, err
+	}
+
+	return toExpenseModel(*entity), nil
+}
+
+func toExpenseRecurringModel(expense *models.Expense) *models.ExpenseRecurring {
+	expenseRecurring := &models.ExpenseRecurring{
+		ID:          strings.ToLower(expense.GetName()),
+		Username:    expense.Username,
+		CategoryID:  expense.CategoryID,
+		Amount:      expense.GetAmount(),
+		Currency:    expense.Currency,
+		Name:        expense.GetName(),
+		Notes:       expense.Notes,
+		CreatedDate: expense.CreatedDate,
+		UpdateDate:  expense.UpdateDate,
+	}
+
+	if expense.RecurringDay != nil {
+		expenseRecurring.RecurringDay = *expense.RecurringDay
+	}
+
+	return expenseRecurring
+}
+
+func (m *MemoryRepository) BatchCreateExpenses(ctx context.Context, expenses []*models.Expense) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, expense := range expenses {
+		entity := toExpenseEntity(expense)
+		entity.setInitialVersion()
+
+		err := m.put(entity)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (m *MemoryRepository) put(entity *expenseEntity) error {
+	entity.PeriodUser = dynamo.BuildPeriodUser(entity.Username, entity.PeriodID)
+
+	copied, err := memory.Copy(entity)
+	if err != nil {
+		return err
+	}
+
+	if m.expenses[entity.Username] == nil {
+		m.expenses[entity.Username] = make(map[string]*expenseEntity)
+	}
+
+	m.expenses[entity.Username][entity.ExpenseID] = copied
+
+	return nil
+}
+
+func (m *MemoryRepository) GetExpenses(ctx context.Context, username string, params *models.QueryParameters) ([]*models.Expense, string, error) {
+	return m.query(username, params)
+}
+
+func (m *MemoryRepository) GetExpensesByPeriod(ctx context.Context, username string, params *models.QueryParameters) ([]*models.Expense, string, error) {
+	return m.query(username, params)
+}
+
+func (m *MemoryRepository) GetExpensesByPeriodAndCategories(ctx context.Context, username string, params *models.QueryParameters) ([]*models.Expense, string, error) {
+	return m.query(username, params)
+}
+
+func (m *MemoryRepository) GetExpensesByCategory(ctx context.Context, username string, params *models.QueryParameters) ([]*models.Expense, string, error) {
+	return m.query(username, params)
+}
+
+func (m *MemoryRepository) query(username string, params *models.QueryParameters) ([]*models.Expense, string, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	entities, err := m.filter(username, func(entity *expenseEntity) bool {
+		return (params.Period == "" || entity.PeriodID == params.Period) && hasCategory(entity, params.Categories) &&
+			params.MatchesFilters(entity.Amount, entity.CreatedDate, entity.Name, entity.Notes)
+	})
+	if err != nil {
+		return nil, "", err
+	}
+
+	page, nextKey, err := memory.Paginate(entities, getSortKey(params), params.StartKey, cursor.NewScope(username, params),
+		params.PageSize, params.SortType == string(models.SortOrderDescending), models.ErrExpensesNotFound)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return toExpenseModels(page), nextKey, nil
+}
+
+// getSortKey returns the sort key of the index the DynamoDB repository would query with params.
+func getSortKey(params *models.QueryParameters) func(e expenseEntity) string {
+	switch {
+	case params.SortBy == string(models.SortParamCreatedDate):
+		return func(e expenseEntity) string {
+			return dynamo.BuildCreatedDateEntityIDKey(e.CreatedDate, e.ExpenseID)
+		}
+	case params.Period != "" && params.SortBy == string(models.SortParamAmount):
+		return func(e expenseEntity) string {
+			return dynamo.BuildAmountKey(e.Amount, e.ExpenseID)
+		}
+	case params.Period != "" && params.SortBy == string(models.SortParamName):
+		return func(e expenseEntity) string {
+			return dynamo.BuildNameKey(e.Name, e.ExpenseID)
+		}
+	default:
+		return func(e expenseEntity) string {
+			return e.ExpenseID
+		}
+	}
+}
+
+// hasCategory tells if the expense belongs to one of the categories. As in the DynamoDB repository, an empty first
+// category matches the expenses without a category.
+func hasCategory(entity *expenseEntity, categories []string) bool {
+	if len(categories) == 0 {
+		return true
+	}
+
+	if categories[0] == "" {
+		return entity.CategoryID == nil
+	}
+
+	for _, categoryID := range categories {
+		if entity.CategoryID != nil && *entity.CategoryID == categoryID {
+			return true
+		}
+	}
+
+	return false
+}
+
+// filter returns copies of the expenses of the user that match.
+func (m *MemoryRepository) filter(username string, match func(entity *expenseEntity) bool) ([]expenseEntity, error) {
+	entities := make([]expenseEntity, 0)
+
+	for _, entity := range m.expenses[username] {
+		if !match(entity) {
+			continue
+		}
+
+		copied, err := memory.Copy(entity)
+		if err != nil {
+			return nil, err
+		}
+
+		entities = append(entities, *copied)
+	}
+
+	return entities, nil
+}
+
+func (m *MemoryRepository) GetExpense(ctx context.Context, username, expenseID string) (*models.Expense, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	entity, ok := m.expenses[username][expenseID]
+	if !ok {
+		return nil, models.ErrExpenseNotFound
+	}
+
+	copied, err := memory.Copy(entity)
+	if err != nil {
+		return nil, err
+	}
+
+	return toExpenseModel(*copied), nil
+}
+
+func (m *MemoryRepository) GetAllExpensesBetweenDates(ctx context.Context, username, startDate, endDate string) ([]*models.Expense, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	// DynamoDB compares the dates as strings, as that's how they are stored.
+	entities, err := m.filter(username, func(entity *expenseEntity) bool {
+		createdDate := entity.CreatedDate.Format(time.RFC3339Nano)
+
+		return createdDate >= startDate && createdDate <= endDate
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(entities) == 0 {
+		return nil, models.ErrExpensesNotFound
+	}
+
+	return toExpenseModels(entities), nil
+}
+
+func (m *MemoryRepository) GetAllExpensesByPeriod(ctx context.Context, username string, params *models.QueryParameters) ([]*models.Expense, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	entities, err := m.filter(username, func(entity *expenseEntity) bool {
+		return entity.PeriodID == params.Period
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(entities) == 0 {
+		return nil, models.ErrExpensesNotFound
+	}
+
+	return toExpenseModels(entities), nil
+}
+
+// UpdateExpense sets the attributes of the expense that aren't empty, as the DynamoDB repository does.
+func (m *MemoryRepository) UpdateExpense(ctx context.Context, expense *models.Expense) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	stored, ok := m.expenses[expense.Username][expense.ExpenseID]
+	if !ok {
+		return models.ErrExpensesNotFound
+	}
+
+	if stored.Version != expense.Version {
+		return models.ErrVersionConflict
+	}
+
+	entity, err := memory.Copy(stored)
+	if err != nil {
+		return err
+	}
+
+	update := toExpenseEntity(expense)
+
+	if update.CategoryID != nil {
+		entity.CategoryID = update.CategoryID
+	}
+
+	if update.Amount != 0 {
+		entity.Amount = update.Amount
+	}
+
+	if update.Currency != "" {
+		entity.Currency = update.Currency
+	}
+
+	if update.Name != "" {
+		entity.Name = update.Name
+	}
+
+	if update.Notes != "" {
+		entity.Notes = update.Notes
+	}
+
+	if update.PeriodID != "" {
+		entity.PeriodID = update.PeriodID
+	}
+
+	entity.UpdateDate = time.Now()
+	entity.Version++
+
+	return m.put(entity)
+}
+
+// ReplaceExpense replaces the stored expense with the given one if the stored expense is at its version.
+func (m *MemoryRepository) ReplaceExpense(ctx context.Context, expense *models.Expense) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	stored, ok := m.expenses[expense.Username][expense.ExpenseID]
+	if !ok {
+		return models.ErrExpensesNotFound
+	}
+
+	if stored.Version != expense.Version {
+		return models.ErrVersionConflict
+	}
+
+	entity := toExpenseEntity(expense)
+	entity.UpdateDate = time.Now()
+	entity.Version = expense.Version + 1
+
+	return m.put(entity)
+}
+
+func (m *MemoryRepository) BatchUpdateExpenses(ctx context.Context, expenses []*models.Expense) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, expense := range expenses {
+		entity := toExpenseEntity(expense)
+		entity.Version = expense.Version + 1
+
+		err := m.put(entity)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// DeleteExpense deletes the expense if it's at version.
+func (m *MemoryRepository) DeleteExpense(ctx context.Context, expenseID, username string, version int64) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	stored, ok := m.expenses[username][expenseID]
+	if !ok {
+		return models.ErrExpensesNotFound
+	}
+
+	if stored.Version != version {
+		return models.ErrVersionConflict
+	}
+
+	delete(m.expenses[username], expenseID)
+
+	return nil
+}
+
+func (m *MemoryRepository) BatchDeleteExpenses(ctx context.Context, expenses []*models.Expense) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, expense := range expenses {
+		delete(m.expenses[expense.Username], expense.ExpenseID)
+	}
+
+	return nil
+}
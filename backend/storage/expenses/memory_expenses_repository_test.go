@@ -0,0 +1,233 @@
+package expenses
+
+import (
+	"context"
+	"fmt"
+	"github.com/JoelD7/money/backend/models"
+	er "github.com/JoelD7/money/backend/storage/expenses-recurring"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestMemoryRepositoryPagination(t *testing.T) {
+	t.Setenv("PAGINATION_KEY_SECRET", "pagination-secret")
+
+	c := require.New(t)
+
+	ctx := context.Background()
+	repo := NewMemoryRepository(nil)
+
+	for i := 1; i <= 5; i++ {
+		_, err := repo.CreateExpense(ctx, newTestExpense(fmt.Sprintf("EX%d", i), "period1", models.Money(6-i)*100))
+		c.NoError(err)
+	}
+
+	_, err := repo.CreateExpense(ctx, newTestExpense("EX6", "period2", 100))
+	c.NoError(err)
+
+	params := &models.QueryParameters{Period: "period1", SortBy: string(models.SortParamAmount), PageSize: 3}
+
+	expenses, nextKey, err := repo.GetExpensesByPeriod(ctx, "test", params)
+	c.NoError(err)
+	c.NotEmpty(nextKey)
+	c.Equal([]string{"EX5", "EX4", "EX3"}, getExpenseIDs(expenses))
+
+	// The next page is right even if the last item of the previous page is deleted.
+	c.ErrorIs(repo.DeleteExpense(ctx, "EX3", "test", 2), models.ErrVersionConflict)
+	c.NoError(repo.DeleteExpense(ctx, "EX3", "test", 1))
+	c.ErrorIs(repo.DeleteExpense(ctx, "EX3", "test", 1), models.ErrExpensesNotFound)
+
+	params.StartKey = nextKey
+
+	expenses, nextKey, err = repo.GetExpensesByPeriod(ctx, "test", params)
+	c.NoError(err)
+	c.Empty(nextKey)
+	c.Equal([]string{"EX2", "EX1"}, getExpenseIDs(expenses))
+
+	params.SortType = string(models.SortOrderDescending)
+	params.StartKey = ""
+
+	expenses, _, err = repo.GetExpensesByPeriod(ctx, "test", params)
+	c.NoError(err)
+	c.Equal([]string{"EX1", "EX2", "EX4"}, getExpenseIDs(expenses))
+
+	_, _, err = repo.GetExpensesByPeriod(ctx, "test", &models.QueryParameters{Period: "period3"})
+	c.ErrorIs(err, models.ErrExpensesNotFound)
+
+	_, _, err = repo.GetExpenses(ctx, "test", &models.QueryParameters{StartKey: "%invalid%"})
+	c.ErrorIs(err, models.ErrInvalidStartKey)
+}
+
+func TestMemoryRepositoryCategories(t *testing.T) {
+	c := require.New(t)
+
+	ctx := context.Background()
+	repo := NewMemoryRepository(nil)
+
+	food := "food"
+	rent := "rent"
+
+	withFood := newTestExpense("EX1", "period1", 100)
+	withFood.CategoryID = &food
+
+	withRent := newTestExpense("EX2", "period1", 200)
+	withRent.CategoryID = &rent
+
+	c.NoError(repo.BatchCreateExpenses(ctx, []*models.Expense{withFood, withRent, newTestExpense("EX3", "period1", 300)}))
+
+	expenses, _, err := repo.GetExpensesByPeriodAndCategories(ctx, "test", &models.QueryParameters{Period: "period1", Categories: []string{food, rent}})
+	c.NoError(err)
+	c.Equal([]string{"EX1", "EX2"}, getExpenseIDs(expenses))
+
+	expenses, _, err = repo.GetExpensesByCategory(ctx, "test", &models.QueryParameters{Categories: []string{""}})
+	c.NoError(err)
+	c.Equal([]string{"EX3"}, getExpenseIDs(expenses))
+}
+
+func TestMemoryRepositoryFilters(t *testing.T) {
+	t.Setenv("PAGINATION_KEY_SECRET", "pagination-secret")
+
+	testRepositoryFilters(require.New(t), NewMemoryRepository(nil))
+}
+
+// testRepositoryFilters checks the amount, date and text filters of the repository, and that the pages are still full.
+func testRepositoryFilters(c *require.Assertions, repo Repository) {
+	ctx := context.Background()
+	now := time.Now()
+
+	for i := 1; i <= 6; i++ {
+		expense := newTestExpense(fmt.Sprintf("EX%d", i), "period1", models.Money(i)*100)
+		expense.CreatedDate = now.AddDate(0, 0, -i)
+
+		if i%2 == 0 {
+			expense.Notes = "Paid with the 50% discount card"
+		}
+
+		c.NoError(repo.BatchCreateExpenses(ctx, []*models.Expense{expense}))
+	}
+
+	minAmount := models.Money(200)
+	maxAmount := models.Money(500)
+
+	params := &models.QueryParameters{Period: "period1", MinAmount: &minAmount, MaxAmount: &maxAmount, PageSize: 2}
+
+	expenses, nextKey, err := repo.GetExpensesByPeriod(ctx, "test", params)
+	c.NoError(err)
+	c.Equal([]string{"EX2", "EX3"}, getExpenseIDs(expenses))
+
+	params.StartKey = nextKey
+
+	expenses, _, err = repo.GetExpensesByPeriod(ctx, "test", params)
+	c.NoError(err)
+	c.Equal([]string{"EX4", "EX5"}, getExpenseIDs(expenses))
+
+	fromDate := now.AddDate(0, 0, -4).Add(-time.Minute)
+	toDate := now.AddDate(0, 0, -2).Add(time.Minute)
+
+	expenses, _, err = repo.GetExpenses(ctx, "test", &models.QueryParameters{FromDate: &fromDate, ToDate: &toDate,
+		SortBy: string(models.SortParamCreatedDate)})
+	c.NoError(err)
+	c.Equal([]string{"EX4", "EX3", "EX2"}, getExpenseIDs(expenses))
+
+	// The search isn't case-sensitive and matches the name or the notes. Wildcards are matched literally.
+	expenses, _, err = repo.GetExpenses(ctx, "test", &models.QueryParameters{Search: "50% DISCOUNT", MinAmount: &minAmount, PageSize: 2})
+	c.NoError(err)
+	c.Equal([]string{"EX2", "EX4"}, getExpenseIDs(expenses))
+
+	expenses, _, err = repo.GetExpenses(ctx, "test", &models.QueryParameters{Search: "rent"})
+	c.NoError(err)
+	c.Len(expenses, 6)
+
+	_, _, err = repo.GetExpenses(ctx, "test", &models.QueryParameters{Search: "5_ discount"})
+	c.ErrorIs(err, models.ErrExpensesNotFound)
+}
+
+func TestMemoryRepositoryUpdate(t *testing.T) {
+	c := require.New(t)
+
+	ctx := context.Background()
+	repo := NewMemoryRepository(nil)
+
+	expense := newTestExpense("EX1", "period1", 100)
+	expense.Notes = "notes"
+
+	_, err := repo.CreateExpense(ctx, expense)
+	c.NoError(err)
+
+	// Changing the expense after creating it doesn't change the stored one.
+	*expense.Amount = 500
+
+	amount := models.Money(200)
+	err = repo.UpdateExpense(ctx, &models.Expense{ExpenseID: "EX1", Username: "test", Amount: &amount, Version: 1})
+	c.NoError(err)
+
+	stored, err := repo.GetExpense(ctx, "test", "EX1")
+	c.NoError(err)
+	c.Equal(int64(2), stored.Version)
+	c.Equal(models.Money(200), stored.GetAmount())
+	c.Equal("notes", stored.Notes)
+	c.Equal("period1", stored.PeriodID)
+	c.False(stored.UpdateDate.IsZero())
+
+	err = repo.UpdateExpense(ctx, &models.Expense{ExpenseID: "EX1", Username: "test", Amount: &amount, Version: 1})
+	c.ErrorIs(err, models.ErrVersionConflict)
+
+	err = repo.UpdateExpense(ctx, &models.Expense{ExpenseID: "EX2", Username: "test", Amount: &amount})
+	c.ErrorIs(err, models.ErrExpensesNotFound)
+
+	_, err = repo.GetExpense(ctx, "test", "EX2")
+	c.ErrorIs(err, models.ErrExpenseNotFound)
+}
+
+func TestMemoryRepositoryRecurring(t *testing.T) {
+	c := require.New(t)
+
+	ctx := context.Background()
+	expensesRecurringRepo := er.NewMemoryRepository()
+	repo := NewMemoryRepository(expensesRecurringRepo)
+
+	recurringDay := 15
+
+	expense := newTestExpense("EX1", "period1", 100)
+	expense.IsRecurring = true
+	expense.RecurringDay = &recurringDay
+
+	_, err := repo.CreateExpense(ctx, expense)
+	c.NoError(err)
+
+	expenseRecurring, err := expensesRecurringRepo.GetExpenseRecurring(ctx, "rent", "test")
+	c.NoError(err)
+	c.Equal(15, expenseRecurring.RecurringDay)
+
+	expense.ExpenseID = "EX2"
+
+	_, err = repo.CreateExpense(ctx, expense)
+	c.ErrorIs(err, models.ErrRecurringExpenseNameTaken)
+
+	_, err = repo.GetExpense(ctx, "test", "EX2")
+	c.ErrorIs(err, models.ErrExpenseNotFound)
+}
+
+func newTestExpense(expenseID, periodID string, amount models.Money) *models.Expense {
+	name := "Rent"
+
+	return &models.Expense{
+		ExpenseID:   expenseID,
+		Username:    "test",
+		Amount:      &amount,
+		Name:        &name,
+		PeriodID:    periodID,
+		CreatedDate: time.Now(),
+	}
+}
+
+func getExpenseIDs(expenses []*models.Expense) []string {
+	ids := make([]string, 0, len(expenses))
+
+	for _, expense := range expenses {
+		ids = append(ids, expense.ExpenseID)
+	}
+
+	return ids
+}
@@ -0,0 +1,399 @@
+package expenses
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/JoelD7/money/backend/models"
+	"github.com/JoelD7/money/backend/storage/cursor"
+	er "github.com/JoelD7/money/backend/storage/expenses-recurring"
+	"github.com/JoelD7/money/backend/storage/sqldb"
+	"strings"
+	"time"
+)
+
+const expenseColumns = `username, expense_id, category_id, amount, currency, name, notes, created_date, update_date,
+	period_id, version`
+
+type SQLRepository struct {
+	db *sqldb.DB
+}
+
+func NewSQLRepository(db *sqldb.DB) *SQLRepository {
+	return &SQLRepository{db: db}
+}
+
+// CreateExpense creates the expense, and its recurring expense if the expense is recurring, in a single transaction.
+func (s *SQLRepository) CreateExpense(ctx context.Context, expense *models.Expense) (*models.Expense, error) {
+	entity := toExpenseEntity(expense)
+	entity.setInitialVersion()
+
+	err := s.db.WithTx(ctx, func(tx *sqldb.Tx) error {
+		_, err := tx.ExecContext(ctx, `INSERT INTO expenses (`+expenseColumns+`) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+			getExpenseArgs(entity)...)
+		if err != nil {
+			return fmt.Errorf("insert expense failed: %v", err)
+		}
+
+		if !expense.IsRecurring {
+			return nil
+		}
+
+		return er.InsertExpenseRecurring(ctx, tx, toExpenseRecurringEntity(expense))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return toExpenseModel(*entity), nil
+}
+
+func (s *SQLRepository) BatchCreateExpenses(ctx context.Context, expenses []*models.Expense) error {
+	entities := make([]*expenseEntity, 0, len(expenses))
+
+	for _, expense := range expenses {
+		entity := toExpenseEntity(expense)
+		entity.setInitialVersion()
+		entities = append(entities, entity)
+	}
+
+	return s.upsert(ctx, entities)
+}
+
+func (s *SQLRepository) upsert(ctx context.Context, entities []*expenseEntity) error {
+	return s.db.WithTx(ctx, func(tx *sqldb.Tx) error {
+		for _, entity := range entities {
+			_, err := tx.ExecContext(ctx, `INSERT INTO expenses (`+expenseColumns+`) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+				ON CONFLICT (username, expense_id) DO UPDATE SET category_id = excluded.category_id,
+				amount = excluded.amount, currency = excluded.currency, name = excluded.name, notes = excluded.notes,
+				created_date = excluded.created_date, update_date = excluded.update_date, period_id = excluded.period_id,
+				version = excluded.version`,
+				getExpenseArgs(entity)...)
+			if err != nil {
+				return fmt.Errorf("batch write expenses failed: %v", err)
+			}
+		}
+
+		return nil
+	})
+}
+
+func (s *SQLRepository) GetExpenses(ctx context.Context, username string, params *models.QueryParameters) ([]*models.Expense, string, error) {
+	return s.queryPage(ctx, username, params)
+}
+
+func (s *SQLRepository) GetExpensesByPeriod(ctx context.Context, username string, params *models.QueryParameters) ([]*models.Expense, string, error) {
+	return s.queryPage(ctx, username, params)
+}
+
+func (s *SQLRepository) GetExpensesByPeriodAndCategories(ctx context.Context, username string, params *models.QueryParameters) ([]*models.Expense, string, error) {
+	return s.queryPage(ctx, username, params)
+}
+
+func (s *SQLRepository) GetExpensesByCategory(ctx context.Context, username string, params *models.QueryParameters) ([]*models.Expense, string, error) {
+	return s.queryPage(ctx, username, params)
+}
+
+func (s *SQLRepository) queryPage(ctx context.Context, username string, params *models.QueryParameters) ([]*models.Expense, string, error) {
+	page := getSQLPage(username, params)
+
+	condition, args, err := page.Condition()
+	if err != nil {
+		return nil, "", err
+	}
+
+	filter, filterArgs := getSQLFilter(username, params)
+
+	entities, err := s.query(ctx, `SELECT `+expenseColumns+` FROM expenses WHERE `+filter+` AND `+condition+` `+
+		page.OrderBy(), append(filterArgs, args...)...)
+	if err != nil {
+		return nil, "", err
+	}
+
+	entities, nextKey, err := sqldb.Paginate(entities, page, getSQLSortKey(params), models.ErrExpensesNotFound)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return toExpenseModels(entities), nextKey, nil
+}
+
+// getSQLPage returns the pagination of the query with params, which sorts by the same attributes as the DynamoDB
+// indexes.
+func getSQLPage(username string, params *models.QueryParameters) *sqldb.Page {
+	page := &sqldb.Page{
+		SortColumn: "expense_id",
+		IDColumn:   "expense_id",
+		Descending: params.SortType == string(models.SortOrderDescending),
+		StartKey:   params.StartKey,
+		PageSize:   params.PageSize,
+		Scope:      cursor.NewScope(username, params),
+	}
+
+	switch params.SortBy {
+	case string(models.SortParamCreatedDate):
+		page.SortColumn = "created_date"
+	case string(models.SortParamAmount):
+		page.SortColumn = "amount"
+	case string(models.SortParamName):
+		page.SortColumn = "lower(name)"
+	}
+
+	return page
+}
+
+func getSQLSortKey(params *models.QueryParameters) func(e expenseEntity) (interface{}, string) {
+	switch params.SortBy {
+	case string(models.SortParamCreatedDate):
+		return func(e expenseEntity) (interface{}, string) {
+			return sqldb.FormatTime(e.CreatedDate), e.ExpenseID
+		}
+	case string(models.SortParamAmount):
+		return func(e expenseEntity) (interface{}, string) {
+			return int64(e.Amount), e.ExpenseID
+		}
+	case string(models.SortParamName):
+		return func(e expenseEntity) (interface{}, string) {
+			return strings.ToLower(e.Name), e.ExpenseID
+		}
+	default:
+		return func(e expenseEntity) (interface{}, string) {
+			return e.ExpenseID, e.ExpenseID
+		}
+	}
+}
+
+// getSQLFilter returns the WHERE condition of the expenses of the user that match the period and categories of params.
+// As in the DynamoDB repository, an empty first category matches the expenses without a category.
+func getSQLFilter(username string, params *models.QueryParameters) (string, []interface{}) {
+	conditions := []string{"username = ?"}
+	args := []interface{}{username}
+
+	if params.Period != "" {
+		conditions = append(conditions, "period_id = ?")
+		args = append(args, params.Period)
+	}
+
+	switch {
+	case len(params.Categories) > 0 && params.Categories[0] == "":
+		conditions = append(conditions, "category_id IS NULL")
+	case len(params.Categories) > 0:
+		conditions = append(conditions, "category_id IN "+sqldb.In(len(params.Categories)))
+
+		for _, categoryID := range params.Categories {
+			args = append(args, categoryID)
+		}
+	}
+
+	filterConditions, filterArgs := sqldb.FilterConditions(params, "amount", "created_date", "name", "notes")
+	conditions = append(conditions, filterConditions...)
+	args = append(args, filterArgs...)
+
+	return strings.Join(conditions, " AND "), args
+}
+
+func (s *SQLRepository) GetExpense(ctx context.Context, username, expenseID string) (*models.Expense, error) {
+	row := s.db.QueryRowContext(ctx, `SELECT `+expenseColumns+` FROM expenses WHERE username = ? AND expense_id = ?`,
+		username, expenseID)
+
+	entity, err := scanExpense(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, models.ErrExpenseNotFound
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return toExpenseModel(*entity), nil
+}
+
+func (s *SQLRepository) GetAllExpensesBetweenDates(ctx context.Context, username, startDate, endDate string) ([]*models.Expense, error) {
+	entities, err := s.query(ctx, `SELECT `+expenseColumns+` FROM expenses
+		WHERE username = ? AND created_date BETWEEN ? AND ? ORDER BY created_date, expense_id`, username, startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(entities) == 0 {
+		return nil, models.ErrExpensesNotFound
+	}
+
+	return toExpenseModels(entities), nil
+}
+
+func (s *SQLRepository) GetAllExpensesByPeriod(ctx context.Context, username string, params *models.QueryParameters) ([]*models.Expense, error) {
+	entities, err := s.query(ctx, `SELECT `+expenseColumns+` FROM expenses WHERE username = ? AND period_id = ?
+		ORDER BY expense_id`, username, params.Period)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(entities) == 0 {
+		return nil, models.ErrExpensesNotFound
+	}
+
+	return toExpenseModels(entities), nil
+}
+
+// UpdateExpense sets the attributes of the expense that aren't empty, as the DynamoDB repository does.
+func (s *SQLRepository) UpdateExpense(ctx context.Context, expense *models.Expense) error {
+	entity := toExpenseEntity(expense)
+
+	assignments := []string{"update_date = ?", "version = ?"}
+	args := []interface{}{sqldb.FormatTime(time.Now()), expense.Version + 1}
+
+	if entity.CategoryID != nil {
+		assignments = append(assignments, "category_id = ?")
+		args = append(args, *entity.CategoryID)
+	}
+
+	if entity.Amount != 0 {
+		assignments = append(assignments, "amount = ?")
+		args = append(args, entity.Amount)
+	}
+
+	if entity.Currency != "" {
+		assignments = append(assignments, "currency = ?")
+		args = append(args, entity.Currency)
+	}
+
+	if entity.Name != "" {
+		assignments = append(assignments, "name = ?")
+		args = append(args, entity.Name)
+	}
+
+	if entity.Notes != "" {
+		assignments = append(assignments, "notes = ?")
+		args = append(args, entity.Notes)
+	}
+
+	if entity.PeriodID != "" {
+		assignments = append(assignments, "period_id = ?")
+		args = append(args, entity.PeriodID)
+	}
+
+	result, err := s.db.ExecContext(ctx, `UPDATE expenses SET `+strings.Join(assignments, ", ")+`
+		WHERE username = ? AND expense_id = ? AND version = ?`, append(args, entity.Username, entity.ExpenseID, expense.Version)...)
+	if err != nil {
+		return fmt.Errorf("update expense failed: %v", err)
+	}
+
+	return s.db.CheckVersionedUpdate(ctx, result, models.ErrExpensesNotFound,
+		`SELECT 1 FROM expenses WHERE username = ? AND expense_id = ?`, entity.Username, entity.ExpenseID)
+}
+
+// ReplaceExpense replaces the stored expense with the given one if the stored expense is at its version, clearing the
+// attributes that it doesn't set.
+func (s *SQLRepository) ReplaceExpense(ctx context.Context, expense *models.Expense) error {
+	entity := toExpenseEntity(expense)
+	entity.UpdateDate = time.Now()
+	entity.Version = expense.Version + 1
+
+	args := getExpenseArgs(entity)
+
+	result, err := s.db.ExecContext(ctx, `UPDATE expenses SET category_id = ?, amount = ?, currency = ?, name = ?,
+		notes = ?, created_date = ?, update_date = ?, period_id = ?, version = ?
+		WHERE username = ? AND expense_id = ? AND version = ?`, append(args[2:], entity.Username, entity.ExpenseID, expense.Version)...)
+	if err != nil {
+		return fmt.Errorf("replace expense failed: %v", err)
+	}
+
+	return s.db.CheckVersionedUpdate(ctx, result, models.ErrExpensesNotFound,
+		`SELECT 1 FROM expenses WHERE username = ? AND expense_id = ?`, entity.Username, entity.ExpenseID)
+}
+
+// BatchUpdateExpenses replaces the expenses, incrementing their version without checking it, like the DynamoDB
+// repository does.
+func (s *SQLRepository) BatchUpdateExpenses(ctx context.Context, expenses []*models.Expense) error {
+	entities := make([]*expenseEntity, 0, len(expenses))
+
+	for _, expense := range expenses {
+		entity := toExpenseEntity(expense)
+		entity.Version = expense.Version + 1
+		entities = append(entities, entity)
+	}
+
+	return s.upsert(ctx, entities)
+}
+
+// DeleteExpense deletes the expense if it's at version.
+func (s *SQLRepository) DeleteExpense(ctx context.Context, expenseID, username string, version int64) error {
+	result, err := s.db.ExecContext(ctx, `DELETE FROM expenses WHERE username = ? AND expense_id = ? AND version = ?`,
+		username, expenseID, version)
+	if err != nil {
+		return fmt.Errorf("delete expense failed: %v", err)
+	}
+
+	return s.db.CheckVersionedUpdate(ctx, result, models.ErrExpensesNotFound,
+		`SELECT 1 FROM expenses WHERE username = ? AND expense_id = ?`, username, expenseID)
+}
+
+func (s *SQLRepository) BatchDeleteExpenses(ctx context.Context, expenses []*models.Expense) error {
+	return s.db.WithTx(ctx, func(tx *sqldb.Tx) error {
+		for _, expense := range expenses {
+			_, err := tx.ExecContext(ctx, `DELETE FROM expenses WHERE username = ? AND expense_id = ?`,
+				expense.Username, expense.ExpenseID)
+			if err != nil {
+				return fmt.Errorf("batch delete expenses failed: %v", err)
+			}
+		}
+
+		return nil
+	})
+}
+
+func (s *SQLRepository) query(ctx context.Context, query string, args ...interface{}) ([]expenseEntity, error) {
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("query expenses failed: %v", err)
+	}
+
+	defer rows.Close()
+
+	entities := make([]expenseEntity, 0)
+
+	for rows.Next() {
+		entity, err := scanExpense(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		entities = append(entities, *entity)
+	}
+
+	return entities, rows.Err()
+}
+
+func getExpenseArgs(e *expenseEntity) []interface{} {
+	return []interface{}{e.Username, e.ExpenseID, sqldb.NullString(e.CategoryID), e.Amount, e.Currency, e.Name, e.Notes,
+		sqldb.FormatTime(e.CreatedDate), sqldb.FormatTime(e.UpdateDate), e.PeriodID, e.Version}
+}
+
+func scanExpense(row sqldb.Scanner) (*expenseEntity, error) {
+	entity := new(expenseEntity)
+
+	var categoryID sql.NullString
+	var createdDate, updateDate string
+
+	err := row.Scan(&entity.Username, &entity.ExpenseID, &categoryID, &entity.Amount, &entity.Currency, &entity.Name,
+		&entity.Notes, &createdDate, &updateDate, &entity.PeriodID, &entity.Version)
+	if err != nil {
+		return nil, err
+	}
+
+	entity.CategoryID = sqldb.StringPtr(categoryID)
+
+	entity.CreatedDate, err = sqldb.ParseTime(createdDate)
+	if err != nil {
+		return nil, err
+	}
+
+	entity.UpdateDate, err = sqldb.ParseTime(updateDate)
+	if err != nil {
+		return nil, err
+	}
+
+	return entity, nil
+}
@@ -0,0 +1,223 @@
+package expenses
+
+import (
+	"context"
+	"fmt"
+	"github.com/JoelD7/money/backend/models"
+	er "github.com/JoelD7/money/backend/storage/expenses-recurring"
+	"github.com/JoelD7/money/backend/storage/sqldb"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestSQLRepositoryPagination(t *testing.T) {
+	t.Setenv("PAGINATION_KEY_SECRET", "pagination-secret")
+
+	c := require.New(t)
+
+	ctx := context.Background()
+	repo := NewSQLRepository(newTestDB(c))
+
+	for i := 1; i <= 5; i++ {
+		_, err := repo.CreateExpense(ctx, newTestExpense(fmt.Sprintf("EX%d", i), "period1", models.Money(6-i)*100))
+		c.NoError(err)
+	}
+
+	_, err := repo.CreateExpense(ctx, newTestExpense("EX6", "period2", 100))
+	c.NoError(err)
+
+	params := &models.QueryParameters{Period: "period1", SortBy: string(models.SortParamAmount), PageSize: 3}
+
+	expenses, nextKey, err := repo.GetExpensesByPeriod(ctx, "test", params)
+	c.NoError(err)
+	c.NotEmpty(nextKey)
+	c.Equal([]string{"EX5", "EX4", "EX3"}, getExpenseIDs(expenses))
+
+	// The next page is right even if the last item of the previous page is deleted.
+	c.ErrorIs(repo.DeleteExpense(ctx, "EX3", "test", 2), models.ErrVersionConflict)
+	c.NoError(repo.DeleteExpense(ctx, "EX3", "test", 1))
+	c.ErrorIs(repo.DeleteExpense(ctx, "EX3", "test", 1), models.ErrExpensesNotFound)
+
+	params.StartKey = nextKey
+
+	expenses, nextKey, err = repo.GetExpensesByPeriod(ctx, "test", params)
+	c.NoError(err)
+	c.Empty(nextKey)
+	c.Equal([]string{"EX2", "EX1"}, getExpenseIDs(expenses))
+
+	params.SortType = string(models.SortOrderDescending)
+	params.StartKey = ""
+
+	expenses, _, err = repo.GetExpensesByPeriod(ctx, "test", params)
+	c.NoError(err)
+	c.Equal([]string{"EX1", "EX2", "EX4"}, getExpenseIDs(expenses))
+
+	_, _, err = repo.GetExpensesByPeriod(ctx, "test", &models.QueryParameters{Period: "period3"})
+	c.ErrorIs(err, models.ErrExpensesNotFound)
+
+	_, _, err = repo.GetExpenses(ctx, "test", &models.QueryParameters{StartKey: "%invalid%"})
+	c.ErrorIs(err, models.ErrInvalidStartKey)
+}
+
+func TestSQLRepositoryCategories(t *testing.T) {
+	c := require.New(t)
+
+	ctx := context.Background()
+	repo := NewSQLRepository(newTestDB(c))
+
+	food := "food"
+	rent := "rent"
+
+	withFood := newTestExpense("EX1", "period1", 100)
+	withFood.CategoryID = &food
+
+	withRent := newTestExpense("EX2", "period1", 200)
+	withRent.CategoryID = &rent
+
+	c.NoError(repo.BatchCreateExpenses(ctx, []*models.Expense{withFood, withRent, newTestExpense("EX3", "period1", 300)}))
+
+	expenses, _, err := repo.GetExpensesByPeriodAndCategories(ctx, "test", &models.QueryParameters{Period: "period1", Categories: []string{food, rent}})
+	c.NoError(err)
+	c.Equal([]string{"EX1", "EX2"}, getExpenseIDs(expenses))
+
+	expenses, _, err = repo.GetExpensesByCategory(ctx, "test", &models.QueryParameters{Categories: []string{""}})
+	c.NoError(err)
+	c.Equal([]string{"EX3"}, getExpenseIDs(expenses))
+}
+
+func TestSQLRepositoryFilters(t *testing.T) {
+	t.Setenv("PAGINATION_KEY_SECRET", "pagination-secret")
+
+	c := require.New(t)
+
+	testRepositoryFilters(c, NewSQLRepository(newTestDB(c)))
+}
+
+func TestSQLRepositoryUpdate(t *testing.T) {
+	c := require.New(t)
+
+	ctx := context.Background()
+	repo := NewSQLRepository(newTestDB(c))
+
+	expense := newTestExpense("EX1", "period1", 100)
+	expense.Notes = "notes"
+
+	_, err := repo.CreateExpense(ctx, expense)
+	c.NoError(err)
+
+	amount := models.Money(200)
+	err = repo.UpdateExpense(ctx, &models.Expense{ExpenseID: "EX1", Username: "test", Amount: &amount, Version: 1})
+	c.NoError(err)
+
+	stored, err := repo.GetExpense(ctx, "test", "EX1")
+	c.NoError(err)
+	c.Equal(int64(2), stored.Version)
+	c.Equal(models.Money(200), stored.GetAmount())
+	c.Equal("notes", stored.Notes)
+	c.Equal("period1", stored.PeriodID)
+	c.True(stored.CreatedDate.Equal(expense.CreatedDate))
+	c.False(stored.UpdateDate.IsZero())
+
+	err = repo.UpdateExpense(ctx, &models.Expense{ExpenseID: "EX1", Username: "test", Amount: &amount, Version: 1})
+	c.ErrorIs(err, models.ErrVersionConflict)
+
+	err = repo.UpdateExpense(ctx, &models.Expense{ExpenseID: "EX2", Username: "test", Amount: &amount})
+	c.ErrorIs(err, models.ErrExpensesNotFound)
+
+	_, err = repo.GetExpense(ctx, "test", "EX2")
+	c.ErrorIs(err, models.ErrExpenseNotFound)
+}
+
+func TestSQLRepositoryReplace(t *testing.T) {
+	c := require.New(t)
+
+	ctx := context.Background()
+	repo := NewSQLRepository(newTestDB(c))
+
+	expense := newTestExpense("EX1", "period1", 100)
+	expense.Notes = "notes"
+
+	_, err := repo.CreateExpense(ctx, expense)
+	c.NoError(err)
+
+	// Unlike an update, a replacement clears the fields that aren't set.
+	replacement := newTestExpense("EX1", "period2", 300)
+	replacement.CreatedDate = expense.CreatedDate
+	replacement.Version = 1
+
+	c.NoError(repo.ReplaceExpense(ctx, replacement))
+
+	stored, err := repo.GetExpense(ctx, "test", "EX1")
+	c.NoError(err)
+	c.Equal(int64(2), stored.Version)
+	c.Equal(models.Money(300), stored.GetAmount())
+	c.Equal("period2", stored.PeriodID)
+	c.Empty(stored.Notes)
+
+	c.ErrorIs(repo.ReplaceExpense(ctx, replacement), models.ErrVersionConflict)
+
+	c.ErrorIs(repo.ReplaceExpense(ctx, newTestExpense("EX2", "period1", 100)), models.ErrExpensesNotFound)
+}
+
+func TestSQLRepositoryRecurring(t *testing.T) {
+	c := require.New(t)
+
+	ctx := context.Background()
+	db := newTestDB(c)
+	expensesRecurringRepo := er.NewSQLRepository(db)
+	repo := NewSQLRepository(db)
+
+	recurringDay := 15
+
+	expense := newTestExpense("EX1", "period1", 100)
+	expense.IsRecurring = true
+	expense.RecurringDay = &recurringDay
+
+	_, err := repo.CreateExpense(ctx, expense)
+	c.NoError(err)
+
+	expenseRecurring, err := expensesRecurringRepo.GetExpenseRecurring(ctx, "rent", "test")
+	c.NoError(err)
+	c.Equal(15, expenseRecurring.RecurringDay)
+
+	expense.ExpenseID = "EX2"
+
+	_, err = repo.CreateExpense(ctx, expense)
+	c.ErrorIs(err, models.ErrRecurringExpenseNameTaken)
+
+	// The expense isn't created if its recurring expense can't be.
+	_, err = repo.GetExpense(ctx, "test", "EX2")
+	c.ErrorIs(err, models.ErrExpenseNotFound)
+}
+
+func TestSQLRepositoryBetweenDates(t *testing.T) {
+	c := require.New(t)
+
+	ctx := context.Background()
+	repo := NewSQLRepository(newTestDB(c))
+
+	first := newTestExpense("EX1", "period1", 100)
+	first.CreatedDate = time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+
+	second := newTestExpense("EX2", "period1", 100)
+	second.CreatedDate = time.Date(2024, 3, 20, 10, 0, 0, 0, time.UTC)
+
+	c.NoError(repo.BatchCreateExpenses(ctx, []*models.Expense{first, second}))
+
+	expenses, err := repo.GetAllExpensesBetweenDates(ctx, "test", "2024-03-01", "2024-03-15")
+	c.NoError(err)
+	c.Equal([]string{"EX1"}, getExpenseIDs(expenses))
+
+	_, err = repo.GetAllExpensesBetweenDates(ctx, "test", "2024-04-01", "2024-04-30")
+	c.ErrorIs(err, models.ErrExpensesNotFound)
+}
+
+func newTestDB(c *require.Assertions) *sqldb.DB {
+	db, err := sqldb.Open(sqldb.DriverSQLite, ":memory:")
+	c.NoError(err)
+
+	c.NoError(db.Migrate(context.Background()))
+
+	return db
+}
@@ -0,0 +1,119 @@
+package income
+
+import (
+	"context"
+	"github.com/JoelD7/money/backend/models"
+	"github.com/JoelD7/money/backend/storage/audit"
+)
+
+// auditedRepository records the writes made to the income in the audit log.
+type auditedRepository struct {
+	Repository
+	recorder *audit.Recorder
+}
+
+func newAuditedRepository(repo Repository, recorder *audit.Recorder) Repository {
+	if recorder == nil {
+		return repo
+	}
+
+	return &auditedRepository{Repository: repo, recorder: recorder}
+}
+
+func newChange(username, incomeID string, old, new *models.Income) *audit.Change {
+	change := &audit.Change{Username: username, EntityType: models.AuditEntityIncome, EntityID: incomeID}
+
+	if old != nil {
+		change.Old = old
+	}
+
+	if new != nil {
+		change.New = new
+	}
+
+	return change
+}
+
+func (a *auditedRepository) CreateIncome(ctx context.Context, income *models.Income) (*models.Income, error) {
+	created, err := a.Repository.CreateIncome(ctx, income)
+	if err != nil {
+		return nil, err
+	}
+
+	a.recorder.Record(ctx, newChange(created.Username, created.IncomeID, nil, created))
+
+	return created, nil
+}
+
+func (a *auditedRepository) BatchCreateIncome(ctx context.Context, incomes []*models.Income) error {
+	err := a.Repository.BatchCreateIncome(ctx, incomes)
+	if err != nil {
+		return err
+	}
+
+	changes := make([]*audit.Change, 0, len(incomes))
+
+	for _, income := range incomes {
+		changes = append(changes, newChange(income.Username, income.IncomeID, nil, income))
+	}
+
+	a.recorder.Record(ctx, changes...)
+
+	return nil
+}
+
+// UpdateIncome records the income as it's stored before and after the update, as only the fields that are set are
+// updated.
+func (a *auditedRepository) UpdateIncome(ctx context.Context, income *models.Income) error {
+	old, err := a.Repository.GetIncome(ctx, income.Username, income.IncomeID)
+	if err != nil {
+		return a.Repository.UpdateIncome(ctx, income)
+	}
+
+	err = a.Repository.UpdateIncome(ctx, income)
+	if err != nil {
+		return err
+	}
+
+	updated, err := a.Repository.GetIncome(ctx, income.Username, income.IncomeID)
+	if err != nil {
+		updated = income
+	}
+
+	a.recorder.Record(ctx, newChange(income.Username, income.IncomeID, old, updated))
+
+	return nil
+}
+
+func (a *auditedRepository) DeleteIncome(ctx context.Context, incomeID, username string) error {
+	old, err := a.Repository.GetIncome(ctx, username, incomeID)
+	if err != nil {
+		return a.Repository.DeleteIncome(ctx, incomeID, username)
+	}
+
+	err = a.Repository.DeleteIncome(ctx, incomeID, username)
+	if err != nil {
+		return err
+	}
+
+	a.recorder.Record(ctx, newChange(username, incomeID, old, nil))
+
+	return nil
+}
+
+func (a *auditedRepository) BatchDeleteIncome(ctx context.Context, incomes []*models.Income) error {
+	err := a.Repository.BatchDeleteIncome(ctx, incomes)
+	if err != nil {
+		return err
+	}
+
+	changes := make([]*audit.Change, 0, len(incomes))
+
+	for _, income := range incomes {
+		changes = append(changes, newChange(income.Username, income.IncomeID, income, nil))
+	}
+
+	a.recorder.Record(ctx, changes...)
+
+	return nil
+}
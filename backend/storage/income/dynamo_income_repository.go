@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"github.com/JoelD7/money/backend/models"
+	"github.com/JoelD7/money/backend/storage/cursor"
 	"github.com/JoelD7/money/backend/storage/dynamo"
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
@@ -11,6 +12,7 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
 	"strings"
+	"time"
 )
 
 const (
@@ -26,6 +28,8 @@ type DynamoRepository struct {
 	usernameCreatedDateIndex    string
 	periodUserNameIncomeIDIndex string
 	periodUserAmountIndex       string
+	// keysByIndex holds the key attributes of each index, used to build the LastEvaluatedKey of a page.
+	keysByIndex map[string][]string
 }
 
 func NewDynamoRepository(dynamoClient *dynamodb.Client, envConfig *models.EnvironmentConfiguration) (*DynamoRepository, error) {
@@ -43,6 +47,16 @@ func NewDynamoRepository(dynamoClient *dynamodb.Client, envConfig *models.Enviro
 	d.periodUserNameIncomeIDIndex = envConfig.PeriodUserNameIncomeIDIndex
 	d.periodUserAmountIndex = envConfig.PeriodUserAmountIndex
 
+	//All indices must include "income_id" and "username" because they are the main table's primary key.
+	d.keysByIndex = map[string][]string{
+		d.periodUserIncomeIndex:       {"income_id", "username", "period_user"},
+		d.periodUserCreatedDateIndex:  {"income_id", "username", "period_user", "created_date"},
+		d.usernameCreatedDateIndex:    {"income_id", "username", "created_date"},
+		d.periodUserAmountIndex:       {"income_id", "username", "period_user", "amount_key"},
+		d.periodUserNameIncomeIDIndex: {"income_id", "username", "period_user", "name_income_id"},
+		"":                            {"income_id", "username"},
+	}
+
 	return d, nil
 }
 
@@ -165,14 +179,14 @@ func (d *DynamoRepository) GetIncome(ctx context.Context, username, incomeID str
 		return nil, models.ErrIncomeNotFound
 	}
 
-	incomeEnt := incomeEntity{}
+	incomeEnt := new(incomeEntity)
 
 	err = attributevalue.UnmarshalMap(result.Item, incomeEnt)
 	if err != nil {
 		return nil, err
 	}
 
-	return toIncomeModel(incomeEnt), nil
+	return toIncomeModel(*incomeEnt), nil
 }
 
 func (d *DynamoRepository) GetIncomeByPeriod(ctx context.Context, username string, params *models.QueryParameters) ([]*models.Income, string, error) {
@@ -181,6 +195,25 @@ func (d *DynamoRepository) GetIncomeByPeriod(ctx context.Context, username strin
 		return nil, "", err
 	}
 
+	return d.performQuery(ctx, input, cursor.NewScope(username, params), params)
+}
+
+func (d *DynamoRepository) GetAllIncome(ctx context.Context, username string, params *models.QueryParameters) ([]*models.Income, string, error) {
+	input, err := d.buildQueryInput(username, params, nil)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return d.performQuery(ctx, input, cursor.NewScope(username, params), params)
+}
+
+func (d *DynamoRepository) performQuery(ctx context.Context, input *dynamodb.QueryInput, scope cursor.Scope, params *models.QueryParameters) ([]*models.Income, string, error) {
+	// If the query has a filter expression it may not include all the items one intends to fetch.
+	// See more details here: https://docs.aws.amazon.com/amazondynamodb/latest/developerguide/Query.FilterExpression.html
+	if input.FilterExpression != nil || params.Search != "" {
+		return d.performQueryWithFilter(ctx, input, scope, params)
+	}
+
 	result, err := d.dynamoClient.Query(ctx, input)
 	if err != nil {
 		return nil, "", err
@@ -201,7 +234,7 @@ func (d *DynamoRepository) GetIncomeByPeriod(ctx context.Context, username strin
 		return nil, "", err
 	}
 
-	nextKey, err := dynamo.EncodePaginationKey(result.LastEvaluatedKey)
+	nextKey, err := dynamo.EncodePaginationKey(result.LastEvaluatedKey, scope)
 	if err != nil {
 		return nil, "", err
 	}
@@ -209,33 +242,63 @@ func (d *DynamoRepository) GetIncomeByPeriod(ctx context.Context, username strin
 	return toIncomeModels(incomeEntities), nextKey, nil
 }
 
-func (d *DynamoRepository) GetAllIncome(ctx context.Context, username string, params *models.QueryParameters) ([]*models.Income, string, error) {
-	input, err := d.buildQueryInput(username, params, nil)
-	if err != nil {
-		return nil, "", err
-	}
+// performQueryWithFilter queries until the page is full or there are no more items, so that filtered pages have as
+// many items as requested.
+func (d *DynamoRepository) performQueryWithFilter(ctx context.Context, input *dynamodb.QueryInput, scope cursor.Scope, params *models.QueryParameters) ([]*models.Income, string, error) {
+	limit := int(*input.Limit)
+	acumItems := make([]map[string]types.AttributeValue, 0, limit)
 
-	result, err := d.dynamoClient.Query(ctx, input)
-	if err != nil {
-		return nil, "", err
+	for {
+		result, err := d.dynamoClient.Query(ctx, input)
+		if err != nil {
+			return nil, "", fmt.Errorf("query failed: %v", err)
+		}
+
+		input.ExclusiveStartKey = result.LastEvaluatedKey
+
+		itemsInQuery := make([]incomeEntity, 0, len(result.Items))
+
+		err = attributevalue.UnmarshalListOfMaps(result.Items, &itemsInQuery)
+		if err != nil {
+			return nil, "", fmt.Errorf("unmarshal income items failed: %v", err)
+		}
+
+		items := filterBySearch(result.Items, itemsInQuery, params)
+
+		if len(acumItems)+len(items) >= limit {
+			acumItems = append(acumItems, items[:limit-len(acumItems)]...)
+
+			input.ExclusiveStartKey, err = d.buildCustomExclusiveStartKey(acumItems[len(acumItems)-1], input)
+			if err != nil {
+				return nil, "", fmt.Errorf("getting income failed: %v", err)
+			}
+
+			break
+		}
+
+		acumItems = append(acumItems, items...)
+
+		if result.LastEvaluatedKey == nil {
+			break
+		}
 	}
 
-	if result.Items == nil || len(result.Items) == 0 && params.StartKey == "" {
+	if len(acumItems) == 0 && params.StartKey == "" {
 		return nil, "", models.ErrIncomeNotFound
 	}
 
-	if result.Items == nil || len(result.Items) == 0 {
+	if len(acumItems) == 0 {
 		return nil, "", models.ErrNoMoreItemsToBeRetrieved
 	}
 
-	incomeEntities := make([]incomeEntity, 0, len(result.Items))
+	incomeEntities := make([]incomeEntity, 0, len(acumItems))
 
-	err = attributevalue.UnmarshalListOfMaps(result.Items, &incomeEntities)
+	err := attributevalue.UnmarshalListOfMaps(acumItems, &incomeEntities)
 	if err != nil {
-		return nil, "", err
+		return nil, "", fmt.Errorf("unmarshal income items failed: %v", err)
 	}
 
-	nextKey, err := dynamo.EncodePaginationKey(result.LastEvaluatedKey)
+	nextKey, err := dynamo.EncodePaginationKey(input.ExclusiveStartKey, scope)
 	if err != nil {
 		return nil, "", err
 	}
@@ -243,6 +306,51 @@ func (d *DynamoRepository) GetAllIncome(ctx context.Context, username string, pa
 	return toIncomeModels(incomeEntities), nextKey, nil
 }
 
+// filterBySearch returns the items whose name or notes contain the search text. entities must be the unmarshalled items.
+func filterBySearch(items []map[string]types.AttributeValue, entities []incomeEntity, params *models.QueryParameters) []map[string]types.AttributeValue {
+	if params.Search == "" {
+		return items
+	}
+
+	filtered := make([]map[string]types.AttributeValue, 0, len(items))
+
+	for i, entity := range entities {
+		if params.MatchesSearch(getString(entity.Name), getString(entity.Notes)) {
+			filtered = append(filtered, items[i])
+		}
+	}
+
+	return filtered
+}
+
+func (d *DynamoRepository) buildCustomExclusiveStartKey(lastItem map[string]types.AttributeValue, input *dynamodb.QueryInput) (map[string]types.AttributeValue, error) {
+	indexName := ""
+	if input.IndexName != nil {
+		indexName = *input.IndexName
+	}
+
+	keys, ok := d.keysByIndex[indexName]
+	if !ok {
+		return nil, models.ErrIndexKeysNotFound
+	}
+
+	exclusiveStartKey := make(map[string]types.AttributeValue, len(keys))
+
+	for _, key := range keys {
+		exclusiveStartKey[key] = lastItem[key]
+	}
+
+	return exclusiveStartKey, nil
+}
+
+func getString(s *string) string {
+	if s == nil {
+		return ""
+	}
+
+	return *s
+}
+
 func (d *DynamoRepository) GetAllIncomeByPeriod(ctx context.Context, username string, params *models.QueryParameters) ([]*models.Income, error) {
 	input, err := d.buildQueryInput(username, params, nil)
 	if err != nil {
@@ -355,7 +463,7 @@ func (d *DynamoRepository) buildQueryInput(username string, params *models.Query
 		input.ScanIndexForward = aws.Bool(false)
 	}
 
-	err := dynamo.SetExclusiveStartKey(params.StartKey, input)
+	err := dynamo.SetExclusiveStartKey(params.StartKey, cursor.NewScope(username, params), input)
 	if err != nil {
 		return nil, err
 	}
@@ -363,6 +471,16 @@ func (d *DynamoRepository) buildQueryInput(username string, params *models.Query
 	keyCondition := d.setQueryIndex(input, username, params)
 	conditionBuilder := expression.NewBuilder().WithKeyCondition(keyCondition)
 
+	filterConditions := buildFilterConditions(params)
+
+	switch len(filterConditions) {
+	case 0:
+	case 1:
+		conditionBuilder = conditionBuilder.WithFilter(filterConditions[0])
+	default:
+		conditionBuilder = conditionBuilder.WithFilter(expression.And(filterConditions[0], filterConditions[1], filterConditions[2:]...))
+	}
+
 	if projection != nil {
 		conditionBuilder.WithProjection(*projection)
 	}
@@ -376,10 +494,36 @@ func (d *DynamoRepository) buildQueryInput(username string, params *models.Query
 	input.ExpressionAttributeValues = expr.Values()
 	input.KeyConditionExpression = expr.KeyCondition()
 	input.ProjectionExpression = expr.Projection()
+	input.FilterExpression = expr.Filter()
 
 	return input, nil
 }
 
+// buildFilterConditions returns the conditions of the amount and date filters. The text search isn't included because
+// DynamoDB can't match it regardless of case, so it's applied to the query results instead.
+func buildFilterConditions(params *models.QueryParameters) []expression.ConditionBuilder {
+	conditions := make([]expression.ConditionBuilder, 0)
+
+	if params.MinAmount != nil {
+		conditions = append(conditions, expression.Name("amount").GreaterThanEqual(expression.Value(*params.MinAmount)))
+	}
+
+	if params.MaxAmount != nil {
+		conditions = append(conditions, expression.Name("amount").LessThanEqual(expression.Value(*params.MaxAmount)))
+	}
+
+	// Dates are stored in UTC in the RFC 3339 format, so they can be compared as strings once the bounds are in UTC.
+	if params.FromDate != nil {
+		conditions = append(conditions, expression.Name("created_date").GreaterThanEqual(expression.Value(params.FromDate.UTC().Format(time.RFC3339Nano))))
+	}
+
+	if params.ToDate != nil {
+		conditions = append(conditions, expression.Name("created_date").LessThanEqual(expression.Value(params.ToDate.UTC().Format(time.RFC3339Nano))))
+	}
+
+	return conditions
+}
+
 // setQueryIndex sets the index to be used in the query based on the sorting and filter parameters. Returns a key
 // condition expression formed with the index's primary key.
 func (d *DynamoRepository) setQueryIndex(input *dynamodb.QueryInput, username string, params *models.QueryParameters) expression.KeyConditionBuilder {
@@ -411,6 +555,60 @@ func (d *DynamoRepository) setQueryIndex(input *dynamodb.QueryInput, username st
 	return keyConditionEx
 }
 
+// UpdateIncome replaces the stored income item with the given income. The whole item is written so that the sort keys
+// derived from the amount, name and period are kept in sync with the new values.
+func (d *DynamoRepository) UpdateIncome(ctx context.Context, income *models.Income) error {
+	incomeEnt := toIncomeEntity(income)
+
+	if income.PeriodID != nil {
+		incomeEnt.PeriodUser = dynamo.BuildPeriodUser(income.Username, *income.PeriodID)
+	}
+
+	incomeAv, err := attributevalue.MarshalMap(incomeEnt)
+	if err != nil {
+		return fmt.Errorf("marshal income attribute value failed: %v", err)
+	}
+
+	input := &dynamodb.PutItemInput{
+		TableName:           aws.String(d.tableName),
+		Item:                incomeAv,
+		ConditionExpression: aws.String("attribute_exists(income_id)"),
+	}
+
+	_, err = d.dynamoClient.PutItem(ctx, input)
+	if err != nil && strings.Contains(err.Error(), conditionFailedEx) {
+		return fmt.Errorf("%v: %w", err, models.ErrUpdateIncomeNotFound)
+	}
+
+	if err != nil {
+		return fmt.Errorf("update income failed: %v", err)
+	}
+
+	return nil
+}
+
+func (d *DynamoRepository) DeleteIncome(ctx context.Context, incomeID, username string) error {
+	input := &dynamodb.DeleteItemInput{
+		TableName: aws.String(d.tableName),
+		Key: map[string]types.AttributeValue{
+			"income_id": &types.AttributeValueMemberS{Value: incomeID},
+			"username":  &types.AttributeValueMemberS{Value: username},
+		},
+		ConditionExpression: aws.String("attribute_exists(income_id)"),
+	}
+
+	_, err := d.dynamoClient.DeleteItem(ctx, input)
+	if err != nil && strings.Contains(err.Error(), conditionFailedEx) {
+		return fmt.Errorf("%v: %w", err, models.ErrDeleteIncomeNotFound)
+	}
+
+	if err != nil {
+		return fmt.Errorf("delete income failed: %v", err)
+	}
+
+	return nil
+}
+
 func (d *DynamoRepository) BatchDeleteIncome(ctx context.Context, income []*models.Income) error {
 	writeRequests := make([]types.WriteRequest, 0, len(income))
 
@@ -7,15 +7,16 @@ import (
 )
 
 type incomeEntity struct {
-	Username    string    `json:"username,omitempty" dynamodbav:"username"`
-	IncomeID    string    `json:"income_id,omitempty" dynamodbav:"income_id"`
-	Amount      *float64  `json:"amount" dynamodbav:"amount"`
-	Name        *string   `json:"name,omitempty" dynamodbav:"name"`
-	Notes       *string   `json:"notes,omitempty" dynamodbav:"notes"`
-	CreatedDate time.Time `json:"created_date,omitempty" dynamodbav:"created_date"`
-	UpdatedDate time.Time `json:"updated_date,omitempty" dynamodbav:"updated_date"`
-	PeriodID    *string   `json:"period_id,omitempty" dynamodbav:"period_id"`
-	PeriodUser  *string   `json:"period_user,omitempty" dynamodbav:"period_user"`
+	Username    string        `json:"username,omitempty" dynamodbav:"username"`
+	IncomeID    string        `json:"income_id,omitempty" dynamodbav:"income_id"`
+	Amount      *models.Money `json:"amount" dynamodbav:"amount"`
+	Currency    string        `json:"currency,omitempty" dynamodbav:"currency,omitempty"`
+	Name        *string       `json:"name,omitempty" dynamodbav:"name"`
+	Notes       *string       `json:"notes,omitempty" dynamodbav:"notes"`
+	CreatedDate time.Time     `json:"created_date,omitempty" dynamodbav:"created_date"`
+	UpdatedDate time.Time     `json:"updated_date,omitempty" dynamodbav:"updated_date"`
+	PeriodID    *string       `json:"period_id,omitempty" dynamodbav:"period_id"`
+	PeriodUser  *string       `json:"period_user,omitempty" dynamodbav:"period_user"`
 	// AmountKey is a special attribute used to sort income by amount. It's composed of a padded-string of the amount
 	AmountKey string `json:"amount_key,omitempty" dynamodbav:"amount_key"`
 	// NameIncomeID is a special attribute used to sort income by name. It's composed of the name plus the income id.
@@ -27,6 +28,7 @@ func toIncomeModel(i incomeEntity) *models.Income {
 		Username:    i.Username,
 		IncomeID:    i.IncomeID,
 		Amount:      i.Amount,
+		Currency:    i.Currency,
 		Name:        i.Name,
 		CreatedDate: i.CreatedDate,
 		UpdatedDate: i.UpdatedDate,
@@ -50,6 +52,7 @@ func toIncomeEntity(i *models.Income) *incomeEntity {
 		Username:     i.Username,
 		IncomeID:     i.IncomeID,
 		Amount:       i.Amount,
+		Currency:     i.Currency,
 		Name:         i.Name,
 		CreatedDate:  i.CreatedDate,
 		UpdatedDate:  i.UpdatedDate,
@@ -22,8 +22,7 @@ func (d *DynamoMock) GetAllIncomeByPeriod(ctx context.Context, username string,
 }
 
 func (d *DynamoMock) BatchDeleteIncome(ctx context.Context, income []*models.Income) error {
-	//TODO implement me
-	panic("implement me")
+	return d.mockedErr
 }
 
 func (d *DynamoMock) GetAllIncomePeriods(ctx context.Context, username string) ([]string, error) {
@@ -78,6 +77,22 @@ func (d *DynamoMock) GetAllIncome(ctx context.Context, username string, params *
 	return d.mockedIncome, "", nil
 }
 
+func (d *DynamoMock) UpdateIncome(ctx context.Context, income *models.Income) error {
+	if d.mockedErr != nil {
+		return d.mockedErr
+	}
+
+	return nil
+}
+
+func (d *DynamoMock) DeleteIncome(ctx context.Context, incomeID, username string) error {
+	if d.mockedErr != nil {
+		return d.mockedErr
+	}
+
+	return nil
+}
+
 func (d *DynamoMock) SetMockedIncome(income []*models.Income) {
 	d.mockedIncome = income
 }
@@ -99,7 +114,7 @@ func GetDummyIncome() []*models.Income {
 		{
 			Username:    "test@gmail.com",
 			IncomeID:    "INC123",
-			Amount:      getFloatPtr(8700),
+			Amount:      getMoneyPtr(8700),
 			Name:        getStringPtr("Salary"),
 			CreatedDate: time.Date(2023, 5, 15, 20, 0, 0, 0, time.UTC),
 			PeriodID:    getStringPtr("2023-5"),
@@ -107,7 +122,7 @@ func GetDummyIncome() []*models.Income {
 		{
 			Username:    "test@gmail.com",
 			IncomeID:    "INC12",
-			Amount:      getFloatPtr(1500),
+			Amount:      getMoneyPtr(1500),
 			Name:        getStringPtr("Debt collection"),
 			CreatedDate: time.Date(2023, 5, 15, 20, 0, 0, 0, time.UTC),
 			PeriodID:    getStringPtr("2023-5"),
@@ -119,6 +134,7 @@ func getStringPtr(s string) *string {
 	return &s
 }
 
-func getFloatPtr(f float64) *float64 {
-	return &f
+func getMoneyPtr(f float64) *models.Money {
+	money := models.NewMoney(f)
+	return &money
 }
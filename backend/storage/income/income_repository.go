@@ -3,6 +3,9 @@ package income
 import (
 	"context"
 	"github.com/JoelD7/money/backend/models"
+	"github.com/JoelD7/money/backend/storage/audit"
+	"github.com/JoelD7/money/backend/storage/sqldb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
 )
 
 type Repository interface {
@@ -15,5 +18,33 @@ type Repository interface {
 	GetAllIncomeByPeriod(ctx context.Context, username string, params *models.QueryParameters) ([]*models.Income, error)
 	GetAllIncomePeriods(ctx context.Context, username string) ([]string, error)
 
+	UpdateIncome(ctx context.Context, income *models.Income) error
+
+	DeleteIncome(ctx context.Context, incomeID, username string) error
 	BatchDeleteIncome(ctx context.Context, income []*models.Income) error
 }
+
+// NewRepository returns the repository of the storage backend set in the configuration. Its writes are recorded in the
+// audit log when it's enabled.
+func NewRepository(ctx context.Context, dynamoClient *dynamodb.Client, envConfig *models.EnvironmentConfiguration) (Repository, error) {
+	recorder, err := audit.NewRecorder(ctx, dynamoClient, envConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	if envConfig.StorageBackend != models.StorageBackendSQL {
+		repo, err := NewDynamoRepository(dynamoClient, envConfig)
+		if err != nil {
+			return nil, err
+		}
+
+		return newAuditedRepository(repo, recorder), nil
+	}
+
+	db, err := sqldb.Connect(ctx, envConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	return newAuditedRepository(NewSQLRepository(db), recorder), nil
+}
@@ -0,0 +1,254 @@
+package income
+
+import (
+	"context"
+	"github.com/JoelD7/money/backend/models"
+	"github.com/JoelD7/money/backend/storage/cursor"
+	"github.com/JoelD7/money/backend/storage/dynamo"
+	"github.com/JoelD7/money/backend/storage/memory"
+	"sort"
+	"sync"
+)
+
+// MemoryRepository is a thread-safe implementation of Repository that keeps the income in memory. Queries are sorted
+// and paginated as they would be by the indexes of the DynamoDB table.
+type MemoryRepository struct {
+	mu sync.RWMutex
+	// income holds the income by username and income ID.
+	income map[string]map[string]*incomeEntity
+}
+
+func NewMemoryRepository() *MemoryRepository {
+	return &MemoryRepository{
+		income: make(map[string]map[string]*incomeEntity),
+	}
+}
+
+func (m *MemoryRepository) CreateIncome(ctx context.Context, income *models.Income) (*models.Income, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.income[income.Username][income.IncomeID]; ok {
+		return nil, models.ErrExistingIncome
+	}
+
+	err := m.put(income)
+	if err != nil {
+		return nil, err
+	}
+
+	return income, nil
+}
+
+func (m *MemoryRepository) BatchCreateIncome(ctx context.Context, incomes []*models.Income) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, income := range incomes {
+		err := m.put(income)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (m *MemoryRepository) put(income *models.Income) error {
+	entity := toIncomeEntity(income)
+
+	if income.PeriodID != nil {
+		entity.PeriodUser = dynamo.BuildPeriodUser(income.Username, *income.PeriodID)
+	}
+
+	copied, err := memory.Copy(entity)
+	if err != nil {
+		return err
+	}
+
+	if m.income[income.Username] == nil {
+		m.income[income.Username] = make(map[string]*incomeEntity)
+	}
+
+	m.income[income.Username][income.IncomeID] = copied
+
+	return nil
+}
+
+func (m *MemoryRepository) GetIncome(ctx context.Context, username, incomeID string) (*models.Income, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	entity, ok := m.income[username][incomeID]
+	if !ok {
+		return nil, models.ErrIncomeNotFound
+	}
+
+	copied, err := memory.Copy(entity)
+	if err != nil {
+		return nil, err
+	}
+
+	return toIncomeModel(*copied), nil
+}
+
+func (m *MemoryRepository) GetAllIncome(ctx context.Context, username string, params *models.QueryParameters) ([]*models.Income, string, error) {
+	return m.query(username, params)
+}
+
+func (m *MemoryRepository) GetIncomeByPeriod(ctx context.Context, username string, params *models.QueryParameters) ([]*models.Income, string, error) {
+	return m.query(username, params)
+}
+
+func (m *MemoryRepository) query(username string, params *models.QueryParameters) ([]*models.Income, string, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	entities, err := m.filter(username, params)
+	if err != nil {
+		return nil, "", err
+	}
+
+	page, nextKey, err := memory.Paginate(entities, getSortKey(params), params.StartKey, cursor.NewScope(username, params),
+		params.PageSize, params.SortType == string(models.SortOrderDescending), models.ErrIncomeNotFound)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return toIncomeModels(page), nextKey, nil
+}
+
+// getSortKey returns the sort key of the index the DynamoDB repository would query with params.
+func getSortKey(params *models.QueryParameters) func(i incomeEntity) string {
+	switch {
+	case params.SortBy == string(models.SortParamCreatedDate):
+		return func(i incomeEntity) string {
+			return dynamo.BuildCreatedDateEntityIDKey(i.CreatedDate, i.IncomeID)
+		}
+	case params.Period != "" && params.SortBy == string(models.SortParamAmount):
+		return func(i incomeEntity) string {
+			return i.AmountKey
+		}
+	case params.Period != "" && params.SortBy == string(models.SortParamName):
+		return func(i incomeEntity) string {
+			return i.NameIncomeID
+		}
+	default:
+		return func(i incomeEntity) string {
+			return i.IncomeID
+		}
+	}
+}
+
+// filter returns copies of the income of the user that match the period, amount, date and text filters of params. All
+// the income is returned if there are no filters.
+func (m *MemoryRepository) filter(username string, params *models.QueryParameters) ([]incomeEntity, error) {
+	entities := make([]incomeEntity, 0)
+
+	for _, entity := range m.income[username] {
+		if params.Period != "" && (entity.PeriodID == nil || *entity.PeriodID != params.Period) {
+			continue
+		}
+
+		var amount models.Money
+
+		if entity.Amount != nil {
+			amount = *entity.Amount
+		} else if params.MinAmount != nil || params.MaxAmount != nil {
+			// As in DynamoDB, income without amount doesn't match the amount filters.
+			continue
+		}
+
+		if !params.MatchesFilters(amount, entity.CreatedDate, getString(entity.Name), getString(entity.Notes)) {
+			continue
+		}
+
+		copied, err := memory.Copy(entity)
+		if err != nil {
+			return nil, err
+		}
+
+		entities = append(entities, *copied)
+	}
+
+	return entities, nil
+}
+
+func (m *MemoryRepository) GetAllIncomeByPeriod(ctx context.Context, username string, params *models.QueryParameters) ([]*models.Income, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	entities, err := m.filter(username, params)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(entities) == 0 {
+		return nil, models.ErrIncomeNotFound
+	}
+
+	return toIncomeModels(entities), nil
+}
+
+func (m *MemoryRepository) GetAllIncomePeriods(ctx context.Context, username string) ([]string, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if len(m.income[username]) == 0 {
+		return nil, models.ErrIncomeNotFound
+	}
+
+	existsMap := make(map[string]struct{})
+	periods := make([]string, 0)
+
+	for _, entity := range m.income[username] {
+		if entity.PeriodID == nil {
+			continue
+		}
+
+		if _, exists := existsMap[*entity.PeriodID]; !exists {
+			periods = append(periods, *entity.PeriodID)
+			existsMap[*entity.PeriodID] = struct{}{}
+		}
+	}
+
+	sort.Strings(periods)
+
+	return periods, nil
+}
+
+// UpdateIncome replaces the stored income with the given income, as the DynamoDB repository does.
+func (m *MemoryRepository) UpdateIncome(ctx context.Context, income *models.Income) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.income[income.Username][income.IncomeID]; !ok {
+		return models.ErrUpdateIncomeNotFound
+	}
+
+	return m.put(income)
+}
+
+func (m *MemoryRepository) DeleteIncome(ctx context.Context, incomeID, username string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.income[username][incomeID]; !ok {
+		return models.ErrDeleteIncomeNotFound
+	}
+
+	delete(m.income[username], incomeID)
+
+	return nil
+}
+
+func (m *MemoryRepository) BatchDeleteIncome(ctx context.Context, income []*models.Income) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, in := range income {
+		delete(m.income[in.Username], in.IncomeID)
+	}
+
+	return nil
+}
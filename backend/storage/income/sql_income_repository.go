@@ -0,0 +1,348 @@
+package income
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/JoelD7/money/backend/models"
+	"github.com/JoelD7/money/backend/storage/cursor"
+	"github.com/JoelD7/money/backend/storage/dynamo"
+	"github.com/JoelD7/money/backend/storage/sqldb"
+	"strings"
+)
+
+const incomeColumns = `username, income_id, amount, currency, name, notes, created_date, updated_date, period_id`
+
+type SQLRepository struct {
+	db *sqldb.DB
+}
+
+func NewSQLRepository(db *sqldb.DB) *SQLRepository {
+	return &SQLRepository{db: db}
+}
+
+func (s *SQLRepository) CreateIncome(ctx context.Context, income *models.Income) (*models.Income, error) {
+	result, err := s.db.ExecContext(ctx, `INSERT INTO income (`+incomeColumns+`) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT (username, income_id) DO NOTHING`, getIncomeArgs(toIncomeEntity(income))...)
+	if err != nil {
+		return nil, fmt.Errorf("insert income failed: %v", err)
+	}
+
+	affected, err := sqldb.RowsAffected(result)
+	if err != nil {
+		return nil, err
+	}
+
+	if affected == 0 {
+		return nil, models.ErrExistingIncome
+	}
+
+	return income, nil
+}
+
+func (s *SQLRepository) BatchCreateIncome(ctx context.Context, incomes []*models.Income) error {
+	return s.db.WithTx(ctx, func(tx *sqldb.Tx) error {
+		for _, income := range incomes {
+			_, err := tx.ExecContext(ctx, `INSERT INTO income (`+incomeColumns+`) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+				ON CONFLICT (username, income_id) DO UPDATE SET amount = excluded.amount, currency = excluded.currency,
+				name = excluded.name, notes = excluded.notes, created_date = excluded.created_date,
+				updated_date = excluded.updated_date, period_id = excluded.period_id`,
+				getIncomeArgs(toIncomeEntity(income))...)
+			if err != nil {
+				return fmt.Errorf("batch write income failed: %v", err)
+			}
+		}
+
+		return nil
+	})
+}
+
+func (s *SQLRepository) GetIncome(ctx context.Context, username, incomeID string) (*models.Income, error) {
+	row := s.db.QueryRowContext(ctx, `SELECT `+incomeColumns+` FROM income WHERE username = ? AND income_id = ?`,
+		username, incomeID)
+
+	entity, err := scanIncome(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, models.ErrIncomeNotFound
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return toIncomeModel(*entity), nil
+}
+
+func (s *SQLRepository) GetAllIncome(ctx context.Context, username string, params *models.QueryParameters) ([]*models.Income, string, error) {
+	return s.queryPage(ctx, username, params)
+}
+
+func (s *SQLRepository) GetIncomeByPeriod(ctx context.Context, username string, params *models.QueryParameters) ([]*models.Income, string, error) {
+	return s.queryPage(ctx, username, params)
+}
+
+func (s *SQLRepository) queryPage(ctx context.Context, username string, params *models.QueryParameters) ([]*models.Income, string, error) {
+	page := getSQLPage(username, params)
+
+	condition, args, err := page.Condition()
+	if err != nil {
+		return nil, "", err
+	}
+
+	filter := "username = ?"
+	filterArgs := []interface{}{username}
+
+	if params.Period != "" {
+		filter += " AND period_id = ?"
+		filterArgs = append(filterArgs, params.Period)
+	}
+
+	filterConditions, conditionArgs := sqldb.FilterConditions(params, "amount", "created_date", "COALESCE(name, '')", "COALESCE(notes, '')")
+	for _, filterCondition := range filterConditions {
+		filter += " AND " + filterCondition
+	}
+
+	filterArgs = append(filterArgs, conditionArgs...)
+
+	entities, err := s.query(ctx, `SELECT `+incomeColumns+` FROM income WHERE `+filter+` AND `+condition+` `+
+		page.OrderBy(), append(filterArgs, args...)...)
+	if err != nil {
+		return nil, "", err
+	}
+
+	entities, nextKey, err := sqldb.Paginate(entities, page, getSQLSortKey(params), models.ErrIncomeNotFound)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return toIncomeModels(entities), nextKey, nil
+}
+
+// getSQLPage returns the pagination of the query with params, which sorts by the same attributes as the DynamoDB
+// indexes. The amount and name can be null, so they are sorted as zero and empty.
+func getSQLPage(username string, params *models.QueryParameters) *sqldb.Page {
+	page := &sqldb.Page{
+		SortColumn: "income_id",
+		IDColumn:   "income_id",
+		Descending: params.SortType == string(models.SortOrderDescending),
+		StartKey:   params.StartKey,
+		PageSize:   params.PageSize,
+		Scope:      cursor.NewScope(username, params),
+	}
+
+	switch params.SortBy {
+	case string(models.SortParamCreatedDate):
+		page.SortColumn = "created_date"
+	case string(models.SortParamAmount):
+		page.SortColumn = "COALESCE(amount, 0)"
+	case string(models.SortParamName):
+		page.SortColumn = "lower(COALESCE(name, ''))"
+	}
+
+	return page
+}
+
+func getSQLSortKey(params *models.QueryParameters) func(i incomeEntity) (interface{}, string) {
+	switch params.SortBy {
+	case string(models.SortParamCreatedDate):
+		return func(i incomeEntity) (interface{}, string) {
+			return sqldb.FormatTime(i.CreatedDate), i.IncomeID
+		}
+	case string(models.SortParamAmount):
+		return func(i incomeEntity) (interface{}, string) {
+			if i.Amount == nil {
+				return int64(0), i.IncomeID
+			}
+
+			return int64(*i.Amount), i.IncomeID
+		}
+	case string(models.SortParamName):
+		return func(i incomeEntity) (interface{}, string) {
+			if i.Name == nil {
+				return "", i.IncomeID
+			}
+
+			return strings.ToLower(*i.Name), i.IncomeID
+		}
+	default:
+		return func(i incomeEntity) (interface{}, string) {
+			return i.IncomeID, i.IncomeID
+		}
+	}
+}
+
+func (s *SQLRepository) GetAllIncomeByPeriod(ctx context.Context, username string, params *models.QueryParameters) ([]*models.Income, error) {
+	entities, err := s.query(ctx, `SELECT `+incomeColumns+` FROM income WHERE username = ? AND period_id = ?
+		ORDER BY income_id`, username, params.Period)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(entities) == 0 {
+		return nil, models.ErrIncomeNotFound
+	}
+
+	return toIncomeModels(entities), nil
+}
+
+func (s *SQLRepository) GetAllIncomePeriods(ctx context.Context, username string) ([]string, error) {
+	var count int
+
+	err := s.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM income WHERE username = ?`, username).Scan(&count)
+	if err != nil {
+		return nil, fmt.Errorf("count income failed: %v", err)
+	}
+
+	if count == 0 {
+		return nil, models.ErrIncomeNotFound
+	}
+
+	rows, err := s.db.QueryContext(ctx, `SELECT DISTINCT period_id FROM income
+		WHERE username = ? AND period_id IS NOT NULL ORDER BY period_id`, username)
+	if err != nil {
+		return nil, fmt.Errorf("query income periods failed: %v", err)
+	}
+
+	defer rows.Close()
+
+	periods := make([]string, 0)
+
+	for rows.Next() {
+		var period string
+
+		err = rows.Scan(&period)
+		if err != nil {
+			return nil, err
+		}
+
+		periods = append(periods, period)
+	}
+
+	return periods, rows.Err()
+}
+
+// UpdateIncome replaces the stored income with the given income, as the DynamoDB repository does.
+func (s *SQLRepository) UpdateIncome(ctx context.Context, income *models.Income) error {
+	entity := toIncomeEntity(income)
+
+	result, err := s.db.ExecContext(ctx, `UPDATE income SET amount = ?, currency = ?, name = ?, notes = ?,
+		created_date = ?, updated_date = ?, period_id = ? WHERE username = ? AND income_id = ?`,
+		append(getIncomeArgs(entity)[2:], entity.Username, entity.IncomeID)...)
+	if err != nil {
+		return fmt.Errorf("update income failed: %v", err)
+	}
+
+	affected, err := sqldb.RowsAffected(result)
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return models.ErrUpdateIncomeNotFound
+	}
+
+	return nil
+}
+
+func (s *SQLRepository) DeleteIncome(ctx context.Context, incomeID, username string) error {
+	result, err := s.db.ExecContext(ctx, `DELETE FROM income WHERE username = ? AND income_id = ?`, username, incomeID)
+	if err != nil {
+		return fmt.Errorf("delete income failed: %v", err)
+	}
+
+	affected, err := sqldb.RowsAffected(result)
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return models.ErrDeleteIncomeNotFound
+	}
+
+	return nil
+}
+
+func (s *SQLRepository) BatchDeleteIncome(ctx context.Context, income []*models.Income) error {
+	return s.db.WithTx(ctx, func(tx *sqldb.Tx) error {
+		for _, in := range income {
+			_, err := tx.ExecContext(ctx, `DELETE FROM income WHERE username = ? AND income_id = ?`, in.Username, in.IncomeID)
+			if err != nil {
+				return fmt.Errorf("batch delete income failed: %v", err)
+			}
+		}
+
+		return nil
+	})
+}
+
+func (s *SQLRepository) query(ctx context.Context, query string, args ...interface{}) ([]incomeEntity, error) {
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("query income failed: %v", err)
+	}
+
+	defer rows.Close()
+
+	entities := make([]incomeEntity, 0)
+
+	for rows.Next() {
+		entity, err := scanIncome(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		entities = append(entities, *entity)
+	}
+
+	return entities, rows.Err()
+}
+
+func getIncomeArgs(i *incomeEntity) []interface{} {
+	var amount sql.NullInt64
+	if i.Amount != nil {
+		amount = sql.NullInt64{Int64: int64(*i.Amount), Valid: true}
+	}
+
+	return []interface{}{i.Username, i.IncomeID, amount, i.Currency, sqldb.NullString(i.Name), sqldb.NullString(i.Notes),
+		sqldb.FormatTime(i.CreatedDate), sqldb.FormatTime(i.UpdatedDate), sqldb.NullString(i.PeriodID)}
+}
+
+func scanIncome(row sqldb.Scanner) (*incomeEntity, error) {
+	entity := new(incomeEntity)
+
+	var amount sql.NullInt64
+	var name, notes, periodID sql.NullString
+	var createdDate, updatedDate string
+
+	err := row.Scan(&entity.Username, &entity.IncomeID, &amount, &entity.Currency, &name, &notes, &createdDate,
+		&updatedDate, &periodID)
+	if err != nil {
+		return nil, err
+	}
+
+	if amount.Valid {
+		value := models.Money(amount.Int64)
+		entity.Amount = &value
+	}
+
+	entity.Name = sqldb.StringPtr(name)
+	entity.Notes = sqldb.StringPtr(notes)
+	entity.PeriodID = sqldb.StringPtr(periodID)
+
+	if entity.PeriodID != nil {
+		entity.PeriodUser = dynamo.BuildPeriodUser(entity.Username, *entity.PeriodID)
+	}
+
+	entity.CreatedDate, err = sqldb.ParseTime(createdDate)
+	if err != nil {
+		return nil, err
+	}
+
+	entity.UpdatedDate, err = sqldb.ParseTime(updatedDate)
+	if err != nil {
+		return nil, err
+	}
+
+	return entity, nil
+}
@@ -0,0 +1,119 @@
+// Package memory contains the helpers shared by the in-memory repositories, which keep their items in maps instead of
+// DynamoDB, so that the use cases can run without AWS.
+package memory
+
+import (
+	"fmt"
+	"github.com/JoelD7/money/backend/models"
+	"github.com/JoelD7/money/backend/storage/cursor"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"sort"
+)
+
+// DefaultPageSize is the page size used when a query doesn't set one, as in the DynamoDB repositories.
+const DefaultPageSize = 10
+
+// Copy returns a deep copy of item. The copy is made by marshaling the item as a DynamoDB item, so the stored items
+// keep the attributes they would have in DynamoDB and can't be changed through the pointers of the callers.
+func Copy[T any](item T) (T, error) {
+	var copied T
+
+	av, err := attributevalue.Marshal(item)
+	if err != nil {
+		return copied, fmt.Errorf("marshal item failed: %v", err)
+	}
+
+	err = attributevalue.Unmarshal(av, &copied)
+	if err != nil {
+		return copied, fmt.Errorf("unmarshal item failed: %v", err)
+	}
+
+	return copied, nil
+}
+
+// Paginate sorts the items by the key returned by sortKey and returns the page that comes after startKey, along with
+// the start key of the next page. The sort key must be unique for each item. The start key of the next page is empty
+// when there are no items left. Start keys are bound to scope, as in the DynamoDB repositories.
+//
+// As in the DynamoDB repositories, notFoundErr is returned when there are no items and models.ErrNoMoreItemsToBeRetrieved
+// when there are no items after the start key.
+func Paginate[T any](items []T, sortKey func(T) string, startKey string, scope cursor.Scope, pageSize int, descending bool,
+	notFoundErr error) ([]T, string, error) {
+	if len(items) == 0 && startKey != "" {
+		return nil, "", models.ErrNoMoreItemsToBeRetrieved
+	}
+
+	if len(items) == 0 {
+		return nil, "", notFoundErr
+	}
+
+	if pageSize <= 0 {
+		pageSize = DefaultPageSize
+	}
+
+	sorted := make([]T, len(items))
+	copy(sorted, items)
+
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if descending {
+			return sortKey(sorted[i]) > sortKey(sorted[j])
+		}
+
+		return sortKey(sorted[i]) < sortKey(sorted[j])
+	})
+
+	start, err := getPageStart(sorted, sortKey, startKey, scope, descending)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if start == len(sorted) {
+		return nil, "", models.ErrNoMoreItemsToBeRetrieved
+	}
+
+	end := start + pageSize
+	if end >= len(sorted) {
+		return sorted[start:], "", nil
+	}
+
+	nextKey, err := encodeStartKey(sortKey(sorted[end-1]), scope)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return sorted[start:end], nextKey, nil
+}
+
+// getPageStart returns the position of the first item after startKey. The position is found by comparing sort keys,
+// so the page is right even if the item of the start key has been deleted.
+func getPageStart[T any](sorted []T, sortKey func(T) string, startKey string, scope cursor.Scope, descending bool) (int, error) {
+	if startKey == "" {
+		return 0, nil
+	}
+
+	lastKey, err := decodeStartKey(startKey, scope)
+	if err != nil {
+		return 0, err
+	}
+
+	return sort.Search(len(sorted), func(i int) bool {
+		if descending {
+			return sortKey(sorted[i]) < lastKey
+		}
+
+		return sortKey(sorted[i]) > lastKey
+	}), nil
+}
+
+func encodeStartKey(key string, scope cursor.Scope) (string, error) {
+	return cursor.Seal([]byte(key), scope)
+}
+
+func decodeStartKey(startKey string, scope cursor.Scope) (string, error) {
+	decoded, err := cursor.Open(startKey, scope)
+	if err != nil {
+		return "", err
+	}
+
+	return string(decoded), nil
+}
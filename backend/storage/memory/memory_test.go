@@ -0,0 +1,95 @@
+package memory
+
+import (
+	"github.com/JoelD7/money/backend/models"
+	"github.com/JoelD7/money/backend/storage/cursor"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+type testEntity struct {
+	ID          string        `dynamodbav:"id"`
+	Name        *string       `dynamodbav:"name"`
+	Amount      *models.Money `dynamodbav:"amount"`
+	CreatedDate time.Time     `dynamodbav:"created_date"`
+}
+
+func TestCopy(t *testing.T) {
+	c := require.New(t)
+
+	name := "Rent"
+	amount := models.NewMoney(100)
+
+	entity := &testEntity{ID: "1", Name: &name, Amount: &amount, CreatedDate: time.Now()}
+
+	copied, err := Copy(entity)
+	c.NoError(err)
+	c.Equal("Rent", *copied.Name)
+	c.Equal(amount, *copied.Amount)
+	c.True(entity.CreatedDate.Equal(copied.CreatedDate))
+
+	*entity.Name = "Groceries"
+	c.Equal("Rent", *copied.Name)
+}
+
+func TestPaginate(t *testing.T) {
+	t.Setenv("PAGINATION_KEY_SECRET", "pagination-secret")
+
+	c := require.New(t)
+
+	items := []string{"e", "a", "d", "c", "b"}
+	identity := func(item string) string { return item }
+	scope := cursor.Scope{Username: "test@gmail.com"}
+
+	page, nextKey, err := Paginate(items, identity, "", scope, 2, false, models.ErrExpensesNotFound)
+	c.NoError(err)
+	c.Equal([]string{"a", "b"}, page)
+	c.NotEmpty(nextKey)
+
+	page, nextKey, err = Paginate(items, identity, nextKey, scope, 2, false, models.ErrExpensesNotFound)
+	c.NoError(err)
+	c.Equal([]string{"c", "d"}, page)
+
+	page, nextKey, err = Paginate(items, identity, nextKey, scope, 2, false, models.ErrExpensesNotFound)
+	c.NoError(err)
+	c.Equal([]string{"e"}, page)
+	c.Empty(nextKey)
+
+	t.Run("Descending", func(t *testing.T) {
+		page, nextKey, err = Paginate(items, identity, "", scope, 3, true, models.ErrExpensesNotFound)
+		c.NoError(err)
+		c.Equal([]string{"e", "d", "c"}, page)
+
+		page, _, err = Paginate(items, identity, nextKey, scope, 3, true, models.ErrExpensesNotFound)
+		c.NoError(err)
+		c.Equal([]string{"b", "a"}, page)
+	})
+
+	t.Run("Start key item was deleted", func(t *testing.T) {
+		_, nextKey, err = Paginate(items, identity, "", scope, 2, false, models.ErrExpensesNotFound)
+		c.NoError(err)
+
+		page, _, err = Paginate([]string{"a", "c", "d"}, identity, nextKey, scope, 2, false, models.ErrExpensesNotFound)
+		c.NoError(err)
+		c.Equal([]string{"c", "d"}, page)
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		_, _, err = Paginate(nil, identity, "", scope, 2, false, models.ErrExpensesNotFound)
+		c.ErrorIs(err, models.ErrExpensesNotFound)
+
+		lastKey, err := encodeStartKey("e", scope)
+		c.NoError(err)
+
+		_, _, err = Paginate(items, identity, lastKey, scope, 2, false, models.ErrExpensesNotFound)
+		c.ErrorIs(err, models.ErrNoMoreItemsToBeRetrieved)
+
+		_, _, err = Paginate(items, identity, lastKey, cursor.Scope{Username: "other@gmail.com"}, 2, false,
+			models.ErrExpensesNotFound)
+		c.ErrorIs(err, models.ErrInvalidStartKey)
+
+		_, _, err = Paginate(items, identity, "invalid key", scope, 2, false, models.ErrExpensesNotFound)
+		c.ErrorIs(err, models.ErrInvalidStartKey)
+	})
+}
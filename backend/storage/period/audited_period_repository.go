@@ -0,0 +1,119 @@
+package period
+
+import (
+	"context"
+	"github.com/JoelD7/money/backend/models"
+	"github.com/JoelD7/money/backend/storage/audit"
+)
+
+// auditedRepository records the writes made to the periods in the audit log.
+type auditedRepository struct {
+	Repository
+	recorder *audit.Recorder
+}
+
+func newAuditedRepository(repo Repository, recorder *audit.Recorder) Repository {
+	if recorder == nil {
+		return repo
+	}
+
+	return &auditedRepository{Repository: repo, recorder: recorder}
+}
+
+func newChange(username, periodID string, old, new *models.Period) *audit.Change {
+	change := &audit.Change{Username: username, EntityType: models.AuditEntityPeriod, EntityID: periodID}
+
+	if old != nil {
+		change.Old = old
+	}
+
+	if new != nil {
+		change.New = new
+	}
+
+	return change
+}
+
+func (a *auditedRepository) CreatePeriod(ctx context.Context, period *models.Period) (*models.Period, error) {
+	created, err := a.Repository.CreatePeriod(ctx, period)
+	if err != nil {
+		return nil, err
+	}
+
+	a.recorder.Record(ctx, newChange(created.Username, created.ID, nil, created))
+
+	return created, nil
+}
+
+func (a *auditedRepository) BatchCreatePeriods(ctx context.Context, periods []*models.Period) error {
+	err := a.Repository.BatchCreatePeriods(ctx, periods)
+	if err != nil {
+		return err
+	}
+
+	changes := make([]*audit.Change, 0, len(periods))
+
+	for _, period := range periods {
+		changes = append(changes, newChange(period.Username, period.ID, nil, period))
+	}
+
+	a.recorder.Record(ctx, changes...)
+
+	return nil
+}
+
+// UpdatePeriod records the period as it's stored before and after the update, as only the fields that are set are
+// updated.
+func (a *auditedRepository) UpdatePeriod(ctx context.Context, period *models.Period) error {
+	old, err := a.Repository.GetPeriod(ctx, period.Username, period.ID)
+	if err != nil {
+		return a.Repository.UpdatePeriod(ctx, period)
+	}
+
+	err = a.Repository.UpdatePeriod(ctx, period)
+	if err != nil {
+		return err
+	}
+
+	updated, err := a.Repository.GetPeriod(ctx, period.Username, period.ID)
+	if err != nil {
+		updated = period
+	}
+
+	a.recorder.Record(ctx, newChange(period.Username, period.ID, old, updated))
+
+	return nil
+}
+
+func (a *auditedRepository) DeletePeriod(ctx context.Context, periodID, username string, version int64) error {
+	old, err := a.Repository.GetPeriod(ctx, username, periodID)
+	if err != nil {
+		return a.Repository.DeletePeriod(ctx, periodID, username, version)
+	}
+
+	err = a.Repository.DeletePeriod(ctx, periodID, username, version)
+	if err != nil {
+		return err
+	}
+
+	a.recorder.Record(ctx, newChange(username, periodID, old, nil))
+
+	return nil
+}
+
+func (a *auditedRepository) BatchDeletePeriods(ctx context.Context, periods []*models.Period) error {
+	err := a.Repository.BatchDeletePeriods(ctx, periods)
+	if err != nil {
+		return err
+	}
+
+	changes := make([]*audit.Change, 0, len(periods))
+
+	for _, period := range periods {
+		changes = append(changes, newChange(period.Username, period.ID, period, nil))
+	}
+
+	a.recorder.Record(ctx, changes...)
+
+	return nil
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"github.com/JoelD7/money/backend/models"
+	"github.com/JoelD7/money/backend/storage/cursor"
 	"github.com/JoelD7/money/backend/storage/dynamo"
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
@@ -29,6 +30,7 @@ var (
 type DynamoRepository struct {
 	dynamoClient               *dynamodb.Client
 	periodTableName            string
+	uniquePeriodTableName      string
 	usernameEndDatePeriodIndex string
 }
 
@@ -41,6 +43,7 @@ func NewDynamoRepository(dynamoClient *dynamodb.Client, envConfig *models.Enviro
 	}
 
 	d.periodTableName = envConfig.PeriodTable
+	d.uniquePeriodTableName = envConfig.UniquePeriodTable
 	d.usernameEndDatePeriodIndex = envConfig.UsernameEndDatePeriodIndex
 
 	return d, nil
@@ -65,6 +68,8 @@ func validateParams(envConfig *models.EnvironmentConfiguration) error {
 func (d *DynamoRepository) CreatePeriod(ctx context.Context, period *models.Period) (*models.Period, error) {
 	period.ID = dynamo.GenerateID(periodPrefix)
 	periodEnt := toPeriodEntity(*period)
+	periodEnt.setInitialVersion()
+	period.Version = periodEnt.Version
 
 	attrValue, err := attributevalue.MarshalMap(periodEnt)
 	if err != nil {
@@ -97,17 +102,50 @@ func (d *DynamoRepository) CreatePeriod(ctx context.Context, period *models.Peri
 	return period, nil
 }
 
+// BatchCreatePeriods creates the periods keeping their IDs. Periods without an ID get a new one.
+func (d *DynamoRepository) BatchCreatePeriods(ctx context.Context, periods []*models.Period) error {
+	writeRequests := make([]types.WriteRequest, 0, len(periods))
+
+	for _, period := range periods {
+		if period.ID == "" {
+			period.ID = dynamo.GenerateID(periodPrefix)
+		}
+
+		entity := toPeriodEntity(*period)
+		entity.setInitialVersion()
+
+		item, err := attributevalue.MarshalMap(entity)
+		if err != nil {
+			return fmt.Errorf("marshal period item failed: %v", err)
+		}
+
+		writeRequests = append(writeRequests, types.WriteRequest{
+			PutRequest: &types.PutRequest{Item: item},
+		})
+	}
+
+	input := &dynamodb.BatchWriteItemInput{
+		RequestItems: map[string][]types.WriteRequest{
+			d.periodTableName: writeRequests,
+		},
+	}
+
+	return dynamo.BatchWrite(ctx, d.dynamoClient, input)
+}
+
+// UpdatePeriod replaces the period if the stored one is at the version of period, and increments it.
 func (d *DynamoRepository) UpdatePeriod(ctx context.Context, period *models.Period) error {
 	periodEnt := toPeriodEntity(*period)
 
 	periodEnt.UpdatedDate = time.Now()
+	periodEnt.Version = period.Version + 1
 
 	periodAv, err := attributevalue.MarshalMap(periodEnt)
 	if err != nil {
 		return fmt.Errorf("marshaling period to attribute value: %v", err)
 	}
 
-	periodExistsCond := expression.Name("period").AttributeExists()
+	periodExistsCond := expression.Name("period").AttributeExists().And(dynamo.VersionCondition(period.Version))
 	periodNameNotTakenCond := expression.Name("name").AttributeNotExists().And(expression.Name("username").AttributeNotExists())
 
 	periodTableExpr, err := expression.NewBuilder().WithCondition(periodExistsCond).Build()
@@ -137,14 +175,19 @@ func (d *DynamoRepository) UpdatePeriod(ctx context.Context, period *models.Peri
 	}
 
 	input := &dynamodb.PutItemInput{
-		Item:                      periodAv,
-		TableName:                 aws.String(d.periodTableName),
-		ConditionExpression:       periodTableExpr.Condition(),
-		ExpressionAttributeNames:  periodTableExpr.Names(),
-		ExpressionAttributeValues: periodTableExpr.Values(),
+		Item:                                periodAv,
+		TableName:                           aws.String(d.periodTableName),
+		ConditionExpression:                 periodTableExpr.Condition(),
+		ExpressionAttributeNames:            periodTableExpr.Names(),
+		ExpressionAttributeValues:           periodTableExpr.Values(),
+		ReturnValuesOnConditionCheckFailure: types.ReturnValuesOnConditionCheckFailureAllOld,
 	}
 
 	_, err = d.dynamoClient.PutItem(ctx, input)
+	if dynamo.IsVersionConflict(err) {
+		return fmt.Errorf("%v: %w", err, models.ErrVersionConflict)
+	}
+
 	if err != nil {
 		return handleUpdatePeriodError(transactItems, errByCondition, err)
 	}
@@ -267,11 +310,13 @@ func (d *DynamoRepository) GetPeriods(ctx context.Context, username, startKey st
 		index = aws.String(d.usernameEndDatePeriodIndex)
 	}
 
+	scope := cursor.NewScope(username, &models.QueryParameters{Active: active})
+
 	var decodedStartKey map[string]types.AttributeValue
 	if startKey != "" {
-		decodedStartKey, err = dynamo.DecodePaginationKey(startKey)
+		decodedStartKey, err = dynamo.DecodePaginationKey(startKey, scope)
 		if err != nil {
-			return nil, "", fmt.Errorf("%v: %w", err, models.ErrInvalidStartKey)
+			return nil, "", err
 		}
 	}
 
@@ -301,7 +346,7 @@ func (d *DynamoRepository) GetPeriods(ctx context.Context, username, startKey st
 		return nil, "", fmt.Errorf("unmarshal periods failed: %v", err)
 	}
 
-	nextKey, err := dynamo.EncodePaginationKey(result.LastEvaluatedKey)
+	nextKey, err := dynamo.EncodePaginationKey(result.LastEvaluatedKey, scope)
 	if err != nil {
 		return nil, "", err
 	}
@@ -405,8 +450,11 @@ func getPageSize(pageSize int) *int32 {
 	return aws.Int32(int32(pageSize))
 }
 
-func (d *DynamoRepository) DeletePeriod(ctx context.Context, periodID, username string) error {
-	conditionExpression := expression.AttributeExists(expression.Name("period")).And(expression.AttributeExists(expression.Name("username")))
+// DeletePeriod deletes the period if it's at version.
+func (d *DynamoRepository) DeletePeriod(ctx context.Context, periodID, username string, version int64) error {
+	conditionExpression := expression.AttributeExists(expression.Name("period")).
+		And(expression.AttributeExists(expression.Name("username"))).
+		And(dynamo.VersionCondition(version))
 
 	expr, err := expression.NewBuilder().WithCondition(conditionExpression).Build()
 	if err != nil {
@@ -418,13 +466,18 @@ func (d *DynamoRepository) DeletePeriod(ctx context.Context, periodID, username
 			"username": &types.AttributeValueMemberS{Value: username},
 			"period":   &types.AttributeValueMemberS{Value: periodID},
 		},
-		TableName:                 aws.String(d.periodTableName),
-		ConditionExpression:       expr.Condition(),
-		ExpressionAttributeNames:  expr.Names(),
-		ExpressionAttributeValues: expr.Values(),
+		TableName:                           aws.String(d.periodTableName),
+		ConditionExpression:                 expr.Condition(),
+		ExpressionAttributeNames:            expr.Names(),
+		ExpressionAttributeValues:           expr.Values(),
+		ReturnValuesOnConditionCheckFailure: types.ReturnValuesOnConditionCheckFailureAllOld,
 	}
 
 	_, err = d.dynamoClient.DeleteItem(ctx, input)
+	if dynamo.IsVersionConflict(err) {
+		return fmt.Errorf("%v: %w", err, models.ErrVersionConflict)
+	}
+
 	if err != nil && strings.Contains(err.Error(), conditionalFailedKeyword) {
 		return fmt.Errorf("%v: %w", err, models.ErrPeriodNotFound)
 	}
@@ -432,8 +485,10 @@ func (d *DynamoRepository) DeletePeriod(ctx context.Context, periodID, username
 	return err
 }
 
+// BatchDeletePeriods deletes the periods, along with the rows that reserve their names in the unique period table when
+// that table is configured.
 func (d *DynamoRepository) BatchDeletePeriods(ctx context.Context, periods []*models.Period) error {
-	periodWriteRequests, _, err := getBatchPeriodDeleteRequests(periods)
+	periodWriteRequests, uniquePeriodWriteRequests, err := getBatchPeriodDeleteRequests(periods)
 	if err != nil {
 		return err
 	}
@@ -444,6 +499,10 @@ func (d *DynamoRepository) BatchDeletePeriods(ctx context.Context, periods []*mo
 		},
 	}
 
+	if d.uniquePeriodTableName != "" {
+		input.RequestItems[d.uniquePeriodTableName] = uniquePeriodWriteRequests
+	}
+
 	return dynamo.BatchWrite(ctx, d.dynamoClient, input)
 }
 
@@ -466,6 +525,11 @@ func getBatchPeriodDeleteRequests(periods []*models.Period) ([]types.WriteReques
 			return nil, nil, fmt.Errorf("marshal username key failed: %v", err)
 		}
 
+		uniqueNameAV := periodNameAV
+		if p.Name != nil && *p.Name != "" {
+			uniqueNameAV = &types.AttributeValueMemberS{Value: *p.Name}
+		}
+
 		periodWriteRequests = append(periodWriteRequests, types.WriteRequest{
 			DeleteRequest: &types.DeleteRequest{
 				Key: map[string]types.AttributeValue{
@@ -478,7 +542,7 @@ func getBatchPeriodDeleteRequests(periods []*models.Period) ([]types.WriteReques
 		uniquePeriodWriteRequests = append(uniquePeriodWriteRequests, types.WriteRequest{
 			DeleteRequest: &types.DeleteRequest{
 				Key: map[string]types.AttributeValue{
-					"name":     periodNameAV,
+					"name":     uniqueNameAV,
 					"username": usernameAV,
 				},
 			},
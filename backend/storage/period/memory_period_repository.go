@@ -0,0 +1,246 @@
+package period
+
+import (
+	"context"
+	"errors"
+	"github.com/JoelD7/money/backend/models"
+	"github.com/JoelD7/money/backend/storage/cursor"
+	"github.com/JoelD7/money/backend/storage/dynamo"
+	"github.com/JoelD7/money/backend/storage/memory"
+	"sync"
+	"time"
+)
+
+// MemoryRepository is a thread-safe implementation of Repository that keeps the periods in memory. Queries are sorted
+// and paginated as they would be by the indexes of the DynamoDB table.
+type MemoryRepository struct {
+	mu sync.RWMutex
+	// periods holds the periods by username and period ID.
+	periods map[string]map[string]*periodEntity
+}
+
+func NewMemoryRepository() *MemoryRepository {
+	return &MemoryRepository{
+		periods: make(map[string]map[string]*periodEntity),
+	}
+}
+
+func (m *MemoryRepository) CreatePeriod(ctx context.Context, period *models.Period) (*models.Period, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	period.ID = dynamo.GenerateID(periodPrefix)
+
+	if _, ok := m.periods[period.Username][period.ID]; ok {
+		return nil, models.ErrPeriodNameIsTaken
+	}
+
+	entity := toPeriodEntity(*period)
+	entity.setInitialVersion()
+	period.Version = entity.Version
+
+	err := m.put(entity)
+	if err != nil {
+		return nil, err
+	}
+
+	return period, nil
+}
+
+// BatchCreatePeriods creates the periods keeping their IDs. Periods without an ID get a new one.
+func (m *MemoryRepository) BatchCreatePeriods(ctx context.Context, periods []*models.Period) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, period := range periods {
+		if period.ID == "" {
+			period.ID = dynamo.GenerateID(periodPrefix)
+		}
+
+		entity := toPeriodEntity(*period)
+		entity.setInitialVersion()
+
+		err := m.put(entity)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (m *MemoryRepository) put(entity periodEntity) error {
+	copied, err := memory.Copy(&entity)
+	if err != nil {
+		return err
+	}
+
+	if m.periods[entity.Username] == nil {
+		m.periods[entity.Username] = make(map[string]*periodEntity)
+	}
+
+	m.periods[entity.Username][entity.ID] = copied
+
+	return nil
+}
+
+func (m *MemoryRepository) UpdatePeriod(ctx context.Context, period *models.Period) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	stored, ok := m.periods[period.Username][period.ID]
+	if !ok {
+		return models.ErrUpdatePeriodNotFound
+	}
+
+	if stored.Version != period.Version {
+		return models.ErrVersionConflict
+	}
+
+	entity := toPeriodEntity(*period)
+	entity.UpdatedDate = time.Now()
+	entity.Version = period.Version + 1
+
+	return m.put(entity)
+}
+
+func (m *MemoryRepository) GetPeriod(ctx context.Context, username, period string) (*models.Period, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	entity, ok := m.periods[username][period]
+	if !ok {
+		return nil, models.ErrPeriodNotFound
+	}
+
+	copied, err := memory.Copy(entity)
+	if err != nil {
+		return nil, err
+	}
+
+	return toPeriodModel(*copied), nil
+}
+
+// GetLastPeriod returns the period with the greatest ID, as the DynamoDB repository does.
+func (m *MemoryRepository) GetLastPeriod(ctx context.Context, username string) (*models.Period, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var last *periodEntity
+
+	for _, entity := range m.periods[username] {
+		if last == nil || entity.ID > last.ID {
+			last = entity
+		}
+	}
+
+	if last == nil {
+		return nil, models.ErrPeriodsNotFound
+	}
+
+	copied, err := memory.Copy(last)
+	if err != nil {
+		return nil, err
+	}
+
+	return toPeriodModel(*copied), nil
+}
+
+// GetPeriods returns a page of the periods of the user sorted by ID. Active periods, the ones that haven't ended, are
+// sorted by end date instead.
+func (m *MemoryRepository) GetPeriods(ctx context.Context, username, startKey string, pageSize int, active bool) ([]*models.Period, string, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	now := time.Now().Format(time.RFC3339)
+	entities := make([]periodEntity, 0)
+
+	for _, entity := range m.periods[username] {
+		if active && entity.EndDatePeriod <= now {
+			continue
+		}
+
+		copied, err := memory.Copy(entity)
+		if err != nil {
+			return nil, "", err
+		}
+
+		entities = append(entities, *copied)
+	}
+
+	sortKey := func(p periodEntity) string {
+		return p.ID
+	}
+
+	if active {
+		sortKey = func(p periodEntity) string {
+			return p.EndDatePeriod
+		}
+	}
+
+	page, nextKey, err := memory.Paginate(entities, sortKey, startKey,
+		cursor.NewScope(username, &models.QueryParameters{Active: active}), pageSize, false, models.ErrPeriodsNotFound)
+	// The DynamoDB repository returns an empty page when there are no periods after the start key.
+	if errors.Is(err, models.ErrNoMoreItemsToBeRetrieved) {
+		return []*models.Period{}, "", nil
+	}
+
+	if err != nil {
+		return nil, "", err
+	}
+
+	return toPeriodModels(page), nextKey, nil
+}
+
+func (m *MemoryRepository) BatchGetPeriods(ctx context.Context, username string, periods []string) ([]*models.Period, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	entities := make([]periodEntity, 0, len(periods))
+
+	for _, period := range periods {
+		entity, ok := m.periods[username][period]
+		if !ok {
+			continue
+		}
+
+		copied, err := memory.Copy(entity)
+		if err != nil {
+			return nil, err
+		}
+
+		entities = append(entities, *copied)
+	}
+
+	return toPeriodModels(entities), nil
+}
+
+// DeletePeriod deletes the period if it's at version.
+func (m *MemoryRepository) DeletePeriod(ctx context.Context, periodID, username string, version int64) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	stored, ok := m.periods[username][periodID]
+	if !ok {
+		return models.ErrPeriodNotFound
+	}
+
+	if stored.Version != version {
+		return models.ErrVersionConflict
+	}
+
+	delete(m.periods[username], periodID)
+
+	return nil
+}
+
+func (m *MemoryRepository) BatchDeletePeriods(ctx context.Context, periods []*models.Period) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, period := range periods {
+		delete(m.periods[period.Username], period.ID)
+	}
+
+	return nil
+}
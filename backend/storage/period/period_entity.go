@@ -7,26 +7,30 @@ import (
 )
 
 type periodEntity struct {
-	Username              string    `json:"username,omitempty" dynamodbav:"username"`
-	ID                    string    `json:"period,omitempty" dynamodbav:"period"`
-	Name                  *string   `json:"name,omitempty" dynamodbav:"name"`
-	StartDate             time.Time `json:"start_date,omitempty" dynamodbav:"start_date"`
-	EndDate               time.Time `json:"end_date,omitempty" dynamodbav:"end_date"`
-	CreatedDate           time.Time `json:"created_date,omitempty" dynamodbav:"created_date"`
-	UpdatedDate           time.Time `json:"updated_date,omitempty" dynamodbav:"updated_date"`
-	UsernameEndDatePeriod *string   `json:"username_end_date_period,omitempty" dynamodbav:"username_end_date_period,omitempty"`
-	EndDatePeriod         string    `json:"end_date_period,omitempty" dynamodbav:"end-date_period"`
+	Username              string                  `json:"username,omitempty" dynamodbav:"username"`
+	ID                    string                  `json:"period,omitempty" dynamodbav:"period"`
+	Name                  *string                 `json:"name,omitempty" dynamodbav:"name"`
+	StartDate             time.Time               `json:"start_date,omitempty" dynamodbav:"start_date"`
+	EndDate               time.Time               `json:"end_date,omitempty" dynamodbav:"end_date"`
+	CreatedDate           time.Time               `json:"created_date,omitempty" dynamodbav:"created_date"`
+	UpdatedDate           time.Time               `json:"updated_date,omitempty" dynamodbav:"updated_date"`
+	UsernameEndDatePeriod *string                 `json:"username_end_date_period,omitempty" dynamodbav:"username_end_date_period,omitempty"`
+	EndDatePeriod         string                  `json:"end_date_period,omitempty" dynamodbav:"end-date_period"`
+	CategoryBudgets       map[string]models.Money `json:"category_budgets,omitempty" dynamodbav:"category_budgets,omitempty"`
+	Version               int64                   `json:"version,omitempty" dynamodbav:"version,omitempty"`
 }
 
 func toPeriodModel(p periodEntity) *models.Period {
 	return &models.Period{
-		Username:    p.Username,
-		ID:          p.ID,
-		Name:        p.Name,
-		StartDate:   p.StartDate,
-		EndDate:     p.EndDate,
-		CreatedDate: p.CreatedDate,
-		UpdatedDate: p.UpdatedDate,
+		Username:        p.Username,
+		ID:              p.ID,
+		Name:            p.Name,
+		StartDate:       p.StartDate,
+		EndDate:         p.EndDate,
+		CreatedDate:     p.CreatedDate,
+		UpdatedDate:     p.UpdatedDate,
+		CategoryBudgets: p.CategoryBudgets,
+		Version:         p.Version,
 	}
 }
 
@@ -51,5 +55,14 @@ func toPeriodEntity(period models.Period) periodEntity {
 		UpdatedDate:           period.UpdatedDate,
 		UsernameEndDatePeriod: nil,
 		EndDatePeriod:         dynamo.BuildEndDatePeriodKey(period.ID, period.EndDate),
+		CategoryBudgets:       period.CategoryBudgets,
+		Version:               period.Version,
+	}
+}
+
+// setInitialVersion sets the version of a period that's being created, unless it already has one.
+func (p *periodEntity) setInitialVersion() {
+	if p.Version == 0 {
+		p.Version = 1
 	}
 }
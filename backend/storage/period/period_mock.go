@@ -45,6 +45,14 @@ func (d *DynamoMock) CreatePeriod(ctx context.Context, period *models.Period) (*
 	return period, nil
 }
 
+func (d *DynamoMock) BatchCreatePeriods(ctx context.Context, periods []*models.Period) error {
+	if d.mockedErr != nil {
+		return d.mockedErr
+	}
+
+	return nil
+}
+
 func (d *DynamoMock) UpdatePeriod(ctx context.Context, period *models.Period) error {
 	if d.mockedErr != nil {
 		return d.mockedErr
@@ -78,11 +86,14 @@ func (d *DynamoMock) GetPeriods(ctx context.Context, username, startKey string,
 }
 
 func (d *DynamoMock) BatchGetPeriods(ctx context.Context, username string, periods []string) ([]*models.Period, error) {
-	//TODO implement me
-	panic("implement me")
+	if d.mockedErr != nil {
+		return nil, d.mockedErr
+	}
+
+	return []*models.Period{defaultPeriod}, nil
 }
 
-func (d *DynamoMock) DeletePeriod(ctx context.Context, periodID, username string) error {
+func (d *DynamoMock) DeletePeriod(ctx context.Context, periodID, username string, version int64) error {
 	if d.mockedErr != nil {
 		return d.mockedErr
 	}
@@ -3,15 +3,44 @@ package period
 import (
 	"context"
 	"github.com/JoelD7/money/backend/models"
+	"github.com/JoelD7/money/backend/storage/audit"
+	"github.com/JoelD7/money/backend/storage/sqldb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
 )
 
 type Repository interface {
 	CreatePeriod(ctx context.Context, period *models.Period) (*models.Period, error)
+	BatchCreatePeriods(ctx context.Context, periods []*models.Period) error
 	UpdatePeriod(ctx context.Context, period *models.Period) error
 	GetPeriod(ctx context.Context, username, period string) (*models.Period, error)
 	GetLastPeriod(ctx context.Context, username string) (*models.Period, error)
 	GetPeriods(ctx context.Context, username, startKey string, pageSize int, active bool) ([]*models.Period, string, error)
 	BatchGetPeriods(ctx context.Context, username string, periods []string) ([]*models.Period, error)
-	DeletePeriod(ctx context.Context, periodID, username string) error
+	DeletePeriod(ctx context.Context, periodID, username string, version int64) error
 	BatchDeletePeriods(ctx context.Context, periods []*models.Period) error
 }
+
+// NewRepository returns the repository of the storage backend set in the configuration. Its writes are recorded in the
+// audit log when it's enabled.
+func NewRepository(ctx context.Context, dynamoClient *dynamodb.Client, envConfig *models.EnvironmentConfiguration) (Repository, error) {
+	recorder, err := audit.NewRecorder(ctx, dynamoClient, envConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	if envConfig.StorageBackend != models.StorageBackendSQL {
+		repo, err := NewDynamoRepository(dynamoClient, envConfig)
+		if err != nil {
+			return nil, err
+		}
+
+		return newAuditedRepository(repo, recorder), nil
+	}
+
+	db, err := sqldb.Connect(ctx, envConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	return newAuditedRepository(NewSQLRepository(db), recorder), nil
+}
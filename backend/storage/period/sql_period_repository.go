@@ -0,0 +1,323 @@
+package period
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/JoelD7/money/backend/models"
+	"github.com/JoelD7/money/backend/storage/cursor"
+	"github.com/JoelD7/money/backend/storage/dynamo"
+	"github.com/JoelD7/money/backend/storage/sqldb"
+	"time"
+)
+
+const periodColumns = `username, period, name, start_date, end_date, created_date, updated_date, category_budgets,
+	version`
+
+type SQLRepository struct {
+	db *sqldb.DB
+}
+
+func NewSQLRepository(db *sqldb.DB) *SQLRepository {
+	return &SQLRepository{db: db}
+}
+
+// CreatePeriod creates the period with a new ID. Period names are unique for each user.
+func (s *SQLRepository) CreatePeriod(ctx context.Context, period *models.Period) (*models.Period, error) {
+	period.ID = dynamo.GenerateID(periodPrefix)
+
+	entity := toPeriodEntity(*period)
+	entity.setInitialVersion()
+	period.Version = entity.Version
+
+	args, err := getPeriodArgs(entity)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := s.db.ExecContext(ctx, `INSERT INTO periods (`+periodColumns+`) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT DO NOTHING`, args...)
+	if err != nil {
+		return nil, fmt.Errorf("insert period failed: %v", err)
+	}
+
+	affected, err := sqldb.RowsAffected(result)
+	if err != nil {
+		return nil, err
+	}
+
+	if affected == 0 {
+		return nil, models.ErrPeriodNameIsTaken
+	}
+
+	return period, nil
+}
+
+// BatchCreatePeriods creates the periods keeping their IDs. Periods without an ID get a new one.
+func (s *SQLRepository) BatchCreatePeriods(ctx context.Context, periods []*models.Period) error {
+	return s.db.WithTx(ctx, func(tx *sqldb.Tx) error {
+		for _, period := range periods {
+			if period.ID == "" {
+				period.ID = dynamo.GenerateID(periodPrefix)
+			}
+
+			entity := toPeriodEntity(*period)
+			entity.setInitialVersion()
+
+			args, err := getPeriodArgs(entity)
+			if err != nil {
+				return err
+			}
+
+			_, err = tx.ExecContext(ctx, `INSERT INTO periods (`+periodColumns+`) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+				ON CONFLICT (username, period) DO UPDATE SET name = excluded.name, start_date = excluded.start_date,
+				end_date = excluded.end_date, created_date = excluded.created_date,
+				updated_date = excluded.updated_date, category_budgets = excluded.category_budgets,
+				version = excluded.version`, args...)
+			if err != nil {
+				return fmt.Errorf("batch write periods failed: %v", err)
+			}
+		}
+
+		return nil
+	})
+}
+
+// UpdatePeriod replaces the period. It fails with models.ErrVersionConflict if the stored period isn't at the version of
+// the given one.
+func (s *SQLRepository) UpdatePeriod(ctx context.Context, period *models.Period) error {
+	entity := toPeriodEntity(*period)
+	entity.UpdatedDate = time.Now()
+	entity.Version = period.Version + 1
+
+	args, err := getPeriodArgs(entity)
+	if err != nil {
+		return err
+	}
+
+	return s.db.WithTx(ctx, func(tx *sqldb.Tx) error {
+		var taken int
+
+		err := tx.QueryRowContext(ctx, `SELECT COUNT(*) FROM periods WHERE username = ? AND name = ? AND period <> ?`,
+			entity.Username, sqldb.NullString(entity.Name), entity.ID).Scan(&taken)
+		if err != nil {
+			return fmt.Errorf("check period name failed: %v", err)
+		}
+
+		if taken > 0 {
+			return models.ErrPeriodNameIsTaken
+		}
+
+		result, err := tx.ExecContext(ctx, `UPDATE periods SET name = ?, start_date = ?, end_date = ?, created_date = ?,
+			updated_date = ?, category_budgets = ?, version = ? WHERE username = ? AND period = ? AND version = ?`,
+			append(args[2:], entity.Username, entity.ID, period.Version)...)
+		if err != nil {
+			return fmt.Errorf("update period failed: %v", err)
+		}
+
+		return tx.CheckVersionedUpdate(ctx, result, models.ErrUpdatePeriodNotFound,
+			`SELECT 1 FROM periods WHERE username = ? AND period = ?`, entity.Username, entity.ID)
+	})
+}
+
+func (s *SQLRepository) GetPeriod(ctx context.Context, username, period string) (*models.Period, error) {
+	row := s.db.QueryRowContext(ctx, `SELECT `+periodColumns+` FROM periods WHERE username = ? AND period = ?`,
+		username, period)
+
+	entity, err := scanPeriod(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, models.ErrPeriodNotFound
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return toPeriodModel(*entity), nil
+}
+
+// GetLastPeriod returns the period with the greatest ID, as the DynamoDB repository does.
+func (s *SQLRepository) GetLastPeriod(ctx context.Context, username string) (*models.Period, error) {
+	row := s.db.QueryRowContext(ctx, `SELECT `+periodColumns+` FROM periods WHERE username = ?
+		ORDER BY period DESC LIMIT 1`, username)
+
+	entity, err := scanPeriod(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, models.ErrPeriodsNotFound
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return toPeriodModel(*entity), nil
+}
+
+// GetPeriods returns a page of the periods of the user sorted by ID. Active periods, the ones that haven't ended, are
+// sorted by end date instead.
+func (s *SQLRepository) GetPeriods(ctx context.Context, username, startKey string, pageSize int, active bool) ([]*models.Period, string, error) {
+	page := &sqldb.Page{
+		SortColumn: "period",
+		IDColumn:   "period",
+		StartKey:   startKey,
+		PageSize:   pageSize,
+		Scope:      cursor.NewScope(username, &models.QueryParameters{Active: active}),
+	}
+
+	filter := "username = ?"
+	filterArgs := []interface{}{username}
+
+	sortKey := func(p periodEntity) (interface{}, string) {
+		return p.ID, p.ID
+	}
+
+	if active {
+		page.SortColumn = "end_date"
+		filter += " AND end_date > ?"
+		filterArgs = append(filterArgs, sqldb.FormatTime(time.Now()))
+
+		sortKey = func(p periodEntity) (interface{}, string) {
+			return sqldb.FormatTime(p.EndDate), p.ID
+		}
+	}
+
+	condition, args, err := page.Condition()
+	if err != nil {
+		return nil, "", err
+	}
+
+	entities, err := s.query(ctx, `SELECT `+periodColumns+` FROM periods WHERE `+filter+` AND `+condition+` `+
+		page.OrderBy(), append(filterArgs, args...)...)
+	if err != nil {
+		return nil, "", err
+	}
+
+	entities, nextKey, err := sqldb.Paginate(entities, page, sortKey, models.ErrPeriodsNotFound)
+	// The DynamoDB repository returns an empty page when there are no periods after the start key.
+	if errors.Is(err, models.ErrNoMoreItemsToBeRetrieved) {
+		return []*models.Period{}, "", nil
+	}
+
+	if err != nil {
+		return nil, "", err
+	}
+
+	return toPeriodModels(entities), nextKey, nil
+}
+
+func (s *SQLRepository) BatchGetPeriods(ctx context.Context, username string, periods []string) ([]*models.Period, error) {
+	if len(periods) == 0 {
+		return []*models.Period{}, nil
+	}
+
+	args := []interface{}{username}
+	for _, period := range periods {
+		args = append(args, period)
+	}
+
+	entities, err := s.query(ctx, `SELECT `+periodColumns+` FROM periods WHERE username = ? AND period IN `+
+		sqldb.In(len(periods)), args...)
+	if err != nil {
+		return nil, err
+	}
+
+	return toPeriodModels(entities), nil
+}
+
+// DeletePeriod deletes the period if it's at version.
+func (s *SQLRepository) DeletePeriod(ctx context.Context, periodID, username string, version int64) error {
+	result, err := s.db.ExecContext(ctx, `DELETE FROM periods WHERE username = ? AND period = ? AND version = ?`,
+		username, periodID, version)
+	if err != nil {
+		return fmt.Errorf("delete period failed: %v", err)
+	}
+
+	return s.db.CheckVersionedUpdate(ctx, result, models.ErrPeriodNotFound,
+		`SELECT 1 FROM periods WHERE username = ? AND period = ?`, username, periodID)
+}
+
+func (s *SQLRepository) BatchDeletePeriods(ctx context.Context, periods []*models.Period) error {
+	return s.db.WithTx(ctx, func(tx *sqldb.Tx) error {
+		for _, period := range periods {
+			_, err := tx.ExecContext(ctx, `DELETE FROM periods WHERE username = ? AND period = ?`, period.Username, period.ID)
+			if err != nil {
+				return fmt.Errorf("batch delete periods failed: %v", err)
+			}
+		}
+
+		return nil
+	})
+}
+
+func (s *SQLRepository) query(ctx context.Context, query string, args ...interface{}) ([]periodEntity, error) {
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("query periods failed: %v", err)
+	}
+
+	defer rows.Close()
+
+	entities := make([]periodEntity, 0)
+
+	for rows.Next() {
+		entity, err := scanPeriod(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		entities = append(entities, *entity)
+	}
+
+	return entities, rows.Err()
+}
+
+func getPeriodArgs(p periodEntity) ([]interface{}, error) {
+	categoryBudgets, err := sqldb.MarshalJSON(p.CategoryBudgets)
+	if err != nil {
+		return nil, err
+	}
+
+	return []interface{}{p.Username, p.ID, sqldb.NullString(p.Name), sqldb.FormatTime(p.StartDate),
+		sqldb.FormatTime(p.EndDate), sqldb.FormatTime(p.CreatedDate), sqldb.FormatTime(p.UpdatedDate),
+		categoryBudgets, p.Version}, nil
+}
+
+func scanPeriod(row sqldb.Scanner) (*periodEntity, error) {
+	entity := new(periodEntity)
+
+	var name, categoryBudgets sql.NullString
+	var startDate, endDate, createdDate, updatedDate string
+
+	err := row.Scan(&entity.Username, &entity.ID, &name, &startDate, &endDate, &createdDate, &updatedDate,
+		&categoryBudgets, &entity.Version)
+	if err != nil {
+		return nil, err
+	}
+
+	entity.Name = sqldb.StringPtr(name)
+
+	err = sqldb.UnmarshalJSON(categoryBudgets, &entity.CategoryBudgets)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, date := range []struct {
+		value string
+		dest  *time.Time
+	}{
+		{startDate, &entity.StartDate},
+		{endDate, &entity.EndDate},
+		{createdDate, &entity.CreatedDate},
+		{updatedDate, &entity.UpdatedDate},
+	} {
+		*date.dest, err = sqldb.ParseTime(date.value)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	entity.EndDatePeriod = dynamo.BuildEndDatePeriodKey(entity.ID, entity.EndDate)
+
+	return entity, nil
+}
@@ -0,0 +1,112 @@
+package savingoal
+
+import (
+	"context"
+	"github.com/JoelD7/money/backend/models"
+	"github.com/JoelD7/money/backend/storage/audit"
+)
+
+// auditedRepository records the writes made to the saving goals in the audit log.
+type auditedRepository struct {
+	Repository
+	recorder *audit.Recorder
+}
+
+func newAuditedRepository(repo Repository, recorder *audit.Recorder) Repository {
+	if recorder == nil {
+		return repo
+	}
+
+	return &auditedRepository{Repository: repo, recorder: recorder}
+}
+
+func newChange(username, savingGoalID string, old, new *models.SavingGoal) *audit.Change {
+	change := &audit.Change{Username: username, EntityType: models.AuditEntitySavingGoal, EntityID: savingGoalID}
+
+	if old != nil {
+		change.Old = old
+	}
+
+	if new != nil {
+		change.New = new
+	}
+
+	return change
+}
+
+func (a *auditedRepository) CreateSavingGoal(ctx context.Context, savingGoal *models.SavingGoal) (*models.SavingGoal, error) {
+	created, err := a.Repository.CreateSavingGoal(ctx, savingGoal)
+	if err != nil {
+		return nil, err
+	}
+
+	a.recorder.Record(ctx, newChange(created.Username, created.SavingGoalID, nil, created))
+
+	return created, nil
+}
+
+func (a *auditedRepository) BatchCreateSavingGoals(ctx context.Context, savingGoals []*models.SavingGoal) error {
+	err := a.Repository.BatchCreateSavingGoals(ctx, savingGoals)
+	if err != nil {
+		return err
+	}
+
+	changes := make([]*audit.Change, 0, len(savingGoals))
+
+	for _, savingGoal := range savingGoals {
+		changes = append(changes, newChange(savingGoal.Username, savingGoal.SavingGoalID, nil, savingGoal))
+	}
+
+	a.recorder.Record(ctx, changes...)
+
+	return nil
+}
+
+func (a *auditedRepository) DeleteSavingGoal(ctx context.Context, username, savingGoalID string, version int64) error {
+	old, err := a.Repository.GetSavingGoal(ctx, username, savingGoalID)
+	if err != nil {
+		return a.Repository.DeleteSavingGoal(ctx, username, savingGoalID, version)
+	}
+
+	err = a.Repository.DeleteSavingGoal(ctx, username, savingGoalID, version)
+	if err != nil {
+		return err
+	}
+
+	a.recorder.Record(ctx, newChange(username, savingGoalID, old, nil))
+
+	return nil
+}
+
+func (a *auditedRepository) BatchDeleteSavingGoals(ctx context.Context, savingGoals []*models.SavingGoal) error {
+	err := a.Repository.BatchDeleteSavingGoals(ctx, savingGoals)
+	if err != nil {
+		return err
+	}
+
+	changes := make([]*audit.Change, 0, len(savingGoals))
+
+	for _, savingGoal := range savingGoals {
+		changes = append(changes, newChange(savingGoal.Username, savingGoal.SavingGoalID, savingGoal, nil))
+	}
+
+	a.recorder.Record(ctx, changes...)
+
+	return nil
+}
+
+func (a *auditedRepository) UpdateSavingGoal(ctx context.Context, savingGoal *models.SavingGoal) (*models.SavingGoal, error) {
+	old, err := a.Repository.GetSavingGoal(ctx, savingGoal.Username, savingGoal.SavingGoalID)
+	if err != nil {
+		return a.Repository.UpdateSavingGoal(ctx, savingGoal)
+	}
+
+	updated, err := a.Repository.UpdateSavingGoal(ctx, savingGoal)
+	if err != nil {
+		return nil, err
+	}
+
+	a.recorder.Record(ctx, newChange(savingGoal.Username, savingGoal.SavingGoalID, old, updated))
+
+	return updated, nil
+}
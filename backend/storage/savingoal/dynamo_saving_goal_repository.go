@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"github.com/JoelD7/money/backend/models"
+	"github.com/JoelD7/money/backend/storage/cursor"
 	"github.com/JoelD7/money/backend/storage/dynamo"
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
@@ -69,6 +70,7 @@ func validateParams(envConfig *models.EnvironmentConfiguration) error {
 func (d *DynamoRepository) CreateSavingGoal(ctx context.Context, savingGoal *models.SavingGoal) (*models.SavingGoal, error) {
 	savingGoal.SavingGoalID = dynamo.GenerateID(savingGoalIDPrefix)
 	entity := toSavingGoalEntity(savingGoal)
+	entity.setInitialVersion()
 
 	now := time.Now()
 	entity.CreatedAt = &now
@@ -91,6 +93,39 @@ func (d *DynamoRepository) CreateSavingGoal(ctx context.Context, savingGoal *mod
 	return toSavingGoalModel(entity), nil
 }
 
+// BatchCreateSavingGoals creates the saving goals keeping their IDs. Saving goals without an ID get a new one.
+func (d *DynamoRepository) BatchCreateSavingGoals(ctx context.Context, savingGoals []*models.SavingGoal) error {
+	writeRequests := make([]types.WriteRequest, 0, len(savingGoals))
+	now := time.Now()
+
+	for _, savingGoal := range savingGoals {
+		if savingGoal.SavingGoalID == "" {
+			savingGoal.SavingGoalID = dynamo.GenerateID(savingGoalIDPrefix)
+		}
+
+		entity := toSavingGoalEntity(savingGoal)
+		entity.CreatedAt = &now
+		entity.setInitialVersion()
+
+		item, err := attributevalue.MarshalMap(entity)
+		if err != nil {
+			return fmt.Errorf("marshal saving goal item failed: %v", err)
+		}
+
+		writeRequests = append(writeRequests, types.WriteRequest{
+			PutRequest: &types.PutRequest{Item: item},
+		})
+	}
+
+	input := &dynamodb.BatchWriteItemInput{
+		RequestItems: map[string][]types.WriteRequest{
+			d.tableName: writeRequests,
+		},
+	}
+
+	return dynamo.BatchWrite(ctx, d.dynamoClient, input)
+}
+
 func (d *DynamoRepository) GetSavingGoal(ctx context.Context, username, savingGoalID string) (*models.SavingGoal, error) {
 	userKey, err := attributevalue.Marshal(username)
 	if err != nil {
@@ -151,7 +186,7 @@ func (d *DynamoRepository) GetSavingGoals(ctx context.Context, username string,
 		return nil, "", fmt.Errorf("unmarshal saving goal items failed: %v", err)
 	}
 
-	nextKey, err := dynamo.EncodePaginationKey(result.LastEvaluatedKey)
+	nextKey, err := dynamo.EncodePaginationKey(result.LastEvaluatedKey, cursor.NewScope(username, params))
 	if err != nil {
 		return nil, "", err
 	}
@@ -173,7 +208,7 @@ func (d *DynamoRepository) buildQueryInput(username string, params *models.Query
 
 	keyConditionEx := d.setQueryIndex(input, username, params)
 
-	err = dynamo.SetExclusiveStartKey(params.StartKey, input)
+	err = dynamo.SetExclusiveStartKey(params.StartKey, cursor.NewScope(username, params), input)
 	if err != nil {
 		return nil, err
 	}
@@ -211,8 +246,11 @@ func (d *DynamoRepository) setQueryIndex(input *dynamodb.QueryInput, username st
 	return keyConditionEx
 }
 
+// UpdateSavingGoal replaces the saving goal. It fails with models.ErrVersionConflict if the stored saving goal isn't at
+// the version of the given one.
 func (d *DynamoRepository) UpdateSavingGoal(ctx context.Context, savingGoal *models.SavingGoal) (*models.SavingGoal, error) {
 	entity := toSavingGoalEntity(savingGoal)
+	entity.Version = savingGoal.Version + 1
 
 	now := time.Now()
 	entity.UpdatedAt = &now
@@ -222,13 +260,27 @@ func (d *DynamoRepository) UpdateSavingGoal(ctx context.Context, savingGoal *mod
 		return nil, fmt.Errorf("marshal saving goal item failed: %v", err)
 	}
 
+	cond := expression.Name("saving_goal_id").AttributeExists().And(dynamo.VersionCondition(savingGoal.Version))
+
+	expr, err := expression.NewBuilder().WithCondition(cond).Build()
+	if err != nil {
+		return nil, fmt.Errorf("build expression failed: %v", err)
+	}
+
 	input := &dynamodb.PutItemInput{
-		TableName:           aws.String(d.tableName),
-		Item:                av,
-		ConditionExpression: aws.String("attribute_exists(saving_goal_id)"),
+		TableName:                           aws.String(d.tableName),
+		Item:                                av,
+		ConditionExpression:                 expr.Condition(),
+		ExpressionAttributeNames:            expr.Names(),
+		ExpressionAttributeValues:           expr.Values(),
+		ReturnValuesOnConditionCheckFailure: types.ReturnValuesOnConditionCheckFailureAllOld,
 	}
 
 	_, err = d.dynamoClient.PutItem(ctx, input)
+	if dynamo.IsVersionConflict(err) {
+		return nil, fmt.Errorf("%v: %w", err, models.ErrVersionConflict)
+	}
+
 	if err != nil && strings.Contains(err.Error(), "ConditionalCheckFailedException") {
 		return nil, fmt.Errorf("%v: %w", err, models.ErrSavingGoalNotFound)
 	}
@@ -240,16 +292,36 @@ func (d *DynamoRepository) UpdateSavingGoal(ctx context.Context, savingGoal *mod
 	return toSavingGoalModel(entity), nil
 }
 
-func (d *DynamoRepository) DeleteSavingGoal(ctx context.Context, username, savingGoalID string) error {
+// DeleteSavingGoal deletes the saving goal if it's at version.
+func (d *DynamoRepository) DeleteSavingGoal(ctx context.Context, username, savingGoalID string, version int64) error {
+	cond := expression.Name("saving_goal_id").AttributeExists().And(dynamo.VersionCondition(version))
+
+	expr, err := expression.NewBuilder().WithCondition(cond).Build()
+	if err != nil {
+		return fmt.Errorf("build condition expression failed: %v", err)
+	}
+
 	input := &dynamodb.DeleteItemInput{
 		TableName: aws.String(d.tableName),
 		Key: map[string]types.AttributeValue{
 			"username":       &types.AttributeValueMemberS{Value: username},
 			"saving_goal_id": &types.AttributeValueMemberS{Value: savingGoalID},
 		},
+		ConditionExpression:                 expr.Condition(),
+		ExpressionAttributeNames:            expr.Names(),
+		ExpressionAttributeValues:           expr.Values(),
+		ReturnValuesOnConditionCheckFailure: types.ReturnValuesOnConditionCheckFailureAllOld,
+	}
+
+	_, err = d.dynamoClient.DeleteItem(ctx, input)
+	if dynamo.IsVersionConflict(err) {
+		return fmt.Errorf("%v: %w", err, models.ErrVersionConflict)
+	}
+
+	if err != nil && strings.Contains(err.Error(), "ConditionalCheckFailedException") {
+		return fmt.Errorf("%v: %w", err, models.ErrSavingGoalNotFound)
 	}
 
-	_, err := d.dynamoClient.DeleteItem(ctx, input)
 	if err != nil {
 		return fmt.Errorf("delete saving goal item failed: %v", err)
 	}
@@ -257,6 +329,29 @@ func (d *DynamoRepository) DeleteSavingGoal(ctx context.Context, username, savin
 	return nil
 }
 
+func (d *DynamoRepository) BatchDeleteSavingGoals(ctx context.Context, savingGoals []*models.SavingGoal) error {
+	writeRequests := make([]types.WriteRequest, 0, len(savingGoals))
+
+	for _, savingGoal := range savingGoals {
+		writeRequests = append(writeRequests, types.WriteRequest{
+			DeleteRequest: &types.DeleteRequest{
+				Key: map[string]types.AttributeValue{
+					"username":       &types.AttributeValueMemberS{Value: savingGoal.Username},
+					"saving_goal_id": &types.AttributeValueMemberS{Value: savingGoal.SavingGoalID},
+				},
+			},
+		})
+	}
+
+	input := &dynamodb.BatchWriteItemInput{
+		RequestItems: map[string][]types.WriteRequest{
+			d.tableName: writeRequests,
+		},
+	}
+
+	return dynamo.BatchWrite(ctx, d.dynamoClient, input)
+}
+
 func (d *DynamoRepository) GetAllRecurringSavingGoals(ctx context.Context, username string) ([]*models.SavingGoal, error) {
 	keyExpr := expression.Key("username").Equal(expression.Value(username))
 	recurrentFilter := expression.Name("is_recurring").Equal(expression.Value(true))
@@ -0,0 +1,239 @@
+package savingoal
+
+import (
+	"context"
+	"github.com/JoelD7/money/backend/models"
+	"github.com/JoelD7/money/backend/storage/cursor"
+	"github.com/JoelD7/money/backend/storage/dynamo"
+	"github.com/JoelD7/money/backend/storage/memory"
+	"sync"
+	"time"
+)
+
+// MemoryRepository is a thread-safe implementation of Repository that keeps the saving goals in memory. Queries are
+// sorted and paginated as they would be by the indexes of the DynamoDB table.
+type MemoryRepository struct {
+	mu sync.RWMutex
+	// savingGoals holds the saving goals by username and saving goal ID.
+	savingGoals map[string]map[string]*savingGoalEntity
+}
+
+func NewMemoryRepository() *MemoryRepository {
+	return &MemoryRepository{
+		savingGoals: make(map[string]map[string]*savingGoalEntity),
+	}
+}
+
+func (m *MemoryRepository) CreateSavingGoal(ctx context.Context, savingGoal *models.SavingGoal) (*models.SavingGoal, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	savingGoal.SavingGoalID = dynamo.GenerateID(savingGoalIDPrefix)
+	entity := toSavingGoalEntity(savingGoal)
+	entity.setInitialVersion()
+
+	now := time.Now()
+	entity.CreatedAt = &now
+
+	err := m.put(entity)
+	if err != nil {
+		return nil, err
+	}
+
+	return toSavingGoalModel(entity), nil
+}
+
+// BatchCreateSavingGoals creates the saving goals keeping their IDs. Saving goals without an ID get a new one.
+func (m *MemoryRepository) BatchCreateSavingGoals(ctx context.Context, savingGoals []*models.SavingGoal) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := time.Now()
+
+	for _, savingGoal := range savingGoals {
+		if savingGoal.SavingGoalID == "" {
+			savingGoal.SavingGoalID = dynamo.GenerateID(savingGoalIDPrefix)
+		}
+
+		entity := toSavingGoalEntity(savingGoal)
+		entity.CreatedAt = &now
+		entity.setInitialVersion()
+
+		err := m.put(entity)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (m *MemoryRepository) put(entity *savingGoalEntity) error {
+	copied, err := memory.Copy(entity)
+	if err != nil {
+		return err
+	}
+
+	if m.savingGoals[entity.Username] == nil {
+		m.savingGoals[entity.Username] = make(map[string]*savingGoalEntity)
+	}
+
+	m.savingGoals[entity.Username][entity.SavingGoalID] = copied
+
+	return nil
+}
+
+// UpdateSavingGoal replaces the stored saving goal with the given one, as the DynamoDB repository does.
+func (m *MemoryRepository) UpdateSavingGoal(ctx context.Context, savingGoal *models.SavingGoal) (*models.SavingGoal, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	stored, ok := m.savingGoals[savingGoal.Username][savingGoal.SavingGoalID]
+	if !ok {
+		return nil, models.ErrSavingGoalNotFound
+	}
+
+	if stored.Version != savingGoal.Version {
+		return nil, models.ErrVersionConflict
+	}
+
+	entity := toSavingGoalEntity(savingGoal)
+	entity.Version = savingGoal.Version + 1
+
+	now := time.Now()
+	entity.UpdatedAt = &now
+
+	err := m.put(entity)
+	if err != nil {
+		return nil, err
+	}
+
+	return toSavingGoalModel(entity), nil
+}
+
+func (m *MemoryRepository) GetSavingGoal(ctx context.Context, username, savingGoalID string) (*models.SavingGoal, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	entity, ok := m.savingGoals[username][savingGoalID]
+	if !ok {
+		return nil, models.ErrSavingGoalNotFound
+	}
+
+	copied, err := memory.Copy(entity)
+	if err != nil {
+		return nil, err
+	}
+
+	return toSavingGoalModel(copied), nil
+}
+
+func (m *MemoryRepository) GetSavingGoals(ctx context.Context, username string, params *models.QueryParameters) ([]*models.SavingGoal, string, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	entities, err := m.filter(username, func(entity *savingGoalEntity) bool {
+		return true
+	})
+	if err != nil {
+		return nil, "", err
+	}
+
+	page, nextKey, err := memory.Paginate(entities, getSortKey(params), params.StartKey, cursor.NewScope(username, params),
+		params.PageSize, params.SortType == string(models.SortOrderDescending), models.ErrSavingGoalsNotFound)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return toSavingGoalModels(page), nextKey, nil
+}
+
+// getSortKey returns the sort key of the index the DynamoDB repository would query with params.
+func getSortKey(params *models.QueryParameters) func(s *savingGoalEntity) string {
+	switch params.SortBy {
+	case string(models.SortParamDeadline):
+		return func(s *savingGoalEntity) string {
+			return dynamo.BuildCreatedDateEntityIDKey(s.Deadline, s.SavingGoalID)
+		}
+	case string(models.SortParamTarget):
+		return func(s *savingGoalEntity) string {
+			return dynamo.BuildAmountKey(s.Target, s.SavingGoalID)
+		}
+	case string(models.SortParamName):
+		return func(s *savingGoalEntity) string {
+			return s.NameSavingGoalID
+		}
+	default:
+		return func(s *savingGoalEntity) string {
+			return s.SavingGoalID
+		}
+	}
+}
+
+func (m *MemoryRepository) GetAllRecurringSavingGoals(ctx context.Context, username string) ([]*models.SavingGoal, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	entities, err := m.filter(username, func(entity *savingGoalEntity) bool {
+		return entity.IsRecurring
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(entities) == 0 {
+		return nil, models.ErrSavingGoalsNotFound
+	}
+
+	return toSavingGoalModels(entities), nil
+}
+
+// filter returns copies of the saving goals of the user that match.
+func (m *MemoryRepository) filter(username string, match func(entity *savingGoalEntity) bool) ([]*savingGoalEntity, error) {
+	entities := make([]*savingGoalEntity, 0)
+
+	for _, entity := range m.savingGoals[username] {
+		if !match(entity) {
+			continue
+		}
+
+		copied, err := memory.Copy(entity)
+		if err != nil {
+			return nil, err
+		}
+
+		entities = append(entities, copied)
+	}
+
+	return entities, nil
+}
+
+// DeleteSavingGoal deletes the saving goal if it's at version.
+func (m *MemoryRepository) DeleteSavingGoal(ctx context.Context, username, savingGoalID string, version int64) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	stored, ok := m.savingGoals[username][savingGoalID]
+	if !ok {
+		return models.ErrSavingGoalNotFound
+	}
+
+	if stored.Version != version {
+		return models.ErrVersionConflict
+	}
+
+	delete(m.savingGoals[username], savingGoalID)
+
+	return nil
+}
+
+func (m *MemoryRepository) BatchDeleteSavingGoals(ctx context.Context, savingGoals []*models.SavingGoal) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, savingGoal := range savingGoals {
+		delete(m.savingGoals[savingGoal.Username], savingGoal.SavingGoalID)
+	}
+
+	return nil
+}
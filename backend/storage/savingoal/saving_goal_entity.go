@@ -8,16 +8,18 @@ import (
 )
 
 type savingGoalEntity struct {
-	SavingGoalID     string     `json:"saving_goal_id,omitempty" dynamodbav:"saving_goal_id"`
-	Username         string     `json:"username,omitempty" dynamodbav:"username"`
-	Name             string     `json:"name,omitempty" dynamodbav:"name"`
-	Target           float64    `json:"target,omitempty" dynamodbav:"target"`
-	Deadline         time.Time  `json:"deadline,omitempty" dynamodbav:"deadline"`
-	CreatedAt        *time.Time `json:"created_at,omitempty" dynamodbav:"created_at"`
-	UpdatedAt        *time.Time `json:"updated_at,omitempty" dynamodbav:"updated_at"`
-	IsRecurring      bool       `json:"is_recurring,omitempty" dynamodbav:"is_recurring"`
-	RecurringAmount  float64    `json:"recurring_amount,omitempty" dynamodbav:"recurring_amount"`
-	NameSavingGoalID string     `json:"name-saving_goal_id,omitempty" dynamodbav:"name-saving_goal_id"`
+	SavingGoalID     string       `json:"saving_goal_id,omitempty" dynamodbav:"saving_goal_id"`
+	Username         string       `json:"username,omitempty" dynamodbav:"username"`
+	Name             string       `json:"name,omitempty" dynamodbav:"name"`
+	Target           models.Money `json:"target,omitempty" dynamodbav:"target"`
+	Currency         string       `json:"currency,omitempty" dynamodbav:"currency,omitempty"`
+	Deadline         time.Time    `json:"deadline,omitempty" dynamodbav:"deadline"`
+	CreatedAt        *time.Time   `json:"created_at,omitempty" dynamodbav:"created_at"`
+	UpdatedAt        *time.Time   `json:"updated_at,omitempty" dynamodbav:"updated_at"`
+	IsRecurring      bool         `json:"is_recurring,omitempty" dynamodbav:"is_recurring"`
+	RecurringAmount  models.Money `json:"recurring_amount,omitempty" dynamodbav:"recurring_amount"`
+	NameSavingGoalID string       `json:"name-saving_goal_id,omitempty" dynamodbav:"name-saving_goal_id"`
+	Version          int64        `json:"version,omitempty" dynamodbav:"version,omitempty"`
 }
 
 func toSavingGoalEntity(s *models.SavingGoal) *savingGoalEntity {
@@ -26,10 +28,12 @@ func toSavingGoalEntity(s *models.SavingGoal) *savingGoalEntity {
 		Username:         s.GetUsername(),
 		Name:             s.GetName(),
 		Target:           s.GetTarget(),
+		Currency:         s.GetCurrency(),
 		Deadline:         s.GetDeadline(),
 		IsRecurring:      s.GetIsRecurring(),
 		RecurringAmount:  s.GetRecurringAmount(),
 		NameSavingGoalID: dynamo.BuildNameKey(s.GetName(), s.GetSavingGoalID()),
+		Version:          s.Version,
 	}
 }
 
@@ -44,9 +48,11 @@ func toSavingGoalModel(s *savingGoalEntity) *models.SavingGoal {
 		Username:        s.Username,
 		Name:            namePtr,
 		Target:          targetPtr,
+		Currency:        s.Currency,
 		Deadline:        deadlinePtr,
 		IsRecurring:     s.IsRecurring,
 		RecurringAmount: recurringAmountPtr,
+		Version:         s.Version,
 	}
 }
 
@@ -59,3 +65,10 @@ func toSavingGoalModels(entities []*savingGoalEntity) []*models.SavingGoal {
 
 	return savingGoals
 }
+
+// setInitialVersion sets the version of a saving goal that's being created, unless it already has one.
+func (s *savingGoalEntity) setInitialVersion() {
+	if s.Version == 0 {
+		s.Version = 1
+	}
+}
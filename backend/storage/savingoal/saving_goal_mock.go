@@ -28,7 +28,7 @@ func (m *Mock) GetSavingGoal(ctx context.Context, username, savingGoalID string)
 	}
 
 	name := "mocked_name"
-	target := float64(1500)
+	target := models.NewMoney(1500)
 	deadline := time.Now().Add(time.Hour * 24 * 30 * 6)
 
 	return &models.SavingGoal{
@@ -44,21 +44,29 @@ func (m *Mock) CreateSavingGoal(ctx context.Context, savingGoal *models.SavingGo
 	return nil, nil
 }
 
+func (m *Mock) BatchCreateSavingGoals(ctx context.Context, savingGoals []*models.SavingGoal) error {
+	return m.mockedErr
+}
+
 func (m *Mock) UpdateSavingGoal(ctx context.Context, savingGoal *models.SavingGoal) (*models.SavingGoal, error) {
 	return nil, nil
 }
 
-func (m *Mock) DeleteSavingGoal(ctx context.Context, username, savingGoalID string) error {
+func (m *Mock) DeleteSavingGoal(ctx context.Context, username, savingGoalID string, version int64) error {
 	return nil
 }
 
+func (m *Mock) BatchDeleteSavingGoals(ctx context.Context, savingGoals []*models.SavingGoal) error {
+	return m.mockedErr
+}
+
 func (m *Mock) GetSavingGoals(ctx context.Context, username string, params *models.QueryParameters) ([]*models.SavingGoal, string, error) {
 	if m.mockedErr != nil {
 		return nil, "", m.mockedErr
 	}
 
 	name := "mocked_name"
-	target := float64(1500)
+	target := models.NewMoney(1500)
 	deadline := time.Now().Add(time.Hour * 24 * 30 * 6)
 
 	return []*models.SavingGoal{
@@ -3,13 +3,43 @@ package savingoal
 import (
 	"context"
 	"github.com/JoelD7/money/backend/models"
+	"github.com/JoelD7/money/backend/storage/audit"
+	"github.com/JoelD7/money/backend/storage/sqldb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
 )
 
 type Repository interface {
 	CreateSavingGoal(ctx context.Context, savingGoal *models.SavingGoal) (*models.SavingGoal, error)
+	BatchCreateSavingGoals(ctx context.Context, savingGoals []*models.SavingGoal) error
 	UpdateSavingGoal(ctx context.Context, savingGoal *models.SavingGoal) (*models.SavingGoal, error)
 	GetSavingGoal(ctx context.Context, username, savingGoalID string) (*models.SavingGoal, error)
 	GetSavingGoals(ctx context.Context, username string, params *models.QueryParameters) ([]*models.SavingGoal, string, error)
 	GetAllRecurringSavingGoals(ctx context.Context, username string) ([]*models.SavingGoal, error)
-	DeleteSavingGoal(ctx context.Context, username, savingGoalID string) error
+	DeleteSavingGoal(ctx context.Context, username, savingGoalID string, version int64) error
+	BatchDeleteSavingGoals(ctx context.Context, savingGoals []*models.SavingGoal) error
+}
+
+// NewRepository returns the repository of the storage backend set in the configuration. Its writes are recorded in the
+// audit log when it's enabled.
+func NewRepository(ctx context.Context, dynamoClient *dynamodb.Client, envConfig *models.EnvironmentConfiguration) (Repository, error) {
+	recorder, err := audit.NewRecorder(ctx, dynamoClient, envConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	if envConfig.StorageBackend != models.StorageBackendSQL {
+		repo, err := NewDynamoRepository(dynamoClient, envConfig)
+		if err != nil {
+			return nil, err
+		}
+
+		return newAuditedRepository(repo, recorder), nil
+	}
+
+	db, err := sqldb.Connect(ctx, envConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	return newAuditedRepository(NewSQLRepository(db), recorder), nil
 }
@@ -0,0 +1,264 @@
+package savingoal
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/JoelD7/money/backend/models"
+	"github.com/JoelD7/money/backend/storage/cursor"
+	"github.com/JoelD7/money/backend/storage/dynamo"
+	"github.com/JoelD7/money/backend/storage/sqldb"
+	"strings"
+	"time"
+)
+
+const savingGoalColumns = `username, saving_goal_id, name, target, currency, deadline, created_at, updated_at,
+	is_recurring, recurring_amount, version`
+
+type SQLRepository struct {
+	db *sqldb.DB
+}
+
+func NewSQLRepository(db *sqldb.DB) *SQLRepository {
+	return &SQLRepository{db: db}
+}
+
+func (s *SQLRepository) CreateSavingGoal(ctx context.Context, savingGoal *models.SavingGoal) (*models.SavingGoal, error) {
+	savingGoal.SavingGoalID = dynamo.GenerateID(savingGoalIDPrefix)
+	entity := toSavingGoalEntity(savingGoal)
+	entity.setInitialVersion()
+	now := time.Now()
+	entity.CreatedAt = &now
+
+	_, err := s.db.ExecContext(ctx, `INSERT INTO saving_goals (`+savingGoalColumns+`)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`, getSavingGoalArgs(entity)...)
+	if err != nil {
+		return nil, fmt.Errorf("insert saving goal failed: %v", err)
+	}
+
+	return toSavingGoalModel(entity), nil
+}
+
+// BatchCreateSavingGoals creates the saving goals keeping their IDs. Saving goals without an ID get a new one.
+func (s *SQLRepository) BatchCreateSavingGoals(ctx context.Context, savingGoals []*models.SavingGoal) error {
+	now := time.Now()
+
+	return s.db.WithTx(ctx, func(tx *sqldb.Tx) error {
+		for _, savingGoal := range savingGoals {
+			if savingGoal.SavingGoalID == "" {
+				savingGoal.SavingGoalID = dynamo.GenerateID(savingGoalIDPrefix)
+			}
+
+			entity := toSavingGoalEntity(savingGoal)
+			entity.CreatedAt = &now
+			entity.setInitialVersion()
+
+			_, err := tx.ExecContext(ctx, `INSERT INTO saving_goals (`+savingGoalColumns+`)
+				VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?) ON CONFLICT (username, saving_goal_id) DO UPDATE SET
+				name = excluded.name, target = excluded.target, currency = excluded.currency,
+				deadline = excluded.deadline, created_at = excluded.created_at, updated_at = excluded.updated_at,
+				is_recurring = excluded.is_recurring, recurring_amount = excluded.recurring_amount,
+				version = excluded.version`,
+				getSavingGoalArgs(entity)...)
+			if err != nil {
+				return fmt.Errorf("batch write saving goals failed: %v", err)
+			}
+		}
+
+		return nil
+	})
+}
+
+// UpdateSavingGoal replaces the stored saving goal with the given one, as the DynamoDB repository does. The creation
+// date is kept, and the update fails with models.ErrVersionConflict if the stored saving goal is at another version.
+func (s *SQLRepository) UpdateSavingGoal(ctx context.Context, savingGoal *models.SavingGoal) (*models.SavingGoal, error) {
+	entity := toSavingGoalEntity(savingGoal)
+	entity.Version = savingGoal.Version + 1
+	now := time.Now()
+	entity.UpdatedAt = &now
+
+	result, err := s.db.ExecContext(ctx, `UPDATE saving_goals SET name = ?, target = ?, currency = ?, deadline = ?,
+		updated_at = ?, is_recurring = ?, recurring_amount = ?, version = ?
+		WHERE username = ? AND saving_goal_id = ? AND version = ?`,
+		entity.Name, entity.Target, entity.Currency, sqldb.FormatTime(entity.Deadline), sqldb.FormatNullTime(entity.UpdatedAt),
+		entity.IsRecurring, entity.RecurringAmount, entity.Version, entity.Username, entity.SavingGoalID, savingGoal.Version)
+	if err != nil {
+		return nil, fmt.Errorf("update saving goal failed: %v", err)
+	}
+
+	err = s.db.CheckVersionedUpdate(ctx, result, models.ErrSavingGoalNotFound,
+		`SELECT 1 FROM saving_goals WHERE username = ? AND saving_goal_id = ?`, entity.Username, entity.SavingGoalID)
+	if err != nil {
+		return nil, err
+	}
+
+	return toSavingGoalModel(entity), nil
+}
+
+func (s *SQLRepository) GetSavingGoal(ctx context.Context, username, savingGoalID string) (*models.SavingGoal, error) {
+	row := s.db.QueryRowContext(ctx, `SELECT `+savingGoalColumns+` FROM saving_goals
+		WHERE username = ? AND saving_goal_id = ?`, username, savingGoalID)
+
+	entity, err := scanSavingGoal(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, models.ErrSavingGoalNotFound
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return toSavingGoalModel(entity), nil
+}
+
+func (s *SQLRepository) GetSavingGoals(ctx context.Context, username string, params *models.QueryParameters) ([]*models.SavingGoal, string, error) {
+	page := &sqldb.Page{
+		SortColumn: "saving_goal_id",
+		IDColumn:   "saving_goal_id",
+		Descending: params.SortType == string(models.SortOrderDescending),
+		StartKey:   params.StartKey,
+		PageSize:   params.PageSize,
+		Scope:      cursor.NewScope(username, params),
+	}
+
+	sortKey := func(s *savingGoalEntity) (interface{}, string) {
+		return s.SavingGoalID, s.SavingGoalID
+	}
+
+	switch params.SortBy {
+	case string(models.SortParamDeadline):
+		page.SortColumn = "deadline"
+		sortKey = func(s *savingGoalEntity) (interface{}, string) {
+			return sqldb.FormatTime(s.Deadline), s.SavingGoalID
+		}
+	case string(models.SortParamTarget):
+		page.SortColumn = "target"
+		sortKey = func(s *savingGoalEntity) (interface{}, string) {
+			return int64(s.Target), s.SavingGoalID
+		}
+	case string(models.SortParamName):
+		page.SortColumn = "lower(name)"
+		sortKey = func(s *savingGoalEntity) (interface{}, string) {
+			return strings.ToLower(s.Name), s.SavingGoalID
+		}
+	}
+
+	condition, args, err := page.Condition()
+	if err != nil {
+		return nil, "", err
+	}
+
+	entities, err := s.query(ctx, `SELECT `+savingGoalColumns+` FROM saving_goals WHERE username = ? AND `+condition+
+		` `+page.OrderBy(), append([]interface{}{username}, args...)...)
+	if err != nil {
+		return nil, "", err
+	}
+
+	entities, nextKey, err := sqldb.Paginate(entities, page, sortKey, models.ErrSavingGoalsNotFound)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return toSavingGoalModels(entities), nextKey, nil
+}
+
+func (s *SQLRepository) GetAllRecurringSavingGoals(ctx context.Context, username string) ([]*models.SavingGoal, error) {
+	entities, err := s.query(ctx, `SELECT `+savingGoalColumns+` FROM saving_goals WHERE username = ? AND is_recurring = ?
+		ORDER BY saving_goal_id`, username, true)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(entities) == 0 {
+		return nil, models.ErrSavingGoalsNotFound
+	}
+
+	return toSavingGoalModels(entities), nil
+}
+
+// DeleteSavingGoal deletes the saving goal if it's at version.
+func (s *SQLRepository) DeleteSavingGoal(ctx context.Context, username, savingGoalID string, version int64) error {
+	result, err := s.db.ExecContext(ctx, `DELETE FROM saving_goals WHERE username = ? AND saving_goal_id = ? AND version = ?`,
+		username, savingGoalID, version)
+	if err != nil {
+		return fmt.Errorf("delete saving goal failed: %v", err)
+	}
+
+	return s.db.CheckVersionedUpdate(ctx, result, models.ErrSavingGoalNotFound,
+		`SELECT 1 FROM saving_goals WHERE username = ? AND saving_goal_id = ?`, username, savingGoalID)
+}
+
+func (s *SQLRepository) BatchDeleteSavingGoals(ctx context.Context, savingGoals []*models.SavingGoal) error {
+	return s.db.WithTx(ctx, func(tx *sqldb.Tx) error {
+		for _, savingGoal := range savingGoals {
+			_, err := tx.ExecContext(ctx, `DELETE FROM saving_goals WHERE username = ? AND saving_goal_id = ?`,
+				savingGoal.Username, savingGoal.SavingGoalID)
+			if err != nil {
+				return fmt.Errorf("batch delete saving goals failed: %v", err)
+			}
+		}
+
+		return nil
+	})
+}
+
+func (s *SQLRepository) query(ctx context.Context, query string, args ...interface{}) ([]*savingGoalEntity, error) {
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("query saving goals failed: %v", err)
+	}
+
+	defer rows.Close()
+
+	entities := make([]*savingGoalEntity, 0)
+
+	for rows.Next() {
+		entity, err := scanSavingGoal(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		entities = append(entities, entity)
+	}
+
+	return entities, rows.Err()
+}
+
+func getSavingGoalArgs(s *savingGoalEntity) []interface{} {
+	return []interface{}{s.Username, s.SavingGoalID, s.Name, s.Target, s.Currency, sqldb.FormatTime(s.Deadline),
+		sqldb.FormatNullTime(s.CreatedAt), sqldb.FormatNullTime(s.UpdatedAt), s.IsRecurring, s.RecurringAmount,
+		s.Version}
+}
+
+func scanSavingGoal(row sqldb.Scanner) (*savingGoalEntity, error) {
+	entity := new(savingGoalEntity)
+
+	var deadline string
+	var createdAt, updatedAt sql.NullString
+
+	err := row.Scan(&entity.Username, &entity.SavingGoalID, &entity.Name, &entity.Target, &entity.Currency, &deadline,
+		&createdAt, &updatedAt, &entity.IsRecurring, &entity.RecurringAmount, &entity.Version)
+	if err != nil {
+		return nil, err
+	}
+
+	entity.Deadline, err = sqldb.ParseTime(deadline)
+	if err != nil {
+		return nil, err
+	}
+
+	entity.CreatedAt, err = sqldb.ParseNullTime(createdAt)
+	if err != nil {
+		return nil, err
+	}
+
+	entity.UpdatedAt, err = sqldb.ParseNullTime(updatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	entity.NameSavingGoalID = dynamo.BuildNameKey(entity.Name, entity.SavingGoalID)
+
+	return entity, nil
+}
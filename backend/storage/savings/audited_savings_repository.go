@@ -0,0 +1,158 @@
+package savings
+
+import (
+	"context"
+	"github.com/JoelD7/money/backend/models"
+	"github.com/JoelD7/money/backend/storage/audit"
+)
+
+// auditedRepository records the writes made to the savings in the audit log.
+type auditedRepository struct {
+	Repository
+	recorder *audit.Recorder
+}
+
+func newAuditedRepository(repo Repository, recorder *audit.Recorder) Repository {
+	if recorder == nil {
+		return repo
+	}
+
+	return &auditedRepository{Repository: repo, recorder: recorder}
+}
+
+func newChange(username, savingID string, old, new *models.Saving) *audit.Change {
+	change := &audit.Change{Username: username, EntityType: models.AuditEntitySaving, EntityID: savingID}
+
+	if old != nil {
+		change.Old = old
+	}
+
+	if new != nil {
+		change.New = new
+	}
+
+	return change
+}
+
+func (a *auditedRepository) CreateSaving(ctx context.Context, saving *models.Saving) (*models.Saving, error) {
+	created, err := a.Repository.CreateSaving(ctx, saving)
+	if err != nil {
+		return nil, err
+	}
+
+	a.recorder.Record(ctx, newChange(created.Username, created.SavingID, nil, created))
+
+	return created, nil
+}
+
+func (a *auditedRepository) BatchCreateSavings(ctx context.Context, savings []*models.Saving) error {
+	err := a.Repository.BatchCreateSavings(ctx, savings)
+	if err != nil {
+		return err
+	}
+
+	changes := make([]*audit.Change, 0, len(savings))
+
+	for _, saving := range savings {
+		changes = append(changes, newChange(saving.Username, saving.SavingID, nil, saving))
+	}
+
+	a.recorder.Record(ctx, changes...)
+
+	return nil
+}
+
+// UpdateSaving records the saving as it's stored before and after the update, as only the fields that are set are
+// updated.
+func (a *auditedRepository) UpdateSaving(ctx context.Context, saving *models.Saving) error {
+	old, err := a.Repository.GetSaving(ctx, saving.Username, saving.SavingID)
+	if err != nil {
+		return a.Repository.UpdateSaving(ctx, saving)
+	}
+
+	err = a.Repository.UpdateSaving(ctx, saving)
+	if err != nil {
+		return err
+	}
+
+	updated, err := a.Repository.GetSaving(ctx, saving.Username, saving.SavingID)
+	if err != nil {
+		updated = saving
+	}
+
+	a.recorder.Record(ctx, newChange(saving.Username, saving.SavingID, old, updated))
+
+	return nil
+}
+
+func (a *auditedRepository) ReplaceSaving(ctx context.Context, saving *models.Saving) error {
+	old, err := a.Repository.GetSaving(ctx, saving.Username, saving.SavingID)
+	if err != nil {
+		return a.Repository.ReplaceSaving(ctx, saving)
+	}
+
+	err = a.Repository.ReplaceSaving(ctx, saving)
+	if err != nil {
+		return err
+	}
+
+	a.recorder.Record(ctx, newChange(saving.Username, saving.SavingID, old, saving))
+
+	return nil
+}
+
+func (a *auditedRepository) DeleteSaving(ctx context.Context, savingID, username string, version int64) error {
+	old, err := a.Repository.GetSaving(ctx, username, savingID)
+	if err != nil {
+		return a.Repository.DeleteSaving(ctx, savingID, username, version)
+	}
+
+	err = a.Repository.DeleteSaving(ctx, savingID, username, version)
+	if err != nil {
+		return err
+	}
+
+	a.recorder.Record(ctx, newChange(username, savingID, old, nil))
+
+	return nil
+}
+
+func (a *auditedRepository) BatchDeleteSavings(ctx context.Context, savings []*models.Saving) error {
+	err := a.Repository.BatchDeleteSavings(ctx, savings)
+	if err != nil {
+		return err
+	}
+
+	changes := make([]*audit.Change, 0, len(savings))
+
+	for _, saving := range savings {
+		changes = append(changes, newChange(saving.Username, saving.SavingID, saving, nil))
+	}
+
+	a.recorder.Record(ctx, changes...)
+
+	return nil
+}
+
+func (a *auditedRepository) BatchUpdateSavings(ctx context.Context, savings []*models.Saving) error {
+	changes := make([]*audit.Change, 0, len(savings))
+
+	for _, saving := range savings {
+		// Savings that can't be read aren't recorded, as the update would fail for them anyway.
+		old, err := a.Repository.GetSaving(ctx, saving.Username, saving.SavingID)
+		if err != nil {
+			continue
+		}
+
+		changes = append(changes, newChange(saving.Username, saving.SavingID, old, saving))
+	}
+
+	err := a.Repository.BatchUpdateSavings(ctx, savings)
+	if err != nil {
+		return err
+	}
+
+	a.recorder.Record(ctx, changes...)
+
+	return nil
+}
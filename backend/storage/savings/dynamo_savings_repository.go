@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"github.com/JoelD7/money/backend/models"
 	"github.com/JoelD7/money/backend/shared/logger"
+	"github.com/JoelD7/money/backend/storage/cursor"
 	"github.com/JoelD7/money/backend/storage/dynamo"
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
@@ -153,7 +154,7 @@ func (d *DynamoRepository) GetSavings(ctx context.Context, username string, para
 		return nil, "", fmt.Errorf("unmarshal savings items failed: %v", err)
 	}
 
-	nextKey, err := dynamo.EncodePaginationKey(result.LastEvaluatedKey)
+	nextKey, err := dynamo.EncodePaginationKey(result.LastEvaluatedKey, cursor.NewScope(username, params))
 	if err != nil {
 		return nil, "", err
 	}
@@ -175,13 +176,18 @@ func (d *DynamoRepository) buildQueryInput(username string, params *models.Query
 
 	keyConditionEx := d.setQueryIndex(input, username, params)
 
-	err = dynamo.SetExclusiveStartKey(params.StartKey, input)
+	err = dynamo.SetExclusiveStartKey(params.StartKey, cursor.NewScope(username, params), input)
 	if err != nil {
 		return nil, err
 	}
 
 	conditionBuilder := expression.NewBuilder().WithCondition(keyConditionEx)
 
+	// The saving goal indexes aren't partitioned by user
+	if params.SavingGoalID != "" {
+		conditionBuilder = conditionBuilder.WithFilter(expression.Name("username").Equal(expression.Value(username)))
+	}
+
 	expr, err := conditionBuilder.Build()
 	if err != nil {
 		return nil, err
@@ -260,7 +266,7 @@ func (d *DynamoRepository) GetSavingsByPeriod(ctx context.Context, username stri
 		return nil, "", fmt.Errorf("unmarshal savings items failed: %v", err)
 	}
 
-	nextKey, err := dynamo.EncodePaginationKey(result.LastEvaluatedKey)
+	nextKey, err := dynamo.EncodePaginationKey(result.LastEvaluatedKey, cursor.NewScope(username, params))
 	if err != nil {
 		return nil, "", err
 	}
@@ -268,8 +274,9 @@ func (d *DynamoRepository) GetSavingsByPeriod(ctx context.Context, username stri
 	return toSavingModels(*savings), nextKey, nil
 }
 
-func (d *DynamoRepository) GetSavingsBySavingGoal(ctx context.Context, params *models.QueryParameters) ([]*models.Saving, string, error) {
-	input, err := d.buildQueryInput("", params)
+// GetSavingsBySavingGoal returns the savings of the saving goal that belong to the user.
+func (d *DynamoRepository) GetSavingsBySavingGoal(ctx context.Context, username string, params *models.QueryParameters) ([]*models.Saving, string, error) {
+	input, err := d.buildQueryInput(username, params)
 	if err != nil {
 		return nil, "", fmt.Errorf("building query input: %v", err)
 	}
@@ -294,7 +301,7 @@ func (d *DynamoRepository) GetSavingsBySavingGoal(ctx context.Context, params *m
 		return nil, "", fmt.Errorf("unmarshal savings items failed: %v", err)
 	}
 
-	nextKey, err := dynamo.EncodePaginationKey(result.LastEvaluatedKey)
+	nextKey, err := dynamo.EncodePaginationKey(result.LastEvaluatedKey, cursor.NewScope(username, params))
 	if err != nil {
 		return nil, "", err
 	}
@@ -302,22 +309,25 @@ func (d *DynamoRepository) GetSavingsBySavingGoal(ctx context.Context, params *m
 	return toSavingModels(*savings), nextKey, nil
 }
 
-func (d *DynamoRepository) GetSavingsBySavingGoalAndPeriod(ctx context.Context, params *models.QueryParameters) ([]*models.Saving, string, error) {
+// GetSavingsBySavingGoalAndPeriod returns the savings of the saving goal and period that belong to the user.
+func (d *DynamoRepository) GetSavingsBySavingGoalAndPeriod(ctx context.Context, username string, params *models.QueryParameters) ([]*models.Saving, string, error) {
 	var decodedStartKey map[string]types.AttributeValue
 	var err error
 	var result *dynamodb.QueryOutput
 	retrievedItems := 0
 	resultSet := make([]savingEntity, 0)
+	scope := cursor.NewScope(username, params)
 
 	if params.StartKey != "" {
-		decodedStartKey, err = dynamo.DecodePaginationKey(params.StartKey)
+		decodedStartKey, err = dynamo.DecodePaginationKey(params.StartKey, scope)
 		if err != nil {
-			return nil, "", fmt.Errorf("%v: %w", err, models.ErrInvalidStartKey)
+			return nil, "", err
 		}
 	}
 
 	nameEx := expression.Name("saving_goal_id").Equal(expression.Value(params.SavingGoalID))
-	filterCondition := expression.Name("period").Equal(expression.Value(params.Period))
+	filterCondition := expression.Name("period").Equal(expression.Value(params.Period)).
+		And(expression.Name("username").Equal(expression.Value(username)))
 
 	expr, err := expression.NewBuilder().WithCondition(nameEx).WithFilter(filterCondition).Build()
 	if err != nil {
@@ -354,7 +364,7 @@ func (d *DynamoRepository) GetSavingsBySavingGoalAndPeriod(ctx context.Context,
 
 		// should implement custom pagination?
 		if retrievedItems >= int(*input.Limit) {
-			return getPaginatedSavings(resultSet, itemsInQuery, input)
+			return getPaginatedSavings(resultSet, itemsInQuery, input, scope)
 		}
 
 		resultSet = append(resultSet, itemsInQuery...)
@@ -364,7 +374,7 @@ func (d *DynamoRepository) GetSavingsBySavingGoalAndPeriod(ctx context.Context,
 		}
 	}
 
-	nextKey, err := dynamo.EncodePaginationKey(input.ExclusiveStartKey)
+	nextKey, err := dynamo.EncodePaginationKey(input.ExclusiveStartKey, scope)
 	if err != nil {
 		return nil, "", err
 	}
@@ -380,7 +390,7 @@ func (d *DynamoRepository) GetSavingsBySavingGoalAndPeriod(ctx context.Context,
 	return toSavingModels(resultSet), nextKey, nil
 }
 
-func getPaginatedSavings(resultSet, itemsInQuery []savingEntity, input *dynamodb.QueryInput) ([]*models.Saving, string, error) {
+func getPaginatedSavings(resultSet, itemsInQuery []savingEntity, input *dynamodb.QueryInput, scope cursor.Scope) ([]*models.Saving, string, error) {
 	var err error
 
 	copyUpto := getCopyUpto(itemsInQuery, resultSet, input)
@@ -391,7 +401,7 @@ func getPaginatedSavings(resultSet, itemsInQuery []savingEntity, input *dynamodb
 		return nil, "", fmt.Errorf("get attribute value pk failed: %v", err)
 	}
 
-	nextKey, err := dynamo.EncodePaginationKey(input.ExclusiveStartKey)
+	nextKey, err := dynamo.EncodePaginationKey(input.ExclusiveStartKey, scope)
 	if err != nil {
 		return nil, "", err
 	}
@@ -432,6 +442,7 @@ func (d *DynamoRepository) CreateSaving(ctx context.Context, saving *models.Savi
 	saving.SavingID = dynamo.GenerateID(savingsPrefix)
 	saving.CreatedDate = time.Now()
 	savingEnt := toSavingEntity(saving)
+	savingEnt.setInitialVersion()
 
 	periodUser := dynamo.BuildPeriodUser(savingEnt.Username, *savingEnt.PeriodID)
 	savingEnt.PeriodUser = periodUser
@@ -458,8 +469,13 @@ func (d *DynamoRepository) BatchCreateSavings(ctx context.Context, savings []*mo
 	entities := make([]*savingEntity, 0, len(savings))
 
 	for _, saving := range savings {
-		saving.SavingID = dynamo.GenerateID(savingsPrefix)
-		saving.CreatedDate = time.Now()
+		if saving.SavingID == "" {
+			saving.SavingID = dynamo.GenerateID(savingsPrefix)
+		}
+
+		if saving.CreatedDate.IsZero() {
+			saving.CreatedDate = time.Now()
+		}
 
 		entity := toSavingEntity(saving)
 		if entity.PeriodID == nil {
@@ -468,6 +484,7 @@ func (d *DynamoRepository) BatchCreateSavings(ctx context.Context, savings []*mo
 			continue
 		}
 
+		entity.setInitialVersion()
 		entity.PeriodUser = dynamo.BuildPeriodUser(entity.Username, *entity.PeriodID)
 		entities = append(entities, entity)
 	}
@@ -481,6 +498,8 @@ func (d *DynamoRepository) BatchCreateSavings(ctx context.Context, savings []*mo
 	return dynamo.BatchWrite(ctx, d.dynamoClient, input)
 }
 
+// BatchUpdateSavings replaces the savings and increments their version. The version isn't checked, as batch writes
+// can't have conditions.
 func (d *DynamoRepository) BatchUpdateSavings(ctx context.Context, savings []*models.Saving) error {
 	entities := make([]*savingEntity, 0, len(savings))
 
@@ -488,6 +507,8 @@ func (d *DynamoRepository) BatchUpdateSavings(ctx context.Context, savings []*mo
 		saving.UpdatedDate = time.Now()
 
 		entity := toSavingEntity(saving)
+		entity.Version = saving.Version + 1
+
 		if entity.PeriodID == nil {
 			logger.Error("saving_with_nil_period", nil, models.Any("saving_model", saving),
 				models.Any("saving_entity", entity))
@@ -527,6 +548,7 @@ func getBatchWriteRequests(entities []*savingEntity) []types.WriteRequest {
 	return writeRequests
 }
 
+// UpdateSaving updates the fields set in the saving if the stored one is at the same version, and increments it.
 func (d *DynamoRepository) UpdateSaving(ctx context.Context, saving *models.Saving) error {
 	savingEnt := toSavingEntity(saving)
 
@@ -550,20 +572,42 @@ func (d *DynamoRepository) UpdateSaving(ctx context.Context, saving *models.Savi
 		return fmt.Errorf("getting attribute values: %v", err)
 	}
 
+	attributeValues[":version"], err = attributevalue.Marshal(saving.Version + 1)
+	if err != nil {
+		return fmt.Errorf("marshaling version: %v", err)
+	}
+
 	updateExpression := getUpdateExpression(attributeValues)
 
+	cond := expression.Name("saving_id").AttributeExists().And(dynamo.VersionCondition(saving.Version))
+
+	condExpr, err := expression.NewBuilder().WithCondition(cond).Build()
+	if err != nil {
+		return fmt.Errorf("build condition expression failed: %v", err)
+	}
+
+	for key, value := range condExpr.Values() {
+		attributeValues[key] = value
+	}
+
 	input := &dynamodb.UpdateItemInput{
 		Key: map[string]types.AttributeValue{
 			"username":  username,
 			"saving_id": savingID,
 		},
-		TableName:                 aws.String(d.tableName),
-		ConditionExpression:       aws.String("attribute_exists(saving_id)"),
-		ExpressionAttributeValues: attributeValues,
-		UpdateExpression:          updateExpression,
+		TableName:                           aws.String(d.tableName),
+		ConditionExpression:                 condExpr.Condition(),
+		ExpressionAttributeNames:            condExpr.Names(),
+		ExpressionAttributeValues:           attributeValues,
+		UpdateExpression:                    updateExpression,
+		ReturnValuesOnConditionCheckFailure: types.ReturnValuesOnConditionCheckFailureAllOld,
 	}
 
 	_, err = d.dynamoClient.UpdateItem(ctx, input)
+	if dynamo.IsVersionConflict(err) {
+		return fmt.Errorf("%v: %w", err, models.ErrVersionConflict)
+	}
+
 	if err != nil && strings.Contains(err.Error(), "ConditionalCheckFailedException") {
 		return fmt.Errorf("%v: %w", err, models.ErrUpdateSavingNotFound)
 	}
@@ -575,6 +619,54 @@ func (d *DynamoRepository) UpdateSaving(ctx context.Context, saving *models.Savi
 	return nil
 }
 
+// ReplaceSaving replaces the stored saving with the given one, removing the attributes that it doesn't set. Like
+// UpdateSaving, it fails with models.ErrVersionConflict if the stored saving isn't at the version of saving.
+func (d *DynamoRepository) ReplaceSaving(ctx context.Context, saving *models.Saving) error {
+	entity := toSavingEntity(saving)
+	entity.UpdatedDate = time.Now()
+	entity.Version = saving.Version + 1
+
+	if entity.PeriodID != nil {
+		entity.PeriodUser = dynamo.BuildPeriodUser(entity.Username, *entity.PeriodID)
+	}
+
+	item, err := attributevalue.MarshalMap(entity)
+	if err != nil {
+		return fmt.Errorf("marshal saving item failed: %v", err)
+	}
+
+	cond := expression.Name("saving_id").AttributeExists().And(dynamo.VersionCondition(saving.Version))
+
+	expr, err := expression.NewBuilder().WithCondition(cond).Build()
+	if err != nil {
+		return fmt.Errorf("build condition expression failed: %v", err)
+	}
+
+	input := &dynamodb.PutItemInput{
+		TableName:                           aws.String(d.tableName),
+		Item:                                item,
+		ConditionExpression:                 expr.Condition(),
+		ExpressionAttributeNames:            expr.Names(),
+		ExpressionAttributeValues:           expr.Values(),
+		ReturnValuesOnConditionCheckFailure: types.ReturnValuesOnConditionCheckFailureAllOld,
+	}
+
+	_, err = d.dynamoClient.PutItem(ctx, input)
+	if dynamo.IsVersionConflict(err) {
+		return fmt.Errorf("%v: %w", err, models.ErrVersionConflict)
+	}
+
+	if err != nil && strings.Contains(err.Error(), "ConditionalCheckFailedException") {
+		return fmt.Errorf("%v: %w", err, models.ErrUpdateSavingNotFound)
+	}
+
+	if err != nil {
+		return fmt.Errorf("replace saving failed: %v", err)
+	}
+
+	return nil
+}
+
 func getAttributeValues(saving *savingEntity) (map[string]types.AttributeValue, error) {
 	m := make(map[string]types.AttributeValue)
 
@@ -588,6 +680,11 @@ func getAttributeValues(saving *savingEntity) (map[string]types.AttributeValue,
 		return nil, err
 	}
 
+	currency, err := attributevalue.Marshal(saving.Currency)
+	if err != nil {
+		return nil, err
+	}
+
 	updatedDate, err := attributevalue.Marshal(time.Now())
 	if err != nil {
 		return nil, err
@@ -611,6 +708,10 @@ func getAttributeValues(saving *savingEntity) (map[string]types.AttributeValue,
 		m[":amount"] = amount
 	}
 
+	if saving.Currency != "" {
+		m[":currency"] = currency
+	}
+
 	if saving.PeriodID != nil {
 		m[":period"] = period
 		m[":period_user"] = periodUser
@@ -642,7 +743,8 @@ func getPageSize(pageSize int) *int32 {
 	return aws.Int32(int32(pageSize))
 }
 
-func (d *DynamoRepository) DeleteSaving(ctx context.Context, savingID, username string) error {
+// DeleteSaving deletes the saving if it's at version.
+func (d *DynamoRepository) DeleteSaving(ctx context.Context, savingID, username string, version int64) error {
 	usernameAtr, err := attributevalue.Marshal(username)
 	if err != nil {
 		return fmt.Errorf("marshaling username key: %v", err)
@@ -653,16 +755,30 @@ func (d *DynamoRepository) DeleteSaving(ctx context.Context, savingID, username
 		return fmt.Errorf("marshaling saving id key: %v", err)
 	}
 
+	cond := expression.Name("saving_id").AttributeExists().And(dynamo.VersionCondition(version))
+
+	expr, err := expression.NewBuilder().WithCondition(cond).Build()
+	if err != nil {
+		return fmt.Errorf("build condition expression failed: %v", err)
+	}
+
 	input := &dynamodb.DeleteItemInput{
 		Key: map[string]types.AttributeValue{
 			"username":  usernameAtr,
 			"saving_id": savingIDAtr,
 		},
-		TableName:           aws.String(d.tableName),
-		ConditionExpression: aws.String("attribute_exists(saving_id)"),
+		TableName:                           aws.String(d.tableName),
+		ConditionExpression:                 expr.Condition(),
+		ExpressionAttributeNames:            expr.Names(),
+		ExpressionAttributeValues:           expr.Values(),
+		ReturnValuesOnConditionCheckFailure: types.ReturnValuesOnConditionCheckFailureAllOld,
 	}
 
 	_, err = d.dynamoClient.DeleteItem(ctx, input)
+	if dynamo.IsVersionConflict(err) {
+		return fmt.Errorf("%v: %w", err, models.ErrVersionConflict)
+	}
+
 	if err != nil && strings.Contains(err.Error(), "ConditionalCheckFailedException") {
 		return fmt.Errorf("%v: %w", err, models.ErrSavingsNotFound)
 	}
@@ -673,3 +789,26 @@ func (d *DynamoRepository) DeleteSaving(ctx context.Context, savingID, username
 
 	return nil
 }
+
+func (d *DynamoRepository) BatchDeleteSavings(ctx context.Context, savings []*models.Saving) error {
+	writeRequests := make([]types.WriteRequest, 0, len(savings))
+
+	for _, saving := range savings {
+		writeRequests = append(writeRequests, types.WriteRequest{
+			DeleteRequest: &types.DeleteRequest{
+				Key: map[string]types.AttributeValue{
+					"username":  &types.AttributeValueMemberS{Value: saving.Username},
+					"saving_id": &types.AttributeValueMemberS{Value: saving.SavingID},
+				},
+			},
+		})
+	}
+
+	input := &dynamodb.BatchWriteItemInput{
+		RequestItems: map[string][]types.WriteRequest{
+			d.tableName: writeRequests,
+		},
+	}
+
+	return dynamo.BatchWrite(ctx, d.dynamoClient, input)
+}
@@ -0,0 +1,301 @@
+package savings
+
+import (
+	"context"
+	"github.com/JoelD7/money/backend/models"
+	"github.com/JoelD7/money/backend/storage/cursor"
+	"github.com/JoelD7/money/backend/storage/dynamo"
+	"github.com/JoelD7/money/backend/storage/memory"
+	"sync"
+	"time"
+)
+
+// MemoryRepository is a thread-safe implementation of Repository that keeps the savings in memory. Queries are sorted
+// and paginated as they would be by the indexes of the DynamoDB table.
+type MemoryRepository struct {
+	mu sync.RWMutex
+	// savings holds the savings by username and saving ID.
+	savings map[string]map[string]*savingEntity
+}
+
+func NewMemoryRepository() *MemoryRepository {
+	return &MemoryRepository{
+		savings: make(map[string]map[string]*savingEntity),
+	}
+}
+
+func (m *MemoryRepository) CreateSaving(ctx context.Context, saving *models.Saving) (*models.Saving, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	saving.SavingID = dynamo.GenerateID(savingsPrefix)
+	saving.CreatedDate = time.Now()
+
+	entity := toSavingEntity(saving)
+	entity.setInitialVersion()
+
+	entity, err := m.put(entity)
+	if err != nil {
+		return nil, err
+	}
+
+	return toSavingModel(*entity), nil
+}
+
+func (m *MemoryRepository) BatchCreateSavings(ctx context.Context, savings []*models.Saving) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, saving := range savings {
+		if saving.SavingID == "" {
+			saving.SavingID = dynamo.GenerateID(savingsPrefix)
+		}
+
+		if saving.CreatedDate.IsZero() {
+			saving.CreatedDate = time.Now()
+		}
+
+		entity := toSavingEntity(saving)
+		entity.setInitialVersion()
+
+		_, err := m.put(entity)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (m *MemoryRepository) put(entity *savingEntity) (*savingEntity, error) {
+	if entity.PeriodID != nil {
+		entity.PeriodUser = dynamo.BuildPeriodUser(entity.Username, *entity.PeriodID)
+	}
+
+	copied, err := memory.Copy(entity)
+	if err != nil {
+		return nil, err
+	}
+
+	if m.savings[entity.Username] == nil {
+		m.savings[entity.Username] = make(map[string]*savingEntity)
+	}
+
+	m.savings[entity.Username][entity.SavingID] = copied
+
+	return entity, nil
+}
+
+func (m *MemoryRepository) GetSaving(ctx context.Context, username, savingID string) (*models.Saving, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	entity, ok := m.savings[username][savingID]
+	if !ok {
+		return nil, models.ErrSavingNotFound
+	}
+
+	copied, err := memory.Copy(entity)
+	if err != nil {
+		return nil, err
+	}
+
+	return toSavingModel(*copied), nil
+}
+
+func (m *MemoryRepository) GetSavings(ctx context.Context, username string, params *models.QueryParameters) ([]*models.Saving, string, error) {
+	return m.query(username, params)
+}
+
+func (m *MemoryRepository) GetSavingsByPeriod(ctx context.Context, username string, params *models.QueryParameters) ([]*models.Saving, string, error) {
+	return m.query(username, params)
+}
+
+func (m *MemoryRepository) GetSavingsBySavingGoal(ctx context.Context, username string, params *models.QueryParameters) ([]*models.Saving, string, error) {
+	return m.query(username, params)
+}
+
+func (m *MemoryRepository) GetSavingsBySavingGoalAndPeriod(ctx context.Context, username string, params *models.QueryParameters) ([]*models.Saving, string, error) {
+	return m.query(username, params)
+}
+
+// query returns the savings of the user that match the period and saving goal of params.
+func (m *MemoryRepository) query(username string, params *models.QueryParameters) ([]*models.Saving, string, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	entities := make([]savingEntity, 0)
+
+	for _, entity := range m.savings[username] {
+		if !matchesParams(entity, params) {
+			continue
+		}
+
+		copied, err := memory.Copy(entity)
+		if err != nil {
+			return nil, "", err
+		}
+
+		entities = append(entities, *copied)
+	}
+
+	page, nextKey, err := memory.Paginate(entities, getSortKey(params), params.StartKey, cursor.NewScope(username, params),
+		params.PageSize, params.SortType == string(models.SortOrderDescending), models.ErrSavingsNotFound)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return toSavingModels(page), nextKey, nil
+}
+
+func matchesParams(entity *savingEntity, params *models.QueryParameters) bool {
+	if params.Period != "" && (entity.PeriodID == nil || *entity.PeriodID != params.Period) {
+		return false
+	}
+
+	return params.SavingGoalID == "" || (entity.SavingGoalID != nil && *entity.SavingGoalID == params.SavingGoalID)
+}
+
+// getSortKey returns the sort key of the index the DynamoDB repository would query with params.
+func getSortKey(params *models.QueryParameters) func(s savingEntity) string {
+	switch {
+	case params.SavingGoalID != "":
+		return func(s savingEntity) string {
+			return s.SavingID
+		}
+	case params.SortBy == string(models.SortParamCreatedDate):
+		return func(s savingEntity) string {
+			return s.CreatedDateSavingID
+		}
+	case params.SortBy == string(models.SortParamAmount):
+		return func(s savingEntity) string {
+			var amount models.Money
+			if s.Amount != nil {
+				amount = *s.Amount
+			}
+
+			return dynamo.BuildAmountKey(amount, s.SavingID)
+		}
+	default:
+		return func(s savingEntity) string {
+			return s.SavingID
+		}
+	}
+}
+
+// UpdateSaving sets the attributes of the saving that aren't empty, as the DynamoDB repository does.
+func (m *MemoryRepository) UpdateSaving(ctx context.Context, saving *models.Saving) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	stored, ok := m.savings[saving.Username][saving.SavingID]
+	if !ok {
+		return models.ErrUpdateSavingNotFound
+	}
+
+	if stored.Version != saving.Version {
+		return models.ErrVersionConflict
+	}
+
+	entity, err := memory.Copy(stored)
+	if err != nil {
+		return err
+	}
+
+	update := toSavingEntity(saving)
+
+	if update.SavingGoalID != nil {
+		entity.SavingGoalID = update.SavingGoalID
+	}
+
+	if update.Amount != nil {
+		entity.Amount = update.Amount
+	}
+
+	if update.Currency != "" {
+		entity.Currency = update.Currency
+	}
+
+	if update.PeriodID != nil {
+		entity.PeriodID = update.PeriodID
+	}
+
+	entity.UpdatedDate = time.Now()
+	entity.Version++
+
+	_, err = m.put(entity)
+
+	return err
+}
+
+// ReplaceSaving replaces the stored saving with the given one if the stored saving is at its version.
+func (m *MemoryRepository) ReplaceSaving(ctx context.Context, saving *models.Saving) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	stored, ok := m.savings[saving.Username][saving.SavingID]
+	if !ok {
+		return models.ErrUpdateSavingNotFound
+	}
+
+	if stored.Version != saving.Version {
+		return models.ErrVersionConflict
+	}
+
+	entity := toSavingEntity(saving)
+	entity.UpdatedDate = time.Now()
+	entity.Version = saving.Version + 1
+
+	_, err := m.put(entity)
+
+	return err
+}
+
+func (m *MemoryRepository) BatchUpdateSavings(ctx context.Context, savings []*models.Saving) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, saving := range savings {
+		saving.UpdatedDate = time.Now()
+
+		entity := toSavingEntity(saving)
+		entity.Version = saving.Version + 1
+
+		_, err := m.put(entity)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// DeleteSaving deletes the saving if it's at version.
+func (m *MemoryRepository) DeleteSaving(ctx context.Context, savingID, username string, version int64) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	stored, ok := m.savings[username][savingID]
+	if !ok {
+		return models.ErrSavingsNotFound
+	}
+
+	if stored.Version != version {
+		return models.ErrVersionConflict
+	}
+
+	delete(m.savings[username], savingID)
+
+	return nil
+}
+
+func (m *MemoryRepository) BatchDeleteSavings(ctx context.Context, savings []*models.Saving) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, saving := range savings {
+		delete(m.savings[saving.Username], saving.SavingID)
+	}
+
+	return nil
+}
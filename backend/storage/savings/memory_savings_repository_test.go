@@ -0,0 +1,56 @@
+package savings
+
+import (
+	"context"
+	"fmt"
+	"github.com/JoelD7/money/backend/models"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestMemoryRepositorySavingsBySavingGoal(t *testing.T) {
+	t.Setenv("PAGINATION_KEY_SECRET", "pagination-secret")
+
+	c := require.New(t)
+
+	ctx := context.Background()
+	repo := NewMemoryRepository()
+	savingGoalID := "SVG1"
+
+	for i := 1; i <= 3; i++ {
+		c.NoError(repo.BatchCreateSavings(ctx, []*models.Saving{
+			{SavingID: fmt.Sprintf("SV%d", i), Username: "test", SavingGoalID: &savingGoalID},
+		}))
+	}
+
+	// The savings of other users are never returned, whatever their saving goal is.
+	c.NoError(repo.BatchCreateSavings(ctx, []*models.Saving{
+		{SavingID: "SV4", Username: "other", SavingGoalID: &savingGoalID},
+	}))
+
+	params := &models.QueryParameters{SavingGoalID: savingGoalID, PageSize: 2}
+
+	savings, nextKey, err := repo.GetSavingsBySavingGoal(ctx, "test", params)
+	c.NoError(err)
+	c.Len(savings, 2)
+	c.NotEmpty(nextKey)
+
+	t.Run("Cursor of another user", func(t *testing.T) {
+		_, _, err = repo.GetSavingsBySavingGoal(ctx, "other", &models.QueryParameters{SavingGoalID: savingGoalID,
+			PageSize: 2, StartKey: nextKey})
+		c.ErrorIs(err, models.ErrInvalidStartKey)
+	})
+
+	params.StartKey = nextKey
+
+	savings, nextKey, err = repo.GetSavingsBySavingGoal(ctx, "test", params)
+	c.NoError(err)
+	c.Len(savings, 1)
+	c.Empty(nextKey)
+	c.Equal("test", savings[0].Username)
+
+	savings, _, err = repo.GetSavingsBySavingGoal(ctx, "other", &models.QueryParameters{SavingGoalID: savingGoalID})
+	c.NoError(err)
+	c.Len(savings, 1)
+	c.Equal("SV4", savings[0].SavingID)
+}
@@ -13,15 +13,17 @@ var (
 )
 
 type savingEntity struct {
-	SavingID            string    `json:"saving_id,omitempty"  dynamodbav:"saving_id"`
-	SavingGoalID        *string   `json:"saving_goal_id,omitempty"  dynamodbav:"saving_goal_id"`
-	Username            string    `json:"username,omitempty"  dynamodbav:"username"`
-	PeriodID            *string   `json:"period_id,omitempty"  dynamodbav:"period_id,omitempty"`
-	PeriodUser          *string   `json:"period_user,omitempty"  dynamodbav:"period_user"`
-	CreatedDate         time.Time `json:"created_date,omitempty"  dynamodbav:"created_date"`
-	UpdatedDate         time.Time `json:"updated_date,omitempty"  dynamodbav:"updated_date"`
-	Amount              *float64  `json:"amount" dynamodbav:"amount"`
-	CreatedDateSavingID string    `json:"created_date_saving_id,omitempty" dynamodbav:"created_date_saving_id"`
+	SavingID            string        `json:"saving_id,omitempty"  dynamodbav:"saving_id"`
+	SavingGoalID        *string       `json:"saving_goal_id,omitempty"  dynamodbav:"saving_goal_id"`
+	Username            string        `json:"username,omitempty"  dynamodbav:"username"`
+	PeriodID            *string       `json:"period_id,omitempty"  dynamodbav:"period_id,omitempty"`
+	PeriodUser          *string       `json:"period_user,omitempty"  dynamodbav:"period_user"`
+	CreatedDate         time.Time     `json:"created_date,omitempty"  dynamodbav:"created_date"`
+	UpdatedDate         time.Time     `json:"updated_date,omitempty"  dynamodbav:"updated_date"`
+	Amount              *models.Money `json:"amount" dynamodbav:"amount"`
+	Currency            string        `json:"currency,omitempty" dynamodbav:"currency,omitempty"`
+	CreatedDateSavingID string        `json:"created_date_saving_id,omitempty" dynamodbav:"created_date_saving_id"`
+	Version             int64         `json:"version,omitempty" dynamodbav:"version,omitempty"`
 }
 
 func toSavingEntity(s *models.Saving) *savingEntity {
@@ -34,6 +36,8 @@ func toSavingEntity(s *models.Saving) *savingEntity {
 		CreatedDate:  s.CreatedDate,
 		UpdatedDate:  s.UpdatedDate,
 		Amount:       s.Amount,
+		Currency:     s.Currency,
+		Version:      s.Version,
 		CreatedDateSavingID: dynamo.BuildCreatedDateEntityIDKey(
 			s.CreatedDate,
 			s.SavingID,
@@ -57,6 +61,8 @@ func toSavingModel(s savingEntity) *models.Saving {
 		CreatedDate:  s.CreatedDate,
 		UpdatedDate:  s.UpdatedDate,
 		Amount:       s.Amount,
+		Currency:     s.Currency,
+		Version:      s.Version,
 	}
 
 	if savingModel.SavingGoalID != nil && *savingModel.SavingGoalID == savingGoalIDNone {
@@ -75,3 +81,10 @@ func toSavingModels(savings []savingEntity) []*models.Saving {
 
 	return modelSavings
 }
+
+// setInitialVersion sets the version of a saving that's being created, unless it already has one.
+func (s *savingEntity) setInitialVersion() {
+	if s.Version == 0 {
+		s.Version = 1
+	}
+}
@@ -56,7 +56,7 @@ func (m *Mock) GetSavingsByPeriod(ctx context.Context, username string, params *
 	return savings, "next_key", nil
 }
 
-func (m *Mock) GetSavingsBySavingGoal(ctx context.Context, params *models.QueryParameters) ([]*models.Saving, string, error) {
+func (m *Mock) GetSavingsBySavingGoal(ctx context.Context, username string, params *models.QueryParameters) ([]*models.Saving, string, error) {
 	if m.mockedErr != nil {
 		return nil, "", m.mockedErr
 	}
@@ -72,7 +72,7 @@ func (m *Mock) GetSavingsBySavingGoal(ctx context.Context, params *models.QueryP
 	return savings, "next_key", nil
 }
 
-func (m *Mock) GetSavingsBySavingGoalAndPeriod(ctx context.Context, params *models.QueryParameters) ([]*models.Saving, string, error) {
+func (m *Mock) GetSavingsBySavingGoalAndPeriod(ctx context.Context, username string, params *models.QueryParameters) ([]*models.Saving, string, error) {
 	if m.mockedErr != nil {
 		return nil, "", m.mockedErr
 	}
@@ -112,6 +112,14 @@ func (m *Mock) CreateSaving(ctx context.Context, saving *models.Saving) (*models
 	return saving, nil
 }
 
+func (m *Mock) ReplaceSaving(ctx context.Context, saving *models.Saving) error {
+	if m.mockedErr != nil {
+		return m.mockedErr
+	}
+
+	return nil
+}
+
 func (m *Mock) UpdateSaving(ctx context.Context, saving *models.Saving) error {
 	if m.mockedErr != nil && strings.Contains(m.mockedErr.Error(), "ConditionalCheckFailedException") {
 		return models.ErrUpdateSavingNotFound
@@ -124,7 +132,7 @@ func (m *Mock) UpdateSaving(ctx context.Context, saving *models.Saving) error {
 	return nil
 }
 
-func (m *Mock) DeleteSaving(ctx context.Context, savingID, email string) error {
+func (m *Mock) DeleteSaving(ctx context.Context, savingID, email string, version int64) error {
 	if m.mockedErr != nil && strings.Contains(m.mockedErr.Error(), "ConditionalCheckFailedException") {
 		return models.ErrDeleteSavingNotFound
 	}
@@ -140,6 +148,10 @@ func (m *Mock) BatchCreateSavings(ctx context.Context, savings []*models.Saving)
 	return nil
 }
 
+func (m *Mock) BatchDeleteSavings(ctx context.Context, savings []*models.Saving) error {
+	return m.mockedErr
+}
+
 func GetDummySavings() []*models.Saving {
 	return []*models.Saving{
 		{
@@ -147,28 +159,28 @@ func GetDummySavings() []*models.Saving {
 			SavingGoalID: getStringPtr("SVG123"),
 			Username:     "test@gmail.com",
 			CreatedDate:  time.Now(),
-			Amount:       getFloatPtr(250),
+			Amount:       getMoneyPtr(250),
 		},
 		{
 			SavingID:     "SV456",
 			SavingGoalID: getStringPtr("SVG46"),
 			Username:     "test@gmail.com",
 			CreatedDate:  time.Now(),
-			Amount:       getFloatPtr(450),
+			Amount:       getMoneyPtr(450),
 		},
 		{
 			SavingID:     "SV789",
 			SavingGoalID: getStringPtr("SVG789"),
 			Username:     "test@gmail.com",
 			CreatedDate:  time.Now(),
-			Amount:       getFloatPtr(789),
+			Amount:       getMoneyPtr(789),
 		},
 		{
 			SavingID:     "SV159",
 			SavingGoalID: getStringPtr("SVG159"),
 			Username:     "test@gmail.com",
 			CreatedDate:  time.Now(),
-			Amount:       getFloatPtr(156),
+			Amount:       getMoneyPtr(156),
 		},
 	}
 }
@@ -177,6 +189,7 @@ func getStringPtr(s string) *string {
 	return &s
 }
 
-func getFloatPtr(f float64) *float64 {
-	return &f
+func getMoneyPtr(f float64) *models.Money {
+	money := models.NewMoney(f)
+	return &money
 }
@@ -3,6 +3,9 @@ package savings
 import (
 	"context"
 	"github.com/JoelD7/money/backend/models"
+	"github.com/JoelD7/money/backend/storage/audit"
+	"github.com/JoelD7/money/backend/storage/sqldb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
 )
 
 type Repository interface {
@@ -12,11 +15,38 @@ type Repository interface {
 	GetSaving(ctx context.Context, username, savingID string) (*models.Saving, error)
 	GetSavings(ctx context.Context, username string, params *models.QueryParameters) ([]*models.Saving, string, error)
 	GetSavingsByPeriod(ctx context.Context, username string, params *models.QueryParameters) ([]*models.Saving, string, error)
-	GetSavingsBySavingGoal(ctx context.Context, params *models.QueryParameters) ([]*models.Saving, string, error)
-	GetSavingsBySavingGoalAndPeriod(ctx context.Context, params *models.QueryParameters) ([]*models.Saving, string, error)
+	GetSavingsBySavingGoal(ctx context.Context, username string, params *models.QueryParameters) ([]*models.Saving, string, error)
+	GetSavingsBySavingGoalAndPeriod(ctx context.Context, username string, params *models.QueryParameters) ([]*models.Saving, string, error)
 
 	UpdateSaving(ctx context.Context, saving *models.Saving) error
+	ReplaceSaving(ctx context.Context, saving *models.Saving) error
 	BatchUpdateSavings(ctx context.Context, savings []*models.Saving) error
 
-	DeleteSaving(ctx context.Context, savingID, username string) error
+	DeleteSaving(ctx context.Context, savingID, username string, version int64) error
+	BatchDeleteSavings(ctx context.Context, savings []*models.Saving) error
+}
+
+// NewRepository returns the repository of the storage backend set in the configuration. Its writes are recorded in the
+// audit log when it's enabled.
+func NewRepository(ctx context.Context, dynamoClient *dynamodb.Client, envConfig *models.EnvironmentConfiguration) (Repository, error) {
+	recorder, err := audit.NewRecorder(ctx, dynamoClient, envConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	if envConfig.StorageBackend != models.StorageBackendSQL {
+		repo, err := NewDynamoRepository(dynamoClient, envConfig)
+		if err != nil {
+			return nil, err
+		}
+
+		return newAuditedRepository(repo, recorder), nil
+	}
+
+	db, err := sqldb.Connect(ctx, envConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	return newAuditedRepository(NewSQLRepository(db), recorder), nil
 }
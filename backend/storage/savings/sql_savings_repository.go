@@ -0,0 +1,374 @@
+package savings
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/JoelD7/money/backend/models"
+	"github.com/JoelD7/money/backend/storage/cursor"
+	"github.com/JoelD7/money/backend/storage/dynamo"
+	"github.com/JoelD7/money/backend/storage/sqldb"
+	"strings"
+	"time"
+)
+
+const savingColumns = `username, saving_id, saving_goal_id, period_id, amount, currency, created_date, updated_date,
+	version`
+
+type SQLRepository struct {
+	db *sqldb.DB
+}
+
+func NewSQLRepository(db *sqldb.DB) *SQLRepository {
+	return &SQLRepository{db: db}
+}
+
+func (s *SQLRepository) CreateSaving(ctx context.Context, saving *models.Saving) (*models.Saving, error) {
+	saving.SavingID = dynamo.GenerateID(savingsPrefix)
+	saving.CreatedDate = time.Now()
+	entity := toSavingEntity(saving)
+	entity.setInitialVersion()
+
+	_, err := s.db.ExecContext(ctx, `INSERT INTO savings (`+savingColumns+`) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		getSavingArgs(entity)...)
+	if err != nil {
+		return nil, fmt.Errorf("insert saving failed: %v", err)
+	}
+
+	return toSavingModel(*entity), nil
+}
+
+func (s *SQLRepository) BatchCreateSavings(ctx context.Context, savings []*models.Saving) error {
+	entities := make([]*savingEntity, 0, len(savings))
+
+	for _, saving := range savings {
+		if saving.SavingID == "" {
+			saving.SavingID = dynamo.GenerateID(savingsPrefix)
+		}
+
+		if saving.CreatedDate.IsZero() {
+			saving.CreatedDate = time.Now()
+		}
+
+		entity := toSavingEntity(saving)
+		entity.setInitialVersion()
+		entities = append(entities, entity)
+	}
+
+	return s.upsert(ctx, entities)
+}
+
+func (s *SQLRepository) upsert(ctx context.Context, entities []*savingEntity) error {
+	return s.db.WithTx(ctx, func(tx *sqldb.Tx) error {
+		for _, entity := range entities {
+			_, err := tx.ExecContext(ctx, `INSERT INTO savings (`+savingColumns+`) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+				ON CONFLICT (username, saving_id) DO UPDATE SET saving_goal_id = excluded.saving_goal_id,
+				period_id = excluded.period_id, amount = excluded.amount, currency = excluded.currency,
+				created_date = excluded.created_date, updated_date = excluded.updated_date, version = excluded.version`,
+				getSavingArgs(entity)...)
+			if err != nil {
+				return fmt.Errorf("batch write savings failed: %v", err)
+			}
+		}
+
+		return nil
+	})
+}
+
+func (s *SQLRepository) GetSaving(ctx context.Context, username, savingID string) (*models.Saving, error) {
+	row := s.db.QueryRowContext(ctx, `SELECT `+savingColumns+` FROM savings WHERE username = ? AND saving_id = ?`,
+		username, savingID)
+
+	entity, err := scanSaving(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, models.ErrSavingNotFound
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return toSavingModel(*entity), nil
+}
+
+func (s *SQLRepository) GetSavings(ctx context.Context, username string, params *models.QueryParameters) ([]*models.Saving, string, error) {
+	return s.queryPage(ctx, username, params)
+}
+
+func (s *SQLRepository) GetSavingsByPeriod(ctx context.Context, username string, params *models.QueryParameters) ([]*models.Saving, string, error) {
+	return s.queryPage(ctx, username, params)
+}
+
+func (s *SQLRepository) GetSavingsBySavingGoal(ctx context.Context, username string, params *models.QueryParameters) ([]*models.Saving, string, error) {
+	return s.queryPage(ctx, username, params)
+}
+
+func (s *SQLRepository) GetSavingsBySavingGoalAndPeriod(ctx context.Context, username string, params *models.QueryParameters) ([]*models.Saving, string, error) {
+	return s.queryPage(ctx, username, params)
+}
+
+// queryPage returns the savings of the user that match the period and saving goal of params.
+func (s *SQLRepository) queryPage(ctx context.Context, username string, params *models.QueryParameters) ([]*models.Saving, string, error) {
+	page := getSQLPage(username, params)
+
+	condition, args, err := page.Condition()
+	if err != nil {
+		return nil, "", err
+	}
+
+	conditions := []string{"username = ?"}
+	filterArgs := []interface{}{username}
+
+	if params.SavingGoalID != "" {
+		conditions = append(conditions, "saving_goal_id = ?")
+		filterArgs = append(filterArgs, params.SavingGoalID)
+	}
+
+	if params.Period != "" {
+		conditions = append(conditions, "period_id = ?")
+		filterArgs = append(filterArgs, params.Period)
+	}
+
+	conditions = append(conditions, condition)
+
+	entities, err := s.query(ctx, `SELECT `+savingColumns+` FROM savings WHERE `+strings.Join(conditions, " AND ")+` `+
+		page.OrderBy(), append(filterArgs, args...)...)
+	if err != nil {
+		return nil, "", err
+	}
+
+	entities, nextKey, err := sqldb.Paginate(entities, page, getSQLSortKey(params), models.ErrSavingsNotFound)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return toSavingModels(entities), nextKey, nil
+}
+
+// getSQLPage returns the pagination of the query with params, which sorts by the same attributes as the DynamoDB
+// indexes. Savings of a saving goal are always sorted by ID.
+func getSQLPage(username string, params *models.QueryParameters) *sqldb.Page {
+	page := &sqldb.Page{
+		SortColumn: "saving_id",
+		IDColumn:   "saving_id",
+		Descending: params.SortType == string(models.SortOrderDescending),
+		StartKey:   params.StartKey,
+		PageSize:   params.PageSize,
+		Scope:      cursor.NewScope(username, params),
+	}
+
+	if params.SavingGoalID != "" {
+		return page
+	}
+
+	switch params.SortBy {
+	case string(models.SortParamCreatedDate):
+		page.SortColumn = "created_date"
+	case string(models.SortParamAmount):
+		page.SortColumn = "COALESCE(amount, 0)"
+	}
+
+	return page
+}
+
+func getSQLSortKey(params *models.QueryParameters) func(s savingEntity) (interface{}, string) {
+	switch {
+	case params.SavingGoalID != "":
+		return func(s savingEntity) (interface{}, string) {
+			return s.SavingID, s.SavingID
+		}
+	case params.SortBy == string(models.SortParamCreatedDate):
+		return func(s savingEntity) (interface{}, string) {
+			return sqldb.FormatTime(s.CreatedDate), s.SavingID
+		}
+	case params.SortBy == string(models.SortParamAmount):
+		return func(s savingEntity) (interface{}, string) {
+			if s.Amount == nil {
+				return int64(0), s.SavingID
+			}
+
+			return int64(*s.Amount), s.SavingID
+		}
+	default:
+		return func(s savingEntity) (interface{}, string) {
+			return s.SavingID, s.SavingID
+		}
+	}
+}
+
+// UpdateSaving sets the attributes of the saving that aren't empty, as the DynamoDB repository does, if the stored
+// saving is at the version of saving.
+func (s *SQLRepository) UpdateSaving(ctx context.Context, saving *models.Saving) error {
+	entity := toSavingEntity(saving)
+
+	assignments := []string{"updated_date = ?", "saving_goal_id = ?", "version = ?"}
+	args := []interface{}{sqldb.FormatTime(time.Now()), getSavingGoalID(entity), saving.Version + 1}
+
+	if entity.Amount != nil {
+		assignments = append(assignments, "amount = ?")
+		args = append(args, int64(*entity.Amount))
+	}
+
+	if entity.Currency != "" {
+		assignments = append(assignments, "currency = ?")
+		args = append(args, entity.Currency)
+	}
+
+	if entity.PeriodID != nil {
+		assignments = append(assignments, "period_id = ?")
+		args = append(args, *entity.PeriodID)
+	}
+
+	result, err := s.db.ExecContext(ctx, `UPDATE savings SET `+strings.Join(assignments, ", ")+`
+		WHERE username = ? AND saving_id = ? AND version = ?`, append(args, entity.Username, entity.SavingID, saving.Version)...)
+	if err != nil {
+		return fmt.Errorf("update saving failed: %v", err)
+	}
+
+	return s.db.CheckVersionedUpdate(ctx, result, models.ErrUpdateSavingNotFound,
+		`SELECT 1 FROM savings WHERE username = ? AND saving_id = ?`, entity.Username, entity.SavingID)
+}
+
+// ReplaceSaving replaces the stored saving with the given one if the stored saving is at its version, clearing the
+// attributes that it doesn't set.
+func (s *SQLRepository) ReplaceSaving(ctx context.Context, saving *models.Saving) error {
+	entity := toSavingEntity(saving)
+	entity.UpdatedDate = time.Now()
+	entity.Version = saving.Version + 1
+
+	args := getSavingArgs(entity)
+
+	result, err := s.db.ExecContext(ctx, `UPDATE savings SET saving_goal_id = ?, period_id = ?, amount = ?, currency = ?,
+		created_date = ?, updated_date = ?, version = ? WHERE username = ? AND saving_id = ? AND version = ?`,
+		append(args[2:], entity.Username, entity.SavingID, saving.Version)...)
+	if err != nil {
+		return fmt.Errorf("replace saving failed: %v", err)
+	}
+
+	return s.db.CheckVersionedUpdate(ctx, result, models.ErrUpdateSavingNotFound,
+		`SELECT 1 FROM savings WHERE username = ? AND saving_id = ?`, entity.Username, entity.SavingID)
+}
+
+// BatchUpdateSavings replaces the savings and increments their version without checking it.
+func (s *SQLRepository) BatchUpdateSavings(ctx context.Context, savings []*models.Saving) error {
+	entities := make([]*savingEntity, 0, len(savings))
+
+	for _, saving := range savings {
+		saving.UpdatedDate = time.Now()
+
+		entity := toSavingEntity(saving)
+		entity.Version = saving.Version + 1
+		entities = append(entities, entity)
+	}
+
+	return s.upsert(ctx, entities)
+}
+
+// DeleteSaving deletes the saving if it's at version.
+func (s *SQLRepository) DeleteSaving(ctx context.Context, savingID, username string, version int64) error {
+	result, err := s.db.ExecContext(ctx, `DELETE FROM savings WHERE username = ? AND saving_id = ? AND version = ?`,
+		username, savingID, version)
+	if err != nil {
+		return fmt.Errorf("delete saving failed: %v", err)
+	}
+
+	return s.db.CheckVersionedUpdate(ctx, result, models.ErrSavingsNotFound,
+		`SELECT 1 FROM savings WHERE username = ? AND saving_id = ?`, username, savingID)
+}
+
+func (s *SQLRepository) BatchDeleteSavings(ctx context.Context, savings []*models.Saving) error {
+	return s.db.WithTx(ctx, func(tx *sqldb.Tx) error {
+		for _, saving := range savings {
+			_, err := tx.ExecContext(ctx, `DELETE FROM savings WHERE username = ? AND saving_id = ?`,
+				saving.Username, saving.SavingID)
+			if err != nil {
+				return fmt.Errorf("batch delete savings failed: %v", err)
+			}
+		}
+
+		return nil
+	})
+}
+
+func (s *SQLRepository) query(ctx context.Context, query string, args ...interface{}) ([]savingEntity, error) {
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("query savings failed: %v", err)
+	}
+
+	defer rows.Close()
+
+	entities := make([]savingEntity, 0)
+
+	for rows.Next() {
+		entity, err := scanSaving(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		entities = append(entities, *entity)
+	}
+
+	return entities, rows.Err()
+}
+
+// getSavingGoalID returns the saving goal of the saving as a column value. Savings without a saving goal are stored
+// with a null saving_goal_id instead of savingGoalIDNone.
+func getSavingGoalID(entity *savingEntity) sql.NullString {
+	if entity.SavingGoalID == nil || *entity.SavingGoalID == savingGoalIDNone {
+		return sql.NullString{}
+	}
+
+	return sql.NullString{String: *entity.SavingGoalID, Valid: true}
+}
+
+func getSavingArgs(entity *savingEntity) []interface{} {
+	var amount sql.NullInt64
+	if entity.Amount != nil {
+		amount = sql.NullInt64{Int64: int64(*entity.Amount), Valid: true}
+	}
+
+	return []interface{}{entity.Username, entity.SavingID, getSavingGoalID(entity), sqldb.NullString(entity.PeriodID),
+		amount, entity.Currency, sqldb.FormatTime(entity.CreatedDate), sqldb.FormatTime(entity.UpdatedDate), entity.Version}
+}
+
+func scanSaving(row sqldb.Scanner) (*savingEntity, error) {
+	entity := new(savingEntity)
+
+	var savingGoalID, periodID sql.NullString
+	var amount sql.NullInt64
+	var createdDate, updatedDate string
+
+	err := row.Scan(&entity.Username, &entity.SavingID, &savingGoalID, &periodID, &amount, &entity.Currency,
+		&createdDate, &updatedDate, &entity.Version)
+	if err != nil {
+		return nil, err
+	}
+
+	entity.SavingGoalID = sqldb.StringPtr(savingGoalID)
+	entity.PeriodID = sqldb.StringPtr(periodID)
+
+	if entity.PeriodID != nil {
+		entity.PeriodUser = dynamo.BuildPeriodUser(entity.Username, *entity.PeriodID)
+	}
+
+	if amount.Valid {
+		value := models.Money(amount.Int64)
+		entity.Amount = &value
+	}
+
+	entity.CreatedDate, err = sqldb.ParseTime(createdDate)
+	if err != nil {
+		return nil, err
+	}
+
+	entity.UpdatedDate, err = sqldb.ParseTime(updatedDate)
+	if err != nil {
+		return nil, err
+	}
+
+	entity.CreatedDateSavingID = dynamo.BuildCreatedDateEntityIDKey(entity.CreatedDate, entity.SavingID)
+
+	return entity, nil
+}
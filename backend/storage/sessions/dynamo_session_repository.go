@@ -0,0 +1,234 @@
+package sessions
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/JoelD7/money/backend/models"
+	"github.com/JoelD7/money/backend/storage/dynamo"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/expression"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+type DynamoRepository struct {
+	dynamoClient *dynamodb.Client
+	tableName    string
+}
+
+func NewDynamoRepository(dynamoClient *dynamodb.Client, envConfig *models.EnvironmentConfiguration) (*DynamoRepository, error) {
+	d := &DynamoRepository{dynamoClient: dynamoClient}
+
+	if envConfig == nil || envConfig.SessionsTable == "" {
+		return nil, fmt.Errorf("initialize sessions dynamo repository failed: table name is required")
+	}
+
+	d.tableName = envConfig.SessionsTable
+
+	return d, nil
+}
+
+func (d *DynamoRepository) CreateSession(ctx context.Context, session *models.Session) error {
+	item, err := attributevalue.MarshalMap(toSessionEntity(session))
+	if err != nil {
+		return fmt.Errorf("marshal session item failed: %v", err)
+	}
+
+	input := &dynamodb.PutItemInput{
+		TableName: aws.String(d.tableName),
+		Item:      item,
+	}
+
+	_, err = d.dynamoClient.PutItem(ctx, input)
+	if err != nil {
+		return fmt.Errorf("put session item failed: %v", err)
+	}
+
+	return nil
+}
+
+func (d *DynamoRepository) GetSession(ctx context.Context, username, sessionID string) (*models.Session, error) {
+	input := &dynamodb.GetItemInput{
+		TableName: aws.String(d.tableName),
+		Key: map[string]types.AttributeValue{
+			"username":   &types.AttributeValueMemberS{Value: username},
+			"session_id": &types.AttributeValueMemberS{Value: sessionID},
+		},
+	}
+
+	result, err := d.dynamoClient.GetItem(ctx, input)
+	if err != nil {
+		return nil, fmt.Errorf("get session item failed: %v", err)
+	}
+
+	if result.Item == nil {
+		return nil, models.ErrSessionNotFound
+	}
+
+	entity := new(sessionEntity)
+
+	err = attributevalue.UnmarshalMap(result.Item, entity)
+	if err != nil {
+		return nil, fmt.Errorf("unmarshal session item failed: %v", err)
+	}
+
+	return toSessionModel(entity), nil
+}
+
+// GetSessions returns all the sessions of the user. A user has few sessions, so they aren't paginated.
+func (d *DynamoRepository) GetSessions(ctx context.Context, username string) ([]*models.Session, error) {
+	keyCondition := expression.Key("username").Equal(expression.Value(username))
+
+	expr, err := expression.NewBuilder().WithKeyCondition(keyCondition).Build()
+	if err != nil {
+		return nil, fmt.Errorf("build expression failed: %v", err)
+	}
+
+	input := &dynamodb.QueryInput{
+		TableName:                 aws.String(d.tableName),
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		KeyConditionExpression:    expr.KeyCondition(),
+	}
+
+	sessions := make([]*models.Session, 0)
+
+	for {
+		result, err := d.dynamoClient.Query(ctx, input)
+		if err != nil {
+			return nil, fmt.Errorf("query sessions failed: %v", err)
+		}
+
+		entities := make([]*sessionEntity, 0, len(result.Items))
+
+		err = attributevalue.UnmarshalListOfMaps(result.Items, &entities)
+		if err != nil {
+			return nil, fmt.Errorf("unmarshal sessions failed: %v", err)
+		}
+
+		for _, entity := range entities {
+			sessions = append(sessions, toSessionModel(entity))
+		}
+
+		if result.LastEvaluatedKey == nil {
+			break
+		}
+
+		input.ExclusiveStartKey = result.LastEvaluatedKey
+	}
+
+	if len(sessions) == 0 {
+		return nil, models.ErrSessionsNotFound
+	}
+
+	return sessions, nil
+}
+
+func (d *DynamoRepository) UpdateSession(ctx context.Context, session *models.Session, refreshToken string) error {
+	item, err := attributevalue.MarshalMap(toSessionEntity(session))
+	if err != nil {
+		return fmt.Errorf("marshal session item failed: %v", err)
+	}
+
+	condition := expression.Name("refresh_token").Equal(expression.Value(refreshToken))
+
+	expr, err := expression.NewBuilder().WithCondition(condition).Build()
+	if err != nil {
+		return fmt.Errorf("build expression failed: %v", err)
+	}
+
+	input := &dynamodb.PutItemInput{
+		TableName:                 aws.String(d.tableName),
+		Item:                      item,
+		ConditionExpression:       expr.Condition(),
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+	}
+
+	var conditionErr *types.ConditionalCheckFailedException
+
+	_, err = d.dynamoClient.PutItem(ctx, input)
+	if errors.As(err, &conditionErr) {
+		return fmt.Errorf("%v: %w", err, models.ErrRefreshTokenReused)
+	}
+
+	if err != nil {
+		return fmt.Errorf("put session item failed: %v", err)
+	}
+
+	return nil
+}
+
+func (d *DynamoRepository) DeleteSession(ctx context.Context, username, sessionID string) error {
+	input := &dynamodb.DeleteItemInput{
+		TableName: aws.String(d.tableName),
+		Key: map[string]types.AttributeValue{
+			"username":   &types.AttributeValueMemberS{Value: username},
+			"session_id": &types.AttributeValueMemberS{Value: sessionID},
+		},
+	}
+
+	_, err := d.dynamoClient.DeleteItem(ctx, input)
+	if err != nil {
+		return fmt.Errorf("delete session item failed: %v", err)
+	}
+
+	return nil
+}
+
+// DeleteAllSessions deletes every session of the user and returns how many were deleted.
+func (d *DynamoRepository) DeleteAllSessions(ctx context.Context, username string) (int, error) {
+	keyCondition := expression.Key("username").Equal(expression.Value(username))
+	projection := expression.NamesList(expression.Name("username"), expression.Name("session_id"))
+
+	expr, err := expression.NewBuilder().WithKeyCondition(keyCondition).WithProjection(projection).Build()
+	if err != nil {
+		return 0, fmt.Errorf("build expression failed: %v", err)
+	}
+
+	input := &dynamodb.QueryInput{
+		TableName:                 aws.String(d.tableName),
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		KeyConditionExpression:    expr.KeyCondition(),
+		ProjectionExpression:      expr.Projection(),
+	}
+
+	deleted := 0
+
+	for {
+		result, err := d.dynamoClient.Query(ctx, input)
+		if err != nil {
+			return deleted, fmt.Errorf("query sessions failed: %v", err)
+		}
+
+		if len(result.Items) > 0 {
+			writeRequests := make([]types.WriteRequest, 0, len(result.Items))
+
+			for _, key := range result.Items {
+				writeRequests = append(writeRequests, types.WriteRequest{
+					DeleteRequest: &types.DeleteRequest{Key: key},
+				})
+			}
+
+			err = dynamo.BatchWrite(ctx, d.dynamoClient, &dynamodb.BatchWriteItemInput{
+				RequestItems: map[string][]types.WriteRequest{
+					d.tableName: writeRequests,
+				},
+			})
+			if err != nil {
+				return deleted, fmt.Errorf("delete sessions failed: %w", err)
+			}
+
+			deleted += len(writeRequests)
+		}
+
+		if result.LastEvaluatedKey == nil {
+			return deleted, nil
+		}
+
+		input.ExclusiveStartKey = result.LastEvaluatedKey
+	}
+}
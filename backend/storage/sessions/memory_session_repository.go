@@ -0,0 +1,127 @@
+package sessions
+
+import (
+	"context"
+	"github.com/JoelD7/money/backend/models"
+	"github.com/JoelD7/money/backend/storage/memory"
+	"sort"
+	"sync"
+)
+
+// MemoryRepository is a thread-safe implementation of Repository that keeps the sessions in memory.
+type MemoryRepository struct {
+	mu       sync.RWMutex
+	sessions map[string]*sessionEntity
+}
+
+func NewMemoryRepository() *MemoryRepository {
+	return &MemoryRepository{
+		sessions: make(map[string]*sessionEntity),
+	}
+}
+
+func (m *MemoryRepository) CreateSession(ctx context.Context, session *models.Session) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.save(session)
+}
+
+func (m *MemoryRepository) GetSession(ctx context.Context, username, sessionID string) (*models.Session, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	entity, ok := m.sessions[buildKey(username, sessionID)]
+	if !ok {
+		return nil, models.ErrSessionNotFound
+	}
+
+	copied, err := memory.Copy(entity)
+	if err != nil {
+		return nil, err
+	}
+
+	return toSessionModel(copied), nil
+}
+
+func (m *MemoryRepository) GetSessions(ctx context.Context, username string) ([]*models.Session, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	sessions := make([]*models.Session, 0)
+
+	for _, entity := range m.sessions {
+		if entity.Username != username {
+			continue
+		}
+
+		copied, err := memory.Copy(entity)
+		if err != nil {
+			return nil, err
+		}
+
+		sessions = append(sessions, toSessionModel(copied))
+	}
+
+	if len(sessions) == 0 {
+		return nil, models.ErrSessionsNotFound
+	}
+
+	sort.Slice(sessions, func(i, j int) bool {
+		return sessions[i].LastUsedDate.After(sessions[j].LastUsedDate)
+	})
+
+	return sessions, nil
+}
+
+func (m *MemoryRepository) UpdateSession(ctx context.Context, session *models.Session, refreshToken string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	stored, ok := m.sessions[buildKey(session.Username, session.ID)]
+	if !ok || stored.RefreshToken != refreshToken {
+		return models.ErrRefreshTokenReused
+	}
+
+	return m.save(session)
+}
+
+func (m *MemoryRepository) DeleteSession(ctx context.Context, username, sessionID string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.sessions, buildKey(username, sessionID))
+
+	return nil
+}
+
+func (m *MemoryRepository) DeleteAllSessions(ctx context.Context, username string) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	deleted := 0
+
+	for key, entity := range m.sessions {
+		if entity.Username == username {
+			delete(m.sessions, key)
+			deleted++
+		}
+	}
+
+	return deleted, nil
+}
+
+func (m *MemoryRepository) save(session *models.Session) error {
+	entity, err := memory.Copy(toSessionEntity(session))
+	if err != nil {
+		return err
+	}
+
+	m.sessions[buildKey(session.Username, session.ID)] = entity
+
+	return nil
+}
+
+func buildKey(username, sessionID string) string {
+	return username + ":" + sessionID
+}
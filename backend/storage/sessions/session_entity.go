@@ -0,0 +1,50 @@
+package sessions
+
+import (
+	"github.com/JoelD7/money/backend/models"
+	"time"
+)
+
+type sessionEntity struct {
+	Username       string    `json:"username" dynamodbav:"username"`
+	SessionID      string    `json:"session_id" dynamodbav:"session_id"`
+	RefreshToken   string    `json:"-" dynamodbav:"refresh_token"`
+	AccessToken    string    `json:"-" dynamodbav:"access_token,omitempty"`
+	UserAgent      string    `json:"user_agent,omitempty" dynamodbav:"user_agent,omitempty"`
+	IPAddress      string    `json:"ip_address,omitempty" dynamodbav:"ip_address,omitempty"`
+	CreatedDate    time.Time `json:"created_date" dynamodbav:"created_date"`
+	LastUsedDate   time.Time `json:"last_used_date" dynamodbav:"last_used_date"`
+	ExpirationDate time.Time `json:"expiration_date" dynamodbav:"expiration_date"`
+	// ExpirationTime is the TTL attribute of the table, in Unix seconds, so that the sessions are deleted once their
+	// refresh token expires.
+	ExpirationTime int64 `json:"expiration_time" dynamodbav:"expiration_time"`
+}
+
+func toSessionEntity(s *models.Session) *sessionEntity {
+	return &sessionEntity{
+		Username:       s.Username,
+		SessionID:      s.ID,
+		RefreshToken:   s.RefreshToken,
+		AccessToken:    s.AccessToken,
+		UserAgent:      s.UserAgent,
+		IPAddress:      s.IPAddress,
+		CreatedDate:    s.CreatedDate,
+		LastUsedDate:   s.LastUsedDate,
+		ExpirationDate: s.ExpirationDate,
+		ExpirationTime: s.ExpirationDate.Unix(),
+	}
+}
+
+func toSessionModel(s *sessionEntity) *models.Session {
+	return &models.Session{
+		ID:             s.SessionID,
+		Username:       s.Username,
+		RefreshToken:   s.RefreshToken,
+		AccessToken:    s.AccessToken,
+		UserAgent:      s.UserAgent,
+		IPAddress:      s.IPAddress,
+		CreatedDate:    s.CreatedDate,
+		LastUsedDate:   s.LastUsedDate,
+		ExpirationDate: s.ExpirationDate,
+	}
+}
@@ -0,0 +1,38 @@
+package sessions
+
+import (
+	"context"
+	"github.com/JoelD7/money/backend/models"
+	"github.com/JoelD7/money/backend/storage/sqldb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+)
+
+type Repository interface {
+	CreateSession(ctx context.Context, session *models.Session) error
+	GetSession(ctx context.Context, username, sessionID string) (*models.Session, error)
+	GetSessions(ctx context.Context, username string) ([]*models.Session, error)
+	// UpdateSession replaces the session only if its refresh token is still refreshToken, failing with
+	// models.ErrRefreshTokenReused otherwise. That way, a refresh token can be exchanged for new tokens only once.
+	UpdateSession(ctx context.Context, session *models.Session, refreshToken string) error
+	DeleteSession(ctx context.Context, username, sessionID string) error
+	DeleteAllSessions(ctx context.Context, username string) (int, error)
+}
+
+// NewRepository returns the repository of the storage backend set in the configuration.
+func NewRepository(ctx context.Context, dynamoClient *dynamodb.Client, envConfig *models.EnvironmentConfiguration) (Repository, error) {
+	if envConfig.StorageBackend != models.StorageBackendSQL {
+		repo, err := NewDynamoRepository(dynamoClient, envConfig)
+		if err != nil {
+			return nil, err
+		}
+
+		return repo, nil
+	}
+
+	db, err := sqldb.Connect(ctx, envConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewSQLRepository(db), nil
+}
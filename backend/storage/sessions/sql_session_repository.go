@@ -0,0 +1,158 @@
+package sessions
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/JoelD7/money/backend/models"
+	"github.com/JoelD7/money/backend/storage/sqldb"
+)
+
+const sessionColumns = `username, session_id, refresh_token, access_token, user_agent, ip_address, created_date,
+	last_used_date, expiration_date`
+
+type SQLRepository struct {
+	db *sqldb.DB
+}
+
+func NewSQLRepository(db *sqldb.DB) *SQLRepository {
+	return &SQLRepository{db: db}
+}
+
+func (s *SQLRepository) CreateSession(ctx context.Context, session *models.Session) error {
+	entity := toSessionEntity(session)
+
+	_, err := s.db.ExecContext(ctx, `INSERT INTO sessions (`+sessionColumns+`) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		entity.Username, entity.SessionID, entity.RefreshToken, entity.AccessToken, entity.UserAgent, entity.IPAddress,
+		sqldb.FormatTime(entity.CreatedDate), sqldb.FormatTime(entity.LastUsedDate), sqldb.FormatTime(entity.ExpirationDate))
+	if err != nil {
+		return fmt.Errorf("insert session failed: %v", err)
+	}
+
+	return nil
+}
+
+func (s *SQLRepository) GetSession(ctx context.Context, username, sessionID string) (*models.Session, error) {
+	row := s.db.QueryRowContext(ctx, `SELECT `+sessionColumns+` FROM sessions WHERE username = ? AND session_id = ?`,
+		username, sessionID)
+
+	entity, err := scanSession(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, models.ErrSessionNotFound
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return toSessionModel(entity), nil
+}
+
+func (s *SQLRepository) GetSessions(ctx context.Context, username string) ([]*models.Session, error) {
+	rows, err := s.db.QueryContext(ctx, `SELECT `+sessionColumns+` FROM sessions WHERE username = ?
+		ORDER BY last_used_date DESC`, username)
+	if err != nil {
+		return nil, fmt.Errorf("query sessions failed: %v", err)
+	}
+	defer rows.Close()
+
+	sessions := make([]*models.Session, 0)
+
+	for rows.Next() {
+		entity, err := scanSession(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		sessions = append(sessions, toSessionModel(entity))
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, fmt.Errorf("read sessions failed: %v", err)
+	}
+
+	if len(sessions) == 0 {
+		return nil, models.ErrSessionsNotFound
+	}
+
+	return sessions, nil
+}
+
+func (s *SQLRepository) UpdateSession(ctx context.Context, session *models.Session, refreshToken string) error {
+	entity := toSessionEntity(session)
+
+	result, err := s.db.ExecContext(ctx, `UPDATE sessions SET refresh_token = ?, access_token = ?, user_agent = ?,
+		ip_address = ?, last_used_date = ?, expiration_date = ?
+		WHERE username = ? AND session_id = ? AND refresh_token = ?`,
+		entity.RefreshToken, entity.AccessToken, entity.UserAgent, entity.IPAddress, sqldb.FormatTime(entity.LastUsedDate),
+		sqldb.FormatTime(entity.ExpirationDate), entity.Username, entity.SessionID, refreshToken)
+	if err != nil {
+		return fmt.Errorf("update session failed: %v", err)
+	}
+
+	affected, err := sqldb.RowsAffected(result)
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return models.ErrRefreshTokenReused
+	}
+
+	return nil
+}
+
+func (s *SQLRepository) DeleteSession(ctx context.Context, username, sessionID string) error {
+	_, err := s.db.ExecContext(ctx, `DELETE FROM sessions WHERE username = ? AND session_id = ?`, username, sessionID)
+	if err != nil {
+		return fmt.Errorf("delete session failed: %v", err)
+	}
+
+	return nil
+}
+
+// DeleteAllSessions deletes every session of the user and returns how many were deleted.
+func (s *SQLRepository) DeleteAllSessions(ctx context.Context, username string) (int, error) {
+	result, err := s.db.ExecContext(ctx, `DELETE FROM sessions WHERE username = ?`, username)
+	if err != nil {
+		return 0, fmt.Errorf("delete sessions failed: %v", err)
+	}
+
+	deleted, err := sqldb.RowsAffected(result)
+	if err != nil {
+		return 0, err
+	}
+
+	return int(deleted), nil
+}
+
+func scanSession(row sqldb.Scanner) (*sessionEntity, error) {
+	entity := new(sessionEntity)
+
+	var createdDate, lastUsedDate, expirationDate string
+
+	err := row.Scan(&entity.Username, &entity.SessionID, &entity.RefreshToken, &entity.AccessToken, &entity.UserAgent,
+		&entity.IPAddress, &createdDate, &lastUsedDate, &expirationDate)
+	if err != nil {
+		return nil, err
+	}
+
+	entity.CreatedDate, err = sqldb.ParseTime(createdDate)
+	if err != nil {
+		return nil, err
+	}
+
+	entity.LastUsedDate, err = sqldb.ParseTime(lastUsedDate)
+	if err != nil {
+		return nil, err
+	}
+
+	entity.ExpirationDate, err = sqldb.ParseTime(expirationDate)
+	if err != nil {
+		return nil, err
+	}
+
+	return entity, nil
+}
@@ -0,0 +1,66 @@
+package sessions
+
+import (
+	"context"
+	"github.com/JoelD7/money/backend/models"
+	"github.com/JoelD7/money/backend/storage/sqldb"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestSQLRepository(t *testing.T) {
+	c := require.New(t)
+
+	ctx := context.Background()
+
+	db, err := sqldb.Open(sqldb.DriverSQLite, ":memory:")
+	c.NoError(err)
+	c.NoError(db.Migrate(ctx))
+
+	repo := NewSQLRepository(db)
+
+	now := time.Now().Truncate(time.Millisecond)
+
+	for i, id := range []string{"SS1", "SS2"} {
+		c.NoError(repo.CreateSession(ctx, &models.Session{
+			ID:             id,
+			Username:       "test",
+			RefreshToken:   "hashed-refresh-token-" + id,
+			UserAgent:      "Firefox",
+			CreatedDate:    now,
+			LastUsedDate:   now.Add(time.Duration(i) * time.Minute),
+			ExpirationDate: now.Add(time.Hour),
+		}))
+	}
+
+	sessions, err := repo.GetSessions(ctx, "test")
+	c.NoError(err)
+	c.Len(sessions, 2)
+	c.Equal("SS2", sessions[0].ID)
+
+	session, err := repo.GetSession(ctx, "test", "SS1")
+	c.NoError(err)
+	c.Equal("Firefox", session.UserAgent)
+	c.True(now.Add(time.Hour).Equal(session.ExpirationDate))
+
+	session.RefreshToken = "rotated-refresh-token"
+	c.NoError(repo.UpdateSession(ctx, session, "hashed-refresh-token-SS1"))
+
+	t.Run("Refresh token already rotated", func(t *testing.T) {
+		err = repo.UpdateSession(ctx, session, "hashed-refresh-token-SS1")
+		c.ErrorIs(err, models.ErrRefreshTokenReused)
+	})
+
+	c.NoError(repo.DeleteSession(ctx, "test", "SS1"))
+
+	_, err = repo.GetSession(ctx, "test", "SS1")
+	c.ErrorIs(err, models.ErrSessionNotFound)
+
+	deleted, err := repo.DeleteAllSessions(ctx, "test")
+	c.NoError(err)
+	c.Equal(1, deleted)
+
+	_, err = repo.GetSessions(ctx, "test")
+	c.ErrorIs(err, models.ErrSessionsNotFound)
+}
@@ -0,0 +1,50 @@
+package sqldb
+
+import (
+	"github.com/JoelD7/money/backend/models"
+	"strings"
+)
+
+// likeEscaper escapes the wildcards of a LIKE pattern.
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// FilterConditions returns the conditions, and their arguments, of the amount, date and text search filters of params.
+// The text search matches the rows where any of textColumns contains the search text, regardless of case.
+func FilterConditions(params *models.QueryParameters, amountColumn, dateColumn string, textColumns ...string) ([]string, []interface{}) {
+	conditions := make([]string, 0)
+	args := make([]interface{}, 0)
+
+	if params.MinAmount != nil {
+		conditions = append(conditions, amountColumn+" >= ?")
+		args = append(args, params.MinAmount.Cents())
+	}
+
+	if params.MaxAmount != nil {
+		conditions = append(conditions, amountColumn+" <= ?")
+		args = append(args, params.MaxAmount.Cents())
+	}
+
+	if params.FromDate != nil {
+		conditions = append(conditions, dateColumn+" >= ?")
+		args = append(args, FormatTime(*params.FromDate))
+	}
+
+	if params.ToDate != nil {
+		conditions = append(conditions, dateColumn+" <= ?")
+		args = append(args, FormatTime(*params.ToDate))
+	}
+
+	if params.Search != "" && len(textColumns) > 0 {
+		pattern := "%" + likeEscaper.Replace(strings.ToLower(params.Search)) + "%"
+		textConditions := make([]string, 0, len(textColumns))
+
+		for _, column := range textColumns {
+			textConditions = append(textConditions, "lower("+column+`) LIKE ? ESCAPE '\'`)
+			args = append(args, pattern)
+		}
+
+		conditions = append(conditions, "("+strings.Join(textConditions, " OR ")+")")
+	}
+
+	return conditions, args
+}
@@ -0,0 +1,163 @@
+package sqldb
+
+import (
+	"context"
+	"embed"
+	"fmt"
+	"io/fs"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+//go:embed migrations/*.sql
+var migrationFiles embed.FS
+
+// migration is a SQL file of the migrations directory. Files are named "<version>_<description>.sql" and applied in
+// order of version.
+type migration struct {
+	version    int
+	name       string
+	statements []string
+}
+
+// Migrate applies the migrations that haven't been applied to the database yet. Each migration runs in a transaction,
+// along with the row that records it in the schema_migrations table.
+func (db *DB) Migrate(ctx context.Context) error {
+	_, err := db.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS schema_migrations (
+		version INTEGER PRIMARY KEY,
+		name TEXT NOT NULL,
+		applied_date TEXT NOT NULL
+	)`)
+	if err != nil {
+		return fmt.Errorf("create schema migrations table failed: %v", err)
+	}
+
+	migrations, err := readMigrations()
+	if err != nil {
+		return err
+	}
+
+	applied, err := db.getAppliedMigrations(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, m := range migrations {
+		if applied[m.version] {
+			continue
+		}
+
+		err = db.WithTx(ctx, func(tx *Tx) error {
+			for _, statement := range m.statements {
+				_, err := tx.ExecContext(ctx, statement)
+				if err != nil {
+					return fmt.Errorf("apply migration %s failed: %v", m.name, err)
+				}
+			}
+
+			_, err := tx.ExecContext(ctx, "INSERT INTO schema_migrations (version, name, applied_date) VALUES (?, ?, ?)",
+				m.version, m.name, FormatTime(time.Now()))
+			if err != nil {
+				return fmt.Errorf("record migration %s failed: %v", m.name, err)
+			}
+
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (db *DB) getAppliedMigrations(ctx context.Context) (map[int]bool, error) {
+	rows, err := db.QueryContext(ctx, "SELECT version FROM schema_migrations")
+	if err != nil {
+		return nil, fmt.Errorf("get applied migrations failed: %v", err)
+	}
+
+	defer rows.Close()
+
+	applied := make(map[int]bool)
+
+	for rows.Next() {
+		var version int
+
+		err = rows.Scan(&version)
+		if err != nil {
+			return nil, fmt.Errorf("scan applied migration failed: %v", err)
+		}
+
+		applied[version] = true
+	}
+
+	return applied, rows.Err()
+}
+
+func readMigrations() ([]migration, error) {
+	files, err := fs.Glob(migrationFiles, "migrations/*.sql")
+	if err != nil {
+		return nil, fmt.Errorf("list migrations failed: %v", err)
+	}
+
+	migrations := make([]migration, 0, len(files))
+
+	for _, file := range files {
+		name := strings.TrimSuffix(strings.TrimPrefix(file, "migrations/"), ".sql")
+
+		version, err := strconv.Atoi(strings.SplitN(name, "_", 2)[0])
+		if err != nil {
+			return nil, fmt.Errorf("invalid migration name %q: %v", name, err)
+		}
+
+		content, err := migrationFiles.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("read migration %s failed: %v", name, err)
+		}
+
+		migrations = append(migrations, migration{
+			version:    version,
+			name:       name,
+			statements: splitStatements(string(content)),
+		})
+	}
+
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].version < migrations[j].version
+	})
+
+	return migrations, nil
+}
+
+// splitStatements splits a migration file in its statements, which must end with a semicolon. Not every driver can run
+// several statements at once. Lines starting with "--" are comments.
+func splitStatements(content string) []string {
+	statements := make([]string, 0)
+
+	var sb strings.Builder
+
+	for _, line := range strings.Split(content, "\n") {
+		trimmed := strings.TrimSpace(line)
+
+		if trimmed == "" || strings.HasPrefix(trimmed, "--") {
+			continue
+		}
+
+		sb.WriteString(line)
+		sb.WriteString("\n")
+
+		if strings.HasSuffix(trimmed, ";") {
+			statements = append(statements, strings.TrimSpace(sb.String()))
+			sb.Reset()
+		}
+	}
+
+	if strings.TrimSpace(sb.String()) != "" {
+		statements = append(statements, strings.TrimSpace(sb.String()))
+	}
+
+	return statements
+}
@@ -0,0 +1,143 @@
+-- Dates are stored as text in UTC with a fixed width, see sqldb.FormatTime, so that they sort and compare the same way
+-- in SQLite and PostgreSQL. Amounts are stored in cents.
+
+CREATE TABLE users (
+    username TEXT PRIMARY KEY,
+    full_name TEXT NOT NULL DEFAULT '',
+    password TEXT NOT NULL DEFAULT '',
+    categories TEXT NOT NULL DEFAULT '[]',
+    created_date TEXT NOT NULL,
+    updated_date TEXT NOT NULL,
+    access_token TEXT NOT NULL DEFAULT '',
+    refresh_token TEXT NOT NULL DEFAULT '',
+    current_period TEXT NOT NULL DEFAULT '',
+    base_currency TEXT NOT NULL DEFAULT ''
+);
+
+CREATE TABLE periods (
+    username TEXT NOT NULL,
+    period TEXT NOT NULL,
+    name TEXT,
+    start_date TEXT NOT NULL,
+    end_date TEXT NOT NULL,
+    created_date TEXT NOT NULL,
+    updated_date TEXT NOT NULL,
+    category_budgets TEXT,
+    PRIMARY KEY (username, period)
+);
+
+CREATE UNIQUE INDEX periods_username_name_idx ON periods (username, name);
+CREATE INDEX periods_username_end_date_idx ON periods (username, end_date);
+
+CREATE TABLE expenses (
+    username TEXT NOT NULL,
+    expense_id TEXT NOT NULL,
+    category_id TEXT,
+    amount BIGINT NOT NULL,
+    currency TEXT NOT NULL DEFAULT '',
+    name TEXT NOT NULL DEFAULT '',
+    notes TEXT NOT NULL DEFAULT '',
+    created_date TEXT NOT NULL,
+    update_date TEXT NOT NULL,
+    period_id TEXT NOT NULL DEFAULT '',
+    PRIMARY KEY (username, expense_id)
+);
+
+CREATE INDEX expenses_username_period_idx ON expenses (username, period_id);
+CREATE INDEX expenses_username_created_date_idx ON expenses (username, created_date);
+
+CREATE TABLE expenses_recurring (
+    username TEXT NOT NULL,
+    id TEXT NOT NULL,
+    category_id TEXT,
+    amount BIGINT NOT NULL,
+    currency TEXT NOT NULL DEFAULT '',
+    name TEXT NOT NULL DEFAULT '',
+    recurring_day INTEGER NOT NULL,
+    notes TEXT NOT NULL DEFAULT '',
+    paused BOOLEAN NOT NULL DEFAULT FALSE,
+    created_date TEXT NOT NULL,
+    update_date TEXT NOT NULL,
+    PRIMARY KEY (username, id)
+);
+
+CREATE INDEX expenses_recurring_day_idx ON expenses_recurring (recurring_day);
+
+CREATE TABLE income (
+    username TEXT NOT NULL,
+    income_id TEXT NOT NULL,
+    amount BIGINT,
+    currency TEXT NOT NULL DEFAULT '',
+    name TEXT,
+    notes TEXT,
+    created_date TEXT NOT NULL,
+    updated_date TEXT NOT NULL,
+    period_id TEXT,
+    PRIMARY KEY (username, income_id)
+);
+
+CREATE INDEX income_username_period_idx ON income (username, period_id);
+
+CREATE TABLE saving_goals (
+    username TEXT NOT NULL,
+    saving_goal_id TEXT NOT NULL,
+    name TEXT NOT NULL DEFAULT '',
+    target BIGINT NOT NULL DEFAULT 0,
+    currency TEXT NOT NULL DEFAULT '',
+    deadline TEXT NOT NULL,
+    created_at TEXT,
+    updated_at TEXT,
+    is_recurring BOOLEAN NOT NULL DEFAULT FALSE,
+    recurring_amount BIGINT NOT NULL DEFAULT 0,
+    PRIMARY KEY (username, saving_goal_id)
+);
+
+CREATE TABLE savings (
+    username TEXT NOT NULL,
+    saving_id TEXT NOT NULL,
+    saving_goal_id TEXT,
+    period_id TEXT,
+    amount BIGINT,
+    currency TEXT NOT NULL DEFAULT '',
+    created_date TEXT NOT NULL,
+    updated_date TEXT NOT NULL,
+    PRIMARY KEY (username, saving_id)
+);
+
+CREATE INDEX savings_username_period_idx ON savings (username, period_id);
+CREATE INDEX savings_saving_goal_idx ON savings (saving_goal_id);
+
+CREATE TABLE exchange_rates (
+    username TEXT NOT NULL,
+    period_id TEXT NOT NULL,
+    currency TEXT NOT NULL,
+    rate DOUBLE PRECISION NOT NULL,
+    created_date TEXT NOT NULL,
+    updated_date TEXT NOT NULL,
+    PRIMARY KEY (username, period_id, currency)
+);
+
+CREATE TABLE budget_alerts (
+    username TEXT NOT NULL,
+    period_id TEXT NOT NULL,
+    category_id TEXT NOT NULL,
+    threshold INTEGER NOT NULL,
+    category_name TEXT NOT NULL DEFAULT '',
+    budget BIGINT NOT NULL,
+    total BIGINT NOT NULL,
+    percentage_used DOUBLE PRECISION NOT NULL,
+    currency TEXT NOT NULL DEFAULT '',
+    created_date TEXT NOT NULL,
+    PRIMARY KEY (username, period_id, category_id, threshold)
+);
+
+CREATE TABLE account_deletions (
+    username TEXT PRIMARY KEY,
+    status TEXT NOT NULL,
+    step TEXT NOT NULL DEFAULT '',
+    deleted_items TEXT,
+    error TEXT NOT NULL DEFAULT '',
+    created_date TEXT NOT NULL,
+    updated_date TEXT NOT NULL,
+    completed_date TEXT NOT NULL
+);
@@ -0,0 +1,12 @@
+CREATE TABLE trash (
+    username TEXT NOT NULL,
+    item_id TEXT NOT NULL,
+    item_type TEXT NOT NULL,
+    name TEXT NOT NULL DEFAULT '',
+    data TEXT NOT NULL,
+    deleted_date TEXT NOT NULL,
+    expiration_date TEXT NOT NULL,
+    PRIMARY KEY (username, item_id)
+);
+
+CREATE INDEX trash_username_deleted_date_idx ON trash (username, deleted_date);
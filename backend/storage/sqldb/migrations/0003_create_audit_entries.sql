@@ -0,0 +1,15 @@
+CREATE TABLE audit_entries (
+    username TEXT NOT NULL,
+    entry_id TEXT NOT NULL,
+    entity_type TEXT NOT NULL,
+    entity_id TEXT NOT NULL,
+    action TEXT NOT NULL,
+    actor TEXT NOT NULL,
+    source TEXT NOT NULL,
+    changes TEXT NOT NULL DEFAULT '[]',
+    created_date TEXT NOT NULL,
+    PRIMARY KEY (username, entry_id)
+);
+
+CREATE INDEX audit_entries_username_created_date_idx ON audit_entries (username, created_date);
+CREATE INDEX audit_entries_entity_idx ON audit_entries (username, entity_type, entity_id, created_date);
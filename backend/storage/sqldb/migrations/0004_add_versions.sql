@@ -0,0 +1,7 @@
+-- The rows created before versioning start at version 1, like the new ones.
+
+ALTER TABLE users ADD COLUMN version BIGINT NOT NULL DEFAULT 1;
+ALTER TABLE periods ADD COLUMN version BIGINT NOT NULL DEFAULT 1;
+ALTER TABLE expenses ADD COLUMN version BIGINT NOT NULL DEFAULT 1;
+ALTER TABLE saving_goals ADD COLUMN version BIGINT NOT NULL DEFAULT 1;
+ALTER TABLE savings ADD COLUMN version BIGINT NOT NULL DEFAULT 1;
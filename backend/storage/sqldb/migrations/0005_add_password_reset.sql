@@ -0,0 +1,2 @@
+ALTER TABLE users ADD COLUMN password_reset_token TEXT NOT NULL DEFAULT '';
+ALTER TABLE users ADD COLUMN password_reset_expiration TEXT;
@@ -0,0 +1,2 @@
+-- The users created before emails were verified are considered verified.
+ALTER TABLE users ADD COLUMN email_verified BOOLEAN NOT NULL DEFAULT TRUE;
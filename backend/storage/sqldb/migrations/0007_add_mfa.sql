@@ -0,0 +1,4 @@
+ALTER TABLE users ADD COLUMN mfa_enabled BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE users ADD COLUMN mfa_secret TEXT NOT NULL DEFAULT '';
+ALTER TABLE users ADD COLUMN mfa_recovery_codes TEXT;
+ALTER TABLE users ADD COLUMN mfa_last_used_step INTEGER NOT NULL DEFAULT 0;
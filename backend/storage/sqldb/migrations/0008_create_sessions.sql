@@ -0,0 +1,12 @@
+CREATE TABLE sessions (
+    username TEXT NOT NULL,
+    session_id TEXT NOT NULL,
+    refresh_token TEXT NOT NULL,
+    access_token TEXT NOT NULL DEFAULT '',
+    user_agent TEXT NOT NULL DEFAULT '',
+    ip_address TEXT NOT NULL DEFAULT '',
+    created_date TEXT NOT NULL,
+    last_used_date TEXT NOT NULL,
+    expiration_date TEXT NOT NULL,
+    PRIMARY KEY (username, session_id)
+);
@@ -0,0 +1 @@
+ALTER TABLE users ADD COLUMN legacy_currency TEXT NOT NULL DEFAULT '';
@@ -0,0 +1,153 @@
+package sqldb
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/JoelD7/money/backend/models"
+	"github.com/JoelD7/money/backend/storage/cursor"
+)
+
+// DefaultPageSize is the page size used when a query doesn't set one, as in the DynamoDB repositories.
+const DefaultPageSize = 10
+
+// Page is the keyset pagination of a query. Rows are sorted by SortColumn and then by IDColumn, so that the order is
+// total, and a page starts after the row encoded in StartKey. Unlike offsets, start keys keep working when the rows
+// before them are deleted. Start keys are sealed with the cursor package, so they're only accepted for the Scope of
+// the query that returned them.
+type Page struct {
+	// SortColumn is the column, or expression, rows are sorted by. It can be the same as IDColumn.
+	SortColumn string
+	IDColumn   string
+	Descending bool
+	StartKey   string
+	PageSize   int
+	Scope      cursor.Scope
+}
+
+// Condition returns the condition that selects the rows after the start key, along with its arguments. The condition
+// is "1 = 1" when there's no start key, so that it can always be added to a WHERE clause.
+func (p *Page) Condition() (string, []interface{}, error) {
+	if p.StartKey == "" {
+		return "1 = 1", nil, nil
+	}
+
+	sortValue, id, err := decodeStartKey(p.StartKey, p.Scope)
+	if err != nil {
+		return "", nil, err
+	}
+
+	operator := ">"
+	if p.Descending {
+		operator = "<"
+	}
+
+	if p.SortColumn == p.IDColumn {
+		return fmt.Sprintf("%s %s ?", p.IDColumn, operator), []interface{}{id}, nil
+	}
+
+	condition := fmt.Sprintf("(%[1]s %[3]s ? OR (%[1]s = ? AND %[2]s %[3]s ?))", p.SortColumn, p.IDColumn, operator)
+
+	return condition, []interface{}{sortValue, sortValue, id}, nil
+}
+
+// OrderBy returns the ORDER BY and LIMIT clauses of the query. One row more than the page size is requested to know
+// if there's a next page.
+func (p *Page) OrderBy() string {
+	direction := "ASC"
+	if p.Descending {
+		direction = "DESC"
+	}
+
+	if p.SortColumn == p.IDColumn {
+		return fmt.Sprintf("ORDER BY %s %s LIMIT %d", p.IDColumn, direction, p.size()+1)
+	}
+
+	return fmt.Sprintf("ORDER BY %[1]s %[3]s, %[2]s %[3]s LIMIT %[4]d", p.SortColumn, p.IDColumn, direction, p.size()+1)
+}
+
+func (p *Page) size() int {
+	if p.PageSize <= 0 {
+		return DefaultPageSize
+	}
+
+	return p.PageSize
+}
+
+// Paginate returns the page of items, which must have been queried with the clauses of p, and the start key of the
+// next page. The start key is empty when there are no items left. sortKey returns the values of the sort and ID
+// columns of an item.
+//
+// As in the DynamoDB repositories, notFoundErr is returned when there are no items and models.ErrNoMoreItemsToBeRetrieved
+// when there are no items after the start key.
+func Paginate[T any](items []T, p *Page, sortKey func(item T) (interface{}, string), notFoundErr error) ([]T, string, error) {
+	if len(items) == 0 && p.StartKey != "" {
+		return nil, "", models.ErrNoMoreItemsToBeRetrieved
+	}
+
+	if len(items) == 0 {
+		return nil, "", notFoundErr
+	}
+
+	if len(items) <= p.size() {
+		return items, "", nil
+	}
+
+	items = items[:p.size()]
+
+	sortValue, id := sortKey(items[len(items)-1])
+
+	nextKey, err := encodeStartKey(sortValue, id, p.Scope)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return items, nextKey, nil
+}
+
+func encodeStartKey(sortValue interface{}, id string, scope cursor.Scope) (string, error) {
+	data, err := json.Marshal([]interface{}{sortValue, id})
+	if err != nil {
+		return "", fmt.Errorf("encode start key failed: %v", err)
+	}
+
+	return cursor.Seal(data, scope)
+}
+
+func decodeStartKey(startKey string, scope cursor.Scope) (interface{}, string, error) {
+	data, err := cursor.Open(startKey, scope)
+	if err != nil {
+		return nil, "", err
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var values []interface{}
+
+	err = decoder.Decode(&values)
+	if err != nil || len(values) != 2 {
+		return nil, "", fmt.Errorf("decoding start key: invalid format: %w", models.ErrInvalidStartKey)
+	}
+
+	id, ok := values[1].(string)
+	if !ok {
+		return nil, "", fmt.Errorf("decoding start key: invalid ID: %w", models.ErrInvalidStartKey)
+	}
+
+	number, ok := values[0].(json.Number)
+	if !ok {
+		return values[0], id, nil
+	}
+
+	if intValue, err := number.Int64(); err == nil {
+		return intValue, id, nil
+	}
+
+	floatValue, err := number.Float64()
+	if err != nil {
+		return nil, "", fmt.Errorf("decoding start key: invalid sort value: %w", models.ErrInvalidStartKey)
+	}
+
+	return floatValue, id, nil
+}
@@ -0,0 +1,190 @@
+// Package sqldb contains the connection and helpers shared by the SQL repositories, which store the data in SQLite or
+// PostgreSQL instead of DynamoDB.
+package sqldb
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/JoelD7/money/backend/models"
+	"strconv"
+	"strings"
+	"sync"
+
+	// Drivers of the supported databases.
+	_ "github.com/lib/pq"
+	_ "modernc.org/sqlite"
+)
+
+const (
+	DriverSQLite   = "sqlite"
+	DriverPostgres = "postgres"
+)
+
+var (
+	errUnsupportedDriver = errors.New("unsupported SQL driver")
+
+	connections   = make(map[string]*DB)
+	connectionsMu sync.Mutex
+)
+
+// DB is a connection pool to a SQL database. Queries are written with "?" placeholders, which are converted to the ones
+// of the driver.
+type DB struct {
+	*sql.DB
+	driver string
+}
+
+// Tx is a transaction started with DB.WithTx.
+type Tx struct {
+	*sql.Tx
+	driver string
+}
+
+// Connect returns the connection to the database set in the configuration, opening it and applying the migrations the
+// first time. The connection is shared by all the repositories of the process.
+func Connect(ctx context.Context, envConfig *models.EnvironmentConfiguration) (*DB, error) {
+	connectionsMu.Lock()
+	defer connectionsMu.Unlock()
+
+	key := envConfig.SQLDriver + ":" + envConfig.SQLDataSource
+
+	if db, ok := connections[key]; ok {
+		return db, nil
+	}
+
+	db, err := Open(envConfig.SQLDriver, envConfig.SQLDataSource)
+	if err != nil {
+		return nil, err
+	}
+
+	err = db.Migrate(ctx)
+	if err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+
+	connections[key] = db
+
+	return db, nil
+}
+
+// Open opens a connection to the database without applying the migrations.
+func Open(driver, dataSource string) (*DB, error) {
+	if driver != DriverSQLite && driver != DriverPostgres {
+		return nil, fmt.Errorf("%w: %q", errUnsupportedDriver, driver)
+	}
+
+	if dataSource == "" {
+		return nil, fmt.Errorf("SQL data source is required")
+	}
+
+	sqlDB, err := sql.Open(driver, dataSource)
+	if err != nil {
+		return nil, fmt.Errorf("open %s database failed: %v", driver, err)
+	}
+
+	if driver == DriverSQLite {
+		// SQLite allows a single writer, so sharing one connection avoids "database is locked" errors. It also keeps
+		// in-memory databases alive, as each connection to ":memory:" opens a new database.
+		sqlDB.SetMaxOpenConns(1)
+	}
+
+	return &DB{DB: sqlDB, driver: driver}, nil
+}
+
+func (db *DB) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	return db.DB.ExecContext(ctx, rebind(db.driver, query), args...)
+}
+
+func (db *DB) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	return db.DB.QueryContext(ctx, rebind(db.driver, query), args...)
+}
+
+func (db *DB) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	return db.DB.QueryRowContext(ctx, rebind(db.driver, query), args...)
+}
+
+// WithTx runs fn in a transaction, which is committed if fn succeeds and rolled back otherwise.
+func (db *DB) WithTx(ctx context.Context, fn func(tx *Tx) error) error {
+	sqlTx, err := db.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin transaction failed: %v", err)
+	}
+
+	err = fn(&Tx{Tx: sqlTx, driver: db.driver})
+	if err != nil {
+		_ = sqlTx.Rollback()
+		return err
+	}
+
+	err = sqlTx.Commit()
+	if err != nil {
+		return fmt.Errorf("commit transaction failed: %v", err)
+	}
+
+	return nil
+}
+
+func (tx *Tx) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	return tx.Tx.ExecContext(ctx, rebind(tx.driver, query), args...)
+}
+
+func (tx *Tx) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	return tx.Tx.QueryContext(ctx, rebind(tx.driver, query), args...)
+}
+
+func (tx *Tx) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	return tx.Tx.QueryRowContext(ctx, rebind(tx.driver, query), args...)
+}
+
+// Execer is implemented by DB and Tx, so that the same statements can run in or out of a transaction.
+type Execer interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+}
+
+// rebind replaces the "?" placeholders of the query with the numbered placeholders of PostgreSQL.
+func rebind(driver, query string) string {
+	if driver != DriverPostgres || !strings.Contains(query, "?") {
+		return query
+	}
+
+	var sb strings.Builder
+	sb.Grow(len(query) + 10)
+
+	n := 0
+
+	for _, r := range query {
+		if r != '?' {
+			sb.WriteRune(r)
+			continue
+		}
+
+		n++
+		sb.WriteString("$")
+		sb.WriteString(strconv.Itoa(n))
+	}
+
+	return sb.String()
+}
+
+// In returns the placeholders of an IN condition for n values, e.g. "(?, ?, ?)".
+func In(n int) string {
+	return "(" + strings.TrimSuffix(strings.Repeat("?, ", n), ", ") + ")"
+}
+
+// RowsAffected returns the number of rows affected by a statement.
+func RowsAffected(result sql.Result) (int64, error) {
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("get affected rows failed: %v", err)
+	}
+
+	return affected, nil
+}
+
+// Scanner is implemented by sql.Row and sql.Rows, so that the same function can scan a row from either.
+type Scanner interface {
+	Scan(dest ...interface{}) error
+}
@@ -0,0 +1,149 @@
+package sqldb
+
+import (
+	"context"
+	"fmt"
+	"github.com/JoelD7/money/backend/models"
+	"github.com/JoelD7/money/backend/storage/cursor"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestMigrate(t *testing.T) {
+	c := require.New(t)
+
+	ctx := context.Background()
+
+	db, err := Open(DriverSQLite, ":memory:")
+	c.NoError(err)
+
+	defer db.Close()
+
+	c.NoError(db.Migrate(ctx))
+	// Applied migrations are skipped.
+	c.NoError(db.Migrate(ctx))
+
+	migrations, err := readMigrations()
+	c.NoError(err)
+
+	var count int
+	c.NoError(db.QueryRowContext(ctx, "SELECT COUNT(*) FROM schema_migrations").Scan(&count))
+	c.Equal(len(migrations), count)
+
+	_, err = db.ExecContext(ctx, "SELECT username FROM expenses WHERE username = ?", "test")
+	c.NoError(err)
+}
+
+func TestRebind(t *testing.T) {
+	c := require.New(t)
+
+	query := "SELECT * FROM expenses WHERE username = ? AND period_id IN (?, ?)"
+
+	c.Equal("SELECT * FROM expenses WHERE username = $1 AND period_id IN ($2, $3)", rebind(DriverPostgres, query))
+	c.Equal(query, rebind(DriverSQLite, query))
+	c.Equal("(?, ?, ?)", In(3))
+}
+
+func TestPaginate(t *testing.T) {
+	t.Setenv("PAGINATION_KEY_SECRET", "pagination-secret")
+
+	c := require.New(t)
+
+	ctx := context.Background()
+
+	db, err := Open(DriverSQLite, ":memory:")
+	c.NoError(err)
+
+	defer db.Close()
+
+	_, err = db.ExecContext(ctx, "CREATE TABLE items (id TEXT PRIMARY KEY, amount BIGINT NOT NULL)")
+	c.NoError(err)
+
+	for i := 1; i <= 5; i++ {
+		// Two items share each amount, so that the ID breaks the ties.
+		_, err = db.ExecContext(ctx, "INSERT INTO items (id, amount) VALUES (?, ?)", fmt.Sprintf("ID%d", i), i/2)
+		c.NoError(err)
+	}
+
+	type item struct {
+		id     string
+		amount int64
+	}
+
+	getPage := func(page *Page) ([]item, string, error) {
+		condition, args, err := page.Condition()
+		if err != nil {
+			return nil, "", err
+		}
+
+		rows, err := db.QueryContext(ctx, "SELECT id, amount FROM items WHERE "+condition+" "+page.OrderBy(), args...)
+		c.NoError(err)
+
+		defer rows.Close()
+
+		items := make([]item, 0)
+
+		for rows.Next() {
+			var i item
+			c.NoError(rows.Scan(&i.id, &i.amount))
+			items = append(items, i)
+		}
+
+		return Paginate(items, page, func(i item) (interface{}, string) {
+			return i.amount, i.id
+		}, models.ErrExpensesNotFound)
+	}
+
+	page := &Page{SortColumn: "amount", IDColumn: "id", Descending: true, PageSize: 2}
+
+	items, nextKey, err := getPage(page)
+	c.NoError(err)
+	c.Equal([]item{{"ID5", 2}, {"ID4", 2}}, items)
+
+	page.StartKey = nextKey
+
+	items, nextKey, err = getPage(page)
+	c.NoError(err)
+	c.Equal([]item{{"ID3", 1}, {"ID2", 1}}, items)
+
+	page.StartKey = nextKey
+
+	items, nextKey, err = getPage(page)
+	c.NoError(err)
+	c.Equal([]item{{"ID1", 0}}, items)
+	c.Empty(nextKey)
+
+	_, _, err = getPage(&Page{SortColumn: "id", IDColumn: "id", StartKey: nextKeyAfter(c, "ID5")})
+	c.ErrorIs(err, models.ErrNoMoreItemsToBeRetrieved)
+
+	_, _, err = getPage(&Page{SortColumn: "id", IDColumn: "id", StartKey: "%invalid%"})
+	c.ErrorIs(err, models.ErrInvalidStartKey)
+
+	_, _, err = getPage(&Page{SortColumn: "id", IDColumn: "id", StartKey: nextKeyAfter(c, "ID5"),
+		Scope: cursor.Scope{Username: "other@gmail.com"}})
+	c.ErrorIs(err, models.ErrInvalidStartKey)
+}
+
+func nextKeyAfter(c *require.Assertions, id string) string {
+	key, err := encodeStartKey(id, id, cursor.Scope{})
+	c.NoError(err)
+
+	return key
+}
+
+func TestFormatTime(t *testing.T) {
+	c := require.New(t)
+
+	location := time.FixedZone("UTC-4", -4*60*60)
+	date := time.Date(2024, 5, 1, 20, 30, 0, 0, location)
+
+	c.Equal("2024-05-02T00:30:00.000000000Z", FormatTime(date))
+
+	parsed, err := ParseTime(FormatTime(date))
+	c.NoError(err)
+	c.True(date.Equal(parsed))
+
+	// Dates sort as text in the same order as in time.
+	c.Less(FormatTime(date), FormatTime(date.Add(time.Millisecond)))
+}
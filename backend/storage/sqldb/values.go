@@ -0,0 +1,98 @@
+package sqldb
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// timeLayout is RFC 3339 with a fixed number of fractional digits, so that dates formatted in UTC sort as text in the
+// same order as they do in time.
+const timeLayout = "2006-01-02T15:04:05.000000000Z07:00"
+
+// FormatTime formats t to be stored in a date column.
+func FormatTime(t time.Time) string {
+	return t.UTC().Format(timeLayout)
+}
+
+// FormatNullTime formats t to be stored in a nullable date column.
+func FormatNullTime(t *time.Time) sql.NullString {
+	if t == nil {
+		return sql.NullString{}
+	}
+
+	return sql.NullString{String: FormatTime(*t), Valid: true}
+}
+
+// ParseTime parses a date column.
+func ParseTime(value string) (time.Time, error) {
+	t, err := time.Parse(time.RFC3339Nano, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("parse date %q failed: %v", value, err)
+	}
+
+	return t, nil
+}
+
+// ParseNullTime parses a nullable date column.
+func ParseNullTime(value sql.NullString) (*time.Time, error) {
+	if !value.Valid {
+		return nil, nil
+	}
+
+	t, err := ParseTime(value.String)
+	if err != nil {
+		return nil, err
+	}
+
+	return &t, nil
+}
+
+// NullString converts s to a nullable column value.
+func NullString(s *string) sql.NullString {
+	if s == nil {
+		return sql.NullString{}
+	}
+
+	return sql.NullString{String: *s, Valid: true}
+}
+
+// StringPtr converts a nullable column value to a pointer, which is nil for NULL.
+func StringPtr(value sql.NullString) *string {
+	if !value.Valid {
+		return nil
+	}
+
+	s := value.String
+
+	return &s
+}
+
+// MarshalJSON encodes v to be stored in a text column. Nil values are stored as NULL.
+func MarshalJSON(v interface{}) (sql.NullString, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return sql.NullString{}, fmt.Errorf("marshal JSON column failed: %v", err)
+	}
+
+	if string(data) == "null" {
+		return sql.NullString{}, nil
+	}
+
+	return sql.NullString{String: string(data), Valid: true}, nil
+}
+
+// UnmarshalJSON decodes a text column into v. v is left as is if the column is NULL.
+func UnmarshalJSON(value sql.NullString, v interface{}) error {
+	if !value.Valid || value.String == "" {
+		return nil
+	}
+
+	err := json.Unmarshal([]byte(value.String), v)
+	if err != nil {
+		return fmt.Errorf("unmarshal JSON column failed: %v", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,48 @@
+package sqldb
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/JoelD7/money/backend/models"
+)
+
+type rowQuerier interface {
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+// CheckVersionedUpdate returns the error of an update that only applies to the row at a given version. When no row was
+// updated, existsQuery tells whether the row is at another version, which is a conflict, or doesn't exist.
+func (db *DB) CheckVersionedUpdate(ctx context.Context, result sql.Result, notFoundErr error, existsQuery string, args ...interface{}) error {
+	return checkVersionedUpdate(ctx, db, result, notFoundErr, existsQuery, args...)
+}
+
+// CheckVersionedUpdate is like DB.CheckVersionedUpdate, but runs existsQuery in the transaction.
+func (tx *Tx) CheckVersionedUpdate(ctx context.Context, result sql.Result, notFoundErr error, existsQuery string, args ...interface{}) error {
+	return checkVersionedUpdate(ctx, tx, result, notFoundErr, existsQuery, args...)
+}
+
+func checkVersionedUpdate(ctx context.Context, q rowQuerier, result sql.Result, notFoundErr error, existsQuery string, args ...interface{}) error {
+	affected, err := RowsAffected(result)
+	if err != nil {
+		return err
+	}
+
+	if affected > 0 {
+		return nil
+	}
+
+	var exists int
+
+	err = q.QueryRowContext(ctx, existsQuery, args...).Scan(&exists)
+	if errors.Is(err, sql.ErrNoRows) {
+		return notFoundErr
+	}
+
+	if err != nil {
+		return fmt.Errorf("check row exists failed: %v", err)
+	}
+
+	return models.ErrVersionConflict
+}
@@ -0,0 +1,232 @@
+package trash
+
+import (
+	"context"
+	"fmt"
+	"github.com/JoelD7/money/backend/models"
+	"github.com/JoelD7/money/backend/storage/cursor"
+	"github.com/JoelD7/money/backend/storage/dynamo"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/expression"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"time"
+)
+
+type DynamoRepository struct {
+	dynamoClient             *dynamodb.Client
+	tableName                string
+	usernameDeletedDateIndex string
+	retention                time.Duration
+}
+
+func NewDynamoRepository(dynamoClient *dynamodb.Client, envConfig *models.EnvironmentConfiguration) (*DynamoRepository, error) {
+	d := &DynamoRepository{dynamoClient: dynamoClient}
+
+	if envConfig == nil || envConfig.TrashTable == "" {
+		return nil, fmt.Errorf("initialize trash dynamo repository failed: table name is required")
+	}
+
+	if envConfig.UsernameDeletedDateIndex == "" {
+		return nil, fmt.Errorf("initialize trash dynamo repository failed: username deleted date index is required")
+	}
+
+	d.tableName = envConfig.TrashTable
+	d.usernameDeletedDateIndex = envConfig.UsernameDeletedDateIndex
+	d.retention = getRetention(envConfig)
+
+	return d, nil
+}
+
+// CreateTrashItem saves a deleted record in the trash. The item is set to expire after the retention period of the trash.
+func (d *DynamoRepository) CreateTrashItem(ctx context.Context, item *models.TrashItem) error {
+	setDates(item, d.retention)
+
+	entity, err := toTrashItemEntity(item)
+	if err != nil {
+		return err
+	}
+
+	av, err := attributevalue.MarshalMap(entity)
+	if err != nil {
+		return fmt.Errorf("marshal trash item failed: %v", err)
+	}
+
+	input := &dynamodb.PutItemInput{
+		TableName: aws.String(d.tableName),
+		Item:      av,
+	}
+
+	_, err = d.dynamoClient.PutItem(ctx, input)
+	if err != nil {
+		return fmt.Errorf("put trash item failed: %v", err)
+	}
+
+	return nil
+}
+
+func (d *DynamoRepository) GetTrashItem(ctx context.Context, username, itemID string) (*models.TrashItem, error) {
+	input := &dynamodb.GetItemInput{
+		TableName: aws.String(d.tableName),
+		Key: map[string]types.AttributeValue{
+			"username": &types.AttributeValueMemberS{Value: username},
+			"item_id":  &types.AttributeValueMemberS{Value: itemID},
+		},
+	}
+
+	result, err := d.dynamoClient.GetItem(ctx, input)
+	if err != nil {
+		return nil, fmt.Errorf("get trash item failed: %v", err)
+	}
+
+	if result.Item == nil {
+		return nil, models.ErrTrashItemNotFound
+	}
+
+	entity := new(trashItemEntity)
+
+	err = attributevalue.UnmarshalMap(result.Item, entity)
+	if err != nil {
+		return nil, fmt.Errorf("unmarshal trash item failed: %v", err)
+	}
+
+	if entity.isExpired(time.Now()) {
+		return nil, models.ErrTrashItemNotFound
+	}
+
+	return toTrashItemModel(entity)
+}
+
+// GetTrashItems returns a page of the trash of the user, most recently deleted first.
+func (d *DynamoRepository) GetTrashItems(ctx context.Context, username string, params *models.QueryParameters) ([]*models.TrashItem, string, error) {
+	keyCondition := expression.Key("username").Equal(expression.Value(username))
+	filter := expression.Name("expiration_time").GreaterThan(expression.Value(time.Now().Unix()))
+
+	expr, err := expression.NewBuilder().WithKeyCondition(keyCondition).WithFilter(filter).Build()
+	if err != nil {
+		return nil, "", fmt.Errorf("build expression failed: %v", err)
+	}
+
+	input := &dynamodb.QueryInput{
+		TableName:                 aws.String(d.tableName),
+		IndexName:                 aws.String(d.usernameDeletedDateIndex),
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		KeyConditionExpression:    expr.KeyCondition(),
+		FilterExpression:          expr.Filter(),
+		ScanIndexForward:          aws.Bool(false),
+		Limit:                     dynamo.GetPageSize(params.PageSize),
+	}
+
+	scope := cursor.NewScope(username, params)
+
+	err = dynamo.SetExclusiveStartKey(params.StartKey, scope, input)
+	if err != nil {
+		return nil, "", err
+	}
+
+	result, err := d.dynamoClient.Query(ctx, input)
+	if err != nil {
+		return nil, "", fmt.Errorf("query trash items failed: %v", err)
+	}
+
+	if len(result.Items) == 0 && params.StartKey == "" {
+		return nil, "", models.ErrTrashItemsNotFound
+	}
+
+	if len(result.Items) == 0 {
+		return nil, "", models.ErrNoMoreItemsToBeRetrieved
+	}
+
+	entities := make([]*trashItemEntity, 0, len(result.Items))
+
+	err = attributevalue.UnmarshalListOfMaps(result.Items, &entities)
+	if err != nil {
+		return nil, "", fmt.Errorf("unmarshal trash items failed: %v", err)
+	}
+
+	nextKey, err := dynamo.EncodePaginationKey(result.LastEvaluatedKey, scope)
+	if err != nil {
+		return nil, "", err
+	}
+
+	items, err := toTrashItemModels(entities)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return items, nextKey, nil
+}
+
+func (d *DynamoRepository) DeleteTrashItem(ctx context.Context, username, itemID string) error {
+	input := &dynamodb.DeleteItemInput{
+		TableName: aws.String(d.tableName),
+		Key: map[string]types.AttributeValue{
+			"username": &types.AttributeValueMemberS{Value: username},
+			"item_id":  &types.AttributeValueMemberS{Value: itemID},
+		},
+	}
+
+	_, err := d.dynamoClient.DeleteItem(ctx, input)
+	if err != nil {
+		return fmt.Errorf("delete trash item failed: %v", err)
+	}
+
+	return nil
+}
+
+// DeleteAllTrashItems deletes every item in the trash of the user and returns how many were deleted.
+func (d *DynamoRepository) DeleteAllTrashItems(ctx context.Context, username string) (int, error) {
+	keyCondition := expression.Key("username").Equal(expression.Value(username))
+	projection := expression.NamesList(expression.Name("username"), expression.Name("item_id"))
+
+	expr, err := expression.NewBuilder().WithKeyCondition(keyCondition).WithProjection(projection).Build()
+	if err != nil {
+		return 0, fmt.Errorf("build expression failed: %v", err)
+	}
+
+	input := &dynamodb.QueryInput{
+		TableName:                 aws.String(d.tableName),
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		KeyConditionExpression:    expr.KeyCondition(),
+		ProjectionExpression:      expr.Projection(),
+	}
+
+	deleted := 0
+
+	for {
+		result, err := d.dynamoClient.Query(ctx, input)
+		if err != nil {
+			return deleted, fmt.Errorf("query trash items failed: %v", err)
+		}
+
+		if len(result.Items) > 0 {
+			writeRequests := make([]types.WriteRequest, 0, len(result.Items))
+
+			for _, key := range result.Items {
+				writeRequests = append(writeRequests, types.WriteRequest{
+					DeleteRequest: &types.DeleteRequest{Key: key},
+				})
+			}
+
+			err = dynamo.BatchWrite(ctx, d.dynamoClient, &dynamodb.BatchWriteItemInput{
+				RequestItems: map[string][]types.WriteRequest{
+					d.tableName: writeRequests,
+				},
+			})
+			if err != nil {
+				return deleted, fmt.Errorf("delete trash items failed: %w", err)
+			}
+
+			deleted += len(writeRequests)
+		}
+
+		if result.LastEvaluatedKey == nil {
+			return deleted, nil
+		}
+
+		input.ExclusiveStartKey = result.LastEvaluatedKey
+	}
+}
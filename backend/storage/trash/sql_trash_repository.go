@@ -0,0 +1,176 @@
+package trash
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/JoelD7/money/backend/models"
+	"github.com/JoelD7/money/backend/storage/cursor"
+	"github.com/JoelD7/money/backend/storage/sqldb"
+	"time"
+)
+
+const trashItemColumns = `username, item_id, item_type, name, data, deleted_date, expiration_date`
+
+type SQLRepository struct {
+	db        *sqldb.DB
+	retention time.Duration
+}
+
+func NewSQLRepository(db *sqldb.DB, envConfig *models.EnvironmentConfiguration) *SQLRepository {
+	return &SQLRepository{db: db, retention: getRetention(envConfig)}
+}
+
+// CreateTrashItem saves a deleted record in the trash. As there's no TTL in SQL, the expired items of the user are
+// deleted at the same time.
+func (s *SQLRepository) CreateTrashItem(ctx context.Context, item *models.TrashItem) error {
+	setDates(item, s.retention)
+
+	entity, err := toTrashItemEntity(item)
+	if err != nil {
+		return err
+	}
+
+	return s.db.WithTx(ctx, func(tx *sqldb.Tx) error {
+		_, err := tx.ExecContext(ctx, `DELETE FROM trash WHERE username = ? AND expiration_date <= ?`, entity.Username,
+			sqldb.FormatTime(time.Now()))
+		if err != nil {
+			return fmt.Errorf("delete expired trash items failed: %v", err)
+		}
+
+		_, err = tx.ExecContext(ctx, `INSERT INTO trash (`+trashItemColumns+`) VALUES (?, ?, ?, ?, ?, ?, ?)
+			ON CONFLICT (username, item_id) DO UPDATE SET item_type = excluded.item_type, name = excluded.name,
+			data = excluded.data, deleted_date = excluded.deleted_date, expiration_date = excluded.expiration_date`,
+			entity.Username, entity.ItemID, string(entity.Type), entity.Name, entity.Data,
+			sqldb.FormatTime(entity.DeletedDate), sqldb.FormatTime(time.Unix(entity.ExpirationTime, 0)))
+		if err != nil {
+			return fmt.Errorf("insert trash item failed: %v", err)
+		}
+
+		return nil
+	})
+}
+
+func (s *SQLRepository) GetTrashItem(ctx context.Context, username, itemID string) (*models.TrashItem, error) {
+	row := s.db.QueryRowContext(ctx, `SELECT `+trashItemColumns+` FROM trash
+		WHERE username = ? AND item_id = ? AND expiration_date > ?`, username, itemID, sqldb.FormatTime(time.Now()))
+
+	entity, err := scanTrashItem(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, models.ErrTrashItemNotFound
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return toTrashItemModel(entity)
+}
+
+// GetTrashItems returns a page of the trash of the user, most recently deleted first.
+func (s *SQLRepository) GetTrashItems(ctx context.Context, username string, params *models.QueryParameters) ([]*models.TrashItem, string, error) {
+	page := &sqldb.Page{
+		SortColumn: "deleted_date",
+		IDColumn:   "item_id",
+		Descending: true,
+		StartKey:   params.StartKey,
+		PageSize:   params.PageSize,
+		Scope:      cursor.NewScope(username, params),
+	}
+
+	condition, args, err := page.Condition()
+	if err != nil {
+		return nil, "", err
+	}
+
+	rows, err := s.db.QueryContext(ctx, `SELECT `+trashItemColumns+` FROM trash
+		WHERE username = ? AND expiration_date > ? AND `+condition+` `+page.OrderBy(),
+		append([]interface{}{username, sqldb.FormatTime(time.Now())}, args...)...)
+	if err != nil {
+		return nil, "", fmt.Errorf("query trash items failed: %v", err)
+	}
+
+	defer rows.Close()
+
+	entities := make([]*trashItemEntity, 0)
+
+	for rows.Next() {
+		entity, err := scanTrashItem(rows)
+		if err != nil {
+			return nil, "", err
+		}
+
+		entities = append(entities, entity)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, "", fmt.Errorf("query trash items failed: %v", err)
+	}
+
+	entities, nextKey, err := sqldb.Paginate(entities, page, func(e *trashItemEntity) (interface{}, string) {
+		return sqldb.FormatTime(e.DeletedDate), e.ItemID
+	}, models.ErrTrashItemsNotFound)
+	if err != nil {
+		return nil, "", err
+	}
+
+	items, err := toTrashItemModels(entities)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return items, nextKey, nil
+}
+
+func (s *SQLRepository) DeleteTrashItem(ctx context.Context, username, itemID string) error {
+	_, err := s.db.ExecContext(ctx, `DELETE FROM trash WHERE username = ? AND item_id = ?`, username, itemID)
+	if err != nil {
+		return fmt.Errorf("delete trash item failed: %v", err)
+	}
+
+	return nil
+}
+
+// DeleteAllTrashItems deletes every item in the trash of the user and returns how many were deleted.
+func (s *SQLRepository) DeleteAllTrashItems(ctx context.Context, username string) (int, error) {
+	result, err := s.db.ExecContext(ctx, `DELETE FROM trash WHERE username = ?`, username)
+	if err != nil {
+		return 0, fmt.Errorf("delete trash items failed: %v", err)
+	}
+
+	deleted, err := sqldb.RowsAffected(result)
+	if err != nil {
+		return 0, err
+	}
+
+	return int(deleted), nil
+}
+
+func scanTrashItem(row sqldb.Scanner) (*trashItemEntity, error) {
+	entity := new(trashItemEntity)
+
+	var itemType, deletedDate, expirationDate string
+
+	err := row.Scan(&entity.Username, &entity.ItemID, &itemType, &entity.Name, &entity.Data, &deletedDate,
+		&expirationDate)
+	if err != nil {
+		return nil, err
+	}
+
+	entity.Type = models.TrashItemType(itemType)
+
+	entity.DeletedDate, err = sqldb.ParseTime(deletedDate)
+	if err != nil {
+		return nil, err
+	}
+
+	expiration, err := sqldb.ParseTime(expirationDate)
+	if err != nil {
+		return nil, err
+	}
+
+	entity.ExpirationTime = expiration.Unix()
+
+	return entity, nil
+}
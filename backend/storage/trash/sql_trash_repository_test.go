@@ -0,0 +1,97 @@
+package trash
+
+import (
+	"context"
+	"github.com/JoelD7/money/backend/models"
+	"github.com/JoelD7/money/backend/storage/sqldb"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestSQLRepository(t *testing.T) {
+	t.Setenv("PAGINATION_KEY_SECRET", "pagination-secret")
+
+	c := require.New(t)
+
+	ctx := context.Background()
+	repo := NewSQLRepository(newTestDB(c), &models.EnvironmentConfiguration{TrashRetentionDays: 30})
+
+	now := time.Now()
+
+	for i, id := range []string{"EX1", "EX2", "EX3"} {
+		name := id
+		amount := models.Money(100)
+
+		expense := &models.Expense{ExpenseID: id, Username: "test", Name: &name, Amount: &amount}
+		item := models.NewExpenseTrashItem(expense)
+		item.DeletedDate = now.Add(time.Duration(i) * time.Hour)
+
+		c.NoError(repo.CreateTrashItem(ctx, item))
+	}
+
+	item, err := repo.GetTrashItem(ctx, "test", "EX1")
+	c.NoError(err)
+	c.Equal(models.TrashItemTypeExpense, item.Type)
+	c.Equal("EX1", item.Expense.ExpenseID)
+	c.Equal(models.Money(100), item.Expense.GetAmount())
+	c.WithinDuration(now.Add(30*24*time.Hour), item.ExpirationDate, time.Second)
+
+	items, nextKey, err := repo.GetTrashItems(ctx, "test", &models.QueryParameters{PageSize: 2})
+	c.NoError(err)
+	c.NotEmpty(nextKey)
+	c.Equal([]string{"EX3", "EX2"}, getItemIDs(items))
+
+	items, nextKey, err = repo.GetTrashItems(ctx, "test", &models.QueryParameters{PageSize: 2, StartKey: nextKey})
+	c.NoError(err)
+	c.Empty(nextKey)
+	c.Equal([]string{"EX1"}, getItemIDs(items))
+
+	t.Run("Expired items are hidden", func(t *testing.T) {
+		expired := models.NewIncomeTrashItem(&models.Income{IncomeID: "IN1", Username: "test"})
+		expired.DeletedDate = now.Add(-31 * 24 * time.Hour)
+
+		c.NoError(repo.CreateTrashItem(ctx, expired))
+
+		_, err = repo.GetTrashItem(ctx, "test", "IN1")
+		c.ErrorIs(err, models.ErrTrashItemNotFound)
+
+		items, _, err = repo.GetTrashItems(ctx, "test", &models.QueryParameters{})
+		c.NoError(err)
+		c.Len(items, 3)
+	})
+
+	t.Run("Purge", func(t *testing.T) {
+		c.NoError(repo.DeleteTrashItem(ctx, "test", "EX2"))
+
+		_, err = repo.GetTrashItem(ctx, "test", "EX2")
+		c.ErrorIs(err, models.ErrTrashItemNotFound)
+
+		// The expired item is deleted too.
+		deleted, err := repo.DeleteAllTrashItems(ctx, "test")
+		c.NoError(err)
+		c.Equal(3, deleted)
+
+		_, _, err = repo.GetTrashItems(ctx, "test", &models.QueryParameters{})
+		c.ErrorIs(err, models.ErrTrashItemsNotFound)
+	})
+}
+
+func getItemIDs(items []*models.TrashItem) []string {
+	ids := make([]string, 0, len(items))
+
+	for _, item := range items {
+		ids = append(ids, item.ItemID)
+	}
+
+	return ids
+}
+
+func newTestDB(c *require.Assertions) *sqldb.DB {
+	db, err := sqldb.Open(sqldb.DriverSQLite, ":memory:")
+	c.NoError(err)
+
+	c.NoError(db.Migrate(context.Background()))
+
+	return db
+}
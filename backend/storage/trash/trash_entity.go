@@ -0,0 +1,124 @@
+package trash
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/JoelD7/money/backend/models"
+	"time"
+)
+
+type trashItemEntity struct {
+	Username string               `json:"username" dynamodbav:"username"`
+	ItemID   string               `json:"item_id" dynamodbav:"item_id"`
+	Type     models.TrashItemType `json:"type" dynamodbav:"type"`
+	Name     string               `json:"name,omitempty" dynamodbav:"name,omitempty"`
+	// Data is the deleted record as JSON, so that items of every type can be stored in the same table.
+	Data        string    `json:"data" dynamodbav:"data"`
+	DeletedDate time.Time `json:"deleted_date" dynamodbav:"deleted_date"`
+	// ExpirationTime is the TTL attribute of the table, in Unix seconds.
+	ExpirationTime int64 `json:"expiration_time" dynamodbav:"expiration_time"`
+}
+
+// setDates sets the deletion date of a new trash item and the date it expires after retention.
+func setDates(item *models.TrashItem, retention time.Duration) {
+	if item.DeletedDate.IsZero() {
+		item.DeletedDate = time.Now()
+	}
+
+	item.ExpirationDate = item.DeletedDate.Add(retention)
+}
+
+func toTrashItemEntity(item *models.TrashItem) (*trashItemEntity, error) {
+	var record interface{}
+
+	switch item.Type {
+	case models.TrashItemTypeExpense:
+		record = item.Expense
+	case models.TrashItemTypeIncome:
+		record = item.Income
+	case models.TrashItemTypeSaving:
+		record = item.Saving
+	case models.TrashItemTypeSavingGoal:
+		record = item.SavingGoal
+	case models.TrashItemTypePeriod:
+		record = item.Period
+	default:
+		return nil, fmt.Errorf("%w: %s", models.ErrInvalidTrashItemType, item.Type)
+	}
+
+	data, err := json.Marshal(record)
+	if err != nil {
+		return nil, fmt.Errorf("marshal trash item record failed: %v", err)
+	}
+
+	return &trashItemEntity{
+		Username:       item.Username,
+		ItemID:         item.ItemID,
+		Type:           item.Type,
+		Name:           item.Name,
+		Data:           string(data),
+		DeletedDate:    item.DeletedDate,
+		ExpirationTime: item.ExpirationDate.Unix(),
+	}, nil
+}
+
+func toTrashItemModel(e *trashItemEntity) (*models.TrashItem, error) {
+	item := &models.TrashItem{
+		Username:       e.Username,
+		ItemID:         e.ItemID,
+		Type:           e.Type,
+		Name:           e.Name,
+		DeletedDate:    e.DeletedDate,
+		ExpirationDate: time.Unix(e.ExpirationTime, 0),
+	}
+
+	var record interface{}
+
+	switch e.Type {
+	case models.TrashItemTypeExpense:
+		item.Expense = new(models.Expense)
+		record = item.Expense
+	case models.TrashItemTypeIncome:
+		item.Income = new(models.Income)
+		record = item.Income
+	case models.TrashItemTypeSaving:
+		item.Saving = new(models.Saving)
+		record = item.Saving
+	case models.TrashItemTypeSavingGoal:
+		item.SavingGoal = new(models.SavingGoal)
+		record = item.SavingGoal
+	case models.TrashItemTypePeriod:
+		item.Period = new(models.Period)
+		record = item.Period
+	default:
+		return nil, fmt.Errorf("%w: %s", models.ErrInvalidTrashItemType, e.Type)
+	}
+
+	err := json.Unmarshal([]byte(e.Data), record)
+	if err != nil {
+		return nil, fmt.Errorf("unmarshal trash item record failed: %v", err)
+	}
+
+	return item, nil
+}
+
+// isExpired tells if the item is past its retention period. DynamoDB may take a while to delete expired items, so they
+// must be skipped when read.
+func (e *trashItemEntity) isExpired(now time.Time) bool {
+	return e.ExpirationTime <= now.Unix()
+}
+
+func toTrashItemModels(entities []*trashItemEntity) ([]*models.TrashItem, error) {
+	items := make([]*models.TrashItem, 0, len(entities))
+
+	for _, entity := range entities {
+		item, err := toTrashItemModel(entity)
+		if err != nil {
+			return nil, err
+		}
+
+		items = append(items, item)
+	}
+
+	return items, nil
+}
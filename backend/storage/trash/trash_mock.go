@@ -0,0 +1,102 @@
+package trash
+
+import (
+	"context"
+	"github.com/JoelD7/money/backend/models"
+	"sort"
+	"time"
+)
+
+type Mock struct {
+	mockedErr error
+	items     map[string]map[string]*models.TrashItem
+}
+
+func NewMock() *Mock {
+	return &Mock{
+		items: make(map[string]map[string]*models.TrashItem),
+	}
+}
+
+func (m *Mock) ActivateForceFailure(err error) {
+	m.mockedErr = err
+}
+
+func (m *Mock) DeactivateForceFailure() {
+	m.mockedErr = nil
+}
+
+func (m *Mock) CreateTrashItem(ctx context.Context, item *models.TrashItem) error {
+	if m.mockedErr != nil {
+		return m.mockedErr
+	}
+
+	setDates(item, getRetention(nil))
+
+	if m.items[item.Username] == nil {
+		m.items[item.Username] = make(map[string]*models.TrashItem)
+	}
+
+	m.items[item.Username][item.ItemID] = item
+
+	return nil
+}
+
+func (m *Mock) GetTrashItem(ctx context.Context, username, itemID string) (*models.TrashItem, error) {
+	if m.mockedErr != nil {
+		return nil, m.mockedErr
+	}
+
+	item, ok := m.items[username][itemID]
+	if !ok || !item.ExpirationDate.After(time.Now()) {
+		return nil, models.ErrTrashItemNotFound
+	}
+
+	return item, nil
+}
+
+// GetTrashItems returns all the trash of the user, most recently deleted first. The pagination parameters are ignored.
+func (m *Mock) GetTrashItems(ctx context.Context, username string, params *models.QueryParameters) ([]*models.TrashItem, string, error) {
+	if m.mockedErr != nil {
+		return nil, "", m.mockedErr
+	}
+
+	items := make([]*models.TrashItem, 0, len(m.items[username]))
+
+	for _, item := range m.items[username] {
+		if item.ExpirationDate.After(time.Now()) {
+			items = append(items, item)
+		}
+	}
+
+	if len(items) == 0 {
+		return nil, "", models.ErrTrashItemsNotFound
+	}
+
+	sort.Slice(items, func(i, j int) bool {
+		return items[i].DeletedDate.After(items[j].DeletedDate)
+	})
+
+	return items, "", nil
+}
+
+func (m *Mock) DeleteTrashItem(ctx context.Context, username, itemID string) error {
+	if m.mockedErr != nil {
+		return m.mockedErr
+	}
+
+	delete(m.items[username], itemID)
+
+	return nil
+}
+
+func (m *Mock) DeleteAllTrashItems(ctx context.Context, username string) (int, error) {
+	if m.mockedErr != nil {
+		return 0, m.mockedErr
+	}
+
+	deleted := len(m.items[username])
+	delete(m.items, username)
+
+	return deleted, nil
+}
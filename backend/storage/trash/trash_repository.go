@@ -0,0 +1,51 @@
+package trash
+
+import (
+	"context"
+	"github.com/JoelD7/money/backend/models"
+	"github.com/JoelD7/money/backend/storage/sqldb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"time"
+)
+
+const (
+	defaultRetentionDays = 30
+)
+
+type Repository interface {
+	CreateTrashItem(ctx context.Context, item *models.TrashItem) error
+	GetTrashItem(ctx context.Context, username, itemID string) (*models.TrashItem, error)
+	GetTrashItems(ctx context.Context, username string, params *models.QueryParameters) ([]*models.TrashItem, string, error)
+	DeleteTrashItem(ctx context.Context, username, itemID string) error
+	DeleteAllTrashItems(ctx context.Context, username string) (int, error)
+}
+
+// NewRepository returns the repository of the storage backend set in the configuration.
+func NewRepository(ctx context.Context, dynamoClient *dynamodb.Client, envConfig *models.EnvironmentConfiguration) (Repository, error) {
+	if envConfig.StorageBackend != models.StorageBackendSQL {
+		repo, err := NewDynamoRepository(dynamoClient, envConfig)
+		if err != nil {
+			return nil, err
+		}
+
+		return repo, nil
+	}
+
+	db, err := sqldb.Connect(ctx, envConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewSQLRepository(db, envConfig), nil
+}
+
+// getRetention returns how long the items are kept in the trash.
+func getRetention(envConfig *models.EnvironmentConfiguration) time.Duration {
+	days := defaultRetentionDays
+
+	if envConfig != nil && envConfig.TrashRetentionDays > 0 {
+		days = envConfig.TrashRetentionDays
+	}
+
+	return time.Duration(days) * 24 * time.Hour
+}
@@ -0,0 +1,75 @@
+package users
+
+import (
+	"context"
+	"github.com/JoelD7/money/backend/models"
+	"github.com/JoelD7/money/backend/storage/audit"
+)
+
+// auditedRepository records the changes made to the categories of the users in the audit log. Categories are stored in
+// the user, so they are compared before and after every update of the user.
+type auditedRepository struct {
+	Repository
+	recorder *audit.Recorder
+}
+
+func newAuditedRepository(repo Repository, recorder *audit.Recorder) Repository {
+	if recorder == nil {
+		return repo
+	}
+
+	return &auditedRepository{Repository: repo, recorder: recorder}
+}
+
+func (a *auditedRepository) UpdateUser(ctx context.Context, user *models.User) error {
+	old, err := a.Repository.GetUser(ctx, user.Username)
+	if err != nil {
+		return a.Repository.UpdateUser(ctx, user)
+	}
+
+	err = a.Repository.UpdateUser(ctx, user)
+	if err != nil {
+		return err
+	}
+
+	updated, err := a.Repository.GetUser(ctx, user.Username)
+	if err != nil {
+		updated = user
+	}
+
+	a.recorder.Record(ctx, getCategoryChanges(user.Username, old.Categories, updated.Categories)...)
+
+	return nil
+}
+
+// getCategoryChanges returns the categories that were created, updated or deleted, matching them by ID.
+func getCategoryChanges(username string, oldCategories, newCategories []*models.Category) []*audit.Change {
+	oldByID := make(map[string]*models.Category, len(oldCategories))
+
+	for _, category := range oldCategories {
+		oldByID[category.ID] = category
+	}
+
+	changes := make([]*audit.Change, 0)
+
+	for _, category := range newCategories {
+		change := &audit.Change{Username: username, EntityType: models.AuditEntityCategory, EntityID: category.ID,
+			New: category}
+
+		if old, ok := oldByID[category.ID]; ok {
+			change.Old = old
+			delete(oldByID, category.ID)
+		}
+
+		changes = append(changes, change)
+	}
+
+	for _, category := range oldCategories {
+		if _, ok := oldByID[category.ID]; ok {
+			changes = append(changes, &audit.Change{Username: username, EntityType: models.AuditEntityCategory,
+				EntityID: category.ID, Old: category})
+		}
+	}
+
+	return changes
+}
@@ -5,8 +5,10 @@ import (
 	"fmt"
 	"github.com/JoelD7/money/backend/models"
 	"github.com/JoelD7/money/backend/shared/env"
+	"github.com/JoelD7/money/backend/storage/dynamo"
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/expression"
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
 	"strings"
@@ -36,6 +38,7 @@ func NewDynamoRepository(dynamoClient *dynamodb.Client, tableName string) (*Dyna
 
 func (d *DynamoRepository) CreateUser(ctx context.Context, u *models.User) (*models.User, error) {
 	user := toUserEntity(u)
+	user.setInitialVersion()
 
 	item, err := attributevalue.MarshalMap(user)
 	if err != nil {
@@ -91,22 +94,38 @@ func (d *DynamoRepository) GetUser(ctx context.Context, username string) (*model
 	return toUserModel(user), nil
 }
 
+// UpdateUser replaces the user. It fails with models.ErrVersionConflict if the stored user isn't at the version of the
+// given one, which is what keeps concurrent changes to the categories from overwriting each other.
 func (d *DynamoRepository) UpdateUser(ctx context.Context, u *models.User) error {
 	user := toUserEntity(u)
 
 	user.UpdatedDate = time.Now()
+	user.Version = u.Version + 1
 
 	updatedItem, err := attributevalue.MarshalMap(user)
 	if err != nil {
 		return err
 	}
 
+	expr, err := expression.NewBuilder().WithCondition(dynamo.VersionCondition(u.Version)).Build()
+	if err != nil {
+		return fmt.Errorf("build expression failed: %v", err)
+	}
+
 	input := &dynamodb.PutItemInput{
-		Item:      updatedItem,
-		TableName: aws.String(d.tableName),
+		Item:                                updatedItem,
+		TableName:                           aws.String(d.tableName),
+		ConditionExpression:                 expr.Condition(),
+		ExpressionAttributeNames:            expr.Names(),
+		ExpressionAttributeValues:           expr.Values(),
+		ReturnValuesOnConditionCheckFailure: types.ReturnValuesOnConditionCheckFailureAllOld,
 	}
 
 	_, err = d.dynamoClient.PutItem(ctx, input)
+	if dynamo.IsVersionConflict(err) {
+		return fmt.Errorf("%v: %w", err, models.ErrVersionConflict)
+	}
+
 	return err
 }
 
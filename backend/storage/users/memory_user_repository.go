@@ -0,0 +1,90 @@
+package users
+
+import (
+	"context"
+	"github.com/JoelD7/money/backend/models"
+	"github.com/JoelD7/money/backend/storage/memory"
+	"sync"
+	"time"
+)
+
+// MemoryRepository is a thread-safe implementation of Repository that keeps the users in memory.
+type MemoryRepository struct {
+	mu    sync.RWMutex
+	users map[string]*userEntity
+}
+
+func NewMemoryRepository() *MemoryRepository {
+	return &MemoryRepository{
+		users: make(map[string]*userEntity),
+	}
+}
+
+func (m *MemoryRepository) CreateUser(ctx context.Context, u *models.User) (*models.User, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.users[u.Username]; ok {
+		return nil, models.ErrExistingUser
+	}
+
+	entity := toUserEntity(u)
+	entity.setInitialVersion()
+
+	user, err := memory.Copy(entity)
+	if err != nil {
+		return nil, err
+	}
+
+	m.users[u.Username] = user
+
+	return toUserModel(entity), nil
+}
+
+func (m *MemoryRepository) GetUser(ctx context.Context, username string) (*models.User, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	user, ok := m.users[username]
+	if !ok {
+		return nil, models.ErrUserNotFound
+	}
+
+	copied, err := memory.Copy(user)
+	if err != nil {
+		return nil, err
+	}
+
+	return toUserModel(copied), nil
+}
+
+func (m *MemoryRepository) UpdateUser(ctx context.Context, u *models.User) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if stored, ok := m.users[u.Username]; ok && stored.Version != u.Version {
+		return models.ErrVersionConflict
+	}
+
+	entity := toUserEntity(u)
+	entity.UpdatedDate = time.Now()
+	entity.Version = u.Version + 1
+
+	user, err := memory.Copy(entity)
+	if err != nil {
+		return err
+	}
+
+	m.users[u.Username] = user
+
+	return nil
+}
+
+func (m *MemoryRepository) DeleteUser(ctx context.Context, username string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.users, username)
+
+	return nil
+}
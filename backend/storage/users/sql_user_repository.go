@@ -0,0 +1,176 @@
+package users
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/JoelD7/money/backend/models"
+	"github.com/JoelD7/money/backend/storage/sqldb"
+	"time"
+)
+
+const userColumns = `username, full_name, password, categories, created_date, updated_date, access_token, refresh_token,
+	current_period, base_currency, version, password_reset_token, password_reset_expiration, email_verified, mfa_enabled, mfa_secret,
+	mfa_recovery_codes, mfa_last_used_step, legacy_currency`
+
+type SQLRepository struct {
+	db *sqldb.DB
+}
+
+func NewSQLRepository(db *sqldb.DB) *SQLRepository {
+	return &SQLRepository{db: db}
+}
+
+func (s *SQLRepository) CreateUser(ctx context.Context, u *models.User) (*models.User, error) {
+	user := toUserEntity(u)
+	user.setInitialVersion()
+
+	args, err := getUserArgs(user)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := s.db.ExecContext(ctx, `INSERT INTO users (`+userColumns+`) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT (username) DO NOTHING`, args...)
+	if err != nil {
+		return nil, fmt.Errorf("insert user failed: %v", err)
+	}
+
+	affected, err := sqldb.RowsAffected(result)
+	if err != nil {
+		return nil, err
+	}
+
+	if affected == 0 {
+		return nil, models.ErrExistingUser
+	}
+
+	return toUserModel(user), nil
+}
+
+func (s *SQLRepository) GetUser(ctx context.Context, username string) (*models.User, error) {
+	row := s.db.QueryRowContext(ctx, `SELECT `+userColumns+` FROM users WHERE username = ?`, username)
+
+	user, err := scanUser(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, models.ErrUserNotFound
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return toUserModel(user), nil
+}
+
+// UpdateUser replaces the stored user with the given one, creating it if it doesn't exist, as the DynamoDB repository
+// does. It fails with models.ErrVersionConflict if the stored user isn't at the version of the given one.
+func (s *SQLRepository) UpdateUser(ctx context.Context, u *models.User) error {
+	user := toUserEntity(u)
+	user.UpdatedDate = time.Now()
+	user.Version = u.Version + 1
+
+	args, err := getUserArgs(user)
+	if err != nil {
+		return err
+	}
+
+	result, err := s.db.ExecContext(ctx, `INSERT INTO users (`+userColumns+`) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT (username) DO UPDATE SET full_name = excluded.full_name, password = excluded.password,
+		categories = excluded.categories, created_date = excluded.created_date, updated_date = excluded.updated_date,
+		access_token = excluded.access_token, refresh_token = excluded.refresh_token,
+		current_period = excluded.current_period, base_currency = excluded.base_currency, version = excluded.version,
+		password_reset_token = excluded.password_reset_token, password_reset_expiration = excluded.password_reset_expiration,
+		email_verified = excluded.email_verified, mfa_enabled = excluded.mfa_enabled, mfa_secret = excluded.mfa_secret,
+		mfa_recovery_codes = excluded.mfa_recovery_codes, mfa_last_used_step = excluded.mfa_last_used_step,
+		legacy_currency = excluded.legacy_currency
+		WHERE users.version = ?`, append(args, u.Version)...)
+	if err != nil {
+		return fmt.Errorf("update user failed: %v", err)
+	}
+
+	affected, err := sqldb.RowsAffected(result)
+	if err != nil {
+		return err
+	}
+
+	// The user exists if nothing was inserted nor updated.
+	if affected == 0 {
+		return models.ErrVersionConflict
+	}
+
+	return nil
+}
+
+func (s *SQLRepository) DeleteUser(ctx context.Context, username string) error {
+	_, err := s.db.ExecContext(ctx, `DELETE FROM users WHERE username = ?`, username)
+	if err != nil {
+		return fmt.Errorf("delete user failed: %v", err)
+	}
+
+	return nil
+}
+
+func getUserArgs(user *userEntity) ([]interface{}, error) {
+	categories, err := sqldb.MarshalJSON(user.Categories)
+	if err != nil {
+		return nil, err
+	}
+
+	recoveryCodes, err := sqldb.MarshalJSON(user.MFARecoveryCodes)
+	if err != nil {
+		return nil, err
+	}
+
+	return []interface{}{user.Username, user.FullName, user.Password, categories, sqldb.FormatTime(user.CreatedDate),
+		sqldb.FormatTime(user.UpdatedDate), user.AccessToken, user.RefreshToken, user.CurrentPeriod, user.BaseCurrency,
+		user.Version, user.PasswordResetToken, sqldb.FormatNullTime(user.PasswordResetExpiration),
+		user.EmailVerified == nil || *user.EmailVerified, user.MFAEnabled, user.MFASecret, recoveryCodes,
+		user.MFALastUsedStep, user.LegacyCurrency}, nil
+}
+
+func scanUser(row sqldb.Scanner) (*userEntity, error) {
+	user := new(userEntity)
+
+	var categories, passwordResetExpiration, recoveryCodes sql.NullString
+	var createdDate, updatedDate string
+	var emailVerified bool
+
+	err := row.Scan(&user.Username, &user.FullName, &user.Password, &categories, &createdDate, &updatedDate,
+		&user.AccessToken, &user.RefreshToken, &user.CurrentPeriod, &user.BaseCurrency, &user.Version,
+		&user.PasswordResetToken, &passwordResetExpiration, &emailVerified, &user.MFAEnabled, &user.MFASecret,
+		&recoveryCodes, &user.MFALastUsedStep, &user.LegacyCurrency)
+	if err != nil {
+		return nil, err
+	}
+
+	err = sqldb.UnmarshalJSON(categories, &user.Categories)
+	if err != nil {
+		return nil, err
+	}
+
+	user.CreatedDate, err = sqldb.ParseTime(createdDate)
+	if err != nil {
+		return nil, err
+	}
+
+	user.UpdatedDate, err = sqldb.ParseTime(updatedDate)
+	if err != nil {
+		return nil, err
+	}
+
+	user.PasswordResetExpiration, err = sqldb.ParseNullTime(passwordResetExpiration)
+	if err != nil {
+		return nil, err
+	}
+
+	user.EmailVerified = &emailVerified
+
+	err = sqldb.UnmarshalJSON(recoveryCodes, &user.MFARecoveryCodes)
+	if err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
@@ -0,0 +1,65 @@
+package users
+
+import (
+	"context"
+	"github.com/JoelD7/money/backend/models"
+	"github.com/JoelD7/money/backend/storage/sqldb"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestSQLRepositoryUpdate(t *testing.T) {
+	c := require.New(t)
+
+	ctx := context.Background()
+
+	db, err := sqldb.Open(sqldb.DriverSQLite, ":memory:")
+	c.NoError(err)
+	c.NoError(db.Migrate(ctx))
+
+	repo := NewSQLRepository(db)
+
+	unverified := GetDummyUser()
+	unverified.Verified = false
+
+	user, err := repo.CreateUser(ctx, unverified)
+	c.NoError(err)
+	c.False(user.Verified)
+
+	expiration := time.Now().Add(time.Hour).Truncate(time.Millisecond)
+	user.Verified = true
+	user.PasswordResetToken = "hashed-token"
+	user.PasswordResetExpiration = &expiration
+	user.MFAEnabled = true
+	user.MFASecret = "JBSWY3DPEHPK3PXP"
+	user.MFARecoveryCodes = []string{"hashed-code-1", "hashed-code-2"}
+	user.MFALastUsedStep = 56666666
+
+	c.NoError(repo.UpdateUser(ctx, user))
+
+	stored, err := repo.GetUser(ctx, user.Username)
+	c.NoError(err)
+	c.True(stored.Verified)
+	c.Equal("hashed-token", stored.PasswordResetToken)
+	c.True(expiration.Equal(*stored.PasswordResetExpiration))
+	c.True(stored.MFAEnabled)
+	c.Equal("JBSWY3DPEHPK3PXP", stored.MFASecret)
+	c.Equal([]string{"hashed-code-1", "hashed-code-2"}, stored.MFARecoveryCodes)
+	c.Equal(int64(56666666), stored.MFALastUsedStep)
+
+	stored.PasswordResetToken = ""
+	stored.PasswordResetExpiration = nil
+
+	c.NoError(repo.UpdateUser(ctx, stored))
+
+	stored, err = repo.GetUser(ctx, user.Username)
+	c.NoError(err)
+	c.Empty(stored.PasswordResetToken)
+	c.Nil(stored.PasswordResetExpiration)
+
+	t.Run("Stale version", func(t *testing.T) {
+		err = repo.UpdateUser(ctx, user)
+		c.ErrorIs(err, models.ErrVersionConflict)
+	})
+}
@@ -2,6 +2,7 @@ package users
 
 import (
 	"github.com/JoelD7/money/backend/models"
+	"github.com/aws/aws-sdk-go-v2/aws"
 	"time"
 )
 
@@ -15,13 +16,28 @@ type userEntity struct {
 	AccessToken   string            `json:"-" dynamodbav:"access_token,omitempty"`
 	RefreshToken  string            `json:"-" dynamodbav:"refresh_token"`
 	CurrentPeriod string            `json:"current_period,omitempty" dynamodbav:"current_period,omitempty"`
+	BaseCurrency  string            `json:"base_currency,omitempty" dynamodbav:"base_currency,omitempty"`
+	Version       int64             `json:"version,omitempty" dynamodbav:"version,omitempty"`
+	// EmailVerified is nil for the users created before emails were verified, which are considered verified.
+	EmailVerified *bool `json:"verified,omitempty" dynamodbav:"email_verified,omitempty"`
+	// LegacyCurrency is the currency of the records saved without one.
+	LegacyCurrency string `json:"legacy_currency,omitempty" dynamodbav:"legacy_currency,omitempty"`
+
+	PasswordResetToken      string     `json:"-" dynamodbav:"password_reset_token,omitempty"`
+	PasswordResetExpiration *time.Time `json:"-" dynamodbav:"password_reset_expiration,omitempty"`
+
+	MFAEnabled       bool     `json:"mfa_enabled" dynamodbav:"mfa_enabled,omitempty"`
+	MFASecret        string   `json:"-" dynamodbav:"mfa_secret,omitempty"`
+	MFARecoveryCodes []string `json:"-" dynamodbav:"mfa_recovery_codes,omitempty"`
+	MFALastUsedStep  int64    `json:"-" dynamodbav:"mfa_last_used_step,omitempty"`
 }
 
 type categoryEntity struct {
-	ID     string   `json:"id,omitempty" dynamodbav:"id"`
-	Name   *string  `json:"name,omitempty" dynamodbav:"name"`
-	Budget *float64 `json:"budget,omitempty" dynamodbav:"budget,omitempty"`
-	Color  *string  `json:"color,omitempty" dynamodbav:"color,omitempty"`
+	ID       string        `json:"id,omitempty" dynamodbav:"id"`
+	Name     *string       `json:"name,omitempty" dynamodbav:"name"`
+	Budget   *models.Money `json:"budget,omitempty" dynamodbav:"budget,omitempty"`
+	Color    *string       `json:"color,omitempty" dynamodbav:"color,omitempty"`
+	Keywords []string      `json:"keywords,omitempty" dynamodbav:"keywords,omitempty"`
 }
 
 func toUserEntity(u *models.User) *userEntity {
@@ -35,6 +51,19 @@ func toUserEntity(u *models.User) *userEntity {
 		AccessToken:   u.AccessToken,
 		RefreshToken:  u.RefreshToken,
 		CurrentPeriod: u.CurrentPeriod,
+		BaseCurrency:  u.BaseCurrency,
+		Version:       u.Version,
+		EmailVerified: aws.Bool(u.Verified),
+
+		LegacyCurrency: u.LegacyCurrency,
+
+		PasswordResetToken:      u.PasswordResetToken,
+		PasswordResetExpiration: u.PasswordResetExpiration,
+
+		MFAEnabled:       u.MFAEnabled,
+		MFASecret:        u.MFASecret,
+		MFARecoveryCodes: u.MFARecoveryCodes,
+		MFALastUsedStep:  u.MFALastUsedStep,
 	}
 }
 
@@ -50,10 +79,11 @@ func toCategoryEntities(modelCategories []*models.Category) []*categoryEntity {
 
 func toCategoryEntity(modelCategory *models.Category) *categoryEntity {
 	return &categoryEntity{
-		ID:     modelCategory.ID,
-		Name:   modelCategory.Name,
-		Budget: modelCategory.Budget,
-		Color:  modelCategory.Color,
+		ID:       modelCategory.ID,
+		Name:     modelCategory.Name,
+		Budget:   modelCategory.Budget,
+		Color:    modelCategory.Color,
+		Keywords: modelCategory.Keywords,
 	}
 }
 
@@ -68,6 +98,19 @@ func toUserModel(u *userEntity) *models.User {
 		AccessToken:   u.AccessToken,
 		RefreshToken:  u.RefreshToken,
 		CurrentPeriod: u.CurrentPeriod,
+		BaseCurrency:  u.BaseCurrency,
+		Version:       u.Version,
+		Verified:      u.EmailVerified == nil || *u.EmailVerified,
+
+		LegacyCurrency: u.LegacyCurrency,
+
+		PasswordResetToken:      u.PasswordResetToken,
+		PasswordResetExpiration: u.PasswordResetExpiration,
+
+		MFAEnabled:       u.MFAEnabled,
+		MFASecret:        u.MFASecret,
+		MFARecoveryCodes: u.MFARecoveryCodes,
+		MFALastUsedStep:  u.MFALastUsedStep,
 	}
 }
 
@@ -83,9 +126,17 @@ func toCategoryModels(entityCategories []*categoryEntity) []*models.Category {
 
 func toCategoryModel(entityCategory *categoryEntity) *models.Category {
 	return &models.Category{
-		ID:     entityCategory.ID,
-		Name:   entityCategory.Name,
-		Budget: entityCategory.Budget,
-		Color:  entityCategory.Color,
+		ID:       entityCategory.ID,
+		Name:     entityCategory.Name,
+		Budget:   entityCategory.Budget,
+		Color:    entityCategory.Color,
+		Keywords: entityCategory.Keywords,
+	}
+}
+
+// setInitialVersion sets the version of a user that's being created, unless it already has one.
+func (u *userEntity) setInitialVersion() {
+	if u.Version == 0 {
+		u.Version = 1
 	}
 }
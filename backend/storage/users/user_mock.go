@@ -76,6 +76,13 @@ func (d *DynamoMock) UpdateUser(ctx context.Context, user *models.User) error {
 			mockedUser.UpdatedDate = user.UpdatedDate
 			mockedUser.CreatedDate = user.CreatedDate
 			mockedUser.Remainder = user.Remainder
+			mockedUser.PasswordResetToken = user.PasswordResetToken
+			mockedUser.PasswordResetExpiration = user.PasswordResetExpiration
+			mockedUser.Verified = user.Verified
+			mockedUser.MFAEnabled = user.MFAEnabled
+			mockedUser.MFASecret = user.MFASecret
+			mockedUser.MFARecoveryCodes = user.MFARecoveryCodes
+			mockedUser.MFALastUsedStep = user.MFALastUsedStep
 			return nil
 		}
 	}
@@ -106,6 +113,7 @@ func GetDummyUser() *models.User {
 		FullName:      "Joel",
 		Username:      "test@gmail.com",
 		CurrentPeriod: "2023-5",
+		Verified:      true,
 		Password:      "$2a$10$.THF8QG33va8JTSIBz3lPuULaO6NiDb6yRmew63OtzujhVHbnZMFe",
 		AccessToken:   hashedDummyToken,
 		RefreshToken:  hashedDummyToken,
@@ -53,7 +53,31 @@ func (e *E2ERequester) DeleteUser(username string, t *testing.T) (int, error) {
 		return 0, fmt.Errorf("user deletion endpoint building failed: %w", err)
 	}
 
-	return e.DeleteResource(endpoint)
+	request, err := http.NewRequest(http.MethodDelete, endpoint, nil)
+	if err != nil {
+		return 0, fmt.Errorf("user deletion request building failed: %w", err)
+	}
+
+	request.Header.Set("Auth", "Bearer "+e.accessToken)
+
+	res, err := e.client.Do(request)
+	if err != nil {
+		return 0, fmt.Errorf("user deletion request failed: %w", err)
+	}
+
+	defer func() {
+		err := res.Body.Close()
+		if err != nil {
+			fmt.Printf("closing response body failed: %v\n", err)
+		}
+	}()
+
+	// The user is deleted in the background, so the request is only accepted.
+	if res.StatusCode != http.StatusAccepted {
+		return res.StatusCode, handleErrorResponse(res.StatusCode, res.Body)
+	}
+
+	return res.StatusCode, nil
 }
 
 func (e *E2ERequester) CreateCategory(category *models.Category, headers map[string]string, t *testing.T) (int, error) {
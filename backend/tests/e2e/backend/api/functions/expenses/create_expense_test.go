@@ -28,8 +28,10 @@ func TestCreateExpense(t *testing.T) {
 		c.Equal(http.StatusCreated, statusCode)
 		c.NotNil(createdPeriod)
 
+		amount := models.NewMoney(1000)
+
 		expense := &models.Expense{
-			Amount:   aws.Float64(1000),
+			Amount:   &amount,
 			Name:     aws.String("test expense for e2e tests"),
 			PeriodID: "random period id",
 		}
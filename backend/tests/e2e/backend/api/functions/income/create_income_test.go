@@ -28,8 +28,10 @@ func TestCreateIncome(t *testing.T) {
 		c.Equal(http.StatusCreated, statusCode)
 		c.NotNil(createdPeriod)
 
+		amount := models.NewMoney(1000)
+
 		income := &models.Income{
-			Amount:   aws.Float64(1000),
+			Amount:   &amount,
 			Name:     aws.String("test income for e2e tests"),
 			PeriodID: stringPtr("random period id"),
 		}
@@ -28,8 +28,10 @@ func TestCreateSaving(t *testing.T) {
 		c.Equal(http.StatusCreated, statusCode)
 		c.NotNil(createdPeriod)
 
+		amount := models.NewMoney(1000)
+
 		saving := &models.Saving{
-			Amount:   aws.Float64(1000),
+			Amount:   &amount,
 			PeriodID: stringPtr("random period id"),
 		}
 
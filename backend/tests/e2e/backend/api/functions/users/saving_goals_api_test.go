@@ -169,7 +169,7 @@ func TestGetSavingGoals(t *testing.T) {
 	var goalIDs []string
 	var createdSavingIDs []string
 
-	createGoal := func(name string, target float64, daysFromNow int) *models.SavingGoal {
+	createGoal := func(name string, target models.Money, daysFromNow int) *models.SavingGoal {
 		inputGoal := new(models.SavingGoal)
 		inputGoal.SetName(name)
 		inputGoal.SetTarget(target)
@@ -186,8 +186,8 @@ func TestGetSavingGoals(t *testing.T) {
 		return createdGoal
 	}
 
-	createSavings := func(goalID string, amounts []float64) float64 {
-		var totalAmount float64
+	createSavings := func(goalID string, amounts []models.Money) models.Money {
+		var totalAmount models.Money
 		for _, amount := range amounts {
 			saving := new(models.Saving)
 			saving.SavingGoalID = &goalID
@@ -213,12 +213,12 @@ func TestGetSavingGoals(t *testing.T) {
 	createdGoals = append(createdGoals, createGoal("Goal 5", 500, 7))     // 7 days, $500
 
 	// Create savings for each goal with different amounts
-	expectedProgress := make(map[string]float64)
-	expectedProgress[createdGoals[0].GetSavingGoalID()] = createSavings(createdGoals[0].GetSavingGoalID(), []float64{100, 200, 50})       // $350
-	expectedProgress[createdGoals[1].GetSavingGoalID()] = createSavings(createdGoals[1].GetSavingGoalID(), []float64{1000, 500})          // $1,500
-	expectedProgress[createdGoals[2].GetSavingGoalID()] = createSavings(createdGoals[2].GetSavingGoalID(), []float64{250, 250, 250, 250}) // $1,000
-	expectedProgress[createdGoals[3].GetSavingGoalID()] = createSavings(createdGoals[3].GetSavingGoalID(), []float64{2000, 1500})         // $3,500
-	expectedProgress[createdGoals[4].GetSavingGoalID()] = createSavings(createdGoals[4].GetSavingGoalID(), []float64{100, 150, 200})      // $450
+	expectedProgress := make(map[string]models.Money)
+	expectedProgress[createdGoals[0].GetSavingGoalID()] = createSavings(createdGoals[0].GetSavingGoalID(), []models.Money{100, 200, 50})       // $350
+	expectedProgress[createdGoals[1].GetSavingGoalID()] = createSavings(createdGoals[1].GetSavingGoalID(), []models.Money{1000, 500})          // $1,500
+	expectedProgress[createdGoals[2].GetSavingGoalID()] = createSavings(createdGoals[2].GetSavingGoalID(), []models.Money{250, 250, 250, 250}) // $1,000
+	expectedProgress[createdGoals[3].GetSavingGoalID()] = createSavings(createdGoals[3].GetSavingGoalID(), []models.Money{2000, 1500})         // $3,500
+	expectedProgress[createdGoals[4].GetSavingGoalID()] = createSavings(createdGoals[4].GetSavingGoalID(), []models.Money{100, 150, 200})      // $450
 
 	t.Run("Get all goals with default parameters", func(t *testing.T) {
 		goals, statusCode, nextKey, err := requester.GetSavingGoals("", "", "", 10)
@@ -238,7 +238,7 @@ func TestGetSavingGoals(t *testing.T) {
 			// Only check goals created in this test
 			if progress, exists := expectedProgress[goal.GetSavingGoalID()]; exists {
 				c.Equal(progress, goal.GetProgress(),
-					fmt.Sprintf("goal %s has incorrect progress value. Expected: %.2f, Actual: %.2f",
+					fmt.Sprintf("goal %s has incorrect progress value. Expected: %s, Actual: %s",
 						goal.GetSavingGoalID(), progress, goal.GetProgress()))
 			}
 		}
@@ -254,7 +254,7 @@ func TestGetSavingGoals(t *testing.T) {
 			c.NotNil(retrievedGoal, "retrieved goal is nil")
 
 			c.Equal(expectedProgress[goalID], retrievedGoal.GetProgress(),
-				fmt.Sprintf("goal %s has incorrect progress value. Expected: %.2f, Actual: %.2f",
+				fmt.Sprintf("goal %s has incorrect progress value. Expected: %s, Actual: %s",
 					goalID, expectedProgress[goalID], retrievedGoal.GetProgress()))
 		}
 	})
@@ -262,7 +262,7 @@ func TestGetSavingGoals(t *testing.T) {
 	t.Run("Add more savings and verify updated progress", func(t *testing.T) {
 		// Add additional savings to a specific goal
 		goalToUpdate := createdGoals[2].GetSavingGoalID() // Goal 3
-		additionalAmount := 500.0
+		additionalAmount := models.NewMoney(500)
 
 		saving := new(models.Saving)
 		saving.SavingGoalID = &goalToUpdate
@@ -283,7 +283,7 @@ func TestGetSavingGoals(t *testing.T) {
 		c.NoError(err, "get saving goal after update failed")
 
 		c.Equal(expectedProgress[goalToUpdate], retrievedGoal.GetProgress(),
-			fmt.Sprintf("goal %s has incorrect progress after update. Expected: %.2f, Actual: %.2f",
+			fmt.Sprintf("goal %s has incorrect progress after update. Expected: %s, Actual: %s",
 				goalToUpdate, expectedProgress[goalToUpdate], retrievedGoal.GetProgress()))
 	})
 
@@ -490,7 +490,7 @@ func TestUpdateSavingGoal(t *testing.T) {
 	t.Run("Successful update of all fields", func(t *testing.T) {
 		updateGoal := new(models.SavingGoal)
 		newName := "updated goal name"
-		newTarget := 2000.0
+		newTarget := models.NewMoney(2000)
 		newDeadline := time.Date(time.Now().Year()+2, time.January, 1, 0, 0, 0, 0, time.UTC)
 
 		updateGoal.SetName(newName)
@@ -31,7 +31,7 @@ func TestProcess(t *testing.T) {
 	ex := &models.Expense{
 		ExpenseID:    "test-expense-id",
 		Username:     username,
-		Amount:       aws.Float64(150.34),
+		Amount:       moneyPtr(150.34),
 		RecurringDay: aws.Int(10),
 		IsRecurring:  true,
 		Name:         aws.String("Test Expense"),
@@ -45,7 +45,7 @@ func TestProcess(t *testing.T) {
 	c.NotNil(createdExpense, "created expense is nil")
 
 	t.Cleanup(func() {
-		err = expensesRepo.DeleteExpense(ctx, createdExpense.ExpenseID, username)
+		err = expensesRepo.DeleteExpense(ctx, createdExpense.ExpenseID, username, createdExpense.Version)
 		c.Nil(err, "deleting expense failed")
 	})
 
@@ -7,7 +7,9 @@ import (
 	"github.com/JoelD7/money/backend/models"
 	"github.com/JoelD7/money/backend/shared/apigateway"
 	"github.com/JoelD7/money/backend/storage/dynamo"
+	"github.com/JoelD7/money/backend/storage/exchangerate"
 	"github.com/JoelD7/money/backend/storage/expenses"
+	"github.com/JoelD7/money/backend/storage/period"
 	"github.com/JoelD7/money/backend/storage/users"
 	"github.com/JoelD7/money/backend/tests/e2e/setup"
 	"github.com/aws/aws-lambda-go/events"
@@ -31,8 +33,17 @@ func TestGetExpensesStats(t *testing.T) {
 	usersRepo, err := users.NewDynamoRepository(dynamoClient, envConfig.UsersTable)
 	c.Nil(err, "creating users repository failed")
 
+	exchangeRateRepo, err := exchangerate.NewDynamoRepository(dynamoClient, envConfig)
+	c.Nil(err, "creating exchange rate repository failed")
+
+	periodRepo, err := period.NewDynamoRepository(dynamoClient, envConfig)
+	c.Nil(err, "creating period repository failed")
+
 	request := handlers.GetExpensesStatsRequest{
-		ExpensesRepo: expensesRepo,
+		ExpensesRepo:     expensesRepo,
+		UserRepo:         usersRepo,
+		ExchangeRateRepo: exchangeRateRepo,
+		PeriodRepo:       periodRepo,
 	}
 
 	apigwRequest := &apigateway.Request{
@@ -67,10 +78,10 @@ func TestGetExpensesStats(t *testing.T) {
 	c.Nil(err, "unmarshalling response body failed")
 	c.Len(categoryExpenseSummary, 3, "unexpected number of categories in the response")
 
-	testValidatorByCategory := map[string]float64{
-		"category_id_1": 172.98,
-		"category_id_2": 430,
-		"category_id_3": 970,
+	testValidatorByCategory := map[string]models.Money{
+		"category_id_1": models.NewMoney(172.98),
+		"category_id_2": models.NewMoney(430),
+		"category_id_3": models.NewMoney(970),
 	}
 
 	for _, summary := range categoryExpenseSummary {
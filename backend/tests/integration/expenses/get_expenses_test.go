@@ -88,7 +88,7 @@ func TestGetByPeriod(t *testing.T) {
 				ExpenseID:   "insurance premium",
 				Username:    "e2e_test@gmail.com",
 				CategoryID:  stringPtr("category_id_1"),
-				Amount:      floatPtr(100),
+				Amount:      moneyPtr(100),
 				Name:        stringPtr("Insurance Premium"),
 				Notes:       "Monthly insurance premium",
 				CreatedDate: time.Date(2021, 9, 1, 0, 0, 0, 0, time.UTC),
@@ -98,7 +98,7 @@ func TestGetByPeriod(t *testing.T) {
 				ExpenseID:   "gym membership",
 				Username:    "e2e_test@gmail.com",
 				CategoryID:  stringPtr("category_id_1"),
-				Amount:      floatPtr(50),
+				Amount:      moneyPtr(50),
 				Name:        stringPtr("Gym Membership"),
 				Notes:       "Monthly gym membership",
 				CreatedDate: time.Date(2021, 9, 2, 0, 0, 0, 0, time.UTC),
@@ -108,7 +108,7 @@ func TestGetByPeriod(t *testing.T) {
 				ExpenseID:   "netflix subscription",
 				Username:    "e2e_test@gmail.com",
 				CategoryID:  stringPtr("category_id_1"),
-				Amount:      floatPtr(12.99),
+				Amount:      moneyPtr(12.99),
 				Name:        stringPtr("Netflix Subscription"),
 				Notes:       "Monthly Netflix subscription",
 				CreatedDate: time.Date(2021, 9, 3, 0, 0, 0, 0, time.UTC),
@@ -118,7 +118,7 @@ func TestGetByPeriod(t *testing.T) {
 				ExpenseID:   "spotify subscription",
 				Username:    "e2e_test@gmail.com",
 				CategoryID:  stringPtr("category_id_1"),
-				Amount:      floatPtr(9.99),
+				Amount:      moneyPtr(9.99),
 				Name:        stringPtr("Spotify Subscription"),
 				Notes:       "Monthly Spotify subscription",
 				CreatedDate: time.Date(2021, 9, 4, 0, 0, 0, 0, time.UTC),
@@ -128,7 +128,7 @@ func TestGetByPeriod(t *testing.T) {
 				ExpenseID:   "car insurance",
 				Username:    "e2e_test@gmail.com",
 				CategoryID:  stringPtr("category_id_2"),
-				Amount:      floatPtr(75),
+				Amount:      moneyPtr(75),
 				Name:        stringPtr("Car Insurance"),
 				Notes:       "Monthly car insurance premium",
 				CreatedDate: time.Date(2021, 9, 5, 0, 0, 0, 0, time.UTC),
@@ -138,7 +138,7 @@ func TestGetByPeriod(t *testing.T) {
 				ExpenseID:   "electricity bill",
 				Username:    "e2e_test@gmail.com",
 				CategoryID:  stringPtr("category_id_2"),
-				Amount:      floatPtr(60),
+				Amount:      moneyPtr(60),
 				Name:        stringPtr("Electricity Bill"),
 				Notes:       "Monthly electricity bill",
 				CreatedDate: time.Date(2021, 9, 6, 0, 0, 0, 0, time.UTC),
@@ -148,7 +148,7 @@ func TestGetByPeriod(t *testing.T) {
 				ExpenseID:   "internet bill",
 				Username:    "e2e_test@gmail.com",
 				CategoryID:  stringPtr("category_id_2"),
-				Amount:      floatPtr(40),
+				Amount:      moneyPtr(40),
 				Name:        stringPtr("Internet Bill"),
 				Notes:       "Monthly internet bill",
 				CreatedDate: time.Date(2021, 9, 7, 0, 0, 0, 0, time.UTC),
@@ -158,7 +158,7 @@ func TestGetByPeriod(t *testing.T) {
 				ExpenseID:   "water bill",
 				Username:    "e2e_test@gmail.com",
 				CategoryID:  stringPtr("category_id_2"),
-				Amount:      floatPtr(30),
+				Amount:      moneyPtr(30),
 				Name:        stringPtr("Water Bill"),
 				Notes:       "Monthly water bill",
 				CreatedDate: time.Date(2021, 9, 8, 0, 0, 0, 0, time.UTC),
@@ -168,7 +168,7 @@ func TestGetByPeriod(t *testing.T) {
 				ExpenseID:   "phone bill",
 				Username:    "e2e_test@gmail.com",
 				CategoryID:  stringPtr("category_id_2"),
-				Amount:      floatPtr(25),
+				Amount:      moneyPtr(25),
 				Name:        stringPtr("Phone Bill"),
 				Notes:       "Monthly phone bill",
 				CreatedDate: time.Date(2021, 9, 9, 0, 0, 0, 0, time.UTC),
@@ -178,7 +178,7 @@ func TestGetByPeriod(t *testing.T) {
 				ExpenseID:   "credit card payment",
 				Username:    "e2e_test@gmail.com",
 				CategoryID:  stringPtr("category_id_2"),
-				Amount:      floatPtr(200),
+				Amount:      moneyPtr(200),
 				Name:        stringPtr("Credit Card Payment"),
 				Notes:       "Monthly credit card payment",
 				CreatedDate: time.Date(2021, 9, 10, 0, 0, 0, 0, time.UTC),
@@ -188,7 +188,7 @@ func TestGetByPeriod(t *testing.T) {
 				ExpenseID:   "student loan",
 				Username:    "e2e_test@gmail.com",
 				CategoryID:  stringPtr("category_id_3"),
-				Amount:      floatPtr(150),
+				Amount:      moneyPtr(150),
 				Name:        stringPtr("Student Loan"),
 				Notes:       "Monthly student loan payment",
 				CreatedDate: time.Date(2021, 9, 11, 0, 0, 0, 0, time.UTC),
@@ -198,7 +198,7 @@ func TestGetByPeriod(t *testing.T) {
 				ExpenseID:   "rent payment",
 				Username:    "e2e_test@gmail.com",
 				CategoryID:  stringPtr("category_id_3"),
-				Amount:      floatPtr(800),
+				Amount:      moneyPtr(800),
 				Name:        stringPtr("Rent Payment"),
 				Notes:       "Monthly rent payment",
 				CreatedDate: time.Date(2021, 9, 12, 0, 0, 0, 0, time.UTC),
@@ -208,7 +208,7 @@ func TestGetByPeriod(t *testing.T) {
 				ExpenseID:   "gas bill",
 				Username:    "e2e_test@gmail.com",
 				CategoryID:  stringPtr("category_id_3"),
-				Amount:      floatPtr(20),
+				Amount:      moneyPtr(20),
 				Name:        stringPtr("Gas Bill"),
 				Notes:       "Monthly gas bill",
 				CreatedDate: time.Date(2021, 9, 13, 0, 0, 0, 0, time.UTC),
@@ -241,7 +241,7 @@ func TestGetByPeriod(t *testing.T) {
 				ExpenseID:   "gas bill",
 				Username:    "e2e_test@gmail.com",
 				CategoryID:  stringPtr("category_id_3"),
-				Amount:      floatPtr(20),
+				Amount:      moneyPtr(20),
 				Name:        stringPtr("Gas Bill"),
 				Notes:       "Monthly gas bill",
 				CreatedDate: time.Date(2021, 9, 13, 0, 0, 0, 0, time.UTC),
@@ -251,7 +251,7 @@ func TestGetByPeriod(t *testing.T) {
 				ExpenseID:   "rent payment",
 				Username:    "e2e_test@gmail.com",
 				CategoryID:  stringPtr("category_id_3"),
-				Amount:      floatPtr(800),
+				Amount:      moneyPtr(800),
 				Name:        stringPtr("Rent Payment"),
 				Notes:       "Monthly rent payment",
 				CreatedDate: time.Date(2021, 9, 12, 0, 0, 0, 0, time.UTC),
@@ -261,7 +261,7 @@ func TestGetByPeriod(t *testing.T) {
 				ExpenseID:   "student loan",
 				Username:    "e2e_test@gmail.com",
 				CategoryID:  stringPtr("category_id_3"),
-				Amount:      floatPtr(150),
+				Amount:      moneyPtr(150),
 				Name:        stringPtr("Student Loan"),
 				Notes:       "Monthly student loan payment",
 				CreatedDate: time.Date(2021, 9, 11, 0, 0, 0, 0, time.UTC),
@@ -271,7 +271,7 @@ func TestGetByPeriod(t *testing.T) {
 				ExpenseID:   "credit card payment",
 				Username:    "e2e_test@gmail.com",
 				CategoryID:  stringPtr("category_id_2"),
-				Amount:      floatPtr(200),
+				Amount:      moneyPtr(200),
 				Name:        stringPtr("Credit Card Payment"),
 				Notes:       "Monthly credit card payment",
 				CreatedDate: time.Date(2021, 9, 10, 0, 0, 0, 0, time.UTC),
@@ -281,7 +281,7 @@ func TestGetByPeriod(t *testing.T) {
 				ExpenseID:   "phone bill",
 				Username:    "e2e_test@gmail.com",
 				CategoryID:  stringPtr("category_id_2"),
-				Amount:      floatPtr(25),
+				Amount:      moneyPtr(25),
 				Name:        stringPtr("Phone Bill"),
 				Notes:       "Monthly phone bill",
 				CreatedDate: time.Date(2021, 9, 9, 0, 0, 0, 0, time.UTC),
@@ -291,7 +291,7 @@ func TestGetByPeriod(t *testing.T) {
 				ExpenseID:   "water bill",
 				Username:    "e2e_test@gmail.com",
 				CategoryID:  stringPtr("category_id_2"),
-				Amount:      floatPtr(30),
+				Amount:      moneyPtr(30),
 				Name:        stringPtr("Water Bill"),
 				Notes:       "Monthly water bill",
 				CreatedDate: time.Date(2021, 9, 8, 0, 0, 0, 0, time.UTC),
@@ -301,7 +301,7 @@ func TestGetByPeriod(t *testing.T) {
 				ExpenseID:   "internet bill",
 				Username:    "e2e_test@gmail.com",
 				CategoryID:  stringPtr("category_id_2"),
-				Amount:      floatPtr(40),
+				Amount:      moneyPtr(40),
 				Name:        stringPtr("Internet Bill"),
 				Notes:       "Monthly internet bill",
 				CreatedDate: time.Date(2021, 9, 7, 0, 0, 0, 0, time.UTC),
@@ -311,7 +311,7 @@ func TestGetByPeriod(t *testing.T) {
 				ExpenseID:   "electricity bill",
 				Username:    "e2e_test@gmail.com",
 				CategoryID:  stringPtr("category_id_2"),
-				Amount:      floatPtr(60),
+				Amount:      moneyPtr(60),
 				Name:        stringPtr("Electricity Bill"),
 				Notes:       "Monthly electricity bill",
 				CreatedDate: time.Date(2021, 9, 6, 0, 0, 0, 0, time.UTC),
@@ -321,7 +321,7 @@ func TestGetByPeriod(t *testing.T) {
 				ExpenseID:   "car insurance",
 				Username:    "e2e_test@gmail.com",
 				CategoryID:  stringPtr("category_id_2"),
-				Amount:      floatPtr(75),
+				Amount:      moneyPtr(75),
 				Name:        stringPtr("Car Insurance"),
 				Notes:       "Monthly car insurance premium",
 				CreatedDate: time.Date(2021, 9, 5, 0, 0, 0, 0, time.UTC),
@@ -331,7 +331,7 @@ func TestGetByPeriod(t *testing.T) {
 				ExpenseID:   "spotify subscription",
 				Username:    "e2e_test@gmail.com",
 				CategoryID:  stringPtr("category_id_1"),
-				Amount:      floatPtr(9.99),
+				Amount:      moneyPtr(9.99),
 				Name:        stringPtr("Spotify Subscription"),
 				Notes:       "Monthly Spotify subscription",
 				CreatedDate: time.Date(2021, 9, 4, 0, 0, 0, 0, time.UTC),
@@ -341,7 +341,7 @@ func TestGetByPeriod(t *testing.T) {
 				ExpenseID:   "netflix subscription",
 				Username:    "e2e_test@gmail.com",
 				CategoryID:  stringPtr("category_id_1"),
-				Amount:      floatPtr(12.99),
+				Amount:      moneyPtr(12.99),
 				Name:        stringPtr("Netflix Subscription"),
 				Notes:       "Monthly Netflix subscription",
 				CreatedDate: time.Date(2021, 9, 3, 0, 0, 0, 0, time.UTC),
@@ -351,7 +351,7 @@ func TestGetByPeriod(t *testing.T) {
 				ExpenseID:   "gym membership",
 				Username:    "e2e_test@gmail.com",
 				CategoryID:  stringPtr("category_id_1"),
-				Amount:      floatPtr(50),
+				Amount:      moneyPtr(50),
 				Name:        stringPtr("Gym Membership"),
 				Notes:       "Monthly gym membership",
 				CreatedDate: time.Date(2021, 9, 2, 0, 0, 0, 0, time.UTC),
@@ -361,7 +361,7 @@ func TestGetByPeriod(t *testing.T) {
 				ExpenseID:   "insurance premium",
 				Username:    "e2e_test@gmail.com",
 				CategoryID:  stringPtr("category_id_1"),
-				Amount:      floatPtr(100),
+				Amount:      moneyPtr(100),
 				Name:        stringPtr("Insurance Premium"),
 				Notes:       "Monthly insurance premium",
 				CreatedDate: time.Date(2021, 9, 1, 0, 0, 0, 0, time.UTC),
@@ -393,7 +393,7 @@ func TestGetByPeriod(t *testing.T) {
 				ExpenseID:   "car insurance",
 				Username:    "e2e_test@gmail.com",
 				CategoryID:  stringPtr("category_id_2"),
-				Amount:      floatPtr(75),
+				Amount:      moneyPtr(75),
 				Name:        stringPtr("Car Insurance"),
 				Notes:       "Monthly car insurance premium",
 				CreatedDate: time.Date(2021, 9, 5, 0, 0, 0, 0, time.UTC),
@@ -403,7 +403,7 @@ func TestGetByPeriod(t *testing.T) {
 				ExpenseID:   "credit card payment",
 				Username:    "e2e_test@gmail.com",
 				CategoryID:  stringPtr("category_id_2"),
-				Amount:      floatPtr(200),
+				Amount:      moneyPtr(200),
 				Name:        stringPtr("Credit Card Payment"),
 				Notes:       "Monthly credit card payment",
 				CreatedDate: time.Date(2021, 9, 10, 0, 0, 0, 0, time.UTC),
@@ -413,7 +413,7 @@ func TestGetByPeriod(t *testing.T) {
 				ExpenseID:   "electricity bill",
 				Username:    "e2e_test@gmail.com",
 				CategoryID:  stringPtr("category_id_2"),
-				Amount:      floatPtr(60),
+				Amount:      moneyPtr(60),
 				Name:        stringPtr("Electricity Bill"),
 				Notes:       "Monthly electricity bill",
 				CreatedDate: time.Date(2021, 9, 6, 0, 0, 0, 0, time.UTC),
@@ -423,7 +423,7 @@ func TestGetByPeriod(t *testing.T) {
 				ExpenseID:   "gas bill",
 				Username:    "e2e_test@gmail.com",
 				CategoryID:  stringPtr("category_id_3"),
-				Amount:      floatPtr(20),
+				Amount:      moneyPtr(20),
 				Name:        stringPtr("Gas Bill"),
 				Notes:       "Monthly gas bill",
 				CreatedDate: time.Date(2021, 9, 13, 0, 0, 0, 0, time.UTC),
@@ -433,7 +433,7 @@ func TestGetByPeriod(t *testing.T) {
 				ExpenseID:   "gym membership",
 				Username:    "e2e_test@gmail.com",
 				CategoryID:  stringPtr("category_id_1"),
-				Amount:      floatPtr(50),
+				Amount:      moneyPtr(50),
 				Name:        stringPtr("Gym Membership"),
 				Notes:       "Monthly gym membership",
 				CreatedDate: time.Date(2021, 9, 2, 0, 0, 0, 0, time.UTC),
@@ -443,7 +443,7 @@ func TestGetByPeriod(t *testing.T) {
 				ExpenseID:   "insurance premium",
 				Username:    "e2e_test@gmail.com",
 				CategoryID:  stringPtr("category_id_1"),
-				Amount:      floatPtr(100),
+				Amount:      moneyPtr(100),
 				Name:        stringPtr("Insurance Premium"),
 				Notes:       "Monthly insurance premium",
 				CreatedDate: time.Date(2021, 9, 1, 0, 0, 0, 0, time.UTC),
@@ -453,7 +453,7 @@ func TestGetByPeriod(t *testing.T) {
 				ExpenseID:   "internet bill",
 				Username:    "e2e_test@gmail.com",
 				CategoryID:  stringPtr("category_id_2"),
-				Amount:      floatPtr(40),
+				Amount:      moneyPtr(40),
 				Name:        stringPtr("Internet Bill"),
 				Notes:       "Monthly internet bill",
 				CreatedDate: time.Date(2021, 9, 7, 0, 0, 0, 0, time.UTC),
@@ -463,7 +463,7 @@ func TestGetByPeriod(t *testing.T) {
 				ExpenseID:   "netflix subscription",
 				Username:    "e2e_test@gmail.com",
 				CategoryID:  stringPtr("category_id_1"),
-				Amount:      floatPtr(12.99),
+				Amount:      moneyPtr(12.99),
 				Name:        stringPtr("Netflix Subscription"),
 				Notes:       "Monthly Netflix subscription",
 				CreatedDate: time.Date(2021, 9, 3, 0, 0, 0, 0, time.UTC),
@@ -473,7 +473,7 @@ func TestGetByPeriod(t *testing.T) {
 				ExpenseID:   "phone bill",
 				Username:    "e2e_test@gmail.com",
 				CategoryID:  stringPtr("category_id_2"),
-				Amount:      floatPtr(25),
+				Amount:      moneyPtr(25),
 				Name:        stringPtr("Phone Bill"),
 				Notes:       "Monthly phone bill",
 				CreatedDate: time.Date(2021, 9, 9, 0, 0, 0, 0, time.UTC),
@@ -483,7 +483,7 @@ func TestGetByPeriod(t *testing.T) {
 				ExpenseID:   "rent payment",
 				Username:    "e2e_test@gmail.com",
 				CategoryID:  stringPtr("category_id_3"),
-				Amount:      floatPtr(800),
+				Amount:      moneyPtr(800),
 				Name:        stringPtr("Rent Payment"),
 				Notes:       "Monthly rent payment",
 				CreatedDate: time.Date(2021, 9, 12, 0, 0, 0, 0, time.UTC),
@@ -493,7 +493,7 @@ func TestGetByPeriod(t *testing.T) {
 				ExpenseID:   "spotify subscription",
 				Username:    "e2e_test@gmail.com",
 				CategoryID:  stringPtr("category_id_1"),
-				Amount:      floatPtr(9.99),
+				Amount:      moneyPtr(9.99),
 				Name:        stringPtr("Spotify Subscription"),
 				Notes:       "Monthly Spotify subscription",
 				CreatedDate: time.Date(2021, 9, 4, 0, 0, 0, 0, time.UTC),
@@ -503,7 +503,7 @@ func TestGetByPeriod(t *testing.T) {
 				ExpenseID:   "student loan",
 				Username:    "e2e_test@gmail.com",
 				CategoryID:  stringPtr("category_id_3"),
-				Amount:      floatPtr(150),
+				Amount:      moneyPtr(150),
 				Name:        stringPtr("Student Loan"),
 				Notes:       "Monthly student loan payment",
 				CreatedDate: time.Date(2021, 9, 11, 0, 0, 0, 0, time.UTC),
@@ -513,7 +513,7 @@ func TestGetByPeriod(t *testing.T) {
 				ExpenseID:   "water bill",
 				Username:    "e2e_test@gmail.com",
 				CategoryID:  stringPtr("category_id_2"),
-				Amount:      floatPtr(30),
+				Amount:      moneyPtr(30),
 				Name:        stringPtr("Water Bill"),
 				Notes:       "Monthly water bill",
 				CreatedDate: time.Date(2021, 9, 8, 0, 0, 0, 0, time.UTC),
@@ -544,7 +544,7 @@ func TestGetByPeriod(t *testing.T) {
 				ExpenseID:   "spotify subscription",
 				Username:    "e2e_test@gmail.com",
 				CategoryID:  stringPtr("category_id_1"),
-				Amount:      floatPtr(9.99),
+				Amount:      moneyPtr(9.99),
 				Name:        stringPtr("Spotify Subscription"),
 				Notes:       "Monthly Spotify subscription",
 				CreatedDate: time.Date(2021, 9, 4, 0, 0, 0, 0, time.UTC),
@@ -554,7 +554,7 @@ func TestGetByPeriod(t *testing.T) {
 				ExpenseID:   "netflix subscription",
 				Username:    "e2e_test@gmail.com",
 				CategoryID:  stringPtr("category_id_1"),
-				Amount:      floatPtr(12.99),
+				Amount:      moneyPtr(12.99),
 				Name:        stringPtr("Netflix Subscription"),
 				Notes:       "Monthly Netflix subscription",
 				CreatedDate: time.Date(2021, 9, 3, 0, 0, 0, 0, time.UTC),
@@ -564,7 +564,7 @@ func TestGetByPeriod(t *testing.T) {
 				ExpenseID:   "gas bill",
 				Username:    "e2e_test@gmail.com",
 				CategoryID:  stringPtr("category_id_3"),
-				Amount:      floatPtr(20),
+				Amount:      moneyPtr(20),
 				Name:        stringPtr("Gas Bill"),
 				Notes:       "Monthly gas bill",
 				CreatedDate: time.Date(2021, 9, 13, 0, 0, 0, 0, time.UTC),
@@ -574,7 +574,7 @@ func TestGetByPeriod(t *testing.T) {
 				ExpenseID:   "phone bill",
 				Username:    "e2e_test@gmail.com",
 				CategoryID:  stringPtr("category_id_2"),
-				Amount:      floatPtr(25),
+				Amount:      moneyPtr(25),
 				Name:        stringPtr("Phone Bill"),
 				Notes:       "Monthly phone bill",
 				CreatedDate: time.Date(2021, 9, 9, 0, 0, 0, 0, time.UTC),
@@ -584,7 +584,7 @@ func TestGetByPeriod(t *testing.T) {
 				ExpenseID:   "water bill",
 				Username:    "e2e_test@gmail.com",
 				CategoryID:  stringPtr("category_id_2"),
-				Amount:      floatPtr(30),
+				Amount:      moneyPtr(30),
 				Name:        stringPtr("Water Bill"),
 				Notes:       "Monthly water bill",
 				CreatedDate: time.Date(2021, 9, 8, 0, 0, 0, 0, time.UTC),
@@ -594,7 +594,7 @@ func TestGetByPeriod(t *testing.T) {
 				ExpenseID:   "internet bill",
 				Username:    "e2e_test@gmail.com",
 				CategoryID:  stringPtr("category_id_2"),
-				Amount:      floatPtr(40),
+				Amount:      moneyPtr(40),
 				Name:        stringPtr("Internet Bill"),
 				Notes:       "Monthly internet bill",
 				CreatedDate: time.Date(2021, 9, 7, 0, 0, 0, 0, time.UTC),
@@ -604,7 +604,7 @@ func TestGetByPeriod(t *testing.T) {
 				ExpenseID:   "gym membership",
 				Username:    "e2e_test@gmail.com",
 				CategoryID:  stringPtr("category_id_1"),
-				Amount:      floatPtr(50),
+				Amount:      moneyPtr(50),
 				Name:        stringPtr("Gym Membership"),
 				Notes:       "Monthly gym membership",
 				CreatedDate: time.Date(2021, 9, 2, 0, 0, 0, 0, time.UTC),
@@ -614,7 +614,7 @@ func TestGetByPeriod(t *testing.T) {
 				ExpenseID:   "electricity bill",
 				Username:    "e2e_test@gmail.com",
 				CategoryID:  stringPtr("category_id_2"),
-				Amount:      floatPtr(60),
+				Amount:      moneyPtr(60),
 				Name:        stringPtr("Electricity Bill"),
 				Notes:       "Monthly electricity bill",
 				CreatedDate: time.Date(2021, 9, 6, 0, 0, 0, 0, time.UTC),
@@ -624,7 +624,7 @@ func TestGetByPeriod(t *testing.T) {
 				ExpenseID:   "car insurance",
 				Username:    "e2e_test@gmail.com",
 				CategoryID:  stringPtr("category_id_2"),
-				Amount:      floatPtr(75),
+				Amount:      moneyPtr(75),
 				Name:        stringPtr("Car Insurance"),
 				Notes:       "Monthly car insurance premium",
 				CreatedDate: time.Date(2021, 9, 5, 0, 0, 0, 0, time.UTC),
@@ -634,7 +634,7 @@ func TestGetByPeriod(t *testing.T) {
 				ExpenseID:   "insurance premium",
 				Username:    "e2e_test@gmail.com",
 				CategoryID:  stringPtr("category_id_1"),
-				Amount:      floatPtr(100),
+				Amount:      moneyPtr(100),
 				Name:        stringPtr("Insurance Premium"),
 				Notes:       "Monthly insurance premium",
 				CreatedDate: time.Date(2021, 9, 1, 0, 0, 0, 0, time.UTC),
@@ -644,7 +644,7 @@ func TestGetByPeriod(t *testing.T) {
 				ExpenseID:   "student loan",
 				Username:    "e2e_test@gmail.com",
 				CategoryID:  stringPtr("category_id_3"),
-				Amount:      floatPtr(150),
+				Amount:      moneyPtr(150),
 				Name:        stringPtr("Student Loan"),
 				Notes:       "Monthly student loan payment",
 				CreatedDate: time.Date(2021, 9, 11, 0, 0, 0, 0, time.UTC),
@@ -654,7 +654,7 @@ func TestGetByPeriod(t *testing.T) {
 				ExpenseID:   "credit card payment",
 				Username:    "e2e_test@gmail.com",
 				CategoryID:  stringPtr("category_id_2"),
-				Amount:      floatPtr(200),
+				Amount:      moneyPtr(200),
 				Name:        stringPtr("Credit Card Payment"),
 				Notes:       "Monthly credit card payment",
 				CreatedDate: time.Date(2021, 9, 10, 0, 0, 0, 0, time.UTC),
@@ -664,7 +664,7 @@ func TestGetByPeriod(t *testing.T) {
 				ExpenseID:   "rent payment",
 				Username:    "e2e_test@gmail.com",
 				CategoryID:  stringPtr("category_id_3"),
-				Amount:      floatPtr(800),
+				Amount:      moneyPtr(800),
 				Name:        stringPtr("Rent Payment"),
 				Notes:       "Monthly rent payment",
 				CreatedDate: time.Date(2021, 9, 12, 0, 0, 0, 0, time.UTC),
@@ -682,6 +682,7 @@ func stringPtr(s string) *string {
 	return &s
 }
 
-func floatPtr(f float64) *float64 {
-	return &f
+func moneyPtr(f float64) *models.Money {
+	money := models.NewMoney(f)
+	return &money
 }
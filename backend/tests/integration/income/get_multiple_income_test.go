@@ -107,7 +107,7 @@ func TestGetMultipleIncomeHandler(t *testing.T) {
 			{
 				Username:   "e2e_test@gmail.com",
 				IncomeID:   "IN1lVnB1tCaSpyQLDEUswM",
-				Amount:     aws.Float64(750),
+				Amount:     moneyPtr(750),
 				Name:       aws.String("income 1"),
 				PeriodID:   aws.String("2023-7"),
 				PeriodUser: aws.String("2023-7:test@gmail.com"),
@@ -115,7 +115,7 @@ func TestGetMultipleIncomeHandler(t *testing.T) {
 			{
 				Username:   "e2e_test@gmail.com",
 				IncomeID:   "IN2lVnB1tCaSpyQLDEUswM",
-				Amount:     aws.Float64(600),
+				Amount:     moneyPtr(600),
 				Name:       aws.String("income 2"),
 				PeriodID:   aws.String("2023-7"),
 				PeriodUser: aws.String("2023-7:test@gmail.com"),
@@ -123,7 +123,7 @@ func TestGetMultipleIncomeHandler(t *testing.T) {
 			{
 				Username:   "e2e_test@gmail.com",
 				IncomeID:   "IN3lVnB1tCaSpyQLDEUswM",
-				Amount:     aws.Float64(450),
+				Amount:     moneyPtr(450),
 				Name:       aws.String("income 3"),
 				PeriodID:   aws.String("2023-7"),
 				PeriodUser: aws.String("2023-7:test@gmail.com"),
@@ -151,7 +151,7 @@ func TestGetMultipleIncomeHandler(t *testing.T) {
 			{
 				Username:   "e2e_test@gmail.com",
 				IncomeID:   "IN3lVnB1tCaSpyQLDEUswM",
-				Amount:     aws.Float64(450),
+				Amount:     moneyPtr(450),
 				Name:       aws.String("income 3"),
 				PeriodID:   aws.String("2023-7"),
 				PeriodUser: aws.String("2023-7:test@gmail.com"),
@@ -159,7 +159,7 @@ func TestGetMultipleIncomeHandler(t *testing.T) {
 			{
 				Username:   "e2e_test@gmail.com",
 				IncomeID:   "IN2lVnB1tCaSpyQLDEUswM",
-				Amount:     aws.Float64(600),
+				Amount:     moneyPtr(600),
 				Name:       aws.String("income 2"),
 				PeriodID:   aws.String("2023-7"),
 				PeriodUser: aws.String("2023-7:test@gmail.com"),
@@ -167,7 +167,7 @@ func TestGetMultipleIncomeHandler(t *testing.T) {
 			{
 				Username:   "e2e_test@gmail.com",
 				IncomeID:   "IN1lVnB1tCaSpyQLDEUswM",
-				Amount:     aws.Float64(750),
+				Amount:     moneyPtr(750),
 				Name:       aws.String("income 1"),
 				PeriodID:   aws.String("2023-7"),
 				PeriodUser: aws.String("2023-7:test@gmail.com"),
@@ -230,3 +230,8 @@ func BenchmarkGetMultipleIncome(t *testing.B) {
 		c.Len(response.Periods, 6)
 	}
 }
+
+func moneyPtr(f float64) *models.Money {
+	money := models.NewMoney(f)
+	return &money
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/JoelD7/money/backend/storage/expenses"
 	expenses_recurring "github.com/JoelD7/money/backend/storage/expenses-recurring"
 	"github.com/JoelD7/money/backend/storage/period"
+	"github.com/JoelD7/money/backend/storage/users"
 	"github.com/JoelD7/money/backend/tests/e2e/setup"
 	"github.com/stretchr/testify/require"
 	"os"
@@ -81,10 +82,14 @@ func TestCron(t *testing.T) {
 	expensesRepo, err := expenses.NewDynamoRepository(dynamoClient, envConfig)
 	c.Nil(err, "failed to create expenses repository")
 
+	usersRepo, err := users.NewDynamoRepository(dynamoClient, envConfig.UsersTable)
+	c.Nil(err, "failed to create users repository")
+
 	req := &handler.CronRequest{
 		Repo:         repo,
 		PeriodRepo:   periodRepo,
 		ExpensesRepo: expensesRepo,
+		UsersRepo:    usersRepo,
 	}
 
 	var expensesToDelete []*models.Expense
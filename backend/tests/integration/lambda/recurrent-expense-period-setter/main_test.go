@@ -70,7 +70,7 @@ func TestProcess(t *testing.T) {
 		EndDate:   endDate,
 	}
 
-	_, err = req.PeriodRepo.CreatePeriod(ctx, period)
+	period, err = req.PeriodRepo.CreatePeriod(ctx, period)
 	c.Nil(err, "creating period failed")
 
 	msg := models.SQSMessage{
@@ -86,7 +86,7 @@ func TestProcess(t *testing.T) {
 		err = req.ExpensesRepo.BatchDeleteExpenses(ctx, expensesList)
 		c.Nil(err, "batch deleting expenses failed")
 
-		err = req.PeriodRepo.DeletePeriod(ctx, period.ID, period.Username)
+		err = req.PeriodRepo.DeletePeriod(ctx, period.ID, period.Username, period.Version)
 		c.Nil(err, "deleting period failed")
 	})
 
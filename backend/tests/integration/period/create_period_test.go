@@ -81,7 +81,7 @@ func TestProcess(t *testing.T) {
 			p, err := req.PeriodRepo.GetLastPeriod(ctx, username)
 			c.Nil(err, "couldn't delete created period: getting last period failed")
 
-			err = req.PeriodRepo.DeletePeriod(ctx, p.ID, p.Username)
+			err = req.PeriodRepo.DeletePeriod(ctx, p.ID, p.Username, p.Version)
 			c.Nil(err, "deleting period failed")
 		})
 
@@ -132,13 +132,13 @@ func TestCreatePeriod(t *testing.T) {
 		// Step 1: Create 12 recurring saving goals
 		for i := 0; i < numSavingGoals; i++ {
 			name := "Saving Goal " + string(rune('A'+i))
-			target := float64(1000 * (i + 1))
-			recurringAmount := float64(100 * (i + 1))
+			target := models.NewMoney(float64(1000 * (i + 1)))
+			recurringAmount := models.NewMoney(float64(100 * (i + 1)))
 
 			savingGoal := &models.SavingGoal{
 				Name:            &name,
 				Target:          &target,
-				Progress:        new(float64),
+				Progress:        new(models.Money),
 				IsRecurring:     true,
 				RecurringAmount: &recurringAmount,
 			}
@@ -263,12 +263,12 @@ func TestCreatePeriod(t *testing.T) {
 
 		// Create a non-recurring saving goal
 		goalName := "One-time Purchase"
-		target := float64(500)
+		target := models.NewMoney(500)
 
 		savingGoal := &models.SavingGoal{
 			Name:        &goalName,
 			Target:      &target,
-			Progress:    new(float64), // Initially 0
+			Progress:    new(models.Money), // Initially 0
 			IsRecurring: false,
 		}
 
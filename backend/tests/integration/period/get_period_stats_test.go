@@ -8,8 +8,10 @@ import (
 	"github.com/JoelD7/money/backend/models"
 	"github.com/JoelD7/money/backend/shared/apigateway"
 	"github.com/JoelD7/money/backend/storage/dynamo"
+	"github.com/JoelD7/money/backend/storage/exchangerate"
 	"github.com/JoelD7/money/backend/storage/expenses"
 	"github.com/JoelD7/money/backend/storage/income"
+	"github.com/JoelD7/money/backend/storage/period"
 	"github.com/JoelD7/money/backend/storage/users"
 	"github.com/JoelD7/money/backend/tests/e2e/setup"
 	"github.com/aws/aws-lambda-go/events"
@@ -42,12 +44,12 @@ func TestGetPeriodStats(t *testing.T) {
 	err = json.Unmarshal([]byte(response.Body), &periodStat)
 	c.Nil(err, "unmarshalling response body failed")
 	c.Len(periodStat.CategoryExpenseSummary, 3, "unexpected number of categories in the response")
-	c.Equal(3000.00, periodStat.TotalIncome, fmt.Sprintf("expected %f, got %f", 3000.00, periodStat.TotalIncome))
+	c.Equal(models.NewMoney(3000), periodStat.TotalIncome, fmt.Sprintf("expected %s, got %s", models.NewMoney(3000), periodStat.TotalIncome))
 
-	testValidatorByCategory := map[string]float64{
-		"category_id_1": 172.98,
-		"category_id_2": 430,
-		"category_id_3": 970,
+	testValidatorByCategory := map[string]models.Money{
+		"category_id_1": models.NewMoney(172.98),
+		"category_id_2": models.NewMoney(430),
+		"category_id_3": models.NewMoney(970),
 	}
 
 	for _, summary := range periodStat.CategoryExpenseSummary {
@@ -72,9 +74,18 @@ func setupGetPeriodStatsTest(ctx context.Context, c *require.Assertions, cleaner
 	incomeRepo, err := income.NewDynamoRepository(dynamoClient, envConfig)
 	c.Nil(err, "creating income repository failed")
 
+	exchangeRateRepo, err := exchangerate.NewDynamoRepository(dynamoClient, envConfig)
+	c.Nil(err, "creating exchange rate repository failed")
+
+	periodRepo, err := period.NewDynamoRepository(dynamoClient, envConfig)
+	c.Nil(err, "creating period repository failed")
+
 	request := handlers.GetPeriodStatRequest{
-		ExpensesRepo: expensesRepo,
-		IncomeRepo:   incomeRepo,
+		ExpensesRepo:     expensesRepo,
+		IncomeRepo:       incomeRepo,
+		UserRepo:         usersRepo,
+		ExchangeRateRepo: exchangeRateRepo,
+		PeriodRepo:       periodRepo,
 	}
 
 	apigwRequest := &apigateway.Request{
@@ -249,12 +260,12 @@ func BenchmarkGetPeriodStats(b *testing.B) {
 		err = json.Unmarshal([]byte(response.Body), &periodStat)
 		c.Nil(err, "unmarshalling response body failed")
 		c.Len(periodStat.CategoryExpenseSummary, 3, "unexpected number of categories in the response")
-		c.Equal(3000.00, periodStat.TotalIncome, fmt.Sprintf("expected %f, got %f", 3000.00, periodStat.TotalIncome))
+		c.Equal(models.NewMoney(3000), periodStat.TotalIncome, fmt.Sprintf("expected %s, got %s", models.NewMoney(3000), periodStat.TotalIncome))
 
-		testValidatorByCategory := map[string]float64{
-			"category_id_1": 172.98,
-			"category_id_2": 430,
-			"category_id_3": 970,
+		testValidatorByCategory := map[string]models.Money{
+			"category_id_1": models.NewMoney(172.98),
+			"category_id_2": models.NewMoney(430),
+			"category_id_3": models.NewMoney(970),
 		}
 
 		for _, summary := range periodStat.CategoryExpenseSummary {
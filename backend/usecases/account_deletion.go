@@ -0,0 +1,332 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/JoelD7/money/backend/models"
+	"github.com/JoelD7/money/backend/shared/logger"
+	"time"
+)
+
+const (
+	// accountDeletionPageSize is the maximum number of items of a DynamoDB batch write.
+	accountDeletionPageSize = 25
+	// accountDeletionTimeMargin is how long before the deadline of the context the deletion stops, so that there's time
+	// to save its progress and resume it in another invocation.
+	accountDeletionTimeMargin = 30 * time.Second
+)
+
+// accountDeletionSteps is the order in which the data of a user is deleted. The user is deleted after all the data it
+// owns, so that a deletion that fails midway can be requested again.
+var accountDeletionSteps = []models.AccountDeletionStep{
+	models.AccountDeletionStepExpenses,
+	models.AccountDeletionStepExpensesRecurring,
+	models.AccountDeletionStepIncome,
+	models.AccountDeletionStepSavings,
+	models.AccountDeletionStepSavingGoals,
+	models.AccountDeletionStepBudgetAlerts,
+	models.AccountDeletionStepPeriods,
+	models.AccountDeletionStepTrash,
+	models.AccountDeletionStepAudit,
+	models.AccountDeletionStepSessions,
+	models.AccountDeletionStepUser,
+	models.AccountDeletionStepCache,
+}
+
+// deletePageFunc deletes a page of the items of a step. It returns the number of items deleted and whether there are
+// no items left.
+type deletePageFunc func(ctx context.Context, username string) (int, bool, error)
+
+// NewAccountDeletionRequester starts the deletion of all the data of a user in the background. If there's a deletion
+// running already, it's returned instead of starting a new one.
+func NewAccountDeletionRequester(adm AccountDeletionManager, queue AccountDeletionQueue) func(ctx context.Context, username string) (*models.AccountDeletion, error) {
+	return func(ctx context.Context, username string) (*models.AccountDeletion, error) {
+		accountDeletion, err := adm.GetAccountDeletion(ctx, username)
+		if err != nil && !errors.Is(err, models.ErrAccountDeletionNotFound) {
+			return nil, err
+		}
+
+		if accountDeletion != nil && accountDeletion.IsRunning() {
+			return accountDeletion, nil
+		}
+
+		now := time.Now()
+
+		accountDeletion = &models.AccountDeletion{
+			Username:     username,
+			Status:       models.AccountDeletionStatusPending,
+			DeletedItems: make(map[models.AccountDeletionStep]int),
+			CreatedDate:  now,
+			UpdatedDate:  now,
+		}
+
+		err = adm.SaveAccountDeletion(ctx, accountDeletion)
+		if err != nil {
+			return nil, err
+		}
+
+		err = queue.SendAccountDeletion(ctx, username)
+		if err == nil {
+			return accountDeletion, nil
+		}
+
+		accountDeletion.Status = models.AccountDeletionStatusFailed
+		accountDeletion.Error = err.Error()
+
+		saveErr := adm.SaveAccountDeletion(ctx, accountDeletion)
+		if saveErr != nil {
+			logger.Error("save_failed_account_deletion_failed", saveErr, models.Any("account_deletion", accountDeletion))
+		}
+
+		return nil, fmt.Errorf("couldn't start account deletion: %w", err)
+	}
+}
+
+func NewAccountDeletionGetter(adm AccountDeletionManager) func(ctx context.Context, username string) (*models.AccountDeletion, error) {
+	return func(ctx context.Context, username string) (*models.AccountDeletion, error) {
+		return adm.GetAccountDeletion(ctx, username)
+	}
+}
+
+// NewAccountDeleter deletes the data of a user, one step at a time, saving the progress after every page of items. When
+// the deadline of the context is close, it stops and returns the deletion without completing it, so that it can be
+// resumed by calling the function again. Deleted items aren't returned by the repositories anymore, so resuming a step
+// only has to delete the items that are left.
+func NewAccountDeleter(adm AccountDeletionManager, um UserManager, em ExpenseManager, erm ExpenseRecurringManager,
+	im IncomeRepository, sm SavingsManager, sgm SavingGoalManager, bam BudgetAlertManager, pm PeriodManager,
+	xrm ExchangeRateManager, tm TrashManager, am AuditManager, ssm SessionManager, userCache UserCacheManager,
+) func(ctx context.Context, username string) (*models.AccountDeletion, error) {
+	deletePageByStep := map[models.AccountDeletionStep]deletePageFunc{
+		models.AccountDeletionStepExpenses: func(ctx context.Context, username string) (int, bool, error) {
+			return deletePage(models.ErrExpensesNotFound, func() ([]*models.Expense, string, error) {
+				return em.GetExpenses(ctx, username, &models.QueryParameters{PageSize: accountDeletionPageSize})
+			}, func(expenses []*models.Expense) error {
+				return em.BatchDeleteExpenses(ctx, expenses)
+			})
+		},
+		models.AccountDeletionStepExpensesRecurring: func(ctx context.Context, username string) (int, bool, error) {
+			return deletePage(models.ErrRecurringExpensesNotFound, func() ([]*models.ExpenseRecurring, string, error) {
+				return erm.GetExpensesRecurring(ctx, username, &models.QueryParameters{PageSize: accountDeletionPageSize})
+			}, func(expensesRecurring []*models.ExpenseRecurring) error {
+				return erm.BatchDeleteExpenseRecurring(ctx, expensesRecurring)
+			})
+		},
+		models.AccountDeletionStepIncome: func(ctx context.Context, username string) (int, bool, error) {
+			return deletePage(models.ErrIncomeNotFound, func() ([]*models.Income, string, error) {
+				return im.GetAllIncome(ctx, username, &models.QueryParameters{PageSize: accountDeletionPageSize})
+			}, func(income []*models.Income) error {
+				return im.BatchDeleteIncome(ctx, income)
+			})
+		},
+		models.AccountDeletionStepSavings: func(ctx context.Context, username string) (int, bool, error) {
+			return deletePage(models.ErrSavingsNotFound, func() ([]*models.Saving, string, error) {
+				return sm.GetSavings(ctx, username, &models.QueryParameters{PageSize: accountDeletionPageSize})
+			}, func(savings []*models.Saving) error {
+				return sm.BatchDeleteSavings(ctx, savings)
+			})
+		},
+		models.AccountDeletionStepSavingGoals: func(ctx context.Context, username string) (int, bool, error) {
+			return deletePage(models.ErrSavingGoalsNotFound, func() ([]*models.SavingGoal, string, error) {
+				return sgm.GetSavingGoals(ctx, username, &models.QueryParameters{PageSize: accountDeletionPageSize})
+			}, func(savingGoals []*models.SavingGoal) error {
+				return sgm.BatchDeleteSavingGoals(ctx, savingGoals)
+			})
+		},
+		models.AccountDeletionStepBudgetAlerts: func(ctx context.Context, username string) (int, bool, error) {
+			// Budget alerts are optional, so their repository isn't set when they are disabled.
+			if bam == nil {
+				return 0, true, nil
+			}
+
+			deleted, err := bam.DeleteAllBudgetAlerts(ctx, username)
+
+			return deleted, err == nil, err
+		},
+		models.AccountDeletionStepPeriods: func(ctx context.Context, username string) (int, bool, error) {
+			return deletePage(models.ErrPeriodsNotFound, func() ([]*models.Period, string, error) {
+				return pm.GetPeriods(ctx, username, "", accountDeletionPageSize, false)
+			}, func(periods []*models.Period) error {
+				for _, period := range periods {
+					err := deletePeriodExchangeRates(ctx, xrm, username, period.ID)
+					if err != nil {
+						return err
+					}
+				}
+
+				return pm.BatchDeletePeriods(ctx, periods)
+			})
+		},
+		models.AccountDeletionStepTrash: func(ctx context.Context, username string) (int, bool, error) {
+			deleted, err := tm.DeleteAllTrashItems(ctx, username)
+
+			return deleted, err == nil, err
+		},
+		models.AccountDeletionStepAudit: func(ctx context.Context, username string) (int, bool, error) {
+			// The audit log is optional too. This step runs after the others because deleting the data of the user
+			// records new entries.
+			if am == nil {
+				return 0, true, nil
+			}
+
+			deleted, err := am.DeleteAllAuditEntries(ctx, username)
+
+			return deleted, err == nil, err
+		},
+		models.AccountDeletionStepSessions: func(ctx context.Context, username string) (int, bool, error) {
+			deleted, err := ssm.DeleteAllSessions(ctx, username)
+
+			return deleted, err == nil, err
+		},
+		models.AccountDeletionStepUser: func(ctx context.Context, username string) (int, bool, error) {
+			err := um.DeleteUser(ctx, username)
+			if err != nil {
+				return 0, false, err
+			}
+
+			return 1, true, nil
+		},
+		models.AccountDeletionStepCache: func(ctx context.Context, username string) (int, bool, error) {
+			return 0, true, userCache.DeleteUserData(ctx, username)
+		},
+	}
+
+	return func(ctx context.Context, username string) (*models.AccountDeletion, error) {
+		return runAccountDeletion(ctx, adm, deletePageByStep, username)
+	}
+}
+
+func runAccountDeletion(ctx context.Context, adm AccountDeletionManager, deletePageByStep map[models.AccountDeletionStep]deletePageFunc, username string) (*models.AccountDeletion, error) {
+	accountDeletion, err := adm.GetAccountDeletion(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+
+	if accountDeletion.Status == models.AccountDeletionStatusCompleted {
+		return accountDeletion, nil
+	}
+
+	accountDeletion.Status = models.AccountDeletionStatusInProgress
+	accountDeletion.Error = ""
+
+	if accountDeletion.DeletedItems == nil {
+		accountDeletion.DeletedItems = make(map[models.AccountDeletionStep]int)
+	}
+
+	for _, step := range getPendingAccountDeletionSteps(accountDeletion.Step) {
+		accountDeletion.Step = step
+
+		done := false
+
+		for !done {
+			if !hasTimeLeft(ctx) {
+				return accountDeletion, saveAccountDeletion(ctx, adm, accountDeletion)
+			}
+
+			var deleted int
+
+			deleted, done, err = deletePageByStep[step](ctx, username)
+			if err != nil {
+				return nil, failAccountDeletion(ctx, adm, accountDeletion, err)
+			}
+
+			accountDeletion.DeletedItems[step] += deleted
+
+			err = saveAccountDeletion(ctx, adm, accountDeletion)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	accountDeletion.Status = models.AccountDeletionStatusCompleted
+	accountDeletion.CompletedDate = time.Now()
+
+	return accountDeletion, saveAccountDeletion(ctx, adm, accountDeletion)
+}
+
+// getPendingAccountDeletionSteps returns the steps from currentStep onwards. The current step is run again, as it
+// might not have finished.
+func getPendingAccountDeletionSteps(currentStep models.AccountDeletionStep) []models.AccountDeletionStep {
+	for i, step := range accountDeletionSteps {
+		if step == currentStep {
+			return accountDeletionSteps[i:]
+		}
+	}
+
+	return accountDeletionSteps
+}
+
+// deletePage deletes the first page of items returned by getPage. notFoundErr is the error the repository returns when
+// the user has no items left.
+func deletePage[T any](notFoundErr error, getPage func() ([]T, string, error), deleteItems func([]T) error) (int, bool, error) {
+	items, _, err := getPage()
+	if errors.Is(err, notFoundErr) || errors.Is(err, models.ErrNoMoreItemsToBeRetrieved) {
+		return 0, true, nil
+	}
+
+	if err != nil {
+		return 0, false, err
+	}
+
+	if len(items) == 0 {
+		return 0, true, nil
+	}
+
+	err = deleteItems(items)
+	if err != nil {
+		return 0, false, err
+	}
+
+	return len(items), false, nil
+}
+
+func deletePeriodExchangeRates(ctx context.Context, xrm ExchangeRateManager, username, periodID string) error {
+	exchangeRates, err := xrm.GetExchangeRates(ctx, username, periodID)
+	if errors.Is(err, models.ErrExchangeRatesNotFound) {
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	for _, exchangeRate := range exchangeRates {
+		err = xrm.DeleteExchangeRate(ctx, username, periodID, exchangeRate.Currency)
+		if err != nil && !errors.Is(err, models.ErrExchangeRateNotFound) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func hasTimeLeft(ctx context.Context) bool {
+	deadline, ok := ctx.Deadline()
+
+	return !ok || time.Until(deadline) > accountDeletionTimeMargin
+}
+
+func saveAccountDeletion(ctx context.Context, adm AccountDeletionManager, accountDeletion *models.AccountDeletion) error {
+	accountDeletion.UpdatedDate = time.Now()
+
+	err := adm.SaveAccountDeletion(ctx, accountDeletion)
+	if err != nil {
+		return fmt.Errorf("couldn't save account deletion progress: %w", err)
+	}
+
+	return nil
+}
+
+// failAccountDeletion records the error in the deletion. The deletion can be resumed from the step that failed.
+func failAccountDeletion(ctx context.Context, adm AccountDeletionManager, accountDeletion *models.AccountDeletion, err error) error {
+	accountDeletion.Status = models.AccountDeletionStatusFailed
+	accountDeletion.Error = err.Error()
+
+	saveErr := saveAccountDeletion(ctx, adm, accountDeletion)
+	if saveErr != nil {
+		logger.Error("save_failed_account_deletion_failed", saveErr, models.Any("account_deletion", accountDeletion))
+	}
+
+	return fmt.Errorf("account deletion failed on step %s: %w", accountDeletion.Step, err)
+}
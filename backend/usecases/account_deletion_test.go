@@ -0,0 +1,188 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"github.com/JoelD7/money/backend/models"
+	"github.com/JoelD7/money/backend/shared/logger"
+	"github.com/JoelD7/money/backend/storage/accountdeletion"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+// newDeletePageByStepMock returns page functions that delete itemsByStep one item at a time.
+func newDeletePageByStepMock(itemsByStep map[models.AccountDeletionStep]int) map[models.AccountDeletionStep]deletePageFunc {
+	deletePageByStep := make(map[models.AccountDeletionStep]deletePageFunc)
+
+	for _, step := range accountDeletionSteps {
+		step := step
+
+		deletePageByStep[step] = func(ctx context.Context, username string) (int, bool, error) {
+			if itemsByStep[step] == 0 {
+				return 0, true, nil
+			}
+
+			itemsByStep[step]--
+
+			return 1, false, nil
+		}
+	}
+
+	return deletePageByStep
+}
+
+func TestAccountDeletionRequester(t *testing.T) {
+	c := require.New(t)
+
+	logger.InitLogger(logger.ConsoleImplementation)
+
+	ctx := context.Background()
+	mock := accountdeletion.NewMock()
+	requestDeletion := NewAccountDeletionRequester(mock, mock)
+
+	accountDeletion, err := requestDeletion(ctx, "test@gmail.com")
+	c.NoError(err)
+	c.Equal(models.AccountDeletionStatusPending, accountDeletion.Status)
+	c.Equal([]string{"test@gmail.com"}, mock.GetSentUsernames())
+
+	t.Run("Deletion already running", func(t *testing.T) {
+		_, err = requestDeletion(ctx, "test@gmail.com")
+		c.NoError(err)
+		c.Len(mock.GetSentUsernames(), 1)
+	})
+
+	t.Run("Queue failed", func(t *testing.T) {
+		queue := accountdeletion.NewMock()
+		queue.ActivateForceFailure(errors.New("queue failed"))
+
+		_, err = NewAccountDeletionRequester(mock, queue)(ctx, "other@gmail.com")
+		c.Error(err)
+
+		accountDeletion, err = mock.GetAccountDeletion(ctx, "other@gmail.com")
+		c.NoError(err)
+		c.Equal(models.AccountDeletionStatusFailed, accountDeletion.Status)
+	})
+}
+
+func TestRunAccountDeletion(t *testing.T) {
+	c := require.New(t)
+
+	logger.InitLogger(logger.ConsoleImplementation)
+
+	ctx := context.Background()
+	mock := accountdeletion.NewMock()
+
+	_, err := NewAccountDeletionRequester(mock, mock)(ctx, "test@gmail.com")
+	c.NoError(err)
+
+	deletePageByStep := newDeletePageByStepMock(map[models.AccountDeletionStep]int{
+		models.AccountDeletionStepExpenses: 3,
+		models.AccountDeletionStepIncome:   2,
+		models.AccountDeletionStepUser:     1,
+	})
+
+	accountDeletion, err := runAccountDeletion(ctx, mock, deletePageByStep, "test@gmail.com")
+	c.NoError(err)
+	c.Equal(models.AccountDeletionStatusCompleted, accountDeletion.Status)
+	c.Equal(3, accountDeletion.DeletedItems[models.AccountDeletionStepExpenses])
+	c.Equal(2, accountDeletion.DeletedItems[models.AccountDeletionStepIncome])
+	c.Equal(1, accountDeletion.DeletedItems[models.AccountDeletionStepUser])
+	c.False(accountDeletion.CompletedDate.IsZero())
+}
+
+func TestRunAccountDeletionResume(t *testing.T) {
+	c := require.New(t)
+
+	logger.InitLogger(logger.ConsoleImplementation)
+
+	ctx := context.Background()
+	mock := accountdeletion.NewMock()
+
+	_, err := NewAccountDeletionRequester(mock, mock)(ctx, "test@gmail.com")
+	c.NoError(err)
+
+	itemsByStep := map[models.AccountDeletionStep]int{
+		models.AccountDeletionStepSavings: 2,
+	}
+
+	deletePageByStep := newDeletePageByStepMock(itemsByStep)
+
+	t.Run("Stop when there's no time left", func(t *testing.T) {
+		timeoutCtx, cancel := context.WithTimeout(ctx, accountDeletionTimeMargin)
+		defer cancel()
+
+		accountDeletion, err := runAccountDeletion(timeoutCtx, mock, deletePageByStep, "test@gmail.com")
+		c.NoError(err)
+		c.Equal(models.AccountDeletionStatusInProgress, accountDeletion.Status)
+		c.Equal(models.AccountDeletionStepExpenses, accountDeletion.Step)
+		c.Equal(2, itemsByStep[models.AccountDeletionStepSavings])
+	})
+
+	t.Run("Resume from the failed step", func(t *testing.T) {
+		deletePageByStep[models.AccountDeletionStepSavings] = func(ctx context.Context, username string) (int, bool, error) {
+			return 0, false, errors.New("dynamo failed")
+		}
+
+		_, err = runAccountDeletion(ctx, mock, deletePageByStep, "test@gmail.com")
+		c.Error(err)
+
+		accountDeletion, err := mock.GetAccountDeletion(ctx, "test@gmail.com")
+		c.NoError(err)
+		c.Equal(models.AccountDeletionStatusFailed, accountDeletion.Status)
+		c.Equal(models.AccountDeletionStepSavings, accountDeletion.Step)
+
+		var calledSteps []models.AccountDeletionStep
+
+		for step, deletePage := range newDeletePageByStepMock(itemsByStep) {
+			step := step
+			deletePage := deletePage
+
+			deletePageByStep[step] = func(ctx context.Context, username string) (int, bool, error) {
+				if len(calledSteps) == 0 || calledSteps[len(calledSteps)-1] != step {
+					calledSteps = append(calledSteps, step)
+				}
+
+				return deletePage(ctx, username)
+			}
+		}
+
+		accountDeletion, err = runAccountDeletion(ctx, mock, deletePageByStep, "test@gmail.com")
+		c.NoError(err)
+		c.Equal(models.AccountDeletionStatusCompleted, accountDeletion.Status)
+		c.Equal(2, accountDeletion.DeletedItems[models.AccountDeletionStepSavings])
+		c.Equal(getPendingAccountDeletionSteps(models.AccountDeletionStepSavings), calledSteps)
+		c.Empty(accountDeletion.Error)
+	})
+}
+
+func TestDeletePage(t *testing.T) {
+	c := require.New(t)
+
+	items := []int{1, 2, 3}
+
+	deleted, done, err := deletePage(models.ErrExpensesNotFound, func() ([]int, string, error) {
+		return items, "", nil
+	}, func(page []int) error {
+		items = nil
+		return nil
+	})
+	c.NoError(err)
+	c.Equal(3, deleted)
+	c.False(done)
+
+	deleted, done, err = deletePage(models.ErrExpensesNotFound, func() ([]int, string, error) {
+		return nil, "", models.ErrExpensesNotFound
+	}, func(page []int) error {
+		return nil
+	})
+	c.NoError(err)
+	c.Zero(deleted)
+	c.True(done)
+
+	_, _, err = deletePage(models.ErrExpensesNotFound, func() ([]int, string, error) {
+		return []int{4}, "", nil
+	}, func(page []int) error {
+		return errors.New("delete failed")
+	})
+	c.Error(err)
+}
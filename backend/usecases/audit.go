@@ -0,0 +1,24 @@
+package usecases
+
+import (
+	"context"
+	"github.com/JoelD7/money/backend/models"
+)
+
+// NewEntityHistoryGetter returns the changes made to an entity of the user, most recent first.
+func NewEntityHistoryGetter(am AuditManager) func(ctx context.Context, username string, entityType models.AuditEntityType, entityID string, params *models.QueryParameters) ([]*models.AuditEntry, string, error) {
+	return func(ctx context.Context, username string, entityType models.AuditEntityType, entityID string, params *models.QueryParameters) ([]*models.AuditEntry, string, error) {
+		if entityID == "" {
+			return nil, "", models.ErrMissingEntityID
+		}
+
+		return am.GetEntityHistory(ctx, username, entityType, entityID, params)
+	}
+}
+
+// NewActivityGetter returns the changes made to all the entities of the user, most recent first.
+func NewActivityGetter(am AuditManager) func(ctx context.Context, username string, params *models.QueryParameters) ([]*models.AuditEntry, string, error) {
+	return func(ctx context.Context, username string, params *models.QueryParameters) ([]*models.AuditEntry, string, error) {
+		return am.GetAuditEntries(ctx, username, params)
+	}
+}
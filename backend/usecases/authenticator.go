@@ -115,10 +115,28 @@ func generateDynamoID(prefix string) string {
 	return prefix + string(b)
 }
 
-// NewUserAuthenticator authenticates a user.
-func NewUserAuthenticator(userGetter UserManager) func(ctx context.Context, username, password string) (*models.User, error) {
-	return func(ctx context.Context, username, password string) (*models.User, error) {
+// NewUserAuthenticator authenticates a user. When the user has MFA enabled, the password is only the first step of the
+// login: the user is returned with models.ErrMFARequired, and the tokens can't be generated until a TOTP code is
+// validated by NewMFALoginVerifier.
+//
+// The failed logins of the username and of the source IP are counted, and after too many of them the logins are
+// blocked with a *models.LoginBlockedError, even if the password is right. A successful login only resets the failures
+// of the username.
+func NewUserAuthenticator(userGetter UserManager, loginAttempts LoginAttemptCache) func(ctx context.Context, username, password, sourceIP string) (*models.User, error) {
+	return func(ctx context.Context, username, password, sourceIP string) (*models.User, error) {
+		attemptKeys := getLoginAttemptKeys(username, sourceIP)
+
+		err := checkLoginBlocked(ctx, loginAttempts, attemptKeys)
+		if err != nil {
+			return nil, err
+		}
+
 		user, err := userGetter.GetUser(ctx, username)
+		if errors.Is(err, models.ErrUserNotFound) {
+			// Logins of users that don't exist are counted too, so that they can't be told apart by the blocks.
+			recordFailedLogin(ctx, loginAttempts, attemptKeys)
+		}
+
 		if err != nil {
 			logger.Error("user_fetching_failed", err, models.Any("auth_request", map[string]interface{}{
 				"s_username": username,
@@ -131,17 +149,44 @@ func NewUserAuthenticator(userGetter UserManager) func(ctx context.Context, user
 		if err != nil {
 			logger.Error("password_mismatch", err, models.Any("request_body", authRequestBody{username, password}))
 
+			recordFailedLogin(ctx, loginAttempts, attemptKeys)
+
 			return nil, models.ErrWrongCredentials
 		}
 
+		// Only the failures of the username are reset. The ones of the source IP expire on their own, so that an attacker
+		// can't keep guessing other passwords by logging into an account of their own every now and then.
+		resetLoginAttempts(ctx, loginAttempts, getLoginAttemptKeys(username, ""))
+
+		if user.MFAEnabled {
+			return user, models.ErrMFARequired
+		}
+
 		return user, nil
 	}
 }
 
-// NewUserTokenGenerator generates access and refresh tokens for the user.
-func NewUserTokenGenerator(userManager UserManager, secretManager SecretManager) func(ctx context.Context, user *models.User) (*models.AuthToken, *models.AuthToken, error) {
-	return func(ctx context.Context, user *models.User) (*models.AuthToken, *models.AuthToken, error) {
+// NewUserTokenGenerator generates access and refresh tokens for a session of the user. A session without ID is a new
+// login, so it's created. Otherwise, the refresh token of the session is replaced, which fails with
+// models.ErrRefreshTokenReused if it was replaced already by another request.
+func NewUserTokenGenerator(sessionManager SessionManager, secretManager SecretManager) func(ctx context.Context, user *models.User, session *models.Session) (*models.AuthToken, *models.AuthToken, error) {
+	return func(ctx context.Context, user *models.User, session *models.Session) (*models.AuthToken, *models.AuthToken, error) {
+		err := checkEmailVerified(user)
+		if err != nil {
+			logger.Warning("unverified_user_tokens_denied", err, user)
+
+			return nil, nil, err
+		}
+
 		now := time.Now()
+		isNewSession := session.ID == ""
+
+		if isNewSession {
+			session.ID = generateDynamoID("SS")
+			session.Username = user.Username
+			session.CreatedDate = now
+		}
+
 		accessTokenAudience := env.GetString("TOKEN_AUDIENCE", "")
 		accessTokenIssuer := env.GetString("TOKEN_ISSUER", "")
 		accessTokenScope := env.GetString("TOKEN_SCOPE", "")
@@ -167,12 +212,17 @@ func NewUserTokenGenerator(userManager UserManager, secretManager SecretManager)
 
 		refreshTokenExpiry := jwt.NumericDate(now.Add(time.Duration(refreshTokenDuration) * time.Second))
 
-		refreshTokenPayload := &jwt.Payload{
-			Subject:        user.Username,
-			ExpirationTime: refreshTokenExpiry,
+		// The JWT ID makes every refresh token of the session different, even if two are issued in the same second.
+		refreshTokenPayload := &models.JWTPayload{
+			SessionID: session.ID,
+			Payload: &jwt.Payload{
+				Subject:        user.Username,
+				ExpirationTime: refreshTokenExpiry,
+				JWTID:          generateDynamoID("RT"),
+			},
 		}
 
-		refreshToken, err := generateJWT(secretManager, refreshTokenPayload, "")
+		refreshToken, err := signJWT(secretManager, refreshTokenPayload)
 		if err != nil {
 			logger.Error("generate_refresh_token_failed", err, nil)
 
@@ -193,12 +243,21 @@ func NewUserTokenGenerator(userManager UserManager, secretManager SecretManager)
 			return nil, nil, err
 		}
 
-		user.RefreshToken = hashedRefresh
-		user.AccessToken = hashedAccess
+		previousRefreshToken := session.RefreshToken
+
+		session.RefreshToken = hashedRefresh
+		session.AccessToken = hashedAccess
+		session.LastUsedDate = now
+		session.ExpirationDate = refreshTokenExpiry.Time
+
+		if isNewSession {
+			err = sessionManager.CreateSession(ctx, session)
+		} else {
+			err = sessionManager.UpdateSession(ctx, session, previousRefreshToken)
+		}
 
-		err = userManager.UpdateUser(ctx, user)
 		if err != nil {
-			logger.Error("update_user_failed", err, user)
+			logger.Error("session_saving_failed", err, session)
 
 			return nil, nil, err
 		}
@@ -217,53 +276,80 @@ func NewUserTokenGenerator(userManager UserManager, secretManager SecretManager)
 	}
 }
 
-// NewRefreshTokenValidator validates a refresh token.
-func NewRefreshTokenValidator(userGetter UserManager) func(ctx context.Context, refreshToken string) (*models.User, error) {
-	return func(ctx context.Context, refreshToken string) (*models.User, error) {
-		payload, err := getTokenPayload(refreshToken)
-		if err != nil {
-			logger.Error("get_refresh_token_payload_failed", err, nil)
+func generateJWT(secrets SecretManager, payload *jwt.Payload, scope string) (string, error) {
+	return signJWT(secrets, &models.JWTPayload{
+		Scope:   scope,
+		Payload: payload,
+	})
+}
 
-			return nil, fmt.Errorf("%w: %v", models.ErrMalformedToken, err)
-		}
+func signJWT(secrets SecretManager, payload *models.JWTPayload) (string, error) {
+	priv, err := getPrivateKey(secrets)
+	if err != nil {
+		return "", fmt.Errorf("private key fetching failed: %w", err)
+	}
 
-		user, err := userGetter.GetUser(ctx, payload.Subject)
-		if err != nil {
-			logger.Error("get_user_failed", err, nil)
+	var signingHash = jwt.NewRS256(jwt.RSAPrivateKey(priv))
 
-			return nil, err
-		}
+	token, err := jwt.Sign(payload, signingHash)
+	if err != nil {
+		return "", fmt.Errorf("jwt signing failed: %w", err)
+	}
 
-		err = validateRefreshToken(user, refreshToken)
-		if err != nil {
-			logger.Warning("refresh_token_validation_failed", err, models.Any("refresh_token", refreshToken))
+	return string(token), nil
+}
 
-			return user, fmt.Errorf("%w: %v", models.ErrInvalidToken, err)
-		}
+// generateScopedToken signs a token that can only be used for the given audience and scope, like verifying an email,
+// so that it's never accepted as an access token.
+func generateScopedToken(secrets SecretManager, subject, audience, scope string, duration time.Duration) (*models.AuthToken, error) {
+	now := time.Now()
+	expiration := jwt.NumericDate(now.Add(duration))
+
+	payload := &jwt.Payload{
+		Issuer:         env.GetString("TOKEN_ISSUER", ""),
+		Subject:        subject,
+		Audience:       jwt.Audience{audience},
+		ExpirationTime: expiration,
+		IssuedAt:       jwt.NumericDate(now),
+	}
 
-		return user, nil
+	token, err := generateJWT(secrets, payload, scope)
+	if err != nil {
+		return nil, err
 	}
+
+	return &models.AuthToken{
+		Value:      token,
+		Expiration: expiration.Time,
+	}, nil
 }
 
-func generateJWT(secrets SecretManager, payload *jwt.Payload, scope string) (string, error) {
-	priv, err := getPrivateKey(secrets)
+// validateScopedToken validates a token generated by generateScopedToken for the audience and scope, and returns its
+// subject. The validation errors are wrapped in invalidErr.
+func validateScopedToken(ctx context.Context, secrets SecretManager, token, audience, scope string, invalidErr error) (string, error) {
+	publicKey, err := getPublicKey(ctx, secrets)
 	if err != nil {
-		return "", fmt.Errorf("private key fetching failed: %w", err)
+		return "", fmt.Errorf("public key fetching failed: %w", err)
 	}
 
-	var signingHash = jwt.NewRS256(jwt.RSAPrivateKey(priv))
+	payload := &models.JWTPayload{Payload: new(jwt.Payload)}
 
-	p := models.JWTPayload{
-		Scope:   scope,
-		Payload: payload,
-	}
+	validatePayload := jwt.ValidatePayload(payload.Payload,
+		jwt.IssuerValidator(env.GetString("TOKEN_ISSUER", "")),
+		jwt.AudienceValidator(jwt.Audience{audience}),
+		jwt.ExpirationTimeValidator(time.Now()),
+	)
 
-	token, err := jwt.Sign(p, signingHash)
+	_, err = jwt.Verify([]byte(token), jwt.NewRS256(jwt.RSAPublicKey(publicKey)), payload, validatePayload)
 	if err != nil {
-		return "", fmt.Errorf("jwt signing failed: %w", err)
+		return "", fmt.Errorf("%w: %v", invalidErr, err)
 	}
 
-	return string(token), nil
+	if payload.Scope != scope {
+		return "", fmt.Errorf("%w: the token has the scope %q", invalidErr, payload.Scope)
+	}
+
+	return payload.Subject, nil
 }
 
 func getPrivateKey(secrets SecretManager) (*rsa.PrivateKey, error) {
@@ -308,42 +394,6 @@ func getTokenPayload(token string) (*models.JWTPayload, error) {
 	return payload, nil
 }
 
-func validateRefreshToken(user *models.User, refreshToken string) error {
-	err := hash.CompareWithToken(user.RefreshToken, refreshToken)
-	if errors.Is(err, hash.ErrHashMismatch) && user.RefreshToken != "" {
-		return fmt.Errorf("%w", models.ErrRefreshTokenMismatch)
-	}
-
-	return err
-}
-
-// NewTokenInvalidator invalidates a user's tokens.
-func NewTokenInvalidator(tokenCache InvalidTokenCache) func(ctx context.Context, user *models.User) error {
-	return func(ctx context.Context, user *models.User) error {
-		accessTokenDuration := env.GetInt("ACCESS_TOKEN_DURATION", 300)
-		refreshTokenDuration := env.GetInt("REFRESH_TOKEN_DURATION", 2592000)
-
-		accessTokenTTL := time.Now().Add(time.Second * time.Duration(accessTokenDuration)).Unix()
-		refreshTokenTTL := time.Now().Add(time.Second * time.Duration(refreshTokenDuration)).Unix()
-
-		err := tokenCache.AddInvalidToken(ctx, user.Username, user.AccessToken, accessTokenTTL)
-		if err != nil {
-			logger.Error("access_token_invalidation_failed", err, user)
-
-			return err
-		}
-
-		err = tokenCache.AddInvalidToken(ctx, user.Username, user.RefreshToken, refreshTokenTTL)
-		if err != nil {
-			logger.Error("refresh_token_invalidation_failed", err, user)
-
-			return err
-		}
-
-		return nil
-	}
-}
-
 // GetJsonWebKeySet returns a JWKS using the public and kid secret names passed in.
 func GetJsonWebKeySet(ctx context.Context, secrets SecretManager) (*models.Jwks, error) {
 	publicKey, err := getPublicKey(ctx, secrets)
@@ -407,23 +457,3 @@ func getKidFromSecret(ctx context.Context, secrets SecretManager) (string, error
 
 	return kidSecret, nil
 }
-
-func NewUserLogout(userGetter UserManager, tokenCache InvalidTokenCache) func(ctx context.Context, token string) error {
-	return func(ctx context.Context, username string) error {
-		user, err := userGetter.GetUser(ctx, username)
-		if err != nil {
-			logger.Error("fetching_user_from_storage_failed", err, nil)
-
-			return err
-		}
-
-		invalidateTokens := NewTokenInvalidator(tokenCache)
-
-		err = invalidateTokens(ctx, user)
-		if err != nil {
-			return err
-		}
-
-		return nil
-	}
-}
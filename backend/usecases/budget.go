@@ -0,0 +1,99 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/JoelD7/money/backend/models"
+	"math"
+)
+
+const (
+	defaultBudgetNearLimitPercentage = 80
+)
+
+// getCategoryBudgets returns the budget of every category that has one for the period. The budgets of the period take
+// precedence over the budgets of the categories. A budget of zero means that the category has no budget.
+func getCategoryBudgets(categories []*models.Category, period *models.Period) map[string]models.Money {
+	budgets := make(map[string]models.Money)
+
+	for _, category := range categories {
+		if category.Budget != nil && *category.Budget > 0 {
+			budgets[category.ID] = *category.Budget
+		}
+	}
+
+	if period == nil {
+		return budgets
+	}
+
+	for categoryID, budget := range period.CategoryBudgets {
+		if budget > 0 {
+			budgets[categoryID] = budget
+			continue
+		}
+
+		delete(budgets, categoryID)
+	}
+
+	return budgets
+}
+
+// getPeriodCategoryBudgets returns the category budgets that apply to a period. Stats can be requested for a period
+// that doesn't exist anymore, in which case only the budgets of the categories are used.
+func getPeriodCategoryBudgets(ctx context.Context, pm PeriodManager, user *models.User, periodID string) (map[string]models.Money, error) {
+	period, err := pm.GetPeriod(ctx, user.Username, periodID)
+	if err != nil && !errors.Is(err, models.ErrPeriodNotFound) {
+		return nil, fmt.Errorf("couldn't get period budgets: %w", err)
+	}
+
+	return getCategoryBudgets(user.Categories, period), nil
+}
+
+// setBudgetStatus compares the spending of every category against its budget. Categories that have a budget but no
+// expenses are added to the summary, as it's useful to know how much is left to spend on them.
+func setBudgetStatus(summary []*models.CategoryExpenseSummary, budgets map[string]models.Money, periodID string, nearLimitPercentage int) []*models.CategoryExpenseSummary {
+	if nearLimitPercentage <= 0 || nearLimitPercentage > 100 {
+		nearLimitPercentage = defaultBudgetNearLimitPercentage
+	}
+
+	summaryByCategory := make(map[string]*models.CategoryExpenseSummary, len(summary))
+
+	for _, categorySummary := range summary {
+		summaryByCategory[categorySummary.CategoryID] = categorySummary
+	}
+
+	for categoryID, budget := range budgets {
+		categorySummary, ok := summaryByCategory[categoryID]
+		if !ok {
+			categorySummary = &models.CategoryExpenseSummary{
+				CategoryID: categoryID,
+				Period:     periodID,
+			}
+
+			summary = append(summary, categorySummary)
+		}
+
+		setCategoryBudgetStatus(categorySummary, budget, nearLimitPercentage)
+	}
+
+	return summary
+}
+
+func setCategoryBudgetStatus(categorySummary *models.CategoryExpenseSummary, budget models.Money, nearLimitPercentage int) {
+	remaining := budget - categorySummary.Total
+	percentageUsed := math.Round(float64(categorySummary.Total)*10000/float64(budget)) / 100
+
+	categorySummary.Budget = &budget
+	categorySummary.Remaining = &remaining
+	categorySummary.PercentageUsed = &percentageUsed
+
+	switch {
+	case categorySummary.Total > budget:
+		categorySummary.BudgetStatus = models.BudgetStatusOver
+	case percentageUsed >= float64(nearLimitPercentage):
+		categorySummary.BudgetStatus = models.BudgetStatusNearLimit
+	default:
+		categorySummary.BudgetStatus = models.BudgetStatusUnder
+	}
+}
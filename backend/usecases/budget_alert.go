@@ -0,0 +1,177 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/JoelD7/money/backend/models"
+	"github.com/JoelD7/money/backend/shared/logger"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// BudgetAlertChecker notifies when an expense makes the total of its category for the period cross one of the alert
+// thresholds of the category budget.
+type BudgetAlertChecker func(ctx context.Context, username string, expense *models.Expense)
+
+// ParseBudgetAlertThresholds parses a comma separated list of budget percentages, like "80,100".
+func ParseBudgetAlertThresholds(value string) ([]int, error) {
+	thresholds := make([]int, 0)
+
+	for _, part := range strings.Split(value, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		threshold, err := strconv.Atoi(part)
+		if err != nil || threshold <= 0 {
+			return nil, fmt.Errorf("invalid budget alert threshold %q", part)
+		}
+
+		thresholds = append(thresholds, threshold)
+	}
+
+	sort.Ints(thresholds)
+
+	return thresholds, nil
+}
+
+// NewBudgetAlertChecker returns a BudgetAlertChecker. Every threshold is notified at most once per category and period,
+// which is guaranteed by storing the alert before it's sent. Checking the budget must not make the expense operation
+// fail, so errors are only logged.
+func NewBudgetAlertChecker(em ExpenseManager, um UserManager, erm ExchangeRateManager, pm PeriodManager, bam BudgetAlertManager, notifier BudgetAlertNotifier, thresholds []int) BudgetAlertChecker {
+	return func(ctx context.Context, username string, expense *models.Expense) {
+		if expense == nil || expense.CategoryID == nil || expense.PeriodID == "" || len(thresholds) == 0 {
+			return
+		}
+
+		err := checkBudgetAlerts(ctx, em, um, erm, pm, bam, notifier, thresholds, username, expense)
+		if err != nil {
+			logger.Warning("budget_alert_check_failed", err, models.Any("expense", expense))
+		}
+	}
+}
+
+func checkBudgetAlerts(ctx context.Context, em ExpenseManager, um UserManager, erm ExchangeRateManager, pm PeriodManager,
+	bam BudgetAlertManager, notifier BudgetAlertNotifier, thresholds []int, username string, expense *models.Expense) error {
+	categoryID := *expense.CategoryID
+
+	user, err := um.GetUser(ctx, username)
+	if err != nil {
+		return err
+	}
+
+	budgets, err := getPeriodCategoryBudgets(ctx, pm, user, expense.PeriodID)
+	if err != nil {
+		return err
+	}
+
+	budget, ok := budgets[categoryID]
+	if !ok {
+		return nil
+	}
+
+	total, err := getCategoryPeriodTotal(ctx, em, erm, user, expense.PeriodID, categoryID)
+	if err != nil {
+		return err
+	}
+
+	for _, threshold := range thresholds {
+		if !isThresholdCrossed(total, budget, threshold) {
+			break
+		}
+
+		alert := &models.BudgetAlert{
+			Username:       username,
+			PeriodID:       expense.PeriodID,
+			CategoryID:     categoryID,
+			CategoryName:   getCategoryName(user, categoryID),
+			Threshold:      threshold,
+			Budget:         budget,
+			Total:          total,
+			PercentageUsed: math.Round(float64(total)*10000/float64(budget)) / 100,
+			Currency:       user.GetBaseCurrency(),
+			CreatedDate:    time.Now(),
+		}
+
+		err = sendBudgetAlert(ctx, bam, notifier, alert)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func getCategoryPeriodTotal(ctx context.Context, em ExpenseManager, erm ExchangeRateManager, user *models.User, periodID, categoryID string) (models.Money, error) {
+	expenses, err := em.GetAllExpensesByPeriod(ctx, user.Username, &models.QueryParameters{Period: periodID})
+	if err != nil {
+		return 0, err
+	}
+
+	converter, err := getPeriodConverter(ctx, erm, user, periodID)
+	if err != nil {
+		return 0, err
+	}
+
+	var total models.Money
+
+	for _, expense := range expenses {
+		if expense.CategoryID == nil || *expense.CategoryID != categoryID || expense.Amount == nil {
+			continue
+		}
+
+		amount, err := converter.toBase(*expense.Amount, expense.Currency)
+		if err != nil {
+			return 0, err
+		}
+
+		total += amount
+	}
+
+	return total, nil
+}
+
+// isThresholdCrossed compares in cents to avoid rounding the percentage used.
+func isThresholdCrossed(total, budget models.Money, threshold int) bool {
+	return total.Cents()*100 >= budget.Cents()*int64(threshold)
+}
+
+// sendBudgetAlert stores the alert and then sends it. If sending fails the alert is deleted, so that the next expense
+// of the category can send it again.
+func sendBudgetAlert(ctx context.Context, bam BudgetAlertManager, notifier BudgetAlertNotifier, alert *models.BudgetAlert) error {
+	err := bam.CreateBudgetAlert(ctx, alert)
+	if errors.Is(err, models.ErrBudgetAlertAlreadySent) {
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("couldn't store budget alert: %w", err)
+	}
+
+	err = notifier.Notify(ctx, alert)
+	if err == nil {
+		return nil
+	}
+
+	deleteErr := bam.DeleteBudgetAlert(ctx, alert)
+	if deleteErr != nil {
+		logger.Error("delete_unsent_budget_alert_failed", deleteErr, models.Any("budget_alert", alert))
+	}
+
+	return fmt.Errorf("couldn't send budget alert: %w", err)
+}
+
+func getCategoryName(user *models.User, categoryID string) string {
+	for _, category := range user.Categories {
+		if category.ID == categoryID && category.Name != nil {
+			return *category.Name
+		}
+	}
+
+	return ""
+}
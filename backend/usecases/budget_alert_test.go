@@ -0,0 +1,134 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"github.com/JoelD7/money/backend/models"
+	"github.com/JoelD7/money/backend/shared/logger"
+	"github.com/JoelD7/money/backend/storage/budgetalert"
+	"github.com/JoelD7/money/backend/storage/exchangerate"
+	"github.com/JoelD7/money/backend/storage/expenses"
+	"github.com/JoelD7/money/backend/storage/period"
+	"github.com/JoelD7/money/backend/storage/users"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+type notifierMock struct {
+	err    error
+	alerts []*models.BudgetAlert
+}
+
+func (n *notifierMock) Notify(ctx context.Context, alert *models.BudgetAlert) error {
+	if n.err != nil {
+		return n.err
+	}
+
+	n.alerts = append(n.alerts, alert)
+
+	return nil
+}
+
+// userManagerMock adapts the users mock to UserManager, as its CreateUser doesn't return the created user.
+type userManagerMock struct {
+	*users.DynamoMock
+}
+
+func (u userManagerMock) CreateUser(ctx context.Context, user *models.User) (*models.User, error) {
+	return user, u.DynamoMock.CreateUser(ctx, user)
+}
+
+func TestParseBudgetAlertThresholds(t *testing.T) {
+	c := require.New(t)
+
+	thresholds, err := ParseBudgetAlertThresholds("100, 80,")
+	c.NoError(err)
+	c.Equal([]int{80, 100}, thresholds)
+
+	_, err = ParseBudgetAlertThresholds("80,abc")
+	c.Error(err)
+
+	_, err = ParseBudgetAlertThresholds("-10")
+	c.Error(err)
+}
+
+func TestBudgetAlertChecker(t *testing.T) {
+	c := require.New(t)
+
+	logger.InitLogger(logger.ConsoleImplementation)
+
+	ctx := context.Background()
+	periodID := "2020-01"
+	categoryID := "CTGzJeEzCNz6HMTiPKwgPmj"
+
+	usersMock := users.NewDynamoMock()
+	expensesMock := expenses.NewDynamoMock()
+	alertsMock := budgetalert.NewMock()
+	notifier := new(notifierMock)
+
+	user, err := usersMock.GetUser(ctx, "test@gmail.com")
+	c.NoError(err)
+
+	budget := models.NewMoney(100)
+	user.Categories[0].Budget = &budget
+
+	checkBudget := NewBudgetAlertChecker(expensesMock, userManagerMock{usersMock}, exchangerate.NewMock(), period.NewDynamoMock(), alertsMock,
+		notifier, []int{80, 100})
+
+	setSpending := func(amounts ...float64) *models.Expense {
+		mockedExpenses := make([]*models.Expense, 0, len(amounts))
+
+		for _, amount := range amounts {
+			money := models.NewMoney(amount)
+
+			mockedExpenses = append(mockedExpenses, &models.Expense{
+				Username:   user.Username,
+				CategoryID: &categoryID,
+				Amount:     &money,
+				PeriodID:   periodID,
+			})
+		}
+
+		expensesMock.SetMockedExpenses(mockedExpenses)
+
+		return mockedExpenses[len(mockedExpenses)-1]
+	}
+
+	t.Run("Below the thresholds", func(t *testing.T) {
+		checkBudget(ctx, user.Username, setSpending(50, 29.99))
+		c.Empty(notifier.alerts)
+	})
+
+	t.Run("Threshold crossed", func(t *testing.T) {
+		checkBudget(ctx, user.Username, setSpending(50, 30))
+		c.Len(notifier.alerts, 1)
+		c.Equal(80, notifier.alerts[0].Threshold)
+		c.Equal("Entertainment", notifier.alerts[0].CategoryName)
+		c.Equal(80.0, notifier.alerts[0].PercentageUsed)
+	})
+
+	t.Run("Threshold is only notified once", func(t *testing.T) {
+		checkBudget(ctx, user.Username, setSpending(50, 30, 10))
+		c.Len(notifier.alerts, 1)
+	})
+
+	t.Run("Failed notification is retried", func(t *testing.T) {
+		notifier.err = errors.New("queue unavailable")
+
+		checkBudget(ctx, user.Username, setSpending(50, 30, 10, 15))
+		c.Len(notifier.alerts, 1)
+		c.Len(alertsMock.GetAlerts(), 1)
+
+		notifier.err = nil
+
+		checkBudget(ctx, user.Username, setSpending(50, 30, 10, 15, 1))
+		c.Len(notifier.alerts, 2)
+		c.Equal(100, notifier.alerts[1].Threshold)
+		c.Equal(models.NewMoney(106), notifier.alerts[1].Total)
+	})
+
+	t.Run("Expense without category", func(t *testing.T) {
+		checkBudget(ctx, user.Username, &models.Expense{PeriodID: periodID})
+		c.Len(notifier.alerts, 2)
+	})
+}
@@ -0,0 +1,84 @@
+package usecases
+
+import (
+	"github.com/JoelD7/money/backend/models"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestGetCategoryBudgets(t *testing.T) {
+	c := require.New(t)
+
+	food := models.NewMoney(500)
+	transport := models.NewMoney(100)
+	noBudget := models.Money(0)
+
+	categories := []*models.Category{
+		{ID: "CTGfood", Budget: &food},
+		{ID: "CTGtransport", Budget: &transport},
+		{ID: "CTGother", Budget: &noBudget},
+		{ID: "CTGgifts"},
+	}
+
+	t.Run("Category budgets", func(t *testing.T) {
+		budgets := getCategoryBudgets(categories, nil)
+		c.Equal(map[string]models.Money{"CTGfood": food, "CTGtransport": transport}, budgets)
+	})
+
+	t.Run("Period overrides", func(t *testing.T) {
+		period := &models.Period{CategoryBudgets: map[string]models.Money{
+			"CTGfood":      models.NewMoney(800),
+			"CTGtransport": 0,
+			"CTGgifts":     models.NewMoney(200),
+		}}
+
+		budgets := getCategoryBudgets(categories, period)
+		c.Equal(map[string]models.Money{"CTGfood": models.NewMoney(800), "CTGgifts": models.NewMoney(200)}, budgets)
+	})
+}
+
+func TestSetBudgetStatus(t *testing.T) {
+	c := require.New(t)
+
+	budgets := map[string]models.Money{
+		"CTGunder": models.NewMoney(100),
+		"CTGnear":  models.NewMoney(100),
+		"CTGover":  models.NewMoney(100),
+		"CTGlimit": models.NewMoney(100),
+		"CTGempty": models.NewMoney(50),
+	}
+
+	summary := []*models.CategoryExpenseSummary{
+		{CategoryID: "CTGunder", Total: models.NewMoney(79.99)},
+		{CategoryID: "CTGnear", Total: models.NewMoney(80)},
+		{CategoryID: "CTGover", Total: models.NewMoney(100.01)},
+		{CategoryID: "CTGlimit", Total: models.NewMoney(100)},
+		{CategoryID: "CTGnobudget", Total: models.NewMoney(30)},
+	}
+
+	summary = setBudgetStatus(summary, budgets, "2024-05", 80)
+	c.Len(summary, 6)
+
+	byCategory := make(map[string]*models.CategoryExpenseSummary)
+	for _, categorySummary := range summary {
+		byCategory[categorySummary.CategoryID] = categorySummary
+	}
+
+	c.Equal(models.BudgetStatusUnder, byCategory["CTGunder"].BudgetStatus)
+	c.Equal(models.NewMoney(20.01), *byCategory["CTGunder"].Remaining)
+	c.Equal(79.99, *byCategory["CTGunder"].PercentageUsed)
+
+	c.Equal(models.BudgetStatusNearLimit, byCategory["CTGnear"].BudgetStatus)
+	c.Equal(models.BudgetStatusNearLimit, byCategory["CTGlimit"].BudgetStatus)
+
+	c.Equal(models.BudgetStatusOver, byCategory["CTGover"].BudgetStatus)
+	c.Equal(models.NewMoney(-0.01), *byCategory["CTGover"].Remaining)
+
+	c.Empty(byCategory["CTGnobudget"].BudgetStatus)
+	c.Nil(byCategory["CTGnobudget"].Budget)
+
+	c.Equal(models.Money(0), byCategory["CTGempty"].Total)
+	c.Equal("2024-05", byCategory["CTGempty"].Period)
+	c.Equal(models.BudgetStatusUnder, byCategory["CTGempty"].BudgetStatus)
+	c.Equal(models.NewMoney(50), *byCategory["CTGempty"].Remaining)
+}
@@ -0,0 +1,157 @@
+package usecases
+
+import (
+	"context"
+	"github.com/JoelD7/money/backend/models"
+	"github.com/JoelD7/money/backend/shared/logger"
+	"strings"
+)
+
+// categoryMatch is the best keyword match found for an expense.
+type categoryMatch struct {
+	category *models.Category
+	// keywordLength is the length of the matched keyword. Longer keywords are considered more specific.
+	keywordLength int
+}
+
+// findCategory returns the category whose keywords best match the expense, or nil if none matches.
+//
+// Matching is case-insensitive and checks whether a keyword is contained in the name or notes of the expense. When
+// more than one category matches, the following precedence rules apply, in order:
+//  1. A match on the expense name takes precedence over a match on the notes.
+//  2. Within the same field, the longest matching keyword wins.
+//  3. If there's still a tie, the category that appears first in the user's category list wins.
+func findCategory(categories []*models.Category, expense *models.Expense) *models.Category {
+	if len(categories) == 0 {
+		return nil
+	}
+
+	match := matchKeywords(categories, expense.GetName())
+	if match != nil {
+		return match.category
+	}
+
+	match = matchKeywords(categories, expense.Notes)
+	if match != nil {
+		return match.category
+	}
+
+	return nil
+}
+
+func matchKeywords(categories []*models.Category, text string) *categoryMatch {
+	text = strings.ToLower(strings.TrimSpace(text))
+	if text == "" {
+		return nil
+	}
+
+	var best *categoryMatch
+
+	for _, category := range categories {
+		for _, keyword := range category.Keywords {
+			keyword = strings.ToLower(strings.TrimSpace(keyword))
+			if keyword == "" || !strings.Contains(text, keyword) {
+				continue
+			}
+
+			if best == nil || len(keyword) > best.keywordLength {
+				best = &categoryMatch{category, len(keyword)}
+			}
+		}
+	}
+
+	return best
+}
+
+// categorizeExpenses assigns a category to every expense that doesn't have one, based on the keywords of the
+// categories. If overwrite is true, expenses that already have a category are re-evaluated too. It returns the
+// expenses whose category changed.
+func categorizeExpenses(categories []*models.Category, overwrite bool, expenses ...*models.Expense) []*models.Expense {
+	changed := make([]*models.Expense, 0)
+
+	for _, expense := range expenses {
+		if expense.CategoryID != nil && !overwrite {
+			continue
+		}
+
+		category := findCategory(categories, expense)
+		if category == nil {
+			continue
+		}
+
+		if expense.CategoryID != nil && *expense.CategoryID == category.ID {
+			continue
+		}
+
+		categoryID := category.ID
+		expense.CategoryID = &categoryID
+		changed = append(changed, expense)
+	}
+
+	return changed
+}
+
+// autoCategorizeExpenses assigns a category to the expenses that arrived without one. Categorization is a best-effort
+// operation, so failing to fetch the user's categories doesn't prevent the expenses from being created.
+func autoCategorizeExpenses(ctx context.Context, um UserManager, username string, expenses ...*models.Expense) {
+	uncategorized := make([]*models.Expense, 0, len(expenses))
+
+	for _, expense := range expenses {
+		if expense.CategoryID == nil {
+			uncategorized = append(uncategorized, expense)
+		}
+	}
+
+	if len(uncategorized) == 0 {
+		return
+	}
+
+	user, err := um.GetUser(ctx, username)
+	if err != nil {
+		logger.Warning("expense_auto_categorization_failed", err, models.Any("user", map[string]interface{}{
+			"s_username": username,
+		}))
+
+		return
+	}
+
+	categorizeExpenses(user.Categories, false, uncategorized...)
+}
+
+// NewExpensesCategorizer applies the category keyword rules to the expenses of a period and persists the expenses
+// whose category changed. By default, only the expenses without a category are evaluated; set overwrite to true to
+// re-evaluate every expense of the period.
+func NewExpensesCategorizer(em ExpenseManager, um UserManager) func(ctx context.Context, username, periodID string, overwrite bool) ([]*models.Expense, error) {
+	return func(ctx context.Context, username, periodID string, overwrite bool) ([]*models.Expense, error) {
+		user, err := um.GetUser(ctx, username)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(user.Categories) == 0 {
+			return nil, models.ErrCategoriesNotFound
+		}
+
+		expenses, err := em.GetAllExpensesByPeriod(ctx, username, &models.QueryParameters{Period: periodID})
+		if err != nil {
+			return nil, err
+		}
+
+		changed := categorizeExpenses(user.Categories, overwrite, expenses...)
+		if len(changed) == 0 {
+			return changed, nil
+		}
+
+		err = em.BatchUpdateExpenses(ctx, changed)
+		if err != nil {
+			return nil, err
+		}
+
+		err = setExpensesCategoryNames(user, changed)
+		if err != nil {
+			return changed, err
+		}
+
+		return changed, nil
+	}
+}
@@ -0,0 +1,65 @@
+package usecases
+
+import (
+	"github.com/JoelD7/money/backend/models"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestFindCategory(t *testing.T) {
+	c := require.New(t)
+
+	food := &models.Category{ID: "CTGfood", Keywords: []string{"restaurant", "pizza"}}
+	transport := &models.Category{ID: "CTGtransport", Keywords: []string{"uber", "bus"}}
+	delivery := &models.Category{ID: "CTGdelivery", Keywords: []string{"uber eats"}}
+	categories := []*models.Category{food, transport, delivery}
+
+	newExpense := func(name, notes string) *models.Expense {
+		return &models.Expense{Name: &name, Notes: notes}
+	}
+
+	t.Run("Case insensitive match on name", func(t *testing.T) {
+		c.Equal(food, findCategory(categories, newExpense("PIZZA Hut", "")))
+	})
+
+	t.Run("Name takes precedence over notes", func(t *testing.T) {
+		c.Equal(transport, findCategory(categories, newExpense("Uber to work", "pizza for lunch")))
+	})
+
+	t.Run("Longest keyword wins", func(t *testing.T) {
+		c.Equal(delivery, findCategory(categories, newExpense("Uber Eats order", "")))
+	})
+
+	t.Run("First category wins on tie", func(t *testing.T) {
+		other := &models.Category{ID: "CTGother", Keywords: []string{"pizza"}}
+		c.Equal(food, findCategory(append(categories, other), newExpense("pizza", "")))
+	})
+
+	t.Run("Match on notes", func(t *testing.T) {
+		c.Equal(food, findCategory(categories, newExpense("Dinner", "at the restaurant")))
+	})
+
+	t.Run("No match", func(t *testing.T) {
+		c.Nil(findCategory(categories, newExpense("Rent", "")))
+	})
+}
+
+func TestCategorizeExpenses(t *testing.T) {
+	c := require.New(t)
+
+	categories := []*models.Category{{ID: "CTGfood", Keywords: []string{"pizza"}}}
+	name := "pizza"
+	currentCategory := "CTGother"
+
+	uncategorized := &models.Expense{Name: &name}
+	categorized := &models.Expense{Name: &name, CategoryID: &currentCategory}
+
+	changed := categorizeExpenses(categories, false, uncategorized, categorized)
+	c.Len(changed, 1)
+	c.Equal("CTGfood", *uncategorized.CategoryID)
+	c.Equal("CTGother", *categorized.CategoryID)
+
+	changed = categorizeExpenses(categories, true, uncategorized, categorized)
+	c.Len(changed, 1)
+	c.Equal("CTGfood", *categorized.CategoryID)
+}
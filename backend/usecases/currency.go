@@ -0,0 +1,221 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/JoelD7/money/backend/models"
+	"math"
+	"time"
+)
+
+// CurrencyHolder is an interface that describes entities that have an amount of money in some currency.
+type CurrencyHolder interface {
+	GetCurrency() string
+	SetCurrency(currency string)
+}
+
+// currencyConverter converts amounts between currencies using the exchange rates a user set for a period.
+type currencyConverter struct {
+	baseCurrency string
+	// legacyCurrency is the currency of the amounts without a currency.
+	legacyCurrency string
+	// rates holds the amount of the base currency that one unit of each currency is worth.
+	rates map[string]float64
+}
+
+func newCurrencyConverter(user *models.User, exchangeRates []*models.ExchangeRate) *currencyConverter {
+	rates := make(map[string]float64, len(exchangeRates))
+
+	for _, exchangeRate := range exchangeRates {
+		rates[exchangeRate.Currency] = exchangeRate.Rate
+	}
+
+	return &currencyConverter{user.GetBaseCurrency(), user.GetLegacyCurrency(), rates}
+}
+
+// getPeriodConverter returns a converter with the exchange rates the user set for the period. If there's no period,
+// the converter only accepts amounts in the base currency.
+func getPeriodConverter(ctx context.Context, erm ExchangeRateManager, user *models.User, periodID string) (*currencyConverter, error) {
+	if periodID == "" {
+		return newCurrencyConverter(user, nil), nil
+	}
+
+	exchangeRates, err := erm.GetExchangeRates(ctx, user.Username, periodID)
+	if err != nil && !errors.Is(err, models.ErrExchangeRatesNotFound) {
+		return nil, fmt.Errorf("couldn't get exchange rates for period: %w", err)
+	}
+
+	return newCurrencyConverter(user, exchangeRates), nil
+}
+
+// rate returns the amount of the base currency that one unit of the currency is worth. Amounts without a currency were
+// created before currencies were supported, so they are considered to be in the legacy currency of the user.
+func (c *currencyConverter) rate(currency string) (float64, error) {
+	currency = defaultToLegacy(currency, c.legacyCurrency)
+
+	if currency == c.baseCurrency {
+		return 1, nil
+	}
+
+	rate, ok := c.rates[currency]
+	if !ok {
+		return 0, fmt.Errorf("%w: %s", models.ErrMissingExchangeRate, currency)
+	}
+
+	return rate, nil
+}
+
+// toBase converts an amount of the currency into the base currency. The result is rounded to the nearest cent.
+func (c *currencyConverter) toBase(amount models.Money, currency string) (models.Money, error) {
+	rate, err := c.rate(currency)
+	if err != nil {
+		return 0, err
+	}
+
+	return amount.MulRate(rate)
+}
+
+// fromBase converts an amount of the base currency into the currency. The result is rounded to the nearest cent.
+func (c *currencyConverter) fromBase(amount models.Money, currency string) (models.Money, error) {
+	rate, err := c.rate(currency)
+	if err != nil {
+		return 0, err
+	}
+
+	return amount.DivRate(rate)
+}
+
+// defaultToLegacy returns the currency, or the legacy currency of the user if it's empty.
+func defaultToLegacy(currency, legacyCurrency string) string {
+	if currency == "" {
+		return legacyCurrency
+	}
+
+	return currency
+}
+
+func getBaseCurrency(ctx context.Context, um UserManager, username string) (string, error) {
+	user, err := um.GetUser(ctx, username)
+	if err != nil {
+		return "", fmt.Errorf("couldn't get the base currency of the user: %w", err)
+	}
+
+	return user.GetBaseCurrency(), nil
+}
+
+// setDefaultCurrency sets the base currency of the user to the entities that don't specify a currency, so that every
+// new record carries its currency.
+func setDefaultCurrency(ctx context.Context, um UserManager, username string, entities ...CurrencyHolder) error {
+	missingCurrency := make([]CurrencyHolder, 0, len(entities))
+
+	for _, entity := range entities {
+		if entity.GetCurrency() == "" {
+			missingCurrency = append(missingCurrency, entity)
+		}
+	}
+
+	if len(missingCurrency) == 0 {
+		return nil
+	}
+
+	baseCurrency, err := getBaseCurrency(ctx, um, username)
+	if err != nil {
+		return err
+	}
+
+	for _, entity := range missingCurrency {
+		entity.SetCurrency(baseCurrency)
+	}
+
+	return nil
+}
+
+func toCurrencyHolders[T CurrencyHolder](entities []T) []CurrencyHolder {
+	holders := make([]CurrencyHolder, 0, len(entities))
+
+	for _, entity := range entities {
+		holders = append(holders, entity)
+	}
+
+	return holders
+}
+
+// NewBaseCurrencyUpdater changes the base currency of the user. Exchange rates are relative to the base currency, so
+// the rates of past periods should be reviewed after changing it. The records without a currency stay in the previous
+// base currency, which is kept as the legacy currency of the user.
+func NewBaseCurrencyUpdater(um UserManager) func(ctx context.Context, username, baseCurrency string) (*models.User, error) {
+	return func(ctx context.Context, username, baseCurrency string) (*models.User, error) {
+		user, err := um.GetUser(ctx, username)
+		if err != nil {
+			return nil, err
+		}
+
+		user.SetBaseCurrency(baseCurrency)
+		user.UpdatedDate = time.Now()
+
+		err = um.UpdateUser(ctx, user)
+		if err != nil {
+			return nil, err
+		}
+
+		return user, nil
+	}
+}
+
+// NewExchangeRateSetter sets the exchange rate of a currency for a period, replacing the previous one if any.
+func NewExchangeRateSetter(erm ExchangeRateManager, pm PeriodManager, um UserManager) func(ctx context.Context, username string, exchangeRate *models.ExchangeRate) (*models.ExchangeRate, error) {
+	return func(ctx context.Context, username string, exchangeRate *models.ExchangeRate) (*models.ExchangeRate, error) {
+		if exchangeRate.Rate <= 0 || math.IsInf(exchangeRate.Rate, 0) || math.IsNaN(exchangeRate.Rate) {
+			return nil, models.ErrInvalidExchangeRate
+		}
+
+		baseCurrency, err := getBaseCurrency(ctx, um, username)
+		if err != nil {
+			return nil, err
+		}
+
+		if exchangeRate.Currency == baseCurrency {
+			return nil, models.ErrExchangeRateForBaseCurrency
+		}
+
+		_, err = pm.GetPeriod(ctx, username, exchangeRate.PeriodID)
+		if err != nil {
+			return nil, err
+		}
+
+		now := time.Now()
+
+		exchangeRate.Username = username
+		exchangeRate.CreatedDate = now
+		exchangeRate.UpdatedDate = now
+
+		currentExchangeRate, err := erm.GetExchangeRate(ctx, username, exchangeRate.PeriodID, exchangeRate.Currency)
+		if err != nil && !errors.Is(err, models.ErrExchangeRateNotFound) {
+			return nil, err
+		}
+
+		if currentExchangeRate != nil {
+			exchangeRate.CreatedDate = currentExchangeRate.CreatedDate
+		}
+
+		err = erm.SaveExchangeRate(ctx, exchangeRate)
+		if err != nil {
+			return nil, err
+		}
+
+		return exchangeRate, nil
+	}
+}
+
+func NewExchangeRatesGetter(erm ExchangeRateManager) func(ctx context.Context, username, periodID string) ([]*models.ExchangeRate, error) {
+	return func(ctx context.Context, username, periodID string) ([]*models.ExchangeRate, error) {
+		return erm.GetExchangeRates(ctx, username, periodID)
+	}
+}
+
+func NewExchangeRateDeleter(erm ExchangeRateManager) func(ctx context.Context, username, periodID, currency string) error {
+	return func(ctx context.Context, username, periodID, currency string) error {
+		return erm.DeleteExchangeRate(ctx, username, periodID, currency)
+	}
+}
@@ -0,0 +1,88 @@
+package usecases
+
+import (
+	"context"
+	"github.com/JoelD7/money/backend/models"
+	"github.com/JoelD7/money/backend/storage/users"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestCurrencyConverter(t *testing.T) {
+	c := require.New(t)
+
+	converter := newCurrencyConverter(&models.User{BaseCurrency: "USD"}, []*models.ExchangeRate{
+		{Currency: "EUR", Rate: 1.1},
+		{Currency: "DOP", Rate: 0.017},
+	})
+
+	t.Run("Empty currency is the base currency", func(t *testing.T) {
+		amount, err := converter.toBase(models.NewMoney(100), "")
+		c.NoError(err)
+		c.Equal(models.NewMoney(100), amount)
+	})
+
+	t.Run("Convert to base currency", func(t *testing.T) {
+		amount, err := converter.toBase(models.NewMoney(100), "EUR")
+		c.NoError(err)
+		c.Equal(models.NewMoney(110), amount)
+	})
+
+	t.Run("Convert from base currency", func(t *testing.T) {
+		amount, err := converter.fromBase(models.NewMoney(17), "DOP")
+		c.NoError(err)
+		c.Equal(models.NewMoney(1000), amount)
+	})
+
+	t.Run("Missing exchange rate", func(t *testing.T) {
+		_, err := converter.toBase(models.NewMoney(100), "GBP")
+		c.ErrorIs(err, models.ErrMissingExchangeRate)
+	})
+}
+
+func TestCurrencyConverterWithLegacyCurrency(t *testing.T) {
+	c := require.New(t)
+
+	converter := newCurrencyConverter(&models.User{BaseCurrency: "EUR", LegacyCurrency: "USD"}, []*models.ExchangeRate{
+		{Currency: "USD", Rate: 0.9},
+	})
+
+	amount, err := converter.toBase(models.NewMoney(100), "")
+	c.NoError(err)
+	c.Equal(models.NewMoney(90), amount)
+
+	amount, err = converter.toBase(models.NewMoney(100), "EUR")
+	c.NoError(err)
+	c.Equal(models.NewMoney(100), amount)
+
+	_, err = newCurrencyConverter(&models.User{BaseCurrency: "EUR", LegacyCurrency: "USD"}, nil).toBase(models.NewMoney(100), "")
+	c.ErrorIs(err, models.ErrMissingExchangeRate)
+}
+
+func TestBaseCurrencyUpdater(t *testing.T) {
+	c := require.New(t)
+
+	ctx := context.Background()
+	userRepo := users.NewMemoryRepository()
+
+	_, err := userRepo.CreateUser(ctx, &models.User{Username: "test"})
+	c.NoError(err)
+
+	updateBaseCurrency := NewBaseCurrencyUpdater(userRepo)
+
+	user, err := updateBaseCurrency(ctx, "test", "EUR")
+	c.NoError(err)
+	c.Equal("EUR", user.GetBaseCurrency())
+	c.Equal(models.DefaultCurrency, user.GetLegacyCurrency())
+
+	// The records without a currency were saved before the first change, so the legacy currency doesn't change again.
+	user, err = updateBaseCurrency(ctx, "test", "DOP")
+	c.NoError(err)
+	c.Equal("DOP", user.GetBaseCurrency())
+	c.Equal(models.DefaultCurrency, user.GetLegacyCurrency())
+
+	stored, err := userRepo.GetUser(ctx, "test")
+	c.NoError(err)
+	c.Equal("DOP", stored.BaseCurrency)
+	c.Equal(models.DefaultCurrency, stored.LegacyCurrency)
+}
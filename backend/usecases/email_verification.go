@@ -0,0 +1,144 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/JoelD7/money/backend/models"
+	"github.com/JoelD7/money/backend/shared/env"
+	"github.com/JoelD7/money/backend/shared/logger"
+	"net/url"
+	"time"
+)
+
+const (
+	emailVerificationAudience = "email_verification"
+	emailVerificationScope    = "verify_email"
+	// defaultEmailVerificationTokenDuration is how many seconds a verification token is valid for when
+	// EMAIL_VERIFICATION_TOKEN_DURATION isn't set.
+	defaultEmailVerificationTokenDuration = 86400
+)
+
+// NewEmailVerificationSender mails the user a signed token to verify the email of the account.
+func NewEmailVerificationSender(secretManager SecretManager, sender MailSender) func(ctx context.Context, user *models.User) error {
+	return func(ctx context.Context, user *models.User) error {
+		duration := env.GetInt("EMAIL_VERIFICATION_TOKEN_DURATION", defaultEmailVerificationTokenDuration)
+
+		token, err := generateScopedToken(secretManager, user.Username, emailVerificationAudience, emailVerificationScope,
+			time.Duration(duration)*time.Second)
+		if err != nil {
+			logger.Error("generate_email_verification_token_failed", err, user)
+
+			return err
+		}
+
+		err = sender.Send(ctx, newEmailVerificationMail(user.Username, token.Value, token.Expiration))
+		if err != nil {
+			logger.Error("email_verification_mail_sending_failed", err, user)
+
+			return err
+		}
+
+		return nil
+	}
+}
+
+// NewEmailVerificationResender sends a new verification token to a user that hasn't verified the email yet. As with
+// the password reset, nothing is sent nor returned for users that don't exist.
+func NewEmailVerificationResender(um UserManager, secretManager SecretManager, sender MailSender) func(ctx context.Context, username string) error {
+	return func(ctx context.Context, username string) error {
+		user, err := um.GetUser(ctx, username)
+		if errors.Is(err, models.ErrUserNotFound) {
+			logger.Warning("email_verification_requested_for_unknown_user", err, models.Any("email_verification", map[string]interface{}{
+				"s_username": username,
+			}))
+
+			return nil
+		}
+
+		if err != nil {
+			return err
+		}
+
+		if user.Verified {
+			return nil
+		}
+
+		sendVerification := NewEmailVerificationSender(secretManager, sender)
+
+		return sendVerification(ctx, user)
+	}
+}
+
+// NewEmailVerifier marks as verified the user the token was issued to.
+func NewEmailVerifier(um UserManager, secretManager SecretManager) func(ctx context.Context, token string) (*models.User, error) {
+	return func(ctx context.Context, token string) (*models.User, error) {
+		username, err := validateScopedToken(ctx, secretManager, token, emailVerificationAudience, emailVerificationScope,
+			models.ErrInvalidEmailVerificationToken)
+		if err != nil {
+			logger.Warning("email_verification_token_validation_failed", err, nil)
+
+			return nil, err
+		}
+
+		user, err := um.GetUser(ctx, username)
+		if errors.Is(err, models.ErrUserNotFound) {
+			return nil, fmt.Errorf("%w: %v", models.ErrInvalidEmailVerificationToken, err)
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		if user.Verified {
+			return user, nil
+		}
+
+		user.Verified = true
+
+		err = um.UpdateUser(ctx, user)
+		if err != nil {
+			logger.Error("email_verification_saving_failed", err, user)
+
+			return nil, err
+		}
+
+		logger.Info("email_verified", user)
+
+		return user, nil
+	}
+}
+
+// checkEmailVerified returns ErrUnverifiedUser when EMAIL_VERIFICATION_REQUIRED is set and the user didn't verify the
+// email within EMAIL_VERIFICATION_GRACE_PERIOD seconds since the sign-up.
+func checkEmailVerified(user *models.User) error {
+	if user.Verified || !env.GetBool("EMAIL_VERIFICATION_REQUIRED") {
+		return nil
+	}
+
+	gracePeriod := time.Duration(env.GetInt("EMAIL_VERIFICATION_GRACE_PERIOD", 0)) * time.Second
+
+	if gracePeriod > 0 && time.Now().Before(user.CreatedDate.Add(gracePeriod)) {
+		return nil
+	}
+
+	return models.ErrUnverifiedUser
+}
+
+// newEmailVerificationMail builds the email with the verification token. When TOKEN_ISSUER is set, which is the URL of
+// the authentication server, the token is sent as a link to the verification endpoint.
+func newEmailVerificationMail(username, token string, expiration time.Time) *models.Mail {
+	verification := token
+
+	issuer := env.GetString("TOKEN_ISSUER", "")
+	if issuer != "" {
+		verification = issuer + "/auth/verify?token=" + url.QueryEscape(token)
+	}
+
+	return &models.Mail{
+		To:      username,
+		Subject: "Verify your email",
+		Body: fmt.Sprintf("Use the following to verify the email of your account, until %s.\n\n%s\n\n"+
+			"If you didn't create an account, you can ignore this email.", expiration.UTC().Format(time.RFC1123), verification),
+	}
+}
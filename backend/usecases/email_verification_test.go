@@ -0,0 +1,116 @@
+package usecases
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"github.com/JoelD7/money/backend/models"
+	"github.com/JoelD7/money/backend/shared/logger"
+	"github.com/JoelD7/money/backend/shared/secrets"
+	"github.com/JoelD7/money/backend/storage/sessions"
+	"github.com/JoelD7/money/backend/storage/users"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func newTokenSecretsMock(t *testing.T, c *require.Assertions) *secrets.MockSecret {
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	c.NoError(err)
+
+	publicKey, err := x509.MarshalPKIXPublicKey(&privateKey.PublicKey)
+	c.NoError(err)
+
+	t.Setenv("TOKEN_PRIVATE_SECRET", "private-secret")
+	t.Setenv("TOKEN_PUBLIC_SECRET", "public-secret")
+
+	secretMock := secrets.NewSecretMock()
+	secretMock.RegisterResponder("private-secret", func(ctx context.Context, name string) (string, error) {
+		return string(pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(privateKey)})), nil
+	})
+	secretMock.RegisterResponder("public-secret", func(ctx context.Context, name string) (string, error) {
+		return string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicKey})), nil
+	})
+
+	return secretMock
+}
+
+func TestEmailVerification(t *testing.T) {
+	c := require.New(t)
+
+	logger.InitLogger(logger.ConsoleImplementation)
+	t.Setenv("EMAIL_VERIFICATION_REQUIRED", "true")
+
+	ctx := context.Background()
+	userRepo := users.NewMemoryRepository()
+	secretMock := newTokenSecretsMock(t, c)
+	sender := new(mailRecorder)
+
+	user, err := userRepo.CreateUser(ctx, &models.User{
+		Username:    "test@gmail.com",
+		CreatedDate: time.Now(),
+	})
+	c.NoError(err)
+
+	generateTokens := NewUserTokenGenerator(sessions.NewMemoryRepository(), secretMock)
+	resendVerification := NewEmailVerificationResender(userRepo, secretMock, sender)
+	verifyEmail := NewEmailVerifier(userRepo, secretMock)
+
+	_, _, err = generateTokens(ctx, user, new(models.Session))
+	c.ErrorIs(err, models.ErrUnverifiedUser)
+
+	t.Run("Within the grace period", func(t *testing.T) {
+		t.Setenv("EMAIL_VERIFICATION_GRACE_PERIOD", "3600")
+
+		_, _, err = generateTokens(ctx, user, new(models.Session))
+		c.NoError(err)
+	})
+
+	c.NoError(resendVerification(ctx, "test@gmail.com"))
+	c.Len(sender.mails, 1)
+	c.Equal("test@gmail.com", sender.mails[0].To)
+
+	_, err = verifyEmail(ctx, "wrong-token")
+	c.ErrorIs(err, models.ErrInvalidEmailVerificationToken)
+
+	user, err = verifyEmail(ctx, sender.lastToken())
+	c.NoError(err)
+	c.True(user.Verified)
+
+	user, err = userRepo.GetUser(ctx, "test@gmail.com")
+	c.NoError(err)
+	c.True(user.Verified)
+
+	accessToken, _, err := generateTokens(ctx, user, new(models.Session))
+	c.NoError(err)
+
+	t.Run("Access token used as verification token", func(t *testing.T) {
+		_, err = verifyEmail(ctx, accessToken.Value)
+		c.ErrorIs(err, models.ErrInvalidEmailVerificationToken)
+	})
+
+	t.Run("Verified user", func(t *testing.T) {
+		mailsSent := len(sender.mails)
+
+		c.NoError(resendVerification(ctx, "test@gmail.com"))
+		c.Len(sender.mails, mailsSent)
+	})
+
+	t.Run("Expired token", func(t *testing.T) {
+		t.Setenv("EMAIL_VERIFICATION_TOKEN_DURATION", "-60")
+
+		c.NoError(NewEmailVerificationSender(secretMock, sender)(ctx, user))
+
+		_, err = verifyEmail(ctx, sender.lastToken())
+		c.ErrorIs(err, models.ErrInvalidEmailVerificationToken)
+	})
+
+	t.Run("Unknown user", func(t *testing.T) {
+		mailsSent := len(sender.mails)
+
+		c.NoError(resendVerification(ctx, "unknown@gmail.com"))
+		c.Len(sender.mails, mailsSent)
+	})
+}
@@ -5,12 +5,15 @@ import (
 	"errors"
 	"fmt"
 	"github.com/JoelD7/money/backend/models"
-	"math"
+	"github.com/JoelD7/money/backend/shared/validate"
 	"math/rand"
+	"strings"
 	"time"
 )
 
-func NewExpenseCreator(em ExpenseManager, pm PeriodManager, cache ResourceCacheManager) func(ctx context.Context, username, idempotencyKey string, expense *models.Expense) (*models.Expense, error) {
+// NewExpenseCreator creates an expense. checkBudget is optional; when set, it's called with the new expense to notify
+// if its category crossed a budget alert threshold.
+func NewExpenseCreator(em ExpenseManager, pm PeriodManager, um UserManager, cache ResourceCacheManager, checkBudget BudgetAlertChecker) func(ctx context.Context, username, idempotencyKey string, expense *models.Expense) (*models.Expense, error) {
 	return func(ctx context.Context, username, idempotencyKey string, expense *models.Expense) (*models.Expense, error) {
 		return CreateResource(ctx, cache, idempotencyKey, func() (*models.Expense, error) {
 			err := validateExpensePeriod(ctx, expense, username, pm)
@@ -22,11 +25,22 @@ func NewExpenseCreator(em ExpenseManager, pm PeriodManager, cache ResourceCacheM
 			expense.Username = username
 			expense.CreatedDate = time.Now()
 
+			err = setDefaultCurrency(ctx, um, username, expense)
+			if err != nil {
+				return nil, err
+			}
+
+			autoCategorizeExpenses(ctx, um, username, expense)
+
 			newExpense, err := em.CreateExpense(ctx, expense)
 			if err != nil {
 				return nil, err
 			}
 
+			if checkBudget != nil {
+				checkBudget(ctx, username, newExpense)
+			}
+
 			return newExpense, nil
 		})
 	}
@@ -41,18 +55,34 @@ func randomErr[R models.Resource](resource R) (R, error) {
 	return nil, fmt.Errorf("random error")
 }
 
-func NewBatchExpensesCreator(em ExpenseManager) func(ctx context.Context, expenses []*models.Expense) error {
+func NewBatchExpensesCreator(em ExpenseManager, um UserManager) func(ctx context.Context, expenses []*models.Expense) error {
 	return func(ctx context.Context, expenses []*models.Expense) error {
+		expensesByUser := make(map[string][]*models.Expense)
+
 		for _, expense := range expenses {
 			expense.ExpenseID = generateDynamoID("EX")
 			expense.CreatedDate = time.Now()
+
+			expensesByUser[expense.Username] = append(expensesByUser[expense.Username], expense)
+		}
+
+		for username, userExpenses := range expensesByUser {
+			err := setDefaultCurrency(ctx, um, username, toCurrencyHolders(userExpenses)...)
+			if err != nil {
+				return err
+			}
+
+			autoCategorizeExpenses(ctx, um, username, userExpenses...)
 		}
 
 		return em.BatchCreateExpenses(ctx, expenses)
 	}
 }
 
-func NewExpenseUpdater(em ExpenseManager, pm PeriodManager, um UserManager) func(ctx context.Context, expenseID, username string, expense *models.Expense) (*models.Expense, error) {
+// NewExpenseUpdater updates an expense. checkBudget is optional; when set, it's called with the updated expense to notify
+// if its category crossed a budget alert threshold. The update fails with models.ErrVersionConflict if the expense isn't
+// at the version of the given one; an expense without a version is applied to the current one.
+func NewExpenseUpdater(em ExpenseManager, pm PeriodManager, um UserManager, checkBudget BudgetAlertChecker) func(ctx context.Context, expenseID, username string, expense *models.Expense) (*models.Expense, error) {
 	return func(ctx context.Context, expenseID, username string, expense *models.Expense) (*models.Expense, error) {
 		user, err := um.GetUser(ctx, username)
 		if err != nil {
@@ -63,6 +93,15 @@ func NewExpenseUpdater(em ExpenseManager, pm PeriodManager, um UserManager) func
 		expense.ExpenseID = expenseID
 		expense.UpdateDate = time.Now()
 
+		if expense.Version == 0 {
+			current, err := em.GetExpense(ctx, username, expenseID)
+			if err != nil {
+				return nil, err
+			}
+
+			expense.Version = current.Version
+		}
+
 		err = validateExpensePeriod(ctx, expense, username, pm)
 		if err != nil {
 			return nil, err
@@ -78,6 +117,10 @@ func NewExpenseUpdater(em ExpenseManager, pm PeriodManager, um UserManager) func
 			return nil, fmt.Errorf("getting updated expense failed: %w", err)
 		}
 
+		if checkBudget != nil {
+			checkBudget(ctx, username, updatedExpense)
+		}
+
 		err = setExpensesCategoryNames(user, []*models.Expense{updatedExpense})
 		if err != nil {
 			return updatedExpense, err
@@ -87,6 +130,79 @@ func NewExpenseUpdater(em ExpenseManager, pm PeriodManager, um UserManager) func
 	}
 }
 
+// NewExpensePatcher applies a JSON merge patch to an expense and validates the result with the rules of its creation.
+// Whether the expense is recurring can't be patched, as the recurring expense that generates it is managed separately.
+func NewExpensePatcher(em ExpenseManager, pm PeriodManager, um UserManager, checkBudget BudgetAlertChecker) func(ctx context.Context, expenseID, username string, version int64, patch []byte) (*models.Expense, error) {
+	return func(ctx context.Context, expenseID, username string, version int64, patch []byte) (*models.Expense, error) {
+		current, err := em.GetExpense(ctx, username, expenseID)
+		if err != nil {
+			return nil, err
+		}
+
+		err = checkVersion(version, current.Version)
+		if err != nil {
+			return nil, err
+		}
+
+		expense, err := applyMergePatch(current, patch)
+		if err != nil {
+			return nil, err
+		}
+
+		expense.ExpenseID = current.ExpenseID
+		expense.Username = current.Username
+		expense.CreatedDate = current.CreatedDate
+		expense.IsRecurring = current.IsRecurring
+		expense.RecurringDay = current.RecurringDay
+		expense.CategoryName = ""
+		expense.PeriodName = ""
+		expense.PeriodUser = nil
+		expense.UpdateDate = time.Now()
+		expense.Version = current.Version
+
+		err = setDefaultCurrency(ctx, um, username, expense)
+		if err != nil {
+			return nil, err
+		}
+
+		err = validate.Expense(expense)
+		if err != nil {
+			return nil, err
+		}
+
+		err = validateExpensePeriod(ctx, expense, username, pm)
+		if err != nil {
+			return nil, err
+		}
+
+		err = em.ReplaceExpense(ctx, expense)
+		if err != nil {
+			return nil, err
+		}
+
+		user, err := um.GetUser(ctx, username)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", models.ErrCategoryNameSettingFailed, err)
+		}
+
+		patchedExpense, err := em.GetExpense(ctx, username, expenseID)
+		if err != nil {
+			return nil, fmt.Errorf("getting patched expense failed: %w", err)
+		}
+
+		if checkBudget != nil {
+			checkBudget(ctx, username, patchedExpense)
+		}
+
+		err = setExpensesCategoryNames(user, []*models.Expense{patchedExpense})
+		if err != nil {
+			return patchedExpense, err
+		}
+
+		return patchedExpense, nil
+	}
+}
+
 func NewExpenseGetter(em ExpenseManager, um UserManager, pm PeriodManager) func(ctx context.Context, username, expenseID string) (*models.Expense, error) {
 	return func(ctx context.Context, username, expenseID string) (*models.Expense, error) {
 		user, err := um.GetUser(ctx, username)
@@ -237,9 +353,24 @@ func NewExpensesByPeriodAndCategoriesGetter(em ExpenseManager, um UserManager, p
 	}
 }
 
-func NewExpensesDeleter(em ExpenseManager) func(ctx context.Context, expenseID, username string) error {
-	return func(ctx context.Context, expenseID, username string) error {
-		return em.DeleteExpense(ctx, expenseID, username)
+// NewExpensesDeleter moves the expense to the trash of the user instead of deleting it permanently. If version isn't 0,
+// the expense is only deleted if it's still at that version. Either way, it's only deleted if it didn't change after it
+// was read, so that the trash holds what was deleted.
+func NewExpensesDeleter(em ExpenseManager, tm TrashManager) func(ctx context.Context, expenseID, username string, version int64) error {
+	return func(ctx context.Context, expenseID, username string, version int64) error {
+		expense, err := em.GetExpense(ctx, username, expenseID)
+		if err != nil {
+			return err
+		}
+
+		err = checkVersion(version, expense.Version)
+		if err != nil {
+			return err
+		}
+
+		return moveToTrash(ctx, tm, username, models.NewExpenseTrashItem(expense), func() error {
+			return em.DeleteExpense(ctx, expenseID, username, expense.Version)
+		})
 	}
 }
 
@@ -316,30 +447,149 @@ func NewExpenseRecurringEliminator(em ExpenseRecurringManager) func(ctx context.
 	}
 }
 
-func NewCategoryExpenseSummaryGetter(em ExpenseManager) func(ctx context.Context, username, periodID string) ([]*models.CategoryExpenseSummary, error) {
+func NewExpenseRecurringCreator(em ExpenseRecurringManager, um UserManager, cache ResourceCacheManager) func(ctx context.Context, username, idempotencyKey string, expenseRecurring *models.ExpenseRecurring) (*models.ExpenseRecurring, error) {
+	return func(ctx context.Context, username, idempotencyKey string, expenseRecurring *models.ExpenseRecurring) (*models.ExpenseRecurring, error) {
+		return CreateResource(ctx, cache, idempotencyKey, func() (*models.ExpenseRecurring, error) {
+			// The ID of a recurring expense is its name, the same way it's built when a recurring expense is created
+			// along with an expense.
+			expenseRecurring.ID = strings.ToLower(expenseRecurring.Name)
+			expenseRecurring.Username = username
+			expenseRecurring.CreatedDate = time.Now()
+
+			err := validateExpenseRecurringCategory(ctx, um, username, expenseRecurring)
+			if err != nil {
+				return nil, err
+			}
+
+			err = setDefaultCurrency(ctx, um, username, expenseRecurring)
+			if err != nil {
+				return nil, err
+			}
+
+			return em.CreateExpenseRecurring(ctx, expenseRecurring)
+		})
+	}
+}
+
+func NewExpenseRecurringGetter(em ExpenseRecurringManager) func(ctx context.Context, expenseRecurringID, username string) (*models.ExpenseRecurring, error) {
+	return func(ctx context.Context, expenseRecurringID, username string) (*models.ExpenseRecurring, error) {
+		return em.GetExpenseRecurring(ctx, expenseRecurringID, username)
+	}
+}
+
+func NewExpensesRecurringGetter(em ExpenseRecurringManager) func(ctx context.Context, username string, params *models.QueryParameters) ([]*models.ExpenseRecurring, string, error) {
+	return func(ctx context.Context, username string, params *models.QueryParameters) ([]*models.ExpenseRecurring, string, error) {
+		if err := validatePageSize(params.PageSize); err != nil {
+			return nil, "", err
+		}
+
+		return em.GetExpensesRecurring(ctx, username, params)
+	}
+}
+
+// NewExpenseRecurringUpdater replaces the amount, category, recurring day, notes and paused flag of a recurring expense.
+// The name can't be changed because it's what identifies the recurring expense.
+func NewExpenseRecurringUpdater(em ExpenseRecurringManager, um UserManager) func(ctx context.Context, expenseRecurringID, username string, expenseRecurring *models.ExpenseRecurring) (*models.ExpenseRecurring, error) {
+	return func(ctx context.Context, expenseRecurringID, username string, expenseRecurring *models.ExpenseRecurring) (*models.ExpenseRecurring, error) {
+		currentExpenseRecurring, err := em.GetExpenseRecurring(ctx, expenseRecurringID, username)
+		if err != nil {
+			return nil, err
+		}
+
+		err = validateExpenseRecurringCategory(ctx, um, username, expenseRecurring)
+		if err != nil {
+			return nil, err
+		}
+
+		currentExpenseRecurring.Amount = expenseRecurring.Amount
+
+		if expenseRecurring.Currency != "" {
+			currentExpenseRecurring.Currency = expenseRecurring.Currency
+		}
+
+		currentExpenseRecurring.CategoryID = expenseRecurring.CategoryID
+		currentExpenseRecurring.RecurringDay = expenseRecurring.RecurringDay
+		currentExpenseRecurring.Notes = expenseRecurring.Notes
+		currentExpenseRecurring.Paused = expenseRecurring.Paused
+		currentExpenseRecurring.UpdateDate = time.Now()
+
+		err = em.UpdateExpenseRecurring(ctx, currentExpenseRecurring)
+		if err != nil {
+			return nil, err
+		}
+
+		return currentExpenseRecurring, nil
+	}
+}
+
+// validateExpenseRecurringCategory checks that the category of the recurring expense, if any, is one of the categories
+// of the user.
+func validateExpenseRecurringCategory(ctx context.Context, um UserManager, username string, expenseRecurring *models.ExpenseRecurring) error {
+	if expenseRecurring.CategoryID == nil || *expenseRecurring.CategoryID == "" {
+		return nil
+	}
+
+	user, err := um.GetUser(ctx, username)
+	if err != nil {
+		return err
+	}
+
+	if !userHasCategory(user, *expenseRecurring.CategoryID) {
+		return models.ErrCategoryNotFound
+	}
+
+	return nil
+}
+
+// NewCategoryExpenseSummaryGetter returns the total expenses by category of a period, converted into the base currency
+// of the user with the exchange rates of the period. Categories with a budget also include how their spending compares
+// to it; nearLimitPercentage is the percentage of the budget from which a category is considered near the limit.
+func NewCategoryExpenseSummaryGetter(em ExpenseManager, um UserManager, erm ExchangeRateManager, pm PeriodManager, nearLimitPercentage int) func(ctx context.Context, username, periodID string) ([]*models.CategoryExpenseSummary, error) {
 	return func(ctx context.Context, username, periodID string) ([]*models.CategoryExpenseSummary, error) {
 		expenses, err := em.GetAllExpensesByPeriod(ctx, username, &models.QueryParameters{Period: periodID})
 		if err != nil {
 			return nil, err
 		}
 
+		user, err := um.GetUser(ctx, username)
+		if err != nil {
+			return nil, err
+		}
+
+		budgets, err := getPeriodCategoryBudgets(ctx, pm, user, periodID)
+		if err != nil {
+			return nil, err
+		}
+
+		converter, err := getPeriodConverter(ctx, erm, user, periodID)
+		if err != nil {
+			return nil, err
+		}
+
 		categoryExpenses := make([]*models.CategoryExpenseSummary, 0)
-		totalExpensesByCategory := make(map[string]float64)
+		totalExpensesByCategory := make(map[string]models.Money)
 
 		for _, expense := range expenses {
-			if expense.CategoryID != nil && expense.Amount != nil {
-				totalExpensesByCategory[*expense.CategoryID] += *expense.Amount
+			if expense.CategoryID == nil || expense.Amount == nil {
+				continue
 			}
+
+			amount, err := converter.toBase(*expense.Amount, expense.Currency)
+			if err != nil {
+				return nil, err
+			}
+
+			totalExpensesByCategory[*expense.CategoryID] += amount
 		}
 
 		for categoryID, total := range totalExpensesByCategory {
 			categoryExpenses = append(categoryExpenses, &models.CategoryExpenseSummary{
 				CategoryID: categoryID,
-				Total:      math.Round(total*100) / 100,
+				Total:      total,
 				Period:     periodID,
 			})
 		}
 
-		return categoryExpenses, nil
+		return setBudgetStatus(categoryExpenses, budgets, periodID, nearLimitPercentage), nil
 	}
 }
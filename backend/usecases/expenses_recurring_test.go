@@ -0,0 +1,252 @@
+package usecases
+
+import (
+	"context"
+	"github.com/JoelD7/money/backend/models"
+	"github.com/JoelD7/money/backend/shared/logger"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+type expenseRecurringManagerMock struct {
+	ExpenseRecurringManager
+	// expensesRecurring holds the recurring expenses by username and ID.
+	expensesRecurring map[string]map[string]models.ExpenseRecurring
+}
+
+func newExpenseRecurringManagerMock() *expenseRecurringManagerMock {
+	return &expenseRecurringManagerMock{
+		expensesRecurring: make(map[string]map[string]models.ExpenseRecurring),
+	}
+}
+
+func (m *expenseRecurringManagerMock) CreateExpenseRecurring(ctx context.Context, expenseRecurring *models.ExpenseRecurring) (*models.ExpenseRecurring, error) {
+	if _, ok := m.expensesRecurring[expenseRecurring.Username][expenseRecurring.ID]; ok {
+		return nil, models.ErrRecurringExpenseNameTaken
+	}
+
+	if m.expensesRecurring[expenseRecurring.Username] == nil {
+		m.expensesRecurring[expenseRecurring.Username] = make(map[string]models.ExpenseRecurring)
+	}
+
+	m.expensesRecurring[expenseRecurring.Username][expenseRecurring.ID] = *expenseRecurring
+
+	return expenseRecurring, nil
+}
+
+func (m *expenseRecurringManagerMock) GetExpenseRecurring(ctx context.Context, expenseRecurringID, username string) (*models.ExpenseRecurring, error) {
+	expenseRecurring, ok := m.expensesRecurring[username][expenseRecurringID]
+	if !ok {
+		return nil, models.ErrRecurringExpenseNotFound
+	}
+
+	return &expenseRecurring, nil
+}
+
+func (m *expenseRecurringManagerMock) GetExpensesRecurring(ctx context.Context, username string, params *models.QueryParameters) ([]*models.ExpenseRecurring, string, error) {
+	expensesRecurring := make([]*models.ExpenseRecurring, 0, len(m.expensesRecurring[username]))
+
+	for _, expenseRecurring := range m.expensesRecurring[username] {
+		expenseRecurring := expenseRecurring
+		expensesRecurring = append(expensesRecurring, &expenseRecurring)
+	}
+
+	if len(expensesRecurring) == 0 {
+		return nil, "", models.ErrRecurringExpensesNotFound
+	}
+
+	return expensesRecurring, "", nil
+}
+
+func (m *expenseRecurringManagerMock) UpdateExpenseRecurring(ctx context.Context, expenseRecurring *models.ExpenseRecurring) error {
+	if _, ok := m.expensesRecurring[expenseRecurring.Username][expenseRecurring.ID]; !ok {
+		return models.ErrRecurringExpenseNotFound
+	}
+
+	m.expensesRecurring[expenseRecurring.Username][expenseRecurring.ID] = *expenseRecurring
+
+	return nil
+}
+
+type userGetterMock struct {
+	UserManager
+	users map[string]*models.User
+}
+
+func (u *userGetterMock) GetUser(ctx context.Context, username string) (*models.User, error) {
+	user, ok := u.users[username]
+	if !ok {
+		return nil, models.ErrUserNotFound
+	}
+
+	return user, nil
+}
+
+type resourceCacheMock struct {
+	resources map[string]string
+}
+
+func (r *resourceCacheMock) AddResource(ctx context.Context, key string, resource interface{}, ttl int64) error {
+	r.resources[key] = string(resource.([]byte))
+
+	return nil
+}
+
+func (r *resourceCacheMock) GetResource(ctx context.Context, key string) (string, error) {
+	return r.resources[key], nil
+}
+
+func TestExpenseRecurringCreator(t *testing.T) {
+	c := require.New(t)
+
+	logger.InitLogger(logger.ConsoleImplementation)
+
+	ctx := context.Background()
+	em := newExpenseRecurringManagerMock()
+	um := &userGetterMock{users: map[string]*models.User{
+		"test":  {Username: "test", BaseCurrency: "EUR"},
+		"other": {Username: "other"},
+	}}
+
+	createExpenseRecurring := NewExpenseRecurringCreator(em, um, &resourceCacheMock{resources: make(map[string]string)})
+
+	created, err := createExpenseRecurring(ctx, "test", "key1", &models.ExpenseRecurring{Name: "Rent", Amount: 1000,
+		RecurringDay: 15})
+	c.NoError(err)
+	c.Equal("rent", created.ID)
+	c.Equal("test", created.Username)
+	c.Equal("EUR", created.Currency)
+	c.False(created.CreatedDate.IsZero())
+
+	stored, err := em.GetExpenseRecurring(ctx, "rent", "test")
+	c.NoError(err)
+	c.Equal(created.Amount, stored.Amount)
+
+	t.Run("Same idempotency key", func(t *testing.T) {
+		cached, err := createExpenseRecurring(ctx, "test", "key1", &models.ExpenseRecurring{Name: "Rent", Amount: 1000,
+			RecurringDay: 15})
+		c.NoError(err)
+		c.Equal(created.ID, cached.ID)
+	})
+
+	t.Run("Name taken", func(t *testing.T) {
+		_, err = createExpenseRecurring(ctx, "test", "key2", &models.ExpenseRecurring{Name: "RENT", Amount: 500,
+			RecurringDay: 1})
+		c.ErrorIs(err, models.ErrRecurringExpenseNameTaken)
+	})
+
+	t.Run("Same name for another user", func(t *testing.T) {
+		_, err = createExpenseRecurring(ctx, "other", "key3", &models.ExpenseRecurring{Name: "Rent", Amount: 500,
+			RecurringDay: 1, Currency: "DOP"})
+		c.NoError(err)
+
+		stored, err = em.GetExpenseRecurring(ctx, "rent", "other")
+		c.NoError(err)
+		c.Equal("DOP", stored.Currency)
+	})
+
+	t.Run("Category not found", func(t *testing.T) {
+		categoryID := "CTG2"
+
+		_, err = createExpenseRecurring(ctx, "test", "key5", &models.ExpenseRecurring{Name: "Gym", Amount: 500,
+			CategoryID: &categoryID, RecurringDay: 1})
+		c.ErrorIs(err, models.ErrCategoryNotFound)
+
+		_, err = em.GetExpenseRecurring(ctx, "gym", "test")
+		c.ErrorIs(err, models.ErrRecurringExpenseNotFound)
+	})
+
+	t.Run("Missing user", func(t *testing.T) {
+		_, err = createExpenseRecurring(ctx, "unknown", "key4", &models.ExpenseRecurring{Name: "Gym", Amount: 500,
+			RecurringDay: 1})
+		c.ErrorIs(err, models.ErrUserNotFound)
+	})
+}
+
+func TestExpenseRecurringGetters(t *testing.T) {
+	c := require.New(t)
+
+	ctx := context.Background()
+	em := newExpenseRecurringManagerMock()
+
+	_, err := em.CreateExpenseRecurring(ctx, &models.ExpenseRecurring{ID: "rent", Username: "test", Name: "Rent",
+		Amount: 1000, RecurringDay: 15})
+	c.NoError(err)
+
+	getExpenseRecurring := NewExpenseRecurringGetter(em)
+	getExpensesRecurring := NewExpensesRecurringGetter(em)
+
+	expenseRecurring, err := getExpenseRecurring(ctx, "rent", "test")
+	c.NoError(err)
+	c.Equal("Rent", expenseRecurring.Name)
+
+	_, err = getExpenseRecurring(ctx, "rent", "other")
+	c.ErrorIs(err, models.ErrRecurringExpenseNotFound)
+
+	expensesRecurring, _, err := getExpensesRecurring(ctx, "test", &models.QueryParameters{PageSize: 10})
+	c.NoError(err)
+	c.Len(expensesRecurring, 1)
+
+	_, _, err = getExpensesRecurring(ctx, "other", &models.QueryParameters{})
+	c.ErrorIs(err, models.ErrRecurringExpensesNotFound)
+
+	_, _, err = getExpensesRecurring(ctx, "test", &models.QueryParameters{PageSize: -1})
+	c.ErrorIs(err, models.ErrInvalidPageSize)
+}
+
+func TestExpenseRecurringUpdater(t *testing.T) {
+	c := require.New(t)
+
+	ctx := context.Background()
+	em := newExpenseRecurringManagerMock()
+	categoryID := "CTG1"
+
+	_, err := em.CreateExpenseRecurring(ctx, &models.ExpenseRecurring{ID: "rent", Username: "test", Name: "Rent",
+		Amount: 1000, Currency: "EUR", RecurringDay: 15})
+	c.NoError(err)
+
+	um := &userGetterMock{users: map[string]*models.User{
+		"test": {Username: "test", Categories: []*models.Category{{ID: categoryID}}},
+	}}
+
+	updateExpenseRecurring := NewExpenseRecurringUpdater(em, um)
+
+	updated, err := updateExpenseRecurring(ctx, "rent", "test", &models.ExpenseRecurring{Name: "Other name",
+		Amount: 1200, CategoryID: &categoryID, RecurringDay: 1, Notes: "Raised", Paused: true})
+	c.NoError(err)
+	c.Equal("Rent", updated.Name)
+	c.Equal("EUR", updated.Currency)
+	c.False(updated.UpdateDate.IsZero())
+
+	stored, err := em.GetExpenseRecurring(ctx, "rent", "test")
+	c.NoError(err)
+	c.Equal(updated.Amount, stored.Amount)
+	c.Equal(&categoryID, stored.CategoryID)
+	c.Equal(1, stored.RecurringDay)
+	c.Equal("Raised", stored.Notes)
+	c.True(stored.Paused)
+
+	_, err = updateExpenseRecurring(ctx, "gym", "test", &models.ExpenseRecurring{Amount: 500, RecurringDay: 1})
+	c.ErrorIs(err, models.ErrRecurringExpenseNotFound)
+
+	t.Run("Category not found", func(t *testing.T) {
+		otherCategoryID := "CTG2"
+
+		_, err = updateExpenseRecurring(ctx, "rent", "test", &models.ExpenseRecurring{Amount: 500,
+			CategoryID: &otherCategoryID, RecurringDay: 1})
+		c.ErrorIs(err, models.ErrCategoryNotFound)
+
+		stored, err = em.GetExpenseRecurring(ctx, "rent", "test")
+		c.NoError(err)
+		c.Equal(&categoryID, stored.CategoryID)
+	})
+
+	t.Run("Recurring expense of another user", func(t *testing.T) {
+		_, err = updateExpenseRecurring(ctx, "rent", "other", &models.ExpenseRecurring{Amount: 500, RecurringDay: 1})
+		c.ErrorIs(err, models.ErrRecurringExpenseNotFound)
+
+		stored, err = em.GetExpenseRecurring(ctx, "rent", "test")
+		c.NoError(err)
+		c.True(stored.Paused)
+	})
+}
@@ -0,0 +1,111 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"github.com/JoelD7/money/backend/models"
+)
+
+const (
+	exportPageSize = 100
+)
+
+// NewUserDataExporter writes all the data of a user to the writer, one page of each repository at a time, so that the
+// data of the user isn't held in memory all at once.
+func NewUserDataExporter(um UserManager, pm PeriodManager, em ExpenseManager, im IncomeRepository, sm SavingsManager, sgm SavingGoalManager, erm ExpenseRecurringManager) func(ctx context.Context, username string, writer UserDataWriter) error {
+	return func(ctx context.Context, username string, writer UserDataWriter) error {
+		user, err := um.GetUser(ctx, username)
+		if err != nil {
+			return err
+		}
+
+		err = writer.WriteUser(user)
+		if err != nil {
+			return err
+		}
+
+		err = forEachPage(models.ErrPeriodsNotFound, func(startKey string) ([]*models.Period, string, error) {
+			return pm.GetPeriods(ctx, username, startKey, exportPageSize, false)
+		}, writer.WritePeriods)
+		if err != nil {
+			return err
+		}
+
+		err = forEachPage(models.ErrExpensesNotFound, func(startKey string) ([]*models.Expense, string, error) {
+			return em.GetExpenses(ctx, username, &models.QueryParameters{StartKey: startKey, PageSize: exportPageSize})
+		}, writer.WriteExpenses)
+		if err != nil {
+			return err
+		}
+
+		err = forEachPage(models.ErrIncomeNotFound, func(startKey string) ([]*models.Income, string, error) {
+			return im.GetAllIncome(ctx, username, &models.QueryParameters{StartKey: startKey, PageSize: exportPageSize})
+		}, writer.WriteIncome)
+		if err != nil {
+			return err
+		}
+
+		err = forEachPage(models.ErrSavingsNotFound, func(startKey string) ([]*models.Saving, string, error) {
+			return sm.GetSavings(ctx, username, &models.QueryParameters{StartKey: startKey, PageSize: exportPageSize})
+		}, writer.WriteSavings)
+		if err != nil {
+			return err
+		}
+
+		err = forEachPage(models.ErrSavingGoalsNotFound, func(startKey string) ([]*models.SavingGoal, string, error) {
+			return sgm.GetSavingGoals(ctx, username, &models.QueryParameters{StartKey: startKey, PageSize: exportPageSize})
+		}, writer.WriteSavingGoals)
+		if err != nil {
+			return err
+		}
+
+		return forEachPage(models.ErrRecurringExpensesNotFound, func(startKey string) ([]*models.ExpenseRecurring, string, error) {
+			return erm.GetExpensesRecurring(ctx, username, &models.QueryParameters{StartKey: startKey, PageSize: exportPageSize})
+		}, writer.WriteExpensesRecurring)
+	}
+}
+
+// getAllItems calls getPage until there are no more pages. notFoundErr is the error the repository returns when the
+// user has no items at all, in which case an empty list is returned.
+func getAllItems[T any](notFoundErr error, getPage func(startKey string) ([]T, string, error)) ([]T, error) {
+	items := make([]T, 0)
+
+	err := forEachPage(notFoundErr, getPage, func(page []T) error {
+		items = append(items, page...)
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return items, nil
+}
+
+// forEachPage calls getPage until there are no more pages, handing each page to handlePage. notFoundErr is the error the
+// repository returns when the user has no items at all, in which case handlePage isn't called.
+func forEachPage[T any](notFoundErr error, getPage func(startKey string) ([]T, string, error), handlePage func(page []T) error) error {
+	startKey := ""
+
+	for {
+		page, nextKey, err := getPage(startKey)
+		if errors.Is(err, notFoundErr) || errors.Is(err, models.ErrNoMoreItemsToBeRetrieved) {
+			return nil
+		}
+
+		if err != nil {
+			return err
+		}
+
+		err = handlePage(page)
+		if err != nil {
+			return err
+		}
+
+		if nextKey == "" || len(page) == 0 {
+			return nil
+		}
+
+		startKey = nextKey
+	}
+}
@@ -0,0 +1,277 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"github.com/JoelD7/money/backend/models"
+	"time"
+)
+
+const (
+	importPeriodsPageSize = 50
+)
+
+// NewExpensesImporter creates expenses from the debits of a bank statement. Every expense is assigned to the period
+// whose date range contains the transaction date, and transactions that match an existing expense by date, amount
+// and name are ignored. If dryRun is true, the proposed expenses are returned without being saved.
+func NewExpensesImporter(em ExpenseManager, pm PeriodManager, um UserManager) func(ctx context.Context, username string, transactions []*models.Transaction, dryRun bool) (*models.ImportResult, error) {
+	return func(ctx context.Context, username string, transactions []*models.Transaction, dryRun bool) (*models.ImportResult, error) {
+		result := &models.ImportResult{DryRun: dryRun}
+
+		transactionsByPeriod, skipped, err := groupTransactionsByPeriod(ctx, pm, username, models.TransactionTypeDebit, transactions)
+		if err != nil {
+			return nil, err
+		}
+
+		result.Skipped = skipped
+		newExpenses := make([]*models.Expense, 0)
+
+		for period, periodTransactions := range transactionsByPeriod {
+			fingerprints, err := getExpenseFingerprints(ctx, em, username, period.ID)
+			if err != nil {
+				return nil, err
+			}
+
+			for _, transaction := range periodTransactions {
+				fingerprint := transaction.Fingerprint()
+				if _, ok := fingerprints[fingerprint]; ok {
+					result.Duplicates++
+					continue
+				}
+
+				fingerprints[fingerprint] = struct{}{}
+				newExpenses = append(newExpenses, toImportedExpense(username, period, transaction))
+			}
+		}
+
+		err = setDefaultCurrency(ctx, um, username, toCurrencyHolders(newExpenses)...)
+		if err != nil {
+			return nil, err
+		}
+
+		autoCategorizeExpenses(ctx, um, username, newExpenses...)
+
+		result.Imported = len(newExpenses)
+		result.Expenses = newExpenses
+
+		if dryRun || len(newExpenses) == 0 {
+			return result, nil
+		}
+
+		for _, expense := range newExpenses {
+			expense.ExpenseID = generateDynamoID("EX")
+		}
+
+		err = em.BatchCreateExpenses(ctx, newExpenses)
+		if err != nil {
+			return nil, err
+		}
+
+		return result, nil
+	}
+}
+
+// NewIncomeImporter creates income from the credits of a bank statement. It follows the same rules as
+// NewExpensesImporter.
+func NewIncomeImporter(im IncomeRepository, pm PeriodManager, um UserManager, cache IncomePeriodCacheManager) func(ctx context.Context, username string, transactions []*models.Transaction, dryRun bool) (*models.ImportResult, error) {
+	return func(ctx context.Context, username string, transactions []*models.Transaction, dryRun bool) (*models.ImportResult, error) {
+		result := &models.ImportResult{DryRun: dryRun}
+
+		transactionsByPeriod, skipped, err := groupTransactionsByPeriod(ctx, pm, username, models.TransactionTypeCredit, transactions)
+		if err != nil {
+			return nil, err
+		}
+
+		result.Skipped = skipped
+		newIncome := make([]*models.Income, 0)
+		importedPeriods := make([]string, 0, len(transactionsByPeriod))
+
+		for period, periodTransactions := range transactionsByPeriod {
+			fingerprints, err := getIncomeFingerprints(ctx, im, username, period.ID)
+			if err != nil {
+				return nil, err
+			}
+
+			periodIncome := 0
+
+			for _, transaction := range periodTransactions {
+				fingerprint := transaction.Fingerprint()
+				if _, ok := fingerprints[fingerprint]; ok {
+					result.Duplicates++
+					continue
+				}
+
+				fingerprints[fingerprint] = struct{}{}
+				newIncome = append(newIncome, toImportedIncome(username, period, transaction))
+				periodIncome++
+			}
+
+			if periodIncome > 0 {
+				importedPeriods = append(importedPeriods, period.ID)
+			}
+		}
+
+		err = setDefaultCurrency(ctx, um, username, toCurrencyHolders(newIncome)...)
+		if err != nil {
+			return nil, err
+		}
+
+		result.Imported = len(newIncome)
+		result.Income = newIncome
+
+		if dryRun || len(newIncome) == 0 {
+			return result, nil
+		}
+
+		for _, income := range newIncome {
+			income.IncomeID = generateDynamoID("IN")
+		}
+
+		err = im.BatchCreateIncome(ctx, newIncome)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, periodID := range importedPeriods {
+			syncIncomePeriodsCache(ctx, username, im, cache, "", periodID)
+		}
+
+		return result, nil
+	}
+}
+
+// groupTransactionsByPeriod groups the transactions of the given type by the period that contains their date. It also
+// returns the number of transactions that were skipped, either because they are of another type or because no period
+// contains them.
+func groupTransactionsByPeriod(ctx context.Context, pm PeriodManager, username string, transactionType models.TransactionType, transactions []*models.Transaction) (map[*models.Period][]*models.Transaction, int, error) {
+	periods, err := getAllPeriods(ctx, pm, username)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	transactionsByPeriod := make(map[*models.Period][]*models.Transaction)
+	skipped := 0
+
+	for _, transaction := range transactions {
+		if transaction.Type != transactionType {
+			skipped++
+			continue
+		}
+
+		period := findPeriodByDate(periods, transaction.Date)
+		if period == nil {
+			skipped++
+			continue
+		}
+
+		transactionsByPeriod[period] = append(transactionsByPeriod[period], transaction)
+	}
+
+	return transactionsByPeriod, skipped, nil
+}
+
+func getAllPeriods(ctx context.Context, pm PeriodManager, username string) ([]*models.Period, error) {
+	periods, err := getAllItems(models.ErrPeriodsNotFound, func(startKey string) ([]*models.Period, string, error) {
+		return pm.GetPeriods(ctx, username, startKey, importPeriodsPageSize, false)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(periods) == 0 {
+		return nil, models.ErrPeriodsNotFound
+	}
+
+	return periods, nil
+}
+
+// findPeriodByDate returns the period whose date range contains the date. Only the date part is compared, so a
+// transaction made on the last day of a period belongs to it regardless of the time.
+func findPeriodByDate(periods []*models.Period, date time.Time) *models.Period {
+	day := date.Format(time.DateOnly)
+
+	for _, period := range periods {
+		if day >= period.StartDate.Format(time.DateOnly) && day <= period.EndDate.Format(time.DateOnly) {
+			return period
+		}
+	}
+
+	return nil
+}
+
+func getExpenseFingerprints(ctx context.Context, em ExpenseManager, username, periodID string) (map[string]struct{}, error) {
+	fingerprints := make(map[string]struct{})
+
+	expenses, err := em.GetAllExpensesByPeriod(ctx, username, &models.QueryParameters{Period: periodID})
+	if errors.Is(err, models.ErrExpensesNotFound) {
+		return fingerprints, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, expense := range expenses {
+		fingerprints[models.BuildTransactionFingerprint(expense.CreatedDate, expense.GetAmount(), expense.GetName())] = struct{}{}
+	}
+
+	return fingerprints, nil
+}
+
+func getIncomeFingerprints(ctx context.Context, im IncomeRepository, username, periodID string) (map[string]struct{}, error) {
+	fingerprints := make(map[string]struct{})
+
+	income, err := im.GetAllIncomeByPeriod(ctx, username, &models.QueryParameters{Period: periodID})
+	if errors.Is(err, models.ErrIncomeNotFound) {
+		return fingerprints, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, i := range income {
+		fingerprints[models.BuildTransactionFingerprint(i.CreatedDate, i.GetAmount(), i.GetName())] = struct{}{}
+	}
+
+	return fingerprints, nil
+}
+
+// toImportedExpense builds an expense from a transaction. The transaction date is used as the created date, as that's
+// the date the expense actually happened.
+func toImportedExpense(username string, period *models.Period, transaction *models.Transaction) *models.Expense {
+	amount := transaction.Amount
+	name := transaction.Name
+
+	return &models.Expense{
+		Username:    username,
+		Amount:      &amount,
+		Name:        &name,
+		Notes:       transaction.Notes,
+		CreatedDate: transaction.Date,
+		PeriodID:    period.ID,
+		PeriodName:  period.GetName(),
+	}
+}
+
+func toImportedIncome(username string, period *models.Period, transaction *models.Transaction) *models.Income {
+	amount := transaction.Amount
+	name := transaction.Name
+	periodID := period.ID
+
+	income := &models.Income{
+		Username:    username,
+		Amount:      &amount,
+		Name:        &name,
+		CreatedDate: transaction.Date,
+		PeriodID:    &periodID,
+		PeriodName:  period.GetName(),
+	}
+
+	if transaction.Notes != "" {
+		notes := transaction.Notes
+		income.Notes = &notes
+	}
+
+	return income
+}
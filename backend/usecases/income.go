@@ -5,10 +5,12 @@ import (
 	"errors"
 	"fmt"
 	"github.com/JoelD7/money/backend/models"
+	"github.com/JoelD7/money/backend/shared/logger"
+	"github.com/JoelD7/money/backend/shared/validate"
 	"time"
 )
 
-func NewIncomeCreator(im IncomeRepository, pm PeriodManager, cache ResourceCacheManager) func(ctx context.Context, username, idempotencyKey string, income *models.Income) (*models.Income, error) {
+func NewIncomeCreator(im IncomeRepository, pm PeriodManager, um UserManager, cache ResourceCacheManager) func(ctx context.Context, username, idempotencyKey string, income *models.Income) (*models.Income, error) {
 	return func(ctx context.Context, username, idempotencyKey string, income *models.Income) (*models.Income, error) {
 		return CreateResource(ctx, cache, idempotencyKey, func() (*models.Income, error) {
 			err := validateIncomePeriod(ctx, username, income, pm)
@@ -20,6 +22,11 @@ func NewIncomeCreator(im IncomeRepository, pm PeriodManager, cache ResourceCache
 			income.Username = username
 			income.CreatedDate = time.Now()
 
+			err = setDefaultCurrency(ctx, um, username, income)
+			if err != nil {
+				return nil, err
+			}
+
 			newIncome, err := im.CreateIncome(ctx, income)
 			if err != nil {
 				return nil, err
@@ -119,6 +126,226 @@ func NewAllIncomeGetter(repository IncomeRepository, cache IncomePeriodCacheMana
 	}
 }
 
+func NewIncomeUpdater(im IncomeRepository, pm PeriodManager, cache IncomePeriodCacheManager) func(ctx context.Context, incomeID, username string, income *models.Income) (*models.Income, error) {
+	return func(ctx context.Context, incomeID, username string, income *models.Income) (*models.Income, error) {
+		currentIncome, err := im.GetIncome(ctx, username, incomeID)
+		if errors.Is(err, models.ErrIncomeNotFound) {
+			return nil, models.ErrUpdateIncomeNotFound
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("getting income to update failed: %w", err)
+		}
+
+		err = validateIncomePeriod(ctx, username, income, pm)
+		if err != nil {
+			return nil, err
+		}
+
+		previousPeriod := currentIncome.GetPeriodID()
+
+		setUpdatedIncomeFields(currentIncome, income)
+		currentIncome.UpdatedDate = time.Now()
+
+		err = im.UpdateIncome(ctx, currentIncome)
+		if err != nil {
+			return nil, err
+		}
+
+		syncIncomePeriodsCache(ctx, username, im, cache, previousPeriod, currentIncome.GetPeriodID())
+
+		err = setEntitiesPeriods(ctx, pm, currentIncome)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't set periods for income: %w", err)
+		}
+
+		return currentIncome, nil
+	}
+}
+
+// NewIncomePatcher applies a JSON merge patch to an income and validates the result with the rules of its creation.
+func NewIncomePatcher(im IncomeRepository, pm PeriodManager, um UserManager, cache IncomePeriodCacheManager) func(ctx context.Context, incomeID, username string, patch []byte) (*models.Income, error) {
+	return func(ctx context.Context, incomeID, username string, patch []byte) (*models.Income, error) {
+		currentIncome, err := im.GetIncome(ctx, username, incomeID)
+		if errors.Is(err, models.ErrIncomeNotFound) {
+			return nil, models.ErrUpdateIncomeNotFound
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("getting income to patch failed: %w", err)
+		}
+
+		income, err := applyMergePatch(currentIncome, patch)
+		if err != nil {
+			return nil, err
+		}
+
+		income.IncomeID = currentIncome.IncomeID
+		income.Username = currentIncome.Username
+		income.CreatedDate = currentIncome.CreatedDate
+		income.PeriodName = ""
+		income.PeriodUser = nil
+		income.UpdatedDate = time.Now()
+
+		err = setDefaultCurrency(ctx, um, username, income)
+		if err != nil {
+			return nil, err
+		}
+
+		err = validate.Income(income)
+		if err != nil {
+			return nil, err
+		}
+
+		err = validateIncomePeriod(ctx, username, income, pm)
+		if err != nil {
+			return nil, err
+		}
+
+		err = im.UpdateIncome(ctx, income)
+		if err != nil {
+			return nil, err
+		}
+
+		syncIncomePeriodsCache(ctx, username, im, cache, currentIncome.GetPeriodID(), income.GetPeriodID())
+
+		err = setEntitiesPeriods(ctx, pm, income)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't set periods for income: %w", err)
+		}
+
+		return income, nil
+	}
+}
+
+// NewIncomeDeleter moves the income to the trash. Its period is removed from the income periods cache if it has no
+// income left.
+func NewIncomeDeleter(im IncomeRepository, tm TrashManager, cache IncomePeriodCacheManager) func(ctx context.Context, incomeID, username string) error {
+	return func(ctx context.Context, incomeID, username string) error {
+		income, err := im.GetIncome(ctx, username, incomeID)
+		if errors.Is(err, models.ErrIncomeNotFound) {
+			return models.ErrDeleteIncomeNotFound
+		}
+
+		if err != nil {
+			return fmt.Errorf("getting income to delete failed: %w", err)
+		}
+
+		err = moveToTrash(ctx, tm, username, models.NewIncomeTrashItem(income), func() error {
+			return im.DeleteIncome(ctx, incomeID, username)
+		})
+		if err != nil {
+			return err
+		}
+
+		syncIncomePeriodsCache(ctx, username, im, cache, income.GetPeriodID(), "")
+
+		return nil
+	}
+}
+
+// setUpdatedIncomeFields copies the fields present in the update request to the stored income. Fields that weren't
+// sent keep their current value.
+func setUpdatedIncomeFields(current, update *models.Income) {
+	if update.Amount != nil {
+		current.Amount = update.Amount
+	}
+
+	if update.Currency != "" {
+		current.Currency = update.Currency
+	}
+
+	if update.Name != nil {
+		current.Name = update.Name
+	}
+
+	if update.Notes != nil {
+		current.Notes = update.Notes
+	}
+
+	if update.PeriodID != nil {
+		current.PeriodID = update.PeriodID
+	}
+}
+
+// syncIncomePeriodsCache keeps the cached set of income periods consistent after an income has been moved from
+// previousPeriod to newPeriod. An empty newPeriod means that the income was deleted.
+// The income is already persisted when this function is called, so failures are logged instead of returned.
+func syncIncomePeriodsCache(ctx context.Context, username string, im IncomeRepository, cache IncomePeriodCacheManager, previousPeriod, newPeriod string) {
+	if previousPeriod == newPeriod {
+		return
+	}
+
+	// Loading the periods first makes sure the cache is populated from the database, which already reflects this
+	// change. Otherwise, adding a single period would leave the cache with an incomplete set.
+	incomePeriods, err := getIncomePeriods(ctx, username, im, cache)
+	if errors.Is(err, models.ErrIncomeNotFound) {
+		return
+	}
+
+	if err != nil {
+		logger.Error("get_income_periods_failed", err, models.Any("user_data", map[string]interface{}{
+			"s_username": username,
+		}))
+
+		return
+	}
+
+	cachedPeriods := make(map[string]struct{}, len(incomePeriods))
+	for _, period := range incomePeriods {
+		cachedPeriods[period] = struct{}{}
+	}
+
+	if _, ok := cachedPeriods[newPeriod]; !ok && newPeriod != "" {
+		err = cache.AddIncomePeriods(ctx, username, []string{newPeriod})
+		if err != nil {
+			logger.Error("add_income_periods_failed", err, models.Any("user_data", map[string]interface{}{
+				"s_username": username,
+				"s_period":   newPeriod,
+			}))
+		}
+	}
+
+	if _, ok := cachedPeriods[previousPeriod]; !ok || previousPeriod == "" {
+		return
+	}
+
+	hasIncome, err := periodHasIncome(ctx, username, previousPeriod, im)
+	if err != nil {
+		logger.Error("check_period_income_failed", err, models.Any("user_data", map[string]interface{}{
+			"s_username": username,
+			"s_period":   previousPeriod,
+		}))
+
+		return
+	}
+
+	if hasIncome {
+		return
+	}
+
+	err = cache.DeleteIncomePeriods(ctx, username, previousPeriod)
+	if err != nil {
+		logger.Error("delete_income_periods_failed", err, models.Any("user_data", map[string]interface{}{
+			"s_username": username,
+			"s_period":   previousPeriod,
+		}))
+	}
+}
+
+func periodHasIncome(ctx context.Context, username, periodID string, im IncomeRepository) (bool, error) {
+	_, _, err := im.GetIncomeByPeriod(ctx, username, &models.QueryParameters{Period: periodID, PageSize: 1})
+	if errors.Is(err, models.ErrIncomeNotFound) {
+		return false, nil
+	}
+
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
 func validateIncomePeriod(ctx context.Context, username string, income *models.Income, pm PeriodManager) error {
 	if income == nil || income.PeriodID == nil {
 		return nil
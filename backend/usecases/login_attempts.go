@@ -0,0 +1,220 @@
+package usecases
+
+import (
+	"context"
+	"crypto/subtle"
+	"github.com/JoelD7/money/backend/models"
+	"github.com/JoelD7/money/backend/shared/env"
+	"github.com/JoelD7/money/backend/shared/logger"
+	"strings"
+	"time"
+)
+
+const (
+	defaultLoginBackoffAttempts   = 3
+	defaultLoginMaxAttempts       = 10
+	defaultLoginIPBackoffAttempts = 20
+	defaultLoginIPMaxAttempts     = 100
+	defaultLoginLockoutDuration   = 900
+	defaultLoginAttemptsWindow    = 900
+
+	// maxLoginBackoffExponent keeps the backoff from overflowing. The lockout duration caps it way before anyway.
+	maxLoginBackoffExponent = 30
+)
+
+// loginAttemptKey is what the failed logins are counted by, along with how many of them are allowed.
+type loginAttemptKey struct {
+	key string
+	// backoffAttempts is how many failed logins are allowed before every new one has to wait.
+	backoffAttempts int64
+	// maxAttempts is how many failed logins lock the key out.
+	maxAttempts int64
+}
+
+func (k loginAttemptKey) GetKey() string {
+	return "login_attempts"
+}
+
+func (k loginAttemptKey) GetValue() (interface{}, error) {
+	return map[string]interface{}{
+		"s_key": k.key,
+	}, nil
+}
+
+func getLoginAttemptKeys(username, sourceIP string) []loginAttemptKey {
+	keys := make([]loginAttemptKey, 0, 2)
+
+	if username != "" {
+		keys = append(keys, loginAttemptKey{
+			key:             "username:" + strings.ToLower(strings.TrimSpace(username)),
+			backoffAttempts: int64(env.GetInt("LOGIN_BACKOFF_ATTEMPTS", defaultLoginBackoffAttempts)),
+			maxAttempts:     int64(env.GetInt("LOGIN_MAX_ATTEMPTS", defaultLoginMaxAttempts)),
+		})
+	}
+
+	if sourceIP != "" {
+		keys = append(keys, loginAttemptKey{
+			key:             "ip:" + sourceIP,
+			backoffAttempts: int64(env.GetInt("LOGIN_IP_BACKOFF_ATTEMPTS", defaultLoginIPBackoffAttempts)),
+			maxAttempts:     int64(env.GetInt("LOGIN_IP_MAX_ATTEMPTS", defaultLoginIPMaxAttempts)),
+		})
+	}
+
+	return keys
+}
+
+// getMFAAttemptKey returns the key the failed MFA codes of the user are counted by. It's apart from the username key,
+// which is reset by every right password, so that knowing the password doesn't allow unlimited guesses of the code.
+func getMFAAttemptKey(username string) loginAttemptKey {
+	return loginAttemptKey{
+		key:             "mfa:" + strings.ToLower(strings.TrimSpace(username)),
+		backoffAttempts: int64(env.GetInt("LOGIN_BACKOFF_ATTEMPTS", defaultLoginBackoffAttempts)),
+		maxAttempts:     int64(env.GetInt("LOGIN_MAX_ATTEMPTS", defaultLoginMaxAttempts)),
+	}
+}
+
+// checkLoginBlocked returns a *models.LoginBlockedError with the latest block of the keys, if any of them is blocked.
+func checkLoginBlocked(ctx context.Context, loginAttempts LoginAttemptCache, keys []loginAttemptKey) error {
+	var blockedUntil time.Time
+
+	for _, key := range keys {
+		until, err := loginAttempts.GetLoginBlock(ctx, key.key)
+		if err != nil {
+			logger.Error("login_block_fetching_failed", err, key)
+
+			return err
+		}
+
+		if until.After(blockedUntil) {
+			blockedUntil = until
+		}
+	}
+
+	if blockedUntil.After(time.Now()) {
+		return &models.LoginBlockedError{Until: blockedUntil}
+	}
+
+	return nil
+}
+
+// recordFailedLogin counts a failed login of the keys. Once a key has more failures than its backoff attempts, its
+// logins are blocked for a second, twice as long after every new failure, and when it reaches the max attempts it's
+// locked out for LOGIN_LOCKOUT_DURATION seconds. The errors are only logged, as the login failed already.
+func recordFailedLogin(ctx context.Context, loginAttempts LoginAttemptCache, keys []loginAttemptKey) {
+	window := int64(env.GetInt("LOGIN_ATTEMPTS_WINDOW", defaultLoginAttemptsWindow))
+	lockoutDuration := time.Duration(env.GetInt("LOGIN_LOCKOUT_DURATION", defaultLoginLockoutDuration)) * time.Second
+
+	for _, key := range keys {
+		failures, err := loginAttempts.AddFailedLogin(ctx, key.key, window)
+		if err != nil {
+			logger.Error("failed_login_recording_failed", err, key)
+
+			continue
+		}
+
+		if failures <= key.backoffAttempts {
+			continue
+		}
+
+		blockDuration := getLoginBackoff(failures-key.backoffAttempts, lockoutDuration)
+
+		if failures >= key.maxAttempts {
+			blockDuration = lockoutDuration
+		}
+
+		until := time.Now().Add(blockDuration)
+
+		err = loginAttempts.BlockLogin(ctx, key.key, until)
+		if err != nil {
+			logger.Error("login_blocking_failed", err, key)
+
+			continue
+		}
+
+		if failures >= key.maxAttempts {
+			logger.Warning("login_locked_out", models.ErrTooManyLoginAttempts, models.Any("login_attempts", map[string]interface{}{
+				"s_key":          key.key,
+				"i_failures":     failures,
+				"t_locked_until": until,
+			}))
+		}
+	}
+}
+
+// getLoginBackoff returns how long the logins are blocked after the nth failed login over the backoff attempts.
+func getLoginBackoff(n int64, lockoutDuration time.Duration) time.Duration {
+	if n > maxLoginBackoffExponent {
+		return lockoutDuration
+	}
+
+	backoff := time.Second << (n - 1)
+	if backoff > lockoutDuration {
+		return lockoutDuration
+	}
+
+	return backoff
+}
+
+func resetLoginAttempts(ctx context.Context, loginAttempts LoginAttemptCache, keys []loginAttemptKey) {
+	for _, key := range keys {
+		err := loginAttempts.DeleteLoginAttempts(ctx, key.key)
+		if err != nil {
+			logger.Error("login_attempts_reset_failed", err, key)
+		}
+	}
+}
+
+// NewLoginUnlocker lifts the block and clears the failed logins of a username, a source IP or both. It's meant for
+// admins, who have to send the key stored in the ADMIN_KEY_SECRET secret.
+func NewLoginUnlocker(secretManager SecretManager, loginAttempts LoginAttemptCache) func(ctx context.Context, adminKey, username, sourceIP string) error {
+	return func(ctx context.Context, adminKey, username, sourceIP string) error {
+		err := validateAdminKey(ctx, secretManager, adminKey)
+		if err != nil {
+			logger.Warning("admin_key_validation_failed", err, nil)
+
+			return err
+		}
+
+		if username == "" && sourceIP == "" {
+			return models.ErrMissingLoginUnlockTarget
+		}
+
+		keys := getLoginAttemptKeys(username, sourceIP)
+		if username != "" {
+			keys = append(keys, getMFAAttemptKey(username))
+		}
+
+		for _, key := range keys {
+			err = loginAttempts.DeleteLoginAttempts(ctx, key.key)
+			if err != nil {
+				logger.Error("login_unlock_failed", err, key)
+
+				return err
+			}
+
+			logger.Info("login_unlocked", key)
+		}
+
+		return nil
+	}
+}
+
+// validateAdminKey compares the key in constant time, so that it can't be guessed by timing the responses. The admin
+// endpoints are disabled if ADMIN_KEY_SECRET isn't set.
+func validateAdminKey(ctx context.Context, secretManager SecretManager, adminKey string) error {
+	secretName := env.GetString("ADMIN_KEY_SECRET", "")
+	if secretName == "" || adminKey == "" {
+		return models.ErrInvalidAdminKey
+	}
+
+	expectedKey, err := secretManager.GetSecret(ctx, secretName)
+	if err != nil {
+		return err
+	}
+
+	if subtle.ConstantTimeCompare([]byte(expectedKey), []byte(adminKey)) != 1 {
+		return models.ErrInvalidAdminKey
+	}
+
+	return nil
+}
@@ -0,0 +1,97 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"github.com/JoelD7/money/backend/models"
+	"github.com/JoelD7/money/backend/shared/logger"
+	"github.com/JoelD7/money/backend/shared/secrets"
+	"github.com/JoelD7/money/backend/storage/cache"
+	"github.com/JoelD7/money/backend/storage/users"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestUserAuthenticatorLoginAttempts(t *testing.T) {
+	c := require.New(t)
+
+	logger.InitLogger(logger.ConsoleImplementation)
+	t.Setenv("LOGIN_BACKOFF_ATTEMPTS", "2")
+	t.Setenv("LOGIN_MAX_ATTEMPTS", "4")
+	t.Setenv("LOGIN_IP_BACKOFF_ATTEMPTS", "7")
+	t.Setenv("LOGIN_IP_MAX_ATTEMPTS", "8")
+	t.Setenv("LOGIN_LOCKOUT_DURATION", "600")
+
+	ctx := context.Background()
+	userRepo := users.NewMemoryRepository()
+	loginAttempts := cache.NewMemoryCache()
+	newPasswordTestUser(c, userRepo, "password")
+
+	authenticate := NewUserAuthenticator(userRepo, loginAttempts)
+
+	for i := 0; i < 2; i++ {
+		_, err := authenticate(ctx, "test@gmail.com", "wrong", "1.1.1.1")
+		c.ErrorIs(err, models.ErrWrongCredentials)
+	}
+
+	_, err := authenticate(ctx, "test@gmail.com", "password", "1.1.1.1")
+	c.NoError(err)
+
+	// The successful login reset the failures of the username, so the backoff starts after two more of them.
+	for i := 0; i < 3; i++ {
+		_, err = authenticate(ctx, "test@gmail.com", "wrong", "1.1.1.1")
+		c.ErrorIs(err, models.ErrWrongCredentials)
+	}
+
+	var blockedErr *models.LoginBlockedError
+
+	_, err = authenticate(ctx, "test@gmail.com", "password", "1.1.1.1")
+	c.ErrorIs(err, models.ErrTooManyLoginAttempts)
+	c.True(errors.As(err, &blockedErr))
+	c.Equal(int64(1), blockedErr.RetryAfter())
+
+	t.Run("Lockout", func(t *testing.T) {
+		// The backoff is over, but the next failure reaches the max attempts.
+		c.NoError(loginAttempts.BlockLogin(ctx, "username:test@gmail.com", time.Now()))
+
+		_, err = authenticate(ctx, "test@gmail.com", "wrong", "1.1.1.1")
+		c.ErrorIs(err, models.ErrWrongCredentials)
+
+		_, err = authenticate(ctx, "test@gmail.com", "password", "2.2.2.2")
+		c.True(errors.As(err, &blockedErr))
+		c.Greater(blockedErr.RetryAfter(), int64(590))
+	})
+
+	t.Run("Source IP", func(t *testing.T) {
+		// The successful login didn't reset the failures of the source IP, so it has 6 of them and the failures of a user
+		// that doesn't exist add up to its lockout.
+		for i := 0; i < 2; i++ {
+			_, err = authenticate(ctx, "unknown@gmail.com", "wrong", "1.1.1.1")
+			c.ErrorIs(err, models.ErrUserNotFound)
+		}
+
+		_, err = authenticate(ctx, "other@gmail.com", "password", "1.1.1.1")
+		c.True(errors.As(err, &blockedErr))
+		c.Greater(blockedErr.RetryAfter(), int64(590))
+	})
+
+	t.Run("Admin unlock", func(t *testing.T) {
+		t.Setenv("ADMIN_KEY_SECRET", "admin-key-secret")
+
+		secretMock := secrets.NewSecretMock()
+		secretMock.RegisterResponder("admin-key-secret", func(ctx context.Context, name string) (string, error) {
+			return "admin-key", nil
+		})
+
+		unlock := NewLoginUnlocker(secretMock, loginAttempts)
+
+		c.ErrorIs(unlock(ctx, "wrong-key", "test@gmail.com", ""), models.ErrInvalidAdminKey)
+		c.ErrorIs(unlock(ctx, "admin-key", "", ""), models.ErrMissingLoginUnlockTarget)
+
+		c.NoError(unlock(ctx, "admin-key", "TEST@gmail.com", "1.1.1.1"))
+
+		_, err = authenticate(ctx, "test@gmail.com", "password", "1.1.1.1")
+		c.NoError(err)
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"github.com/JoelD7/money/backend/models"
 	"net/http"
+	"time"
 )
 
 // Expenses
@@ -21,13 +22,24 @@ type ExpenseManager interface {
 	GetAllExpensesByPeriod(ctx context.Context, username string, params *models.QueryParameters) ([]*models.Expense, error)
 
 	UpdateExpense(ctx context.Context, expense *models.Expense) error
+	ReplaceExpense(ctx context.Context, expense *models.Expense) error
 	BatchUpdateExpenses(ctx context.Context, expenses []*models.Expense) error
 
-	DeleteExpense(ctx context.Context, expenseID, username string) error
+	DeleteExpense(ctx context.Context, expenseID, username string, version int64) error
+	BatchDeleteExpenses(ctx context.Context, expenses []*models.Expense) error
 }
 
 type ExpenseRecurringManager interface {
+	CreateExpenseRecurring(ctx context.Context, expenseRecurring *models.ExpenseRecurring) (*models.ExpenseRecurring, error)
+	BatchCreateExpenseRecurring(ctx context.Context, expensesRecurring []*models.ExpenseRecurring) error
+
+	GetExpenseRecurring(ctx context.Context, expenseRecurringID, username string) (*models.ExpenseRecurring, error)
+	GetExpensesRecurring(ctx context.Context, username string, params *models.QueryParameters) ([]*models.ExpenseRecurring, string, error)
+
+	UpdateExpenseRecurring(ctx context.Context, expenseRecurring *models.ExpenseRecurring) error
+
 	DeleteExpenseRecurring(ctx context.Context, expenseRecurringID, username string) error
+	BatchDeleteExpenseRecurring(ctx context.Context, expenseRecurring []*models.ExpenseRecurring) error
 }
 
 // User
@@ -44,20 +56,46 @@ type InvalidTokenCache interface {
 	AddInvalidToken(ctx context.Context, username, token string, ttl int64) error
 }
 
+type LoginAttemptCache interface {
+	AddFailedLogin(ctx context.Context, key string, window int64) (int64, error)
+	GetLoginBlock(ctx context.Context, key string) (time.Time, error)
+	BlockLogin(ctx context.Context, key string, until time.Time) error
+	DeleteLoginAttempts(ctx context.Context, key string) error
+}
+
+type SessionManager interface {
+	CreateSession(ctx context.Context, session *models.Session) error
+	GetSession(ctx context.Context, username, sessionID string) (*models.Session, error)
+	GetSessions(ctx context.Context, username string) ([]*models.Session, error)
+	UpdateSession(ctx context.Context, session *models.Session, refreshToken string) error
+	DeleteSession(ctx context.Context, username, sessionID string) error
+	DeleteAllSessions(ctx context.Context, username string) (int, error)
+}
+
 type SecretManager interface {
 	GetSecret(ctx context.Context, name string) (string, error)
 }
 
+type MailSender interface {
+	Send(ctx context.Context, mail *models.Mail) error
+}
+
 // Income
 
 type IncomeRepository interface {
 	CreateIncome(ctx context.Context, income *models.Income) (*models.Income, error)
+	BatchCreateIncome(ctx context.Context, incomes []*models.Income) error
 
 	GetIncome(ctx context.Context, username, incomeID string) (*models.Income, error)
 	GetAllIncome(ctx context.Context, username string, params *models.QueryParameters) ([]*models.Income, string, error)
 	GetIncomeByPeriod(ctx context.Context, username string, params *models.QueryParameters) ([]*models.Income, string, error)
 	GetAllIncomeByPeriod(ctx context.Context, username string, params *models.QueryParameters) ([]*models.Income, error)
 	GetAllIncomePeriods(ctx context.Context, username string) ([]string, error)
+
+	UpdateIncome(ctx context.Context, income *models.Income) error
+
+	DeleteIncome(ctx context.Context, incomeID, username string) error
+	BatchDeleteIncome(ctx context.Context, income []*models.Income) error
 }
 
 type IncomePeriodCacheManager interface {
@@ -77,23 +115,71 @@ type JWKSGetter interface {
 
 type PeriodManager interface {
 	CreatePeriod(ctx context.Context, period *models.Period) (*models.Period, error)
+	BatchCreatePeriods(ctx context.Context, periods []*models.Period) error
 	UpdatePeriod(ctx context.Context, period *models.Period) error
 	GetPeriod(ctx context.Context, username, period string) (*models.Period, error)
 	GetLastPeriod(ctx context.Context, username string) (*models.Period, error)
 	GetPeriods(ctx context.Context, username, startKey string, pageSize int, active bool) ([]*models.Period, string, error)
 	BatchGetPeriods(ctx context.Context, username string, periods []string) ([]*models.Period, error)
-	DeletePeriod(ctx context.Context, periodID, username string) error
+	DeletePeriod(ctx context.Context, periodID, username string, version int64) error
+	BatchDeletePeriods(ctx context.Context, periods []*models.Period) error
 }
 
 type SavingGoalManager interface {
 	CreateSavingGoal(ctx context.Context, savingGoal *models.SavingGoal) (*models.SavingGoal, error)
+	BatchCreateSavingGoals(ctx context.Context, savingGoals []*models.SavingGoal) error
 	UpdateSavingGoal(ctx context.Context, savingGoal *models.SavingGoal) (*models.SavingGoal, error)
 	GetSavingGoal(ctx context.Context, username, savingGoalID string) (*models.SavingGoal, error)
 	GetSavingGoals(ctx context.Context, username string, params *models.QueryParameters) ([]*models.SavingGoal, string, error)
-	DeleteSavingGoal(ctx context.Context, username, savingGoalID string) error
+	DeleteSavingGoal(ctx context.Context, username, savingGoalID string, version int64) error
+	BatchDeleteSavingGoals(ctx context.Context, savingGoals []*models.SavingGoal) error
 	GetAllRecurringSavingGoals(ctx context.Context, username string) ([]*models.SavingGoal, error)
 }
 
+type ExchangeRateManager interface {
+	SaveExchangeRate(ctx context.Context, exchangeRate *models.ExchangeRate) error
+	GetExchangeRate(ctx context.Context, username, periodID, currency string) (*models.ExchangeRate, error)
+	GetExchangeRates(ctx context.Context, username, periodID string) ([]*models.ExchangeRate, error)
+	DeleteExchangeRate(ctx context.Context, username, periodID, currency string) error
+}
+
+type BudgetAlertManager interface {
+	CreateBudgetAlert(ctx context.Context, alert *models.BudgetAlert) error
+	DeleteBudgetAlert(ctx context.Context, alert *models.BudgetAlert) error
+	DeleteAllBudgetAlerts(ctx context.Context, username string) (int, error)
+}
+
+type BudgetAlertNotifier interface {
+	Notify(ctx context.Context, alert *models.BudgetAlert) error
+}
+
+type AccountDeletionManager interface {
+	SaveAccountDeletion(ctx context.Context, accountDeletion *models.AccountDeletion) error
+	GetAccountDeletion(ctx context.Context, username string) (*models.AccountDeletion, error)
+}
+
+type AccountDeletionQueue interface {
+	SendAccountDeletion(ctx context.Context, username string) error
+}
+
+type TrashManager interface {
+	CreateTrashItem(ctx context.Context, item *models.TrashItem) error
+	GetTrashItem(ctx context.Context, username, itemID string) (*models.TrashItem, error)
+	GetTrashItems(ctx context.Context, username string, params *models.QueryParameters) ([]*models.TrashItem, string, error)
+	DeleteTrashItem(ctx context.Context, username, itemID string) error
+	DeleteAllTrashItems(ctx context.Context, username string) (int, error)
+}
+
+type AuditManager interface {
+	GetEntityHistory(ctx context.Context, username string, entityType models.AuditEntityType, entityID string, params *models.QueryParameters) ([]*models.AuditEntry, string, error)
+	GetAuditEntries(ctx context.Context, username string, params *models.QueryParameters) ([]*models.AuditEntry, string, error)
+	DeleteAllAuditEntries(ctx context.Context, username string) (int, error)
+}
+
+type UserCacheManager interface {
+	DeleteUserData(ctx context.Context, username string) error
+}
+
 type SavingsManager interface {
 	CreateSaving(ctx context.Context, saving *models.Saving) (*models.Saving, error)
 	BatchCreateSavings(ctx context.Context, savings []*models.Saving) error
@@ -101,10 +187,26 @@ type SavingsManager interface {
 	GetSaving(ctx context.Context, username, savingID string) (*models.Saving, error)
 	GetSavings(ctx context.Context, username string, params *models.QueryParameters) ([]*models.Saving, string, error)
 	GetSavingsByPeriod(ctx context.Context, username string, params *models.QueryParameters) ([]*models.Saving, string, error)
-	GetSavingsBySavingGoal(ctx context.Context, params *models.QueryParameters) ([]*models.Saving, string, error)
-	GetSavingsBySavingGoalAndPeriod(ctx context.Context, params *models.QueryParameters) ([]*models.Saving, string, error)
+	GetSavingsBySavingGoal(ctx context.Context, username string, params *models.QueryParameters) ([]*models.Saving, string, error)
+	GetSavingsBySavingGoalAndPeriod(ctx context.Context, username string, params *models.QueryParameters) ([]*models.Saving, string, error)
 
 	UpdateSaving(ctx context.Context, saving *models.Saving) error
+	ReplaceSaving(ctx context.Context, saving *models.Saving) error
+
+	DeleteSaving(ctx context.Context, savingID, username string, version int64) error
+	BatchDeleteSavings(ctx context.Context, savings []*models.Saving) error
+}
 
-	DeleteSaving(ctx context.Context, savingID, username string) error
+// Export
+
+// UserDataWriter writes the export of a user as its data is read. The user is written first, then the pages of each
+// entity in the order of models.Export.
+type UserDataWriter interface {
+	WriteUser(user *models.User) error
+	WritePeriods(periods []*models.Period) error
+	WriteExpenses(expenses []*models.Expense) error
+	WriteIncome(income []*models.Income) error
+	WriteSavings(savings []*models.Saving) error
+	WriteSavingGoals(savingGoals []*models.SavingGoal) error
+	WriteExpensesRecurring(expensesRecurring []*models.ExpenseRecurring) error
 }
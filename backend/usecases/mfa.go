@@ -0,0 +1,308 @@
+package usecases
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base32"
+	"errors"
+	"fmt"
+	"github.com/JoelD7/money/backend/models"
+	"github.com/JoelD7/money/backend/shared/env"
+	"github.com/JoelD7/money/backend/shared/hash"
+	"github.com/JoelD7/money/backend/shared/logger"
+	"github.com/JoelD7/money/backend/shared/totp"
+	"io"
+	"strings"
+	"time"
+)
+
+const (
+	mfaAudience = "mfa"
+	mfaScope    = "mfa_challenge"
+	// defaultMFATokenDuration is how many seconds the user has to enter the TOTP code when MFA_TOKEN_DURATION isn't
+	// set.
+	defaultMFATokenDuration = 300
+
+	recoveryCodesCount = 10
+	recoveryCodeSize   = 5
+)
+
+var recoveryCodeEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// NewMFAEnroller starts the enrollment of a TOTP authenticator. MFA isn't enabled until the user confirms that the
+// authenticator works with NewMFAEnrollmentConfirmer, so starting again replaces the pending authenticator.
+func NewMFAEnroller(um UserManager) func(ctx context.Context, username string) (*models.MFAEnrollment, error) {
+	return func(ctx context.Context, username string) (*models.MFAEnrollment, error) {
+		user, err := um.GetUser(ctx, username)
+		if err != nil {
+			return nil, err
+		}
+
+		if user.MFAEnabled {
+			return nil, models.ErrMFAAlreadyEnabled
+		}
+
+		secret, err := totp.GenerateSecret()
+		if err != nil {
+			return nil, err
+		}
+
+		user.MFASecret = secret
+
+		err = um.UpdateUser(ctx, user)
+		if err != nil {
+			logger.Error("mfa_secret_saving_failed", err, user)
+
+			return nil, err
+		}
+
+		return &models.MFAEnrollment{
+			Secret:          secret,
+			ProvisioningURI: totp.ProvisioningURI(secret, env.GetString("MFA_ISSUER", "Money"), user.Username),
+		}, nil
+	}
+}
+
+// NewMFAEnrollmentConfirmer enables MFA for the user once the first code of the authenticator is validated. It returns
+// the recovery codes, which are shown to the user only this time, as only their hashes are stored.
+func NewMFAEnrollmentConfirmer(um UserManager) func(ctx context.Context, username, code string) ([]string, error) {
+	return func(ctx context.Context, username, code string) ([]string, error) {
+		user, err := um.GetUser(ctx, username)
+		if err != nil {
+			return nil, err
+		}
+
+		if user.MFAEnabled {
+			return nil, models.ErrMFAAlreadyEnabled
+		}
+
+		if user.MFASecret == "" {
+			return nil, models.ErrMFANotEnrolled
+		}
+
+		err = validateTOTPCode(user, code)
+		if err != nil {
+			logger.Warning("mfa_enrollment_code_validation_failed", err, user)
+
+			return nil, err
+		}
+
+		recoveryCodes, hashedCodes, err := generateRecoveryCodes()
+		if err != nil {
+			return nil, err
+		}
+
+		user.MFAEnabled = true
+		user.MFARecoveryCodes = hashedCodes
+
+		err = um.UpdateUser(ctx, user)
+		if err != nil {
+			logger.Error("mfa_enabling_failed", err, user)
+
+			return nil, err
+		}
+
+		logger.Info("mfa_enabled", user)
+
+		return recoveryCodes, nil
+	}
+}
+
+// NewMFADisabler disables MFA for the user, who has to enter a TOTP or recovery code, so that a stolen password isn't
+// enough to turn it off. The failed codes are counted along with the ones of the MFA logins, so that a stolen session
+// doesn't allow unlimited guesses either.
+func NewMFADisabler(um UserManager, loginAttempts LoginAttemptCache) func(ctx context.Context, username, code string) error {
+	return func(ctx context.Context, username, code string) error {
+		mfaKey := getMFAAttemptKey(username)
+		attemptKeys := []loginAttemptKey{mfaKey}
+
+		err := checkLoginBlocked(ctx, loginAttempts, attemptKeys)
+		if err != nil {
+			return err
+		}
+
+		user, err := um.GetUser(ctx, username)
+		if err != nil {
+			return err
+		}
+
+		if !user.MFAEnabled {
+			return models.ErrMFANotEnabled
+		}
+
+		err = validateMFACode(user, code)
+		if err != nil {
+			logger.Warning("mfa_disabling_code_validation_failed", err, user)
+
+			if errors.Is(err, models.ErrInvalidMFACode) {
+				recordFailedLogin(ctx, loginAttempts, attemptKeys)
+			}
+
+			return err
+		}
+
+		user.MFAEnabled = false
+		user.MFASecret = ""
+		user.MFARecoveryCodes = nil
+		user.MFALastUsedStep = 0
+
+		err = um.UpdateUser(ctx, user)
+		if err != nil {
+			logger.Error("mfa_disabling_failed", err, user)
+
+			return err
+		}
+
+		resetLoginAttempts(ctx, loginAttempts, attemptKeys)
+
+		logger.Info("mfa_disabled", user)
+
+		return nil
+	}
+}
+
+// NewMFAChallengeIssuer issues the token a user with MFA enabled gets after entering the password. It's sent back with
+// the TOTP code to NewMFALoginVerifier, and is valid for MFA_TOKEN_DURATION seconds.
+func NewMFAChallengeIssuer(secretManager SecretManager) func(ctx context.Context, user *models.User) (*models.AuthToken, error) {
+	return func(ctx context.Context, user *models.User) (*models.AuthToken, error) {
+		duration := env.GetInt("MFA_TOKEN_DURATION", defaultMFATokenDuration)
+
+		token, err := generateScopedToken(secretManager, user.Username, mfaAudience, mfaScope, time.Duration(duration)*time.Second)
+		if err != nil {
+			logger.Error("generate_mfa_token_failed", err, user)
+
+			return nil, err
+		}
+
+		return token, nil
+	}
+}
+
+// NewMFALoginVerifier completes the login of a user with MFA enabled, validating the token issued by
+// NewMFAChallengeIssuer and the TOTP or recovery code. The user that is returned can be given access and refresh tokens.
+//
+// The failed codes of the user and of the source IP are counted as the failed logins are, and after too many of them the
+// codes are rejected with a *models.LoginBlockedError, even if they are right. A right code only resets the failures of
+// the user.
+func NewMFALoginVerifier(um UserManager, secretManager SecretManager, loginAttempts LoginAttemptCache) func(ctx context.Context, mfaToken, code, sourceIP string) (*models.User, error) {
+	return func(ctx context.Context, mfaToken, code, sourceIP string) (*models.User, error) {
+		username, err := validateScopedToken(ctx, secretManager, mfaToken, mfaAudience, mfaScope, models.ErrInvalidMFAToken)
+		if err != nil {
+			logger.Warning("mfa_token_validation_failed", err, nil)
+
+			return nil, err
+		}
+
+		mfaKey := getMFAAttemptKey(username)
+		attemptKeys := append(getLoginAttemptKeys("", sourceIP), mfaKey)
+
+		err = checkLoginBlocked(ctx, loginAttempts, attemptKeys)
+		if err != nil {
+			return nil, err
+		}
+
+		user, err := um.GetUser(ctx, username)
+		if errors.Is(err, models.ErrUserNotFound) {
+			return nil, fmt.Errorf("%w: %v", models.ErrInvalidMFAToken, err)
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		if !user.MFAEnabled {
+			return nil, fmt.Errorf("%w: %v", models.ErrInvalidMFAToken, models.ErrMFANotEnabled)
+		}
+
+		err = validateMFACode(user, code)
+		if err != nil {
+			logger.Warning("mfa_login_code_validation_failed", err, user)
+
+			if errors.Is(err, models.ErrInvalidMFACode) {
+				recordFailedLogin(ctx, loginAttempts, attemptKeys)
+			}
+
+			return nil, err
+		}
+
+		// The code is saved as used, or the recovery code removed, before the login completes.
+		err = um.UpdateUser(ctx, user)
+		if err != nil {
+			logger.Error("mfa_code_usage_saving_failed", err, user)
+
+			return nil, err
+		}
+
+		resetLoginAttempts(ctx, loginAttempts, []loginAttemptKey{mfaKey})
+
+		return um.GetUser(ctx, username)
+	}
+}
+
+// validateMFACode accepts either the current TOTP code or one of the recovery codes, which is removed from the user.
+func validateMFACode(user *models.User, code string) error {
+	err := validateTOTPCode(user, code)
+	if !errors.Is(err, models.ErrInvalidMFACode) {
+		return err
+	}
+
+	normalizedCode := normalizeRecoveryCode(code)
+
+	for i, hashedCode := range user.MFARecoveryCodes {
+		if hash.CompareWithToken(hashedCode, normalizedCode) == nil {
+			user.MFARecoveryCodes = append(user.MFARecoveryCodes[:i:i], user.MFARecoveryCodes[i+1:]...)
+
+			return nil
+		}
+	}
+
+	return err
+}
+
+// validateTOTPCode validates the code against the secret of the user, and saves its time step as the last one used.
+func validateTOTPCode(user *models.User, code string) error {
+	step, err := totp.Validate(user.MFASecret, strings.TrimSpace(code), time.Now(), user.MFALastUsedStep)
+	if errors.Is(err, totp.ErrInvalidCode) {
+		return models.ErrInvalidMFACode
+	}
+
+	if err != nil {
+		return err
+	}
+
+	user.MFALastUsedStep = step
+
+	return nil
+}
+
+// generateRecoveryCodes returns the recovery codes, formatted as xxxx-xxxx so that they're easier to write down, and
+// their hashes.
+func generateRecoveryCodes() ([]string, []string, error) {
+	codes := make([]string, 0, recoveryCodesCount)
+	hashedCodes := make([]string, 0, recoveryCodesCount)
+
+	for i := 0; i < recoveryCodesCount; i++ {
+		randomBytes := make([]byte, recoveryCodeSize)
+
+		_, err := io.ReadFull(rand.Reader, randomBytes)
+		if err != nil {
+			return nil, nil, fmt.Errorf("generating recovery code failed: %v", err)
+		}
+
+		code := strings.ToLower(recoveryCodeEncoding.EncodeToString(randomBytes))
+
+		hashedCode, err := hash.Apply(code)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		codes = append(codes, code[:len(code)/2]+"-"+code[len(code)/2:])
+		hashedCodes = append(hashedCodes, hashedCode)
+	}
+
+	return codes, hashedCodes, nil
+}
+
+func normalizeRecoveryCode(code string) string {
+	return strings.ToLower(strings.NewReplacer("-", "", " ", "").Replace(code))
+}
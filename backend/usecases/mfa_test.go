@@ -0,0 +1,168 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"github.com/JoelD7/money/backend/models"
+	"github.com/JoelD7/money/backend/shared/logger"
+	"github.com/JoelD7/money/backend/shared/totp"
+	"github.com/JoelD7/money/backend/storage/cache"
+	"github.com/JoelD7/money/backend/storage/sessions"
+	"github.com/JoelD7/money/backend/storage/users"
+	"github.com/stretchr/testify/require"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMFA(t *testing.T) {
+	c := require.New(t)
+
+	logger.InitLogger(logger.ConsoleImplementation)
+
+	ctx := context.Background()
+	userRepo := users.NewMemoryRepository()
+	secretMock := newTokenSecretsMock(t, c)
+	newPasswordTestUser(c, userRepo, "password")
+
+	loginAttempts := cache.NewMemoryCache()
+	authenticate := NewUserAuthenticator(userRepo, loginAttempts)
+	issueChallenge := NewMFAChallengeIssuer(secretMock)
+	verifyMFA := NewMFALoginVerifier(userRepo, secretMock, loginAttempts)
+	generateTokens := NewUserTokenGenerator(sessions.NewMemoryRepository(), secretMock)
+
+	enrollment, err := NewMFAEnroller(userRepo)(ctx, "test@gmail.com")
+	c.NoError(err)
+	c.Contains(enrollment.ProvisioningURI, enrollment.Secret)
+
+	// MFA isn't enabled until the enrollment is confirmed.
+	_, err = authenticate(ctx, "test@gmail.com", "password", "")
+	c.NoError(err)
+
+	confirmMFA := NewMFAEnrollmentConfirmer(userRepo)
+
+	_, err = confirmMFA(ctx, "test@gmail.com", "wrong")
+	c.ErrorIs(err, models.ErrInvalidMFACode)
+
+	now := time.Now()
+
+	code, err := totp.Code(enrollment.Secret, now)
+	c.NoError(err)
+
+	recoveryCodes, err := confirmMFA(ctx, "test@gmail.com", code)
+	c.NoError(err)
+	c.Len(recoveryCodes, recoveryCodesCount)
+
+	user, err := authenticate(ctx, "test@gmail.com", "password", "")
+	c.ErrorIs(err, models.ErrMFARequired)
+	c.NotNil(user)
+
+	mfaToken, err := issueChallenge(ctx, user)
+	c.NoError(err)
+
+	t.Run("Code used twice", func(t *testing.T) {
+		_, err = verifyMFA(ctx, mfaToken.Value, code, "")
+		c.ErrorIs(err, models.ErrInvalidMFACode)
+	})
+
+	nextCode, err := totp.Code(enrollment.Secret, now.Add(totp.Period*time.Second))
+	c.NoError(err)
+
+	user, err = verifyMFA(ctx, mfaToken.Value, nextCode, "")
+	c.NoError(err)
+
+	accessToken, _, err := generateTokens(ctx, user, new(models.Session))
+	c.NoError(err)
+
+	t.Run("Access token used as MFA token", func(t *testing.T) {
+		_, err = verifyMFA(ctx, accessToken.Value, nextCode, "")
+		c.ErrorIs(err, models.ErrInvalidMFAToken)
+	})
+
+	t.Run("Expired MFA token", func(t *testing.T) {
+		t.Setenv("MFA_TOKEN_DURATION", "-60")
+
+		expiredToken, err := issueChallenge(ctx, user)
+		c.NoError(err)
+
+		_, err = verifyMFA(ctx, expiredToken.Value, recoveryCodes[0], "")
+		c.ErrorIs(err, models.ErrInvalidMFAToken)
+	})
+
+	t.Run("Recovery code", func(t *testing.T) {
+		recoveryCode := strings.ToUpper(strings.ReplaceAll(recoveryCodes[0], "-", ""))
+
+		_, err = verifyMFA(ctx, mfaToken.Value, recoveryCode, "")
+		c.NoError(err)
+
+		_, err = verifyMFA(ctx, mfaToken.Value, recoveryCode, "")
+		c.ErrorIs(err, models.ErrInvalidMFACode)
+	})
+
+	t.Run("Too many wrong codes", func(t *testing.T) {
+		t.Setenv("LOGIN_BACKOFF_ATTEMPTS", "2")
+		t.Setenv("LOGIN_MAX_ATTEMPTS", "3")
+		t.Setenv("LOGIN_IP_BACKOFF_ATTEMPTS", "1")
+
+		// The recovery code used twice is the first failure.
+		for i := 0; i < 2; i++ {
+			_, err = verifyMFA(ctx, mfaToken.Value, "000000", "3.3.3.3")
+			c.ErrorIs(err, models.ErrInvalidMFACode)
+		}
+
+		// The failures of the source IP are counted too.
+		until, err := loginAttempts.GetLoginBlock(ctx, "ip:3.3.3.3")
+		c.NoError(err)
+		c.True(until.After(time.Now()))
+
+		// The right password doesn't lift the block of the codes.
+		_, err = authenticate(ctx, "test@gmail.com", "password", "")
+		c.ErrorIs(err, models.ErrMFARequired)
+
+		var blockedErr *models.LoginBlockedError
+
+		_, err = verifyMFA(ctx, mfaToken.Value, recoveryCodes[3], "")
+		c.True(errors.As(err, &blockedErr))
+		c.Greater(blockedErr.RetryAfter(), int64(890))
+	})
+
+	t.Run("Enrollment while enabled", func(t *testing.T) {
+		_, err = NewMFAEnroller(userRepo)(ctx, "test@gmail.com")
+		c.ErrorIs(err, models.ErrMFAAlreadyEnabled)
+	})
+
+	disableMFA := NewMFADisabler(userRepo, loginAttempts)
+
+	t.Run("Disabling with too many wrong codes", func(t *testing.T) {
+		var blockedErr *models.LoginBlockedError
+
+		// The wrong codes of the MFA logins block the disabling too.
+		c.True(errors.As(disableMFA(ctx, "test@gmail.com", recoveryCodes[1]), &blockedErr))
+
+		c.NoError(loginAttempts.DeleteLoginAttempts(ctx, "mfa:test@gmail.com"))
+
+		t.Setenv("LOGIN_BACKOFF_ATTEMPTS", "1")
+		t.Setenv("LOGIN_MAX_ATTEMPTS", "2")
+
+		for i := 0; i < 2; i++ {
+			c.ErrorIs(disableMFA(ctx, "test@gmail.com", "wrong"), models.ErrInvalidMFACode)
+		}
+
+		c.True(errors.As(disableMFA(ctx, "test@gmail.com", recoveryCodes[1]), &blockedErr))
+
+		c.NoError(loginAttempts.DeleteLoginAttempts(ctx, "mfa:test@gmail.com"))
+	})
+
+	c.ErrorIs(disableMFA(ctx, "test@gmail.com", "wrong"), models.ErrInvalidMFACode)
+	c.NoError(disableMFA(ctx, "test@gmail.com", recoveryCodes[1]))
+
+	// The right code reset the failures, so this is the first one.
+	failures, err := loginAttempts.AddFailedLogin(ctx, "mfa:test@gmail.com", 900)
+	c.NoError(err)
+	c.Equal(int64(1), failures)
+
+	_, err = authenticate(ctx, "test@gmail.com", "password", "")
+	c.NoError(err)
+
+	c.ErrorIs(disableMFA(ctx, "test@gmail.com", recoveryCodes[2]), models.ErrMFANotEnabled)
+}
@@ -0,0 +1,200 @@
+package usecases
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"github.com/JoelD7/money/backend/models"
+	"github.com/JoelD7/money/backend/shared/env"
+	"github.com/JoelD7/money/backend/shared/hash"
+	"github.com/JoelD7/money/backend/shared/logger"
+	"golang.org/x/crypto/bcrypt"
+	"io"
+	"time"
+)
+
+const (
+	passwordResetTokenSize = 32
+	// defaultPasswordResetTokenDuration is how many seconds a password reset token is valid for when
+	// PASSWORD_RESET_TOKEN_DURATION isn't set.
+	defaultPasswordResetTokenDuration = 3600
+)
+
+// NewPasswordChanger changes the password of the user, who has to confirm the current one.
+func NewPasswordChanger(um UserManager) func(ctx context.Context, username, currentPassword, newPassword string) error {
+	return func(ctx context.Context, username, currentPassword, newPassword string) error {
+		user, err := um.GetUser(ctx, username)
+		if errors.Is(err, models.ErrUserNotFound) {
+			return models.ErrWrongCredentials
+		}
+
+		if err != nil {
+			logger.Error("user_fetching_failed", err, models.Any("password_change", map[string]interface{}{
+				"s_username": username,
+			}))
+
+			return err
+		}
+
+		err = bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(currentPassword))
+		if err != nil {
+			logger.Warning("password_change_mismatch", err, models.Any("password_change", map[string]interface{}{
+				"s_username": username,
+			}))
+
+			return models.ErrWrongCredentials
+		}
+
+		return setPassword(ctx, um, user, newPassword)
+	}
+}
+
+// NewPasswordResetRequester sends the user a token to reset the password. The token is valid for
+// PASSWORD_RESET_TOKEN_DURATION seconds and only the hash of it is stored. Requesting a new token replaces the previous
+// one.
+//
+// No error is returned if the user doesn't exist, so that the endpoint can't be used to find out which accounts exist.
+func NewPasswordResetRequester(um UserManager, sender MailSender) func(ctx context.Context, username string) error {
+	return func(ctx context.Context, username string) error {
+		user, err := um.GetUser(ctx, username)
+		if errors.Is(err, models.ErrUserNotFound) {
+			logger.Warning("password_reset_requested_for_unknown_user", err, models.Any("password_reset", map[string]interface{}{
+				"s_username": username,
+			}))
+
+			return nil
+		}
+
+		if err != nil {
+			return err
+		}
+
+		token, err := generatePasswordResetToken()
+		if err != nil {
+			return err
+		}
+
+		hashedToken, err := hash.Apply(token)
+		if err != nil {
+			return err
+		}
+
+		duration := env.GetInt("PASSWORD_RESET_TOKEN_DURATION", defaultPasswordResetTokenDuration)
+		expiration := time.Now().Add(time.Duration(duration) * time.Second)
+
+		user.PasswordResetToken = hashedToken
+		user.PasswordResetExpiration = &expiration
+
+		err = um.UpdateUser(ctx, user)
+		if err != nil {
+			logger.Error("password_reset_token_saving_failed", err, user)
+
+			return err
+		}
+
+		err = sender.Send(ctx, newPasswordResetMail(user.Username, token, expiration))
+		if err != nil {
+			logger.Error("password_reset_mail_sending_failed", err, user)
+
+			return err
+		}
+
+		return nil
+	}
+}
+
+// NewPasswordResetter sets the password of the user with a token sent by NewPasswordResetRequester. The token can be
+// used only once, and all the sessions of the user are revoked after the reset, as whoever made the user reset the
+// password may have access to them.
+func NewPasswordResetter(um UserManager, sm SessionManager, tokenCache InvalidTokenCache) func(ctx context.Context, username, token, newPassword string) error {
+	return func(ctx context.Context, username, token, newPassword string) error {
+		user, err := um.GetUser(ctx, username)
+		if errors.Is(err, models.ErrUserNotFound) {
+			return models.ErrInvalidPasswordResetToken
+		}
+
+		if err != nil {
+			return err
+		}
+
+		err = validatePasswordResetToken(user, token)
+		if err != nil {
+			logger.Warning("password_reset_token_validation_failed", err, user)
+
+			return err
+		}
+
+		err = setPassword(ctx, um, user, newPassword)
+		if err != nil {
+			return err
+		}
+
+		revokeSessions := NewAllSessionsRevoker(sm, tokenCache)
+
+		return revokeSessions(ctx, user.Username)
+	}
+}
+
+// setPassword hashes and saves the new password of the user. The password reset token is cleared, as it's no longer
+// needed to recover the account.
+func setPassword(ctx context.Context, um UserManager, user *models.User, newPassword string) error {
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(newPassword), passwordCost)
+	if err != nil {
+		logger.Error("password_hashing_failed", err, nil)
+
+		return err
+	}
+
+	user.Password = string(hashedPassword)
+	user.PasswordResetToken = ""
+	user.PasswordResetExpiration = nil
+
+	err = um.UpdateUser(ctx, user)
+	if err != nil {
+		logger.Error("password_update_failed", err, user)
+
+		return err
+	}
+
+	return nil
+}
+
+func validatePasswordResetToken(user *models.User, token string) error {
+	if user.PasswordResetToken == "" || user.PasswordResetExpiration == nil {
+		return fmt.Errorf("%w: no password reset was requested", models.ErrInvalidPasswordResetToken)
+	}
+
+	if time.Now().After(*user.PasswordResetExpiration) {
+		return fmt.Errorf("%w: the token expired at %s", models.ErrInvalidPasswordResetToken,
+			user.PasswordResetExpiration.Format(time.RFC3339))
+	}
+
+	err := hash.CompareWithToken(user.PasswordResetToken, token)
+	if errors.Is(err, hash.ErrHashMismatch) {
+		return fmt.Errorf("%w: %v", models.ErrInvalidPasswordResetToken, err)
+	}
+
+	return err
+}
+
+func generatePasswordResetToken() (string, error) {
+	token := make([]byte, passwordResetTokenSize)
+
+	_, err := io.ReadFull(rand.Reader, token)
+	if err != nil {
+		return "", fmt.Errorf("generating password reset token failed: %v", err)
+	}
+
+	return base64.RawURLEncoding.EncodeToString(token), nil
+}
+
+func newPasswordResetMail(username, token string, expiration time.Time) *models.Mail {
+	return &models.Mail{
+		To:      username,
+		Subject: "Reset your password",
+		Body: fmt.Sprintf("Use the following token to reset your password. It can be used once, until %s.\n\n%s\n\n"+
+			"If you didn't ask to reset your password, you can ignore this email.", expiration.UTC().Format(time.RFC1123), token),
+	}
+}
@@ -0,0 +1,155 @@
+package usecases
+
+import (
+	"context"
+	"github.com/JoelD7/money/backend/models"
+	"github.com/JoelD7/money/backend/shared/logger"
+	"github.com/JoelD7/money/backend/storage/cache"
+	"github.com/JoelD7/money/backend/storage/sessions"
+	"github.com/JoelD7/money/backend/storage/users"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+	"strings"
+	"testing"
+	"time"
+)
+
+type mailRecorder struct {
+	mails []*models.Mail
+}
+
+func (m *mailRecorder) Send(ctx context.Context, mail *models.Mail) error {
+	m.mails = append(m.mails, mail)
+
+	return nil
+}
+
+// lastToken returns the token of the last email, which is in its own paragraph.
+func (m *mailRecorder) lastToken() string {
+	return strings.Split(m.mails[len(m.mails)-1].Body, "\n\n")[1]
+}
+
+func newPasswordTestUser(c *require.Assertions, userRepo *users.MemoryRepository, password string) *models.User {
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.MinCost)
+	c.NoError(err)
+
+	user, err := userRepo.CreateUser(context.Background(), &models.User{
+		Username: "test@gmail.com",
+		Password: string(hashedPassword),
+	})
+	c.NoError(err)
+
+	return user
+}
+
+func TestPasswordChanger(t *testing.T) {
+	c := require.New(t)
+
+	logger.InitLogger(logger.ConsoleImplementation)
+
+	ctx := context.Background()
+	userRepo := users.NewMemoryRepository()
+	newPasswordTestUser(c, userRepo, "current")
+
+	changePassword := NewPasswordChanger(userRepo)
+
+	err := changePassword(ctx, "test@gmail.com", "wrong", "new")
+	c.ErrorIs(err, models.ErrWrongCredentials)
+
+	err = changePassword(ctx, "unknown@gmail.com", "current", "new")
+	c.ErrorIs(err, models.ErrWrongCredentials)
+
+	c.NoError(changePassword(ctx, "test@gmail.com", "current", "new"))
+
+	_, err = NewUserAuthenticator(userRepo, cache.NewMemoryCache())(ctx, "test@gmail.com", "new", "")
+	c.NoError(err)
+}
+
+func TestPasswordReset(t *testing.T) {
+	c := require.New(t)
+
+	logger.InitLogger(logger.ConsoleImplementation)
+
+	ctx := context.Background()
+	userRepo := users.NewMemoryRepository()
+	sessionRepo := sessions.NewMemoryRepository()
+	tokenCache := cache.NewMemoryCache()
+	sender := new(mailRecorder)
+	newPasswordTestUser(c, userRepo, "forgotten")
+
+	c.NoError(sessionRepo.CreateSession(ctx, &models.Session{
+		ID:             "SS1",
+		Username:       "test@gmail.com",
+		AccessToken:    "hashed-access-token",
+		RefreshToken:   "hashed-refresh-token",
+		ExpirationDate: time.Now().Add(time.Hour),
+	}))
+
+	requestReset := NewPasswordResetRequester(userRepo, sender)
+	resetPassword := NewPasswordResetter(userRepo, sessionRepo, tokenCache)
+
+	c.NoError(requestReset(ctx, "test@gmail.com"))
+	c.Len(sender.mails, 1)
+	c.Equal("test@gmail.com", sender.mails[0].To)
+
+	token := sender.lastToken()
+
+	user, err := userRepo.GetUser(ctx, "test@gmail.com")
+	c.NoError(err)
+	c.NotEqual(token, user.PasswordResetToken)
+
+	err = resetPassword(ctx, "test@gmail.com", "wrong-token", "new")
+	c.ErrorIs(err, models.ErrInvalidPasswordResetToken)
+
+	c.NoError(resetPassword(ctx, "test@gmail.com", token, "new"))
+
+	_, err = NewUserAuthenticator(userRepo, cache.NewMemoryCache())(ctx, "test@gmail.com", "new", "")
+	c.NoError(err)
+
+	user, err = userRepo.GetUser(ctx, "test@gmail.com")
+	c.NoError(err)
+	c.Empty(user.PasswordResetToken)
+
+	_, err = sessionRepo.GetSessions(ctx, "test@gmail.com")
+	c.ErrorIs(err, models.ErrSessionsNotFound)
+
+	invalidTokens, err := tokenCache.GetInvalidTokens(ctx, "test@gmail.com")
+	c.NoError(err)
+	c.Len(invalidTokens, 2)
+
+	t.Run("Token used twice", func(t *testing.T) {
+		err = resetPassword(ctx, "test@gmail.com", token, "other")
+		c.ErrorIs(err, models.ErrInvalidPasswordResetToken)
+	})
+
+	t.Run("Expired token", func(t *testing.T) {
+		t.Setenv("PASSWORD_RESET_TOKEN_DURATION", "-1")
+
+		c.NoError(requestReset(ctx, "test@gmail.com"))
+
+		err = resetPassword(ctx, "test@gmail.com", sender.lastToken(), "other")
+		c.ErrorIs(err, models.ErrInvalidPasswordResetToken)
+	})
+
+	t.Run("New token replaces the previous one", func(t *testing.T) {
+		c.NoError(requestReset(ctx, "test@gmail.com"))
+		previousToken := sender.lastToken()
+
+		c.NoError(requestReset(ctx, "test@gmail.com"))
+
+		err = resetPassword(ctx, "test@gmail.com", previousToken, "other")
+		c.ErrorIs(err, models.ErrInvalidPasswordResetToken)
+
+		c.NoError(resetPassword(ctx, "test@gmail.com", sender.lastToken(), "other"))
+	})
+
+	t.Run("Unknown user", func(t *testing.T) {
+		mailsSent := len(sender.mails)
+
+		c.NoError(requestReset(ctx, "unknown@gmail.com"))
+		c.Len(sender.mails, mailsSent)
+
+		err = resetPassword(ctx, "unknown@gmail.com", token, "other")
+		c.ErrorIs(err, models.ErrInvalidPasswordResetToken)
+	})
+}
@@ -0,0 +1,146 @@
+package usecases
+
+import (
+	"context"
+	"github.com/JoelD7/money/backend/models"
+	"github.com/JoelD7/money/backend/shared/logger"
+	"github.com/JoelD7/money/backend/storage/expenses"
+	"github.com/JoelD7/money/backend/storage/period"
+	"github.com/JoelD7/money/backend/storage/users"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestExpensePatcher(t *testing.T) {
+	c := require.New(t)
+
+	logger.InitLogger(logger.ConsoleImplementation)
+
+	ctx := context.Background()
+	username := "test@gmail.com"
+
+	userRepo := users.NewMemoryRepository()
+	periodRepo := period.NewMemoryRepository()
+	expensesRepo := expenses.NewMemoryRepository(nil)
+
+	_, err := userRepo.CreateUser(ctx, &models.User{Username: username, Categories: []*models.Category{{ID: "CTG1"}}})
+	c.NoError(err)
+
+	c.NoError(periodRepo.BatchCreatePeriods(ctx, []*models.Period{{Username: username, ID: "PRD1"}}))
+
+	name := "Groceries"
+	amount := models.NewMoney(10)
+	categoryID := "CTG1"
+	createdDate := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	c.NoError(expensesRepo.BatchCreateExpenses(ctx, []*models.Expense{
+		{Username: username, ExpenseID: "EX1", PeriodID: "PRD1", CategoryID: &categoryID, Name: &name, Amount: &amount,
+			Currency: "USD", Notes: "Weekly", CreatedDate: createdDate},
+	}))
+
+	patchExpense := NewExpensePatcher(expensesRepo, periodRepo, userRepo, nil)
+
+	// Null members are cleared, omitted members are kept and the identity of the expense can't be patched.
+	patched, err := patchExpense(ctx, "EX1", username, 1,
+		[]byte(`{"amount": 25.5, "notes": null, "category_id": null, "expense_id": "EX2", "username": "other@gmail.com"}`))
+	c.NoError(err)
+	c.Equal("EX1", patched.ExpenseID)
+	c.Equal(username, patched.Username)
+	c.Equal(models.NewMoney(25.5), *patched.Amount)
+	c.Equal(name, *patched.Name)
+	c.Equal("USD", patched.Currency)
+	c.Empty(patched.Notes)
+	c.Nil(patched.CategoryID)
+	c.True(createdDate.Equal(patched.CreatedDate))
+	c.Equal(int64(2), patched.Version)
+
+	t.Run("Stale version", func(t *testing.T) {
+		_, err := patchExpense(ctx, "EX1", username, 1, []byte(`{"notes": "Monthly"}`))
+		require.ErrorIs(t, err, models.ErrVersionConflict)
+	})
+
+	t.Run("Result is validated", func(t *testing.T) {
+		_, err := patchExpense(ctx, "EX1", username, 0, []byte(`{"name": null}`))
+		require.ErrorIs(t, err, models.ErrMissingName)
+
+		_, err = patchExpense(ctx, "EX1", username, 0, []byte(`{"period_id": "PRD2"}`))
+		require.ErrorIs(t, err, models.ErrInvalidPeriod)
+	})
+
+	t.Run("Invalid patch", func(t *testing.T) {
+		_, err := patchExpense(ctx, "EX1", username, 0, []byte(`{"amount":`))
+		require.ErrorIs(t, err, models.ErrInvalidRequestBody)
+	})
+
+	t.Run("Expense not found", func(t *testing.T) {
+		_, err := patchExpense(ctx, "EX3", username, 0, []byte(`{"notes": "Monthly"}`))
+		require.ErrorIs(t, err, models.ErrExpenseNotFound)
+	})
+}
+
+func TestCategoryPatcher(t *testing.T) {
+	c := require.New(t)
+
+	ctx := context.Background()
+	username := "test@gmail.com"
+
+	userRepo := users.NewMemoryRepository()
+
+	food, transport := "Food", "Transport"
+	budget := models.NewMoney(100)
+	color := "#ff0000"
+
+	_, err := userRepo.CreateUser(ctx, &models.User{Username: username, Categories: []*models.Category{
+		{ID: "CTG1", Name: &food, Budget: &budget, Color: &color, Keywords: []string{"market"}},
+		{ID: "CTG2", Name: &transport, Budget: &budget, Color: &color},
+	}})
+	c.NoError(err)
+
+	patchCategory := NewCategoryPatcher(userRepo)
+
+	user, err := patchCategory(ctx, username, "CTG1", 1, []byte(`{"color": "#00ff00", "keywords": null}`))
+	c.NoError(err)
+	c.Equal(int64(2), user.Version)
+	c.Len(user.Categories, 2)
+	c.Equal("CTG1", user.Categories[0].ID)
+	c.Equal(food, *user.Categories[0].Name)
+	c.Equal("#00ff00", *user.Categories[0].Color)
+	c.Nil(user.Categories[0].Keywords)
+
+	_, err = patchCategory(ctx, username, "CTG1", 0, []byte(`{"name": "Transport"}`))
+	c.ErrorIs(err, models.ErrCategoryNameAlreadyExists)
+
+	_, err = patchCategory(ctx, username, "CTG1", 0, []byte(`{"budget": null}`))
+	c.ErrorIs(err, models.ErrMissingCategoryBudget)
+
+	_, err = patchCategory(ctx, username, "CTG3", 0, []byte(`{"color": "#00ff00"}`))
+	c.ErrorIs(err, models.ErrCategoryNotFound)
+}
+
+func TestUserPatcher(t *testing.T) {
+	c := require.New(t)
+
+	ctx := context.Background()
+	username := "test@gmail.com"
+
+	userRepo := users.NewMemoryRepository()
+
+	_, err := userRepo.CreateUser(ctx, &models.User{Username: username, FullName: "Test"})
+	c.NoError(err)
+
+	patchUser := NewUserPatcher(userRepo)
+
+	user, err := patchUser(ctx, username, 0, []byte(`{"full_name": "Other"}`))
+	c.NoError(err)
+	c.Equal("Other", user.FullName)
+	c.Empty(user.LegacyCurrency)
+
+	user, err = patchUser(ctx, username, 0, []byte(`{"base_currency": "eur"}`))
+	c.NoError(err)
+	c.Equal("EUR", user.BaseCurrency)
+	c.Equal(models.DefaultCurrency, user.LegacyCurrency)
+
+	_, err = patchUser(ctx, username, 0, []byte(`{"base_currency": "ABC"}`))
+	c.ErrorIs(err, models.ErrInvalidCurrency)
+}
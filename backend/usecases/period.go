@@ -8,10 +8,10 @@ import (
 	"github.com/JoelD7/money/backend/models"
 	"github.com/JoelD7/money/backend/shared/env"
 	"github.com/JoelD7/money/backend/shared/logger"
+	"github.com/JoelD7/money/backend/shared/validate"
 	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/service/sqs"
 	"github.com/aws/aws-sdk-go/aws"
-	"math"
 	"sync"
 	"time"
 )
@@ -118,8 +118,23 @@ func sendPeriodToSQS(ctx context.Context, period *models.Period) error {
 	return nil
 }
 
+// NewPeriodUpdater replaces the period. It fails with models.ErrVersionConflict if the period was changed since the
+// version of the given one; a period without a version replaces the current one.
 func NewPeriodUpdater(pm PeriodManager) func(ctx context.Context, username, periodID string, period *models.Period) (*models.Period, error) {
 	return func(ctx context.Context, username, periodID string, period *models.Period) (*models.Period, error) {
+		if period.Version == 0 {
+			current, err := pm.GetPeriod(ctx, username, periodID)
+			if errors.Is(err, models.ErrPeriodNotFound) {
+				return nil, models.ErrUpdatePeriodNotFound
+			}
+
+			if err != nil {
+				return nil, err
+			}
+
+			period.Version = current.Version
+		}
+
 		err := pm.UpdatePeriod(ctx, period)
 		if err != nil {
 			return nil, err
@@ -134,6 +149,57 @@ func NewPeriodUpdater(pm PeriodManager) func(ctx context.Context, username, peri
 	}
 }
 
+// NewPeriodPatcher applies a JSON merge patch to a period and validates the result with the rules of its creation.
+func NewPeriodPatcher(pm PeriodManager) func(ctx context.Context, username, periodID string, version int64, patch []byte) (*models.Period, error) {
+	return func(ctx context.Context, username, periodID string, version int64, patch []byte) (*models.Period, error) {
+		current, err := pm.GetPeriod(ctx, username, periodID)
+		if errors.Is(err, models.ErrPeriodNotFound) {
+			return nil, models.ErrUpdatePeriodNotFound
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		err = checkVersion(version, current.Version)
+		if err != nil {
+			return nil, err
+		}
+
+		period, err := applyMergePatch(current, patch)
+		if err != nil {
+			return nil, err
+		}
+
+		period.ID = current.ID
+		period.Username = current.Username
+		period.CreatedDate = current.CreatedDate
+		period.UpdatedDate = time.Now()
+		period.Version = current.Version
+
+		err = validate.Period(period)
+		if err != nil {
+			return nil, err
+		}
+
+		if period.StartDate.After(period.EndDate) {
+			return nil, models.ErrStartDateShouldBeBeforeEndDate
+		}
+
+		err = pm.UpdatePeriod(ctx, period)
+		if err != nil {
+			return nil, err
+		}
+
+		patchedPeriod, err := pm.GetPeriod(ctx, username, periodID)
+		if err != nil {
+			return nil, fmt.Errorf("get patched period failed: %w", err)
+		}
+
+		return patchedPeriod, nil
+	}
+}
+
 func NewPeriodGetter(pm PeriodManager) func(ctx context.Context, username, periodID string) (*models.Period, error) {
 	return func(ctx context.Context, username, periodID string) (*models.Period, error) {
 		return pm.GetPeriod(ctx, username, periodID)
@@ -146,22 +212,58 @@ func NewPeriodsGetter(pm PeriodManager) func(ctx context.Context, username, star
 	}
 }
 
-func NewPeriodDeleter(pm PeriodManager, cache IncomePeriodCacheManager) func(ctx context.Context, periodID, username string) error {
-	return func(ctx context.Context, periodID, username string) error {
-		err := cache.DeleteIncomePeriods(ctx, username, periodID)
+// NewPeriodDeleter moves the period to the trash. The expenses, income and savings of the period are kept. If version
+// isn't 0, the period is only deleted if it's still at that version. Either way, it's only deleted if it didn't change
+// after it was read.
+func NewPeriodDeleter(pm PeriodManager, tm TrashManager, cache IncomePeriodCacheManager) func(ctx context.Context, periodID, username string, version int64) error {
+	return func(ctx context.Context, periodID, username string, version int64) error {
+		period, err := pm.GetPeriod(ctx, username, periodID)
+		if err != nil {
+			return err
+		}
+
+		err = checkVersion(version, period.Version)
+		if err != nil {
+			return err
+		}
+
+		err = cache.DeleteIncomePeriods(ctx, username, periodID)
 		if err != nil {
 			return fmt.Errorf("couldn't delete income periods from cache: %w", err)
 		}
 
-		return pm.DeletePeriod(ctx, periodID, username)
+		return moveToTrash(ctx, tm, username, models.NewPeriodTrashItem(period), func() error {
+			return pm.DeletePeriod(ctx, periodID, username, period.Version)
+		})
 	}
 }
 
-func NewPeriodStatsGetter(em ExpenseManager, im IncomeRepository) func(ctx context.Context, username, periodID string) (*models.PeriodStat, error) {
+// NewPeriodStatsGetter returns the total income and the expenses by category of a period, converted into the base
+// currency of the user with the exchange rates of the period. Categories with a budget also include how their spending
+// compares to it; nearLimitPercentage is the percentage of the budget from which a category is considered near the
+// limit.
+func NewPeriodStatsGetter(em ExpenseManager, im IncomeRepository, um UserManager, erm ExchangeRateManager, pm PeriodManager, nearLimitPercentage int) func(ctx context.Context, username, periodID string) (*models.PeriodStat, error) {
 	return func(ctx context.Context, username, periodID string) (*models.PeriodStat, error) {
+		user, err := um.GetUser(ctx, username)
+		if err != nil {
+			return nil, err
+		}
+
+		baseCurrency := user.GetBaseCurrency()
+
+		budgets, err := getPeriodCategoryBudgets(ctx, pm, user, periodID)
+		if err != nil {
+			return nil, err
+		}
+
+		converter, err := getPeriodConverter(ctx, erm, user, periodID)
+		if err != nil {
+			return nil, err
+		}
+
 		wg := sync.WaitGroup{}
 		errChan := make(chan error, 2)
-		totalIncome := 0.0
+		var totalIncome models.Money
 		categoryExpenseSummary := make([]*models.CategoryExpenseSummary, 0)
 
 		wg.Add(1)
@@ -175,9 +277,17 @@ func NewPeriodStatsGetter(em ExpenseManager, im IncomeRepository) func(ctx conte
 			}
 
 			for _, inc := range income {
-				if inc.Amount != nil {
-					totalIncome += *inc.Amount
+				if inc.Amount == nil {
+					continue
+				}
+
+				amount, err := converter.toBase(*inc.Amount, inc.Currency)
+				if err != nil {
+					errChan <- fmt.Errorf("couldn't convert income: %w", err)
+					return
 				}
+
+				totalIncome += amount
 			}
 		}()
 
@@ -191,18 +301,26 @@ func NewPeriodStatsGetter(em ExpenseManager, im IncomeRepository) func(ctx conte
 				return
 			}
 
-			categoryExpenses := make(map[string]float64)
+			categoryExpenses := make(map[string]models.Money)
 
 			for _, expense := range expenses {
-				if expense.CategoryID != nil && expense.Amount != nil {
-					categoryExpenses[*expense.CategoryID] += *expense.Amount
+				if expense.CategoryID == nil || expense.Amount == nil {
+					continue
+				}
+
+				amount, err := converter.toBase(*expense.Amount, expense.Currency)
+				if err != nil {
+					errChan <- fmt.Errorf("couldn't convert expense: %w", err)
+					return
 				}
+
+				categoryExpenses[*expense.CategoryID] += amount
 			}
 
 			for category, amount := range categoryExpenses {
 				categoryExpenseSummary = append(categoryExpenseSummary, &models.CategoryExpenseSummary{
 					CategoryID: category,
-					Total:      math.Round(amount*100) / 100,
+					Total:      amount,
 				})
 			}
 		}()
@@ -224,8 +342,9 @@ func NewPeriodStatsGetter(em ExpenseManager, im IncomeRepository) func(ctx conte
 
 		return &models.PeriodStat{
 			PeriodID:               periodID,
+			Currency:               baseCurrency,
 			TotalIncome:            totalIncome,
-			CategoryExpenseSummary: categoryExpenseSummary,
+			CategoryExpenseSummary: setBudgetStatus(categoryExpenseSummary, budgets, "", nearLimitPercentage),
 		}, nil
 	}
 }
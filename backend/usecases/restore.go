@@ -0,0 +1,465 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"github.com/JoelD7/money/backend/models"
+	"strings"
+)
+
+const (
+	restoredPeriodPrefix     = "PRD"
+	restoredSavingGoalPrefix = "SVG"
+	restoredSavingPrefix     = "SV"
+)
+
+// idMapping holds the new IDs of the restored items whose original ID was already in use.
+type idMapping map[string]string
+
+// get returns the ID an item must be referenced by after the restore.
+func (m idMapping) get(id string) string {
+	if newID, ok := m[id]; ok {
+		return newID
+	}
+
+	return id
+}
+
+func (m idMapping) getPtr(id *string) *string {
+	if id == nil {
+		return nil
+	}
+
+	newID := m.get(*id)
+
+	return &newID
+}
+
+// idSet is a set of the IDs in use for a kind of entity.
+type idSet map[string]struct{}
+
+func (s idSet) has(id string) bool {
+	_, ok := s[id]
+	return ok
+}
+
+func (s idSet) add(id string) {
+	s[id] = struct{}{}
+}
+
+// assignID returns the ID a restored item should have: its own ID if it's free, or a new one otherwise. The returned
+// ID is reserved in the set. mapping can be nil for entities that aren't referenced by others.
+func (s idSet) assignID(id, prefix string, mapping idMapping, summary *models.RestoreSummary) string {
+	summary.Restored++
+
+	if id != "" && !s.has(id) {
+		s.add(id)
+		return id
+	}
+
+	newID := generateDynamoID(prefix)
+	for s.has(newID) {
+		newID = generateDynamoID(prefix)
+	}
+
+	s.add(newID)
+
+	if id != "" && mapping != nil {
+		mapping[id] = newID
+	}
+
+	summary.Remapped++
+
+	return newID
+}
+
+// NewUserDataRestorer recreates the data of an Export document in the account of the user. IDs are preserved when
+// possible. When an ID is already in use, the item gets a new one and every reference to it is updated. Categories and
+// periods that already exist with the same name are reused instead of being duplicated, and recurring expenses whose
+// name is taken are skipped, as their ID is derived from the name.
+//
+// The operation is idempotent for the same idempotencyKey.
+func NewUserDataRestorer(um UserManager, pm PeriodManager, em ExpenseManager, im IncomeRepository, sm SavingsManager,
+	sgm SavingGoalManager, erm ExpenseRecurringManager, incomeCache IncomePeriodCacheManager, resourceCache ResourceCacheManager,
+) func(ctx context.Context, username, idempotencyKey string, export *models.Export) (*models.RestoreResult, error) {
+	return func(ctx context.Context, username, idempotencyKey string, export *models.Export) (*models.RestoreResult, error) {
+		if export.Version < 1 || export.Version > models.ExportVersion {
+			return nil, fmt.Errorf("%w: %d", models.ErrUnsupportedExportVersion, export.Version)
+		}
+
+		return CreateResource(ctx, resourceCache, idempotencyKey, func() (*models.RestoreResult, error) {
+			r := &restorer{
+				username: username,
+				result:   new(models.RestoreResult),
+			}
+
+			steps := []func() error{
+				func() error { return r.restoreCategories(ctx, um, export.User) },
+				func() error { return r.restorePeriods(ctx, pm, export.Periods) },
+				func() error { return r.restoreSavingGoals(ctx, sgm, export.SavingGoals) },
+				func() error { return r.restoreExpenses(ctx, em, export.Expenses) },
+				func() error { return r.restoreIncome(ctx, im, incomeCache, export.Income) },
+				func() error { return r.restoreSavings(ctx, sm, export.Savings) },
+				func() error { return r.restoreExpensesRecurring(ctx, erm, export.ExpensesRecurring) },
+			}
+
+			for _, step := range steps {
+				err := step()
+				if err != nil {
+					return nil, err
+				}
+			}
+
+			return r.result, nil
+		})
+	}
+}
+
+// restorer holds the state shared by the steps of a restore. The steps must run in order, as the later ones depend on
+// the ID mappings of the former.
+type restorer struct {
+	username          string
+	result            *models.RestoreResult
+	categoryMapping   idMapping
+	periodMapping     idMapping
+	savingGoalMapping idMapping
+}
+
+func (r *restorer) restoreCategories(ctx context.Context, um UserManager, exportedUser *models.User) error {
+	r.categoryMapping = make(idMapping)
+
+	if exportedUser == nil || len(exportedUser.Categories) == 0 {
+		return nil
+	}
+
+	user, err := um.GetUser(ctx, r.username)
+	if err != nil {
+		return err
+	}
+
+	ids := make(idSet)
+	idsByName := make(map[string]string)
+
+	for _, category := range user.Categories {
+		ids.add(category.ID)
+
+		if category.Name != nil {
+			idsByName[strings.ToLower(*category.Name)] = category.ID
+		}
+	}
+
+	summary := &r.result.Categories
+
+	for _, category := range exportedUser.Categories {
+		if category.Name != nil {
+			if existingID, ok := idsByName[strings.ToLower(*category.Name)]; ok {
+				if existingID != category.ID {
+					r.categoryMapping[category.ID] = existingID
+				}
+
+				summary.Skipped++
+				continue
+			}
+		}
+
+		restored := *category
+		restored.ID = ids.assignID(category.ID, categoryPrefix, r.categoryMapping, summary)
+
+		user.Categories = append(user.Categories, &restored)
+	}
+
+	if summary.Restored == 0 {
+		return nil
+	}
+
+	err = um.UpdateUser(ctx, user)
+	if err != nil {
+		return fmt.Errorf("restore categories failed: %w", err)
+	}
+
+	return nil
+}
+
+func (r *restorer) restorePeriods(ctx context.Context, pm PeriodManager, exportedPeriods []*models.Period) error {
+	r.periodMapping = make(idMapping)
+
+	if len(exportedPeriods) == 0 {
+		return nil
+	}
+
+	existingPeriods, err := getAllItems(models.ErrPeriodsNotFound, func(startKey string) ([]*models.Period, string, error) {
+		return pm.GetPeriods(ctx, r.username, startKey, exportPageSize, false)
+	})
+	if err != nil {
+		return err
+	}
+
+	ids := make(idSet)
+	idsByName := make(map[string]string)
+
+	for _, period := range existingPeriods {
+		ids.add(period.ID)
+		idsByName[strings.ToLower(period.GetName())] = period.ID
+	}
+
+	summary := &r.result.Periods
+	periods := make([]*models.Period, 0, len(exportedPeriods))
+
+	for _, period := range exportedPeriods {
+		if existingID, ok := idsByName[strings.ToLower(period.GetName())]; ok && period.GetName() != "" {
+			if existingID != period.ID {
+				r.periodMapping[period.ID] = existingID
+			}
+
+			summary.Skipped++
+			continue
+		}
+
+		restored := *period
+		restored.Username = r.username
+		restored.ID = ids.assignID(period.ID, restoredPeriodPrefix, r.periodMapping, summary)
+
+		periods = append(periods, &restored)
+	}
+
+	if len(periods) == 0 {
+		return nil
+	}
+
+	err = pm.BatchCreatePeriods(ctx, periods)
+	if err != nil {
+		return fmt.Errorf("restore periods failed: %w", err)
+	}
+
+	return nil
+}
+
+func (r *restorer) restoreSavingGoals(ctx context.Context, sgm SavingGoalManager, exportedSavingGoals []*models.SavingGoal) error {
+	r.savingGoalMapping = make(idMapping)
+
+	if len(exportedSavingGoals) == 0 {
+		return nil
+	}
+
+	existingSavingGoals, err := getAllItems(models.ErrSavingGoalsNotFound, func(startKey string) ([]*models.SavingGoal, string, error) {
+		return sgm.GetSavingGoals(ctx, r.username, &models.QueryParameters{StartKey: startKey, PageSize: exportPageSize})
+	})
+	if err != nil {
+		return err
+	}
+
+	ids := make(idSet)
+	for _, savingGoal := range existingSavingGoals {
+		ids.add(savingGoal.SavingGoalID)
+	}
+
+	summary := &r.result.SavingGoals
+	savingGoals := make([]*models.SavingGoal, 0, len(exportedSavingGoals))
+
+	for _, savingGoal := range exportedSavingGoals {
+		restored := *savingGoal
+		restored.Username = r.username
+		restored.SavingGoalID = ids.assignID(savingGoal.SavingGoalID, restoredSavingGoalPrefix, r.savingGoalMapping, summary)
+
+		savingGoals = append(savingGoals, &restored)
+	}
+
+	err = sgm.BatchCreateSavingGoals(ctx, savingGoals)
+	if err != nil {
+		return fmt.Errorf("restore saving goals failed: %w", err)
+	}
+
+	return nil
+}
+
+func (r *restorer) restoreExpenses(ctx context.Context, em ExpenseManager, exportedExpenses []*models.Expense) error {
+	if len(exportedExpenses) == 0 {
+		return nil
+	}
+
+	existingExpenses, err := getAllItems(models.ErrExpensesNotFound, func(startKey string) ([]*models.Expense, string, error) {
+		return em.GetExpenses(ctx, r.username, &models.QueryParameters{StartKey: startKey, PageSize: exportPageSize})
+	})
+	if err != nil {
+		return err
+	}
+
+	ids := make(idSet)
+	for _, expense := range existingExpenses {
+		ids.add(expense.ExpenseID)
+	}
+
+	summary := &r.result.Expenses
+	expenses := make([]*models.Expense, 0, len(exportedExpenses))
+
+	for _, expense := range exportedExpenses {
+		restored := *expense
+		restored.Username = r.username
+		restored.ExpenseID = ids.assignID(expense.ExpenseID, "EX", nil, summary)
+		restored.CategoryID = r.categoryMapping.getPtr(expense.CategoryID)
+		restored.PeriodID = r.periodMapping.get(expense.PeriodID)
+		restored.PeriodUser = nil
+
+		expenses = append(expenses, &restored)
+	}
+
+	err = em.BatchCreateExpenses(ctx, expenses)
+	if err != nil {
+		return fmt.Errorf("restore expenses failed: %w", err)
+	}
+
+	return nil
+}
+
+func (r *restorer) restoreIncome(ctx context.Context, im IncomeRepository, cache IncomePeriodCacheManager, exportedIncome []*models.Income) error {
+	if len(exportedIncome) == 0 {
+		return nil
+	}
+
+	existingIncome, err := getAllItems(models.ErrIncomeNotFound, func(startKey string) ([]*models.Income, string, error) {
+		return im.GetAllIncome(ctx, r.username, &models.QueryParameters{StartKey: startKey, PageSize: exportPageSize})
+	})
+	if err != nil {
+		return err
+	}
+
+	ids := make(idSet)
+	for _, i := range existingIncome {
+		ids.add(i.IncomeID)
+	}
+
+	summary := &r.result.Income
+	income := make([]*models.Income, 0, len(exportedIncome))
+	incomePeriods := make(map[string]struct{})
+
+	for _, i := range exportedIncome {
+		// Income is always stored with a period.
+		if i.PeriodID == nil {
+			summary.Skipped++
+			continue
+		}
+
+		restored := *i
+		restored.Username = r.username
+		restored.IncomeID = ids.assignID(i.IncomeID, "IN", nil, summary)
+		restored.PeriodID = r.periodMapping.getPtr(i.PeriodID)
+		restored.PeriodUser = nil
+
+		income = append(income, &restored)
+		incomePeriods[*restored.PeriodID] = struct{}{}
+	}
+
+	if len(income) == 0 {
+		return nil
+	}
+
+	err = im.BatchCreateIncome(ctx, income)
+	if err != nil {
+		return fmt.Errorf("restore income failed: %w", err)
+	}
+
+	for period := range incomePeriods {
+		syncIncomePeriodsCache(ctx, r.username, im, cache, "", period)
+	}
+
+	return nil
+}
+
+func (r *restorer) restoreSavings(ctx context.Context, sm SavingsManager, exportedSavings []*models.Saving) error {
+	if len(exportedSavings) == 0 {
+		return nil
+	}
+
+	existingSavings, err := getAllItems(models.ErrSavingsNotFound, func(startKey string) ([]*models.Saving, string, error) {
+		return sm.GetSavings(ctx, r.username, &models.QueryParameters{StartKey: startKey, PageSize: exportPageSize})
+	})
+	if err != nil {
+		return err
+	}
+
+	ids := make(idSet)
+	for _, saving := range existingSavings {
+		ids.add(saving.SavingID)
+	}
+
+	summary := &r.result.Savings
+	savings := make([]*models.Saving, 0, len(exportedSavings))
+
+	for _, saving := range exportedSavings {
+		// Savings are always stored with a period.
+		if saving.PeriodID == nil {
+			summary.Skipped++
+			continue
+		}
+
+		restored := *saving
+		restored.Username = r.username
+		restored.SavingID = ids.assignID(saving.SavingID, restoredSavingPrefix, nil, summary)
+		restored.PeriodID = r.periodMapping.getPtr(saving.PeriodID)
+		restored.SavingGoalID = r.savingGoalMapping.getPtr(saving.SavingGoalID)
+		restored.PeriodUser = nil
+
+		savings = append(savings, &restored)
+	}
+
+	if len(savings) == 0 {
+		return nil
+	}
+
+	err = sm.BatchCreateSavings(ctx, savings)
+	if err != nil {
+		return fmt.Errorf("restore savings failed: %w", err)
+	}
+
+	return nil
+}
+
+func (r *restorer) restoreExpensesRecurring(ctx context.Context, erm ExpenseRecurringManager, exportedExpensesRecurring []*models.ExpenseRecurring) error {
+	if len(exportedExpensesRecurring) == 0 {
+		return nil
+	}
+
+	existingExpensesRecurring, err := getAllItems(models.ErrRecurringExpensesNotFound, func(startKey string) ([]*models.ExpenseRecurring, string, error) {
+		return erm.GetExpensesRecurring(ctx, r.username, &models.QueryParameters{StartKey: startKey, PageSize: exportPageSize})
+	})
+	if err != nil {
+		return err
+	}
+
+	ids := make(idSet)
+	for _, expenseRecurring := range existingExpensesRecurring {
+		ids.add(expenseRecurring.ID)
+	}
+
+	summary := &r.result.ExpensesRecurring
+	expensesRecurring := make([]*models.ExpenseRecurring, 0, len(exportedExpensesRecurring))
+
+	for _, expenseRecurring := range exportedExpensesRecurring {
+		id := strings.ToLower(expenseRecurring.Name)
+		if id == "" || ids.has(id) {
+			summary.Skipped++
+			continue
+		}
+
+		ids.add(id)
+		summary.Restored++
+
+		restored := *expenseRecurring
+		restored.ID = id
+		restored.Username = r.username
+		restored.CategoryID = r.categoryMapping.getPtr(expenseRecurring.CategoryID)
+
+		expensesRecurring = append(expensesRecurring, &restored)
+	}
+
+	if len(expensesRecurring) == 0 {
+		return nil
+	}
+
+	err = erm.BatchCreateExpenseRecurring(ctx, expensesRecurring)
+	if err != nil {
+		return fmt.Errorf("restore recurring expenses failed: %w", err)
+	}
+
+	return nil
+}
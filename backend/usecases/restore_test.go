@@ -0,0 +1,40 @@
+package usecases
+
+import (
+	"github.com/JoelD7/money/backend/models"
+	"github.com/stretchr/testify/require"
+	"strings"
+	"testing"
+)
+
+func TestAssignID(t *testing.T) {
+	c := require.New(t)
+
+	ids := idSet{"PRD1": {}}
+	mapping := make(idMapping)
+	summary := new(models.RestoreSummary)
+
+	t.Run("Free ID is kept", func(t *testing.T) {
+		c.Equal("PRD2", ids.assignID("PRD2", "PRD", mapping, summary))
+		c.Empty(mapping)
+		c.True(ids.has("PRD2"))
+	})
+
+	t.Run("Taken ID is remapped", func(t *testing.T) {
+		newID := ids.assignID("PRD1", "PRD", mapping, summary)
+
+		c.NotEqual("PRD1", newID)
+		c.True(strings.HasPrefix(newID, "PRD"))
+		c.Equal(newID, mapping.get("PRD1"))
+
+		oldID := "PRD1"
+		c.Equal(newID, *mapping.getPtr(&oldID))
+	})
+
+	t.Run("Unmapped IDs are returned as is", func(t *testing.T) {
+		c.Equal("PRD2", mapping.get("PRD2"))
+		c.Nil(mapping.getPtr(nil))
+	})
+
+	c.Equal(models.RestoreSummary{Restored: 2, Remapped: 1}, *summary)
+}
@@ -5,39 +5,56 @@ import (
 	"errors"
 	"github.com/JoelD7/money/backend/models"
 	"github.com/JoelD7/money/backend/shared/logger"
+	"github.com/JoelD7/money/backend/shared/validate"
 	"sync"
+	"time"
 )
 
-func NewSavingGoalCreator(savingGoalManager SavingGoalManager, cache ResourceCacheManager) func(ctx context.Context, username, idempotencyKey string, savingGoal *models.SavingGoal) (*models.SavingGoal, error) {
+func NewSavingGoalCreator(savingGoalManager SavingGoalManager, um UserManager, cache ResourceCacheManager) func(ctx context.Context, username, idempotencyKey string, savingGoal *models.SavingGoal) (*models.SavingGoal, error) {
 	return func(ctx context.Context, username, idempotencyKey string, savingGoal *models.SavingGoal) (*models.SavingGoal, error) {
 		savingGoal.Username = username
 
 		return CreateResource(ctx, cache, idempotencyKey, func() (*models.SavingGoal, error) {
+			err := setDefaultCurrency(ctx, um, username, savingGoal)
+			if err != nil {
+				return nil, err
+			}
+
 			return savingGoalManager.CreateSavingGoal(ctx, savingGoal)
 		})
 	}
 }
 
-func NewSavingGoalGetter(savingGoalManager SavingGoalManager, savingManager SavingsManager) func(ctx context.Context, username, savingGoalID string) (*models.SavingGoal, error) {
+func NewSavingGoalGetter(savingGoalManager SavingGoalManager, savingManager SavingsManager, um UserManager, erm ExchangeRateManager) func(ctx context.Context, username, savingGoalID string) (*models.SavingGoal, error) {
 	return func(ctx context.Context, username, savingGoalID string) (*models.SavingGoal, error) {
 		savingGoal, err := savingGoalManager.GetSavingGoal(ctx, username, savingGoalID)
 		if err != nil {
 			return nil, err
 		}
 
-		calculateProgressByGoal(ctx, savingGoal, savingManager)
+		user, err := um.GetUser(ctx, username)
+		if err != nil {
+			return nil, err
+		}
+
+		calculateProgressByGoal(ctx, savingGoal, savingManager, erm, user)
 
 		return savingGoal, nil
 	}
 }
 
-func NewSavingGoalsGetter(savingGoalManager SavingGoalManager, savingManager SavingsManager) func(ctx context.Context, username string, params *models.QueryParameters) ([]*models.SavingGoal, string, error) {
+func NewSavingGoalsGetter(savingGoalManager SavingGoalManager, savingManager SavingsManager, um UserManager, erm ExchangeRateManager) func(ctx context.Context, username string, params *models.QueryParameters) ([]*models.SavingGoal, string, error) {
 	return func(ctx context.Context, username string, params *models.QueryParameters) ([]*models.SavingGoal, string, error) {
 		savingGoals, nextKey, err := savingGoalManager.GetSavingGoals(ctx, username, params)
 		if err != nil {
 			return nil, "", err
 		}
 
+		user, err := um.GetUser(ctx, username)
+		if err != nil {
+			return nil, "", err
+		}
+
 		var wg sync.WaitGroup
 
 		for _, goal := range savingGoals {
@@ -46,7 +63,7 @@ func NewSavingGoalsGetter(savingGoalManager SavingGoalManager, savingManager Sav
 				defer func() {
 					wg.Done()
 				}()
-				calculateProgressByGoal(ctx, savingGoal, savingManager)
+				calculateProgressByGoal(ctx, savingGoal, savingManager, erm, user)
 			}(goal)
 		}
 
@@ -56,7 +73,9 @@ func NewSavingGoalsGetter(savingGoalManager SavingGoalManager, savingManager Sav
 	}
 }
 
-func calculateProgressByGoal(ctx context.Context, savingGoal *models.SavingGoal, savingManager SavingsManager) {
+// calculateProgressByGoal sets the sum of the savings of the goal as its progress. Savings are converted into the
+// currency of the goal with the exchange rates of the period each saving belongs to.
+func calculateProgressByGoal(ctx context.Context, savingGoal *models.SavingGoal, savingManager SavingsManager, erm ExchangeRateManager, user *models.User) {
 	params := &models.QueryParameters{
 		PageSize:     10,
 		SavingGoalID: savingGoal.SavingGoalID,
@@ -65,7 +84,7 @@ func calculateProgressByGoal(ctx context.Context, savingGoal *models.SavingGoal,
 	goalSavings := make([]*models.Saving, 0)
 
 	for {
-		savings, nextKey, err := savingManager.GetSavingsBySavingGoal(ctx, params)
+		savings, nextKey, err := savingManager.GetSavingsBySavingGoal(ctx, savingGoal.Username, params)
 		if errors.Is(err, models.ErrSavingsNotFound) {
 			logger.Info("saving_goal_has_no_savings", models.Any("saving_goal", savingGoal))
 			savingGoal.SetProgress(0)
@@ -93,24 +112,150 @@ func calculateProgressByGoal(ctx context.Context, savingGoal *models.SavingGoal,
 		}
 	}
 
-	progress := 0.0
-	for _, saving := range goalSavings {
-		progress += saving.GetAmount()
+	progress, err := sumSavingsInCurrency(ctx, erm, user, savingGoal.GetCurrency(), goalSavings)
+	if err != nil {
+		logger.Error("calculate_saving_progress_by_goal_failed", err, models.Any("saving_goal", savingGoal))
+		savingGoal.SetProgress(0)
+		return
 	}
 
 	savingGoal.SetProgress(progress)
 }
 
+// sumSavingsInCurrency returns the sum of the savings expressed in the given currency. An empty currency means the legacy
+// currency of the user. Savings that are already in the given currency are added as they are, so only the converted ones are subject
+// to rounding.
+func sumSavingsInCurrency(ctx context.Context, erm ExchangeRateManager, user *models.User, currency string, savings []*models.Saving) (models.Money, error) {
+	convertersByPeriod := make(map[string]*currencyConverter)
+	targetCurrency := defaultToLegacy(currency, user.GetLegacyCurrency())
+
+	var total models.Money
+
+	for _, saving := range savings {
+		if defaultToLegacy(saving.GetCurrency(), user.GetLegacyCurrency()) == targetCurrency {
+			total += saving.GetAmount()
+			continue
+		}
+
+		periodID := saving.GetPeriodID()
+
+		converter, ok := convertersByPeriod[periodID]
+		if !ok {
+			var err error
+
+			converter, err = getPeriodConverter(ctx, erm, user, periodID)
+			if err != nil {
+				return 0, err
+			}
+
+			convertersByPeriod[periodID] = converter
+		}
+
+		amount, err := converter.toBase(saving.GetAmount(), saving.GetCurrency())
+		if err != nil {
+			return 0, err
+		}
+
+		amount, err = converter.fromBase(amount, currency)
+		if err != nil {
+			return 0, err
+		}
+
+		total += amount
+	}
+
+	return total, nil
+}
+
+// NewSavingGoalUpdator replaces the saving goal. It fails with models.ErrVersionConflict if the saving goal was changed
+// since the version of the given one; a saving goal without a version replaces the current one.
 func NewSavingGoalUpdator(savingGoalManager SavingGoalManager) func(ctx context.Context, username, savingGoalID string, savingGoal *models.SavingGoal) (*models.SavingGoal, error) {
 	return func(ctx context.Context, username, savingGoalID string, savingGoal *models.SavingGoal) (*models.SavingGoal, error) {
 		savingGoal.Username = username
 		savingGoal.SavingGoalID = savingGoalID
+
+		if savingGoal.Version == 0 {
+			current, err := savingGoalManager.GetSavingGoal(ctx, username, savingGoalID)
+			if err != nil {
+				return nil, err
+			}
+
+			savingGoal.Version = current.Version
+		}
+
 		return savingGoalManager.UpdateSavingGoal(ctx, savingGoal)
 	}
 }
 
-func NewSavingGoalEliminator(savingGoalManager SavingGoalManager) func(ctx context.Context, username, savingGoalID string) error {
-	return func(ctx context.Context, username, savingGoalID string) error {
-		return savingGoalManager.DeleteSavingGoal(ctx, username, savingGoalID)
+// NewSavingGoalPatcher applies a JSON merge patch to a saving goal and validates the result with the rules of its
+// creation. The deadline is only required to be in the future when the patch changes it.
+func NewSavingGoalPatcher(savingGoalManager SavingGoalManager, um UserManager) func(ctx context.Context, username, savingGoalID string, version int64, patch []byte) (*models.SavingGoal, error) {
+	return func(ctx context.Context, username, savingGoalID string, version int64, patch []byte) (*models.SavingGoal, error) {
+		current, err := savingGoalManager.GetSavingGoal(ctx, username, savingGoalID)
+		if err != nil {
+			return nil, err
+		}
+
+		err = checkVersion(version, current.Version)
+		if err != nil {
+			return nil, err
+		}
+
+		savingGoal, err := applyMergePatch(current, patch)
+		if err != nil {
+			return nil, err
+		}
+
+		savingGoal.SavingGoalID = current.SavingGoalID
+		savingGoal.Username = current.Username
+		savingGoal.Progress = nil
+		savingGoal.Version = current.Version
+
+		err = setDefaultCurrency(ctx, um, username, savingGoal)
+		if err != nil {
+			return nil, err
+		}
+
+		err = validate.SavingGoal(savingGoal)
+		if err != nil {
+			return nil, err
+		}
+
+		if !deadlineEqual(savingGoal.Deadline, current.Deadline) {
+			err = validate.SavingGoalDeadline(savingGoal.Deadline)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		return savingGoalManager.UpdateSavingGoal(ctx, savingGoal)
+	}
+}
+
+func deadlineEqual(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+
+	return a.Equal(*b)
+}
+
+// NewSavingGoalEliminator moves the saving goal to the trash. If version isn't 0, the saving goal is only deleted if
+// it's still at that version. Either way, it's only deleted if it didn't change after it was read.
+func NewSavingGoalEliminator(savingGoalManager SavingGoalManager, tm TrashManager) func(ctx context.Context, username, savingGoalID string, version int64) error {
+	return func(ctx context.Context, username, savingGoalID string, version int64) error {
+		savingGoal, err := savingGoalManager.GetSavingGoal(ctx, username, savingGoalID)
+		if err != nil {
+			return err
+		}
+
+		err = checkVersion(version, savingGoal.Version)
+		if err != nil {
+			return err
+		}
+
+		return moveToTrash(ctx, tm, username, models.NewSavingGoalTrashItem(savingGoal), func() error {
+			return savingGoalManager.DeleteSavingGoal(ctx, username, savingGoalID, savingGoal.Version)
+		})
 	}
 }
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"github.com/JoelD7/money/backend/models"
 	"github.com/JoelD7/money/backend/shared/logger"
+	"github.com/JoelD7/money/backend/shared/validate"
 	"math"
 	"time"
 )
@@ -101,8 +102,8 @@ func NewSavingByPeriodGetter(sm SavingsManager, sgm SavingGoalManager, pm Period
 	}
 }
 
-func NewSavingBySavingGoalGetter(sm SavingsManager, sgm SavingGoalManager, pm PeriodManager) func(ctx context.Context, params *models.QueryParameters) ([]*models.Saving, string, error) {
-	return func(ctx context.Context, params *models.QueryParameters) ([]*models.Saving, string, error) {
+func NewSavingBySavingGoalGetter(sm SavingsManager, sgm SavingGoalManager, pm PeriodManager) func(ctx context.Context, username string, params *models.QueryParameters) ([]*models.Saving, string, error) {
+	return func(ctx context.Context, username string, params *models.QueryParameters) ([]*models.Saving, string, error) {
 		if err := validatePageSize(params.PageSize); err != nil {
 			logger.Error("invalid_page_size_detected", err, models.Any("user_data", map[string]interface{}{
 				"i_page_size": params.PageSize,
@@ -111,12 +112,12 @@ func NewSavingBySavingGoalGetter(sm SavingsManager, sgm SavingGoalManager, pm Pe
 			return nil, "", err
 		}
 
-		savings, nextKey, err := sm.GetSavingsBySavingGoal(ctx, params)
+		savings, nextKey, err := sm.GetSavingsBySavingGoal(ctx, username, params)
 		if err != nil {
 			return nil, "", fmt.Errorf("savings fetch failed: %w", err)
 		}
 
-		err = setSavingGoalNamesForSavingGoal(ctx, sgm, savings[0].Username, params.SavingGoalID, savings)
+		err = setSavingGoalNamesForSavingGoal(ctx, sgm, username, params.SavingGoalID, savings)
 		if err != nil {
 			return savings, "", fmt.Errorf("%w: %v", models.ErrSavingGoalNameSettingFailed, err)
 		}
@@ -135,8 +136,8 @@ func NewSavingBySavingGoalGetter(sm SavingsManager, sgm SavingGoalManager, pm Pe
 	}
 }
 
-func NewSavingBySavingGoalAndPeriodGetter(sm SavingsManager, sgm SavingGoalManager, pm PeriodManager) func(ctx context.Context, params *models.QueryParameters) ([]*models.Saving, string, error) {
-	return func(ctx context.Context, params *models.QueryParameters) ([]*models.Saving, string, error) {
+func NewSavingBySavingGoalAndPeriodGetter(sm SavingsManager, sgm SavingGoalManager, pm PeriodManager) func(ctx context.Context, username string, params *models.QueryParameters) ([]*models.Saving, string, error) {
+	return func(ctx context.Context, username string, params *models.QueryParameters) ([]*models.Saving, string, error) {
 		if err := validatePageSize(params.PageSize); err != nil {
 			logger.Error("invalid_page_size_detected", err, models.Any("user_data", map[string]interface{}{
 				"i_page_size": params.PageSize,
@@ -145,12 +146,12 @@ func NewSavingBySavingGoalAndPeriodGetter(sm SavingsManager, sgm SavingGoalManag
 			return nil, "", err
 		}
 
-		savings, nextKey, err := sm.GetSavingsBySavingGoalAndPeriod(ctx, params)
+		savings, nextKey, err := sm.GetSavingsBySavingGoalAndPeriod(ctx, username, params)
 		if err != nil {
 			return nil, "", fmt.Errorf("savings fetch failed: %w", err)
 		}
 
-		err = setSavingGoalNames(ctx, sgm, savings[0].Username, savings)
+		err = setSavingGoalNames(ctx, sgm, username, savings)
 		if err != nil {
 			return savings, "", fmt.Errorf("%w: %v", models.ErrSavingGoalNameSettingFailed, err)
 		}
@@ -169,7 +170,7 @@ func NewSavingBySavingGoalAndPeriodGetter(sm SavingsManager, sgm SavingGoalManag
 	}
 }
 
-func NewSavingCreator(sm SavingsManager, p PeriodManager, cache ResourceCacheManager) func(ctx context.Context, username, idempotencyKey string, saving *models.Saving) (*models.Saving, error) {
+func NewSavingCreator(sm SavingsManager, p PeriodManager, um UserManager, cache ResourceCacheManager) func(ctx context.Context, username, idempotencyKey string, saving *models.Saving) (*models.Saving, error) {
 	return func(ctx context.Context, username, idempotencyKey string, saving *models.Saving) (*models.Saving, error) {
 		createdSaving, err := CreateResource(ctx, cache, idempotencyKey, func() (*models.Saving, error) {
 			err := validateSavingPeriod(ctx, saving, username, p)
@@ -179,6 +180,11 @@ func NewSavingCreator(sm SavingsManager, p PeriodManager, cache ResourceCacheMan
 
 			saving.Username = username
 
+			err = setDefaultCurrency(ctx, um, username, saving)
+			if err != nil {
+				return nil, err
+			}
+
 			newSaving, err := sm.CreateSaving(ctx, saving)
 			if err != nil {
 				return nil, fmt.Errorf("saving creation failed: %w", err)
@@ -195,8 +201,23 @@ func NewSavingCreator(sm SavingsManager, p PeriodManager, cache ResourceCacheMan
 	}
 }
 
+// NewSavingUpdater updates the saving. It fails with models.ErrVersionConflict if the saving was changed since the
+// version of the given one; a saving without a version is applied to the current one.
 func NewSavingUpdater(sm SavingsManager, pm PeriodManager, sgm SavingGoalManager) func(ctx context.Context, username string, saving *models.Saving) (*models.Saving, error) {
 	return func(ctx context.Context, username string, saving *models.Saving) (*models.Saving, error) {
+		if saving.Version == 0 {
+			current, err := sm.GetSaving(ctx, username, saving.SavingID)
+			if errors.Is(err, models.ErrSavingNotFound) {
+				return nil, models.ErrUpdateSavingNotFound
+			}
+
+			if err != nil {
+				return nil, err
+			}
+
+			saving.Version = current.Version
+		}
+
 		err := validateSavingPeriod(ctx, saving, username, pm)
 		if err != nil {
 			return nil, err
@@ -223,6 +244,71 @@ func NewSavingUpdater(sm SavingsManager, pm PeriodManager, sgm SavingGoalManager
 	}
 }
 
+// NewSavingPatcher applies a JSON merge patch to a saving and validates the result with the rules of its creation.
+func NewSavingPatcher(sm SavingsManager, pm PeriodManager, sgm SavingGoalManager, um UserManager) func(ctx context.Context, username, savingID string, version int64, patch []byte) (*models.Saving, error) {
+	return func(ctx context.Context, username, savingID string, version int64, patch []byte) (*models.Saving, error) {
+		current, err := sm.GetSaving(ctx, username, savingID)
+		if errors.Is(err, models.ErrSavingNotFound) {
+			return nil, models.ErrUpdateSavingNotFound
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		err = checkVersion(version, current.Version)
+		if err != nil {
+			return nil, err
+		}
+
+		saving, err := applyMergePatch(current, patch)
+		if err != nil {
+			return nil, err
+		}
+
+		saving.SavingID = current.SavingID
+		saving.Username = current.Username
+		saving.CreatedDate = current.CreatedDate
+		saving.SavingGoalName = ""
+		saving.PeriodName = ""
+		saving.PeriodUser = nil
+		saving.UpdatedDate = time.Now()
+		saving.Version = current.Version
+
+		err = setDefaultCurrency(ctx, um, username, saving)
+		if err != nil {
+			return nil, err
+		}
+
+		err = validate.Saving(saving)
+		if err != nil {
+			return nil, err
+		}
+
+		err = validateSavingPeriod(ctx, saving, username, pm)
+		if err != nil {
+			return nil, err
+		}
+
+		err = sm.ReplaceSaving(ctx, saving)
+		if err != nil {
+			return nil, err
+		}
+
+		patchedSaving, err := sm.GetSaving(ctx, username, savingID)
+		if err != nil {
+			return nil, fmt.Errorf("getting patched saving failed: %w", err)
+		}
+
+		err = setSavingGoalName(ctx, sgm, patchedSaving)
+		if err != nil {
+			return patchedSaving, fmt.Errorf("%w: %v", models.ErrSavingGoalNameSettingFailed, err)
+		}
+
+		return patchedSaving, nil
+	}
+}
+
 func validatePageSize(pageSize int) error {
 	if pageSize < 0 || pageSize > math.MaxInt32 {
 		return models.ErrInvalidPageSize
@@ -231,18 +317,31 @@ func validatePageSize(pageSize int) error {
 	return nil
 }
 
-func NewSavingDeleter(sm SavingsManager) func(ctx context.Context, savingID, username string) error {
-	return func(ctx context.Context, savingID, username string) error {
+// NewSavingDeleter moves the saving to the trash. If version isn't 0, the saving is only deleted if it's still at that
+// version. Either way, it's only deleted if it didn't change after it was read.
+func NewSavingDeleter(sm SavingsManager, tm TrashManager) func(ctx context.Context, savingID, username string, version int64) error {
+	return func(ctx context.Context, savingID, username string, version int64) error {
 		if savingID == "" {
 			return models.ErrMissingSavingID
 		}
 
-		err := sm.DeleteSaving(ctx, savingID, username)
+		saving, err := sm.GetSaving(ctx, username, savingID)
+		if errors.Is(err, models.ErrSavingNotFound) {
+			return models.ErrDeleteSavingNotFound
+		}
+
 		if err != nil {
 			return err
 		}
 
-		return nil
+		err = checkVersion(version, saving.Version)
+		if err != nil {
+			return err
+		}
+
+		return moveToTrash(ctx, tm, username, models.NewSavingTrashItem(saving), func() error {
+			return sm.DeleteSaving(ctx, savingID, username, saving.Version)
+		})
 	}
 }
 
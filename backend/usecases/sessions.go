@@ -0,0 +1,256 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/JoelD7/money/backend/models"
+	"github.com/JoelD7/money/backend/shared/env"
+	"github.com/JoelD7/money/backend/shared/hash"
+	"github.com/JoelD7/money/backend/shared/logger"
+	"github.com/gbrlsnchs/jwt/v3"
+	"time"
+)
+
+// NewTokenRefresher exchanges a refresh token for new access and refresh tokens of the same session. Every refresh
+// token can be exchanged only once: if one that was already replaced is received, either the user or whoever stole the
+// token is using an old copy, and as there's no telling which, the session is revoked for both.
+func NewTokenRefresher(um UserManager, sm SessionManager, secretManager SecretManager, tokenCache InvalidTokenCache) func(ctx context.Context, refreshToken string) (*models.AuthToken, *models.AuthToken, error) {
+	return func(ctx context.Context, refreshToken string) (*models.AuthToken, *models.AuthToken, error) {
+		payload, err := verifyRefreshToken(ctx, secretManager, refreshToken)
+		if err != nil {
+			logger.Warning("refresh_token_validation_failed", err, nil)
+
+			return nil, nil, err
+		}
+
+		session, err := sm.GetSession(ctx, payload.Subject, payload.SessionID)
+		if errors.Is(err, models.ErrSessionNotFound) {
+			return nil, nil, fmt.Errorf("%w: %v", models.ErrInvalidToken, err)
+		}
+
+		if err != nil {
+			return nil, nil, err
+		}
+
+		err = hash.CompareWithToken(session.RefreshToken, refreshToken)
+		if errors.Is(err, hash.ErrHashMismatch) {
+			return nil, nil, revokeReusedSession(ctx, sm, tokenCache, session.Username, session.ID)
+		}
+
+		if err != nil {
+			return nil, nil, err
+		}
+
+		user, err := um.GetUser(ctx, session.Username)
+		if errors.Is(err, models.ErrUserNotFound) {
+			return nil, nil, fmt.Errorf("%w: %v", models.ErrInvalidToken, err)
+		}
+
+		if err != nil {
+			return nil, nil, err
+		}
+
+		generateTokens := NewUserTokenGenerator(sm, secretManager)
+
+		accessToken, newRefreshToken, err := generateTokens(ctx, user, session)
+		if errors.Is(err, models.ErrRefreshTokenReused) {
+			// Another request exchanged the same token in the meantime.
+			return nil, nil, revokeReusedSession(ctx, sm, tokenCache, session.Username, session.ID)
+		}
+
+		if err != nil {
+			return nil, nil, err
+		}
+
+		logger.Info("session_tokens_rotated", session)
+
+		return accessToken, newRefreshToken, nil
+	}
+}
+
+// NewSessionsGetter returns the active sessions of the user, the most recently used first.
+func NewSessionsGetter(sm SessionManager) func(ctx context.Context, username string) ([]*models.Session, error) {
+	return func(ctx context.Context, username string) ([]*models.Session, error) {
+		sessions, err := sm.GetSessions(ctx, username)
+		if err != nil {
+			return nil, err
+		}
+
+		// Expired sessions may not have been removed from the storage yet.
+		now := time.Now()
+		activeSessions := make([]*models.Session, 0, len(sessions))
+
+		for _, session := range sessions {
+			if session.ExpirationDate.After(now) {
+				activeSessions = append(activeSessions, session)
+			}
+		}
+
+		if len(activeSessions) == 0 {
+			return nil, models.ErrSessionsNotFound
+		}
+
+		return activeSessions, nil
+	}
+}
+
+// NewSessionRevoker logs the user out of a session. Its last access token is invalidated too, so that the session can't
+// be used until that token expires.
+func NewSessionRevoker(sm SessionManager, tokenCache InvalidTokenCache) func(ctx context.Context, username, sessionID string) error {
+	return func(ctx context.Context, username, sessionID string) error {
+		session, err := sm.GetSession(ctx, username, sessionID)
+		if err != nil {
+			return err
+		}
+
+		err = invalidateSessionTokens(ctx, tokenCache, session)
+		if err != nil {
+			return err
+		}
+
+		err = sm.DeleteSession(ctx, username, sessionID)
+		if err != nil {
+			logger.Error("session_deletion_failed", err, session)
+
+			return err
+		}
+
+		logger.Info("session_revoked", session)
+
+		return nil
+	}
+}
+
+// NewAllSessionsRevoker logs the user out of every session.
+func NewAllSessionsRevoker(sm SessionManager, tokenCache InvalidTokenCache) func(ctx context.Context, username string) error {
+	return func(ctx context.Context, username string) error {
+		sessions, err := sm.GetSessions(ctx, username)
+		if errors.Is(err, models.ErrSessionsNotFound) {
+			return nil
+		}
+
+		if err != nil {
+			return err
+		}
+
+		for _, session := range sessions {
+			err = invalidateSessionTokens(ctx, tokenCache, session)
+			if err != nil {
+				return err
+			}
+		}
+
+		deleted, err := sm.DeleteAllSessions(ctx, username)
+		if err != nil {
+			logger.Error("sessions_deletion_failed", err, models.Any("sessions", map[string]interface{}{
+				"s_username": username,
+			}))
+
+			return err
+		}
+
+		logger.Info("all_sessions_revoked", models.Any("sessions", map[string]interface{}{
+			"s_username": username,
+			"i_deleted":  deleted,
+		}))
+
+		return nil
+	}
+}
+
+// NewUserLogout revokes the session of the refresh token. Without a refresh token, there's no way to tell which session
+// is being logged out of, so all of them are revoked.
+func NewUserLogout(sm SessionManager, secretManager SecretManager, tokenCache InvalidTokenCache) func(ctx context.Context, username, refreshToken string) error {
+	return func(ctx context.Context, username, refreshToken string) error {
+		if refreshToken == "" {
+			revokeSessions := NewAllSessionsRevoker(sm, tokenCache)
+
+			return revokeSessions(ctx, username)
+		}
+
+		payload, err := verifyRefreshToken(ctx, secretManager, refreshToken)
+		if err == nil && payload.Subject != username {
+			err = fmt.Errorf("%w: the token belongs to another user", models.ErrInvalidToken)
+		}
+
+		if err != nil {
+			// An expired or foreign refresh token doesn't give access to any session, so there's nothing to revoke.
+			logger.Warning("logout_refresh_token_validation_failed", err, nil)
+
+			return nil
+		}
+
+		revokeSession := NewSessionRevoker(sm, tokenCache)
+
+		err = revokeSession(ctx, username, payload.SessionID)
+		if errors.Is(err, models.ErrSessionNotFound) {
+			return nil
+		}
+
+		return err
+	}
+}
+
+// verifyRefreshToken checks the signature and expiration of a refresh token, and that it belongs to a session.
+func verifyRefreshToken(ctx context.Context, secrets SecretManager, refreshToken string) (*models.JWTPayload, error) {
+	publicKey, err := getPublicKey(ctx, secrets)
+	if err != nil {
+		return nil, fmt.Errorf("public key fetching failed: %w", err)
+	}
+
+	payload := &models.JWTPayload{Payload: new(jwt.Payload)}
+
+	validatePayload := jwt.ValidatePayload(payload.Payload, jwt.ExpirationTimeValidator(time.Now()))
+
+	_, err = jwt.Verify([]byte(refreshToken), jwt.NewRS256(jwt.RSAPublicKey(publicKey)), payload, validatePayload)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", models.ErrInvalidToken, err)
+	}
+
+	// Access tokens are signed with the same key, but don't belong to a session.
+	if payload.SessionID == "" || payload.Subject == "" {
+		return nil, fmt.Errorf("%w: the token doesn't belong to a session", models.ErrInvalidToken)
+	}
+
+	return payload, nil
+}
+
+func revokeReusedSession(ctx context.Context, sm SessionManager, tokenCache InvalidTokenCache, username, sessionID string) error {
+	logger.Warning("refresh_token_reused", models.ErrRefreshTokenReused, models.Any("session", map[string]interface{}{
+		"s_id":       sessionID,
+		"s_username": username,
+	}))
+
+	// The stored session is revoked, not the one in memory, as it holds the tokens issued to the other request.
+	revokeSession := NewSessionRevoker(sm, tokenCache)
+
+	err := revokeSession(ctx, username, sessionID)
+	if err != nil && !errors.Is(err, models.ErrSessionNotFound) {
+		return err
+	}
+
+	return models.ErrRefreshTokenReused
+}
+
+// invalidateSessionTokens adds the last tokens of the session to the invalid tokens, until they expire.
+func invalidateSessionTokens(ctx context.Context, tokenCache InvalidTokenCache, session *models.Session) error {
+	accessTokenDuration := env.GetInt("ACCESS_TOKEN_DURATION", 300)
+	accessTokenTTL := time.Now().Add(time.Second * time.Duration(accessTokenDuration)).Unix()
+
+	err := tokenCache.AddInvalidToken(ctx, session.Username, session.AccessToken, accessTokenTTL)
+	if err != nil {
+		logger.Error("access_token_invalidation_failed", err, session)
+
+		return err
+	}
+
+	err = tokenCache.AddInvalidToken(ctx, session.Username, session.RefreshToken, session.ExpirationDate.Unix())
+	if err != nil {
+		logger.Error("refresh_token_invalidation_failed", err, session)
+
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,130 @@
+package usecases
+
+import (
+	"context"
+	"github.com/JoelD7/money/backend/models"
+	"github.com/JoelD7/money/backend/shared/logger"
+	"github.com/JoelD7/money/backend/storage/cache"
+	"github.com/JoelD7/money/backend/storage/sessions"
+	"github.com/JoelD7/money/backend/storage/users"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestTokenRefresher(t *testing.T) {
+	c := require.New(t)
+
+	logger.InitLogger(logger.ConsoleImplementation)
+
+	ctx := context.Background()
+	userRepo := users.NewMemoryRepository()
+	sessionRepo := sessions.NewMemoryRepository()
+	tokenCache := cache.NewMemoryCache()
+	secretMock := newTokenSecretsMock(t, c)
+	user := newPasswordTestUser(c, userRepo, "password")
+
+	generateTokens := NewUserTokenGenerator(sessionRepo, secretMock)
+	refreshTokens := NewTokenRefresher(userRepo, sessionRepo, secretMock, tokenCache)
+
+	session := &models.Session{UserAgent: "laptop"}
+
+	accessToken, refreshToken, err := generateTokens(ctx, user, session)
+	c.NoError(err)
+	c.NotEmpty(session.ID)
+
+	_, otherRefreshToken, err := generateTokens(ctx, user, &models.Session{UserAgent: "phone"})
+	c.NoError(err)
+
+	userSessions, err := NewSessionsGetter(sessionRepo)(ctx, user.Username)
+	c.NoError(err)
+	c.Len(userSessions, 2)
+
+	t.Run("Access token used as refresh token", func(t *testing.T) {
+		_, _, err = refreshTokens(ctx, accessToken.Value)
+		c.ErrorIs(err, models.ErrInvalidToken)
+	})
+
+	_, rotatedRefreshToken, err := refreshTokens(ctx, refreshToken.Value)
+	c.NoError(err)
+	c.NotEqual(refreshToken.Value, rotatedRefreshToken.Value)
+
+	_, rotatedRefreshToken, err = refreshTokens(ctx, rotatedRefreshToken.Value)
+	c.NoError(err)
+
+	// The first refresh token was replaced, so only a copy of it can be used again.
+	_, _, err = refreshTokens(ctx, refreshToken.Value)
+	c.ErrorIs(err, models.ErrRefreshTokenReused)
+
+	_, err = sessionRepo.GetSession(ctx, user.Username, session.ID)
+	c.ErrorIs(err, models.ErrSessionNotFound)
+
+	_, _, err = refreshTokens(ctx, rotatedRefreshToken.Value)
+	c.ErrorIs(err, models.ErrInvalidToken)
+
+	invalidTokens, err := tokenCache.GetInvalidTokens(ctx, user.Username)
+	c.NoError(err)
+	c.Len(invalidTokens, 2)
+
+	// The session of the other device isn't affected.
+	_, _, err = refreshTokens(ctx, otherRefreshToken.Value)
+	c.NoError(err)
+}
+
+func TestSessionRevokers(t *testing.T) {
+	c := require.New(t)
+
+	logger.InitLogger(logger.ConsoleImplementation)
+
+	ctx := context.Background()
+	userRepo := users.NewMemoryRepository()
+	sessionRepo := sessions.NewMemoryRepository()
+	tokenCache := cache.NewMemoryCache()
+	secretMock := newTokenSecretsMock(t, c)
+	user := newPasswordTestUser(c, userRepo, "password")
+
+	generateTokens := NewUserTokenGenerator(sessionRepo, secretMock)
+	refreshTokens := NewTokenRefresher(userRepo, sessionRepo, secretMock, tokenCache)
+	logout := NewUserLogout(sessionRepo, secretMock, tokenCache)
+
+	newSession := func() (*models.Session, *models.AuthToken) {
+		session := new(models.Session)
+
+		_, refreshToken, err := generateTokens(ctx, user, session)
+		c.NoError(err)
+
+		return session, refreshToken
+	}
+
+	revokedSession, revokedRefreshToken := newSession()
+	loggedOutSession, loggedOutRefreshToken := newSession()
+	_, refreshToken := newSession()
+
+	c.NoError(NewSessionRevoker(sessionRepo, tokenCache)(ctx, user.Username, revokedSession.ID))
+
+	_, _, err := refreshTokens(ctx, revokedRefreshToken.Value)
+	c.ErrorIs(err, models.ErrInvalidToken)
+
+	err = NewSessionRevoker(sessionRepo, tokenCache)(ctx, user.Username, revokedSession.ID)
+	c.ErrorIs(err, models.ErrSessionNotFound)
+
+	c.NoError(logout(ctx, user.Username, loggedOutRefreshToken.Value))
+
+	_, err = sessionRepo.GetSession(ctx, user.Username, loggedOutSession.ID)
+	c.ErrorIs(err, models.ErrSessionNotFound)
+
+	userSessions, err := NewSessionsGetter(sessionRepo)(ctx, user.Username)
+	c.NoError(err)
+	c.Len(userSessions, 1)
+
+	c.NoError(NewAllSessionsRevoker(sessionRepo, tokenCache)(ctx, user.Username))
+
+	_, _, err = refreshTokens(ctx, refreshToken.Value)
+	c.ErrorIs(err, models.ErrInvalidToken)
+
+	_, err = NewSessionsGetter(sessionRepo)(ctx, user.Username)
+	c.ErrorIs(err, models.ErrSessionsNotFound)
+
+	invalidTokens, err := tokenCache.GetInvalidTokens(ctx, user.Username)
+	c.NoError(err)
+	c.Len(invalidTokens, 6)
+}
@@ -0,0 +1,272 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/JoelD7/money/backend/models"
+	"github.com/JoelD7/money/backend/shared/logger"
+	"strings"
+)
+
+// moveToTrash saves the trash item of a record of the user and then deletes the record with deleteRecord. If the
+// record can't be deleted, the trash item is removed, so that the record isn't in both places.
+func moveToTrash(ctx context.Context, tm TrashManager, username string, item *models.TrashItem, deleteRecord func() error) error {
+	item.Username = username
+
+	err := tm.CreateTrashItem(ctx, item)
+	if err != nil {
+		return fmt.Errorf("move to trash failed: %w", err)
+	}
+
+	err = deleteRecord()
+	if err == nil {
+		return nil
+	}
+
+	deleteErr := tm.DeleteTrashItem(ctx, username, item.ItemID)
+	if deleteErr != nil {
+		logger.Error("delete_trash_item_failed", deleteErr, models.Any("trash_item", map[string]interface{}{
+			"s_username": username,
+			"s_item_id":  item.ItemID,
+		}))
+	}
+
+	return err
+}
+
+func NewTrashItemsGetter(tm TrashManager) func(ctx context.Context, username string, params *models.QueryParameters) ([]*models.TrashItem, string, error) {
+	return func(ctx context.Context, username string, params *models.QueryParameters) ([]*models.TrashItem, string, error) {
+		return tm.GetTrashItems(ctx, username, params)
+	}
+}
+
+// NewTrashItemPurger deletes an item of the trash permanently.
+func NewTrashItemPurger(tm TrashManager) func(ctx context.Context, username, itemID string) error {
+	return func(ctx context.Context, username, itemID string) error {
+		if itemID == "" {
+			return models.ErrMissingTrashItemID
+		}
+
+		_, err := tm.GetTrashItem(ctx, username, itemID)
+		if err != nil {
+			return err
+		}
+
+		return tm.DeleteTrashItem(ctx, username, itemID)
+	}
+}
+
+// NewTrashItemRestorer recreates the record of a trash item with its original ID and removes it from the trash. The
+// record is validated again, as the period, category or saving goal it references may have been deleted after it.
+func NewTrashItemRestorer(tm TrashManager, um UserManager, pm PeriodManager, em ExpenseManager, im IncomeRepository,
+	sm SavingsManager, sgm SavingGoalManager, cache IncomePeriodCacheManager,
+) func(ctx context.Context, username, itemID string) (*models.TrashItem, error) {
+	return func(ctx context.Context, username, itemID string) (*models.TrashItem, error) {
+		if itemID == "" {
+			return nil, models.ErrMissingTrashItemID
+		}
+
+		item, err := tm.GetTrashItem(ctx, username, itemID)
+		if err != nil {
+			return nil, err
+		}
+
+		switch item.Type {
+		case models.TrashItemTypeExpense:
+			err = restoreExpense(ctx, um, pm, em, username, item.Expense)
+		case models.TrashItemTypeIncome:
+			err = restoreIncome(ctx, pm, im, cache, username, item.Income)
+		case models.TrashItemTypeSaving:
+			err = restoreSaving(ctx, pm, sm, sgm, username, item.Saving)
+		case models.TrashItemTypeSavingGoal:
+			err = restoreSavingGoal(ctx, sgm, username, item.SavingGoal)
+		case models.TrashItemTypePeriod:
+			err = restorePeriod(ctx, pm, im, cache, username, item.Period)
+		default:
+			err = fmt.Errorf("%w: %s", models.ErrInvalidTrashItemType, item.Type)
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		// The record is restored already, so failing to remove the item from the trash shouldn't fail the request. The
+		// item would be found again in the trash, but restoring it a second time fails, as the record exists.
+		err = tm.DeleteTrashItem(ctx, username, itemID)
+		if err != nil {
+			logger.Error("delete_restored_trash_item_failed", err, models.Any("trash_item", map[string]interface{}{
+				"s_username": username,
+				"s_item_id":  itemID,
+			}))
+		}
+
+		return item, nil
+	}
+}
+
+func restoreExpense(ctx context.Context, um UserManager, pm PeriodManager, em ExpenseManager, username string, expense *models.Expense) error {
+	expense.Username = username
+
+	_, err := em.GetExpense(ctx, username, expense.ExpenseID)
+	if err == nil {
+		return models.ErrTrashItemAlreadyExists
+	}
+
+	if !errors.Is(err, models.ErrExpenseNotFound) {
+		return err
+	}
+
+	err = validateTrashItemPeriod(ctx, pm, username, expense.PeriodID)
+	if err != nil {
+		return err
+	}
+
+	if expense.CategoryID != nil && *expense.CategoryID != "" {
+		user, err := um.GetUser(ctx, username)
+		if err != nil {
+			return err
+		}
+
+		if !userHasCategory(user, *expense.CategoryID) {
+			return models.ErrTrashItemCategoryNotFound
+		}
+	}
+
+	return em.BatchCreateExpenses(ctx, []*models.Expense{expense})
+}
+
+func restoreIncome(ctx context.Context, pm PeriodManager, im IncomeRepository, cache IncomePeriodCacheManager, username string, income *models.Income) error {
+	income.Username = username
+
+	err := validateTrashItemPeriod(ctx, pm, username, income.GetPeriodID())
+	if err != nil {
+		return err
+	}
+
+	_, err = im.CreateIncome(ctx, income)
+	if errors.Is(err, models.ErrExistingIncome) {
+		return models.ErrTrashItemAlreadyExists
+	}
+
+	if err != nil {
+		return err
+	}
+
+	syncIncomePeriodsCache(ctx, username, im, cache, "", income.GetPeriodID())
+
+	return nil
+}
+
+func restoreSaving(ctx context.Context, pm PeriodManager, sm SavingsManager, sgm SavingGoalManager, username string, saving *models.Saving) error {
+	saving.Username = username
+
+	_, err := sm.GetSaving(ctx, username, saving.SavingID)
+	if err == nil {
+		return models.ErrTrashItemAlreadyExists
+	}
+
+	if !errors.Is(err, models.ErrSavingNotFound) {
+		return err
+	}
+
+	if saving.PeriodID != nil {
+		err = validateTrashItemPeriod(ctx, pm, username, *saving.PeriodID)
+		if err != nil {
+			return err
+		}
+	}
+
+	if saving.SavingGoalID != nil && *saving.SavingGoalID != "" {
+		_, err = sgm.GetSavingGoal(ctx, username, *saving.SavingGoalID)
+		if errors.Is(err, models.ErrSavingGoalNotFound) {
+			return models.ErrTrashItemSavingGoalNotFound
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return sm.BatchCreateSavings(ctx, []*models.Saving{saving})
+}
+
+func restoreSavingGoal(ctx context.Context, sgm SavingGoalManager, username string, savingGoal *models.SavingGoal) error {
+	savingGoal.Username = username
+
+	_, err := sgm.GetSavingGoal(ctx, username, savingGoal.SavingGoalID)
+	if err == nil {
+		return models.ErrTrashItemAlreadyExists
+	}
+
+	if !errors.Is(err, models.ErrSavingGoalNotFound) {
+		return err
+	}
+
+	return sgm.BatchCreateSavingGoals(ctx, []*models.SavingGoal{savingGoal})
+}
+
+// restorePeriod recreates a period, unless another period took its ID or its name after it was deleted.
+func restorePeriod(ctx context.Context, pm PeriodManager, im IncomeRepository, cache IncomePeriodCacheManager, username string, period *models.Period) error {
+	period.Username = username
+
+	periods, err := getAllItems(models.ErrPeriodsNotFound, func(startKey string) ([]*models.Period, string, error) {
+		return pm.GetPeriods(ctx, username, startKey, exportPageSize, false)
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, p := range periods {
+		if p.ID == period.ID {
+			return models.ErrTrashItemAlreadyExists
+		}
+
+		if period.GetName() != "" && strings.EqualFold(p.GetName(), period.GetName()) {
+			return models.ErrPeriodNameIsTaken
+		}
+	}
+
+	err = pm.BatchCreatePeriods(ctx, []*models.Period{period})
+	if err != nil {
+		return err
+	}
+
+	// The income of the period wasn't deleted with it, so the period must be in the cache again if it has income.
+	hasIncome, err := periodHasIncome(ctx, username, period.ID, im)
+	if err != nil {
+		logger.Error("check_period_income_failed", err, models.Any("user_data", map[string]interface{}{
+			"s_username": username,
+			"s_period":   period.ID,
+		}))
+	}
+
+	if hasIncome {
+		syncIncomePeriodsCache(ctx, username, im, cache, "", period.ID)
+	}
+
+	return nil
+}
+
+func validateTrashItemPeriod(ctx context.Context, pm PeriodManager, username, periodID string) error {
+	if periodID == "" {
+		return nil
+	}
+
+	_, err := pm.GetPeriod(ctx, username, periodID)
+	if errors.Is(err, models.ErrPeriodNotFound) {
+		return models.ErrTrashItemPeriodNotFound
+	}
+
+	return err
+}
+
+func userHasCategory(user *models.User, categoryID string) bool {
+	for _, category := range user.Categories {
+		if category.ID == categoryID {
+			return true
+		}
+	}
+
+	return false
+}
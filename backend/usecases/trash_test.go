@@ -0,0 +1,132 @@
+package usecases
+
+import (
+	"context"
+	"github.com/JoelD7/money/backend/models"
+	"github.com/JoelD7/money/backend/shared/logger"
+	"github.com/JoelD7/money/backend/storage/cache"
+	"github.com/JoelD7/money/backend/storage/expenses"
+	"github.com/JoelD7/money/backend/storage/income"
+	"github.com/JoelD7/money/backend/storage/period"
+	"github.com/JoelD7/money/backend/storage/savingoal"
+	"github.com/JoelD7/money/backend/storage/savings"
+	"github.com/JoelD7/money/backend/storage/trash"
+	"github.com/JoelD7/money/backend/storage/users"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestTrash(t *testing.T) {
+	c := require.New(t)
+
+	logger.InitLogger(logger.ConsoleImplementation)
+
+	ctx := context.Background()
+	username := "test@gmail.com"
+
+	userRepo := users.NewMemoryRepository()
+	periodRepo := period.NewMemoryRepository()
+	expensesRepo := expenses.NewMemoryRepository(nil)
+	incomeRepo := income.NewMemoryRepository()
+	trashRepo := trash.NewMock()
+	incomeCache := cache.NewMemoryCache()
+
+	_, err := userRepo.CreateUser(ctx, &models.User{Username: username, Categories: []*models.Category{{ID: "CTG1"}}})
+	c.NoError(err)
+
+	c.NoError(periodRepo.BatchCreatePeriods(ctx, []*models.Period{{Username: username, ID: "PRD1"}}))
+
+	categoryID := "CTG1"
+	c.NoError(expensesRepo.BatchCreateExpenses(ctx, []*models.Expense{
+		{Username: username, ExpenseID: "EX1", PeriodID: "PRD1", CategoryID: &categoryID},
+		{Username: username, ExpenseID: "EX2", PeriodID: "PRD1"},
+	}))
+
+	deleteExpense := NewExpensesDeleter(expensesRepo, trashRepo)
+	restoreTrashItem := NewTrashItemRestorer(trashRepo, userRepo, periodRepo, expensesRepo, incomeRepo,
+		savings.NewMemoryRepository(), savingoal.NewMemoryRepository(), incomeCache)
+
+	// The expense isn't deleted if it changed since the version of the request.
+	c.ErrorIs(deleteExpense(ctx, "EX1", username, 2), models.ErrVersionConflict)
+	c.NoError(deleteExpense(ctx, "EX1", username, 1))
+
+	_, err = expensesRepo.GetExpense(ctx, username, "EX1")
+	c.ErrorIs(err, models.ErrExpenseNotFound)
+
+	items, _, err := NewTrashItemsGetter(trashRepo)(ctx, username, &models.QueryParameters{})
+	c.NoError(err)
+	c.Len(items, 1)
+	c.Equal(models.TrashItemTypeExpense, items[0].Type)
+
+	item, err := restoreTrashItem(ctx, username, "EX1")
+	c.NoError(err)
+	c.Equal("EX1", item.ItemID)
+
+	expense, err := expensesRepo.GetExpense(ctx, username, "EX1")
+	c.NoError(err)
+	c.Equal("CTG1", *expense.CategoryID)
+
+	_, err = trashRepo.GetTrashItem(ctx, username, "EX1")
+	c.ErrorIs(err, models.ErrTrashItemNotFound)
+
+	t.Run("Period of the item was deleted", func(t *testing.T) {
+		c.NoError(deleteExpense(ctx, "EX2", username, 0))
+		c.NoError(NewPeriodDeleter(periodRepo, trashRepo, incomeCache)(ctx, "PRD1", username, 0))
+
+		_, err = restoreTrashItem(ctx, username, "EX2")
+		c.ErrorIs(err, models.ErrTrashItemPeriodNotFound)
+
+		_, err = restoreTrashItem(ctx, username, "PRD1")
+		c.NoError(err)
+
+		_, err = restoreTrashItem(ctx, username, "EX2")
+		c.NoError(err)
+	})
+
+	t.Run("Category of the item was deleted", func(t *testing.T) {
+		c.NoError(deleteExpense(ctx, "EX1", username, 0))
+		c.NoError(userRepo.UpdateUser(ctx, &models.User{Username: username, Categories: []*models.Category{}, Version: 1}))
+
+		_, err = restoreTrashItem(ctx, username, "EX1")
+		c.ErrorIs(err, models.ErrTrashItemCategoryNotFound)
+	})
+
+	t.Run("Expense changed after it was read", func(t *testing.T) {
+		deleteChangedExpense := NewExpensesDeleter(&changingExpenseManager{ExpenseManager: expensesRepo}, trashRepo)
+
+		c.ErrorIs(deleteChangedExpense(ctx, "EX2", username, 0), models.ErrVersionConflict)
+
+		_, err = expensesRepo.GetExpense(ctx, username, "EX2")
+		c.NoError(err)
+
+		_, err = trashRepo.GetTrashItem(ctx, username, "EX2")
+		c.ErrorIs(err, models.ErrTrashItemNotFound)
+	})
+
+	t.Run("Purge", func(t *testing.T) {
+		purgeTrashItem := NewTrashItemPurger(trashRepo)
+
+		c.NoError(purgeTrashItem(ctx, username, "EX1"))
+		c.ErrorIs(purgeTrashItem(ctx, username, "EX1"), models.ErrTrashItemNotFound)
+
+		_, err = restoreTrashItem(ctx, username, "EX1")
+		c.ErrorIs(err, models.ErrTrashItemNotFound)
+	})
+}
+
+// changingExpenseManager updates the expenses right after they are read, like a concurrent request would.
+type changingExpenseManager struct {
+	ExpenseManager
+}
+
+func (m *changingExpenseManager) GetExpense(ctx context.Context, username, expenseID string) (*models.Expense, error) {
+	expense, err := m.ExpenseManager.GetExpense(ctx, username, expenseID)
+	if err != nil {
+		return nil, err
+	}
+
+	changed := *expense
+	changed.Notes = "changed"
+
+	return expense, m.ExpenseManager.UpdateExpense(ctx, &changed)
+}
@@ -5,7 +5,9 @@ import (
 	"errors"
 	"fmt"
 	"github.com/JoelD7/money/backend/models"
+	"github.com/JoelD7/money/backend/shared/validate"
 	"regexp"
+	"strings"
 	"sync"
 )
 
@@ -17,7 +19,9 @@ const (
 	categoryPrefix = "CTG"
 )
 
-func NewUserGetter(u UserManager, i IncomeRepository, e ExpenseManager) func(ctx context.Context, username string) (*models.User, error) {
+// NewUserGetter returns the user along with the remainder of its current period, converted into the base currency of
+// the user with the exchange rates of the period.
+func NewUserGetter(u UserManager, i IncomeRepository, e ExpenseManager, erm ExchangeRateManager) func(ctx context.Context, username string) (*models.User, error) {
 	return func(ctx context.Context, username string) (*models.User, error) {
 		user, err := u.GetUser(ctx, username)
 		if err != nil {
@@ -59,15 +63,30 @@ func NewUserGetter(u UserManager, i IncomeRepository, e ExpenseManager) func(ctx
 			}
 		}
 
-		totalExpense := 0.0
+		converter, err := getPeriodConverter(ctx, erm, user, user.CurrentPeriod)
+		if err != nil {
+			return user, fmt.Errorf("the remainder for the user's current period couldn't be calculated: %w", err)
+		}
+
+		var totalExpense models.Money
 
 		for _, expense := range userExpenses {
-			totalExpense += *expense.Amount
+			amount, err := converter.toBase(expense.GetAmount(), expense.Currency)
+			if err != nil {
+				return user, fmt.Errorf("the remainder for the user's current period couldn't be calculated: %w", err)
+			}
+
+			totalExpense += amount
 		}
 
-		totalIncome := 0.0
+		var totalIncome models.Money
 		for _, inc := range userIncome {
-			totalIncome += *inc.Amount
+			amount, err := converter.toBase(inc.GetAmount(), inc.Currency)
+			if err != nil {
+				return user, fmt.Errorf("the remainder for the user's current period couldn't be calculated: %w", err)
+			}
+
+			totalIncome += amount
 		}
 
 		user.Remainder = totalIncome - totalExpense
@@ -116,12 +135,6 @@ func getAllIncomeForPeriod(ctx context.Context, username string, period string,
 	return income, nil
 }
 
-func NewUserDeleter(u UserManager) func(ctx context.Context, username string) error {
-	return func(ctx context.Context, username string) error {
-		return u.DeleteUser(ctx, username)
-	}
-}
-
 func NewCategoryCreator(u UserManager, cache ResourceCacheManager) func(ctx context.Context, username, idempotencyKey string, category *models.Category) error {
 	return func(ctx context.Context, username, idempotencyKey string, category *models.Category) error {
 		user, err := u.GetUser(ctx, username)
@@ -154,40 +167,50 @@ func NewCategoryCreator(u UserManager, cache ResourceCacheManager) func(ctx cont
 	}
 }
 
-func NewCategoriesGetter(u UserManager) func(ctx context.Context, username string) ([]*models.Category, error) {
-	return func(ctx context.Context, username string) ([]*models.Category, error) {
+// NewCategoriesGetter returns the categories of the user along with the version of the user, which is the version of
+// the categories.
+func NewCategoriesGetter(u UserManager) func(ctx context.Context, username string) ([]*models.Category, int64, error) {
+	return func(ctx context.Context, username string) ([]*models.Category, int64, error) {
 		user, err := u.GetUser(ctx, username)
 		if err != nil {
-			return nil, err
+			return nil, 0, err
 		}
 
 		if len(user.Categories) == 0 {
-			return nil, models.ErrCategoriesNotFound
+			return nil, 0, models.ErrCategoriesNotFound
 		}
 
-		return user.Categories, nil
+		return user.Categories, user.Version, nil
 	}
 }
 
-func NewCategoryUpdater(u UserManager) func(ctx context.Context, username, categoryID string, newCategory *models.Category) error {
-	return func(ctx context.Context, username, categoryID string, newCategory *models.Category) error {
+// NewCategoryUpdater updates a category of the user and returns the updated user. As all the categories are stored in
+// the user, version is the version of the user that the change was based on; if it isn't 0 and the user has changed
+// since, the update fails with models.ErrVersionConflict.
+func NewCategoryUpdater(u UserManager) func(ctx context.Context, username, categoryID string, version int64, newCategory *models.Category) (*models.User, error) {
+	return func(ctx context.Context, username, categoryID string, version int64, newCategory *models.Category) (*models.User, error) {
 		user, err := u.GetUser(ctx, username)
 		if err != nil {
-			return err
+			return nil, err
+		}
+
+		err = checkVersion(version, user.Version)
+		if err != nil {
+			return nil, err
 		}
 
 		err = validateCategoryName(newCategory, user.Categories)
 		if err != nil {
-			return err
+			return nil, err
 		}
 
 		err = validateCategoryColor(newCategory.Color)
 		if err != nil {
-			return err
+			return nil, err
 		}
 
 		if user.Categories == nil {
-			return models.ErrCategoryNotFound
+			return nil, models.ErrCategoryNotFound
 		}
 
 		newCategories := make([]*models.Category, 0, len(user.Categories))
@@ -203,7 +226,7 @@ func NewCategoryUpdater(u UserManager) func(ctx context.Context, username, categ
 		}
 
 		if categoryToUpdate == nil {
-			return models.ErrCategoryNotFound
+			return nil, models.ErrCategoryNotFound
 		}
 
 		if newCategory.Name != nil {
@@ -218,11 +241,138 @@ func NewCategoryUpdater(u UserManager) func(ctx context.Context, username, categ
 			categoryToUpdate.Color = newCategory.Color
 		}
 
+		if newCategory.Keywords != nil {
+			categoryToUpdate.Keywords = newCategory.Keywords
+		}
+
 		newCategories = append(newCategories, categoryToUpdate)
 
 		user.Categories = newCategories
 
-		return u.UpdateUser(ctx, user)
+		err = u.UpdateUser(ctx, user)
+		if err != nil {
+			return nil, err
+		}
+
+		updatedUser, err := u.GetUser(ctx, username)
+		if err != nil {
+			return nil, fmt.Errorf("get updated user failed: %w", err)
+		}
+
+		return updatedUser, nil
+	}
+}
+
+// NewCategoryPatcher applies a JSON merge patch to a category of the user and validates the result with the rules of
+// its creation. As with NewCategoryUpdater, the version is the one of the user and the updated user is returned.
+func NewCategoryPatcher(u UserManager) func(ctx context.Context, username, categoryID string, version int64, patch []byte) (*models.User, error) {
+	return func(ctx context.Context, username, categoryID string, version int64, patch []byte) (*models.User, error) {
+		user, err := u.GetUser(ctx, username)
+		if err != nil {
+			return nil, err
+		}
+
+		err = checkVersion(version, user.Version)
+		if err != nil {
+			return nil, err
+		}
+
+		index := -1
+
+		for i, category := range user.Categories {
+			if category.ID == categoryID {
+				index = i
+				break
+			}
+		}
+
+		if index == -1 {
+			return nil, models.ErrCategoryNotFound
+		}
+
+		category, err := applyMergePatch(user.Categories[index], patch)
+		if err != nil {
+			return nil, err
+		}
+
+		category.ID = categoryID
+
+		err = validate.Category(category)
+		if err != nil {
+			return nil, err
+		}
+
+		err = validateCategoryColor(category.Color)
+		if err != nil {
+			return nil, err
+		}
+
+		otherCategories := make([]*models.Category, 0, len(user.Categories)-1)
+		otherCategories = append(otherCategories, user.Categories[:index]...)
+		otherCategories = append(otherCategories, user.Categories[index+1:]...)
+
+		err = validateCategoryName(category, otherCategories)
+		if err != nil {
+			return nil, err
+		}
+
+		user.Categories[index] = category
+
+		err = u.UpdateUser(ctx, user)
+		if err != nil {
+			return nil, err
+		}
+
+		patchedUser, err := u.GetUser(ctx, username)
+		if err != nil {
+			return nil, fmt.Errorf("get patched user failed: %w", err)
+		}
+
+		return patchedUser, nil
+	}
+}
+
+// NewUserPatcher applies a JSON merge patch to the profile of the user. Only the full name and the base currency can be
+// patched; the categories and the current period have their own endpoints, so the rest of the members are ignored. The
+// base currency changes the same way it does with NewBaseCurrencyUpdater.
+func NewUserPatcher(u UserManager) func(ctx context.Context, username string, version int64, patch []byte) (*models.User, error) {
+	return func(ctx context.Context, username string, version int64, patch []byte) (*models.User, error) {
+		user, err := u.GetUser(ctx, username)
+		if err != nil {
+			return nil, err
+		}
+
+		err = checkVersion(version, user.Version)
+		if err != nil {
+			return nil, err
+		}
+
+		patchedProfile, err := applyMergePatch(user, patch)
+		if err != nil {
+			return nil, err
+		}
+
+		baseCurrency := strings.ToUpper(patchedProfile.BaseCurrency)
+
+		err = validate.Currency(baseCurrency)
+		if err != nil {
+			return nil, err
+		}
+
+		user.FullName = patchedProfile.FullName
+		user.SetBaseCurrency(baseCurrency)
+
+		err = u.UpdateUser(ctx, user)
+		if err != nil {
+			return nil, err
+		}
+
+		patchedUser, err := u.GetUser(ctx, username)
+		if err != nil {
+			return nil, fmt.Errorf("get patched user failed: %w", err)
+		}
+
+		return patchedUser, nil
 	}
 }
 
@@ -2,7 +2,10 @@ package usecases
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 	"github.com/JoelD7/money/backend/models"
+	"github.com/JoelD7/money/backend/shared/mergepatch"
 )
 
 // PeriodHolder is an interface that describes entities that have a period.
@@ -56,3 +59,35 @@ func setEntitiesPeriods(ctx context.Context, pm PeriodManager, entitites ...Peri
 
 	return nil
 }
+
+// checkVersion returns models.ErrVersionConflict if the version expected by the request isn't the current one. A
+// version of 0 means that the request doesn't expect any.
+func checkVersion(expected, current int64) error {
+	if expected != 0 && expected != current {
+		return models.ErrVersionConflict
+	}
+
+	return nil
+}
+
+// applyMergePatch returns a copy of entity with the JSON merge patch applied. The entity isn't modified.
+func applyMergePatch[T any](entity *T, patch []byte) (*T, error) {
+	document, err := json.Marshal(entity)
+	if err != nil {
+		return nil, fmt.Errorf("marshalling entity to patch failed: %w", err)
+	}
+
+	patched, err := mergepatch.Apply(document, patch)
+	if err != nil {
+		return nil, fmt.Errorf("%v: %w", err, models.ErrInvalidRequestBody)
+	}
+
+	result := new(T)
+
+	err = json.Unmarshal(patched, result)
+	if err != nil {
+		return nil, fmt.Errorf("%v: %w", err, models.ErrInvalidRequestBody)
+	}
+
+	return result, nil
+}